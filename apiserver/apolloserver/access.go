@@ -0,0 +1,181 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package apolloserver
+
+import (
+	"context"
+	"net"
+	"net/http"
+
+	"github.com/emicklei/go-restful"
+	"go.uber.org/zap"
+
+	api "github.com/polarismesh/polaris-server/common/api/v1"
+	"github.com/polarismesh/polaris-server/common/utils"
+)
+
+const (
+	notificationsPath = "/notifications/v2"
+)
+
+// GetApolloAccessServer Apollo 客户端使用的配置接口
+func (h *ApolloServer) GetApolloAccessServer() *restful.WebService {
+	ws := new(restful.WebService)
+	ws.Produces(restful.MIME_JSON)
+
+	ws.Route(ws.GET("/configs/{appId}/{clusterName}/{namespace}").To(h.queryConfig))
+	ws.Route(ws.GET("/configfiles/json/{appId}/{clusterName}/{namespace}").To(h.queryConfigAsJson))
+	ws.Route(ws.GET(notificationsPath).To(h.pollNotifications))
+	return ws
+}
+
+// queryConfig 获取配置，客户端的 releaseKey 和当前发布一致时返回 304
+func (h *ApolloServer) queryConfig(req *restful.Request, rsp *restful.Response) {
+	apolloConfig, status := h.loadConfig(req)
+	if status != http.StatusOK {
+		rsp.WriteHeader(status)
+		return
+	}
+	if req.QueryParameter("releaseKey") == apolloConfig.ReleaseKey {
+		rsp.WriteHeader(http.StatusNotModified)
+		return
+	}
+	_ = rsp.WriteHeaderAndJson(http.StatusOK, apolloConfig, restful.MIME_JSON)
+}
+
+// queryConfigAsJson 只返回配置项，用于非 Java 客户端直接获取配置
+func (h *ApolloServer) queryConfigAsJson(req *restful.Request, rsp *restful.Response) {
+	apolloConfig, status := h.loadConfig(req)
+	if status != http.StatusOK {
+		rsp.WriteHeader(status)
+		return
+	}
+	_ = rsp.WriteHeaderAndJson(http.StatusOK, apolloConfig.Configurations, restful.MIME_JSON)
+}
+
+func (h *ApolloServer) loadConfig(req *restful.Request) (*ApolloConfig, int) {
+	appId := req.PathParameter("appId")
+	cluster := req.PathParameter("clusterName")
+	namespaceName := req.PathParameter("namespace")
+
+	fileRsp := h.configServer.Service().GetConfigFileForClient(clientContext(req), cluster, appId,
+		toFileName(namespaceName), 0)
+	switch fileRsp.GetCode().GetValue() {
+	case api.ExecuteSuccess:
+	case api.NotFoundResource:
+		return nil, http.StatusNotFound
+	case api.BadRequest:
+		return nil, http.StatusBadRequest
+	case api.NotAllowedAccess:
+		return nil, http.StatusUnauthorized
+	default:
+		log.Error("[Config][Apollo] get config file for client error.",
+			zap.String("appId", appId),
+			zap.String("cluster", cluster),
+			zap.String("namespace", namespaceName),
+			zap.String("info", fileRsp.GetInfo().GetValue()))
+		return nil, http.StatusInternalServerError
+	}
+
+	file := fileRsp.GetConfigFile()
+	return &ApolloConfig{
+		AppID:          appId,
+		Cluster:        cluster,
+		NamespaceName:  namespaceName,
+		Configurations: toConfigurations(namespaceName, file.GetContent().GetValue()),
+		ReleaseKey:     file.GetMd5().GetValue(),
+	}, http.StatusOK
+}
+
+// pollNotifications 长轮询等待配置发布，客户端的通知 ID 为配置发布的版本号，超时未发布时返回 304
+func (h *ApolloServer) pollNotifications(req *restful.Request, rsp *restful.Response) {
+	appId := req.QueryParameter("appId")
+	cluster := req.QueryParameter("cluster")
+	notifications, err := parseNotifications(req.QueryParameter("notifications"))
+	if err != nil || appId == "" || cluster == "" || len(notifications) == 0 {
+		_ = rsp.WriteErrorString(http.StatusBadRequest, "invalid appId, cluster or notifications")
+		return
+	}
+
+	// 配置文件名和客户端上报的 namespace 的对应关系，响应时使用客户端上报的 namespace
+	namespaceNames := make(map[string]string, len(notifications))
+	watchFiles := make([]*api.ClientConfigFileInfo, 0, len(notifications))
+	for _, notification := range notifications {
+		fileName := toFileName(notification.NamespaceName)
+		namespaceNames[fileName] = notification.NamespaceName
+
+		var version uint64
+		if notification.NotificationID > 0 {
+			version = uint64(notification.NotificationID)
+		}
+		watchFiles = append(watchFiles, &api.ClientConfigFileInfo{
+			Namespace: utils.NewStringValue(cluster),
+			Group:     utils.NewStringValue(appId),
+			FileName:  utils.NewStringValue(fileName),
+			Version:   utils.NewUInt64Value(version),
+		})
+	}
+
+	clientId := req.Request.RemoteAddr + "@" + utils.NewUUID()[0:8]
+	watcher := h.configServer.NewStreamWatcher(clientId)
+	defer watcher.Close()
+	watcher.Subscribe(clientContext(req), watchFiles)
+
+	changes := watcher.WaitChanges(req.Request.Context(), h.longPollingTimeout)
+	if len(changes) == 0 {
+		rsp.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	result := make([]*ApolloNotification, 0, len(changes))
+	for _, change := range changes {
+		namespaceName, ok := namespaceNames[change.GetFileName().GetValue()]
+		if !ok {
+			continue
+		}
+		notificationId := int64(change.GetVersion().GetValue())
+		result = append(result, &ApolloNotification{
+			NamespaceName:  namespaceName,
+			NotificationID: notificationId,
+			Messages: &ApolloNotificationMessages{
+				Details: map[string]int64{notificationKey(appId, cluster, namespaceName): notificationId},
+			},
+		})
+	}
+	_ = rsp.WriteHeaderAndJson(http.StatusOK, result, restful.MIME_JSON)
+}
+
+// clientContext 记录客户端 IP 用于匹配灰度发布规则，客户端上报的 IP 优先，以及获取加密配置的访问凭证
+func clientContext(req *restful.Request) context.Context {
+	ctx := context.WithValue(context.Background(), utils.StringContext("request-id"),
+		req.HeaderParameter("Request-Id"))
+
+	clientIP := req.QueryParameter("ip")
+	if clientIP == "" {
+		clientIP = req.Request.RemoteAddr
+		if host, _, err := net.SplitHostPort(clientIP); err == nil {
+			clientIP = host
+		}
+	}
+	ctx = context.WithValue(ctx, utils.StringContext("client-ip"), clientIP)
+
+	if token := req.HeaderParameter(utils.HeaderAuthTokenKey); token != "" {
+		ctx = context.WithValue(ctx, utils.ContextAuthTokenKey, token)
+	}
+	return ctx
+}
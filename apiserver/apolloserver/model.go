@@ -0,0 +1,94 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package apolloserver
+
+import (
+	"encoding/json"
+	"path"
+	"strings"
+
+	"github.com/polarismesh/polaris-server/common/utils"
+	utils2 "github.com/polarismesh/polaris-server/config/utils"
+)
+
+const (
+	// contentKey 非 properties 格式的配置，Apollo 把配置内容放在 content 配置项中
+	contentKey = "content"
+	// notificationKeySeparator Apollo 通知详情的 key 由 appId、cluster、namespace 拼接而成
+	notificationKeySeparator = "+"
+)
+
+// namespaceFormats Apollo namespace 后缀对应的配置格式，没有后缀的 namespace 为 properties 格式
+var namespaceFormats = map[string]string{
+	".properties": utils.FileFormatProperties,
+	".yaml":       utils.FileFormatYaml,
+	".yml":        utils.FileFormatYaml,
+	".json":       utils.FileFormatJson,
+	".xml":        utils.FileFormatXml,
+	".txt":        utils.FileFormatText,
+}
+
+// ApolloConfig Apollo 客户端获取配置的响应
+type ApolloConfig struct {
+	AppID          string            `json:"appId"`
+	Cluster        string            `json:"cluster"`
+	NamespaceName  string            `json:"namespaceName"`
+	Configurations map[string]string `json:"configurations"`
+	ReleaseKey     string            `json:"releaseKey"`
+}
+
+// ApolloNotification Apollo 客户端长轮询上报以及服务端返回的配置变更通知
+type ApolloNotification struct {
+	NamespaceName  string                      `json:"namespaceName"`
+	NotificationID int64                       `json:"notificationId"`
+	Messages       *ApolloNotificationMessages `json:"messages,omitempty"`
+}
+
+// ApolloNotificationMessages 配置变更通知的详情，key 为 appId+cluster+namespace
+type ApolloNotificationMessages struct {
+	Details map[string]int64 `json:"details"`
+}
+
+// toFileName Apollo namespace 转换为配置文件名，没有后缀的 properties 格式 namespace 补充 .properties 后缀
+func toFileName(namespaceName string) string {
+	if _, ok := namespaceFormats[strings.ToLower(path.Ext(namespaceName))]; ok {
+		return namespaceName
+	}
+	return namespaceName + ".properties"
+}
+
+// toConfigurations 把配置内容转换为 Apollo 的配置项，properties 格式按照配置项拆分，其他格式放在 content 配置项中
+func toConfigurations(namespaceName, content string) map[string]string {
+	if namespaceFormats[strings.ToLower(path.Ext(toFileName(namespaceName)))] != utils.FileFormatProperties {
+		return map[string]string{contentKey: content}
+	}
+	return utils2.ParseProperties(content)
+}
+
+// parseNotifications 解析客户端上报的通知列表，格式为 [{"namespaceName":"application","notificationId":-1}]
+func parseNotifications(raw string) ([]*ApolloNotification, error) {
+	var notifications []*ApolloNotification
+	if err := json.Unmarshal([]byte(raw), &notifications); err != nil {
+		return nil, err
+	}
+	return notifications, nil
+}
+
+func notificationKey(appId, cluster, namespaceName string) string {
+	return strings.Join([]string{appId, cluster, namespaceName}, notificationKeySeparator)
+}
@@ -0,0 +1,56 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package apolloserver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToFileName(t *testing.T) {
+	assert.Equal(t, "application.properties", toFileName("application"))
+	assert.Equal(t, "application.properties", toFileName("application.properties"))
+	assert.Equal(t, "application.yaml", toFileName("application.yaml"))
+	assert.Equal(t, "datasource.json", toFileName("datasource.json"))
+	// 公共 namespace 带有部门前缀，不是格式后缀
+	assert.Equal(t, "TEST1.apollo.properties", toFileName("TEST1.apollo"))
+}
+
+func TestToConfigurations(t *testing.T) {
+	configurations := toConfigurations("application", "# comment\nk1=v1\nk2 = v2\n")
+	assert.Equal(t, map[string]string{"k1": "v1", "k2": "v2"}, configurations)
+
+	configurations = toConfigurations("application.yaml", "k1: v1\n")
+	assert.Equal(t, map[string]string{contentKey: "k1: v1\n"}, configurations)
+}
+
+func TestParseNotifications(t *testing.T) {
+	notifications, err := parseNotifications(
+		`[{"namespaceName":"application","notificationId":-1},{"namespaceName":"app.yaml","notificationId":3}]`)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(notifications))
+	assert.Equal(t, "application", notifications[0].NamespaceName)
+	assert.Equal(t, int64(-1), notifications[0].NotificationID)
+	assert.Equal(t, int64(3), notifications[1].NotificationID)
+
+	_, err = parseNotifications("invalid")
+	assert.NotNil(t, err)
+
+	assert.Equal(t, "app+default+application", notificationKey("app", "default", "application"))
+}
@@ -0,0 +1,266 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package apolloserver
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/emicklei/go-restful"
+	"go.uber.org/zap"
+
+	"github.com/polarismesh/polaris-server/apiserver"
+	"github.com/polarismesh/polaris-server/common/connlimit"
+	"github.com/polarismesh/polaris-server/config"
+)
+
+const (
+	// DefaultLongPollingTimeout Apollo 客户端的长轮询读超时为 90s，服务端最多挂起 60s
+	DefaultLongPollingTimeout = 60
+)
+
+// ApolloServer 兼容 Apollo 客户端配置接口的 HTTP 服务器，Apollo 的 cluster 对应北极星的命名空间，
+// appId 对应配置文件组，namespace 对应配置文件
+type ApolloServer struct {
+	listenIP           string
+	listenPort         uint32
+	option             map[string]interface{}
+	openAPI            map[string]apiserver.APIConfig
+	connLimitConfig    *connlimit.Config
+	longPollingTimeout time.Duration
+	start              bool
+	restart            bool
+	exitCh             chan struct{}
+
+	server       *http.Server
+	configServer *config.Server
+}
+
+// GetPort 获取端口
+func (h *ApolloServer) GetPort() uint32 {
+	return h.listenPort
+}
+
+// GetProtocol 获取Server的协议
+func (h *ApolloServer) GetProtocol() string {
+	return "apollo"
+}
+
+// Initialize 初始化HTTP API服务器
+func (h *ApolloServer) Initialize(_ context.Context, option map[string]interface{},
+	api map[string]apiserver.APIConfig) error {
+	h.option = option
+	h.openAPI = api
+	h.listenIP = option["listenIP"].(string)
+	h.listenPort = uint32(option["listenPort"].(int))
+
+	longPollingTimeout := DefaultLongPollingTimeout
+	if value, ok := option["longPollingTimeout"]; ok {
+		longPollingTimeout = value.(int)
+	}
+	if longPollingTimeout <= 0 || longPollingTimeout > DefaultLongPollingTimeout {
+		longPollingTimeout = DefaultLongPollingTimeout
+	}
+	h.longPollingTimeout = time.Duration(longPollingTimeout) * time.Second
+
+	// 连接数限制的配置
+	if raw, _ := option["connLimit"].(map[interface{}]interface{}); raw != nil {
+		connLimitConfig, err := connlimit.ParseConnLimitConfig(raw)
+		if err != nil {
+			return err
+		}
+		h.connLimitConfig = connLimitConfig
+	}
+	return nil
+}
+
+// Run 启动HTTP API服务器
+func (h *ApolloServer) Run(errCh chan error) {
+	log.Infof("start ApolloServer")
+	h.exitCh = make(chan struct{}, 1)
+	h.start = true
+	defer func() {
+		close(h.exitCh)
+		h.start = false
+	}()
+
+	var err error
+
+	// 引入功能模块和插件
+	h.configServer, err = config.GetServer()
+	if err != nil {
+		log.Errorf("%v", err)
+		errCh <- err
+		return
+	}
+
+	// 初始化http server
+	address := fmt.Sprintf("%v:%v", h.listenIP, h.listenPort)
+
+	wsContainer := h.createRestfulContainer()
+
+	// 长轮询请求需要挂起，写超时需要大于长轮询的超时时间
+	server := http.Server{Addr: address, Handler: wsContainer, WriteTimeout: 2 * time.Minute}
+	var ln net.Listener
+	ln, err = net.Listen("tcp", address)
+	if err != nil {
+		log.Errorf("net listen(%s) err: %s", address, err.Error())
+		errCh <- err
+		return
+	}
+
+	ln = &tcpKeepAliveListener{ln.(*net.TCPListener)}
+	// 开启最大连接数限制
+	if h.connLimitConfig != nil && h.connLimitConfig.OpenConnLimit {
+		log.Infof("apollo server use max connection limit per ip: %d, http max limit: %d",
+			h.connLimitConfig.MaxConnPerHost, h.connLimitConfig.MaxConnLimit)
+		ln, err = connlimit.NewListener(ln, h.GetProtocol(), h.connLimitConfig)
+		if err != nil {
+			log.Errorf("conn limit init err: %s", err.Error())
+			errCh <- err
+			return
+		}
+	}
+	h.server = &server
+
+	// 开始对外服务
+	err = server.Serve(ln)
+	if err != nil {
+		log.Errorf("%+v", err)
+		if !h.restart {
+			log.Infof("not in restart progress, broadcast error")
+			errCh <- err
+		}
+
+		return
+	}
+
+	log.Infof("ApolloServer stop")
+}
+
+// Stop shutdown server
+func (h *ApolloServer) Stop() {
+	// 释放connLimit的数据，如果没有开启，也需要执行一下
+	// 目的：防止restart的时候，connLimit冲突
+	connlimit.RemoveLimitListener(h.GetProtocol())
+	if h.server != nil {
+		_ = h.server.Close()
+	}
+}
+
+// Restart restart server
+func (h *ApolloServer) Restart(option map[string]interface{}, api map[string]apiserver.APIConfig,
+	errCh chan error) error {
+	log.Infof("restart ApolloServer new config: %+v", option)
+	// 备份一下option
+	backupOption := h.option
+	// 备份一下api
+	backupAPI := h.openAPI
+
+	// 设置restart标记，防止stop的时候把错误抛出
+	h.restart = true
+	// 关闭ApolloServer
+	h.Stop()
+	// 等待ApolloServer退出
+	if h.start {
+		<-h.exitCh
+	}
+
+	log.Infof("old ApolloServer has stopped, begin restart ApolloServer")
+
+	ctx := context.Background()
+	if err := h.Initialize(ctx, option, api); err != nil {
+		h.restart = false
+		if initErr := h.Initialize(ctx, backupOption, backupAPI); initErr != nil {
+			log.Errorf("start ApolloServer with backup cfg err: %s", initErr.Error())
+			return initErr
+		}
+		go h.Run(errCh)
+
+		log.Errorf("restart ApolloServer initialize err: %s", err.Error())
+		return err
+	}
+
+	log.Infof("init ApolloServer successfully, restart it")
+	h.restart = false
+	go h.Run(errCh)
+	return nil
+}
+
+// createRestfulContainer create handler
+func (h *ApolloServer) createRestfulContainer() *restful.Container {
+	wsContainer := restful.NewContainer()
+	wsContainer.Filter(h.process)
+	wsContainer.Add(h.GetApolloAccessServer())
+	return wsContainer
+}
+
+// process 在接收和回复时统一处理请求
+func (h *ApolloServer) process(req *restful.Request, rsp *restful.Response, chain *restful.FilterChain) {
+	req.SetAttribute("start-time", time.Now())
+
+	chain.ProcessFilter(req, rsp)
+
+	h.postProcess(req, rsp)
+}
+
+// postProcess 请求后处理：打印耗时过长的非长轮询请求
+func (h *ApolloServer) postProcess(req *restful.Request, rsp *restful.Response) {
+	path := strings.TrimSuffix(req.Request.URL.Path, "/")
+	if path == notificationsPath {
+		return
+	}
+
+	startTime := req.Attribute("start-time").(time.Time)
+	diff := time.Since(startTime)
+	// 打印耗时超过1s的请求
+	if diff > time.Second {
+		log.Info("handling time > 1s",
+			zap.String("client-address", req.Request.RemoteAddr),
+			zap.String("user-agent", req.HeaderParameter("User-Agent")),
+			zap.String("method", req.Request.Method),
+			zap.String("url", req.Request.URL.String()),
+			zap.Int("status", rsp.StatusCode()),
+			zap.Duration("handling-time", diff),
+		)
+	}
+}
+
+// tcpKeepAliveListener sets TCP keep-alive timeouts on accepted
+// connections. It's used by ListenAndServe and ListenAndServeTLS so
+// dead TCP connections (e.g. closing laptop mid-download) eventually
+// go away.
+// 来自net/http
+type tcpKeepAliveListener struct {
+	*net.TCPListener
+}
+
+// Accept 来自于net/http
+func (ln tcpKeepAliveListener) Accept() (net.Conn, error) {
+	tc, err := ln.AcceptTCP()
+	if err != nil {
+		return nil, err
+	}
+	_ = tc.SetKeepAlive(true)
+	_ = tc.SetKeepAlivePeriod(3 * time.Minute)
+	return tc, nil
+}
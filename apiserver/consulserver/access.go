@@ -0,0 +1,411 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package consulserver
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/emicklei/go-restful"
+	"go.uber.org/zap"
+
+	api "github.com/polarismesh/polaris-server/common/api/v1"
+	"github.com/polarismesh/polaris-server/common/model"
+	"github.com/polarismesh/polaris-server/common/utils"
+)
+
+const (
+	// defaultBlockingWait 阻塞查询未指定 wait 时的等待时间
+	defaultBlockingWait = 5 * time.Minute
+	// maxBlockingWait 阻塞查询最长的等待时间
+	maxBlockingWait = 10 * time.Minute
+	// blockingCheckInterval 阻塞查询检查数据是否变化的间隔
+	blockingCheckInterval = time.Second
+
+	consulTokenHeader = "X-Consul-Token"
+	consulIndexHeader = "X-Consul-Index"
+)
+
+// GetConsulAccessServer Consul 客户端使用的接口
+func (h *ConsulServer) GetConsulAccessServer() *restful.WebService {
+	ws := new(restful.WebService)
+	ws.Path("/v1").Produces(restful.MIME_JSON)
+
+	ws.Route(ws.GET("/catalog/services").To(h.catalogServices))
+	ws.Route(ws.GET("/catalog/service/{service}").To(h.catalogService))
+	ws.Route(ws.GET("/health/service/{service}").To(h.healthService))
+
+	ws.Route(ws.PUT("/agent/service/register").To(h.registerService))
+	ws.Route(ws.PUT("/agent/service/deregister/{service_id}").To(h.deregisterService))
+	ws.Route(ws.PUT("/agent/check/pass/{check_id}").To(h.passCheck))
+	ws.Route(ws.PUT("/agent/check/warn/{check_id}").To(h.passCheck))
+	ws.Route(ws.PUT("/agent/check/fail/{check_id}").To(h.failCheck))
+	ws.Route(ws.PUT("/agent/check/update/{check_id}").To(h.updateCheck))
+	return ws
+}
+
+// catalogServices 查询命名空间下的全部服务以及服务实例的标签
+func (h *ConsulServer) catalogServices(req *restful.Request, rsp *restful.Response) {
+	namespace := h.toNamespace(req)
+	h.blockingQuery(req, rsp, "catalog/services/"+namespace, func() (interface{}, string) {
+		services := make(map[string][]string)
+		var revisions []string
+		serviceCache := h.namingServer.Cache().Service()
+		_ = serviceCache.IteratorServices(func(_ string, svc *model.Service) (bool, error) {
+			if svc.Namespace != namespace || svc.IsAlias() {
+				return true, nil
+			}
+			tags := make([]string, 0)
+			instances, revision := h.serviceInstances(svc)
+			for _, instance := range instances {
+				tags = mergeTags(tags, instanceTags(instance.Proto))
+			}
+			services[svc.Name] = tags
+			revisions = append(revisions, svc.Name+":"+revision)
+			return true, nil
+		})
+		// 命名空间下没有服务时不记录查询
+		if len(revisions) == 0 {
+			return services, ""
+		}
+		sort.Strings(revisions)
+		sum := md5.Sum([]byte(strings.Join(revisions, ",")))
+		return services, hex.EncodeToString(sum[:])
+	})
+}
+
+// catalogService 查询服务的全部实例，不区分实例的健康状态
+func (h *ConsulServer) catalogService(req *restful.Request, rsp *restful.Response) {
+	namespace := h.toNamespace(req)
+	name := req.PathParameter("service")
+	tags := req.Request.URL.Query()["tag"]
+	h.blockingQuery(req, rsp, "service/"+namespace+"/"+name, func() (interface{}, string) {
+		instances, revision := h.queryInstances(namespace, name)
+		services := make([]*CatalogService, 0, len(instances))
+		for _, instance := range instances {
+			if hasTags(instanceTags(instance.Proto), tags) {
+				services = append(services, h.toCatalogService(instance.Proto))
+			}
+		}
+		return services, revision
+	})
+}
+
+// healthService 查询服务实例以及实例的健康状态，passing 参数只返回健康的实例
+func (h *ConsulServer) healthService(req *restful.Request, rsp *restful.Response) {
+	namespace := h.toNamespace(req)
+	name := req.PathParameter("service")
+	tags := req.Request.URL.Query()["tag"]
+	passingOnly := parseFlag(req, "passing")
+	h.blockingQuery(req, rsp, "service/"+namespace+"/"+name, func() (interface{}, string) {
+		instances, revision := h.queryInstances(namespace, name)
+		entries := make([]*ServiceEntry, 0, len(instances))
+		for _, instance := range instances {
+			if passingOnly && checkStatus(instance.Proto) != HealthPassing {
+				continue
+			}
+			if hasTags(instanceTags(instance.Proto), tags) {
+				entries = append(entries, h.toServiceEntry(instance.Proto))
+			}
+		}
+		return entries, revision
+	})
+}
+
+// registerService 注册服务实例，实例已经存在时更新实例，服务不存在时自动创建服务
+func (h *ConsulServer) registerService(req *restful.Request, rsp *restful.Response) {
+	registration := &AgentServiceRegistration{}
+	if err := json.NewDecoder(req.Request.Body).Decode(registration); err != nil {
+		writeText(rsp, http.StatusBadRequest, "Request decode failed: "+err.Error())
+		return
+	}
+	instance, err := registration.toPolarisInstance(h.toNamespace(req), clientIP(req))
+	if err != nil {
+		writeText(rsp, http.StatusBadRequest, "Invalid service: "+err.Error())
+		return
+	}
+
+	ctx := h.requestContext(req)
+	code := h.namingServer.RegisterInstance(ctx, instance).GetCode().GetValue()
+	if code == api.NotFoundResource {
+		svcResp := h.namingServer.CreateServices(ctx, []*api.Service{{
+			Namespace: instance.GetNamespace(),
+			Name:      instance.GetService(),
+			Owners:    utils.NewStringValue(h.owner),
+		}})
+		code = svcResp.GetCode().GetValue()
+		if code == api.ExecuteSuccess || code == api.ExistedResource {
+			code = h.namingServer.RegisterInstance(ctx, instance).GetCode().GetValue()
+		}
+	}
+	// Consul 重复注册时覆盖原有的注册信息
+	if code == api.ExistedResource {
+		code = h.namingServer.UpdateInstances(ctx, []*api.Instance{instance}).GetCode().GetValue()
+		if code == api.NoNeedUpdate {
+			code = api.ExecuteSuccess
+		}
+	}
+	if code != api.ExecuteSuccess {
+		log.Error("[Naming][Consul] register service error.",
+			zap.String("namespace", instance.GetNamespace().GetValue()),
+			zap.String("service", instance.GetService().GetValue()),
+			zap.String("id", instance.GetId().GetValue()),
+			zap.Uint32("code", code))
+		writePolarisError(rsp, code, api.Code2Info(code))
+		return
+	}
+	if checkID := instance.GetMetadata()[MetadataCheckID]; checkID != "" {
+		h.checks.add(checkKey(instance.GetNamespace().GetValue(), clientIP(req), checkID), instance.GetId().GetValue())
+	}
+	rsp.WriteHeader(http.StatusOK)
+}
+
+// deregisterService 根据注册时的服务 ID 反注册实例，服务 ID 只在注册的 agent 内唯一
+func (h *ConsulServer) deregisterService(req *restful.Request, rsp *restful.Response) {
+	serviceID := req.PathParameter("service_id")
+	resp := h.namingServer.DeregisterInstance(h.requestContext(req), &api.Instance{
+		Id: utils.NewStringValue(instanceID(h.toNamespace(req), clientIP(req), serviceID)),
+	})
+	switch code := resp.GetCode().GetValue(); code {
+	case api.ExecuteSuccess:
+		rsp.WriteHeader(http.StatusOK)
+	case api.NotFoundResource, api.NotFoundInstance:
+		writeText(rsp, http.StatusNotFound, "Unknown service ID "+strconv.Quote(serviceID))
+	default:
+		writePolarisError(rsp, code, resp.GetInfo().GetValue())
+	}
+}
+
+// passCheck TTL 检查上报 passing 或者 warning 状态，转换为北极星的心跳
+func (h *ConsulServer) passCheck(req *restful.Request, rsp *restful.Response) {
+	h.reportCheck(req, rsp, req.PathParameter("check_id"))
+}
+
+// failCheck 北极星的心跳不支持主动上报不健康，不再上报心跳后实例会在 TTL 超时后变为不健康
+func (h *ConsulServer) failCheck(req *restful.Request, rsp *restful.Response) {
+	rsp.WriteHeader(http.StatusOK)
+}
+
+// updateCheck 更新 TTL 检查的状态
+func (h *ConsulServer) updateCheck(req *restful.Request, rsp *restful.Response) {
+	update := &CheckUpdate{}
+	if err := json.NewDecoder(req.Request.Body).Decode(update); err != nil {
+		writeText(rsp, http.StatusBadRequest, "Request decode failed: "+err.Error())
+		return
+	}
+	switch update.Status {
+	case HealthPassing, HealthWarning:
+		h.reportCheck(req, rsp, req.PathParameter("check_id"))
+	case HealthCritical:
+		h.failCheck(req, rsp)
+	default:
+		writeText(rsp, http.StatusBadRequest, "Invalid check status: "+strconv.Quote(update.Status))
+	}
+}
+
+func (h *ConsulServer) reportCheck(req *restful.Request, rsp *restful.Response, checkID string) {
+	instanceID := h.checkInstanceID(h.toNamespace(req), clientIP(req), checkID)
+	if instanceID == "" {
+		writeText(rsp, http.StatusNotFound, "Unknown check ID "+strconv.Quote(checkID))
+		return
+	}
+
+	resp := h.healthCheckServer.Report(h.requestContext(req), &api.Instance{Id: utils.NewStringValue(instanceID)})
+	switch code := resp.GetCode().GetValue(); code {
+	case api.ExecuteSuccess:
+		rsp.WriteHeader(http.StatusOK)
+	case api.HeartbeatOnDisabledIns, api.NotFoundResource, api.NotFoundInstance:
+		writeText(rsp, http.StatusNotFound, "Unknown check ID "+strconv.Quote(checkID))
+	default:
+		writePolarisError(rsp, code, resp.GetInfo().GetValue())
+	}
+}
+
+// checkInstanceID 根据 agent 内的 TTL 检查 ID 查找实例的 ID，默认的检查 ID 为 service:<服务 ID>
+func (h *ConsulServer) checkInstanceID(namespace, agent, checkID string) string {
+	if strings.HasPrefix(checkID, serviceCheckPrefix) {
+		return instanceID(namespace, agent, strings.TrimPrefix(checkID, serviceCheckPrefix))
+	}
+	key := checkKey(namespace, agent, checkID)
+	return h.checks.lookup(key, func(id string) bool {
+		instance := h.namingServer.Cache().Instance().GetInstance(id)
+		return instance != nil && instanceCheckKey(instance) == key
+	})
+}
+
+// scanCheckIDs 扫描实例缓存中全部自定义的检查 ID
+func (h *ConsulServer) scanCheckIDs() map[string]string {
+	ids := make(map[string]string)
+	_ = h.namingServer.Cache().Instance().IteratorInstances(func(_ string, instance *model.Instance) (bool, error) {
+		if key := instanceCheckKey(instance); key != "" {
+			ids[key] = instance.ID()
+		}
+		return true, nil
+	})
+	return ids
+}
+
+// blockingQuery 执行 Consul 的阻塞查询，客户端携带的 index 和当前的 index 一致时，挂起到数据变化或者超时
+// key 标识查询的资源，用于生成递增的 index
+func (h *ConsulServer) blockingQuery(req *restful.Request, rsp *restful.Response, key string,
+	query func() (interface{}, string)) {
+	wait := defaultBlockingWait
+	if raw := req.QueryParameter("wait"); raw != "" {
+		value, err := time.ParseDuration(raw)
+		if err != nil || value < 0 {
+			writeText(rsp, http.StatusBadRequest, "Invalid wait time")
+			return
+		}
+		if value < maxBlockingWait {
+			wait = value
+		} else {
+			wait = maxBlockingWait
+		}
+	}
+
+	result, revision := query()
+	index := h.indexer.index(key, revision)
+	clientIndex, _ := strconv.ParseUint(req.QueryParameter("index"), 10, 64)
+	if clientIndex != 0 && clientIndex == index {
+		timer := time.NewTimer(wait)
+		defer timer.Stop()
+		ticker := time.NewTicker(blockingCheckInterval)
+		defer ticker.Stop()
+	loop:
+		for {
+			select {
+			case <-req.Request.Context().Done():
+				return
+			case <-timer.C:
+				break loop
+			case <-ticker.C:
+				result, revision = query()
+				if index = h.indexer.index(key, revision); index != clientIndex {
+					break loop
+				}
+			}
+		}
+	}
+
+	rsp.AddHeader(consulIndexHeader, strconv.FormatUint(index, 10))
+	rsp.AddHeader("X-Consul-KnownLeader", "true")
+	rsp.AddHeader("X-Consul-LastContact", "0")
+	_ = rsp.WriteHeaderAndJson(http.StatusOK, result, restful.MIME_JSON)
+}
+
+// queryInstances 从缓存查询服务的实例以及实例的版本号，服务不存在时返回空列表
+func (h *ConsulServer) queryInstances(namespace, name string) ([]*model.Instance, string) {
+	svc := h.namingServer.Cache().Service().GetServiceByName(name, namespace)
+	if svc == nil {
+		return nil, ""
+	}
+	// 别名服务返回源服务的实例
+	if svc.IsAlias() {
+		svc = h.namingServer.Cache().Service().GetServiceByID(svc.Reference)
+		if svc == nil {
+			return nil, ""
+		}
+	}
+	return h.serviceInstances(svc)
+}
+
+func (h *ConsulServer) serviceInstances(svc *model.Service) ([]*model.Instance, string) {
+	instances := h.namingServer.Cache().Instance().GetInstancesByServiceID(svc.ID)
+	revision := h.namingServer.Cache().GetServiceInstanceRevision(svc.ID)
+	if revision == "" {
+		revision, _ = h.namingServer.GetServiceInstanceRevision(svc.ID, instances)
+	}
+	return instances, revision
+}
+
+// toNamespace Consul 企业版的 ns 参数对应北极星的命名空间
+func (h *ConsulServer) toNamespace(req *restful.Request) string {
+	if namespace := req.QueryParameter("ns"); namespace != "" {
+		return namespace
+	}
+	return h.namespace
+}
+
+// requestContext 记录客户端的访问凭证，Consul 的 ACL token 作为北极星的访问凭证
+func (h *ConsulServer) requestContext(req *restful.Request) context.Context {
+	ctx := context.WithValue(context.Background(), utils.StringContext("request-id"),
+		req.HeaderParameter("Request-Id"))
+	ctx = context.WithValue(ctx, utils.StringContext("operator"), "Consul:"+clientIP(req))
+
+	token := req.HeaderParameter(consulTokenHeader)
+	if token == "" {
+		token = req.HeaderParameter(utils.HeaderAuthTokenKey)
+	}
+	if token == "" {
+		token = req.QueryParameter("token")
+	}
+	if token != "" {
+		ctx = context.WithValue(ctx, utils.ContextAuthTokenKey, token)
+	}
+	return ctx
+}
+
+func clientIP(req *restful.Request) string {
+	if host, _, err := net.SplitHostPort(req.Request.RemoteAddr); err == nil {
+		return host
+	}
+	return req.Request.RemoteAddr
+}
+
+// parseFlag Consul 的开关参数不带值时表示打开
+func parseFlag(req *restful.Request, name string) bool {
+	values, ok := req.Request.URL.Query()[name]
+	if !ok {
+		return false
+	}
+	if len(values) == 0 || values[0] == "" {
+		return true
+	}
+	value, err := strconv.ParseBool(values[0])
+	return err == nil && value
+}
+
+// writePolarisError 把北极星的错误码转换为 HTTP 状态码
+func writePolarisError(rsp *restful.Response, code uint32, info string) {
+	status := http.StatusInternalServerError
+	switch code {
+	case api.NotAllowedAccess:
+		status = http.StatusForbidden
+	case api.NotFoundResource:
+		status = http.StatusNotFound
+	default:
+		if code >= 400000 && code < 500000 {
+			status = http.StatusBadRequest
+		}
+	}
+	writeText(rsp, status, info)
+}
+
+func writeText(rsp *restful.Response, status int, body string) {
+	rsp.AddHeader(restful.HEADER_ContentType, "text/plain; charset=utf-8")
+	rsp.WriteHeader(status)
+	_, _ = rsp.Write([]byte(body))
+}
@@ -0,0 +1,87 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package consulserver
+
+import (
+	"sync"
+	"time"
+
+	"github.com/polarismesh/polaris-server/common/model"
+)
+
+// checkIndexScanInterval 自定义检查 ID 未命中索引时，重新扫描实例缓存的最小间隔
+const checkIndexScanInterval = time.Second
+
+// checkIndex 自定义 TTL 检查 ID 到北极星实例 ID 的索引，避免每次上报检查状态都遍历全部实例
+// 本节点注册的实例直接写入索引，其他节点注册的实例在未命中时扫描实例缓存重建索引
+type checkIndex struct {
+	mutex    sync.Mutex
+	ids      map[string]string
+	scanTime time.Time
+	// scan 扫描实例缓存，返回全部自定义检查 ID 对应的实例 ID
+	scan func() map[string]string
+}
+
+func newCheckIndex(scan func() map[string]string) *checkIndex {
+	return &checkIndex{
+		ids:  make(map[string]string),
+		scan: scan,
+	}
+}
+
+// checkKey 检查 ID 只在注册的 agent 内唯一
+func checkKey(namespace, agent, checkID string) string {
+	return namespace + "##" + agent + "##" + checkID
+}
+
+// instanceCheckKey 实例的自定义检查 ID 对应的索引 key，没有自定义检查 ID 时返回空
+func instanceCheckKey(instance *model.Instance) string {
+	metadata := instance.Metadata()
+	if metadata[MetadataCheckID] == "" {
+		return ""
+	}
+	return checkKey(instance.Namespace(), metadata[MetadataAgent], metadata[MetadataCheckID])
+}
+
+// add 记录本节点注册的实例
+func (c *checkIndex) add(key, instanceID string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.ids[key] = instanceID
+}
+
+// lookup 查找检查 ID 对应的实例 ID，valid 校验索引中的实例是否仍然使用这个检查 ID
+// 未命中时重新扫描实例缓存，两次扫描的间隔不小于 checkIndexScanInterval
+func (c *checkIndex) lookup(key string, valid func(instanceID string) bool) string {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if id, ok := c.ids[key]; ok {
+		if valid(id) {
+			return id
+		}
+		delete(c.ids, key)
+	}
+	if time.Since(c.scanTime) < checkIndexScanInterval {
+		return ""
+	}
+	c.scanTime = time.Now()
+	c.ids = c.scan()
+	return c.ids[key]
+}
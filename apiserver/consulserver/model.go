@@ -0,0 +1,458 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package consulserver
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	api "github.com/polarismesh/polaris-server/common/api/v1"
+	"github.com/polarismesh/polaris-server/common/utils"
+)
+
+const (
+	ServerConsul = "consul"
+
+	MetadataRegisterFrom = "internal-register-from"
+	MetadataTags         = "internal-consul-tags"
+	MetadataCheckID      = "internal-consul-check-id"
+	MetadataServiceID    = "internal-consul-service-id"
+	MetadataAgent        = "internal-consul-agent"
+
+	HealthPassing  = "passing"
+	HealthWarning  = "warning"
+	HealthCritical = "critical"
+
+	// emptyRevisionIndex 查询的数据不存在时返回的 index
+	emptyRevisionIndex = 1
+	// maxRevisionIndexes 最多记录的阻塞查询个数
+	maxRevisionIndexes = 65536
+
+	// serviceCheckPrefix 未指定 CheckID 时 Consul 为服务检查生成的 ID 前缀
+	serviceCheckPrefix = "service:"
+	// expireTtlCount 北极星在 3 个心跳周期内没有收到心跳时把实例置为不健康
+	expireTtlCount = 3
+	// weightRatio Consul 的默认权重为 1，对应北极星的默认权重 100
+	weightRatio = 100
+	maxWeight   = 10000
+)
+
+// AgentWeights 服务的权重
+type AgentWeights struct {
+	Passing int
+	Warning int
+}
+
+// AgentServiceCheck 注册服务时携带的健康检查
+type AgentServiceCheck struct {
+	CheckID                        string
+	Name                           string
+	Status                         string
+	TTL                            string
+	HTTP                           string
+	TCP                            string
+	GRPC                           string
+	Interval                       string
+	Timeout                        string
+	DeregisterCriticalServiceAfter string
+}
+
+// AgentServiceRegistration 注册服务的请求
+type AgentServiceRegistration struct {
+	ID      string
+	Name    string
+	Tags    []string
+	Port    int
+	Address string
+	Meta    map[string]string
+	Weights *AgentWeights
+	Check   *AgentServiceCheck
+	Checks  []*AgentServiceCheck
+}
+
+// CheckUpdate 更新 TTL 检查状态的请求
+type CheckUpdate struct {
+	Status string
+	Output string
+}
+
+// Node 服务实例所在的节点，北极星没有节点的概念，使用实例的 IP 作为节点
+type Node struct {
+	ID              string
+	Node            string
+	Address         string
+	Datacenter      string
+	TaggedAddresses map[string]string
+	Meta            map[string]string
+}
+
+// AgentService 健康查询返回的服务实例
+type AgentService struct {
+	ID      string
+	Service string
+	Tags    []string
+	Address string
+	Meta    map[string]string
+	Port    int
+	Weights AgentWeights
+}
+
+// HealthCheck 服务实例的健康检查状态
+type HealthCheck struct {
+	Node        string
+	CheckID     string
+	Name        string
+	Status      string
+	Notes       string
+	Output      string
+	ServiceID   string
+	ServiceName string
+	ServiceTags []string
+}
+
+// ServiceEntry 健康查询的结果
+type ServiceEntry struct {
+	Node    *Node
+	Service *AgentService
+	Checks  []*HealthCheck
+}
+
+// CatalogService 目录查询的结果
+type CatalogService struct {
+	ID              string
+	Node            string
+	Address         string
+	Datacenter      string
+	TaggedAddresses map[string]string
+	NodeMeta        map[string]string
+	ServiceID       string
+	ServiceName     string
+	ServiceTags     []string
+	ServiceAddress  string
+	ServiceMeta     map[string]string
+	ServicePort     int
+	ServiceWeights  AgentWeights
+}
+
+// ttlCheck 注册请求中的 TTL 检查，Consul 只支持由客户端上报的 TTL 检查
+func (r *AgentServiceRegistration) ttlCheck() *AgentServiceCheck {
+	if r.Check != nil && r.Check.TTL != "" {
+		return r.Check
+	}
+	for _, check := range r.Checks {
+		if check != nil && check.TTL != "" {
+			return check
+		}
+	}
+	return nil
+}
+
+// toPolarisInstance 注册请求转换为北极星实例，未指定 ID 和地址时使用服务名和客户端的地址
+// Consul 的服务 ID 只在一个 agent 内唯一，客户端直接注册到北极星时以客户端的地址作为 agent，
+// 北极星的实例 ID 由命名空间、agent 和服务 ID 生成，服务 ID 和 agent 保存在元数据中
+func (r *AgentServiceRegistration) toPolarisInstance(namespace, clientIP string) (*api.Instance, error) {
+	if r.Name == "" {
+		return nil, errors.New("missing service name")
+	}
+	if r.Port <= 0 {
+		return nil, errors.New("invalid service port")
+	}
+	serviceID := r.ID
+	if serviceID == "" {
+		serviceID = r.Name
+	}
+	address := r.Address
+	if address == "" {
+		address = clientIP
+	}
+
+	metadata := make(map[string]string, len(r.Meta)+5)
+	for k, v := range r.Meta {
+		metadata[k] = v
+	}
+	metadata[MetadataRegisterFrom] = ServerConsul
+	metadata[MetadataServiceID] = serviceID
+	metadata[MetadataAgent] = clientIP
+	if len(r.Tags) > 0 {
+		tags, _ := json.Marshal(r.Tags)
+		metadata[MetadataTags] = string(tags)
+	}
+
+	weight := uint32(weightRatio)
+	if r.Weights != nil {
+		weight = toPolarisWeight(r.Weights.Passing)
+	}
+
+	instance := &api.Instance{
+		Id:        utils.NewStringValue(instanceID(namespace, clientIP, serviceID)),
+		Namespace: utils.NewStringValue(namespace),
+		Service:   utils.NewStringValue(r.Name),
+		Host:      utils.NewStringValue(address),
+		Port:      utils.NewUInt32Value(uint32(r.Port)),
+		Weight:    utils.NewUInt32Value(weight),
+		Healthy:   utils.NewBoolValue(true),
+		Metadata:  metadata,
+	}
+
+	// 只有 TTL 检查能够转换为北极星的心跳，其他类型的检查需要 Consul agent 主动探测，不做处理
+	check := r.ttlCheck()
+	if check == nil {
+		return instance, nil
+	}
+	ttl, err := parseTTL(check.TTL)
+	if err != nil {
+		return nil, err
+	}
+	if check.CheckID != "" && check.CheckID != serviceCheckPrefix+serviceID {
+		metadata[MetadataCheckID] = check.CheckID
+	}
+	// TTL 检查在第一次上报之前的状态为 critical
+	instance.Healthy = utils.NewBoolValue(check.Status == HealthPassing || check.Status == HealthWarning)
+	instance.EnableHealthCheck = utils.NewBoolValue(true)
+	instance.HealthCheck = &api.HealthCheck{
+		Type:      api.HealthCheck_HEARTBEAT,
+		Heartbeat: &api.HeartbeatHealthCheck{Ttl: utils.NewUInt32Value(ttl)},
+	}
+	return instance, nil
+}
+
+// instanceID 根据命名空间、agent 的地址和 Consul 的服务 ID 生成北极星的实例 ID
+func instanceID(namespace, agent, serviceID string) string {
+	sum := sha1.Sum([]byte(namespace + "##" + agent + "##" + serviceID))
+	return hex.EncodeToString(sum[:])
+}
+
+// parseTTL Consul 的 TTL 转换为北极星的心跳周期
+func parseTTL(ttl string) (uint32, error) {
+	duration, err := time.ParseDuration(ttl)
+	if err != nil {
+		return 0, fmt.Errorf("invalid check ttl %s: %v", ttl, err)
+	}
+	if duration <= 0 {
+		return 0, fmt.Errorf("invalid check ttl %s", ttl)
+	}
+	seconds := uint32(math.Ceil(duration.Seconds() / expireTtlCount))
+	if seconds == 0 {
+		seconds = 1
+	}
+	return seconds, nil
+}
+
+// toPolarisWeight Consul 的权重转换为北极星的权重
+func toPolarisWeight(weight int) uint32 {
+	if weight <= 0 {
+		return 0
+	}
+	if weight*weightRatio > maxWeight {
+		return maxWeight
+	}
+	return uint32(weight * weightRatio)
+}
+
+func instanceTags(instance *api.Instance) []string {
+	tags := make([]string, 0)
+	if raw := instance.GetMetadata()[MetadataTags]; raw != "" {
+		_ = json.Unmarshal([]byte(raw), &tags)
+	}
+	return tags
+}
+
+// instanceMeta 去掉北极星内部使用的元数据
+func instanceMeta(instance *api.Instance) map[string]string {
+	meta := make(map[string]string, len(instance.GetMetadata()))
+	for k, v := range instance.GetMetadata() {
+		if !strings.HasPrefix(k, "internal-") {
+			meta[k] = v
+		}
+	}
+	return meta
+}
+
+func instanceWeights(instance *api.Instance) AgentWeights {
+	return AgentWeights{
+		Passing: int(math.Ceil(float64(instance.GetWeight().GetValue()) / weightRatio)),
+		Warning: 1,
+	}
+}
+
+// checkStatus 隔离的实例视为维护状态，和不健康的实例一样返回 critical
+func checkStatus(instance *api.Instance) string {
+	if instance.GetIsolate().GetValue() || !instance.GetHealthy().GetValue() {
+		return HealthCritical
+	}
+	return HealthPassing
+}
+
+// serviceID 实例对应的 Consul 服务 ID，不是通过 Consul 注册的实例使用北极星的实例 ID
+func serviceID(instance *api.Instance) string {
+	if id := instance.GetMetadata()[MetadataServiceID]; id != "" {
+		return id
+	}
+	return instance.GetId().GetValue()
+}
+
+// checkID 实例的服务检查 ID
+func checkID(instance *api.Instance) string {
+	if id := instance.GetMetadata()[MetadataCheckID]; id != "" {
+		return id
+	}
+	return serviceCheckPrefix + serviceID(instance)
+}
+
+func (h *ConsulServer) toNode(instance *api.Instance) *Node {
+	return &Node{
+		Node:            instance.GetHost().GetValue(),
+		Address:         instance.GetHost().GetValue(),
+		Datacenter:      h.datacenter,
+		TaggedAddresses: map[string]string{"lan": instance.GetHost().GetValue()},
+		Meta:            map[string]string{},
+	}
+}
+
+// toServiceEntry 北极星实例转换为健康查询的结果
+func (h *ConsulServer) toServiceEntry(instance *api.Instance) *ServiceEntry {
+	tags := instanceTags(instance)
+	node := h.toNode(instance)
+	return &ServiceEntry{
+		Node: node,
+		Service: &AgentService{
+			ID:      serviceID(instance),
+			Service: instance.GetService().GetValue(),
+			Tags:    tags,
+			Address: instance.GetHost().GetValue(),
+			Meta:    instanceMeta(instance),
+			Port:    int(instance.GetPort().GetValue()),
+			Weights: instanceWeights(instance),
+		},
+		Checks: []*HealthCheck{{
+			Node:        node.Node,
+			CheckID:     checkID(instance),
+			Name:        "Service '" + instance.GetService().GetValue() + "' check",
+			Status:      checkStatus(instance),
+			ServiceID:   serviceID(instance),
+			ServiceName: instance.GetService().GetValue(),
+			ServiceTags: tags,
+		}},
+	}
+}
+
+// toCatalogService 北极星实例转换为目录查询的结果
+func (h *ConsulServer) toCatalogService(instance *api.Instance) *CatalogService {
+	node := h.toNode(instance)
+	return &CatalogService{
+		Node:            node.Node,
+		Address:         node.Address,
+		Datacenter:      node.Datacenter,
+		TaggedAddresses: node.TaggedAddresses,
+		NodeMeta:        node.Meta,
+		ServiceID:       serviceID(instance),
+		ServiceName:     instance.GetService().GetValue(),
+		ServiceTags:     instanceTags(instance),
+		ServiceAddress:  instance.GetHost().GetValue(),
+		ServiceMeta:     instanceMeta(instance),
+		ServicePort:     int(instance.GetPort().GetValue()),
+		ServiceWeights:  instanceWeights(instance),
+	}
+}
+
+// hasTags 实例是否包含全部的标签
+func hasTags(tags []string, wanted []string) bool {
+	for _, want := range wanted {
+		found := false
+		for _, tag := range tags {
+			if tag == want {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// mergeTags 合并多个实例的标签，结果去重并排序
+func mergeTags(tags []string, more []string) []string {
+	for _, tag := range more {
+		if !hasTags(tags, []string{tag}) {
+			tags = append(tags, tag)
+		}
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+// revisionIndexer 生成 Consul 阻塞查询使用的 index，每个查询的版本号变化时分配一个新的递增 index
+// 查询的 key 可以由客户端任意指定，只记录存在数据的查询，并且限制记录的个数
+type revisionIndexer struct {
+	mutex    sync.Mutex
+	last     uint64
+	capacity int
+	indexes  map[string]*revisionIndexEntry
+}
+
+type revisionIndexEntry struct {
+	revision string
+	index    uint64
+}
+
+// newRevisionIndexer 以启动时的毫秒时间戳作为初始值，重启之后的 index 不会小于重启之前返回的 index，
+// 同时保证 index 在 53 位以内，避免使用双精度浮点数解析 index 的客户端丢失精度
+func newRevisionIndexer(capacity int) *revisionIndexer {
+	return &revisionIndexer{
+		last:     uint64(time.Now().UnixNano() / int64(time.Millisecond)),
+		capacity: capacity,
+		indexes:  make(map[string]*revisionIndexEntry),
+	}
+}
+
+// index 获取查询当前版本号对应的 index，版本号和上一次不同时 index 递增
+// 版本号为空表示查询的数据不存在，不记录查询并返回固定的 index，数据出现后 index 变大
+func (r *revisionIndexer) index(key string, revision string) uint64 {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if revision == "" {
+		delete(r.indexes, key)
+		return emptyRevisionIndex
+	}
+	entry, ok := r.indexes[key]
+	if !ok {
+		// 超过上限时清空全部记录，被清理的查询分配新的 index，客户端只会多收到一次相同的数据
+		if len(r.indexes) >= r.capacity {
+			r.indexes = make(map[string]*revisionIndexEntry)
+		}
+		entry = &revisionIndexEntry{}
+		r.indexes[key] = entry
+	}
+	if !ok || entry.revision != revision {
+		r.last++
+		entry.revision = revision
+		entry.index = r.last
+	}
+	return entry.index
+}
@@ -0,0 +1,192 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package consulserver
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"github.com/stretchr/testify/assert"
+
+	api "github.com/polarismesh/polaris-server/common/api/v1"
+)
+
+func TestToPolarisInstance(t *testing.T) {
+	registration := &AgentServiceRegistration{
+		Name:    "web",
+		Tags:    []string{"v1", "primary"},
+		Port:    8080,
+		Meta:    map[string]string{"env": "prod"},
+		Weights: &AgentWeights{Passing: 2, Warning: 1},
+		Check:   &AgentServiceCheck{TTL: "15s"},
+	}
+	instance, err := registration.toPolarisInstance("default", "10.0.0.1")
+	assert.Nil(t, err)
+	assert.Equal(t, instanceID("default", "10.0.0.1", "web"), instance.GetId().GetValue())
+	assert.Equal(t, "web", serviceID(instance))
+	assert.Equal(t, "10.0.0.1", instance.GetHost().GetValue())
+	assert.Equal(t, uint32(200), instance.GetWeight().GetValue())
+	assert.False(t, instance.GetHealthy().GetValue())
+	assert.Equal(t, api.HealthCheck_HEARTBEAT, instance.GetHealthCheck().GetType())
+	assert.Equal(t, uint32(5), instance.GetHealthCheck().GetHeartbeat().GetTtl().GetValue())
+	assert.Equal(t, ServerConsul, instance.GetMetadata()[MetadataRegisterFrom])
+	assert.Equal(t, []string{"v1", "primary"}, instanceTags(instance))
+	assert.Equal(t, map[string]string{"env": "prod"}, instanceMeta(instance))
+	assert.Equal(t, "service:web", checkID(instance))
+
+	registration.ID = "web-1"
+	registration.Address = "10.0.0.2"
+	registration.Check = nil
+	registration.Checks = []*AgentServiceCheck{{HTTP: "http://10.0.0.2/health"},
+		{CheckID: "web-ttl", TTL: "1s", Status: HealthPassing}}
+	instance, err = registration.toPolarisInstance("default", "10.0.0.1")
+	assert.Nil(t, err)
+	assert.Equal(t, instanceID("default", "10.0.0.1", "web-1"), instance.GetId().GetValue())
+	assert.Equal(t, "web-1", serviceID(instance))
+	assert.Equal(t, "10.0.0.2", instance.GetHost().GetValue())
+	assert.True(t, instance.GetHealthy().GetValue())
+	assert.Equal(t, uint32(1), instance.GetHealthCheck().GetHeartbeat().GetTtl().GetValue())
+	assert.Equal(t, "web-ttl", checkID(instance))
+
+	registration.Checks = []*AgentServiceCheck{{HTTP: "http://10.0.0.2/health"}}
+	instance, err = registration.toPolarisInstance("default", "10.0.0.1")
+	assert.Nil(t, err)
+	assert.True(t, instance.GetHealthy().GetValue())
+	assert.Nil(t, instance.GetHealthCheck())
+
+	_, err = (&AgentServiceRegistration{Name: "web"}).toPolarisInstance("default", "10.0.0.1")
+	assert.NotNil(t, err)
+	_, err = (&AgentServiceRegistration{Name: "web", Port: 80, Check: &AgentServiceCheck{TTL: "abc"}}).
+		toPolarisInstance("default", "10.0.0.1")
+	assert.NotNil(t, err)
+}
+
+// TestRegisterFromTwoAgents 两个地址注册同名服务时，Consul 的服务 ID 相同，北极星的实例 ID 不同
+func TestRegisterFromTwoAgents(t *testing.T) {
+	registration := &AgentServiceRegistration{Name: "web", Port: 8080, Check: &AgentServiceCheck{TTL: "15s"}}
+	first, err := registration.toPolarisInstance("default", "10.0.0.1")
+	assert.Nil(t, err)
+	second, err := registration.toPolarisInstance("default", "10.0.0.2")
+	assert.Nil(t, err)
+
+	assert.NotEqual(t, first.GetId().GetValue(), second.GetId().GetValue())
+	assert.Equal(t, "web", serviceID(first))
+	assert.Equal(t, "web", serviceID(second))
+	assert.Equal(t, "10.0.0.2", second.GetMetadata()[MetadataAgent])
+	assert.Equal(t, "service:web", checkID(second))
+
+	// 反注册和心跳上报按照 agent 找回各自的实例
+	h := &ConsulServer{}
+	assert.Equal(t, first.GetId().GetValue(), h.checkInstanceID("default", "10.0.0.1", checkID(first)))
+	assert.Equal(t, second.GetId().GetValue(), h.checkInstanceID("default", "10.0.0.2", checkID(second)))
+	assert.NotEqual(t, first.GetId().GetValue(), instanceID("production", "10.0.0.1", "web"))
+}
+
+func TestServiceEntry(t *testing.T) {
+	h := &ConsulServer{datacenter: DefaultDatacenter}
+	instance, err := (&AgentServiceRegistration{ID: "web-1", Name: "web", Port: 8080, Address: "10.0.0.2"}).
+		toPolarisInstance("default", "10.0.0.1")
+	assert.Nil(t, err)
+
+	entry := h.toServiceEntry(instance)
+	assert.Equal(t, "10.0.0.2", entry.Node.Node)
+	assert.Equal(t, DefaultDatacenter, entry.Node.Datacenter)
+	assert.Equal(t, "web-1", entry.Service.ID)
+	assert.Equal(t, 8080, entry.Service.Port)
+	assert.Equal(t, []string{}, entry.Service.Tags)
+	assert.Equal(t, 1, entry.Service.Weights.Passing)
+	assert.Equal(t, HealthPassing, entry.Checks[0].Status)
+
+	instance.Isolate = &wrappers.BoolValue{Value: true}
+	assert.Equal(t, HealthCritical, h.toServiceEntry(instance).Checks[0].Status)
+
+	service := h.toCatalogService(instance)
+	assert.Equal(t, "web-1", service.ServiceID)
+	assert.Equal(t, "10.0.0.2", service.Address)
+}
+
+func TestTags(t *testing.T) {
+	assert.True(t, hasTags([]string{"a", "b"}, nil))
+	assert.True(t, hasTags([]string{"a", "b"}, []string{"b"}))
+	assert.False(t, hasTags([]string{"a", "b"}, []string{"b", "c"}))
+	assert.Equal(t, []string{"a", "b", "c"}, mergeTags([]string{"b"}, []string{"c", "a", "b"}))
+}
+
+func TestRevisionIndex(t *testing.T) {
+	indexer := newRevisionIndexer(2)
+	first := indexer.index("health/default/web", "rev-1")
+	assert.Equal(t, first, indexer.index("health/default/web", "rev-1"))
+	assert.True(t, first < 1<<53)
+
+	// 版本号变化后 index 递增，即使版本号变回之前的值
+	second := indexer.index("health/default/web", "rev-2")
+	assert.True(t, second > first)
+	third := indexer.index("health/default/web", "rev-1")
+	assert.True(t, third > second)
+
+	// 不同查询的 index 互不影响
+	other := indexer.index("health/default/api", "rev-1")
+	assert.True(t, other > third)
+	assert.Equal(t, third, indexer.index("health/default/web", "rev-1"))
+
+	// 不存在的数据不记录
+	assert.Equal(t, uint64(emptyRevisionIndex), indexer.index("health/default/unknown", ""))
+	assert.Equal(t, 2, len(indexer.indexes))
+
+	// 超过上限后清空记录，被清理的查询分配新的 index
+	indexer.index("health/default/db", "rev-1")
+	assert.Equal(t, 1, len(indexer.indexes))
+	assert.True(t, indexer.index("health/default/web", "rev-1") > third)
+}
+
+func TestCheckIndex(t *testing.T) {
+	scans := 0
+	instances := map[string]string{checkKey("default", "10.0.0.1", "web-ttl"): "id-1"}
+	index := newCheckIndex(func() map[string]string {
+		scans++
+		ids := make(map[string]string, len(instances))
+		for k, v := range instances {
+			ids[k] = v
+		}
+		return ids
+	})
+	valid := func(id string) bool {
+		for _, v := range instances {
+			if v == id {
+				return true
+			}
+		}
+		return false
+	}
+
+	// 本节点注册的实例不需要扫描
+	index.add(checkKey("default", "10.0.0.2", "web-ttl"), "id-2")
+	instances[checkKey("default", "10.0.0.2", "web-ttl")] = "id-2"
+	assert.Equal(t, "id-2", index.lookup(checkKey("default", "10.0.0.2", "web-ttl"), valid))
+	assert.Equal(t, 0, scans)
+
+	// 其他节点注册的实例扫描一次之后命中索引
+	assert.Equal(t, "id-1", index.lookup(checkKey("default", "10.0.0.1", "web-ttl"), valid))
+	assert.Equal(t, "id-1", index.lookup(checkKey("default", "10.0.0.1", "web-ttl"), valid))
+	assert.Equal(t, 1, scans)
+
+	// 未知的检查 ID 在扫描间隔内不会重复扫描
+	assert.Equal(t, "", index.lookup(checkKey("default", "10.0.0.3", "web-ttl"), valid))
+	assert.Equal(t, "", index.lookup(checkKey("default", "10.0.0.3", "web-ttl"), valid))
+	assert.Equal(t, 1, scans)
+}
@@ -19,9 +19,14 @@ package config
 
 import (
 	"context"
+	"io"
+	"time"
 
 	"github.com/google/uuid"
 	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 
 	"github.com/polarismesh/polaris-server/apiserver/grpcserver"
 	api "github.com/polarismesh/polaris-server/common/api/v1"
@@ -29,9 +34,27 @@ import (
 	"github.com/polarismesh/polaris-server/common/utils"
 )
 
+const (
+	// streamHeartbeatInterval 双向流订阅的心跳间隔，心跳为 DataNoChange 响应
+	streamHeartbeatInterval = 10 * time.Second
+)
+
 // GetConfigFile 拉取配置
 func (g *ConfigGRPCServer) GetConfigFile(ctx context.Context, configFile *api.ClientConfigFileInfo) (*api.ConfigClientResponse, error) {
+	// 获取加密配置时需要校验客户端的访问凭证
+	var token string
+	if meta, exist := metadata.FromIncomingContext(ctx); exist {
+		if tokens := meta.Get(utils.HeaderAuthTokenKey); len(tokens) > 0 {
+			token = tokens[0]
+		}
+	}
 	ctx = grpcserver.ConvertContext(ctx)
+	if token != "" {
+		ctx = context.WithValue(ctx, utils.ContextAuthTokenKey, token)
+	}
+	if labels := configFile.GetLabels(); len(labels) > 0 {
+		ctx = context.WithValue(ctx, utils.StringContext("client-labels"), labels)
+	}
 
 	namespace := configFile.GetNamespace().GetValue()
 	group := configFile.GetGroup().GetValue()
@@ -59,15 +82,10 @@ func (g *ConfigGRPCServer) GetConfigFile(ctx context.Context, configFile *api.Cl
 
 // WatchConfigFiles 订阅配置变更
 func (g *ConfigGRPCServer) WatchConfigFiles(ctx context.Context, watchConfigFileRequest *api.ClientWatchConfigFileRequest) (*api.ConfigClientResponse, error) {
-	ctx = grpcserver.ConvertContext(ctx)
+	ctx = withWatchClient(grpcserver.ConvertContext(ctx), watchConfigFileRequest)
 	requestId, _ := ctx.Value(utils.StringContext("request-id")).(string)
 	clientAddress, _ := ctx.Value(utils.StringContext("client-address")).(string)
 
-	clientIP := watchConfigFileRequest.GetClientIp().GetValue()
-	if clientIP == "" {
-		clientIP, _ = ctx.Value(utils.StringContext("client-ip")).(string)
-	}
-
 	commonlog.ConfigScope().Debug("[Config][Client] received client listener request.",
 		zap.String("requestId", requestId),
 		zap.String("client", clientAddress))
@@ -86,10 +104,75 @@ func (g *ConfigGRPCServer) WatchConfigFiles(ctx context.Context, watchConfigFile
 	finishChan := make(chan *api.ConfigClientResponse)
 	defer close(finishChan)
 
-	g.configServer.ConnManager().AddConn(clientId, watchFiles, finishChan)
+	g.configServer.ConnManager().AddConn(ctx, clientId, watchFiles, finishChan)
 
 	// 3. 阻塞等待响应
 	rsp := <-finishChan
 
 	return rsp, nil
 }
+
+// StreamWatchConfigFiles 通过双向流订阅配置变更。客户端每次发送完整的订阅列表，服务端在配置发布时主动推送，
+// 并定时发送心跳。客户端断线重连后重新发送订阅列表，服务端补推断线期间的变更
+func (g *ConfigGRPCServer) StreamWatchConfigFiles(stream api.PolarisConfigGRPC_StreamWatchConfigFilesServer) error {
+	ctx := grpcserver.ConvertContext(stream.Context())
+	requestId, _ := ctx.Value(utils.StringContext("request-id")).(string)
+	clientAddress, _ := ctx.Value(utils.StringContext("client-address")).(string)
+
+	id, _ := uuid.NewUUID()
+	clientId := clientAddress + "@" + id.String()[0:8]
+
+	watcher := g.configServer.NewStreamWatcher(clientId)
+	defer watcher.Close()
+
+	recvErrChan := make(chan error, 1)
+	go func() {
+		for {
+			req, err := stream.Recv()
+			if err != nil {
+				recvErrChan <- err
+				return
+			}
+			commonlog.ConfigScope().Debug("[Config][Client] received client stream watch request.",
+				zap.String("requestId", requestId),
+				zap.String("client", clientAddress),
+				zap.Int("files", len(req.GetWatchFiles())))
+			watcher.Subscribe(withWatchClient(ctx, req), req.GetWatchFiles())
+		}
+	}()
+
+	ticker := time.NewTicker(streamHeartbeatInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case err := <-recvErrChan:
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		case <-watcher.Overflow():
+			return status.Error(codes.ResourceExhausted, "too many config changes to push, please resubscribe")
+		case rsp := <-watcher.Responses():
+			if err := stream.Send(rsp); err != nil {
+				return err
+			}
+		case <-ticker.C:
+			if err := stream.Send(api.NewConfigClientResponse(api.DataNoChange, nil)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// withWatchClient 记录订阅请求中客户端的 IP 和标签，用于匹配灰度规则
+func withWatchClient(ctx context.Context, req *api.ClientWatchConfigFileRequest) context.Context {
+	clientIP := req.GetClientIp().GetValue()
+	if clientIP == "" {
+		clientIP, _ = ctx.Value(utils.StringContext("client-ip")).(string)
+	}
+	ctx = context.WithValue(ctx, utils.StringContext("client-ip"), clientIP)
+	if labels := req.GetLabels(); len(labels) > 0 {
+		ctx = context.WithValue(ctx, utils.StringContext("client-labels"), labels)
+	}
+	return ctx
+}
@@ -119,7 +119,7 @@ func (g *ConfigGRPCServer) allowAccess(method string) bool {
 }
 
 func getConfigClientOpenMethod(protocol string) (map[string]bool, error) {
-	openMethods := []string{"GetConfigFile", "WatchConfigFiles"}
+	openMethods := []string{"GetConfigFile", "WatchConfigFiles", "StreamWatchConfigFiles"}
 
 	openMethod := make(map[string]bool)
 
@@ -22,17 +22,23 @@ import (
 	"fmt"
 	"io"
 	"strings"
+	"sync"
 
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/peer"
 
 	"github.com/polarismesh/polaris-server/apiserver/grpcserver"
 	api "github.com/polarismesh/polaris-server/common/api/v1"
 	"github.com/polarismesh/polaris-server/common/log"
 	"github.com/polarismesh/polaris-server/common/utils"
+	"github.com/polarismesh/polaris-server/service"
 )
 
+// instancePushMetadataKey 客户端在 Discover 流的 metadata 中携带该字段开启服务实例变更的推送
+const instancePushMetadataKey = "instance-push"
+
 // ReportClient 客户端上报
 func (g *GRPCServer) ReportClient(ctx context.Context, in *api.Client) (*api.Response, error) {
 	return g.namingServer.ReportClient(grpcserver.ConvertContext(ctx), in), nil
@@ -77,6 +83,16 @@ func (g *GRPCServer) Discover(server api.PolarisGRPC_DiscoverServer) error {
 	userAgent, _ := ctx.Value(utils.StringContext("user-agent")).(string)
 	method, _ := grpc.MethodFromServerStream(server)
 
+	// 推送和请求的响应在不同的协程中发送，需要串行发送
+	stream := &discoverStream{server: server}
+	var subscriber *service.InstanceSubscriber
+	if instancePushEnabled(server.Context()) {
+		if subscriber = g.namingServer.NewInstanceSubscriber(); subscriber != nil {
+			defer subscriber.Close()
+			go g.pushInstances(ctx, server.Context(), stream, subscriber, clientAddress)
+		}
+	}
+
 	for {
 		in, err := server.Recv()
 		if err != nil {
@@ -97,7 +113,7 @@ func (g *GRPCServer) Discover(server api.PolarisGRPC_DiscoverServer) error {
 		// 是否允许访问
 		if ok := g.allowAccess(method); !ok {
 			resp := api.NewDiscoverResponse(api.ClientAPINotOpen)
-			if sendErr := server.Send(resp); sendErr != nil {
+			if sendErr := stream.Send(resp); sendErr != nil {
 				return sendErr
 			}
 			continue
@@ -106,7 +122,7 @@ func (g *GRPCServer) Discover(server api.PolarisGRPC_DiscoverServer) error {
 		// stream模式，需要对每个包进行检测
 		if code := g.enterRateLimit(clientIP, method); code != api.ExecuteSuccess {
 			resp := api.NewDiscoverResponse(code)
-			if err = server.Send(resp); err != nil {
+			if err = stream.Send(resp); err != nil {
 				return err
 			}
 			continue
@@ -115,7 +131,14 @@ func (g *GRPCServer) Discover(server api.PolarisGRPC_DiscoverServer) error {
 		var out *api.DiscoverResponse
 		switch in.Type {
 		case api.DiscoverRequest_INSTANCE:
-			out = g.namingServer.ServiceInstancesCache(ctx, in.Service)
+			if in.GetDelta() {
+				out = g.namingServer.ServiceInstancesDeltaCache(ctx, in.Service)
+			} else {
+				out = g.namingServer.ServiceInstancesCache(ctx, in.Service)
+			}
+			if subscriber != nil {
+				subscribeInstances(subscriber, in.Service, out)
+			}
 		case api.DiscoverRequest_ROUTING:
 			out = g.namingServer.GetRoutingConfigWithCache(ctx, in.Service)
 		case api.DiscoverRequest_RATE_LIMIT:
@@ -128,13 +151,68 @@ func (g *GRPCServer) Discover(server api.PolarisGRPC_DiscoverServer) error {
 			out = api.NewDiscoverRoutingResponse(api.InvalidDiscoverResource, in.Service)
 		}
 
-		err = server.Send(out)
+		err = stream.Send(out)
 		if err != nil {
 			return err
 		}
 	}
 }
 
+// discoverStream 串行发送 Discover 流的响应
+type discoverStream struct {
+	mutex  sync.Mutex
+	server api.PolarisGRPC_DiscoverServer
+}
+
+// Send 发送响应
+func (s *discoverStream) Send(resp *api.DiscoverResponse) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.server.Send(resp)
+}
+
+// pushInstances 订阅的服务实例发生变化时主动推送，流关闭后退出
+func (g *GRPCServer) pushInstances(ctx, streamCtx context.Context, stream *discoverStream,
+	subscriber *service.InstanceSubscriber, clientAddress string) {
+	for {
+		select {
+		case <-streamCtx.Done():
+			return
+		case <-subscriber.Notify():
+			for _, resp := range subscriber.Changes(ctx) {
+				if err := stream.Send(resp); err != nil {
+					log.Warn("push grpc discover instances failed",
+						zap.String("client-address", clientAddress),
+						zap.String("service", resp.GetService().GetName().GetValue()),
+						zap.String("namespace", resp.GetService().GetNamespace().GetValue()),
+						zap.Error(err))
+					return
+				}
+			}
+		}
+	}
+}
+
+// subscribeInstances 记录客户端请求的服务以及返回给客户端的版本号
+func subscribeInstances(subscriber *service.InstanceSubscriber, req *api.Service, out *api.DiscoverResponse) {
+	switch out.GetCode().GetValue() {
+	case api.ExecuteSuccess:
+		subscriber.Subscribe(req, out.GetService().GetRevision().GetValue())
+	case api.DataNoChange:
+		subscriber.Subscribe(req, req.GetRevision().GetValue())
+	}
+}
+
+// instancePushEnabled 客户端是否开启了服务实例变更的推送
+func instancePushEnabled(ctx context.Context) bool {
+	meta, exist := metadata.FromIncomingContext(ctx)
+	if !exist {
+		return false
+	}
+	values := meta.Get(instancePushMetadataKey)
+	return len(values) > 0 && values[0] == "true"
+}
+
 // Heartbeat 上报心跳
 func (g *GRPCServer) Heartbeat(ctx context.Context, in *api.Instance) (*api.Response, error) {
 	return g.healthCheckServer.Report(grpcserver.ConvertContext(ctx), in), nil
@@ -18,13 +18,17 @@
 package httpserver
 
 import (
+	"context"
+	"net"
 	"strconv"
+	"strings"
 
 	"github.com/emicklei/go-restful"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
 
 	api "github.com/polarismesh/polaris-server/common/api/v1"
+	"github.com/polarismesh/polaris-server/common/utils"
 )
 
 func (h *HTTPServer) getConfigFile(req *restful.Request, rsp *restful.Response) {
@@ -41,7 +45,9 @@ func (h *HTTPServer) getConfigFile(req *restful.Request, rsp *restful.Response)
 		handler.WriteHeaderAndProto(api.NewConfigClientResponseWithMessage(api.BadRequest, "version must be number"))
 	}
 
-	response := h.configServer.Service().GetConfigFileForClient(handler.ParseHeaderContext(), namespace, group, fileName, clientVersion)
+	ctx := withClientInfo(handler.ParseHeaderContext(), "", req.Request.RemoteAddr,
+		parseClientLabels(handler.QueryParameter("labels")))
+	response := h.configServer.Service().GetConfigFileForClient(ctx, namespace, group, fileName, clientVersion)
 
 	var version uint64 = 0
 	if response.ConfigFile != nil {
@@ -79,8 +85,10 @@ func (h *HTTPServer) watchConfigFile(req *restful.Request, rsp *restful.Response
 	}
 
 	watchFiles := watchConfigFileRequest.WatchFiles
+	ctx := withClientInfo(handler.ParseHeaderContext(), watchConfigFileRequest.GetClientIp().GetValue(),
+		clientAddr, watchConfigFileRequest.GetLabels())
 	// 2. 检查客户端是否有版本落后
-	response := h.configServer.Service().CheckClientConfigFileByVersion(handler.ParseHeaderContext(), watchFiles)
+	response := h.configServer.Service().CheckClientConfigFileByVersion(ctx, watchFiles)
 	if response.Code.GetValue() != api.DataNoChange {
 		handler.WriteHeaderAndProto(response)
 		return
@@ -93,10 +101,41 @@ func (h *HTTPServer) watchConfigFile(req *restful.Request, rsp *restful.Response
 	finishChan := make(chan *api.ConfigClientResponse)
 	defer close(finishChan)
 
-	h.configServer.ConnManager().AddConn(clientId, watchFiles, finishChan)
+	h.configServer.ConnManager().AddConn(ctx, clientId, watchFiles, finishChan)
 
 	// 阻塞等待响应
 	watchRsp := <-finishChan
 
 	handler.WriteHeaderAndProto(watchRsp)
 }
+
+// withClientInfo 在 ctx 中记录客户端的 IP 和标签，用于匹配灰度发布规则。客户端上报的 IP 优先
+func withClientInfo(ctx context.Context, clientIP, remoteAddr string, labels map[string]string) context.Context {
+	if clientIP == "" {
+		clientIP = remoteAddr
+		if host, _, err := net.SplitHostPort(remoteAddr); err == nil {
+			clientIP = host
+		}
+	}
+	ctx = context.WithValue(ctx, utils.StringContext("client-ip"), clientIP)
+	if len(labels) > 0 {
+		ctx = context.WithValue(ctx, utils.StringContext("client-labels"), labels)
+	}
+	return ctx
+}
+
+// parseClientLabels 解析 k1:v1,k2:v2 格式的客户端标签
+func parseClientLabels(value string) map[string]string {
+	if value == "" {
+		return nil
+	}
+	labels := make(map[string]string)
+	for _, item := range strings.Split(value, ",") {
+		kv := strings.SplitN(item, ":", 2)
+		if len(kv) != 2 || kv[0] == "" {
+			continue
+		}
+		labels[strings.TrimSpace(kv[0])] = strings.TrimSpace(kv[1])
+	}
+	return labels
+}
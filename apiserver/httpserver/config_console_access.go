@@ -18,7 +18,15 @@
 package httpserver
 
 import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/emicklei/go-restful"
 	"github.com/golang/protobuf/proto"
@@ -28,6 +36,13 @@ import (
 	"github.com/polarismesh/polaris-server/common/utils"
 )
 
+const (
+	// configArchiveContentType 配置导入导出压缩包的类型
+	configArchiveContentType = "application/zip"
+	// configArchiveMaxSize 导入的压缩包大小限制
+	configArchiveMaxSize = 32 * 1024 * 1024
+)
+
 // CreateConfigFileGroup 创建配置文件组
 func (h *HTTPServer) CreateConfigFileGroup(req *restful.Request, rsp *restful.Response) {
 	handler := &Handler{req, rsp}
@@ -225,6 +240,68 @@ func (h *HTTPServer) GetConfigFileRelease(req *restful.Request, rsp *restful.Res
 	handler.WriteHeaderAndProto(response)
 }
 
+// GrayPublishConfigFile 灰度发布配置文件，只有命中灰度规则的客户端能获取到发布内容
+func (h *HTTPServer) GrayPublishConfigFile(req *restful.Request, rsp *restful.Response) {
+	handler := &Handler{req, rsp}
+
+	configFile := &api.ConfigFileRelease{}
+	ctx, err := handler.Parse(configFile)
+	requestId := ctx.Value(utils.StringContext("request-id"))
+
+	if err != nil {
+		configLog.Error("[Config][HttpServer] parse config file gray release from request error.",
+			zap.String("requestId", requestId.(string)),
+			zap.String("error", err.Error()))
+		handler.WriteHeaderAndProto(api.NewConfigFileReleaseResponseWithMessage(api.ParseException, err.Error()))
+		return
+	}
+
+	handler.WriteHeaderAndProto(h.configServer.Service().GrayPublishConfigFile(ctx, configFile))
+}
+
+// GetConfigFileGrayRelease 获取配置文件生效中的灰度发布
+func (h *HTTPServer) GetConfigFileGrayRelease(req *restful.Request, rsp *restful.Response) {
+	handler := &Handler{req, rsp}
+
+	namespace := handler.QueryParameter("namespace")
+	group := handler.QueryParameter("group")
+	name := handler.QueryParameter("name")
+
+	response := h.configServer.Service().GetConfigFileGrayRelease(handler.ParseHeaderContext(), namespace, group, name)
+
+	handler.WriteHeaderAndProto(response)
+}
+
+// PromoteConfigFileGrayRelease 灰度发布转为全量发布
+func (h *HTTPServer) PromoteConfigFileGrayRelease(req *restful.Request, rsp *restful.Response) {
+	handler := &Handler{req, rsp}
+
+	namespace := handler.QueryParameter("namespace")
+	group := handler.QueryParameter("group")
+	name := handler.QueryParameter("name")
+	operator := handler.QueryParameter("operator")
+
+	response := h.configServer.Service().PromoteConfigFileGrayRelease(handler.ParseHeaderContext(),
+		namespace, group, name, operator)
+
+	handler.WriteHeaderAndProto(response)
+}
+
+// AbortConfigFileGrayRelease 终止灰度发布，所有客户端回到正式发布的内容
+func (h *HTTPServer) AbortConfigFileGrayRelease(req *restful.Request, rsp *restful.Response) {
+	handler := &Handler{req, rsp}
+
+	namespace := handler.QueryParameter("namespace")
+	group := handler.QueryParameter("group")
+	name := handler.QueryParameter("name")
+	operator := handler.QueryParameter("operator")
+
+	response := h.configServer.Service().AbortConfigFileGrayRelease(handler.ParseHeaderContext(),
+		namespace, group, name, operator)
+
+	handler.WriteHeaderAndProto(response)
+}
+
 // GetConfigFileReleaseHistory 获取配置文件发布历史，按照发布时间倒序排序
 func (h *HTTPServer) GetConfigFileReleaseHistory(req *restful.Request, rsp *restful.Response) {
 	handler := &Handler{req, rsp}
@@ -247,3 +324,224 @@ func (h *HTTPServer) GetConfigFileReleaseHistory(req *restful.Request, rsp *rest
 
 	handler.WriteHeaderAndProto(response)
 }
+
+// DiffConfigFileReleaseHistory 比较两条发布历史记录的内容差异
+func (h *HTTPServer) DiffConfigFileReleaseHistory(req *restful.Request, rsp *restful.Response) {
+	handler := &Handler{req, rsp}
+
+	fromId, err := strconv.ParseUint(handler.QueryParameter("fromId"), 10, 64)
+	if err != nil {
+		handler.WriteHeaderAndProto(api.NewConfigFileResponseWithMessage(api.InvalidParameter, "fromId must be number"))
+		return
+	}
+	toId, err := strconv.ParseUint(handler.QueryParameter("toId"), 10, 64)
+	if err != nil {
+		handler.WriteHeaderAndProto(api.NewConfigFileResponseWithMessage(api.InvalidParameter, "toId must be number"))
+		return
+	}
+
+	response := h.configServer.Service().DiffConfigFileReleaseHistory(handler.ParseHeaderContext(), fromId, toId)
+
+	handler.WriteHeaderAndProto(response)
+}
+
+// DiffConfigFileDraft 比较配置文件当前内容和生效中发布内容的差异
+func (h *HTTPServer) DiffConfigFileDraft(req *restful.Request, rsp *restful.Response) {
+	handler := &Handler{req, rsp}
+
+	namespace := handler.QueryParameter("namespace")
+	group := handler.QueryParameter("group")
+	name := handler.QueryParameter("name")
+
+	response := h.configServer.Service().DiffConfigFileDraft(handler.ParseHeaderContext(), namespace, group, name)
+
+	handler.WriteHeaderAndProto(response)
+}
+
+// RollbackConfigFile 回滚配置文件到指定的发布历史，历史内容会作为新版本重新发布
+func (h *HTTPServer) RollbackConfigFile(req *restful.Request, rsp *restful.Response) {
+	handler := &Handler{req, rsp}
+
+	namespace := handler.QueryParameter("namespace")
+	group := handler.QueryParameter("group")
+	name := handler.QueryParameter("name")
+	historyId, err := strconv.ParseUint(handler.QueryParameter("historyId"), 10, 64)
+	if err != nil {
+		handler.WriteHeaderAndProto(api.NewConfigFileResponseWithMessage(api.InvalidParameter, "historyId must be number"))
+		return
+	}
+
+	response := h.configServer.Service().RollbackConfigFile(handler.ParseHeaderContext(), namespace, group, name, historyId)
+
+	handler.WriteHeaderAndProto(response)
+}
+
+// QueryConfigFileListeners 查询所有节点上订阅配置文件的客户端，以及客户端持有的配置版本
+func (h *HTTPServer) QueryConfigFileListeners(req *restful.Request, rsp *restful.Response) {
+	handler := &Handler{req, rsp}
+
+	namespace := handler.QueryParameter("namespace")
+	group := handler.QueryParameter("group")
+	name := handler.QueryParameter("name")
+	offset, _ := strconv.ParseUint(handler.QueryParameter("offset"), 10, 64)
+	limit, _ := strconv.ParseUint(handler.QueryParameter("limit"), 10, 64)
+
+	response := h.configServer.Service().QueryConfigFileListeners(handler.ParseHeaderContext(),
+		namespace, group, name, uint32(offset), uint32(limit))
+
+	handler.WriteHeaderAndProto(response)
+}
+
+// CreateConfigFilePublishRequest 创建配置发布申请，approveUsers 和 approveGroups 为审批人，planTime 为空时审批通过后手动发布
+func (h *HTTPServer) CreateConfigFilePublishRequest(req *restful.Request, rsp *restful.Response) {
+	h.operateConfigFilePublishRequest(req, rsp, h.configServer.Service().CreateConfigFilePublishRequest)
+}
+
+// SubmitConfigFilePublishRequest 提交配置发布申请等待审批
+func (h *HTTPServer) SubmitConfigFilePublishRequest(req *restful.Request, rsp *restful.Response) {
+	h.operateConfigFilePublishRequest(req, rsp, h.configServer.Service().SubmitConfigFilePublishRequest)
+}
+
+// ApproveConfigFilePublishRequest 审批通过配置发布申请
+func (h *HTTPServer) ApproveConfigFilePublishRequest(req *restful.Request, rsp *restful.Response) {
+	h.operateConfigFilePublishRequest(req, rsp, h.configServer.Service().ApproveConfigFilePublishRequest)
+}
+
+// RejectConfigFilePublishRequest 驳回配置发布申请
+func (h *HTTPServer) RejectConfigFilePublishRequest(req *restful.Request, rsp *restful.Response) {
+	h.operateConfigFilePublishRequest(req, rsp, h.configServer.Service().RejectConfigFilePublishRequest)
+}
+
+// ScheduleConfigFilePublishRequest 设置配置发布申请的计划发布时间
+func (h *HTTPServer) ScheduleConfigFilePublishRequest(req *restful.Request, rsp *restful.Response) {
+	h.operateConfigFilePublishRequest(req, rsp, h.configServer.Service().ScheduleConfigFilePublishRequest)
+}
+
+// ExecuteConfigFilePublishRequest 立即发布审批通过的配置发布申请
+func (h *HTTPServer) ExecuteConfigFilePublishRequest(req *restful.Request, rsp *restful.Response) {
+	h.operateConfigFilePublishRequest(req, rsp, h.configServer.Service().ExecuteConfigFilePublishRequest)
+}
+
+// CancelConfigFilePublishRequest 取消配置发布申请
+func (h *HTTPServer) CancelConfigFilePublishRequest(req *restful.Request, rsp *restful.Response) {
+	h.operateConfigFilePublishRequest(req, rsp, h.configServer.Service().CancelConfigFilePublishRequest)
+}
+
+func (h *HTTPServer) operateConfigFilePublishRequest(req *restful.Request, rsp *restful.Response,
+	operate func(ctx context.Context, publishRequest *api.ConfigFilePublishRequest) *api.ConfigResponse) {
+	handler := &Handler{req, rsp}
+
+	publishRequest := &api.ConfigFilePublishRequest{}
+	ctx, err := handler.Parse(publishRequest)
+	requestId := ctx.Value(utils.StringContext("request-id"))
+
+	if err != nil {
+		configLog.Error("[Config][HttpServer] parse config file publish request from request error.",
+			zap.String("requestId", requestId.(string)),
+			zap.String("error", err.Error()))
+		handler.WriteHeaderAndProto(api.NewConfigFileResponseWithMessage(api.ParseException, err.Error()))
+		return
+	}
+
+	handler.WriteHeaderAndProto(operate(ctx, publishRequest))
+}
+
+// GetConfigFilePublishRequest 获取配置发布申请
+func (h *HTTPServer) GetConfigFilePublishRequest(req *restful.Request, rsp *restful.Response) {
+	handler := &Handler{req, rsp}
+
+	id := handler.QueryParameter("id")
+
+	response := h.configServer.Service().GetConfigFilePublishRequest(handler.ParseHeaderContext(), id)
+
+	handler.WriteHeaderAndProto(response)
+}
+
+// QueryConfigFilePublishRequests 查询配置发布申请，按照创建时间倒序
+func (h *HTTPServer) QueryConfigFilePublishRequests(req *restful.Request, rsp *restful.Response) {
+	handler := &Handler{req, rsp}
+
+	namespace := handler.QueryParameter("namespace")
+	group := handler.QueryParameter("group")
+	name := handler.QueryParameter("name")
+	status := handler.QueryParameter("status")
+	offset, _ := strconv.ParseUint(handler.QueryParameter("offset"), 10, 64)
+	limit, _ := strconv.ParseUint(handler.QueryParameter("limit"), 10, 64)
+
+	response := h.configServer.Service().QueryConfigFilePublishRequests(handler.ParseHeaderContext(),
+		namespace, group, name, status, uint32(offset), uint32(limit))
+
+	handler.WriteHeaderAndProto(response)
+}
+
+// ExportConfigFiles 把配置文件组导出为 zip 压缩包，包含配置内容以及记录注释、格式、标签的 manifest.json
+// query参数：namespace，必须；group，可选，为空时导出命名空间下的全部配置文件
+func (h *HTTPServer) ExportConfigFiles(req *restful.Request, rsp *restful.Response) {
+	handler := &Handler{req, rsp}
+
+	namespace := handler.QueryParameter("namespace")
+	group := handler.QueryParameter("group")
+
+	// 先写入内存，避免导出失败时返回不完整的文件
+	buf := &bytes.Buffer{}
+	response := h.configServer.Service().ExportConfigFiles(handler.ParseHeaderContext(), namespace, group, buf)
+	if response.Code.GetValue() != api.ExecuteSuccess {
+		handler.WriteHeaderAndProto(response)
+		return
+	}
+
+	name := namespace
+	if group != "" {
+		name += "-" + group
+	}
+	filename := fmt.Sprintf("polaris-config-%s-%s.zip", name, time.Now().Format("20060102150405"))
+	rsp.AddHeader("Content-Type", configArchiveContentType)
+	rsp.AddHeader("Content-Disposition", fmt.Sprintf("attachment; filename=%s", filename))
+	rsp.WriteHeader(http.StatusOK)
+	_, _ = rsp.Write(buf.Bytes())
+}
+
+// ImportConfigFiles 导入 ExportConfigFiles 导出的压缩包，请求 body 为压缩包或者表单的 file 字段
+// query参数：namespace，可选，为空时导入到压缩包记录的命名空间；conflictPolicy，可选，skip、overwrite 或 fail，默认 fail；
+// publish，可选，为 true 时导入后发布；operator，可选，操作人
+func (h *HTTPServer) ImportConfigFiles(req *restful.Request, rsp *restful.Response) {
+	handler := &Handler{req, rsp}
+
+	namespace := handler.QueryParameter("namespace")
+	conflictPolicy := handler.QueryParameter("conflictPolicy")
+	publish, _ := strconv.ParseBool(handler.QueryParameter("publish"))
+	operator := handler.QueryParameter("operator")
+
+	archive, err := readConfigArchive(req)
+	if err != nil {
+		handler.WriteHeaderAndProto(api.NewConfigBatchWriteResponseWithMessage(api.ParseException, err.Error()))
+		return
+	}
+
+	response := h.configServer.Service().ImportConfigFiles(handler.ParseHeaderContext(), namespace, archive,
+		conflictPolicy, publish, operator)
+
+	handler.WriteHeaderAndProto(response)
+}
+
+// readConfigArchive 读取请求中的压缩包，超过大小限制时返回错误
+func readConfigArchive(req *restful.Request) ([]byte, error) {
+	var body io.Reader = req.Request.Body
+	if strings.HasPrefix(req.HeaderParameter("Content-Type"), "multipart/form-data") {
+		file, _, err := req.Request.FormFile("file")
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+		body = file
+	}
+
+	archive, err := ioutil.ReadAll(io.LimitReader(body, configArchiveMaxSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(archive) > configArchiveMaxSize {
+		return nil, fmt.Errorf("archive size exceeds %d bytes", configArchiveMaxSize)
+	}
+	return archive, nil
+}
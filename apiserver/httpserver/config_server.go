@@ -77,8 +77,38 @@ func (h *HTTPServer) bindConfigConsoleEndpoint(ws *restful.WebService) {
 	ws.Route(ws.POST("/configfiles/release").To(h.PublishConfigFile))
 	ws.Route(ws.GET("/configfiles/release").To(h.GetConfigFileRelease))
 
+	// 配置文件灰度发布
+	ws.Route(ws.POST("/configfiles/release/gray").To(h.GrayPublishConfigFile))
+	ws.Route(ws.GET("/configfiles/release/gray").To(h.GetConfigFileGrayRelease))
+	ws.Route(ws.PUT("/configfiles/release/gray/promote").To(h.PromoteConfigFileGrayRelease))
+	ws.Route(ws.DELETE("/configfiles/release/gray").To(h.AbortConfigFileGrayRelease))
+
 	// 配置文件发布历史
 	ws.Route(ws.GET("/configfiles/releasehistory").To(h.GetConfigFileReleaseHistory))
+	ws.Route(ws.GET("/configfiles/releasehistory/diff").To(h.DiffConfigFileReleaseHistory))
+	ws.Route(ws.GET("/configfiles/diff").To(h.DiffConfigFileDraft))
+
+	// 配置文件回滚
+	ws.Route(ws.POST("/configfiles/rollback").To(h.RollbackConfigFile))
+
+	// 配置文件订阅者
+	ws.Route(ws.GET("/configfiles/listeners").To(h.QueryConfigFileListeners))
+
+	// 配置发布申请
+	ws.Route(ws.POST("/configfiles/publishrequests").To(h.CreateConfigFilePublishRequest))
+	ws.Route(ws.GET("/configfiles/publishrequests").To(h.QueryConfigFilePublishRequests))
+	ws.Route(ws.GET("/configfiles/publishrequests/detail").To(h.GetConfigFilePublishRequest))
+	ws.Route(ws.PUT("/configfiles/publishrequests/submit").To(h.SubmitConfigFilePublishRequest))
+	ws.Route(ws.PUT("/configfiles/publishrequests/approve").To(h.ApproveConfigFilePublishRequest))
+	ws.Route(ws.PUT("/configfiles/publishrequests/reject").To(h.RejectConfigFilePublishRequest))
+	ws.Route(ws.PUT("/configfiles/publishrequests/schedule").To(h.ScheduleConfigFilePublishRequest))
+	ws.Route(ws.PUT("/configfiles/publishrequests/execute").To(h.ExecuteConfigFilePublishRequest))
+	ws.Route(ws.PUT("/configfiles/publishrequests/cancel").To(h.CancelConfigFilePublishRequest))
+
+	// 配置文件导入导出
+	ws.Route(ws.GET("/configfiles/export").Produces(configArchiveContentType, restful.MIME_JSON).To(h.ExportConfigFiles))
+	ws.Route(ws.POST("/configfiles/import").
+		Consumes(configArchiveContentType, "application/octet-stream", "multipart/form-data").To(h.ImportConfigFiles))
 
 }
 
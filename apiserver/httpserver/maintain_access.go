@@ -18,11 +18,15 @@
 package httpserver
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
 	"io/ioutil"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/emicklei/go-restful"
 	api "github.com/polarismesh/polaris-server/common/api/v1"
@@ -43,9 +47,14 @@ func (h *HTTPServer) GetMaintainAccessServer() *restful.WebService {
 	ws.Route(ws.GET("/instance/heartbeat").To(h.GetLastHeartbeat))
 	ws.Route(ws.GET("/log/outputlevel").To(h.GetLogOutputLevel))
 	ws.Route(ws.PUT("/log/outputlevel").To(h.SetLogOutputLevel))
+	ws.Route(ws.GET("/backup").Produces(backupContentType).To(h.Backup))
+	ws.Route(ws.POST("/restore").Consumes(backupContentType, "application/octet-stream").To(h.Restore))
 	return ws
 }
 
+// backupContentType 备份文件的类型
+const backupContentType = "application/gzip"
+
 // GetServerConnections 查看server的连接数
 // query参数：protocol，必须，查看指定协议server
 //           host，可选，查看指定host
@@ -212,3 +221,50 @@ func initContext(req *restful.Request) context.Context {
 
 	return ctx
 }
+
+// Backup 导出全部数据的备份文件
+// query参数：namespaces，可选，逗号分隔的命名空间列表，只导出指定命名空间下的资源
+func (h *HTTPServer) Backup(req *restful.Request, rsp *restful.Response) {
+	ctx := initContext(req)
+	params := utils.ParseQueryParams(req)
+
+	// 先写入内存，避免导出失败时返回不完整的文件
+	buf := &bytes.Buffer{}
+	if err := h.maintainServer.Backup(ctx, buf, parseNamespaces(params["namespaces"])); err != nil {
+		_ = rsp.WriteErrorString(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	filename := fmt.Sprintf("polaris-backup-%s.tar.gz", time.Now().Format("20060102150405"))
+	rsp.AddHeader("Content-Type", backupContentType)
+	rsp.AddHeader("Content-Disposition", fmt.Sprintf("attachment; filename=%s", filename))
+	rsp.WriteHeader(http.StatusOK)
+	_, _ = rsp.Write(buf.Bytes())
+}
+
+// Restore 从备份文件中还原数据，请求body为备份文件
+// query参数：namespaces，可选，逗号分隔的命名空间列表，只还原指定命名空间下的资源
+// dry_run，可选，为true时只统计不写入
+func (h *HTTPServer) Restore(req *restful.Request, rsp *restful.Response) {
+	ctx := initContext(req)
+	params := utils.ParseQueryParams(req)
+	dryRun, _ := strconv.ParseBool(params["dry_run"])
+
+	report, err := h.maintainServer.Restore(ctx, req.Request.Body, parseNamespaces(params["namespaces"]), dryRun)
+	if err != nil {
+		_ = rsp.WriteErrorString(http.StatusBadRequest, err.Error())
+		return
+	}
+	_ = rsp.WriteAsJson(report)
+}
+
+// parseNamespaces 解析逗号分隔的命名空间列表
+func parseNamespaces(value string) []string {
+	var namespaces []string
+	for _, ns := range strings.Split(value, ",") {
+		if ns = strings.TrimSpace(ns); ns != "" {
+			namespaces = append(namespaces, ns)
+		}
+	}
+	return namespaces
+}
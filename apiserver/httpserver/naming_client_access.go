@@ -138,7 +138,11 @@ func (h *HTTPServer) Discover(req *restful.Request, rsp *restful.Response) {
 	var ret *api.DiscoverResponse
 	switch discoverRequest.Type {
 	case api.DiscoverRequest_INSTANCE:
-		ret = h.namingServer.ServiceInstancesCache(ctx, discoverRequest.Service)
+		if discoverRequest.GetDelta() {
+			ret = h.namingServer.ServiceInstancesDeltaCache(ctx, discoverRequest.Service)
+		} else {
+			ret = h.namingServer.ServiceInstancesCache(ctx, discoverRequest.Service)
+		}
 	case api.DiscoverRequest_ROUTING:
 		ret = h.namingServer.GetRoutingConfigWithCache(ctx, discoverRequest.Service)
 	case api.DiscoverRequest_RATE_LIMIT:
@@ -0,0 +1,329 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package nacosserver
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/emicklei/go-restful"
+	"go.uber.org/zap"
+
+	api "github.com/polarismesh/polaris-server/common/api/v1"
+	"github.com/polarismesh/polaris-server/common/model"
+	"github.com/polarismesh/polaris-server/common/utils"
+	utils2 "github.com/polarismesh/polaris-server/config/utils"
+)
+
+const (
+	configListenerPath = "/nacos/v1/cs/configs/listener"
+
+	listeningConfigsParam     = "Listening-Configs"
+	longPollingTimeoutHeader  = "Long-Pulling-Timeout"
+	longPollingNoHangUpHeader = "Long-Pulling-Timeout-No-Hangup"
+	// longPollingAdvance 服务端提前返回监听请求，避免客户端读超时
+	longPollingAdvance = 500 * time.Millisecond
+
+	mimeTextPlain = "text/plain;charset=UTF-8"
+)
+
+// GetNacosConfigAccessServer Nacos 配置客户端使用的接口
+func (h *NacosServer) GetNacosConfigAccessServer() *restful.WebService {
+	ws := new(restful.WebService)
+	ws.Path("/nacos/v1/cs")
+
+	ws.Route(ws.GET("/configs").To(h.getConfig))
+	ws.Route(ws.POST("/configs").To(h.publishConfig))
+	ws.Route(ws.DELETE("/configs").To(h.deleteConfig))
+	ws.Route(ws.POST("/configs/listener").To(h.listenConfigs))
+	return ws
+}
+
+// getConfig 获取已发布的配置内容
+func (h *NacosServer) getConfig(req *restful.Request, rsp *restful.Response) {
+	key, ok := parseConfigKey(req)
+	if !ok {
+		writeText(rsp, http.StatusBadRequest, "dataId is required")
+		return
+	}
+
+	fileRsp := h.configServer.Service().GetConfigFileForClient(h.configContext(req), h.toNamespace(key.Tenant),
+		key.Group, key.DataID, 0)
+	switch fileRsp.GetCode().GetValue() {
+	case api.ExecuteSuccess:
+	case api.NotFoundResource:
+		writeText(rsp, http.StatusNotFound, "config data not exist")
+		return
+	default:
+		writePolarisError(rsp, fileRsp.GetCode().GetValue(), fileRsp.GetInfo().GetValue())
+		return
+	}
+
+	content := fileRsp.GetConfigFile().GetContent().GetValue()
+	rsp.AddHeader("Content-MD5", utils2.CalMd5(content))
+	writeText(rsp, http.StatusOK, content)
+}
+
+// publishConfig 创建或者更新配置并立即发布
+func (h *NacosServer) publishConfig(req *restful.Request, rsp *restful.Response) {
+	key, ok := parseConfigKey(req)
+	if !ok {
+		writeText(rsp, http.StatusBadRequest, "dataId is required")
+		return
+	}
+
+	ctx := h.configContext(req)
+	namespace := h.toNamespace(key.Tenant)
+	if !h.checkConfigWrite(ctx, rsp, namespace, key.Group, model.Modify, "NacosPublishConfig") {
+		return
+	}
+	operator := utils.ParseOperator(ctx)
+	configFile := &api.ConfigFile{
+		Namespace: utils.NewStringValue(namespace),
+		Group:     utils.NewStringValue(key.Group),
+		Name:      utils.NewStringValue(key.DataID),
+		Content:   utils.NewStringValue(req.Request.FormValue("content")),
+		Format:    utils.NewStringValue(toFileFormat(req.Request.FormValue("type"))),
+		Comment:   utils.NewStringValue(req.Request.FormValue("desc")),
+		CreateBy:  utils.NewStringValue(operator),
+		ModifyBy:  utils.NewStringValue(operator),
+	}
+
+	service := h.configServer.Service()
+	fileRsp := service.GetConfigFileBaseInfo(ctx, namespace, key.Group, key.DataID)
+	switch fileRsp.GetCode().GetValue() {
+	case api.ExecuteSuccess:
+		// 不指定配置类型时保留原有的格式
+		if req.Request.FormValue("type") == "" {
+			configFile.Format = fileRsp.GetConfigFile().GetFormat()
+		}
+		fileRsp = service.UpdateConfigFile(ctx, configFile)
+	case api.NotFoundResource:
+		fileRsp = service.CreateConfigFile(ctx, configFile)
+	}
+	if fileRsp.GetCode().GetValue() != api.ExecuteSuccess {
+		writePolarisError(rsp, fileRsp.GetCode().GetValue(), fileRsp.GetInfo().GetValue())
+		return
+	}
+
+	releaseRsp := service.PublishConfigFile(ctx, &api.ConfigFileRelease{
+		Namespace: configFile.Namespace,
+		Group:     configFile.Group,
+		FileName:  configFile.Name,
+		CreateBy:  configFile.CreateBy,
+	})
+	if releaseRsp.GetCode().GetValue() != api.ExecuteSuccess {
+		writePolarisError(rsp, releaseRsp.GetCode().GetValue(), releaseRsp.GetInfo().GetValue())
+		return
+	}
+	writeText(rsp, http.StatusOK, "true")
+}
+
+// deleteConfig 删除配置以及配置的发布
+func (h *NacosServer) deleteConfig(req *restful.Request, rsp *restful.Response) {
+	key, ok := parseConfigKey(req)
+	if !ok {
+		writeText(rsp, http.StatusBadRequest, "dataId is required")
+		return
+	}
+
+	ctx := h.configContext(req)
+	namespace := h.toNamespace(key.Tenant)
+	if !h.checkConfigWrite(ctx, rsp, namespace, key.Group, model.Delete, "NacosDeleteConfig") {
+		return
+	}
+	fileRsp := h.configServer.Service().DeleteConfigFile(ctx, namespace, key.Group, key.DataID,
+		utils.ParseOperator(ctx))
+	code := fileRsp.GetCode().GetValue()
+	if code != api.ExecuteSuccess && code != api.NotFoundResource {
+		writePolarisError(rsp, code, fileRsp.GetInfo().GetValue())
+		return
+	}
+	writeText(rsp, http.StatusOK, "true")
+}
+
+// checkConfigWrite 配置的写接口需要显式开启，并且通过鉴权模块校验客户端的访问凭证
+func (h *NacosServer) checkConfigWrite(ctx context.Context, rsp *restful.Response, namespace, group string,
+	operation model.ResourceOperation, method string) bool {
+	if !h.configWritable {
+		writeText(rsp, http.StatusForbidden, "config write is disabled, set configWritable to enable it")
+		return false
+	}
+	if authRsp := h.configServer.Service().CheckClientWritePermission(ctx, namespace, group, operation,
+		method); authRsp != nil {
+		writePolarisError(rsp, authRsp.GetCode().GetValue(), authRsp.GetInfo().GetValue())
+		return false
+	}
+	return true
+}
+
+// listenConfigs 监听配置变更，客户端的 md5 和已发布的配置不一致时立即返回，否则挂起到有配置发布或者超时
+func (h *NacosServer) listenConfigs(req *restful.Request, rsp *restful.Response) {
+	configs, err := parseListeningConfigs(req.Request.FormValue(listeningConfigsParam))
+	if err != nil {
+		writeText(rsp, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctx := h.configContext(req)
+	changed, watchFiles := h.checkListeningConfigs(ctx, configs)
+	timeout := h.longPollingTimeout(req)
+	if len(changed) > 0 || timeout <= 0 || len(watchFiles) == 0 {
+		writeText(rsp, http.StatusOK, encodeChangedConfigs(changed))
+		return
+	}
+
+	// 配置文件和客户端上报的配置的对应关系，响应时使用客户端上报的 tenant
+	keys := make(map[string]configKey, len(configs))
+	for _, config := range configs {
+		keys[utils.GenFileId(h.toNamespace(config.Tenant), config.Group, config.DataID)] = config.configKey
+	}
+
+	clientId := req.Request.RemoteAddr + "@" + utils.NewUUID()[0:8]
+	watcher := h.configServer.NewStreamWatcher(clientId)
+	defer watcher.Close()
+	watcher.Subscribe(ctx, watchFiles)
+
+	for _, change := range watcher.WaitChanges(req.Request.Context(), timeout) {
+		fileId := utils.GenFileId(change.GetNamespace().GetValue(), change.GetGroup().GetValue(),
+			change.GetFileName().GetValue())
+		if key, ok := keys[fileId]; ok {
+			changed = append(changed, key)
+		}
+	}
+	writeText(rsp, http.StatusOK, encodeChangedConfigs(changed))
+}
+
+// checkListeningConfigs 返回 md5 不一致的配置，以及 md5 一致需要继续订阅的配置文件和当前发布的版本
+func (h *NacosServer) checkListeningConfigs(ctx context.Context,
+	configs []*listeningConfig) ([]configKey, []*api.ClientConfigFileInfo) {
+	var changed []configKey
+	var watchFiles []*api.ClientConfigFileInfo
+	for _, config := range configs {
+		namespace := h.toNamespace(config.Tenant)
+		fileRsp := h.configServer.Service().GetConfigFileForClient(ctx, namespace, config.Group, config.DataID, 0)
+
+		var md5 string
+		switch fileRsp.GetCode().GetValue() {
+		case api.ExecuteSuccess:
+			md5 = utils2.CalMd5(fileRsp.GetConfigFile().GetContent().GetValue())
+		case api.NotFoundResource:
+		default:
+			configLog.Warn("[Config][Nacos] check listening config error.",
+				zap.String("namespace", namespace),
+				zap.String("group", config.Group),
+				zap.String("dataId", config.DataID),
+				zap.String("info", fileRsp.GetInfo().GetValue()))
+			continue
+		}
+
+		if md5 != config.Md5 {
+			changed = append(changed, config.configKey)
+			continue
+		}
+		watchFiles = append(watchFiles, &api.ClientConfigFileInfo{
+			Namespace: utils.NewStringValue(namespace),
+			Group:     utils.NewStringValue(config.Group),
+			FileName:  utils.NewStringValue(config.DataID),
+			Version:   utils.NewUInt64Value(fileRsp.GetConfigFile().GetVersion().GetValue()),
+		})
+	}
+	return changed, watchFiles
+}
+
+// longPollingTimeout 客户端指定的挂起时间，不挂起时返回 0
+func (h *NacosServer) longPollingTimeout(req *restful.Request) time.Duration {
+	if req.HeaderParameter(longPollingNoHangUpHeader) == "true" {
+		return 0
+	}
+	millis, err := strconv.ParseInt(req.HeaderParameter(longPollingTimeoutHeader), 10, 64)
+	if err != nil || millis <= 0 {
+		return 0
+	}
+	timeout := time.Duration(millis)*time.Millisecond - longPollingAdvance
+	if timeout > h.maxLongPollingTimeout {
+		timeout = h.maxLongPollingTimeout
+	}
+	return timeout
+}
+
+func (h *NacosServer) toNamespace(tenant string) string {
+	if tenant == "" {
+		return h.defaultNamespace
+	}
+	return tenant
+}
+
+// configContext 记录客户端 IP 用于匹配灰度发布规则，以及客户端的访问凭证和操作人
+func (h *NacosServer) configContext(req *restful.Request) context.Context {
+	ctx := context.WithValue(context.Background(), utils.StringContext("request-id"),
+		req.HeaderParameter("Request-Id"))
+
+	clientIP := req.Request.RemoteAddr
+	if host, _, err := net.SplitHostPort(clientIP); err == nil {
+		clientIP = host
+	}
+	ctx = context.WithValue(ctx, utils.StringContext("client-ip"), clientIP)
+	ctx = context.WithValue(ctx, utils.StringContext("operator"), "Nacos:"+clientIP)
+
+	token := req.HeaderParameter(utils.HeaderAuthTokenKey)
+	if token == "" {
+		token = req.Request.FormValue("accessToken")
+	}
+	if token != "" {
+		ctx = context.WithValue(ctx, utils.ContextAuthTokenKey, token)
+	}
+	return ctx
+}
+
+func parseConfigKey(req *restful.Request) (configKey, bool) {
+	key := configKey{
+		DataID: req.Request.FormValue("dataId"),
+		Group:  req.Request.FormValue("group"),
+		Tenant: req.Request.FormValue("tenant"),
+	}
+	if key.Group == "" {
+		key.Group = DefaultGroup
+	}
+	return key, key.DataID != ""
+}
+
+// writePolarisError 把北极星的错误码转换为 Nacos 客户端能够识别的 HTTP 状态码
+func writePolarisError(rsp *restful.Response, code uint32, info string) {
+	status := http.StatusInternalServerError
+	switch code {
+	case api.NotAllowedAccess, api.TokenDisabled, api.TokenNotExisted:
+		status = http.StatusForbidden
+	case api.NotFoundResource:
+		status = http.StatusNotFound
+	default:
+		if code >= 400000 && code < 500000 {
+			status = http.StatusBadRequest
+		}
+	}
+	writeText(rsp, status, info)
+}
+
+func writeText(rsp *restful.Response, status int, body string) {
+	rsp.AddHeader(restful.HEADER_ContentType, mimeTextPlain)
+	rsp.WriteHeader(status)
+	_, _ = rsp.Write([]byte(body))
+}
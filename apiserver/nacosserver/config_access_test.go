@@ -0,0 +1,59 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package nacosserver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/emicklei/go-restful"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigWriteDisabledByDefault(t *testing.T) {
+	h := &NacosServer{}
+	err := h.Initialize(nil, map[string]interface{}{
+		"listenIP":   "127.0.0.1",
+		"listenPort": 8848,
+	}, nil)
+	assert.Nil(t, err)
+	assert.False(t, h.configWritable)
+
+	form := url.Values{"dataId": {"app.properties"}, "content": {"a=1"}}
+	for _, method := range []string{http.MethodPost, http.MethodDelete} {
+		httpReq := httptest.NewRequest(method, "/nacos/v1/cs/configs?"+form.Encode(), nil)
+		recorder := httptest.NewRecorder()
+		req, rsp := restful.NewRequest(httpReq), restful.NewResponse(recorder)
+		if method == http.MethodPost {
+			h.publishConfig(req, rsp)
+		} else {
+			h.deleteConfig(req, rsp)
+		}
+		assert.Equal(t, http.StatusForbidden, recorder.Code, method)
+	}
+
+	err = h.Initialize(nil, map[string]interface{}{
+		"listenIP":       "127.0.0.1",
+		"listenPort":     8848,
+		"configWritable": true,
+	}, nil)
+	assert.Nil(t, err)
+	assert.True(t, h.configWritable)
+}
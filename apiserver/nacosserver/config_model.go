@@ -0,0 +1,106 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package nacosserver
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+
+	"github.com/polarismesh/polaris-server/common/utils"
+)
+
+const (
+	// DefaultGroup Nacos 客户端不指定分组时使用的分组
+	DefaultGroup = "DEFAULT_GROUP"
+
+	// wordSeparator 监听配置中各字段的分隔符
+	wordSeparator = "\x02"
+	// lineSeparator 监听配置之间的分隔符
+	lineSeparator = "\x01"
+)
+
+// configKey Nacos 配置的标识，tenant 为客户端上报的命名空间
+type configKey struct {
+	DataID string
+	Group  string
+	Tenant string
+}
+
+// listeningConfig 客户端监听的配置以及客户端持有的配置内容的 md5
+type listeningConfig struct {
+	configKey
+	Md5 string
+}
+
+// parseListeningConfigs 解析 Listening-Configs，格式为 dataId^2group^2md5^2tenant^1，tenant 可以为空
+func parseListeningConfigs(raw string) ([]*listeningConfig, error) {
+	var configs []*listeningConfig
+	for _, line := range strings.Split(raw, lineSeparator) {
+		if line == "" {
+			continue
+		}
+		words := strings.Split(line, wordSeparator)
+		if len(words) < 3 || len(words) > 4 || words[0] == "" {
+			return nil, errors.New("invalid Listening-Configs")
+		}
+		config := &listeningConfig{
+			configKey: configKey{DataID: words[0], Group: words[1]},
+			Md5:       words[2],
+		}
+		if config.Group == "" {
+			config.Group = DefaultGroup
+		}
+		if len(words) == 4 {
+			config.Tenant = words[3]
+		}
+		configs = append(configs, config)
+	}
+	if len(configs) == 0 {
+		return nil, errors.New("empty Listening-Configs")
+	}
+	return configs, nil
+}
+
+// encodeChangedConfigs 生成配置监听的响应，格式为 url 编码后的 dataId^2group^2tenant^1，tenant 为空时省略
+func encodeChangedConfigs(keys []configKey) string {
+	sb := strings.Builder{}
+	for _, key := range keys {
+		sb.WriteString(key.DataID)
+		sb.WriteString(wordSeparator)
+		sb.WriteString(key.Group)
+		if key.Tenant != "" {
+			sb.WriteString(wordSeparator)
+			sb.WriteString(key.Tenant)
+		}
+		sb.WriteString(lineSeparator)
+	}
+	return url.QueryEscape(sb.String())
+}
+
+// toFileFormat Nacos 配置类型转换为配置文件格式，无法识别的类型作为文本
+func toFileFormat(configType string) string {
+	format := strings.ToLower(configType)
+	if format == "yml" {
+		format = utils.FileFormatYaml
+	}
+	if !utils.IsValidFileFormat(format) {
+		return utils.FileFormatText
+	}
+	return format
+}
@@ -0,0 +1,66 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package nacosserver
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/polarismesh/polaris-server/common/utils"
+)
+
+func TestParseListeningConfigs(t *testing.T) {
+	raw := "app.properties\x02DEFAULT_GROUP\x02md5-1\x01" +
+		"app.yaml\x02\x02\x02dev\x01"
+	configs, err := parseListeningConfigs(raw)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(configs))
+	assert.Equal(t, "app.properties", configs[0].DataID)
+	assert.Equal(t, DefaultGroup, configs[0].Group)
+	assert.Equal(t, "md5-1", configs[0].Md5)
+	assert.Equal(t, "", configs[0].Tenant)
+	assert.Equal(t, DefaultGroup, configs[1].Group)
+	assert.Equal(t, "", configs[1].Md5)
+	assert.Equal(t, "dev", configs[1].Tenant)
+
+	_, err = parseListeningConfigs("")
+	assert.NotNil(t, err)
+	_, err = parseListeningConfigs("app.properties\x01")
+	assert.NotNil(t, err)
+}
+
+func TestEncodeChangedConfigs(t *testing.T) {
+	encoded := encodeChangedConfigs([]configKey{
+		{DataID: "app.properties", Group: DefaultGroup},
+		{DataID: "app.yaml", Group: "g1", Tenant: "dev"},
+	})
+	decoded, err := url.QueryUnescape(encoded)
+	assert.Nil(t, err)
+	assert.Equal(t, "app.properties\x02DEFAULT_GROUP\x01app.yaml\x02g1\x02dev\x01", decoded)
+
+	assert.Equal(t, "", encodeChangedConfigs(nil))
+}
+
+func TestToFileFormat(t *testing.T) {
+	assert.Equal(t, utils.FileFormatYaml, toFileFormat("yml"))
+	assert.Equal(t, utils.FileFormatProperties, toFileFormat("PROPERTIES"))
+	assert.Equal(t, utils.FileFormatText, toFileFormat(""))
+	assert.Equal(t, utils.FileFormatText, toFileFormat("unknown"))
+}
@@ -0,0 +1,465 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package nacosserver
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/emicklei/go-restful"
+	"go.uber.org/zap"
+
+	api "github.com/polarismesh/polaris-server/common/api/v1"
+	"github.com/polarismesh/polaris-server/common/model"
+	"github.com/polarismesh/polaris-server/common/utils"
+)
+
+const (
+	// serviceCacheMillis 客户端缓存服务实例列表的时间
+	serviceCacheMillis = 10000
+	defaultPageSize    = 10
+)
+
+// GetNacosNamingAccessServer Nacos 注册发现客户端使用的接口
+func (h *NacosServer) GetNacosNamingAccessServer() *restful.WebService {
+	ws := new(restful.WebService)
+	ws.Path("/nacos/v1/ns")
+
+	ws.Route(ws.POST("/instance").To(h.registerInstance))
+	ws.Route(ws.DELETE("/instance").To(h.deregisterInstance))
+	ws.Route(ws.PUT("/instance").To(h.updateInstance))
+	ws.Route(ws.GET("/instance").To(h.getInstance))
+	ws.Route(ws.GET("/instance/list").To(h.listInstances))
+	ws.Route(ws.PUT("/instance/beat").To(h.beatInstance))
+
+	ws.Route(ws.POST("/service").To(h.createService))
+	ws.Route(ws.DELETE("/service").To(h.deleteService))
+	ws.Route(ws.PUT("/service").To(h.updateService))
+	ws.Route(ws.GET("/service").To(h.getService))
+	ws.Route(ws.GET("/service/list").To(h.listServices))
+	return ws
+}
+
+// registerInstance 注册实例，服务不存在时自动创建服务
+func (h *NacosServer) registerInstance(req *restful.Request, rsp *restful.Response) {
+	instance, ok := h.parseInstance(req)
+	if !ok {
+		writeText(rsp, http.StatusBadRequest, "valid serviceName, ip and port are required")
+		return
+	}
+
+	ctx := h.configContext(req)
+	resp := h.namingServer.RegisterInstance(ctx, instance)
+	code := resp.GetCode().GetValue()
+	if code == api.NotFoundResource {
+		svcResp := h.namingServer.CreateServices(ctx, []*api.Service{{
+			Namespace: instance.GetNamespace(),
+			Name:      instance.GetService(),
+			Owners:    utils.NewStringValue(h.owner),
+		}})
+		code = svcResp.GetCode().GetValue()
+		if code == api.ExecuteSuccess || code == api.ExistedResource {
+			code = h.namingServer.RegisterInstance(ctx, instance).GetCode().GetValue()
+		}
+	}
+	if code != api.ExecuteSuccess && code != api.ExistedResource {
+		log.Error("[Naming][Nacos] register instance error.",
+			zap.String("namespace", instance.GetNamespace().GetValue()),
+			zap.String("service", instance.GetService().GetValue()),
+			zap.String("host", instance.GetHost().GetValue()),
+			zap.Uint32("port", instance.GetPort().GetValue()),
+			zap.Uint32("code", code))
+		writePolarisError(rsp, code, api.Code2Info(code))
+		return
+	}
+	writeText(rsp, http.StatusOK, "ok")
+}
+
+// deregisterInstance 反注册实例，实例不存在时视为成功
+func (h *NacosServer) deregisterInstance(req *restful.Request, rsp *restful.Response) {
+	key, ok := h.parseServiceKey(req)
+	port, err := strconv.ParseUint(req.Request.FormValue("port"), 10, 32)
+	if !ok || err != nil || req.Request.FormValue("ip") == "" {
+		writeText(rsp, http.StatusBadRequest, "valid serviceName, ip and port are required")
+		return
+	}
+
+	resp := h.namingServer.DeregisterInstance(h.configContext(req), &api.Instance{
+		Namespace: utils.NewStringValue(key.Namespace),
+		Service:   utils.NewStringValue(key.polarisName()),
+		Host:      utils.NewStringValue(req.Request.FormValue("ip")),
+		Port:      utils.NewUInt32Value(uint32(port)),
+	})
+	code := resp.GetCode().GetValue()
+	if code != api.ExecuteSuccess && code != api.NotFoundResource && code != api.NotFoundInstance {
+		writePolarisError(rsp, code, resp.GetInfo().GetValue())
+		return
+	}
+	writeText(rsp, http.StatusOK, "ok")
+}
+
+// updateInstance 更新实例的权重、元数据以及上下线状态
+func (h *NacosServer) updateInstance(req *restful.Request, rsp *restful.Response) {
+	instance, ok := h.parseInstance(req)
+	if !ok {
+		writeText(rsp, http.StatusBadRequest, "valid serviceName, ip and port are required")
+		return
+	}
+
+	resp := h.namingServer.UpdateInstances(h.configContext(req), []*api.Instance{instance})
+	code := resp.GetCode().GetValue()
+	if code != api.ExecuteSuccess && code != api.NoNeedUpdate {
+		writePolarisError(rsp, code, resp.GetInfo().GetValue())
+		return
+	}
+	writeText(rsp, http.StatusOK, "ok")
+}
+
+// getInstance 查询单个实例的详情
+func (h *NacosServer) getInstance(req *restful.Request, rsp *restful.Response) {
+	key, ok := h.parseServiceKey(req)
+	if !ok {
+		writeText(rsp, http.StatusBadRequest, "valid serviceName is required")
+		return
+	}
+	ip := req.Request.FormValue("ip")
+	port, _ := strconv.ParseUint(req.Request.FormValue("port"), 10, 32)
+	cluster := req.Request.FormValue("cluster")
+
+	instances, code := h.queryInstances(h.configContext(req), key)
+	if code != api.ExecuteSuccess && code != api.NotFoundResource {
+		writePolarisError(rsp, code, api.Code2Info(code))
+		return
+	}
+	for _, instance := range instances {
+		if instance.IP == ip && instance.Port == uint32(port) && (cluster == "" || instance.ClusterName == cluster) {
+			_ = rsp.WriteHeaderAndJson(http.StatusOK, instance, restful.MIME_JSON)
+			return
+		}
+	}
+	writeText(rsp, http.StatusNotFound, "no ips found for cluster "+cluster+" in service "+key.groupedName())
+}
+
+// listInstances 查询服务的实例列表，服务不存在时返回空列表
+func (h *NacosServer) listInstances(req *restful.Request, rsp *restful.Response) {
+	key, ok := h.parseServiceKey(req)
+	if !ok {
+		writeText(rsp, http.StatusBadRequest, "valid serviceName is required")
+		return
+	}
+	clusters := req.Request.FormValue("clusters")
+	healthyOnly, _ := strconv.ParseBool(req.Request.FormValue("healthyOnly"))
+
+	instances, code := h.queryInstances(h.configContext(req), key)
+	if code != api.ExecuteSuccess && code != api.NotFoundResource {
+		writePolarisError(rsp, code, api.Code2Info(code))
+		return
+	}
+
+	hosts := make([]*NacosInstance, 0, len(instances))
+	for _, instance := range instances {
+		// 下线的实例不返回给客户端
+		if !instance.Enabled || !matchClusters(instance, clusters) {
+			continue
+		}
+		if healthyOnly && !instance.Healthy {
+			continue
+		}
+		hosts = append(hosts, instance)
+	}
+
+	_ = rsp.WriteHeaderAndJson(http.StatusOK, &NacosServiceInstances{
+		Name:        key.groupedName(),
+		GroupName:   key.Group,
+		Clusters:    clusters,
+		CacheMillis: serviceCacheMillis,
+		Hosts:       hosts,
+		LastRefTime: time.Now().UnixNano() / int64(time.Millisecond),
+		Checksum:    h.namingServer.Cache().GetServiceInstanceRevision(h.serviceID(key)),
+		Valid:       true,
+	}, restful.MIME_JSON)
+}
+
+// beatInstance 临时实例的心跳，实例不存在时通知客户端重新注册
+func (h *NacosServer) beatInstance(req *restful.Request, rsp *restful.Response) {
+	key, ok := h.parseServiceKey(req)
+	if !ok {
+		writeText(rsp, http.StatusBadRequest, "valid serviceName is required")
+		return
+	}
+
+	beat := &NacosBeatInfo{}
+	if raw := req.Request.FormValue("beat"); raw != "" {
+		if err := json.Unmarshal([]byte(raw), beat); err != nil {
+			writeText(rsp, http.StatusBadRequest, "invalid beat info: "+err.Error())
+			return
+		}
+	}
+	if beat.IP == "" {
+		beat.IP = req.Request.FormValue("ip")
+	}
+	if beat.Port == 0 {
+		port, _ := strconv.ParseUint(req.Request.FormValue("port"), 10, 32)
+		beat.Port = uint32(port)
+	}
+	if beat.IP == "" || beat.Port == 0 {
+		writeText(rsp, http.StatusBadRequest, "ip and port are required")
+		return
+	}
+
+	resp := h.healthCheckServer.Report(h.configContext(req), &api.Instance{
+		Namespace: utils.NewStringValue(key.Namespace),
+		Service:   utils.NewStringValue(key.polarisName()),
+		Host:      utils.NewStringValue(beat.IP),
+		Port:      utils.NewUInt32Value(beat.Port),
+	})
+	switch resp.GetCode().GetValue() {
+	case api.ExecuteSuccess:
+		_ = rsp.WriteHeaderAndJson(http.StatusOK, &NacosBeatResult{
+			ClientBeatInterval: DefaultHeartbeatInterval * 1000,
+			Code:               beatCodeOk,
+			LightBeatEnabled:   true,
+		}, restful.MIME_JSON)
+	case api.HeartbeatOnDisabledIns, api.NotFoundResource, api.NotFoundInstance:
+		_ = rsp.WriteHeaderAndJson(http.StatusOK, &NacosBeatResult{
+			ClientBeatInterval: DefaultHeartbeatInterval * 1000,
+			Code:               beatCodeNotFound,
+		}, restful.MIME_JSON)
+	default:
+		writePolarisError(rsp, resp.GetCode().GetValue(), resp.GetInfo().GetValue())
+	}
+}
+
+// createService 创建服务
+func (h *NacosServer) createService(req *restful.Request, rsp *restful.Response) {
+	key, ok := h.parseServiceKey(req)
+	if !ok {
+		writeText(rsp, http.StatusBadRequest, "valid serviceName is required")
+		return
+	}
+
+	resp := h.namingServer.CreateServices(h.configContext(req), []*api.Service{h.toPolarisService(req, key)})
+	if code := resp.GetCode().GetValue(); code != api.ExecuteSuccess {
+		writePolarisError(rsp, code, resp.GetInfo().GetValue())
+		return
+	}
+	writeText(rsp, http.StatusOK, "ok")
+}
+
+// deleteService 删除服务，服务下存在实例时删除失败
+func (h *NacosServer) deleteService(req *restful.Request, rsp *restful.Response) {
+	key, ok := h.parseServiceKey(req)
+	if !ok {
+		writeText(rsp, http.StatusBadRequest, "valid serviceName is required")
+		return
+	}
+
+	resp := h.namingServer.DeleteServices(h.configContext(req), []*api.Service{{
+		Namespace: utils.NewStringValue(key.Namespace),
+		Name:      utils.NewStringValue(key.polarisName()),
+	}})
+	if code := resp.GetCode().GetValue(); code != api.ExecuteSuccess {
+		writePolarisError(rsp, code, resp.GetInfo().GetValue())
+		return
+	}
+	writeText(rsp, http.StatusOK, "ok")
+}
+
+// updateService 更新服务的元数据以及保护阈值
+func (h *NacosServer) updateService(req *restful.Request, rsp *restful.Response) {
+	key, ok := h.parseServiceKey(req)
+	if !ok {
+		writeText(rsp, http.StatusBadRequest, "valid serviceName is required")
+		return
+	}
+
+	resp := h.namingServer.UpdateServices(h.configContext(req), []*api.Service{h.toPolarisService(req, key)})
+	if code := resp.GetCode().GetValue(); code != api.ExecuteSuccess && code != api.NoNeedUpdate {
+		writePolarisError(rsp, code, resp.GetInfo().GetValue())
+		return
+	}
+	writeText(rsp, http.StatusOK, "ok")
+}
+
+// getService 查询服务详情
+func (h *NacosServer) getService(req *restful.Request, rsp *restful.Response) {
+	key, ok := h.parseServiceKey(req)
+	if !ok {
+		writeText(rsp, http.StatusBadRequest, "valid serviceName is required")
+		return
+	}
+
+	svc := h.namingServer.Cache().Service().GetServiceByName(key.polarisName(), key.Namespace)
+	if svc == nil {
+		writeText(rsp, http.StatusNotFound, "service "+key.groupedName()+" is not found")
+		return
+	}
+
+	clusters := make([]string, 0)
+	instances, _ := h.queryInstances(h.configContext(req), key)
+	existed := make(map[string]bool)
+	for _, instance := range instances {
+		if !existed[instance.ClusterName] {
+			existed[instance.ClusterName] = true
+			clusters = append(clusters, instance.ClusterName)
+		}
+	}
+	sort.Strings(clusters)
+
+	protectThreshold, _ := strconv.ParseFloat(svc.Meta[MetadataProtectThreshold], 64)
+	_ = rsp.WriteHeaderAndJson(http.StatusOK, &NacosService{
+		NamespaceID:      key.Namespace,
+		GroupName:        key.Group,
+		Name:             key.Service,
+		ProtectThreshold: protectThreshold,
+		Metadata:         userMetadata(svc.Meta),
+		Selector:         map[string]string{"type": "none"},
+		Clusters:         clusters,
+	}, restful.MIME_JSON)
+}
+
+// listServices 分页查询分组下的服务名
+func (h *NacosServer) listServices(req *restful.Request, rsp *restful.Response) {
+	namespace := h.toNamespace(req.Request.FormValue("namespaceId"))
+	group := req.Request.FormValue("groupName")
+	if group == "" {
+		group = DefaultGroup
+	}
+	pageNo, err := strconv.Atoi(req.Request.FormValue("pageNo"))
+	if err != nil || pageNo <= 0 {
+		pageNo = 1
+	}
+	pageSize, err := strconv.Atoi(req.Request.FormValue("pageSize"))
+	if err != nil || pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+
+	var services []*model.Service
+	_ = h.namingServer.Cache().Service().IteratorServices(func(_ string, svc *model.Service) (bool, error) {
+		services = append(services, svc)
+		return true, nil
+	})
+	names := groupServiceNames(services, namespace, group)
+
+	doms := make([]string, 0, pageSize)
+	if start := (pageNo - 1) * pageSize; start < len(names) {
+		end := start + pageSize
+		if end > len(names) {
+			end = len(names)
+		}
+		doms = append(doms, names[start:end]...)
+	}
+	_ = rsp.WriteHeaderAndJson(http.StatusOK, &NacosServiceList{Count: len(names), Doms: doms}, restful.MIME_JSON)
+}
+
+// groupServiceNames 返回命名空间下属于分组的 Nacos 服务名，按照服务名排序
+func groupServiceNames(services []*model.Service, namespace, group string) []string {
+	var names []string
+	for _, svc := range services {
+		if svc.Namespace != namespace || svc.IsAlias() {
+			continue
+		}
+		if svcGroup, name := fromPolarisName(svc.Name); svcGroup == group {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// queryInstances 从缓存查询服务的全部实例
+func (h *NacosServer) queryInstances(ctx context.Context, key serviceKey) ([]*NacosInstance, uint32) {
+	resp := h.namingServer.ServiceInstancesCache(ctx, &api.Service{
+		Namespace: utils.NewStringValue(key.Namespace),
+		Name:      utils.NewStringValue(key.polarisName()),
+	})
+	if code := resp.GetCode().GetValue(); code != api.ExecuteSuccess && code != api.DataNoChange {
+		return nil, code
+	}
+	instances := make([]*NacosInstance, 0, len(resp.GetInstances()))
+	for _, instance := range resp.GetInstances() {
+		instances = append(instances, toNacosInstance(instance, key))
+	}
+	return instances, api.ExecuteSuccess
+}
+
+func (h *NacosServer) serviceID(key serviceKey) string {
+	svc := h.namingServer.Cache().Service().GetServiceByName(key.polarisName(), key.Namespace)
+	if svc == nil {
+		return ""
+	}
+	return svc.ID
+}
+
+// parseServiceKey 解析请求中的命名空间、分组以及服务名，服务名为空或者分组和服务名无法唯一映射到北极星服务名时返回 false
+func (h *NacosServer) parseServiceKey(req *restful.Request) (serviceKey, bool) {
+	group, service := parseServiceName(req.Request.FormValue("serviceName"), req.Request.FormValue("groupName"))
+	key := serviceKey{
+		Namespace: h.toNamespace(req.Request.FormValue("namespaceId")),
+		Group:     group,
+		Service:   service,
+	}
+	return key, key.valid()
+}
+
+// parseInstance 解析注册以及更新实例的请求
+func (h *NacosServer) parseInstance(req *restful.Request) (*api.Instance, bool) {
+	key, ok := h.parseServiceKey(req)
+	ip := req.Request.FormValue("ip")
+	port, err := strconv.ParseUint(req.Request.FormValue("port"), 10, 32)
+	if !ok || ip == "" || err != nil {
+		return nil, false
+	}
+
+	weight := 1.0
+	if value, err := strconv.ParseFloat(req.Request.FormValue("weight"), 64); err == nil {
+		weight = value
+	}
+	healthy := parseBoolParam(req, "healthy", true)
+	enabled := parseBoolParam(req, "enabled", true)
+	ephemeral := parseBoolParam(req, "ephemeral", true)
+	return newPolarisInstance(key, ip, uint32(port), req.Request.FormValue("clusterName"), weight, healthy, enabled,
+		ephemeral, parseMetadata(req.Request.FormValue("metadata"))), true
+}
+
+// toPolarisService 创建以及更新服务的请求转换为北极星服务
+func (h *NacosServer) toPolarisService(req *restful.Request, key serviceKey) *api.Service {
+	metadata := parseMetadata(req.Request.FormValue("metadata"))
+	if threshold := req.Request.FormValue("protectThreshold"); threshold != "" {
+		metadata[MetadataProtectThreshold] = threshold
+	}
+	return &api.Service{
+		Namespace: utils.NewStringValue(key.Namespace),
+		Name:      utils.NewStringValue(key.polarisName()),
+		Metadata:  metadata,
+		Owners:    utils.NewStringValue(h.owner),
+	}
+}
+
+func parseBoolParam(req *restful.Request, name string, defaultValue bool) bool {
+	value, err := strconv.ParseBool(strings.TrimSpace(req.Request.FormValue(name)))
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
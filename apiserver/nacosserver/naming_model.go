@@ -0,0 +1,295 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package nacosserver
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/golang/protobuf/ptypes/wrappers"
+
+	api "github.com/polarismesh/polaris-server/common/api/v1"
+	"github.com/polarismesh/polaris-server/common/utils"
+)
+
+const (
+	// DefaultCluster Nacos 实例默认所属的集群
+	DefaultCluster = "DEFAULT"
+	// DefaultOwner 自动创建的服务的负责人
+	DefaultOwner = "polaris"
+	// DefaultHeartbeatInterval Nacos 客户端默认的心跳间隔，单位秒
+	DefaultHeartbeatInterval = 5
+
+	ServerNacos = "nacos"
+
+	MetadataRegisterFrom = "internal-register-from"
+	MetadataCluster      = "internal-nacos-cluster"
+	MetadataEphemeral    = "internal-nacos-ephemeral"
+	// MetadataProtectThreshold 服务的保护阈值，记录在北极星服务的元数据中
+	MetadataProtectThreshold = "internal-nacos-protect-threshold"
+
+	// groupedNameSeparator Nacos 客户端上报的服务名为 group@@service
+	groupedNameSeparator = "@@"
+	// serviceNameSeparator 非默认分组的服务对应的北极星服务名为 group__service
+	serviceNameSeparator = "__"
+
+	// weightRatio 北极星的默认权重为 100，对应 Nacos 的默认权重 1.0
+	weightRatio = 100
+	maxWeight   = 10000
+
+	beatCodeOk       = 10200
+	beatCodeNotFound = 20404
+)
+
+// NacosInstance Nacos 实例
+type NacosInstance struct {
+	InstanceID                string            `json:"instanceId"`
+	IP                        string            `json:"ip"`
+	Port                      uint32            `json:"port"`
+	Weight                    float64           `json:"weight"`
+	Healthy                   bool              `json:"healthy"`
+	Enabled                   bool              `json:"enabled"`
+	Ephemeral                 bool              `json:"ephemeral"`
+	ClusterName               string            `json:"clusterName"`
+	ServiceName               string            `json:"serviceName"`
+	Metadata                  map[string]string `json:"metadata"`
+	InstanceHeartBeatInterval int64             `json:"instanceHeartBeatInterval"`
+	InstanceHeartBeatTimeOut  int64             `json:"instanceHeartBeatTimeOut"`
+	IPDeleteTimeout           int64             `json:"ipDeleteTimeout"`
+}
+
+// NacosServiceInstances 查询实例列表的响应
+type NacosServiceInstances struct {
+	Name                     string           `json:"name"`
+	GroupName                string           `json:"groupName"`
+	Clusters                 string           `json:"clusters"`
+	CacheMillis              int64            `json:"cacheMillis"`
+	Hosts                    []*NacosInstance `json:"hosts"`
+	LastRefTime              int64            `json:"lastRefTime"`
+	Checksum                 string           `json:"checksum"`
+	AllIPs                   bool             `json:"allIPs"`
+	ReachProtectionThreshold bool             `json:"reachProtectionThreshold"`
+	Valid                    bool             `json:"valid"`
+}
+
+// NacosBeatInfo 客户端心跳上报的实例信息
+type NacosBeatInfo struct {
+	ServiceName string            `json:"serviceName"`
+	IP          string            `json:"ip"`
+	Port        uint32            `json:"port"`
+	Cluster     string            `json:"cluster"`
+	Weight      float64           `json:"weight"`
+	Metadata    map[string]string `json:"metadata"`
+}
+
+// NacosBeatResult 心跳的响应，实例不存在时客户端重新注册
+type NacosBeatResult struct {
+	ClientBeatInterval int64 `json:"clientBeatInterval"`
+	Code               int   `json:"code"`
+	LightBeatEnabled   bool  `json:"lightBeatEnabled"`
+}
+
+// NacosService Nacos 服务详情
+type NacosService struct {
+	NamespaceID      string            `json:"namespaceId"`
+	GroupName        string            `json:"groupName"`
+	Name             string            `json:"name"`
+	ProtectThreshold float64           `json:"protectThreshold"`
+	Metadata         map[string]string `json:"metadata"`
+	Selector         map[string]string `json:"selector"`
+	Clusters         []string          `json:"clusters"`
+}
+
+// NacosServiceList 分页查询服务名的响应
+type NacosServiceList struct {
+	Count int      `json:"count"`
+	Doms  []string `json:"doms"`
+}
+
+// serviceKey Nacos 服务的标识
+type serviceKey struct {
+	Namespace string
+	Group     string
+	Service   string
+}
+
+// polarisName Nacos 服务对应的北极星服务名，默认分组的服务名不变
+func (k serviceKey) polarisName() string {
+	if k.Group == DefaultGroup {
+		return k.Service
+	}
+	return k.Group + serviceNameSeparator + k.Service
+}
+
+// valid 分组中不能包含服务名分隔符也不能以下划线结尾，默认分组的服务名中不能包含服务名分隔符，
+// 保证不同分组的服务对应不同的北极星服务名，并且北极星服务名能够解析回原来的分组和服务名
+func (k serviceKey) valid() bool {
+	if k.Service == "" {
+		return false
+	}
+	if k.Group == DefaultGroup {
+		return !strings.Contains(k.Service, serviceNameSeparator)
+	}
+	return !strings.Contains(k.Group, serviceNameSeparator) && !strings.HasSuffix(k.Group, "_")
+}
+
+// groupedName Nacos 客户端使用的带分组的服务名
+func (k serviceKey) groupedName() string {
+	return k.Group + groupedNameSeparator + k.Service
+}
+
+// parseServiceName 解析 Nacos 客户端上报的服务名，服务名中携带的分组优先
+func parseServiceName(serviceName, groupName string) (group, service string) {
+	if idx := strings.Index(serviceName, groupedNameSeparator); idx >= 0 {
+		groupName, serviceName = serviceName[:idx], serviceName[idx+len(groupedNameSeparator):]
+	}
+	if groupName == "" {
+		groupName = DefaultGroup
+	}
+	return groupName, serviceName
+}
+
+// fromPolarisName 解析北极星服务名对应的 Nacos 分组和服务名
+func fromPolarisName(name string) (group, service string) {
+	if idx := strings.Index(name, serviceNameSeparator); idx > 0 {
+		return name[:idx], name[idx+len(serviceNameSeparator):]
+	}
+	return DefaultGroup, name
+}
+
+// parseMetadata 解析 Nacos 的元数据，支持 JSON 格式以及 k1=v1,k2=v2 格式
+func parseMetadata(raw string) map[string]string {
+	metadata := make(map[string]string)
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return metadata
+	}
+	if strings.HasPrefix(raw, "{") {
+		_ = json.Unmarshal([]byte(raw), &metadata)
+		return metadata
+	}
+	for _, item := range strings.Split(raw, ",") {
+		kv := strings.SplitN(item, "=", 2)
+		if len(kv) != 2 || kv[0] == "" {
+			continue
+		}
+		metadata[strings.TrimSpace(kv[0])] = strings.TrimSpace(kv[1])
+	}
+	return metadata
+}
+
+// toPolarisWeight Nacos 权重转换为北极星权重
+func toPolarisWeight(weight float64) uint32 {
+	if weight <= 0 {
+		return 0
+	}
+	polarisWeight := weight * weightRatio
+	if polarisWeight > maxWeight {
+		return maxWeight
+	}
+	return uint32(polarisWeight)
+}
+
+// toNacosInstance 北极星实例转换为 Nacos 实例，不返回北极星内部使用的元数据
+func toNacosInstance(instance *api.Instance, key serviceKey) *NacosInstance {
+	cluster := instance.GetMetadata()[MetadataCluster]
+	if cluster == "" {
+		cluster = DefaultCluster
+	}
+	interval := int64(instance.GetHealthCheck().GetHeartbeat().GetTtl().GetValue()) * 1000
+	if interval == 0 {
+		interval = DefaultHeartbeatInterval * 1000
+	}
+	return &NacosInstance{
+		InstanceID:                instance.GetId().GetValue(),
+		IP:                        instance.GetHost().GetValue(),
+		Port:                      instance.GetPort().GetValue(),
+		Weight:                    float64(instance.GetWeight().GetValue()) / weightRatio,
+		Healthy:                   instance.GetHealthy().GetValue(),
+		Enabled:                   !instance.GetIsolate().GetValue(),
+		Ephemeral:                 instance.GetMetadata()[MetadataEphemeral] != "false",
+		ClusterName:               cluster,
+		ServiceName:               key.groupedName(),
+		Metadata:                  userMetadata(instance.GetMetadata()),
+		InstanceHeartBeatInterval: interval,
+		InstanceHeartBeatTimeOut:  3 * interval,
+		IPDeleteTimeout:           6 * interval,
+	}
+}
+
+// newPolarisInstance 创建北极星实例，临时实例由心跳维持健康状态，持久化实例使用客户端上报的健康状态
+func newPolarisInstance(key serviceKey, ip string, port uint32, cluster string, weight float64, healthy, enabled,
+	ephemeral bool, metadata map[string]string) *api.Instance {
+	if cluster == "" {
+		cluster = DefaultCluster
+	}
+	instanceMetadata := make(map[string]string, len(metadata)+3)
+	for k, v := range metadata {
+		instanceMetadata[k] = v
+	}
+	instanceMetadata[MetadataRegisterFrom] = ServerNacos
+	instanceMetadata[MetadataCluster] = cluster
+	if !ephemeral {
+		instanceMetadata[MetadataEphemeral] = "false"
+	}
+
+	instance := &api.Instance{
+		Namespace: utils.NewStringValue(key.Namespace),
+		Service:   utils.NewStringValue(key.polarisName()),
+		Host:      utils.NewStringValue(ip),
+		Port:      utils.NewUInt32Value(port),
+		Weight:    utils.NewUInt32Value(toPolarisWeight(weight)),
+		Healthy:   utils.NewBoolValue(healthy),
+		Isolate:   utils.NewBoolValue(!enabled),
+		Metadata:  instanceMetadata,
+	}
+	if ephemeral {
+		instance.EnableHealthCheck = utils.NewBoolValue(true)
+		instance.HealthCheck = &api.HealthCheck{
+			Type: api.HealthCheck_HEARTBEAT,
+			Heartbeat: &api.HeartbeatHealthCheck{
+				Ttl: &wrappers.UInt32Value{Value: DefaultHeartbeatInterval},
+			},
+		}
+	}
+	return instance
+}
+
+// userMetadata 去掉北极星内部使用的元数据
+func userMetadata(metadata map[string]string) map[string]string {
+	result := make(map[string]string, len(metadata))
+	for k, v := range metadata {
+		if !strings.HasPrefix(k, "internal-") {
+			result[k] = v
+		}
+	}
+	return result
+}
+
+// matchClusters 实例是否属于查询的集群，clusters 为空时匹配全部集群
+func matchClusters(instance *NacosInstance, clusters string) bool {
+	if clusters == "" {
+		return true
+	}
+	for _, cluster := range strings.Split(clusters, ",") {
+		if strings.TrimSpace(cluster) == instance.ClusterName {
+			return true
+		}
+	}
+	return false
+}
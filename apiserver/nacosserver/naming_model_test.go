@@ -0,0 +1,128 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package nacosserver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	api "github.com/polarismesh/polaris-server/common/api/v1"
+	"github.com/polarismesh/polaris-server/common/model"
+)
+
+func TestParseServiceName(t *testing.T) {
+	group, service := parseServiceName("g1@@svc", "")
+	assert.Equal(t, "g1", group)
+	assert.Equal(t, "svc", service)
+
+	group, service = parseServiceName("svc", "")
+	assert.Equal(t, DefaultGroup, group)
+	assert.Equal(t, "svc", service)
+
+	group, service = parseServiceName("svc", "g2")
+	assert.Equal(t, "g2", group)
+	assert.Equal(t, "svc", service)
+}
+
+func TestPolarisServiceName(t *testing.T) {
+	key := serviceKey{Namespace: "default", Group: DefaultGroup, Service: "svc"}
+	assert.Equal(t, "svc", key.polarisName())
+	assert.Equal(t, "DEFAULT_GROUP@@svc", key.groupedName())
+
+	key.Group = "g1"
+	assert.Equal(t, "g1__svc", key.polarisName())
+
+	group, service := fromPolarisName("g1__svc")
+	assert.Equal(t, "g1", group)
+	assert.Equal(t, "svc", service)
+	group, service = fromPolarisName("svc")
+	assert.Equal(t, DefaultGroup, group)
+	assert.Equal(t, "svc", service)
+}
+
+// TestServiceNameCollision 不同分组的服务不能映射到同一个北极星服务名
+func TestServiceNameCollision(t *testing.T) {
+	grouped := serviceKey{Namespace: "default", Group: "foo", Service: "bar"}
+	assert.True(t, grouped.valid())
+	assert.Equal(t, "foo__bar", grouped.polarisName())
+
+	// 默认分组的 foo__bar 会被解析为 foo 分组的 bar
+	assert.False(t, serviceKey{Namespace: "default", Group: DefaultGroup, Service: "foo__bar"}.valid())
+	// foo_ 分组的 bar 与 foo 分组的 _bar 对应的北极星服务名都是 foo___bar
+	assert.False(t, serviceKey{Namespace: "default", Group: "foo_", Service: "bar"}.valid())
+	assert.True(t, serviceKey{Namespace: "default", Group: "foo", Service: "_bar"}.valid())
+	assert.False(t, serviceKey{Namespace: "default", Group: "a__b", Service: "c"}.valid())
+	assert.False(t, serviceKey{Namespace: "default", Group: DefaultGroup, Service: ""}.valid())
+
+	group, service := fromPolarisName("foo___bar")
+	assert.Equal(t, "foo", group)
+	assert.Equal(t, "_bar", service)
+
+	services := []*model.Service{
+		{Namespace: "default", Name: grouped.polarisName()},
+		{Namespace: "default", Name: "bar"},
+		{Namespace: "default", Name: "foo__baz"},
+		{Namespace: "other", Name: "foo__qux"},
+	}
+	assert.Equal(t, []string{"bar", "baz"}, groupServiceNames(services, "default", "foo"))
+	assert.Equal(t, []string{"bar"}, groupServiceNames(services, "default", DefaultGroup))
+	assert.Equal(t, []string{"qux"}, groupServiceNames(services, "other", "foo"))
+}
+
+func TestParseMetadata(t *testing.T) {
+	assert.Equal(t, map[string]string{"a": "1", "b": "2"}, parseMetadata(`{"a":"1","b":"2"}`))
+	assert.Equal(t, map[string]string{"a": "1", "b": "2"}, parseMetadata("a=1, b=2,invalid"))
+	assert.Equal(t, map[string]string{}, parseMetadata(""))
+}
+
+func TestToPolarisWeight(t *testing.T) {
+	assert.Equal(t, uint32(100), toPolarisWeight(1))
+	assert.Equal(t, uint32(50), toPolarisWeight(0.5))
+	assert.Equal(t, uint32(0), toPolarisWeight(-1))
+	assert.Equal(t, uint32(maxWeight), toPolarisWeight(1000))
+}
+
+func TestInstanceConversion(t *testing.T) {
+	key := serviceKey{Namespace: "default", Group: "g1", Service: "svc"}
+	instance := newPolarisInstance(key, "127.0.0.1", 8080, "", 2, true, false, true,
+		map[string]string{"version": "v1"})
+	assert.Equal(t, "g1__svc", instance.GetService().GetValue())
+	assert.Equal(t, uint32(200), instance.GetWeight().GetValue())
+	assert.True(t, instance.GetIsolate().GetValue())
+	assert.True(t, instance.GetEnableHealthCheck().GetValue())
+	assert.Equal(t, api.HealthCheck_HEARTBEAT, instance.GetHealthCheck().GetType())
+	assert.Equal(t, ServerNacos, instance.GetMetadata()[MetadataRegisterFrom])
+	assert.Equal(t, DefaultCluster, instance.GetMetadata()[MetadataCluster])
+
+	nacosInstance := toNacosInstance(instance, key)
+	assert.Equal(t, "g1@@svc", nacosInstance.ServiceName)
+	assert.Equal(t, float64(2), nacosInstance.Weight)
+	assert.False(t, nacosInstance.Enabled)
+	assert.True(t, nacosInstance.Ephemeral)
+	assert.Equal(t, DefaultCluster, nacosInstance.ClusterName)
+	assert.Equal(t, map[string]string{"version": "v1"}, nacosInstance.Metadata)
+
+	persistent := newPolarisInstance(key, "127.0.0.1", 8080, "c1", 1, false, true, false, nil)
+	assert.Nil(t, persistent.GetHealthCheck())
+	assert.False(t, toNacosInstance(persistent, key).Ephemeral)
+	assert.False(t, toNacosInstance(persistent, key).Healthy)
+	assert.True(t, matchClusters(toNacosInstance(persistent, key), "c0,c1"))
+	assert.False(t, matchClusters(toNacosInstance(persistent, key), "c0"))
+	assert.True(t, matchClusters(toNacosInstance(persistent, key), ""))
+}
@@ -0,0 +1,136 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package xdsserverv3
+
+import (
+	"encoding/json"
+
+	cluster "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	"github.com/golang/protobuf/ptypes/wrappers"
+
+	api "github.com/polarismesh/polaris-server/common/api/v1"
+	"github.com/polarismesh/polaris-server/common/model"
+)
+
+// 熔断生效的比例，100 表示达到阈值时一定摘除
+const fullEnforcing = 100
+
+// findCircuitBreakerPolicy 查找作用于服务的第一个熔断策略
+func findCircuitBreakerPolicy(serviceInfo *ServiceInfo, conf *model.ServiceWithCircuitBreaker) *api.DestinationSet {
+	if conf == nil || conf.CircuitBreaker == nil || conf.CircuitBreaker.Inbounds == "" {
+		return nil
+	}
+
+	var inBounds []*api.CbRule
+	if err := json.Unmarshal([]byte(conf.CircuitBreaker.Inbounds), &inBounds); err != nil {
+		log.Errorf("unmarshal inbounds circuitBreaker rule error, %v", err)
+		return nil
+	}
+
+	for _, rule := range inBounds {
+		for _, dest := range rule.GetDestinations() {
+			if dest.GetPolicy() == nil {
+				continue
+			}
+			if matchName(dest.GetService().GetValue(), serviceInfo.Name) &&
+				matchName(dest.GetNamespace().GetValue(), serviceInfo.Namespace) {
+				return dest
+			}
+		}
+	}
+	return nil
+}
+
+// makeOutlierDetection Translate the circuit breaker configuration of Polaris into OutlierDetection
+func makeOutlierDetection(serviceInfo *ServiceInfo) *cluster.OutlierDetection {
+	dest := findCircuitBreakerPolicy(serviceInfo, serviceInfo.CircuitBreaker)
+	if dest == nil {
+		return nil
+	}
+
+	policy := dest.Policy
+	outlierDetection := &cluster.OutlierDetection{}
+
+	if consecutive := policy.Consecutive; consecutive != nil && policyEnabled(consecutive.Enable) {
+		if consecutive.GetConsecutiveErrorToOpen().GetValue() > 0 {
+			outlierDetection.Consecutive_5Xx =
+				&wrappers.UInt32Value{Value: consecutive.GetConsecutiveErrorToOpen().GetValue()}
+			outlierDetection.EnforcingConsecutive_5Xx = &wrappers.UInt32Value{Value: fullEnforcing}
+		}
+	} else {
+		// envoy 默认开启连续错误摘除，没有配置时关闭
+		outlierDetection.EnforcingConsecutive_5Xx = &wrappers.UInt32Value{Value: 0}
+	}
+	if errorRate := policy.ErrorRate; errorRate != nil && policyEnabled(errorRate.Enable) {
+		outlierDetection.FailurePercentageRequestVolume =
+			&wrappers.UInt32Value{Value: errorRate.GetRequestVolumeThreshold().GetValue()}
+		outlierDetection.FailurePercentageThreshold =
+			&wrappers.UInt32Value{Value: errorRate.GetErrorRateToOpen().GetValue()}
+		outlierDetection.EnforcingFailurePercentage = &wrappers.UInt32Value{Value: fullEnforcing}
+		// 熔断按照单个实例判断，不要求集群的最少实例数
+		outlierDetection.FailurePercentageMinimumHosts = &wrappers.UInt32Value{Value: 1}
+	}
+	if policy.GetMaxEjectionPercent().GetValue() > 0 {
+		outlierDetection.MaxEjectionPercent = &wrappers.UInt32Value{Value: policy.GetMaxEjectionPercent().GetValue()}
+	}
+	// 按照度量周期进行熔断判断，没有配置时使用决策周期
+	if window := dest.GetMetricWindow(); window.GetSeconds() > 0 || window.GetNanos() > 0 {
+		outlierDetection.Interval = window
+	} else if judge := policy.GetJudgeDuration(); judge.GetSeconds() > 0 || judge.GetNanos() > 0 {
+		outlierDetection.Interval = judge
+	}
+	// 熔断后到半开的等待时间
+	if sleep := dest.GetRecover().GetSleepWindow(); sleep.GetSeconds() > 0 || sleep.GetNanos() > 0 {
+		outlierDetection.BaseEjectionTime = sleep
+	}
+
+	return outlierDetection
+}
+
+// policyEnabled 未启用的策略不生效，没有配置启用开关时按照启用处理
+func policyEnabled(enable *wrappers.BoolValue) bool {
+	return enable == nil || enable.GetValue()
+}
+
+// makeMaxRequests 单机并发限流规则对应服务实例入流量集群的最大并发请求数，没有规则时返回空
+func makeMaxRequests(serviceInfo *ServiceInfo) *wrappers.UInt32Value {
+	if serviceInfo.RateLimit == nil {
+		return nil
+	}
+	var maxRequests uint32
+	for _, rule := range serviceInfo.RateLimit.Rules {
+		if rule.GetDisable().GetValue() || rule.GetType() != api.Rule_LOCAL ||
+			rule.GetResource() != api.Rule_CONCURRENCY {
+			continue
+		}
+		// 集群的阈值无法按照请求标签区分，只使用没有标签的规则
+		if len(rule.Labels) > 0 || rule.GetMethod().GetValue().GetValue() != "" {
+			continue
+		}
+		for _, amount := range rule.Amounts {
+			value := amount.GetMaxAmount().GetValue()
+			if value > 0 && (maxRequests == 0 || value < maxRequests) {
+				maxRequests = value
+			}
+		}
+	}
+	if maxRequests == 0 {
+		return nil
+	}
+	return &wrappers.UInt32Value{Value: maxRequests}
+}
@@ -0,0 +1,193 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package xdsserverv3
+
+import (
+	"fmt"
+
+	"github.com/mitchellh/mapstructure"
+)
+
+const (
+	// SnapshotByNamespace 同一命名空间下的节点共享快照
+	SnapshotByNamespace = "namespace"
+	// SnapshotByNode 每个节点单独生成快照
+	SnapshotByNode = "node"
+	// SnapshotByGroup 按照节点 metadata 中的分组共享快照
+	SnapshotByGroup = "group"
+
+	// ListenerOutbound sidecar 出流量监听器，通过 original_dst 透明拦截
+	ListenerOutbound = "outbound"
+	// ListenerInbound sidecar 入流量监听器，转发到请求的原始地址
+	ListenerInbound = "inbound"
+	// ListenerGateway 网关监听器，按照域名路由到服务
+	ListenerGateway = "gateway"
+
+	// ProtocolHTTP 监听器使用 http_connection_manager
+	ProtocolHTTP = "http"
+	// ProtocolTCP 监听器使用 tcp_proxy
+	ProtocolTCP = "tcp"
+
+	// RoleSidecar sidecar 节点
+	RoleSidecar = "sidecar"
+	// RoleGateway 网关节点
+	RoleGateway = "gateway"
+
+	defaultNodeGroupKey = "polaris.group"
+)
+
+// TLSConfig 证书配置，文件由 envoy 读取
+type TLSConfig struct {
+	CertFile string `mapstructure:"certFile"`
+	KeyFile  string `mapstructure:"keyFile"`
+	// CAFile 监听器上配置时要求客户端证书，集群上配置时校验服务端证书
+	CAFile string `mapstructure:"caFile"`
+	// SNI 访问上游时使用的 SNI
+	SNI string `mapstructure:"sni"`
+}
+
+// ListenerConfig 下发给 envoy 的监听器
+type ListenerConfig struct {
+	Name      string `mapstructure:"name"`
+	Address   string `mapstructure:"address"`
+	Port      uint32 `mapstructure:"port"`
+	Direction string `mapstructure:"direction"`
+	Protocol  string `mapstructure:"protocol"`
+	// Cluster tcp 协议转发的目标集群，默认转发到请求的原始地址
+	Cluster string `mapstructure:"cluster"`
+	// Role 接收该监听器的节点角色
+	Role string     `mapstructure:"role"`
+	TLS  *TLSConfig `mapstructure:"tls"`
+}
+
+// Config xds 服务的配置
+type Config struct {
+	// Snapshot 快照的粒度
+	Snapshot string `mapstructure:"snapshot"`
+	// NodeGroupKey 按照分组生成快照时，节点 metadata 中分组的 key
+	NodeGroupKey string            `mapstructure:"nodeGroupKey"`
+	Listeners    []*ListenerConfig `mapstructure:"listeners"`
+	// ClusterTLS 访问服务实例时使用的证书
+	ClusterTLS *TLSConfig `mapstructure:"clusterTLS"`
+}
+
+// defaultListener 未配置监听器时，只下发 sidecar 的 15001 出流量监听器
+func defaultListener() *ListenerConfig {
+	return &ListenerConfig{
+		Name:      "listener_15001",
+		Port:      15001,
+		Direction: ListenerOutbound,
+	}
+}
+
+// parseConfig 解析 xds 服务的配置并填充默认值
+func parseConfig(option map[string]interface{}) (*Config, error) {
+	config := &Config{}
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		WeaklyTypedInput: true,
+		Result:           config,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := decoder.Decode(option); err != nil {
+		return nil, err
+	}
+
+	if config.Snapshot == "" {
+		config.Snapshot = SnapshotByNamespace
+	}
+	if config.Snapshot != SnapshotByNamespace && config.Snapshot != SnapshotByNode &&
+		config.Snapshot != SnapshotByGroup {
+		return nil, fmt.Errorf("snapshot should be namespace, node or group, got %s", config.Snapshot)
+	}
+	if config.NodeGroupKey == "" {
+		config.NodeGroupKey = defaultNodeGroupKey
+	}
+	if len(config.Listeners) == 0 {
+		config.Listeners = []*ListenerConfig{defaultListener()}
+	}
+
+	names := make(map[string]bool, len(config.Listeners))
+	for _, listener := range config.Listeners {
+		if err := listener.checkAndDefault(); err != nil {
+			return nil, err
+		}
+		if names[listener.Name] {
+			return nil, fmt.Errorf("listener %s is duplicated", listener.Name)
+		}
+		names[listener.Name] = true
+	}
+	if err := config.ClusterTLS.check(); err != nil {
+		return nil, fmt.Errorf("cluster tls: %v", err)
+	}
+	return config, nil
+}
+
+func (l *ListenerConfig) checkAndDefault() error {
+	if l.Port == 0 {
+		return fmt.Errorf("listener %s port is empty", l.Name)
+	}
+	if l.Direction == "" {
+		l.Direction = ListenerOutbound
+	}
+	if l.Direction != ListenerOutbound && l.Direction != ListenerInbound && l.Direction != ListenerGateway {
+		return fmt.Errorf("listener %s direction should be outbound, inbound or gateway", l.Name)
+	}
+	if l.Name == "" {
+		l.Name = fmt.Sprintf("%s_%d", l.Direction, l.Port)
+	}
+	if l.Address == "" {
+		l.Address = "0.0.0.0"
+	}
+	if l.Protocol == "" {
+		l.Protocol = ProtocolHTTP
+	}
+	if l.Protocol != ProtocolHTTP && l.Protocol != ProtocolTCP {
+		return fmt.Errorf("listener %s protocol should be http or tcp", l.Name)
+	}
+	if l.Cluster == "" {
+		l.Cluster = passthroughClusterName
+	}
+	if l.Role == "" {
+		l.Role = RoleSidecar
+		if l.Direction == ListenerGateway {
+			l.Role = RoleGateway
+		}
+	}
+	if err := l.TLS.check(); err != nil {
+		return fmt.Errorf("listener %s tls: %v", l.Name, err)
+	}
+	if l.TLS != nil && l.TLS.CertFile == "" {
+		return fmt.Errorf("listener %s tls: certFile is empty", l.Name)
+	}
+	return nil
+}
+
+func (t *TLSConfig) check() error {
+	if t == nil {
+		return nil
+	}
+	if (t.CertFile == "") != (t.KeyFile == "") {
+		return fmt.Errorf("certFile and keyFile should be set together")
+	}
+	if t.CertFile == "" && t.CAFile == "" {
+		return fmt.Errorf("neither certificate nor ca is set")
+	}
+	return nil
+}
@@ -0,0 +1,272 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package xdsserverv3
+
+import (
+	core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	listener "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	route "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	tcp "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/tcp_proxy/v3"
+	tls "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/cache/types"
+	"github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/wrappers"
+)
+
+const (
+	passthroughClusterName = "PassthroughCluster"
+	// inboundClusterName 节点代理的服务的入流量集群，转发到请求的原始地址，承载单机的并发阈值
+	inboundClusterName = "InboundPassthroughCluster"
+	// outboundRouteName 出流量和网关监听器使用的路由配置
+	outboundRouteName = "polaris-router"
+	// inboundRouteName 入流量监听器使用的路由配置，转发到请求的原始地址
+	inboundRouteName = "polaris-inbound"
+)
+
+// makeListeners 生成节点角色对应的监听器
+func makeListeners(confs []*ListenerConfig, role string) []types.Resource {
+	var listeners []types.Resource
+	for _, conf := range confs {
+		if conf.Role != role {
+			continue
+		}
+		listeners = append(listeners, makeListener(conf))
+	}
+	return listeners
+}
+
+// makeInboundRouteConfiguration 入流量的请求全部转发到原始地址
+// 节点声明了代理的服务时，服务的单机限流规则作用于入流量，每个实例独立计算配额
+func makeInboundRouteConfiguration(served *ServiceInfo) *route.RouteConfiguration {
+	routes := []*route.Route{getDefaultRoute(passthroughClusterName)}
+	if served != nil {
+		routes = []*route.Route{getDefaultRoute(inboundClusterName)}
+		applyLocalRateLimit(served, routes)
+	}
+	return &route.RouteConfiguration{
+		Name: inboundRouteName,
+		ValidateClusters: &wrappers.BoolValue{
+			Value: false,
+		},
+		VirtualHosts: []*route.VirtualHost{
+			{
+				Name:    "inbound",
+				Domains: []string{"*"},
+				Routes:  routes,
+			},
+		},
+	}
+}
+
+// listenerRouteNames 节点角色的监听器引用的路由配置，快照中只能包含被引用的路由配置
+func listenerRouteNames(confs []*ListenerConfig, role string) map[string]bool {
+	names := make(map[string]bool)
+	for _, conf := range confs {
+		if conf.Role == role && conf.Protocol == ProtocolHTTP {
+			names[conf.routeName()] = true
+		}
+	}
+	return names
+}
+
+func (l *ListenerConfig) routeName() string {
+	if l.Direction == ListenerInbound {
+		return inboundRouteName
+	}
+	return outboundRouteName
+}
+
+func makeListener(conf *ListenerConfig) *listener.Listener {
+	var filter *listener.Filter
+	if conf.Protocol == ProtocolTCP {
+		filter = makeTCPProxyFilter(conf.Cluster)
+	} else {
+		filter = makeHTTPConnectionManagerFilter(conf.routeName())
+	}
+
+	l := &listener.Listener{
+		Name: conf.Name,
+		Address: &core.Address{
+			Address: &core.Address_SocketAddress{
+				SocketAddress: &core.SocketAddress{
+					Protocol: core.SocketAddress_TCP,
+					Address:  conf.Address,
+					PortSpecifier: &core.SocketAddress_PortValue{
+						PortValue: conf.Port,
+					},
+				},
+			},
+		},
+		FilterChains: []*listener.FilterChain{
+			{
+				Filters:         []*listener.Filter{filter},
+				TransportSocket: makeDownstreamTLS(conf.TLS),
+			},
+		},
+	}
+
+	// sidecar 通过 iptables 拦截流量，需要还原请求的原始地址
+	if conf.Direction != ListenerGateway {
+		l.ListenerFilters = []*listener.ListenerFilter{
+			{
+				Name: wellknown.OriginalDestination,
+			},
+		}
+	}
+	// 出流量中的非 http 请求直接转发到原始地址
+	if conf.Direction == ListenerOutbound && conf.Protocol == ProtocolHTTP {
+		l.DefaultFilterChain = &listener.FilterChain{
+			Name:    "PassthroughFilterChain",
+			Filters: []*listener.Filter{makeTCPProxyFilter(passthroughClusterName)},
+		}
+	}
+	return l
+}
+
+func makeHTTPConnectionManagerFilter(routeName string) *listener.Filter {
+	localRateLimit, err := makeLocalRateLimitFilter()
+	if err != nil {
+		panic(err)
+	}
+
+	manager := &hcm.HttpConnectionManager{
+		CodecType:  hcm.HttpConnectionManager_AUTO,
+		StatPrefix: "http",
+		RouteSpecifier: &hcm.HttpConnectionManager_Rds{
+			Rds: &hcm.Rds{
+				ConfigSource: &core.ConfigSource{
+					ResourceApiVersion: resource.DefaultAPIVersion,
+					ConfigSourceSpecifier: &core.ConfigSource_Ads{
+						Ads: &core.AggregatedConfigSource{},
+					},
+				},
+				RouteConfigName: routeName,
+			},
+		},
+		HttpFilters: []*hcm.HttpFilter{
+			{
+				Name: LocalRateLimitFilterName,
+				ConfigType: &hcm.HttpFilter_TypedConfig{
+					TypedConfig: localRateLimit,
+				},
+			},
+			{
+				Name: wellknown.Router,
+			},
+		},
+	}
+
+	pbst, err := ptypes.MarshalAny(manager)
+	if err != nil {
+		panic(err)
+	}
+	return &listener.Filter{
+		Name: wellknown.HTTPConnectionManager,
+		ConfigType: &listener.Filter_TypedConfig{
+			TypedConfig: pbst,
+		},
+	}
+}
+
+func makeTCPProxyFilter(clusterName string) *listener.Filter {
+	tcpConfig := &tcp.TcpProxy{
+		StatPrefix: clusterName,
+		ClusterSpecifier: &tcp.TcpProxy_Cluster{
+			Cluster: clusterName,
+		},
+	}
+
+	tcpC, err := ptypes.MarshalAny(tcpConfig)
+	if err != nil {
+		panic(err)
+	}
+	return &listener.Filter{
+		Name: wellknown.TCPProxy,
+		ConfigType: &listener.Filter_TypedConfig{
+			TypedConfig: tcpC,
+		},
+	}
+}
+
+// makeDownstreamTLS 监听器的证书，配置 CA 时要求客户端提供证书
+func makeDownstreamTLS(conf *TLSConfig) *core.TransportSocket {
+	if conf == nil {
+		return nil
+	}
+	tlsContext := &tls.DownstreamTlsContext{
+		CommonTlsContext: makeCommonTLSContext(conf),
+	}
+	if conf.CAFile != "" {
+		tlsContext.RequireClientCertificate = &wrappers.BoolValue{Value: true}
+	}
+	return makeTLSTransportSocket(tlsContext)
+}
+
+// makeUpstreamTLS 访问服务实例时使用的证书，配置 CA 时校验服务端证书
+func makeUpstreamTLS(conf *TLSConfig) *core.TransportSocket {
+	if conf == nil {
+		return nil
+	}
+	return makeTLSTransportSocket(&tls.UpstreamTlsContext{
+		CommonTlsContext: makeCommonTLSContext(conf),
+		Sni:              conf.SNI,
+	})
+}
+
+func makeCommonTLSContext(conf *TLSConfig) *tls.CommonTlsContext {
+	tlsContext := &tls.CommonTlsContext{}
+	if conf.CertFile != "" {
+		tlsContext.TlsCertificates = []*tls.TlsCertificate{
+			{
+				CertificateChain: &core.DataSource{
+					Specifier: &core.DataSource_Filename{Filename: conf.CertFile},
+				},
+				PrivateKey: &core.DataSource{
+					Specifier: &core.DataSource_Filename{Filename: conf.KeyFile},
+				},
+			},
+		}
+	}
+	if conf.CAFile != "" {
+		tlsContext.ValidationContextType = &tls.CommonTlsContext_ValidationContext{
+			ValidationContext: &tls.CertificateValidationContext{
+				TrustedCa: &core.DataSource{
+					Specifier: &core.DataSource_Filename{Filename: conf.CAFile},
+				},
+			},
+		}
+	}
+	return tlsContext
+}
+
+func makeTLSTransportSocket(tlsContext proto.Message) *core.TransportSocket {
+	config, err := ptypes.MarshalAny(tlsContext)
+	if err != nil {
+		panic(err)
+	}
+	return &core.TransportSocket{
+		Name: wellknown.TransportSocketTls,
+		ConfigType: &core.TransportSocket_TypedConfig{
+			TypedConfig: config,
+		},
+	}
+}
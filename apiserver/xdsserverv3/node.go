@@ -0,0 +1,230 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package xdsserverv3
+
+import (
+	"strings"
+
+	core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	discovery "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	testv3 "github.com/envoyproxy/go-control-plane/pkg/test/v3"
+)
+
+const (
+	// 节点 metadata 中描述服务视图的字段
+	nodeMetaRole         = "polaris.role"
+	nodeMetaService      = "polaris.service"
+	nodeMetaDependencies = "polaris.dependencies"
+)
+
+// nodeView 节点看到的服务视图，共享快照的节点使用第一个连接上来的节点的视图
+type nodeView struct {
+	key       string
+	namespace string
+	role      string
+	// service sidecar 代理的服务，为空时不下发出流量规则
+	service string
+	// dependencies 节点依赖的服务，为空时下发命名空间下的全部服务
+	dependencies map[string]bool
+}
+
+// newNodeView 根据节点 ID 和 metadata 生成服务视图
+func newNodeView(key string, node *core.Node) *nodeView {
+	view := &nodeView{
+		key:       key,
+		namespace: nodeNamespace(node),
+		role:      nodeMetadata(node, nodeMetaRole),
+		service:   nodeMetadata(node, nodeMetaService),
+	}
+	if view.role == "" {
+		view.role = RoleSidecar
+	}
+	for _, dependency := range strings.Split(nodeMetadata(node, nodeMetaDependencies), ",") {
+		if dependency = strings.TrimSpace(dependency); dependency != "" {
+			if view.dependencies == nil {
+				view.dependencies = make(map[string]bool)
+			}
+			view.dependencies[dependency] = true
+		}
+	}
+	return view
+}
+
+// namespaceView 按照命名空间共享快照时的服务视图
+func namespaceView(namespace string) *nodeView {
+	return &nodeView{key: namespace, namespace: namespace, role: RoleSidecar}
+}
+
+// services 过滤出节点依赖的服务
+func (v *nodeView) services(all []*ServiceInfo) []*ServiceInfo {
+	if len(v.dependencies) == 0 {
+		return all
+	}
+	var services []*ServiceInfo
+	for _, service := range all {
+		if v.dependencies[service.Name] {
+			services = append(services, service)
+		}
+	}
+	return services
+}
+
+// callers 节点代理的主调服务，用于匹配路由规则的来源
+// 节点没有声明服务时无法确定主调方，只按照服务名匹配入流量规则的来源，不下发任何服务的出流量规则
+func (v *nodeView) callers(all []*ServiceInfo) []*ServiceInfo {
+	if v.service == "" {
+		callers := make([]*ServiceInfo, 0, len(all))
+		for _, service := range all {
+			callers = append(callers, &ServiceInfo{Name: service.Name, Namespace: service.Namespace})
+		}
+		return callers
+	}
+	for _, service := range all {
+		if service.Name == v.service {
+			return []*ServiceInfo{service}
+		}
+	}
+	// 服务还没有注册时，只按照命名空间匹配来源
+	return []*ServiceInfo{{Name: v.service, Namespace: v.namespace}}
+}
+
+// served 节点代理的服务，节点没有声明服务或者服务还没有注册时返回空
+func (v *nodeView) served(all []*ServiceInfo) *ServiceInfo {
+	if v.service == "" {
+		return nil
+	}
+	for _, service := range all {
+		if service.Name == v.service {
+			return service
+		}
+	}
+	return nil
+}
+
+// nodeNamespace id 的格式是 namespace/uuid~hostIp
+func nodeNamespace(node *core.Node) string {
+	if node == nil || node.Id == "" || !strings.Contains(node.Id, "/") {
+		return ""
+	}
+	return strings.Split(node.Id, "/")[0]
+}
+
+func nodeMetadata(node *core.Node, key string) string {
+	return node.GetMetadata().GetFields()[key].GetStringValue()
+}
+
+// nodeCallbacks 记录连接上来的节点，为节点生成快照
+type nodeCallbacks struct {
+	*testv3.Callbacks
+	server *XDSServer
+}
+
+// OnStreamRequest 收到节点的请求
+func (cb *nodeCallbacks) OnStreamRequest(id int64, req *discovery.DiscoveryRequest) error {
+	if err := cb.Callbacks.OnStreamRequest(id, req); err != nil {
+		return err
+	}
+	cb.server.onNodeRequest(id, req.GetNode())
+	return nil
+}
+
+// OnStreamClosed 节点的连接断开
+func (cb *nodeCallbacks) OnStreamClosed(id int64) {
+	cb.Callbacks.OnStreamClosed(id)
+	cb.server.onStreamClosed(id)
+}
+
+// OnStreamDeltaRequest 收到节点的增量请求
+func (cb *nodeCallbacks) OnStreamDeltaRequest(id int64, req *discovery.DeltaDiscoveryRequest) error {
+	if err := cb.Callbacks.OnStreamDeltaRequest(id, req); err != nil {
+		return err
+	}
+	cb.server.onNodeRequest(id, req.GetNode())
+	return nil
+}
+
+// OnDeltaStreamClosed 节点的增量连接断开
+func (cb *nodeCallbacks) OnDeltaStreamClosed(id int64) {
+	cb.Callbacks.OnDeltaStreamClosed(id)
+	cb.server.onStreamClosed(id)
+}
+
+// onNodeRequest 节点第一次请求时生成快照，envoy 只在连接建立后的首个请求中携带完整的节点信息
+func (x *XDSServer) onNodeRequest(streamID int64, node *core.Node) {
+	if x.config.Snapshot == SnapshotByNamespace || node == nil {
+		return
+	}
+	key := x.nodeHash.ID(node)
+	if key == "" {
+		return
+	}
+
+	x.lock.Lock()
+	defer x.lock.Unlock()
+
+	if _, ok := x.streams[streamID]; ok {
+		return
+	}
+	x.streams[streamID] = key
+	x.nodeRefs[key]++
+	if _, ok := x.nodes[key]; ok {
+		return
+	}
+	view := newNodeView(key, node)
+	x.nodes[key] = view
+
+	log.Infof("[XDS] new node view %s, namespace: %s, role: %s, service: %s",
+		key, view.namespace, view.role, view.service)
+	if err := x.pushSnapshot(x.nextVersion(), view, x.registryInfo[view.namespace]); err != nil {
+		log.Errorf("[XDS] push snapshot for node %s error %v", key, err)
+	}
+}
+
+// onStreamClosed 共享快照的节点全部断开后清理快照
+func (x *XDSServer) onStreamClosed(streamID int64) {
+	x.lock.Lock()
+	defer x.lock.Unlock()
+
+	key, ok := x.streams[streamID]
+	if !ok {
+		return
+	}
+	delete(x.streams, streamID)
+	x.nodeRefs[key]--
+	if x.nodeRefs[key] > 0 {
+		return
+	}
+	delete(x.nodeRefs, key)
+	delete(x.nodes, key)
+	x.cache.ClearSnapshot(key)
+	log.Infof("[XDS] node view %s has been removed", key)
+}
+
+// nodeViews 获取命名空间下需要推送快照的服务视图，调用方需要持有 x.lock
+func (x *XDSServer) nodeViews(namespace string) []*nodeView {
+	if x.config.Snapshot == SnapshotByNamespace {
+		return []*nodeView{namespaceView(namespace)}
+	}
+	var views []*nodeView
+	for _, view := range x.nodes {
+		if view.namespace == namespace {
+			views = append(views, view)
+		}
+	}
+	return views
+}
@@ -0,0 +1,215 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package xdsserverv3
+
+import (
+	"testing"
+
+	core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	listener "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	cachev3 "github.com/envoyproxy/go-control-plane/pkg/cache/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+	_struct "github.com/golang/protobuf/ptypes/struct"
+	"go.uber.org/atomic"
+
+	api "github.com/polarismesh/polaris-server/common/api/v1"
+)
+
+func newNode(id string, metadata map[string]string) *core.Node {
+	fields := make(map[string]*_struct.Value, len(metadata))
+	for k, v := range metadata {
+		fields[k] = &_struct.Value{Kind: &_struct.Value_StringValue{StringValue: v}}
+	}
+	return &core.Node{Id: id, Metadata: &_struct.Struct{Fields: fields}}
+}
+
+func TestParseConfig(t *testing.T) {
+	config, err := parseConfig(map[string]interface{}{"listenPort": 15010})
+	if err != nil {
+		t.Fatalf("parse config: %v", err)
+	}
+	if config.Snapshot != SnapshotByNamespace || config.NodeGroupKey != defaultNodeGroupKey ||
+		len(config.Listeners) != 1 || config.Listeners[0].Name != "listener_15001" ||
+		config.Listeners[0].Role != RoleSidecar || config.Listeners[0].Cluster != passthroughClusterName {
+		t.Fatalf("default config: %+v", config)
+	}
+
+	// yaml 解析出来的嵌套配置是 map[interface{}]interface{}
+	config, err = parseConfig(map[string]interface{}{
+		"snapshot": "group",
+		"listeners": []interface{}{
+			map[interface{}]interface{}{"port": "8080", "direction": "gateway"},
+			map[interface{}]interface{}{"port": 15006, "direction": "inbound", "protocol": "tcp"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("parse config: %v", err)
+	}
+	if config.Listeners[0].Name != "gateway_8080" || config.Listeners[0].Role != RoleGateway ||
+		config.Listeners[1].Protocol != ProtocolTCP || config.Listeners[1].Role != RoleSidecar {
+		t.Fatalf("listeners: %+v %+v", config.Listeners[0], config.Listeners[1])
+	}
+
+	for _, option := range []map[string]interface{}{
+		{"snapshot": "pod"},
+		{"listeners": []interface{}{map[string]interface{}{"direction": "outbound"}}},
+		{"listeners": []interface{}{map[string]interface{}{"port": 80, "protocol": "udp"}}},
+		{"listeners": []interface{}{map[string]interface{}{"port": 80}, map[string]interface{}{"port": 80}}},
+		{"listeners": []interface{}{map[string]interface{}{"port": 80, "tls": map[string]interface{}{"caFile": "ca"}}}},
+		{"clusterTLS": map[string]interface{}{"certFile": "cert"}},
+	} {
+		if _, err := parseConfig(option); err == nil {
+			t.Fatalf("option %v should be invalid", option)
+		}
+	}
+}
+
+func TestPolarisNodeHash(t *testing.T) {
+	node := newNode("default/uuid~127.0.0.1", map[string]string{"polaris.group": "gateway"})
+	cases := []struct {
+		hash PolarisNodeHash
+		node *core.Node
+		want string
+	}{
+		{PolarisNodeHash{mode: SnapshotByNamespace}, node, "default"},
+		{PolarisNodeHash{mode: SnapshotByNode}, node, "default/uuid~127.0.0.1"},
+		{PolarisNodeHash{mode: SnapshotByGroup, groupKey: "polaris.group"}, node, "default/gateway"},
+		{PolarisNodeHash{mode: SnapshotByGroup, groupKey: "group"}, node, "default"},
+		{PolarisNodeHash{mode: SnapshotByNode}, newNode("uuid~127.0.0.1", nil), ""},
+		{PolarisNodeHash{mode: SnapshotByNode}, nil, ""},
+	}
+	for _, c := range cases {
+		if got := c.hash.ID(c.node); got != c.want {
+			t.Fatalf("hash %+v: want %s, got %s", c.hash, c.want, got)
+		}
+	}
+}
+
+func TestNodeView(t *testing.T) {
+	services := []*ServiceInfo{
+		{Name: "caller", Namespace: "default"},
+		{Name: "callee", Namespace: "default"},
+		{Name: "other", Namespace: "default"},
+	}
+	view := newNodeView("default/uuid", newNode("default/uuid", map[string]string{
+		nodeMetaService:      "caller",
+		nodeMetaDependencies: "callee, missing",
+	}))
+	if view.role != RoleSidecar || view.namespace != "default" {
+		t.Fatalf("view: %+v", view)
+	}
+	if deps := view.services(services); len(deps) != 1 || deps[0].Name != "callee" {
+		t.Fatalf("services: %+v", deps)
+	}
+	if callers := view.callers(services); len(callers) != 1 || callers[0] != services[0] {
+		t.Fatalf("callers: %+v", callers)
+	}
+	if served := view.served(services); served != services[0] {
+		t.Fatalf("served: %+v", served)
+	}
+
+	view = newNodeView("default", newNode("default/uuid", nil))
+	if len(view.services(services)) != 3 || len(view.callers(services)) != 3 {
+		t.Fatalf("view without metadata should see all services")
+	}
+	if view.served(services) != nil {
+		t.Fatalf("view without metadata should not serve any service")
+	}
+}
+
+func TestPushSnapshot(t *testing.T) {
+	config, err := parseConfig(map[string]interface{}{
+		"snapshot": "node",
+		"listeners": []interface{}{
+			map[string]interface{}{"port": 15001},
+			map[string]interface{}{"port": 15006, "direction": "inbound"},
+			map[string]interface{}{"port": 8443, "direction": "gateway", "tls": map[string]interface{}{
+				"certFile": "server.crt", "keyFile": "server.key", "caFile": "ca.crt",
+			}},
+			map[string]interface{}{"port": 9000, "direction": "gateway", "protocol": "tcp", "cluster": "callee"},
+		},
+		"clusterTLS": map[string]interface{}{"caFile": "ca.crt", "sni": "callee"},
+	})
+	if err != nil {
+		t.Fatalf("parse config: %v", err)
+	}
+	x := &XDSServer{
+		config:     config,
+		nodeHash:   PolarisNodeHash{mode: config.Snapshot},
+		versionNum: atomic.NewUint64(0),
+		nodes:      make(map[string]*nodeView),
+		nodeRefs:   make(map[string]int),
+		streams:    make(map[int64]string),
+		registryInfo: map[string][]*ServiceInfo{"default": {
+			{Name: "callee", Namespace: "default", Instances: []*api.Instance{}},
+			{Name: "other", Namespace: "default", Instances: []*api.Instance{}},
+		}},
+	}
+	x.cache = cachev3.NewSnapshotCache(false, x.nodeHash, nil)
+
+	sidecar := newNode("default/sidecar~127.0.0.1", map[string]string{nodeMetaDependencies: "callee"})
+	gateway := newNode("default/gateway~127.0.0.1", map[string]string{nodeMetaRole: RoleGateway})
+	x.onNodeRequest(1, sidecar)
+	x.onNodeRequest(2, gateway)
+	x.onNodeRequest(3, gateway)
+
+	snapshot, err := x.cache.GetSnapshot(sidecar.Id)
+	if err != nil {
+		t.Fatalf("sidecar snapshot: %v", err)
+	}
+	if len(snapshot.GetResources(resource.ListenerType)) != 2 || len(snapshot.GetResources(resource.RouteType)) != 2 {
+		t.Fatalf("sidecar snapshot: %+v", snapshot)
+	}
+	if len(snapshot.GetResources(resource.ClusterType)) != 2 || snapshot.GetResources(resource.EndpointType)["callee"] == nil {
+		t.Fatalf("sidecar should only see callee: %+v", snapshot.GetResources(resource.ClusterType))
+	}
+
+	snapshot, err = x.cache.GetSnapshot(gateway.Id)
+	if err != nil {
+		t.Fatalf("gateway snapshot: %v", err)
+	}
+	listeners := snapshot.GetResources(resource.ListenerType)
+	if len(listeners) != 2 || len(snapshot.GetResources(resource.ClusterType)) != 3 {
+		t.Fatalf("gateway snapshot: %+v", snapshot)
+	}
+	for _, res := range listeners {
+		l := res.(*listener.Listener)
+		if err := l.Validate(); err != nil {
+			t.Fatalf("validate listener %s: %v", l.Name, err)
+		}
+		if len(l.ListenerFilters) != 0 || l.DefaultFilterChain != nil {
+			t.Fatalf("gateway listener should not use original_dst: %+v", l)
+		}
+	}
+	if listeners["gateway_8443"].(*listener.Listener).FilterChains[0].TransportSocket == nil {
+		t.Fatalf("gateway listener should use tls")
+	}
+
+	// 共享快照的连接全部断开后清理快照
+	x.onStreamClosed(2)
+	if _, err := x.cache.GetSnapshot(gateway.Id); err != nil {
+		t.Fatalf("gateway snapshot should be kept: %v", err)
+	}
+	x.onStreamClosed(3)
+	if _, err := x.cache.GetSnapshot(gateway.Id); err == nil {
+		t.Fatalf("gateway snapshot should be cleared")
+	}
+	if len(x.nodeViews("default")) != 1 {
+		t.Fatalf("node views: %+v", x.nodes)
+	}
+}
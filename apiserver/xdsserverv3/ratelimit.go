@@ -0,0 +1,262 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package xdsserverv3
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	route "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	ratelimit "github.com/envoyproxy/go-control-plane/envoy/extensions/common/ratelimit/v3"
+	localratelimit "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/local_ratelimit/v3"
+	envoytype "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	api "github.com/polarismesh/polaris-server/common/api/v1"
+)
+
+const (
+	// LocalRateLimitFilterName envoy 单机限流插件
+	LocalRateLimitFilterName = "envoy.filters.http.local_ratelimit"
+	localRateLimitStatPrefix = "http_local_rate_limiter"
+
+	// envoy 限流动作生成的 descriptor key
+	descriptorKeyHeaderMatch = "header_match"
+	descriptorKeyGenericKey  = "generic_key"
+)
+
+// makeLocalRateLimitFilter 监听器上的单机限流插件，不配置令牌桶，由路由上的配置生效
+func makeLocalRateLimitFilter() (*any.Any, error) {
+	return ptypes.MarshalAny(&localratelimit.LocalRateLimit{
+		StatPrefix: localRateLimitStatPrefix,
+	})
+}
+
+// localRateLimitRules 获取服务生效的单机QPS限流规则，按照优先级排序
+func localRateLimitRules(serviceInfo *ServiceInfo) []*api.Rule {
+	if serviceInfo.RateLimit == nil {
+		return nil
+	}
+	var rules []*api.Rule
+	for _, rule := range serviceInfo.RateLimit.Rules {
+		if rule.GetDisable().GetValue() || rule.GetType() != api.Rule_LOCAL || rule.GetResource() != api.Rule_QPS {
+			continue
+		}
+		rules = append(rules, rule)
+	}
+	sort.SliceStable(rules, func(i, j int) bool {
+		return rules[i].GetPriority().GetValue() < rules[j].GetPriority().GetValue()
+	})
+	return rules
+}
+
+// applyLocalRateLimit 将服务的单机限流规则下发到服务的每一条路由上
+// 每个限流规则的每个阈值对应一个 descriptor，请求匹配规则的标签时生成 descriptor 并消耗对应的令牌
+func applyLocalRateLimit(serviceInfo *ServiceInfo, routes []*route.Route) {
+	var rateLimits []*route.RateLimit
+	var descriptors []*ratelimit.LocalRateLimitDescriptor
+	for _, rule := range localRateLimitRules(serviceInfo) {
+		ruleRateLimits, ruleDescriptors, err := makeRuleRateLimits(rule)
+		if err != nil {
+			log.Warnf("[XDS][RateLimit] skip rate limit rule %s of service %s/%s: %v",
+				rule.GetId().GetValue(), serviceInfo.Namespace, serviceInfo.Name, err)
+			continue
+		}
+		rateLimits = append(rateLimits, ruleRateLimits...)
+		descriptors = append(descriptors, ruleDescriptors...)
+	}
+	if len(descriptors) == 0 {
+		return
+	}
+
+	config, err := ptypes.MarshalAny(&localratelimit.LocalRateLimit{
+		StatPrefix: localRateLimitStatPrefix,
+		// 路由级别的令牌桶不限制流量，descriptor 的周期需要是它的整数倍
+		TokenBucket: &envoytype.TokenBucket{
+			MaxTokens:     math.MaxUint32,
+			TokensPerFill: &wrappers.UInt32Value{Value: math.MaxUint32},
+			FillInterval:  durationpb.New(time.Second),
+		},
+		FilterEnabled:  fullRuntimeFraction("local_rate_limit_enabled"),
+		FilterEnforced: fullRuntimeFraction("local_rate_limit_enforced"),
+		Descriptors:    descriptors,
+	})
+	if err != nil {
+		log.Errorf("[XDS][RateLimit] marshal local rate limit of service %s/%s: %v",
+			serviceInfo.Namespace, serviceInfo.Name, err)
+		return
+	}
+	for _, r := range routes {
+		if action := r.GetRoute(); action != nil {
+			action.RateLimits = rateLimits
+		}
+		if r.TypedPerFilterConfig == nil {
+			r.TypedPerFilterConfig = make(map[string]*any.Any)
+		}
+		r.TypedPerFilterConfig[LocalRateLimitFilterName] = config
+	}
+}
+
+func makeRuleRateLimits(rule *api.Rule) ([]*route.RateLimit, []*ratelimit.LocalRateLimitDescriptor, error) {
+	labels := make(map[string]*api.MatchString, len(rule.Labels)+1)
+	for key, value := range rule.Labels {
+		labels[key] = value
+	}
+	// 被调接口按照请求路径匹配
+	if rule.GetMethod().GetValue().GetValue() != "" {
+		labels[routeLabelPath] = rule.GetMethod()
+	}
+	headers, err := makeRateLimitHeaders(labels)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var rateLimits []*route.RateLimit
+	var descriptors []*ratelimit.LocalRateLimitDescriptor
+	for i, amount := range rule.Amounts {
+		maxAmount := amount.GetMaxAmount().GetValue()
+		if maxAmount == 0 {
+			continue
+		}
+		value := fmt.Sprintf("%s-%d", rule.GetId().GetValue(), i)
+		action, key := makeRateLimitAction(value, headers)
+		rateLimits = append(rateLimits, &route.RateLimit{Actions: []*route.RateLimit_Action{action}})
+		descriptors = append(descriptors, &ratelimit.LocalRateLimitDescriptor{
+			Entries: []*ratelimit.RateLimitDescriptor_Entry{{Key: key, Value: value}},
+			TokenBucket: &envoytype.TokenBucket{
+				MaxTokens:     maxAmount,
+				TokensPerFill: &wrappers.UInt32Value{Value: maxAmount},
+				FillInterval:  durationpb.New(fillInterval(amount)),
+			},
+		})
+	}
+	if len(descriptors) == 0 {
+		return nil, nil, fmt.Errorf("no valid amount")
+	}
+	return rateLimits, descriptors, nil
+}
+
+// makeRateLimitAction 没有标签时对所有请求生成 descriptor，否则只有请求头匹配时生成
+func makeRateLimitAction(value string, headers []*route.HeaderMatcher) (*route.RateLimit_Action, string) {
+	if len(headers) == 0 {
+		return &route.RateLimit_Action{
+			ActionSpecifier: &route.RateLimit_Action_GenericKey_{
+				GenericKey: &route.RateLimit_Action_GenericKey{DescriptorValue: value},
+			},
+		}, descriptorKeyGenericKey
+	}
+	return &route.RateLimit_Action{
+		ActionSpecifier: &route.RateLimit_Action_HeaderValueMatch_{
+			HeaderValueMatch: &route.RateLimit_Action_HeaderValueMatch{
+				DescriptorValue: value,
+				ExpectMatch:     &wrappers.BoolValue{Value: true},
+				Headers:         headers,
+			},
+		},
+	}, descriptorKeyHeaderMatch
+}
+
+// makeRateLimitHeaders 限流动作只能匹配请求头，请求路径通过 :path 匹配并忽略查询参数
+func makeRateLimitHeaders(labels map[string]*api.MatchString) ([]*route.HeaderMatcher, error) {
+	keys := make([]string, 0, len(labels))
+	for key := range labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var headers []*route.HeaderMatcher
+	for _, key := range keys {
+		matchString := labels[key]
+		if matchString.GetValueType() != api.MatchString_TEXT {
+			return nil, fmt.Errorf("label %s: value type %s is not supported", key, matchString.GetValueType())
+		}
+		var name string
+		switch {
+		case key == routeLabelPath:
+			headerMatch, err := makePathHeaderMatcher(matchString)
+			if err != nil {
+				return nil, fmt.Errorf("label %s: %v", key, err)
+			}
+			headers = append(headers, headerMatch)
+			continue
+		case key == routeLabelMethod:
+			name = ":method"
+		case strings.HasPrefix(key, routeLabelHeaderPrefix) || !strings.HasPrefix(key, "$"):
+			name = strings.TrimPrefix(key, routeLabelHeaderPrefix)
+		default:
+			return nil, fmt.Errorf("label %s is not supported", key)
+		}
+		headerMatch, err := makeHeaderMatcher(name, matchString)
+		if err != nil {
+			return nil, fmt.Errorf("label %s: %v", key, err)
+		}
+		headers = append(headers, headerMatch)
+	}
+	return headers, nil
+}
+
+func makePathHeaderMatcher(matchString *api.MatchString) (*route.HeaderMatcher, error) {
+	var regex string
+	switch matchString.GetType() {
+	case api.MatchString_EXACT, api.MatchString_NOT_EQUALS:
+		regex = regexp.QuoteMeta(matchString.GetValue().GetValue())
+	case api.MatchString_REGEX:
+		regex = partialRegex(matchString.GetValue().GetValue())
+	case api.MatchString_IN:
+		regex = "(?:" + inRegex(matchString.GetValue().GetValue()) + ")"
+	default:
+		return nil, fmt.Errorf("match type %s is not supported", matchString.GetType())
+	}
+	return &route.HeaderMatcher{
+		Name: ":path",
+		HeaderMatchSpecifier: &route.HeaderMatcher_SafeRegexMatch{
+			SafeRegexMatch: makeRegexMatcher(regex + `(\?.*)?`),
+		},
+		InvertMatch: matchString.GetType() == api.MatchString_NOT_EQUALS,
+	}, nil
+}
+
+// fillInterval 限流周期按秒向上取整，最小为1秒
+func fillInterval(amount *api.Amount) time.Duration {
+	interval := time.Duration(amount.GetValidDuration().GetSeconds()) * time.Second
+	if amount.GetValidDuration().GetNanos() > 0 {
+		interval += time.Second
+	}
+	if interval < time.Second {
+		interval = time.Second
+	}
+	return interval
+}
+
+func fullRuntimeFraction(runtimeKey string) *core.RuntimeFractionalPercent {
+	return &core.RuntimeFractionalPercent{
+		DefaultValue: &envoytype.FractionalPercent{
+			Numerator:   100,
+			Denominator: envoytype.FractionalPercent_HUNDRED,
+		},
+		RuntimeKey: runtimeKey,
+	}
+}
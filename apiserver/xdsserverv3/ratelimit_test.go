@@ -0,0 +1,207 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package xdsserverv3
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	route "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	localratelimit "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/local_ratelimit/v3"
+	"github.com/golang/protobuf/ptypes"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	api "github.com/polarismesh/polaris-server/common/api/v1"
+	"github.com/polarismesh/polaris-server/common/model"
+	"github.com/polarismesh/polaris-server/common/utils"
+)
+
+func amount(maxAmount uint32, duration time.Duration) *api.Amount {
+	return &api.Amount{MaxAmount: utils.NewUInt32Value(maxAmount), ValidDuration: durationpb.New(duration)}
+}
+
+func TestApplyLocalRateLimit(t *testing.T) {
+	serviceInfo := &ServiceInfo{
+		Name:      "callee",
+		Namespace: "default",
+		RateLimit: &api.RateLimit{Rules: []*api.Rule{
+			{
+				Id:      utils.NewStringValue("rule-1"),
+				Type:    api.Rule_LOCAL,
+				Labels:  map[string]*api.MatchString{"$header.user": matchString(api.MatchString_EXACT, "guest")},
+				Method:  matchString(api.MatchString_EXACT, "/api/echo"),
+				Amounts: []*api.Amount{amount(10, time.Second), amount(100, 1500*time.Millisecond)},
+			},
+			{
+				Id:      utils.NewStringValue("rule-2"),
+				Type:    api.Rule_LOCAL,
+				Amounts: []*api.Amount{amount(1000, time.Second)},
+			},
+			{
+				Id:      utils.NewStringValue("global"),
+				Type:    api.Rule_GLOBAL,
+				Amounts: []*api.Amount{amount(1, time.Second)},
+			},
+			{
+				Id:      utils.NewStringValue("query"),
+				Type:    api.Rule_LOCAL,
+				Labels:  map[string]*api.MatchString{"$query.uid": matchString(api.MatchString_EXACT, "1")},
+				Amounts: []*api.Amount{amount(1, time.Second)},
+			},
+			{
+				Id:       utils.NewStringValue("concurrency"),
+				Type:     api.Rule_LOCAL,
+				Resource: api.Rule_CONCURRENCY,
+				Amounts:  []*api.Amount{amount(64, time.Second)},
+			},
+		}},
+	}
+
+	routes := makeRoutes(serviceInfo, []*ServiceInfo{serviceInfo})
+	applyLocalRateLimit(serviceInfo, routes)
+
+	r := routes[len(routes)-1]
+	if err := r.Validate(); err != nil {
+		t.Fatalf("validate route: %v", err)
+	}
+	rateLimits := r.GetRoute().RateLimits
+	if len(rateLimits) != 3 {
+		t.Fatalf("rate limits: %+v", rateLimits)
+	}
+	headerMatch := rateLimits[0].Actions[0].GetHeaderValueMatch()
+	if headerMatch.GetDescriptorValue() != "rule-1-0" || len(headerMatch.Headers) != 2 {
+		t.Fatalf("header match: %+v", headerMatch)
+	}
+	if rateLimits[2].Actions[0].GetGenericKey().GetDescriptorValue() != "rule-2-0" {
+		t.Fatalf("generic key: %+v", rateLimits[2])
+	}
+
+	config := &localratelimit.LocalRateLimit{}
+	if err := ptypes.UnmarshalAny(r.TypedPerFilterConfig[LocalRateLimitFilterName], config); err != nil {
+		t.Fatalf("unmarshal config: %v", err)
+	}
+	if err := config.Validate(); err != nil {
+		t.Fatalf("validate config: %v", err)
+	}
+	if len(config.Descriptors) != 3 {
+		t.Fatalf("descriptors: %+v", config.Descriptors)
+	}
+	// 周期按秒向上取整
+	if config.Descriptors[1].TokenBucket.FillInterval.AsDuration() != 2*time.Second {
+		t.Fatalf("descriptor: %+v", config.Descriptors[1])
+	}
+
+	if makeMaxRequests(serviceInfo).GetValue() != 64 {
+		t.Fatalf("max requests: %+v", makeMaxRequests(serviceInfo))
+	}
+
+	// 单机限流作用于节点代理的服务的入流量，主调方的出流量路由不限流
+	inbound := makeInboundRouteConfiguration(serviceInfo)
+	inboundRoute := inbound.VirtualHosts[0].Routes[0]
+	if inboundRoute.GetRoute().GetCluster() != inboundClusterName || len(inboundRoute.GetRoute().RateLimits) != 3 ||
+		inboundRoute.TypedPerFilterConfig[LocalRateLimitFilterName] == nil {
+		t.Fatalf("inbound route: %+v", inboundRoute)
+	}
+	if route := makeInboundRouteConfiguration(nil).VirtualHosts[0].Routes[0]; route.GetRoute().GetCluster() !=
+		passthroughClusterName || route.TypedPerFilterConfig != nil {
+		t.Fatalf("inbound route without service: %+v", route)
+	}
+	for _, r := range makeVirtualHosts([]*ServiceInfo{serviceInfo}, nil).VirtualHosts[0].Routes {
+		if r.TypedPerFilterConfig != nil || len(r.GetRoute().RateLimits) != 0 {
+			t.Fatalf("outbound route should not be limited: %+v", r)
+		}
+	}
+	cluster := makePassthroughCluster(inboundClusterName, makeMaxRequests(serviceInfo))
+	if err := cluster.Validate(); err != nil {
+		t.Fatalf("validate cluster: %v", err)
+	}
+	if cluster.CircuitBreakers.Thresholds[0].MaxRequests.GetValue() != 64 {
+		t.Fatalf("inbound cluster: %+v", cluster)
+	}
+}
+
+func TestApplyLocalRateLimitWithoutRules(t *testing.T) {
+	serviceInfo := &ServiceInfo{Name: "callee", Namespace: "default"}
+	routes := []*route.Route{getDefaultRoute(serviceInfo.Name)}
+	applyLocalRateLimit(serviceInfo, routes)
+	if routes[0].TypedPerFilterConfig != nil || routes[0].GetRoute().RateLimits != nil {
+		t.Fatalf("route: %+v", routes[0])
+	}
+	if makeMaxRequests(serviceInfo) != nil {
+		t.Fatalf("max requests should be nil")
+	}
+}
+
+func TestMakeOutlierDetection(t *testing.T) {
+	inbounds, _ := json.Marshal([]*api.CbRule{{
+		Destinations: []*api.DestinationSet{
+			{
+				Service: utils.NewStringValue("other"),
+				Policy:  &api.CbPolicy{},
+			},
+			{
+				Service:      utils.NewStringValue("*"),
+				Namespace:    utils.NewStringValue("*"),
+				MetricWindow: durationpb.New(30 * time.Second),
+				Recover:      &api.RecoverConfig{SleepWindow: durationpb.New(time.Minute)},
+				Policy: &api.CbPolicy{
+					ErrorRate: &api.CbPolicy_ErrRateConfig{
+						Enable:                 utils.NewBoolValue(true),
+						RequestVolumeThreshold: utils.NewUInt32Value(10),
+						ErrorRateToOpen:        utils.NewUInt32Value(50),
+					},
+					Consecutive: &api.CbPolicy_ConsecutiveErrConfig{
+						Enable:                 utils.NewBoolValue(false),
+						ConsecutiveErrorToOpen: utils.NewUInt32Value(5),
+					},
+					MaxEjectionPercent: utils.NewUInt32Value(30),
+				},
+			},
+		},
+	}})
+	serviceInfo := &ServiceInfo{
+		Name:      "callee",
+		Namespace: "default",
+		CircuitBreaker: &model.ServiceWithCircuitBreaker{
+			CircuitBreaker: &model.CircuitBreaker{Inbounds: string(inbounds)},
+		},
+	}
+
+	outlierDetection := makeOutlierDetection(serviceInfo)
+	if err := outlierDetection.Validate(); err != nil {
+		t.Fatalf("validate outlier detection: %v", err)
+	}
+	if outlierDetection.FailurePercentageThreshold.GetValue() != 50 ||
+		outlierDetection.FailurePercentageRequestVolume.GetValue() != 10 ||
+		outlierDetection.EnforcingFailurePercentage.GetValue() != 100 {
+		t.Fatalf("failure percentage: %+v", outlierDetection)
+	}
+	if outlierDetection.Consecutive_5Xx != nil || outlierDetection.EnforcingConsecutive_5Xx.GetValue() != 0 {
+		t.Fatalf("consecutive: %+v", outlierDetection)
+	}
+	if outlierDetection.MaxEjectionPercent.GetValue() != 30 ||
+		outlierDetection.Interval.AsDuration() != 30*time.Second ||
+		outlierDetection.BaseEjectionTime.AsDuration() != time.Minute {
+		t.Fatalf("outlier detection: %+v", outlierDetection)
+	}
+
+	if makeOutlierDetection(&ServiceInfo{Name: "callee"}) != nil {
+		t.Fatalf("outlier detection should be nil")
+	}
+}
@@ -0,0 +1,449 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package xdsserverv3
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+
+	cluster "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	route "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	matcher "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
+	_struct "github.com/golang/protobuf/ptypes/struct"
+	"github.com/golang/protobuf/ptypes/wrappers"
+
+	api "github.com/polarismesh/polaris-server/common/api/v1"
+)
+
+const (
+	// 路由规则中的请求标签，不带前缀的标签按照 header 处理
+	routeLabelHeaderPrefix = "$header."
+	routeLabelQueryPrefix  = "$query."
+	routeLabelPath         = "$path"
+	routeLabelMethod       = "$method"
+
+	matchAll = "*"
+	// 多个值使用逗号分隔
+	inValueSeparator = ","
+)
+
+// serviceRoutes 获取作用于服务的路由规则，主调方的出流量规则优先于被调方的入流量规则
+// callers 为当前 sidecar 可能代理的主调服务，规则中的来源服务和命名空间需要和主调服务匹配
+func serviceRoutes(serviceInfo *ServiceInfo, callers []*ServiceInfo) []*api.Route {
+	var routes []*api.Route
+	for _, caller := range callers {
+		if caller.Routing == nil {
+			continue
+		}
+		for _, r := range caller.Routing.Outbounds {
+			if rule := filterRoute(r, serviceInfo, []*ServiceInfo{caller}); rule != nil {
+				routes = append(routes, rule)
+			}
+		}
+	}
+	if serviceInfo.Routing != nil {
+		for _, r := range serviceInfo.Routing.Inbounds {
+			if rule := filterRoute(r, serviceInfo, callers); rule != nil {
+				routes = append(routes, rule)
+			}
+		}
+	}
+	return routes
+}
+
+// filterRoute 过滤出规则中和主调服务匹配的来源以及指向被调服务的目标，规则不适用时返回空
+func filterRoute(r *api.Route, serviceInfo *ServiceInfo, callers []*ServiceInfo) *api.Route {
+	var destinations []*api.Destination
+	for _, destination := range r.Destinations {
+		if matchName(destination.GetService().GetValue(), serviceInfo.Name) &&
+			matchName(destination.GetNamespace().GetValue(), serviceInfo.Namespace) {
+			destinations = append(destinations, destination)
+		}
+	}
+	if len(destinations) == 0 {
+		return nil
+	}
+	// 没有来源时规则对所有主调方生效
+	if len(r.Sources) == 0 {
+		return &api.Route{Destinations: destinations}
+	}
+	var sources []*api.Source
+	for _, source := range r.Sources {
+		for _, caller := range callers {
+			if matchName(source.GetService().GetValue(), caller.Name) &&
+				matchName(source.GetNamespace().GetValue(), caller.Namespace) {
+				sources = append(sources, source)
+				break
+			}
+		}
+	}
+	if len(sources) == 0 {
+		return nil
+	}
+	return &api.Route{Sources: sources, Destinations: destinations}
+}
+
+func matchName(pattern string, name string) bool {
+	return pattern == "" || pattern == matchAll || pattern == name
+}
+
+func makeRoutes(serviceInfo *ServiceInfo, callers []*ServiceInfo) []*route.Route {
+	var routes []*route.Route
+
+	for _, r := range serviceRoutes(serviceInfo, callers) {
+		action := makeRouteAction(serviceInfo, r.Destinations)
+		if action == nil {
+			// 目标全部被隔离，交给后续的规则处理
+			continue
+		}
+		if len(r.Sources) == 0 {
+			routes = append(routes, &route.Route{
+				Match:  &route.RouteMatch{PathSpecifier: &route.RouteMatch_Prefix{Prefix: "/"}},
+				Action: action,
+			})
+			continue
+		}
+		// 多个来源之间是或的关系，每个来源生成一条路由
+		for _, source := range r.Sources {
+			match, err := makeRouteMatch(source.Metadata)
+			if err != nil {
+				log.Warnf("[XDS][Routing] skip route source of service %s/%s: %v",
+					serviceInfo.Namespace, serviceInfo.Name, err)
+				continue
+			}
+			routes = append(routes, &route.Route{Match: match, Action: action})
+		}
+	}
+
+	// 如果没有路由，会进入最后的默认处理
+	routes = append(routes, getDefaultRoute(serviceInfo.Name))
+	return routes
+}
+
+// makeRouteMatch 将来源的请求标签转换为 envoy 的路由匹配条件
+func makeRouteMatch(metadata map[string]*api.MatchString) (*route.RouteMatch, error) {
+	match := &route.RouteMatch{
+		PathSpecifier: &route.RouteMatch_Prefix{
+			Prefix: "/",
+		},
+	}
+
+	// 按照标签排序，保证每次生成的配置一致
+	keys := make([]string, 0, len(metadata))
+	for key := range metadata {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		matchString := metadata[key]
+		if matchString.GetValueType() != api.MatchString_TEXT {
+			return nil, fmt.Errorf("label %s: value type %s is not supported", key, matchString.GetValueType())
+		}
+		switch {
+		case key == routeLabelPath:
+			if err := setPathMatch(match, matchString); err != nil {
+				return nil, fmt.Errorf("label %s: %v", key, err)
+			}
+		case key == routeLabelMethod:
+			headerMatch, err := makeHeaderMatcher(":method", matchString)
+			if err != nil {
+				return nil, fmt.Errorf("label %s: %v", key, err)
+			}
+			match.Headers = append(match.Headers, headerMatch)
+		case strings.HasPrefix(key, routeLabelQueryPrefix):
+			queryMatch, err := makeQueryParameterMatcher(strings.TrimPrefix(key, routeLabelQueryPrefix), matchString)
+			if err != nil {
+				return nil, fmt.Errorf("label %s: %v", key, err)
+			}
+			match.QueryParameters = append(match.QueryParameters, queryMatch)
+		case strings.HasPrefix(key, routeLabelHeaderPrefix) || !strings.HasPrefix(key, "$"):
+			headerMatch, err := makeHeaderMatcher(strings.TrimPrefix(key, routeLabelHeaderPrefix), matchString)
+			if err != nil {
+				return nil, fmt.Errorf("label %s: %v", key, err)
+			}
+			match.Headers = append(match.Headers, headerMatch)
+		default:
+			return nil, fmt.Errorf("label %s is not supported", key)
+		}
+	}
+	return match, nil
+}
+
+func setPathMatch(match *route.RouteMatch, matchString *api.MatchString) error {
+	value := matchString.GetValue().GetValue()
+	switch matchString.GetType() {
+	case api.MatchString_EXACT:
+		match.PathSpecifier = &route.RouteMatch_Path{Path: value}
+	case api.MatchString_REGEX:
+		match.PathSpecifier = &route.RouteMatch_SafeRegex{SafeRegex: makeRegexMatcher(partialRegex(value))}
+	case api.MatchString_IN:
+		match.PathSpecifier = &route.RouteMatch_SafeRegex{SafeRegex: makeRegexMatcher(inRegex(value))}
+	default:
+		return fmt.Errorf("match type %s is not supported", matchString.GetType())
+	}
+	return nil
+}
+
+func makeHeaderMatcher(name string, matchString *api.MatchString) (*route.HeaderMatcher, error) {
+	stringMatcher, invert, err := makeStringMatcher(matchString)
+	if err != nil {
+		return nil, err
+	}
+	return &route.HeaderMatcher{
+		Name:                 name,
+		HeaderMatchSpecifier: &route.HeaderMatcher_StringMatch{StringMatch: stringMatcher},
+		InvertMatch:          invert,
+	}, nil
+}
+
+func makeQueryParameterMatcher(name string, matchString *api.MatchString) (*route.QueryParameterMatcher, error) {
+	stringMatcher, invert, err := makeStringMatcher(matchString)
+	if err != nil {
+		return nil, err
+	}
+	// 查询参数不支持取反
+	if invert {
+		return nil, errors.New("query parameter does not support not equals")
+	}
+	return &route.QueryParameterMatcher{
+		Name: name,
+		QueryParameterMatchSpecifier: &route.QueryParameterMatcher_StringMatch{
+			StringMatch: stringMatcher,
+		},
+	}, nil
+}
+
+// makeStringMatcher 转换匹配规则，不等于通过精确匹配取反实现
+func makeStringMatcher(matchString *api.MatchString) (*matcher.StringMatcher, bool, error) {
+	value := matchString.GetValue().GetValue()
+	switch matchString.GetType() {
+	case api.MatchString_EXACT:
+		return &matcher.StringMatcher{MatchPattern: &matcher.StringMatcher_Exact{Exact: value}}, false, nil
+	case api.MatchString_NOT_EQUALS:
+		return &matcher.StringMatcher{MatchPattern: &matcher.StringMatcher_Exact{Exact: value}}, true, nil
+	case api.MatchString_REGEX:
+		return &matcher.StringMatcher{
+			MatchPattern: &matcher.StringMatcher_SafeRegex{SafeRegex: makeRegexMatcher(partialRegex(value))},
+		}, false, nil
+	case api.MatchString_IN:
+		return &matcher.StringMatcher{
+			MatchPattern: &matcher.StringMatcher_SafeRegex{SafeRegex: makeRegexMatcher(inRegex(value))},
+		}, false, nil
+	default:
+		return nil, false, fmt.Errorf("match type %s is not supported", matchString.GetType())
+	}
+}
+
+func makeRegexMatcher(regex string) *matcher.RegexMatcher {
+	return &matcher.RegexMatcher{
+		EngineType: &matcher.RegexMatcher_GoogleRe2{GoogleRe2: &matcher.RegexMatcher_GoogleRE2{}},
+		Regex:      regex,
+	}
+}
+
+// partialRegex polaris 的正则只需要匹配部分内容，envoy 的 SafeRegex 要求完整匹配，需要在前后补上任意字符
+func partialRegex(regex string) string {
+	return ".*(?:" + regex + ").*"
+}
+
+// inRegex 将逗号分隔的多个值转换为完整匹配其中任意一个值的正则
+func inRegex(value string) string {
+	values := strings.Split(value, inValueSeparator)
+	for i := range values {
+		values[i] = regexp.QuoteMeta(strings.TrimSpace(values[i]))
+	}
+	return strings.Join(values, "|")
+}
+
+// makeRouteAction 根据目标生成带权重的 subset 集群
+// 隔离的目标不分配流量；只使用存在可用实例的最高优先级分组，分组内按照权重分配
+func makeRouteAction(serviceInfo *ServiceInfo, destinations []*api.Destination) *route.Route_Route {
+	groups := make(map[uint32][]*api.Destination)
+	var priorities []uint32
+	for _, destination := range destinations {
+		if destination.GetIsolate().GetValue() {
+			continue
+		}
+		if !isExactMetadata(destination.Metadata) {
+			log.Warnf("[XDS][Routing] skip destination of service %s/%s, only exact metadata is supported",
+				serviceInfo.Namespace, serviceInfo.Name)
+			continue
+		}
+		// 没有设置优先级的认为优先级最低
+		priority := uint32(math.MaxUint32)
+		if destination.Priority != nil {
+			priority = destination.Priority.GetValue()
+		}
+		if _, ok := groups[priority]; !ok {
+			priorities = append(priorities, priority)
+		}
+		groups[priority] = append(groups[priority], destination)
+	}
+	if len(priorities) == 0 {
+		return nil
+	}
+	sort.Slice(priorities, func(i, j int) bool {
+		return priorities[i] < priorities[j]
+	})
+
+	selected := groups[priorities[0]]
+	for _, priority := range priorities {
+		if hasAvailableInstance(serviceInfo, groups[priority]) {
+			selected = groups[priority]
+			break
+		}
+	}
+
+	weightedClusters := makeWeightedClusters(serviceInfo.Name, selected)
+	if len(weightedClusters) == 0 {
+		return nil
+	}
+	var totalWeight uint32
+	for _, weightedCluster := range weightedClusters {
+		totalWeight += weightedCluster.Weight.GetValue()
+	}
+	return &route.Route_Route{
+		Route: &route.RouteAction{
+			ClusterSpecifier: &route.RouteAction_WeightedClusters{
+				WeightedClusters: &route.WeightedCluster{
+					TotalWeight: &wrappers.UInt32Value{Value: totalWeight},
+					Clusters:    weightedClusters,
+				},
+			},
+		},
+	}
+}
+
+// makeWeightedClusters 部分设置权重时，没有设置权重的目标不分配流量；全部没有设置权重时平均分配
+func makeWeightedClusters(clusterName string, destinations []*api.Destination) []*route.WeightedCluster_ClusterWeight {
+	weighted := false
+	for _, destination := range destinations {
+		if destination.GetWeight().GetValue() > 0 {
+			weighted = true
+			break
+		}
+	}
+
+	var weightedClusters []*route.WeightedCluster_ClusterWeight
+	for _, destination := range destinations {
+		weight := destination.GetWeight().GetValue()
+		if !weighted {
+			weight = 1
+		}
+		if weight == 0 {
+			continue
+		}
+
+		// makeClusters() 也使用目标的 metadata 生成对应的 subset
+		fields := make(map[string]*_struct.Value)
+		for k, v := range destination.Metadata {
+			fields[k] = &_struct.Value{
+				Kind: &_struct.Value_StringValue{
+					StringValue: v.GetValue().GetValue(),
+				},
+			}
+		}
+		weightedClusters = append(weightedClusters, &route.WeightedCluster_ClusterWeight{
+			Name:   clusterName,
+			Weight: &wrappers.UInt32Value{Value: weight},
+			MetadataMatch: &core.Metadata{
+				FilterMetadata: map[string]*_struct.Struct{
+					"envoy.lb": {
+						Fields: fields,
+					},
+				},
+			},
+		})
+	}
+	return weightedClusters
+}
+
+// isExactMetadata envoy 的 subset 只支持精确匹配实例标签
+func isExactMetadata(metadata map[string]*api.MatchString) bool {
+	for _, matchString := range metadata {
+		if matchString.GetType() != api.MatchString_EXACT || matchString.GetValueType() != api.MatchString_TEXT {
+			return false
+		}
+	}
+	return true
+}
+
+// hasAvailableInstance 分组中是否存在健康且未隔离的实例
+func hasAvailableInstance(serviceInfo *ServiceInfo, destinations []*api.Destination) bool {
+	for _, instance := range serviceInfo.Instances {
+		if !instance.GetHealthy().GetValue() || instance.GetIsolate().GetValue() {
+			continue
+		}
+		for _, destination := range destinations {
+			if instanceMatches(instance, destination.Metadata) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func instanceMatches(instance *api.Instance, metadata map[string]*api.MatchString) bool {
+	for key, matchString := range metadata {
+		if instance.Metadata[key] != matchString.GetValue().GetValue() {
+			return false
+		}
+	}
+	return true
+}
+
+// makeLbSubsetConfig 为路由规则中的每一组目标标签生成 subset
+func makeLbSubsetConfig(serviceInfo *ServiceInfo, callers []*ServiceInfo) *cluster.Cluster_LbSubsetConfig {
+	routes := serviceRoutes(serviceInfo, callers)
+	if len(routes) == 0 {
+		return nil
+	}
+
+	lbSubsetConfig := &cluster.Cluster_LbSubsetConfig{}
+	var subsetSelectors []*cluster.Cluster_LbSubsetConfig_LbSubsetSelector
+	lbSubsetConfig.FallbackPolicy = cluster.Cluster_LbSubsetConfig_ANY_ENDPOINT
+
+	selected := make(map[string]bool)
+	for _, r := range routes {
+		// 对每一组 destination 的标签产生一个 subset
+		for _, destination := range r.Destinations {
+			var keys []string
+			for s := range destination.Metadata {
+				keys = append(keys, s)
+			}
+			sort.Strings(keys)
+			if selected[strings.Join(keys, ",")] {
+				continue
+			}
+			selected[strings.Join(keys, ",")] = true
+			subsetSelectors = append(subsetSelectors, &cluster.Cluster_LbSubsetConfig_LbSubsetSelector{
+				Keys:           keys,
+				FallbackPolicy: cluster.Cluster_LbSubsetConfig_LbSubsetSelector_NO_FALLBACK,
+			})
+		}
+	}
+
+	lbSubsetConfig.SubsetSelectors = subsetSelectors
+	return lbSubsetConfig
+}
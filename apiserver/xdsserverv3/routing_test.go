@@ -0,0 +1,193 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package xdsserverv3
+
+import (
+	"regexp"
+	"testing"
+
+	route "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+
+	api "github.com/polarismesh/polaris-server/common/api/v1"
+	"github.com/polarismesh/polaris-server/common/utils"
+)
+
+func matchString(matchType api.MatchString_MatchStringType, value string) *api.MatchString {
+	return &api.MatchString{Type: matchType, Value: utils.NewStringValue(value)}
+}
+
+func destination(env string, priority uint32, weight uint32) *api.Destination {
+	return &api.Destination{
+		Service:  utils.NewStringValue("callee"),
+		Metadata: map[string]*api.MatchString{"env": matchString(api.MatchString_EXACT, env)},
+		Priority: utils.NewUInt32Value(priority),
+		Weight:   utils.NewUInt32Value(weight),
+	}
+}
+
+func TestMakeRouteMatch(t *testing.T) {
+	match, err := makeRouteMatch(map[string]*api.MatchString{
+		"$path":        matchString(api.MatchString_REGEX, "/api/.*"),
+		"$method":      matchString(api.MatchString_IN, "GET, POST"),
+		"$query.uid":   matchString(api.MatchString_EXACT, "10"),
+		"$header.user": matchString(api.MatchString_NOT_EQUALS, "guest"),
+		"region":       matchString(api.MatchString_EXACT, "gz"),
+	})
+	if err != nil {
+		t.Fatalf("make route match: %v", err)
+	}
+	// polaris 的正则是部分匹配，envoy 要求完整匹配
+	if match.GetSafeRegex().GetRegex() != ".*(?:/api/.*).*" {
+		t.Fatalf("path: %+v", match.PathSpecifier)
+	}
+	if !regexp.MustCompile("^(?:" + partialRegex("api|v1") + ")$").MatchString("/api/users") {
+		t.Fatalf("regex should match part of the value")
+	}
+	if len(match.QueryParameters) != 1 || match.QueryParameters[0].GetStringMatch().GetExact() != "10" {
+		t.Fatalf("query: %+v", match.QueryParameters)
+	}
+	headers := make(map[string]*route.HeaderMatcher)
+	for _, header := range match.Headers {
+		headers[header.Name] = header
+	}
+	if headers[":method"].GetStringMatch().GetSafeRegex().GetRegex() != "GET|POST" {
+		t.Fatalf("method: %+v", headers[":method"])
+	}
+	if !headers["user"].InvertMatch || headers["user"].GetStringMatch().GetExact() != "guest" {
+		t.Fatalf("header: %+v", headers["user"])
+	}
+	if headers["region"].GetStringMatch().GetExact() != "gz" {
+		t.Fatalf("header: %+v", headers["region"])
+	}
+
+	if _, err := makeRouteMatch(map[string]*api.MatchString{
+		"$query.uid": matchString(api.MatchString_NOT_EQUALS, "10"),
+	}); err == nil {
+		t.Fatalf("query parameter not equals should not be supported")
+	}
+	if _, err := makeRouteMatch(map[string]*api.MatchString{
+		"$caller_ip": matchString(api.MatchString_EXACT, "127.0.0.1"),
+	}); err == nil {
+		t.Fatalf("unknown label should not be supported")
+	}
+}
+
+func TestMakeRouteAction(t *testing.T) {
+	serviceInfo := &ServiceInfo{
+		Name:      "callee",
+		Namespace: "default",
+		Instances: []*api.Instance{
+			{Healthy: utils.NewBoolValue(true), Metadata: map[string]string{"env": "gray"}},
+			{Healthy: utils.NewBoolValue(false), Metadata: map[string]string{"env": "prod"}},
+		},
+	}
+
+	t.Run("优先级高的分组没有可用实例时使用下一个分组", func(t *testing.T) {
+		action := makeRouteAction(serviceInfo, []*api.Destination{
+			destination("prod", 0, 100),
+			destination("gray", 1, 20),
+			destination("base", 1, 0),
+		})
+		clusters := action.Route.GetWeightedClusters().GetClusters()
+		if len(clusters) != 1 || clusters[0].Weight.GetValue() != 20 {
+			t.Fatalf("clusters: %+v", clusters)
+		}
+		if clusters[0].MetadataMatch.FilterMetadata["envoy.lb"].Fields["env"].GetStringValue() != "gray" {
+			t.Fatalf("clusters: %+v", clusters)
+		}
+	})
+
+	t.Run("隔离的目标不分配流量", func(t *testing.T) {
+		isolated := destination("gray", 0, 100)
+		isolated.Isolate = utils.NewBoolValue(true)
+		if action := makeRouteAction(serviceInfo, []*api.Destination{isolated}); action != nil {
+			t.Fatalf("action: %+v", action)
+		}
+	})
+
+	t.Run("没有设置权重时平均分配", func(t *testing.T) {
+		action := makeRouteAction(serviceInfo, []*api.Destination{
+			destination("gray", 0, 0),
+			destination("prod", 0, 0),
+		})
+		if action.Route.GetWeightedClusters().GetTotalWeight().GetValue() != 2 {
+			t.Fatalf("action: %+v", action)
+		}
+	})
+}
+
+func TestMakeRoutes(t *testing.T) {
+	callee := &ServiceInfo{
+		Name:      "callee",
+		Namespace: "default",
+		Routing: &api.Routing{
+			Inbounds: []*api.Route{{
+				Sources: []*api.Source{{
+					Service:   utils.NewStringValue("*"),
+					Namespace: utils.NewStringValue("*"),
+					Metadata:  map[string]*api.MatchString{"env": matchString(api.MatchString_EXACT, "prod")},
+				}},
+				Destinations: []*api.Destination{destination("prod", 0, 100)},
+			}, {
+				Sources: []*api.Source{{
+					Service:   utils.NewStringValue("other"),
+					Namespace: utils.NewStringValue("production"),
+				}},
+				Destinations: []*api.Destination{destination("base", 0, 100)},
+			}},
+		},
+	}
+	caller := &ServiceInfo{
+		Name:      "caller",
+		Namespace: "default",
+		Routing: &api.Routing{
+			Outbounds: []*api.Route{{
+				Sources: []*api.Source{{
+					Service:   utils.NewStringValue("caller"),
+					Namespace: utils.NewStringValue("default"),
+					Metadata:  map[string]*api.MatchString{"$header.env": matchString(api.MatchString_EXACT, "gray")},
+				}},
+				Destinations: []*api.Destination{destination("gray", 0, 100)},
+			}},
+		},
+	}
+
+	routes := makeRoutes(callee, []*ServiceInfo{callee, caller})
+	// 出流量规则、入流量规则、默认路由，来源命名空间不匹配的规则不生效
+	if len(routes) != 3 {
+		t.Fatalf("routes: %+v", routes)
+	}
+	if routes[0].Match.Headers[0].Name != "env" || routes[0].Match.Headers[0].GetStringMatch().GetExact() != "gray" {
+		t.Fatalf("outbound route: %+v", routes[0])
+	}
+	if routes[2].GetRoute().GetCluster() != "callee" {
+		t.Fatalf("default route: %+v", routes[2])
+	}
+
+	subsets := makeLbSubsetConfig(callee, []*ServiceInfo{callee, caller})
+	if len(subsets.SubsetSelectors) != 1 || subsets.SubsetSelectors[0].Keys[0] != "env" {
+		t.Fatalf("subsets: %+v", subsets)
+	}
+
+	// 节点没有声明服务时不下发主调方的出流量规则
+	callers := namespaceView("default").callers([]*ServiceInfo{callee, caller})
+	routes = makeRoutes(callee, callers)
+	if len(routes) != 2 || routes[0].Match.Headers[0].GetStringMatch().GetExact() != "prod" {
+		t.Fatalf("namespace routes: %+v", routes)
+	}
+}
@@ -19,21 +19,18 @@ package xdsserverv3
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	cluster "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
 	core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	envoy_config_core_v3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	endpoint "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
-	listener "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
 	route "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
-	hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
-	tcp "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/tcp_proxy/v3"
 	clusterservice "github.com/envoyproxy/go-control-plane/envoy/service/cluster/v3"
 	discoverygrpc "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
 	endpointservice "github.com/envoyproxy/go-control-plane/envoy/service/endpoint/v3"
@@ -46,7 +43,6 @@ import (
 	"github.com/envoyproxy/go-control-plane/pkg/resource/v3"
 	serverv3 "github.com/envoyproxy/go-control-plane/pkg/server/v3"
 	testv3 "github.com/envoyproxy/go-control-plane/pkg/test/v3"
-	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
 	"github.com/golang/protobuf/ptypes"
 	_struct "github.com/golang/protobuf/ptypes/struct"
 	"github.com/golang/protobuf/ptypes/wrappers"
@@ -80,25 +76,44 @@ type XDSServer struct {
 	versionNum      *atomic.Uint64
 	server          *grpc.Server
 	connLimitConfig *connlimit.Config
+	config          *Config
+	nodeHash        PolarisNodeHash
 
+	// lock 保护服务信息和连接上来的节点，推送快照时也需要持有
+	lock         sync.Mutex
 	registryInfo map[string][]*ServiceInfo
+	// nodes 按照快照 key 记录节点的服务视图，nodeRefs 记录共享快照的连接数
+	nodes    map[string]*nodeView
+	nodeRefs map[string]int
+	streams  map[int64]string
 }
 
 // PolarisNodeHash 存放 hash 方法
-type PolarisNodeHash struct{}
+type PolarisNodeHash struct {
+	mode     string
+	groupKey string
+}
 
 // ID id 的格式是 namespace/uuid~hostIp
-func (PolarisNodeHash) ID(node *envoy_config_core_v3.Node) string {
-	if node == nil {
-		return ""
-	}
-	if node.Id == "" || !strings.Contains(node.Id, "/") {
+func (h PolarisNodeHash) ID(node *envoy_config_core_v3.Node) string {
+	namespace := nodeNamespace(node)
+	if namespace == "" {
 		return ""
 	}
-	// 每个命名空间下的 envoy node 拥有相同的服务视图
-	namespace := strings.Split(node.Id, "/")[0]
 
-	return namespace
+	switch h.mode {
+	case SnapshotByNode:
+		return node.Id
+	case SnapshotByGroup:
+		// 没有分组的节点使用命名空间的服务视图
+		if group := nodeMetadata(node, h.groupKey); group != "" {
+			return namespace + "/" + group
+		}
+		return namespace
+	default:
+		// 每个命名空间下的 envoy node 拥有相同的服务视图
+		return namespace
+	}
 }
 
 // GetProtocol 服务注册到北极星中的协议
@@ -121,109 +136,20 @@ type ServiceInfo struct {
 	Routing            *api.Routing
 	SvcRoutingRevision string
 	Ports              string
-}
-
-func makeLbSubsetConfig(serviceInfo *ServiceInfo) *cluster.Cluster_LbSubsetConfig {
-	if serviceInfo.Routing != nil && serviceInfo.Routing.Inbounds != nil &&
-		len(serviceInfo.Routing.Inbounds) > 0 {
-		lbSubsetConfig := &cluster.Cluster_LbSubsetConfig{}
-		var subsetSelectors []*cluster.Cluster_LbSubsetConfig_LbSubsetSelector
-		lbSubsetConfig.FallbackPolicy = cluster.Cluster_LbSubsetConfig_ANY_ENDPOINT
-
-		for _, inbound := range serviceInfo.Routing.Inbounds {
-			// 对每一个 destination 产生一个 subset
-			for _, destination := range inbound.Destinations {
-				var keys []string
-				for s := range destination.Metadata {
-					keys = append(keys, s)
-				}
-				subsetSelectors = append(subsetSelectors, &cluster.Cluster_LbSubsetConfig_LbSubsetSelector{
-					Keys:           keys,
-					FallbackPolicy: cluster.Cluster_LbSubsetConfig_LbSubsetSelector_NO_FALLBACK,
-				})
-			}
-		}
 
-		lbSubsetConfig.SubsetSelectors = subsetSelectors
-		return lbSubsetConfig
-	}
-	return nil
+	RateLimit                 *api.RateLimit
+	SvcRateLimitRevision      string
+	CircuitBreaker            *model.ServiceWithCircuitBreaker
+	SvcCircuitBreakerRevision string
 }
 
-// Translate the circuit breaker configuration of Polaris into OutlierDetection
-func makeOutlierDetection(conf *model.ServiceWithCircuitBreaker) *cluster.OutlierDetection {
-	if conf != nil {
-		cbRules := conf.CircuitBreaker.Inbounds
-		if cbRules == "" {
-			return nil
-		}
-
-		var inBounds []*api.CbRule
-		if err := json.Unmarshal([]byte(cbRules), &inBounds); err != nil {
-			log.Errorf("unmarshal inbounds circuitBreaker rule error, %v", err)
-			return nil
-		}
-
-		if len(inBounds) == 0 || len(inBounds[0].GetDestinations()) == 0 ||
-			inBounds[0].GetDestinations()[0].Policy == nil {
-			return nil
-		}
-
-		var (
-			consecutiveErrConfig *api.CbPolicy_ConsecutiveErrConfig
-			errorRateConfig      *api.CbPolicy_ErrRateConfig
-			policy               *api.CbPolicy
-			dest                 *api.DestinationSet
-		)
-
-		dest = inBounds[0].GetDestinations()[0]
-		policy = dest.Policy
-		consecutiveErrConfig = policy.Consecutive
-		errorRateConfig = policy.ErrorRate
-
-		outlierDetection := &cluster.OutlierDetection{}
-
-		if consecutiveErrConfig != nil {
-			outlierDetection.Consecutive_5Xx =
-				&wrappers.UInt32Value{Value: consecutiveErrConfig.ConsecutiveErrorToOpen.Value}
-		}
-		if errorRateConfig != nil {
-			outlierDetection.FailurePercentageRequestVolume =
-				&wrappers.UInt32Value{Value: errorRateConfig.RequestVolumeThreshold.Value}
-			outlierDetection.FailurePercentageThreshold =
-				&wrappers.UInt32Value{Value: errorRateConfig.ErrorRateToOpen.Value}
-		}
-
-		return outlierDetection
-	}
-	return nil
-}
-
-func (x *XDSServer) makeClusters(services []*ServiceInfo) []types.Resource {
+func (x *XDSServer) makeClusters(services, callers []*ServiceInfo) []types.Resource {
 	var clusters []types.Resource
 	// 默认 passthrough cluster
-	passthroughClsuter := &cluster.Cluster{
-		Name:                 "PassthroughCluster",
-		ConnectTimeout:       durationpb.New(5 * time.Second),
-		ClusterDiscoveryType: &cluster.Cluster_Type{Type: cluster.Cluster_ORIGINAL_DST},
-		LbPolicy:             cluster.Cluster_CLUSTER_PROVIDED,
-		CircuitBreakers: &cluster.CircuitBreakers{
-			Thresholds: []*cluster.CircuitBreakers_Thresholds{
-				{
-					MaxConnections:     &wrappers.UInt32Value{Value: 4294967295},
-					MaxPendingRequests: &wrappers.UInt32Value{Value: 4294967295},
-					MaxRequests:        &wrappers.UInt32Value{Value: 4294967295},
-					MaxRetries:         &wrappers.UInt32Value{Value: 4294967295},
-				},
-			},
-		},
-	}
-
-	clusters = append(clusters, passthroughClsuter)
+	clusters = append(clusters, makePassthroughCluster(passthroughClusterName, nil))
 
 	// 每一个 polaris service 对应一个 envoy cluster
 	for _, service := range services {
-		circuitBreakerConf := x.namingServer.Cache().CircuitBreaker().GetCircuitBreakerConfig(service.ID)
 		cluster := &cluster.Cluster{
 			Name:                 service.Name,
 			ConnectTimeout:       ptypes.DurationProto(5 * time.Second),
@@ -237,8 +163,9 @@ func (x *XDSServer) makeClusters(services []*ServiceInfo) []types.Resource {
 					},
 				},
 			},
-			LbSubsetConfig:   makeLbSubsetConfig(service),
-			OutlierDetection: makeOutlierDetection(circuitBreakerConf),
+			LbSubsetConfig:   makeLbSubsetConfig(service, callers),
+			OutlierDetection: makeOutlierDetection(service),
+			TransportSocket:  makeUpstreamTLS(x.config.ClusterTLS),
 		}
 
 		clusters = append(clusters, cluster)
@@ -247,6 +174,31 @@ func (x *XDSServer) makeClusters(services []*ServiceInfo) []types.Resource {
 	return clusters
 }
 
+// makePassthroughCluster 转发到请求原始地址的集群，maxRequests 为空时不限制并发请求数
+func makePassthroughCluster(name string, maxRequests *wrappers.UInt32Value) *cluster.Cluster {
+	trackRemaining := maxRequests != nil
+	if maxRequests == nil {
+		maxRequests = &wrappers.UInt32Value{Value: 4294967295}
+	}
+	return &cluster.Cluster{
+		Name:                 name,
+		ConnectTimeout:       durationpb.New(5 * time.Second),
+		ClusterDiscoveryType: &cluster.Cluster_Type{Type: cluster.Cluster_ORIGINAL_DST},
+		LbPolicy:             cluster.Cluster_CLUSTER_PROVIDED,
+		CircuitBreakers: &cluster.CircuitBreakers{
+			Thresholds: []*cluster.CircuitBreakers_Thresholds{
+				{
+					MaxConnections:     &wrappers.UInt32Value{Value: 4294967295},
+					MaxPendingRequests: &wrappers.UInt32Value{Value: 4294967295},
+					MaxRequests:        maxRequests,
+					MaxRetries:         &wrappers.UInt32Value{Value: 4294967295},
+					TrackRemaining:     trackRemaining,
+				},
+			},
+		},
+	}
+}
+
 func getEndpointMetaFromPolarisIns(ins *api.Instance) *core.Metadata {
 	meta := &core.Metadata{}
 	fields := make(map[string]*_struct.Value)
@@ -312,94 +264,6 @@ func makeEndpoints(services []*ServiceInfo) []types.Resource {
 	return clusterLoads
 }
 
-func makeRoutes(serviceInfo *ServiceInfo) []*route.Route {
-
-	var routes []*route.Route
-
-	// 路由目前只处理 inbounds
-	if serviceInfo.Routing != nil && len(serviceInfo.Routing.Inbounds) > 0 {
-		for _, r := range serviceInfo.Routing.Inbounds {
-
-			// 目前只支持从 header 中取 metadata
-			var headerMatchers []*route.HeaderMatcher
-
-			// 使用 sources 生成 routeMatch
-			for _, source := range r.Sources {
-				if source.Metadata != nil && len(source.Metadata) > 0 {
-					for name, matchString := range source.Metadata {
-						headerMatch := &route.HeaderMatcher{}
-						headerMatch.Name = name
-						if matchString.Type == api.MatchString_EXACT {
-							headerMatch.HeaderMatchSpecifier = &route.HeaderMatcher_ExactMatch{
-								ExactMatch: matchString.Value.Value,
-							}
-						} else {
-							headerMatch.HeaderMatchSpecifier = &route.HeaderMatcher_SuffixMatch{
-								SuffixMatch: matchString.Value.Value,
-							}
-						}
-						headerMatchers = append(headerMatchers, headerMatch)
-					}
-				}
-			}
-
-			var weightedClusters []*route.WeightedCluster_ClusterWeight
-			var totalWeight uint32
-
-			// 使用 destinations 生成 weightedClusters。makeClusters() 也使用这个字段生成对应的 subset
-			for _, destination := range r.Destinations {
-
-				fields := make(map[string]*_struct.Value)
-				for k, v := range destination.Metadata {
-					fields[k] = &_struct.Value{
-						Kind: &_struct.Value_StringValue{
-							StringValue: v.Value.Value,
-						},
-					}
-				}
-
-				weightedClusters = append(weightedClusters, &route.WeightedCluster_ClusterWeight{
-					Name:   serviceInfo.Name,
-					Weight: destination.Weight,
-					MetadataMatch: &core.Metadata{
-						FilterMetadata: map[string]*_struct.Struct{
-							"envoy.lb": {
-								Fields: fields,
-							},
-						},
-					},
-				})
-
-				totalWeight += destination.Weight.Value
-			}
-
-			route := &route.Route{
-				Match: &route.RouteMatch{
-					PathSpecifier: &route.RouteMatch_Prefix{
-						Prefix: "/",
-					},
-					Headers: headerMatchers,
-				},
-				Action: &route.Route_Route{
-					Route: &route.RouteAction{
-						ClusterSpecifier: &route.RouteAction_WeightedClusters{
-							WeightedClusters: &route.WeightedCluster{
-								TotalWeight: &wrappers.UInt32Value{Value: totalWeight},
-								Clusters:    weightedClusters,
-							},
-						},
-					},
-				},
-			}
-			routes = append(routes, route)
-		}
-	}
-
-	// 如果没有路由，会进入最后的默认处理
-	routes = append(routes, getDefaultRoute(serviceInfo.Name))
-	return routes
-}
-
 // 默认路由
 func getDefaultRoute(serviceName string) *route.Route {
 	return &route.Route{
@@ -445,17 +309,17 @@ func generateServiceDomains(serviceInfo *ServiceInfo) []string {
 	return resDomains
 }
 
-func makeVirtualHosts(services []*ServiceInfo) []types.Resource {
-	// 每个 polaris service 对应一个 virtualHost
-	var routeConfs []types.Resource
+func makeVirtualHosts(services, callers []*ServiceInfo) *route.RouteConfiguration {
+	// 每个 polaris service 对应一个 virtualHost，主调服务的出流量规则作用于节点
 	var hosts []*route.VirtualHost
 
 	for _, service := range services {
+		routes := makeRoutes(service, callers)
 
 		hosts = append(hosts, &route.VirtualHost{
 			Name:    service.Name,
 			Domains: generateServiceDomains(service),
-			Routes:  makeRoutes(service),
+			Routes:  routes,
 		})
 	}
 
@@ -473,7 +337,7 @@ func makeVirtualHosts(services []*ServiceInfo) []types.Resource {
 				Action: &route.Route_Route{
 					Route: &route.RouteAction{
 						ClusterSpecifier: &route.RouteAction_Cluster{
-							Cluster: "PassthroughCluster",
+							Cluster: passthroughClusterName,
 						},
 					},
 				},
@@ -481,126 +345,72 @@ func makeVirtualHosts(services []*ServiceInfo) []types.Resource {
 		},
 	})
 
-	routeConfiguration := &route.RouteConfiguration{
-		Name: "polaris-router",
+	return &route.RouteConfiguration{
+		Name: outboundRouteName,
 		ValidateClusters: &wrappers.BoolValue{
 			Value: false,
 		},
 		VirtualHosts: hosts,
 	}
-
-	return append(routeConfs, routeConfiguration)
 }
 
-func makeListeners() []types.Resource {
-	manager := &hcm.HttpConnectionManager{
-		CodecType:  hcm.HttpConnectionManager_AUTO,
-		StatPrefix: "http",
-		RouteSpecifier: &hcm.HttpConnectionManager_Rds{
-			Rds: &hcm.Rds{
-				ConfigSource: &core.ConfigSource{
-					ResourceApiVersion: resource.DefaultAPIVersion,
-					ConfigSourceSpecifier: &core.ConfigSource_Ads{
-						Ads: &core.AggregatedConfigSource{},
-					},
-				},
-				RouteConfigName: "polaris-router",
-			},
-		},
-		HttpFilters: []*hcm.HttpFilter{{
-			Name: wellknown.Router,
-		}},
-	}
-
-	pbst, err := ptypes.MarshalAny(manager)
-	if err != nil {
-		panic(err)
-	}
+// pushRegistryInfoToXDSCache 为命名空间下的每个服务视图推送快照，调用方需要持有 x.lock
+func (x *XDSServer) pushRegistryInfoToXDSCache(registryInfo map[string][]*ServiceInfo) error {
+	versionLocal := x.nextVersion()
 
-	tcpConfig := &tcp.TcpProxy{
-		StatPrefix: "PassthroughCluster",
-		ClusterSpecifier: &tcp.TcpProxy_Cluster{
-			Cluster: "PassthroughCluster",
-		},
+	for ns := range registryInfo {
+		for _, view := range x.nodeViews(ns) {
+			if err := x.pushSnapshot(versionLocal, view, registryInfo[ns]); err != nil {
+				return err
+			}
+		}
 	}
-
-	tcpC, err := ptypes.MarshalAny(tcpConfig)
-	if err != nil {
-		panic(err)
-	}
-
-	return []types.Resource{
-		&listener.Listener{
-			Name: "listener_15001",
-			Address: &core.Address{
-				Address: &core.Address_SocketAddress{
-					SocketAddress: &core.SocketAddress{
-						Protocol: core.SocketAddress_TCP,
-						Address:  "0.0.0.0",
-						PortSpecifier: &core.SocketAddress_PortValue{
-							PortValue: 15001,
-						},
-					},
-				},
-			},
-			FilterChains: []*listener.FilterChain{
-				{
-					Filters: []*listener.Filter{
-						{
-							Name: wellknown.HTTPConnectionManager,
-							ConfigType: &listener.Filter_TypedConfig{
-								TypedConfig: pbst,
-							},
-						},
-					},
-				},
-			},
-			DefaultFilterChain: &listener.FilterChain{
-				Name: "PassthroughFilterChain",
-				Filters: []*listener.Filter{
-					{
-						Name: wellknown.TCPProxy,
-						ConfigType: &listener.Filter_TypedConfig{
-							TypedConfig: tcpC,
-						},
-					},
-				},
-			},
-			ListenerFilters: []*listener.ListenerFilter{
-				{
-					Name: "envoy.filters.listener.original_dst",
-				},
-			},
-		}}
+	return nil
 }
 
-func (x *XDSServer) pushRegistryInfoToXDSCache(registryInfo map[string][]*ServiceInfo) error {
-	versionLocal := time.Now().Format(time.RFC3339) + "/" + strconv.FormatUint(x.versionNum.Inc(), 10)
+func (x *XDSServer) nextVersion() string {
+	return time.Now().Format(time.RFC3339) + "/" + strconv.FormatUint(x.versionNum.Inc(), 10)
+}
 
-	for ns := range registryInfo {
-		resources := make(map[resource.Type][]types.Resource)
-		resources[resource.EndpointType] = makeEndpoints(registryInfo[ns])
-		resources[resource.ClusterType] = x.makeClusters(registryInfo[ns])
-		resources[resource.RouteType] = makeVirtualHosts(registryInfo[ns])
-		resources[resource.ListenerType] = makeListeners()
-		snapshot, err := cachev3.NewSnapshot(versionLocal, resources)
-		if err != nil {
-			log.Errorf("fail to create snapshot for %s, err is %v", ns, err)
-			return err
-		}
-		// 检查 snapshot 一致性
-		if err := snapshot.Consistent(); err != nil {
-			log.Errorf("snapshot inconsistency: %v, err is %v", snapshot, err)
-			return err
+// pushSnapshot 按照节点的服务视图生成快照
+func (x *XDSServer) pushSnapshot(version string, view *nodeView, services []*ServiceInfo) error {
+	callers := view.callers(services)
+	served := view.served(services)
+	services = view.services(services)
+
+	resources := make(map[resource.Type][]types.Resource)
+	resources[resource.EndpointType] = makeEndpoints(services)
+	resources[resource.ClusterType] = x.makeClusters(services, callers)
+	resources[resource.ListenerType] = makeListeners(x.config.Listeners, view.role)
+	routeNames := listenerRouteNames(x.config.Listeners, view.role)
+	if routeNames[outboundRouteName] {
+		resources[resource.RouteType] = append(resources[resource.RouteType], makeVirtualHosts(services, callers))
+	}
+	if routeNames[inboundRouteName] {
+		resources[resource.RouteType] = append(resources[resource.RouteType], makeInboundRouteConfiguration(served))
+		// 单机的并发阈值作用于节点代理的服务的入流量
+		if served != nil {
+			resources[resource.ClusterType] = append(resources[resource.ClusterType],
+				makePassthroughCluster(inboundClusterName, makeMaxRequests(served)))
 		}
+	}
+	snapshot, err := cachev3.NewSnapshot(version, resources)
+	if err != nil {
+		log.Errorf("fail to create snapshot for %s, err is %v", view.key, err)
+		return err
+	}
+	// 检查 snapshot 一致性
+	if err := snapshot.Consistent(); err != nil {
+		log.Errorf("snapshot inconsistency: %v, err is %v", snapshot, err)
+		return err
+	}
 
-		log.Infof("will serve ns: %s ,snapshot: %+v", ns, snapshot)
+	log.Infof("will serve node: %s ,snapshot: %+v", view.key, snapshot)
 
-		// 为每个 ns 刷写 cache ，推送 xds 更新
-		if err := x.cache.SetSnapshot(context.Background(), ns, snapshot); err != nil {
-			log.Errorf("snapshot error %q for %+v", err, snapshot)
-			return err
-		}
+	// 为每个服务视图刷写 cache ，推送 xds 更新
+	if err := x.cache.SetSnapshot(context.Background(), view.key, snapshot); err != nil {
+		log.Errorf("snapshot error %q for %+v", err, snapshot)
+		return err
 	}
 	return nil
 }
@@ -666,6 +476,22 @@ func (x *XDSServer) getRegistryInfoWithCache(ctx context.Context, registryInfo m
 
 			svc.SvcInsRevision = resp.Service.Revision.Value
 			svc.Instances = resp.Instances
+
+			rateLimitResp := x.namingServer.GetRateLimitWithCache(ctx, s)
+			if rateLimitResp.GetCode().Value != api.ExecuteSuccess {
+				log.Errorf("error sync ratelimit for %s, info : %s", svc.Name, rateLimitResp.Info.GetValue())
+				return fmt.Errorf("error sync ratelimit for %s", svc.Name)
+			}
+			if rateLimitResp.RateLimit != nil {
+				svc.SvcRateLimitRevision = rateLimitResp.RateLimit.Revision.GetValue()
+				svc.RateLimit = rateLimitResp.RateLimit
+			}
+
+			circuitBreaker := x.namingServer.Cache().CircuitBreaker().GetCircuitBreakerConfig(svc.ID)
+			if circuitBreaker != nil && circuitBreaker.CircuitBreaker != nil {
+				svc.SvcCircuitBreakerRevision = circuitBreaker.CircuitBreaker.Revision
+				svc.CircuitBreaker = circuitBreaker
+			}
 		}
 	}
 
@@ -700,13 +526,22 @@ func (x *XDSServer) Initialize(ctx context.Context, option map[string]interface{
 	defer logger.Sync() // flushes buffer, if any
 	l := logger.Sugar()
 
-	x.cache = cachev3.NewSnapshotCache(false, PolarisNodeHash{}, l)
+	config, err := parseConfig(option)
+	if err != nil {
+		log.Errorf("parse xds config error %v", err)
+		return err
+	}
+	x.config = config
+	x.nodeHash = PolarisNodeHash{mode: config.Snapshot, groupKey: config.NodeGroupKey}
+	x.cache = cachev3.NewSnapshotCache(false, x.nodeHash, l)
 	x.registryInfo = make(map[string][]*ServiceInfo)
+	x.nodes = make(map[string]*nodeView)
+	x.nodeRefs = make(map[string]int)
+	x.streams = make(map[int64]string)
 	x.listenPort = uint32(option["listenPort"].(int))
 	x.listenIP = option["listenIP"].(string)
 
 	x.versionNum = atomic.NewUint64(0)
-	var err error
 
 	x.namingServer, err = service.GetServer()
 	if err != nil {
@@ -757,6 +592,9 @@ func (x *XDSServer) startSynTask(ctx context.Context) error {
 			return
 		}
 
+		x.lock.Lock()
+		defer x.lock.Unlock()
+
 		needPush := make(map[string][]*ServiceInfo)
 
 		// 处理删除 ns 中最后一个 service
@@ -824,6 +662,12 @@ func (x *XDSServer) checkUpdate(curServiceInfo, cacheServiceInfo []*ServiceInfo)
 				if info.SvcRoutingRevision != serviceInfo.SvcRoutingRevision {
 					return true
 				}
+				if info.SvcRateLimitRevision != serviceInfo.SvcRateLimitRevision {
+					return true
+				}
+				if info.SvcCircuitBreakerRevision != serviceInfo.SvcCircuitBreakerRevision {
+					return true
+				}
 				find = true
 			}
 		}
@@ -840,7 +684,7 @@ func (x *XDSServer) Run(errCh chan error) {
 
 	// 启动 grpc server
 	ctx := context.Background()
-	cb := &testv3.Callbacks{Debug: true}
+	cb := &nodeCallbacks{Callbacks: &testv3.Callbacks{Debug: true}, server: x}
 	srv := serverv3.NewServer(ctx, x.cache, cb)
 	var grpcOptions []grpc.ServerOption
 	grpcOptions = append(grpcOptions, grpc.MaxConcurrentStreams(1000))
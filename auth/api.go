@@ -69,6 +69,20 @@ type AuthChecker interface {
 	IsOpenClientAuth() bool
 }
 
+// ApproverChecker 审批人校验接口，由支持用户和用户组的鉴权实现提供，用于配置发布等需要审批的流程
+type ApproverChecker interface {
+	// CheckApprovers 校验审批人，users 为用户 ID 列表，groups 为用户组 ID 列表
+	CheckApprovers(users, groups []string) error
+	// IsApprover 校验请求携带的 token 是否为审批人，用户属于任一审批用户组也视为审批人，返回操作者 ID
+	IsApprover(preCtx *model.AcquireContext, users, groups []string) (string, bool, error)
+}
+
+// ReadPermissionChecker 资源读权限校验接口，用于加密配置等读取内容也需要授权的场景
+type ReadPermissionChecker interface {
+	// CheckReadPermission 校验请求携带的 token 对访问资源是否有读权限，匿名用户没有读权限
+	CheckReadPermission(preCtx *model.AcquireContext) (bool, error)
+}
+
 // UserOperator 用户数据管理 server
 type UserOperator interface {
 
@@ -0,0 +1,84 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package defaultauth
+
+import (
+	"fmt"
+
+	"github.com/polarismesh/polaris-server/common/model"
+)
+
+// CheckApprovers 校验审批用户和审批用户组是否存在
+func (checker *defaultAuthChecker) CheckApprovers(users, groups []string) error {
+	if len(users) == 0 && len(groups) == 0 {
+		return fmt.Errorf("approvers is empty")
+	}
+	userCache := checker.Cache().User()
+	for _, id := range users {
+		if userCache.GetUserByID(id) == nil {
+			return fmt.Errorf("approve user %s not found", id)
+		}
+	}
+	for _, id := range groups {
+		if userCache.GetGroup(id) == nil {
+			return fmt.Errorf("approve group %s not found", id)
+		}
+	}
+	return nil
+}
+
+// IsApprover 校验 token 对应的操作者是否为审批人
+// 	case 1. 用户 token，用户在审批用户中，或者属于任一审批用户组
+// 	case 2. 用户组 token，用户组在审批用户组中
+// token 被禁用或者降级为匿名用户时，不能进行审批
+func (checker *defaultAuthChecker) IsApprover(preCtx *model.AcquireContext, users,
+	groups []string) (string, bool, error) {
+	if err := checker.VerifyCredential(preCtx); err != nil {
+		return "", false, err
+	}
+	operator, ok := preCtx.GetAttachment(model.TokenDetailInfoKey).(OperatorInfo)
+	if !ok || IsEmptyOperator(operator) {
+		return "", false, nil
+	}
+	if operator.Disable {
+		return operator.OperatorID, false, model.ErrorTokenDisabled
+	}
+
+	if !operator.IsUserToken {
+		return operator.OperatorID, containsApprover(groups, operator.OperatorID), nil
+	}
+	if containsApprover(users, operator.OperatorID) {
+		return operator.OperatorID, true, nil
+	}
+	userCache := checker.Cache().User()
+	for _, groupID := range groups {
+		if userCache.IsUserInGroup(operator.OperatorID, groupID) {
+			return operator.OperatorID, true, nil
+		}
+	}
+	return operator.OperatorID, false, nil
+}
+
+func containsApprover(approvers []string, id string) bool {
+	for _, approver := range approvers {
+		if approver == id {
+			return true
+		}
+	}
+	return false
+}
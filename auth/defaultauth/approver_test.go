@@ -0,0 +1,122 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package defaultauth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/polarismesh/polaris-server/cache"
+	"github.com/polarismesh/polaris-server/common/model"
+	"github.com/polarismesh/polaris-server/plugin"
+	storemock "github.com/polarismesh/polaris-server/store/mock"
+)
+
+func Test_defaultAuthChecker_Approver(t *testing.T) {
+	reset(false)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	users := createMockUser(4)
+	groups := createMockUserGroup(users)
+
+	storage := storemock.NewMockStore(ctrl)
+	storage.EXPECT().GetUsersForCache(gomock.Any(), gomock.Any()).AnyTimes().Return(users, nil)
+	storage.EXPECT().GetGroupsForCache(gomock.Any(), gomock.Any()).AnyTimes().Return(groups, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	if err := cache.TestCacheInitialize(ctx, &cache.Config{
+		Open: true,
+		Resources: []cache.ConfigEntry{
+			{
+				Name: "users",
+			},
+		},
+	}, storage); err != nil {
+		t.Fatal(err)
+	}
+
+	cacheMgn, err := cache.GetCacheManager()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() {
+		cancel()
+		cacheMgn.Clear()
+		time.Sleep(2 * time.Second)
+	}()
+
+	checker := &defaultAuthChecker{}
+	checker.cacheMgn = cacheMgn
+	checker.authPlugin = plugin.GetAuth()
+
+	newAuthCtx := func(token string) *model.AcquireContext {
+		return model.NewAcquireContext(
+			model.WithRequestContext(context.Background()),
+			model.WithMethod("Test_defaultAuthChecker_Approver"),
+			model.WithToken(token),
+		)
+	}
+
+	t.Run("校验审批人", func(t *testing.T) {
+		assert.NoError(t, checker.CheckApprovers([]string{users[1].ID}, []string{groups[2].ID}))
+		assert.Error(t, checker.CheckApprovers(nil, nil), "approvers should not be empty")
+		assert.Error(t, checker.CheckApprovers([]string{"not-exist-user"}, nil))
+		assert.Error(t, checker.CheckApprovers(nil, []string{"not-exist-group"}))
+	})
+
+	t.Run("审批用户", func(t *testing.T) {
+		operatorID, ok, err := checker.IsApprover(newAuthCtx(users[1].Token), []string{users[1].ID}, nil)
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, users[1].ID, operatorID)
+
+		_, ok, err = checker.IsApprover(newAuthCtx(users[3].Token), []string{users[1].ID}, nil)
+		assert.NoError(t, err)
+		assert.False(t, ok)
+	})
+
+	t.Run("用户属于审批用户组", func(t *testing.T) {
+		_, ok, err := checker.IsApprover(newAuthCtx(users[2].Token), nil, []string{groups[2].ID})
+		assert.NoError(t, err)
+		assert.True(t, ok)
+
+		_, ok, err = checker.IsApprover(newAuthCtx(users[3].Token), nil, []string{groups[2].ID})
+		assert.NoError(t, err)
+		assert.False(t, ok)
+	})
+
+	t.Run("用户组token", func(t *testing.T) {
+		operatorID, ok, err := checker.IsApprover(newAuthCtx(groups[2].Token), []string{users[2].ID},
+			[]string{groups[2].ID})
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, groups[2].ID, operatorID)
+	})
+
+	t.Run("非法token降级为匿名用户", func(t *testing.T) {
+		_, ok, err := checker.IsApprover(newAuthCtx("invalid-token"), []string{users[1].ID}, nil)
+		assert.NoError(t, err)
+		assert.False(t, ok)
+	})
+}
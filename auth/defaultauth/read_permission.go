@@ -0,0 +1,63 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package defaultauth
+
+import (
+	api "github.com/polarismesh/polaris-server/common/api/v1"
+	"github.com/polarismesh/polaris-server/common/model"
+)
+
+// CheckReadPermission 校验 token 对访问资源的读权限
+// 和 CheckPermission 不同，读操作不会直接放通，资源没有关联任何策略时也只有资源的负责人可以读取
+// token 被禁用或者降级为匿名用户时，没有读权限
+func (checker *defaultAuthChecker) CheckReadPermission(preCtx *model.AcquireContext) (bool, error) {
+	if err := checker.VerifyCredential(preCtx); err != nil {
+		return false, err
+	}
+	operator, ok := preCtx.GetAttachment(model.TokenDetailInfoKey).(OperatorInfo)
+	if !ok || IsEmptyOperator(operator) {
+		return false, nil
+	}
+	if operator.Disable {
+		return false, model.ErrorTokenDisabled
+	}
+
+	strategies, err := checker.findStrategies(operator)
+	if err != nil {
+		return false, err
+	}
+	// 鉴权插件在没有策略时直接放通，这里只允许资源的负责人读取
+	if len(strategies) == 0 {
+		return isResourcesOwner(operator.OperatorID, preCtx.GetAccessResources()), nil
+	}
+	return checker.authPlugin.CheckPermission(preCtx, strategies)
+}
+
+// isResourcesOwner 判断操作者是否为全部资源的负责人，没有访问资源时返回 false
+func isResourcesOwner(operatorID string, resources map[api.ResourceType][]model.ResourceEntry) bool {
+	found := false
+	for _, entries := range resources {
+		for _, entry := range entries {
+			if entry.Owner != operatorID {
+				return false
+			}
+			found = true
+		}
+	}
+	return found
+}
@@ -0,0 +1,137 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+
+package defaultauth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/polarismesh/polaris-server/cache"
+	api "github.com/polarismesh/polaris-server/common/api/v1"
+	"github.com/polarismesh/polaris-server/common/model"
+	"github.com/polarismesh/polaris-server/common/utils"
+	"github.com/polarismesh/polaris-server/plugin"
+	storemock "github.com/polarismesh/polaris-server/store/mock"
+)
+
+func Test_defaultAuthChecker_CheckReadPermission(t *testing.T) {
+	reset(false)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	users := createMockUser(3)
+	strategyID := utils.NewUUID()
+	strategies := []*model.StrategyDetail{{
+		ID:         strategyID,
+		Name:       "read_config_group",
+		Action:     api.AuthAction_READ_WRITE.String(),
+		Principals: []model.Principal{{PrincipalID: users[1].ID, PrincipalRole: model.PrincipalUser}},
+		Owner:      users[0].ID,
+		Resources: []model.StrategyResource{
+			{StrategyID: strategyID, ResType: int32(api.ResourceType_Namespaces), ResID: "ns"},
+			{StrategyID: strategyID, ResType: int32(api.ResourceType_ConfigGroups), ResID: "1"},
+		},
+		Valid:    true,
+		Revision: utils.NewUUID(),
+	}}
+
+	storage := storemock.NewMockStore(ctrl)
+	storage.EXPECT().GetUsersForCache(gomock.Any(), gomock.Any()).AnyTimes().Return(users, nil)
+	storage.EXPECT().GetGroupsForCache(gomock.Any(), gomock.Any()).AnyTimes().Return(nil, nil)
+	storage.EXPECT().GetStrategyDetailsForCache(gomock.Any(), gomock.Any()).AnyTimes().Return(strategies, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	if err := cache.TestCacheInitialize(ctx, &cache.Config{
+		Open: true,
+		Resources: []cache.ConfigEntry{
+			{
+				Name: "users",
+			},
+			{
+				Name: "strategyRule",
+			},
+		},
+	}, storage); err != nil {
+		t.Fatal(err)
+	}
+
+	cacheMgn, err := cache.GetCacheManager()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() {
+		cancel()
+		cacheMgn.Clear()
+		time.Sleep(2 * time.Second)
+	}()
+
+	time.Sleep(time.Second)
+
+	checker := &defaultAuthChecker{}
+	checker.cacheMgn = cacheMgn
+	checker.authPlugin = plugin.GetAuth()
+
+	newAuthCtx := func(token string, group string) *model.AcquireContext {
+		return model.NewAcquireContext(
+			model.WithRequestContext(context.Background()),
+			model.WithMethod("Test_defaultAuthChecker_CheckReadPermission"),
+			model.WithToken(token),
+			model.WithOperation(model.Read),
+			model.WithModule(model.ConfigModule),
+			model.WithAccessResources(map[api.ResourceType][]model.ResourceEntry{
+				api.ResourceType_Namespaces:   {{ID: "ns", Owner: users[0].ID}},
+				api.ResourceType_ConfigGroups: {{ID: group, Owner: users[0].ID}},
+			}),
+		)
+	}
+
+	t.Run("策略授权了配置分组", func(t *testing.T) {
+		ok, err := checker.CheckReadPermission(newAuthCtx(users[1].Token, "1"))
+		assert.NoError(t, err)
+		assert.True(t, ok)
+	})
+
+	t.Run("策略没有授权配置分组", func(t *testing.T) {
+		ok, _ := checker.CheckReadPermission(newAuthCtx(users[1].Token, "2"))
+		assert.False(t, ok)
+	})
+
+	t.Run("没有任何策略的子账户", func(t *testing.T) {
+		ok, err := checker.CheckReadPermission(newAuthCtx(users[2].Token, "1"))
+		assert.NoError(t, err)
+		assert.False(t, ok)
+	})
+
+	t.Run("资源的负责人", func(t *testing.T) {
+		ok, err := checker.CheckReadPermission(newAuthCtx(users[0].Token, "2"))
+		assert.NoError(t, err)
+		assert.True(t, ok)
+	})
+
+	t.Run("非法token降级为匿名用户", func(t *testing.T) {
+		ok, err := checker.CheckReadPermission(newAuthCtx("invalid-token", "1"))
+		assert.NoError(t, err)
+		assert.False(t, ok)
+	})
+}
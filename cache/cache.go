@@ -285,6 +285,9 @@ func (nc *NamingCache) processRevisionWorker(req *revisionNotify) bool {
 	if !req.valid {
 		log.CacheScope().Infof("[Cache][Revision] service(%s) revision has all been removed", req.serviceID)
 		nc.revisions.Delete(req.serviceID)
+		if ic, ok := nc.caches[CacheInstance].(*instanceCache); ok {
+			ic.removeInstanceChanges(req.serviceID)
+		}
 		return true
 	}
 
@@ -294,6 +297,12 @@ func (nc *NamingCache) processRevisionWorker(req *revisionNotify) bool {
 		return false
 	}
 
+	// 先记录变更序号再读取实例，保证版本号对应的增量不会遗漏变更
+	ic, _ := nc.caches[CacheInstance].(*instanceCache)
+	var seq uint64
+	if ic != nil {
+		seq = ic.instanceChangeSeq(req.serviceID)
+	}
 	instances := nc.Instance().GetInstancesByServiceID(req.serviceID)
 	revision, err := ComputeRevision(service.Revision, instances)
 	if err != nil {
@@ -302,6 +311,9 @@ func (nc *NamingCache) processRevisionWorker(req *revisionNotify) bool {
 		return false
 	}
 	nc.revisions.Store(req.serviceID, revision) // string -> string
+	if ic != nil {
+		ic.recordInstanceRevision(req.serviceID, revision, seq)
+	}
 	return true
 }
 
@@ -0,0 +1,151 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package cache
+
+import (
+	"sort"
+	"time"
+
+	"github.com/polarismesh/polaris-server/common/log"
+	"github.com/polarismesh/polaris-server/common/model"
+)
+
+const (
+	// changeLogPullLimit 每次拉取变更日志的最大条数
+	changeLogPullLimit = 10000
+	// changeLogGapTimeout 序号空洞的等待时间，超时认为对应的事务已经回滚
+	changeLogGapTimeout = 10 * time.Second
+	// changeLogRecheckWindow 跳过的序号在该时间内继续重新拉取，避免长事务提交的变更永久丢失
+	changeLogRecheckWindow = 5 * time.Minute
+	// changeLogMaxSkipped 重新拉取的序号的最大数量
+	changeLogMaxSkipped = changeLogPullLimit
+	// systemNamespace 系统命名空间，disableBusiness 时只缓存该命名空间下的数据
+	systemNamespace = "Polaris"
+)
+
+// changeLogCursor 变更日志的拉取游标
+// 序号在写入时分配，事务提交的顺序与序号不一定一致，因此只有序号连续时才推进游标，
+// 空洞之后已经处理过的序号记录在 seen 中，避免重复处理。空洞超时后跳过的序号记录在 skipped 中，
+// 在 changeLogRecheckWindow 内从最小的跳过序号开始重新拉取
+type changeLogCursor struct {
+	seq      uint64               // 已经连续处理的最大序号
+	seen     map[uint64]struct{}  // 空洞之后已经处理的序号
+	gapSince time.Time            // 发现空洞的时间
+	skipped  map[uint64]time.Time // 空洞超时后跳过的序号以及跳过的时间
+}
+
+// newChangeLogCursor 从指定的序号之后开始拉取
+func newChangeLogCursor(seq uint64) *changeLogCursor {
+	return &changeLogCursor{seq: seq, seen: make(map[uint64]struct{}), skipped: make(map[uint64]time.Time)}
+}
+
+// pullFrom 返回拉取的起始序号，存在未过期的跳过序号时从最小的跳过序号开始拉取
+func (c *changeLogCursor) pullFrom(now time.Time) uint64 {
+	from := c.seq
+	for seq, skipTime := range c.skipped {
+		if now.Sub(skipTime) >= changeLogRecheckWindow {
+			delete(c.skipped, seq)
+			continue
+		}
+		if seq-1 < from {
+			from = seq - 1
+		}
+	}
+	return from
+}
+
+// accept 过滤已经处理过的变更记录，返回需要处理的资源ID
+func (c *changeLogCursor) accept(logs []*model.ChangeLog) map[string]bool {
+	ids := make(map[string]bool)
+	for _, entry := range logs {
+		if entry.Seq <= c.seq {
+			// 跳过之后才提交的变更
+			if _, ok := c.skipped[entry.Seq]; ok {
+				delete(c.skipped, entry.Seq)
+				ids[entry.ID] = true
+			}
+			continue
+		}
+		if _, ok := c.seen[entry.Seq]; ok {
+			continue
+		}
+		c.seen[entry.Seq] = struct{}{}
+		ids[entry.ID] = true
+	}
+	return ids
+}
+
+// compact 推进连续的序号，空洞超时后跳过，跳过的序号在一段时间内重新拉取
+func (c *changeLogCursor) compact(now time.Time) {
+	for {
+		for {
+			if _, ok := c.seen[c.seq+1]; !ok {
+				break
+			}
+			delete(c.seen, c.seq+1)
+			c.seq++
+		}
+		if len(c.seen) == 0 {
+			c.gapSince = time.Time{}
+			return
+		}
+		if c.gapSince.IsZero() {
+			c.gapSince = now
+			return
+		}
+		if now.Sub(c.gapSince) < changeLogGapTimeout {
+			return
+		}
+		seqs := make([]uint64, 0, len(c.seen))
+		for seq := range c.seen {
+			seqs = append(seqs, seq)
+		}
+		sort.Slice(seqs, func(i, j int) bool { return seqs[i] < seqs[j] })
+		log.CacheScope().Warnf("[Cache] skip change log gap (%d, %d) after %s",
+			c.seq, seqs[0], changeLogGapTimeout)
+		for seq := c.seq + 1; seq < seqs[0] && len(c.skipped) < changeLogMaxSkipped; seq++ {
+			c.skipped[seq] = now
+		}
+		c.seq = seqs[0] - 1
+		c.gapSince = now
+	}
+}
+
+// pullChangeLogs 从游标的位置开始分页拉取变更记录，交由 apply 处理
+func pullChangeLogs(cursor *changeLogCursor,
+	pull func(seq uint64, limit uint32) ([]*model.ChangeLog, error),
+	apply func(ids map[string]bool)) (int, error) {
+	total := 0
+	from := cursor.pullFrom(time.Now())
+	for {
+		logs, err := pull(from, changeLogPullLimit)
+		if err != nil {
+			return total, err
+		}
+		total += len(logs)
+		if ids := cursor.accept(logs); len(ids) > 0 {
+			apply(ids)
+		}
+		if len(logs) < changeLogPullLimit {
+			break
+		}
+		from = logs[len(logs)-1].Seq
+	}
+	cursor.compact(time.Now())
+	return total, nil
+}
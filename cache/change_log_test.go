@@ -0,0 +1,157 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/polarismesh/polaris-server/common/model"
+	"github.com/polarismesh/polaris-server/store"
+	"github.com/polarismesh/polaris-server/store/mock"
+)
+
+// changeLogStore 在 mock 存储的基础上实现变更日志
+type changeLogStore struct {
+	*mock.MockStore
+	logs      []*model.ChangeLog
+	instances map[string]*model.Instance
+}
+
+func (s *changeLogStore) ChangeLogEnabled() bool {
+	return true
+}
+
+func (s *changeLogStore) GetLatestChangeSeq(resource string) (uint64, error) {
+	if len(s.logs) == 0 {
+		return 0, nil
+	}
+	return s.logs[len(s.logs)-1].Seq, nil
+}
+
+func (s *changeLogStore) GetServiceChanges(seq uint64, limit uint32, needMeta bool) (
+	[]*model.ChangeLog, map[string]*model.Service, error) {
+	return nil, nil, nil
+}
+
+func (s *changeLogStore) GetInstanceChanges(seq uint64, limit uint32, needMeta bool) (
+	[]*model.ChangeLog, map[string]*model.Instance, error) {
+	logs := make([]*model.ChangeLog, 0)
+	instances := make(map[string]*model.Instance)
+	for _, entry := range s.logs {
+		if entry.Seq <= seq || uint32(len(logs)) >= limit {
+			continue
+		}
+		logs = append(logs, entry)
+		if instance, ok := s.instances[entry.ID]; ok {
+			instances[entry.ID] = instance
+		}
+	}
+	return logs, instances, nil
+}
+
+// TestChangeLogCursor 测试变更日志游标对序号空洞的处理
+func TestChangeLogCursor(t *testing.T) {
+	Convey("序号连续时推进游标", t, func() {
+		c := newChangeLogCursor(1)
+		ids := c.accept([]*model.ChangeLog{{Seq: 1, ID: "a"}, {Seq: 2, ID: "b"}, {Seq: 3, ID: "b"}})
+		So(ids, ShouldResemble, map[string]bool{"b": true})
+		c.compact(time.Now())
+		So(c.seq, ShouldEqual, 3)
+		So(len(c.seen), ShouldEqual, 0)
+	})
+	Convey("存在空洞时等待，超时后跳过", t, func() {
+		now := time.Now()
+		c := newChangeLogCursor(1)
+		So(len(c.accept([]*model.ChangeLog{{Seq: 2, ID: "a"}, {Seq: 4, ID: "b"}})), ShouldEqual, 2)
+		c.compact(now)
+		So(c.seq, ShouldEqual, 2)
+		// 重复拉取到的序号不再处理
+		So(len(c.accept([]*model.ChangeLog{{Seq: 4, ID: "b"}})), ShouldEqual, 0)
+		c.compact(now.Add(time.Second))
+		So(c.seq, ShouldEqual, 2)
+		c.compact(now.Add(changeLogGapTimeout))
+		So(c.seq, ShouldEqual, 4)
+		So(c.gapSince.IsZero(), ShouldBeTrue)
+	})
+	Convey("跳过的序号在一段时间内重新拉取", t, func() {
+		now := time.Now()
+		c := newChangeLogCursor(1)
+		So(len(c.accept([]*model.ChangeLog{{Seq: 3, ID: "a"}})), ShouldEqual, 1)
+		c.compact(now)
+		c.compact(now.Add(changeLogGapTimeout))
+		So(c.seq, ShouldEqual, 3)
+		So(c.pullFrom(now.Add(changeLogGapTimeout)), ShouldEqual, 1)
+
+		// 跳过之后才提交的序号2仍然处理，已经处理的序号3不重复处理
+		ids := c.accept([]*model.ChangeLog{{Seq: 2, ID: "b"}, {Seq: 3, ID: "a"}})
+		So(ids, ShouldResemble, map[string]bool{"b": true})
+		So(c.pullFrom(now.Add(changeLogGapTimeout)), ShouldEqual, 3)
+
+		// 超过重新拉取的时间后不再拉取
+		So(len(c.accept([]*model.ChangeLog{{Seq: 5, ID: "c"}})), ShouldEqual, 1)
+		c.compact(now)
+		c.compact(now.Add(changeLogGapTimeout))
+		So(c.pullFrom(now.Add(changeLogGapTimeout)), ShouldEqual, 3)
+		So(c.pullFrom(now.Add(changeLogGapTimeout+changeLogRecheckWindow)), ShouldEqual, 5)
+		So(len(c.skipped), ShouldEqual, 0)
+	})
+}
+
+// TestInstanceCache_ChangeLog 测试开启变更日志后按照序号增量更新实例
+func TestInstanceCache_ChangeLog(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	instances := genModelInstances("service1", 2)
+	storage := &changeLogStore{
+		MockStore: mock.NewMockStore(ctl),
+		logs:      []*model.ChangeLog{{Seq: 1, ID: "instanceID-service1-0"}},
+		instances: instances,
+	}
+	ic := newInstanceCache(storage, make(chan *revisionNotify, 1024))
+	_ = ic.initialize(map[string]interface{}{"needMeta": true})
+	var _ store.ChangeLogStore = storage
+
+	Convey("首次全量加载，之后只拉取变更日志", t, func() {
+		storage.MockStore.EXPECT().GetMoreInstances(gomock.Any(), true, true, nil).Return(instances, nil).Times(1)
+		So(ic.realUpdate(), ShouldBeNil)
+		So(ic.GetInstancesCount(), ShouldEqual, 2)
+		So(ic.cursor.seq, ShouldEqual, 1)
+
+		// 序号3先于序号2可见
+		updated := *instances["instanceID-service1-0"]
+		updated.ServiceID = "serviceID-service2"
+		storage.instances["instanceID-service1-0"] = &updated
+		storage.logs = append(storage.logs, &model.ChangeLog{Seq: 3, ID: "instanceID-service1-0"})
+		So(ic.realUpdate(), ShouldBeNil)
+		So(ic.GetInstance("instanceID-service1-0").ServiceID, ShouldEqual, "serviceID-service2")
+		So(ic.cursor.seq, ShouldEqual, 1)
+
+		// 存储中已经不存在的实例按照删除处理
+		delete(storage.instances, "instanceID-service1-1")
+		storage.logs = []*model.ChangeLog{storage.logs[0], {Seq: 2, ID: "instanceID-service1-1"}, storage.logs[1]}
+		So(ic.realUpdate(), ShouldBeNil)
+		So(ic.GetInstance("instanceID-service1-1"), ShouldBeNil)
+		So(ic.GetInstancesCount(), ShouldEqual, 1)
+		So(ic.cursor.seq, ShouldEqual, 3)
+	})
+}
@@ -26,6 +26,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/polarismesh/polaris-server/common/log"
+	"github.com/polarismesh/polaris-server/common/model"
 	"github.com/polarismesh/polaris-server/common/utils"
 	"github.com/polarismesh/polaris-server/store"
 )
@@ -60,6 +61,29 @@ type Entry struct {
 	ExpireTime time.Time
 	// 标识是否是空缓存
 	Empty bool
+	// 生效中的灰度发布，为空表示没有灰度
+	Gray *GrayEntry
+}
+
+// GrayEntry 灰度发布的缓存对象
+type GrayEntry struct {
+	Content string
+	Md5     string
+	Version uint64
+	Rule    *model.ConfigFileGrayRule
+}
+
+// ForClient 获取客户端可见的缓存内容，命中灰度规则的客户端返回灰度发布的内容
+func (e *Entry) ForClient(clientIP string, labels map[string]string) *Entry {
+	if e.Gray == nil || !e.Gray.Rule.Match(clientIP, labels) {
+		return e
+	}
+	return &Entry{
+		Content:    e.Gray.Content,
+		Md5:        e.Gray.Md5,
+		Version:    e.Gray.Version,
+		ExpireTime: e.ExpireTime,
+	}
 }
 
 // NewFileCache 创建文件缓存
@@ -135,6 +159,16 @@ func (fc *FileCache) GetOrLoadIfAbsent(namespace, group, fileName string) (*Entr
 		return emptyEntry, nil
 	}
 
+	grayFile, err := fc.storage.GetConfigFileGrayRelease(nil, namespace, group, fileName)
+	if err != nil {
+		log.ConfigScope().Error("[Config][Cache] load config file gray release error.",
+			zap.String("namespace", namespace),
+			zap.String("group", group),
+			zap.String("fileName", fileName),
+			zap.Error(err))
+		return nil, err
+	}
+
 	// 数据库中有对象，更新缓存
 	newEntry := &Entry{
 		Content:    file.Content,
@@ -143,6 +177,14 @@ func (fc *FileCache) GetOrLoadIfAbsent(namespace, group, fileName string) (*Entr
 		ExpireTime: fc.getExpireTime(),
 		Empty:      false,
 	}
+	if grayFile != nil {
+		newEntry.Gray = &GrayEntry{
+			Content: grayFile.Content,
+			Md5:     grayFile.Md5,
+			Version: grayFile.Version,
+			Rule:    grayFile.GrayRule,
+		}
+	}
 
 	// 缓存不存在，则直接存入缓存
 	if !ok {
@@ -163,6 +163,8 @@ func TestUpdateCache(t *testing.T) {
 func newConfigFileMockedCache(t *testing.T) (*gomock.Controller, *mock.MockStore, *FileCache) {
 	control := gomock.NewController(t)
 	mockedStorage := mock.NewMockStore(control)
+	mockedStorage.EXPECT().GetConfigFileGrayRelease(nil, gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, nil).AnyTimes()
 	fileCache := NewFileCache(context.Background(), mockedStorage, FileCacheParam{
 		ExpireTimeAfterWrite: 60000,
 	})
@@ -191,3 +193,33 @@ func assembleConfigFileRelease(configFile *model.ConfigFile) *model.ConfigFileRe
 		Version:   uint64(10),
 	}
 }
+
+// TestGrayEntry 测试灰度发布的缓存，只有命中灰度规则的客户端才能获取到灰度内容
+func TestGrayEntry(t *testing.T) {
+	control := gomock.NewController(t)
+	defer control.Finish()
+	mockedStorage := mock.NewMockStore(control)
+	fileCache := NewFileCache(context.Background(), mockedStorage, FileCacheParam{
+		ExpireTimeAfterWrite: 60000,
+	})
+
+	configFileRelease := assembleConfigFileRelease(assembleConfigFile())
+	grayRelease := assembleConfigFileRelease(assembleConfigFile())
+	grayRelease.Content = "gray"
+	grayRelease.Version = 11
+	grayRelease.GrayRule = &model.ConfigFileGrayRule{
+		ClientIPs:    []string{"127.0.0.1"},
+		ClientLabels: map[string]string{"env": "gray"},
+	}
+	mockedStorage.EXPECT().GetConfigFileRelease(nil, testNamespace, testGroup, testFile).Return(configFileRelease, nil)
+	mockedStorage.EXPECT().GetConfigFileGrayRelease(nil, testNamespace, testGroup, testFile).Return(grayRelease, nil)
+
+	entry, err := fileCache.GetOrLoadIfAbsent(testNamespace, testGroup, testFile)
+	assert.Nil(t, err)
+	assert.NotNil(t, entry.Gray)
+
+	assert.Equal(t, "gray", entry.ForClient("127.0.0.1", nil).Content)
+	assert.Equal(t, uint64(11), entry.ForClient("", map[string]string{"env": "gray", "zone": "sz"}).Version)
+	assert.Equal(t, configFileRelease.Content, entry.ForClient("127.0.0.2", map[string]string{"env": "prod"}).Content)
+	assert.Equal(t, uint64(10), entry.ForClient("", nil).Version)
+}
@@ -55,6 +55,8 @@ type InstanceCache interface {
 	GetInstancesCount() int
 	// GetInstancesCountByServiceID 根据服务ID获取实例数
 	GetInstancesCountByServiceID(serviceID string) model.InstanceCount
+	// GetInstanceChanges 获取服务在指定版本号之后变更和删除的实例，版本号未知或者过旧时返回false
+	GetInstanceChanges(serviceID string, revision string) ([]*model.Instance, []*model.Instance, bool)
 }
 
 // instanceCache 实例缓存的类
@@ -75,6 +77,9 @@ type instanceCache struct {
 	singleFlight     *singleflight.Group
 	instanceCount    int64
 	lastCheckAllTime int64
+	changes          store.ChangeLogStore // 存储开启变更日志时，按照序号增量拉取
+	cursor           *changeLogCursor     // 为空时需要全量加载
+	changeRings      *sync.Map            // service id -> instanceChangeRing
 }
 
 func init() {
@@ -96,8 +101,12 @@ func (ic *instanceCache) initialize(opt map[string]interface{}) error {
 	ic.ids = new(sync.Map)
 	ic.services = new(sync.Map)
 	ic.instanceCounts = new(sync.Map)
+	ic.changeRings = new(sync.Map)
 	ic.lastMtime = 0
 	ic.firstUpdate = true
+	if changes, ok := ic.storage.(store.ChangeLogStore); ok && changes.ChangeLogEnabled() {
+		ic.changes = changes
+	}
 	if opt == nil {
 		return nil
 	}
@@ -153,11 +162,24 @@ func (ic *instanceCache) checkAll() {
 		"[Cache][Instance] instance count not match, expect %d, actual %d, fallback to load all",
 		count, ic.instanceCount)
 	ic.lastMtime = 0
+	ic.cursor = nil
 }
 
 const maxLoadTimeDuration = 1 * time.Second
 
 func (ic *instanceCache) realUpdate() error {
+	if ic.changes != nil && ic.cursor != nil {
+		return ic.updateByChangeLog()
+	}
+	// 全量加载前先记录变更日志的序号，之后的变更通过变更日志拉取
+	var latest uint64
+	if ic.changes != nil {
+		var err error
+		if latest, err = ic.changes.GetLatestChangeSeq(store.ChangeLogInstance); err != nil {
+			log.CacheScope().Errorf("[Cache][Instance] get latest change seq err: %s", err.Error())
+			return err
+		}
+	}
 	// 拉取diff前的所有数据
 	start := time.Now()
 	lastMtime := ic.LastMtime()
@@ -176,6 +198,61 @@ func (ic *instanceCache) realUpdate() error {
 			zap.Int("update", update), zap.Int("delete", del),
 			zap.Time("last", lastMtime), zap.Duration("used", time.Since(start)))
 	}
+	if ic.changes != nil {
+		ic.cursor = newChangeLogCursor(latest)
+	}
+	return nil
+}
+
+// updateByChangeLog 根据变更日志增量更新实例
+func (ic *instanceCache) updateByChangeLog() error {
+	start := time.Now()
+	var update, del int
+	systemServices := make(map[string]bool, len(ic.systemServiceID))
+	for _, id := range ic.systemServiceID {
+		systemServices[id] = true
+	}
+	// 本次拉取到的实例，在 apply 时使用
+	var pulled map[string]*model.Instance
+	total, err := pullChangeLogs(ic.cursor, func(seq uint64, limit uint32) ([]*model.ChangeLog, error) {
+		logs, instances, err := ic.changes.GetInstanceChanges(seq, limit, ic.needMeta)
+		if err != nil {
+			return nil, err
+		}
+		pulled = instances
+		return logs, nil
+	}, func(ids map[string]bool) {
+		changed := make(map[string]*model.Instance, len(ids))
+		for id := range ids {
+			instance, ok := pulled[id]
+			if !ok {
+				// 存储中已经不存在，按照删除处理
+				cached := ic.GetInstance(id)
+				if cached == nil {
+					continue
+				}
+				deleted := *cached
+				deleted.Valid = false
+				instance = &deleted
+			}
+			if len(systemServices) > 0 && !systemServices[instance.ServiceID] {
+				continue
+			}
+			changed[id] = instance
+		}
+		u, d := ic.setInstances(changed)
+		update += u
+		del += d
+	})
+	if err != nil {
+		log.CacheScope().Errorf("[Cache][Instance] update instances by change log err: %s", err.Error())
+		return err
+	}
+	if time.Since(start) > maxLoadTimeDuration {
+		log.CacheScope().Info("[Cache][Instance] get instance changes",
+			zap.Int("changes", total), zap.Int("update", update), zap.Int("delete", del),
+			zap.Uint64("seq", ic.cursor.seq), zap.Duration("used", time.Since(start)))
+	}
 	return nil
 }
 
@@ -184,8 +261,10 @@ func (ic *instanceCache) clear() error {
 	ic.ids = new(sync.Map)
 	ic.services = new(sync.Map)
 	ic.instanceCounts = new(sync.Map)
+	ic.changeRings = new(sync.Map)
 	ic.instanceCount = 0
 	ic.lastMtime = 0
+	ic.cursor = nil
 	return nil
 }
 
@@ -232,7 +311,11 @@ func (ic *instanceCache) setInstances(ins map[string]*model.Instance) (int, int)
 			lastMtime = modifyTime
 		}
 		affect[item.ServiceID] = true
-		_, itemExist := ic.ids.Load(item.ID())
+		value, itemExist := ic.ids.Load(item.ID())
+		var old *model.Instance
+		if itemExist {
+			old = value.(*model.Instance)
+		}
 		// 待删除的instance
 		if !item.Valid {
 			del++
@@ -241,12 +324,10 @@ func (ic *instanceCache) setInstances(ins map[string]*model.Instance) (int, int)
 				ic.manager.onEvent(item, EventDeleted)
 				instanceCount--
 			}
-			value, ok := ic.services.Load(item.ServiceID)
-			if !ok {
-				continue
+			if value, ok := ic.services.Load(item.ServiceID); ok {
+				value.(*sync.Map).Delete(item.ID())
 			}
-
-			value.(*sync.Map).Delete(item.ID())
+			ic.recordInstanceChange(old, item)
 			continue
 		}
 		// 有修改或者新增的数据
@@ -271,6 +352,8 @@ func (ic *instanceCache) setInstances(ins map[string]*model.Instance) (int, int)
 			ic.services.Store(item.ServiceID, value)
 		}
 		value.(*sync.Map).Store(item.ID(), item)
+		// 实例写入缓存之后再记录变更，版本号计算时读取到的变更序号不会超前于读取到的实例
+		ic.recordInstanceChange(old, item)
 	}
 
 	if ic.lastMtime != lastMtime {
@@ -0,0 +1,217 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package cache
+
+import (
+	"sync"
+
+	"github.com/polarismesh/polaris-server/common/model"
+)
+
+const (
+	// instanceChangeRingSize 每个服务保留的实例变更记录数，超出后过旧的版本号只能返回全量
+	instanceChangeRingSize = 1024
+	// instanceRevisionRingSize 每个服务保留的版本号个数
+	instanceRevisionRingSize = 32
+)
+
+// instanceChange 服务下实例的一次变更
+type instanceChange struct {
+	seq        uint64
+	instanceID string
+	// removed 实例被删除时保存删除前的数据，用于告知客户端被删除实例的地址
+	removed *model.Instance
+}
+
+// instanceRevision 版本号与计算版本号时变更序号的对应关系
+type instanceRevision struct {
+	revision string
+	seq      uint64
+}
+
+// instanceChangeRing 服务的实例变更环，用于根据客户端持有的版本号计算增量
+type instanceChangeRing struct {
+	mutex     sync.RWMutex
+	seq       uint64
+	changes   []instanceChange
+	revisions []instanceRevision
+}
+
+func newInstanceChangeRing() *instanceChangeRing {
+	return &instanceChangeRing{
+		changes:   make([]instanceChange, 0, instanceChangeRingSize),
+		revisions: make([]instanceRevision, 0, instanceRevisionRingSize),
+	}
+}
+
+// record 记录一次实例变更
+func (r *instanceChangeRing) record(instance *model.Instance) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.seq++
+	change := instanceChange{seq: r.seq, instanceID: instance.ID()}
+	if !instance.Valid {
+		change.removed = instance
+	}
+	if len(r.changes) < instanceChangeRingSize {
+		r.changes = append(r.changes, change)
+		return
+	}
+	r.changes[int((r.seq-1)%instanceChangeRingSize)] = change
+}
+
+// currentSeq 当前最新的变更序号
+func (r *instanceChangeRing) currentSeq() uint64 {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	return r.seq
+}
+
+// recordRevision 记录版本号对应的变更序号，相同的版本号保留最小的序号
+// 序号偏小只会让增量多包含一些实例，偏大则会遗漏变更，因此已知的版本号不再调高序号
+func (r *instanceChangeRing) recordRevision(revision string, seq uint64) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for i := range r.revisions {
+		if r.revisions[i].revision == revision {
+			if r.revisions[i].seq > seq {
+				r.revisions[i].seq = seq
+			}
+			return
+		}
+	}
+	if len(r.revisions) < instanceRevisionRingSize {
+		r.revisions = append(r.revisions, instanceRevision{revision: revision, seq: seq})
+		return
+	}
+	copy(r.revisions, r.revisions[1:])
+	r.revisions[len(r.revisions)-1] = instanceRevision{revision: revision, seq: seq}
+}
+
+// changesSince 获取版本号之后变更过的实例，实例ID -> 删除前的数据（未删除时为空）
+// 版本号未知或者变更记录已经被覆盖时返回false
+func (r *instanceChangeRing) changesSince(revision string) (map[string]*model.Instance, bool) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	var since uint64
+	found := false
+	for i := range r.revisions {
+		if r.revisions[i].revision == revision {
+			since, found = r.revisions[i].seq, true
+			break
+		}
+	}
+	if !found {
+		return nil, false
+	}
+	// 环中最旧的一条变更序号为 seq-len+1，since 之后的变更必须都还在环中
+	if r.seq-since > uint64(len(r.changes)) {
+		return nil, false
+	}
+	latest := make(map[string]instanceChange)
+	for _, change := range r.changes {
+		if change.seq <= since {
+			continue
+		}
+		// 环中的变更不按序号排列，保留每个实例序号最大的一次变更
+		if last, ok := latest[change.instanceID]; !ok || last.seq < change.seq {
+			latest[change.instanceID] = change
+		}
+	}
+	changes := make(map[string]*model.Instance, len(latest))
+	for id, change := range latest {
+		changes[id] = change.removed
+	}
+	return changes, true
+}
+
+// instanceChangeRing 获取服务的实例变更环
+func (ic *instanceCache) instanceChangeRing(serviceID string) *instanceChangeRing {
+	value, ok := ic.changeRings.Load(serviceID)
+	if !ok {
+		value, _ = ic.changeRings.LoadOrStore(serviceID, newInstanceChangeRing())
+	}
+	return value.(*instanceChangeRing)
+}
+
+// recordInstanceChange 记录实例变更，数据未发生变化时不记录
+func (ic *instanceCache) recordInstanceChange(old *model.Instance, item *model.Instance) {
+	if item.Valid && old != nil && old.Revision() != "" && old.Revision() == item.Revision() {
+		return
+	}
+	if !item.Valid && old == nil {
+		return
+	}
+	removed := item
+	if !item.Valid && old != nil {
+		// 删除的数据可能只有ID，使用缓存中的数据保留实例的地址
+		deleted := *old
+		deleted.Valid = false
+		removed = &deleted
+	}
+	ic.instanceChangeRing(item.ServiceID).record(removed)
+}
+
+// instanceChangeSeq 获取服务当前的实例变更序号
+func (ic *instanceCache) instanceChangeSeq(serviceID string) uint64 {
+	return ic.instanceChangeRing(serviceID).currentSeq()
+}
+
+// recordInstanceRevision 记录服务的版本号对应的变更序号
+func (ic *instanceCache) recordInstanceRevision(serviceID string, revision string, seq uint64) {
+	ic.instanceChangeRing(serviceID).recordRevision(revision, seq)
+}
+
+// removeInstanceChanges 服务的实例全部删除时清理变更环
+func (ic *instanceCache) removeInstanceChanges(serviceID string) {
+	ic.changeRings.Delete(serviceID)
+}
+
+// GetInstanceChanges 获取服务在指定版本号之后变更的实例
+// changed 为当前仍然存在的实例，removed 为已经删除的实例；版本号未知或者过旧时 ok 为false
+func (ic *instanceCache) GetInstanceChanges(serviceID string, revision string) (
+	changed []*model.Instance, removed []*model.Instance, ok bool) {
+	if serviceID == "" || revision == "" {
+		return nil, nil, false
+	}
+	value, exist := ic.changeRings.Load(serviceID)
+	if !exist {
+		return nil, nil, false
+	}
+	changes, ok := value.(*instanceChangeRing).changesSince(revision)
+	if !ok {
+		return nil, nil, false
+	}
+	for id, deleted := range changes {
+		// 以缓存中的最新数据为准，实例可能在删除之后重新注册
+		if instance := ic.GetInstance(id); instance != nil && instance.ServiceID == serviceID {
+			changed = append(changed, instance)
+			continue
+		}
+		if deleted == nil {
+			// 缓存已经被清理，无法得知实例的变化
+			return nil, nil, false
+		}
+		removed = append(removed, deleted)
+	}
+	return changed, removed, true
+}
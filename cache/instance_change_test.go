@@ -0,0 +1,212 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package cache
+
+import (
+	"fmt"
+	"testing"
+
+	v1 "github.com/polarismesh/polaris-server/common/api/v1"
+	"github.com/polarismesh/polaris-server/common/model"
+	"github.com/polarismesh/polaris-server/common/utils"
+)
+
+// TestInstanceCache_GetInstanceChanges 根据版本号获取增量实例
+func TestInstanceCache_GetInstanceChanges(t *testing.T) {
+	ctl, _, ic := newTestInstanceCache(t)
+	defer ctl.Finish()
+	ic.revisionCh = make(chan *revisionNotify, 2*instanceChangeRingSize)
+
+	serviceID := "serviceID-delta"
+	instances := genModelInstances("delta", 10)
+	for _, instance := range instances {
+		instance.Proto.Revision = utils.NewStringValue("rev-1")
+	}
+	ic.setInstances(instances)
+	ic.recordInstanceRevision(serviceID, "revision-1", ic.instanceChangeSeq(serviceID))
+
+	t.Run("版本号之后没有变更", func(t *testing.T) {
+		changed, removed, ok := ic.GetInstanceChanges(serviceID, "revision-1")
+		if !ok || len(changed) != 0 || len(removed) != 0 {
+			t.Fatalf("changed: %d, removed: %d, ok: %v", len(changed), len(removed), ok)
+		}
+	})
+
+	t.Run("未变化的实例不记录为变更", func(t *testing.T) {
+		same := instances["instanceID-delta-0"]
+		ic.setInstances(map[string]*model.Instance{same.ID(): same})
+		changed, _, ok := ic.GetInstanceChanges(serviceID, "revision-1")
+		if !ok || len(changed) != 0 {
+			t.Fatalf("changed: %d, ok: %v", len(changed), ok)
+		}
+	})
+
+	t.Run("返回变更和删除的实例", func(t *testing.T) {
+		updated := &model.Instance{
+			Proto: &v1.Instance{
+				Id:       utils.NewStringValue("instanceID-delta-1"),
+				Host:     utils.NewStringValue("host-delta-1"),
+				Port:     utils.NewUInt32Value(11),
+				Revision: utils.NewStringValue("rev-2"),
+			},
+			ServiceID: serviceID,
+			Valid:     true,
+		}
+		deleted := &model.Instance{
+			Proto:     &v1.Instance{Id: utils.NewStringValue("instanceID-delta-2")},
+			ServiceID: serviceID,
+			Valid:     false,
+		}
+		ic.setInstances(map[string]*model.Instance{updated.ID(): updated, deleted.ID(): deleted})
+
+		changed, removed, ok := ic.GetInstanceChanges(serviceID, "revision-1")
+		if !ok {
+			t.Fatalf("error")
+		}
+		if len(changed) != 1 || changed[0].ID() != updated.ID() {
+			t.Fatalf("changed: %+v", changed)
+		}
+		if len(removed) != 1 || removed[0].ID() != deleted.ID() || removed[0].Host() != "host-delta-2" {
+			t.Fatalf("removed: %+v", removed)
+		}
+	})
+
+	t.Run("未知的版本号无法计算增量", func(t *testing.T) {
+		if _, _, ok := ic.GetInstanceChanges(serviceID, "revision-unknown"); ok {
+			t.Fatalf("error")
+		}
+	})
+
+	t.Run("变更超出保留数量后无法计算增量", func(t *testing.T) {
+		for i := 0; i <= instanceChangeRingSize; i++ {
+			instance := &model.Instance{
+				Proto: &v1.Instance{
+					Id:       utils.NewStringValue("instanceID-delta-3"),
+					Revision: utils.NewStringValue(fmt.Sprintf("rev-%d", i+10)),
+				},
+				ServiceID: serviceID,
+				Valid:     true,
+			}
+			ic.setInstances(map[string]*model.Instance{instance.ID(): instance})
+		}
+		if _, _, ok := ic.GetInstanceChanges(serviceID, "revision-1"); ok {
+			t.Fatalf("error")
+		}
+	})
+}
+
+// revisionComputingListener 在 setInstances 执行过程中计算版本号，模拟 processRevisionWorker 并发执行
+type revisionComputingListener struct {
+	compute func()
+}
+
+// OnCreated 实例写入缓存的过程中计算版本号
+func (l *revisionComputingListener) OnCreated(value interface{}) {
+	l.compute()
+}
+
+// OnUpdated 实例写入缓存的过程中计算版本号
+func (l *revisionComputingListener) OnUpdated(value interface{}) {
+	l.compute()
+}
+
+// OnDeleted 实例写入缓存的过程中计算版本号
+func (l *revisionComputingListener) OnDeleted(value interface{}) {
+	l.compute()
+}
+
+// OnBatchCreated callback when cache value created
+func (l *revisionComputingListener) OnBatchCreated(value interface{}) {}
+
+// OnBatchUpdated callback when cache value updated
+func (l *revisionComputingListener) OnBatchUpdated(value interface{}) {}
+
+// OnBatchDeleted callback when cache value deleted
+func (l *revisionComputingListener) OnBatchDeleted(value interface{}) {}
+
+// TestInstanceCache_InstanceChangesWithConcurrentRevision 实例更新与版本号计算交错执行时，增量不能遗漏变更
+func TestInstanceCache_InstanceChangesWithConcurrentRevision(t *testing.T) {
+	ctl, _, ic := newTestInstanceCache(t)
+	defer ctl.Finish()
+
+	serviceID := "serviceID-race"
+	snapshots := make(map[string]map[string]bool)
+	// 与 processRevisionWorker 相同，先读取变更序号再读取实例计算版本号
+	computeRevision := func() {
+		seq := ic.instanceChangeSeq(serviceID)
+		instances := ic.GetInstancesByServiceID(serviceID)
+		revision, err := ComputeRevision("service-revision", instances)
+		if err != nil {
+			t.Fatalf("compute revision: %v", err)
+		}
+		ic.recordInstanceRevision(serviceID, revision, seq)
+		if _, ok := snapshots[revision]; ok {
+			return
+		}
+		ids := make(map[string]bool, len(instances))
+		for _, instance := range instances {
+			ids[instance.ID()] = true
+		}
+		snapshots[revision] = ids
+	}
+	ic.manager = newListenerManager([]Listener{&revisionComputingListener{compute: computeRevision}})
+
+	instances := make(map[string]*model.Instance)
+	for i := 0; i < 10; i++ {
+		instance := &model.Instance{
+			Proto: &v1.Instance{
+				Id:       utils.NewStringValue(fmt.Sprintf("instanceID-race-%d", i)),
+				Revision: utils.NewStringValue(fmt.Sprintf("rev-%d", i)),
+			},
+			ServiceID: serviceID,
+			Valid:     true,
+		}
+		instances[instance.ID()] = instance
+		ic.setInstances(map[string]*model.Instance{instance.ID(): instance})
+		// 更新完成后再计算一次，与实例写入过程中计算出的版本号交替出现
+		computeRevision()
+	}
+	deleted := &model.Instance{
+		Proto:     &v1.Instance{Id: utils.NewStringValue("instanceID-race-0")},
+		ServiceID: serviceID,
+		Valid:     false,
+	}
+	ic.setInstances(map[string]*model.Instance{deleted.ID(): deleted})
+	delete(instances, deleted.ID())
+
+	for revision, ids := range snapshots {
+		changed, removed, ok := ic.GetInstanceChanges(serviceID, revision)
+		if !ok {
+			continue
+		}
+		for _, instance := range changed {
+			ids[instance.ID()] = true
+		}
+		for _, instance := range removed {
+			delete(ids, instance.ID())
+		}
+		if len(ids) != len(instances) {
+			t.Fatalf("revision %s: expect %d instances after applying delta, got %d", revision, len(instances), len(ids))
+		}
+		for id := range instances {
+			if !ids[id] {
+				t.Fatalf("revision %s misses the change of instance %s", revision, id)
+			}
+		}
+	}
+}
@@ -131,6 +131,8 @@ type serviceCache struct {
 	pendingServices     map[string]int8
 	namespaceServiceCnt *sync.Map // namespce -> model.NamespaceServiceCount
 	cancel              context.CancelFunc
+	changes             store.ChangeLogStore // 存储开启变更日志时，按照序号增量拉取
+	cursor              *changeLogCursor     // 为空时需要全量加载
 }
 
 // init 自注册到缓存列表
@@ -165,6 +167,10 @@ func (sc *serviceCache) initialize(opt map[string]interface{}) error {
 	sc.cancel = cancel
 	go sc.watchCountChangeCh(ctx)
 
+	if changes, ok := sc.storage.(store.ChangeLogStore); ok && changes.ChangeLogEnabled() {
+		sc.changes = changes
+	}
+
 	if opt == nil {
 		return nil
 	}
@@ -193,6 +199,18 @@ func (sc *serviceCache) update() error {
 }
 
 func (sc *serviceCache) realUpdate() error {
+	if sc.changes != nil && sc.cursor != nil {
+		return sc.updateByChangeLog()
+	}
+	// 全量加载前先记录变更日志的序号，之后的变更通过变更日志拉取
+	var latest uint64
+	if sc.changes != nil {
+		var err error
+		if latest, err = sc.changes.GetLatestChangeSeq(store.ChangeLogService); err != nil {
+			log.CacheScope().Errorf("[Cache][Service] get latest change seq err: %s", err.Error())
+			return err
+		}
+	}
 	// 获取几秒前的全部数据
 	start := time.Now()
 	lastMtime := sc.LastMtime()
@@ -208,6 +226,57 @@ func (sc *serviceCache) realUpdate() error {
 	log.CacheScope().Info(
 		"[Cache][Service] get more services", zap.Int("update", update), zap.Int("delete", del),
 		zap.Time("last", lastMtime), zap.Duration("used", time.Since(start)))
+	if sc.changes != nil {
+		sc.cursor = newChangeLogCursor(latest)
+	}
+	return nil
+}
+
+// updateByChangeLog 根据变更日志增量更新服务
+func (sc *serviceCache) updateByChangeLog() error {
+	start := time.Now()
+	var update, del int
+	// 本次拉取到的服务，在 apply 时使用
+	var pulled map[string]*model.Service
+	total, err := pullChangeLogs(sc.cursor, func(seq uint64, limit uint32) ([]*model.ChangeLog, error) {
+		logs, services, err := sc.changes.GetServiceChanges(seq, limit, sc.needMeta)
+		if err != nil {
+			return nil, err
+		}
+		pulled = services
+		return logs, nil
+	}, func(ids map[string]bool) {
+		changed := make(map[string]*model.Service, len(ids))
+		for id := range ids {
+			service, ok := pulled[id]
+			if !ok {
+				// 存储中已经不存在，按照删除处理
+				cached := sc.GetServiceByID(id)
+				if cached == nil {
+					continue
+				}
+				deleted := *cached
+				deleted.Valid = false
+				service = &deleted
+			}
+			if sc.disableBusiness && service.Namespace != systemNamespace {
+				continue
+			}
+			changed[id] = service
+		}
+		u, d := sc.setServices(changed)
+		update += u
+		del += d
+	})
+	if err != nil {
+		log.CacheScope().Errorf("[Cache][Service] update services by change log err: %s", err.Error())
+		return err
+	}
+	if total > 0 {
+		log.CacheScope().Info(
+			"[Cache][Service] get service changes", zap.Int("changes", total), zap.Int("update", update),
+			zap.Int("delete", del), zap.Uint64("seq", sc.cursor.seq), zap.Duration("used", time.Since(start)))
+	}
 	return nil
 }
 
@@ -220,6 +289,7 @@ func (sc *serviceCache) clear() error {
 	sc.namespaceServiceCnt = new(sync.Map)
 	sc.pendingServices = make(map[string]int8)
 	sc.lastMtime = 0
+	sc.cursor = nil
 	return nil
 }
 
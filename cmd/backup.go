@@ -0,0 +1,120 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/polarismesh/polaris-server/store/backup"
+)
+
+var (
+	backupConfig     = ""
+	backupFile       = ""
+	backupNamespaces []string
+	restoreDryRun    = false
+
+	backupCmd = &cobra.Command{
+		Use:   "backup",
+		Short: "export all data as a backup archive",
+		Long:  "export all data of the store configured in the config file as a store independent backup archive",
+		RunE: func(c *cobra.Command, args []string) error {
+			return runBackup(backupConfig, backupFile, backupNamespaces)
+		},
+	}
+
+	restoreCmd = &cobra.Command{
+		Use:   "restore",
+		Short: "restore data from a backup archive",
+		Long:  "restore data from a backup archive into the store configured in the config file",
+		RunE: func(c *cobra.Command, args []string) error {
+			return runRestore(backupConfig, backupFile, backupNamespaces, restoreDryRun)
+		},
+	}
+)
+
+// init 解析命令参数
+func init() {
+	for _, c := range []*cobra.Command{backupCmd, restoreCmd} {
+		c.PersistentFlags().StringVarP(&backupConfig, "config", "c", "polaris-server.yaml", "config file path")
+		c.PersistentFlags().StringSliceVarP(&backupNamespaces, "namespaces", "n", nil,
+			"only the resources of these namespaces, all namespaces if empty")
+	}
+	backupCmd.PersistentFlags().StringVarP(&backupFile, "output", "o", "polaris-backup.tar.gz",
+		"backup archive path")
+	restoreCmd.PersistentFlags().StringVarP(&backupFile, "input", "i", "", "backup archive path")
+	restoreCmd.PersistentFlags().BoolVar(&restoreDryRun, "dry-run", false,
+		"only report what would be restored, without writing to the store")
+}
+
+// runBackup 导出备份文件
+func runBackup(configPath, output string, namespaces []string) error {
+	s, err := openStore(configPath)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = s.Destroy() }()
+
+	f, err := os.Create(output)
+	if err != nil {
+		return err
+	}
+	manifest, err := backup.Export(s, f, namespaces)
+	if err != nil {
+		_ = f.Close()
+		_ = os.Remove(output)
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	fmt.Printf("backup archive %s created, counts: %v\n", output, manifest.Counts)
+	return nil
+}
+
+// runRestore 从备份文件还原数据
+func runRestore(configPath, input string, namespaces []string, dryRun bool) error {
+	if input == "" {
+		return errors.New("backup archive path is required")
+	}
+	f, err := os.Open(input)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	s, err := openStore(configPath)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = s.Destroy() }()
+
+	report, err := backup.Restore(s, f, namespaces, dryRun)
+	if err != nil {
+		return err
+	}
+	fmt.Print(report.String())
+	if !dryRun && !report.Verified() {
+		return errors.New("verification failed: some archived data is missing in store")
+	}
+	return nil
+}
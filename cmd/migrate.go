@@ -0,0 +1,99 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/polarismesh/polaris-server/bootstrap/config"
+	"github.com/polarismesh/polaris-server/store"
+	"github.com/polarismesh/polaris-server/store/migrate"
+)
+
+var (
+	migrateSource = ""
+	migrateTarget = ""
+	migrateDryRun = false
+
+	migrateCmd = &cobra.Command{
+		Use:   "migrate",
+		Short: "migrate data between store plugins",
+		Long: "migrate all data from the store configured in the source config file " +
+			"to the store configured in the target config file",
+		RunE: func(c *cobra.Command, args []string) error {
+			return runMigrate(migrateSource, migrateTarget, migrateDryRun)
+		},
+	}
+)
+
+// init 解析命令参数
+func init() {
+	migrateCmd.PersistentFlags().StringVarP(&migrateSource, "source", "s", "",
+		"config file path of the source store")
+	migrateCmd.PersistentFlags().StringVarP(&migrateTarget, "target", "t", "",
+		"config file path of the target store")
+	migrateCmd.PersistentFlags().BoolVar(&migrateDryRun, "dry-run", false,
+		"only report what would be migrated, without writing to the target store")
+}
+
+// runMigrate 根据配置文件中的 store 配置初始化源存储和目标存储，并执行迁移
+func runMigrate(sourcePath, targetPath string, dryRun bool) error {
+	source, err := openStore(sourcePath)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = source.Destroy() }()
+
+	target, err := openStore(targetPath)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = target.Destroy() }()
+
+	report, err := migrate.NewMigrator(target, dryRun).Run(source)
+	if err != nil {
+		return err
+	}
+	fmt.Print(report.String())
+	if !report.Verified() {
+		return errors.New("verification failed: some source data is missing in target store")
+	}
+	return nil
+}
+
+// openStore 读取配置文件中的 store 配置，创建并初始化独立的存储实例
+func openStore(path string) (store.Store, error) {
+	if path == "" {
+		return nil, errors.New("config file path is required")
+	}
+	cfg, err := config.Load(path)
+	if err != nil {
+		return nil, err
+	}
+	s, err := store.NewStore(cfg.Store.Name)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Initialize(&cfg.Store); err != nil {
+		return nil, fmt.Errorf("initialize store `%s` from %s: %v", cfg.Store.Name, path, err)
+	}
+	return s, nil
+}
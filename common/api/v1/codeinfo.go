@@ -156,6 +156,17 @@ const (
 	InvalidConfigFileTags          uint32 = 400805
 	InvalidWatchConfigFileFormat   uint32 = 400806
 	NotFoundResourceConfigFile     uint32 = 400807
+	InvalidConfigFileGrayRule      uint32 = 400808
+	NotReleasedConfigFile          uint32 = 400809
+	InvalidConfigFileHistory       uint32 = 400810
+	InvalidConfigFileContent       uint32 = 400811
+	InvalidConfigFileSchema        uint32 = 400812
+	NotSupportEncryptedConfigFile  uint32 = 400813
+	InvalidConfigFileTemplate      uint32 = 400814
+	InvalidConfigFileArchive       uint32 = 400815
+	InvalidPublishRequest          uint32 = 400816
+	InvalidPublishRequestStatus    uint32 = 400817
+	ConfigFileCryptoException      uint32 = 500801
 
 	// 鉴权相关错误码
 	InvalidUserOwners         uint32 = 400410
@@ -314,6 +325,17 @@ var code2info = map[uint32]string{
 	InvalidConfigFileTags:          "invalid config file tags, tags should be pair, like key1,value1,key2,value2. and key,value should not blank",
 	InvalidWatchConfigFileFormat:   "invalid watch config file format",
 	NotFoundResourceConfigFile:     "config file not existed",
+	InvalidConfigFileGrayRule:      "invalid config file gray rule, client ips or client labels is required",
+	NotReleasedConfigFile:          "config file has not been released",
+	InvalidConfigFileHistory:       "config file release history can not be rolled back",
+	InvalidConfigFileContent:       "invalid config file content",
+	InvalidConfigFileSchema:        "invalid json schema of config file group",
+	NotSupportEncryptedConfigFile:  "operation is not supported for encrypted config file",
+	InvalidConfigFileTemplate:      "invalid config file template",
+	InvalidConfigFileArchive:       "invalid config file archive",
+	InvalidPublishRequest:          "invalid config file publish request",
+	InvalidPublishRequestStatus:    "operation is not allowed in current status of publish request",
+	ConfigFileCryptoException:      "encrypt or decrypt config file exception",
 
 	// 鉴权错误
 	NotFoundUser:             "not found user",
@@ -29,6 +29,7 @@ type ConfigFileGroup struct {
 	ModifyTime           *wrappers.StringValue `protobuf:"bytes,7,opt,name=modify_time,json=modifyTime,proto3" json:"modify_time,omitempty"`
 	ModifyBy             *wrappers.StringValue `protobuf:"bytes,8,opt,name=modify_by,json=modifyBy,proto3" json:"modify_by,omitempty"`
 	FileCount            *wrappers.UInt64Value `protobuf:"bytes,9,opt,name=fileCount,proto3" json:"fileCount,omitempty"`
+	JsonSchema           *wrappers.StringValue `protobuf:"bytes,10,opt,name=json_schema,json=jsonSchema,proto3" json:"json_schema,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
 	XXX_unrecognized     []byte                `json:"-"`
 	XXX_sizecache        int32                 `json:"-"`
@@ -121,6 +122,13 @@ func (m *ConfigFileGroup) GetFileCount() *wrappers.UInt64Value {
 	return nil
 }
 
+func (m *ConfigFileGroup) GetJsonSchema() *wrappers.StringValue {
+	if m != nil {
+		return m.JsonSchema
+	}
+	return nil
+}
+
 type ConfigFile struct {
 	Id                   *wrappers.UInt64Value `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	Name                 *wrappers.StringValue `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
@@ -137,6 +145,9 @@ type ConfigFile struct {
 	ModifyBy             *wrappers.StringValue `protobuf:"bytes,13,opt,name=modify_by,json=modifyBy,proto3" json:"modify_by,omitempty"`
 	ReleaseTime          *wrappers.StringValue `protobuf:"bytes,14,opt,name=release_time,json=releaseTime,proto3" json:"release_time,omitempty"`
 	ReleaseBy            *wrappers.StringValue `protobuf:"bytes,15,opt,name=release_by,json=releaseBy,proto3" json:"release_by,omitempty"`
+	Encrypted            *wrappers.BoolValue   `protobuf:"bytes,16,opt,name=encrypted,proto3" json:"encrypted,omitempty"`
+	BaseFile             *wrappers.StringValue `protobuf:"bytes,17,opt,name=base_file,json=baseFile,proto3" json:"base_file,omitempty"`
+	VariablesFile        *wrappers.StringValue `protobuf:"bytes,18,opt,name=variables_file,json=variablesFile,proto3" json:"variables_file,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
 	XXX_unrecognized     []byte                `json:"-"`
 	XXX_sizecache        int32                 `json:"-"`
@@ -271,6 +282,27 @@ func (m *ConfigFile) GetReleaseBy() *wrappers.StringValue {
 	return nil
 }
 
+func (m *ConfigFile) GetEncrypted() *wrappers.BoolValue {
+	if m != nil {
+		return m.Encrypted
+	}
+	return nil
+}
+
+func (m *ConfigFile) GetBaseFile() *wrappers.StringValue {
+	if m != nil {
+		return m.BaseFile
+	}
+	return nil
+}
+
+func (m *ConfigFile) GetVariablesFile() *wrappers.StringValue {
+	if m != nil {
+		return m.VariablesFile
+	}
+	return nil
+}
+
 type ConfigFileTag struct {
 	Key                  *wrappers.StringValue `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
 	Value                *wrappers.StringValue `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
@@ -331,6 +363,8 @@ type ConfigFileRelease struct {
 	CreateBy             *wrappers.StringValue `protobuf:"bytes,11,opt,name=create_by,json=createBy,proto3" json:"create_by,omitempty"`
 	ModifyTime           *wrappers.StringValue `protobuf:"bytes,12,opt,name=modify_time,json=modifyTime,proto3" json:"modify_time,omitempty"`
 	ModifyBy             *wrappers.StringValue `protobuf:"bytes,13,opt,name=modify_by,json=modifyBy,proto3" json:"modify_by,omitempty"`
+	GrayRule             *ConfigFileGrayRule   `protobuf:"bytes,14,opt,name=gray_rule,json=grayRule,proto3" json:"gray_rule,omitempty"`
+	Encrypted            *wrappers.BoolValue   `protobuf:"bytes,15,opt,name=encrypted,proto3" json:"encrypted,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
 	XXX_unrecognized     []byte                `json:"-"`
 	XXX_sizecache        int32                 `json:"-"`
@@ -451,6 +485,20 @@ func (m *ConfigFileRelease) GetModifyBy() *wrappers.StringValue {
 	return nil
 }
 
+func (m *ConfigFileRelease) GetGrayRule() *ConfigFileGrayRule {
+	if m != nil {
+		return m.GrayRule
+	}
+	return nil
+}
+
+func (m *ConfigFileRelease) GetEncrypted() *wrappers.BoolValue {
+	if m != nil {
+		return m.Encrypted
+	}
+	return nil
+}
+
 type ConfigFileReleaseHistory struct {
 	Id                   *wrappers.UInt64Value `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	Name                 *wrappers.StringValue `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
@@ -468,6 +516,8 @@ type ConfigFileReleaseHistory struct {
 	CreateBy             *wrappers.StringValue `protobuf:"bytes,14,opt,name=create_by,json=createBy,proto3" json:"create_by,omitempty"`
 	ModifyTime           *wrappers.StringValue `protobuf:"bytes,15,opt,name=modify_time,json=modifyTime,proto3" json:"modify_time,omitempty"`
 	ModifyBy             *wrappers.StringValue `protobuf:"bytes,16,opt,name=modify_by,json=modifyBy,proto3" json:"modify_by,omitempty"`
+	Encrypted            *wrappers.BoolValue   `protobuf:"bytes,17,opt,name=encrypted,proto3" json:"encrypted,omitempty"`
+	TemplateContent      *wrappers.StringValue `protobuf:"bytes,18,opt,name=template_content,json=templateContent,proto3" json:"template_content,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
 	XXX_unrecognized     []byte                `json:"-"`
 	XXX_sizecache        int32                 `json:"-"`
@@ -609,6 +659,20 @@ func (m *ConfigFileReleaseHistory) GetModifyBy() *wrappers.StringValue {
 	return nil
 }
 
+func (m *ConfigFileReleaseHistory) GetEncrypted() *wrappers.BoolValue {
+	if m != nil {
+		return m.Encrypted
+	}
+	return nil
+}
+
+func (m *ConfigFileReleaseHistory) GetTemplateContent() *wrappers.StringValue {
+	if m != nil {
+		return m.TemplateContent
+	}
+	return nil
+}
+
 type ClientConfigFileInfo struct {
 	Namespace            *wrappers.StringValue `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
 	Group                *wrappers.StringValue `protobuf:"bytes,2,opt,name=group,proto3" json:"group,omitempty"`
@@ -616,6 +680,7 @@ type ClientConfigFileInfo struct {
 	Content              *wrappers.StringValue `protobuf:"bytes,4,opt,name=content,proto3" json:"content,omitempty"`
 	Version              *wrappers.UInt64Value `protobuf:"bytes,5,opt,name=version,proto3" json:"version,omitempty"`
 	Md5                  *wrappers.StringValue `protobuf:"bytes,6,opt,name=md5,proto3" json:"md5,omitempty"`
+	Labels               map[string]string     `protobuf:"bytes,7,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
 	XXX_unrecognized     []byte                `json:"-"`
 	XXX_sizecache        int32                 `json:"-"`
@@ -687,10 +752,18 @@ func (m *ClientConfigFileInfo) GetMd5() *wrappers.StringValue {
 	return nil
 }
 
+func (m *ClientConfigFileInfo) GetLabels() map[string]string {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
 type ClientWatchConfigFileRequest struct {
 	ClientIp             *wrappers.StringValue   `protobuf:"bytes,1,opt,name=client_ip,json=clientIp,proto3" json:"client_ip,omitempty"`
 	ServiceName          *wrappers.StringValue   `protobuf:"bytes,2,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
 	WatchFiles           []*ClientConfigFileInfo `protobuf:"bytes,3,rep,name=watch_files,json=watchFiles,proto3" json:"watch_files,omitempty"`
+	Labels               map[string]string       `protobuf:"bytes,4,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
 	XXX_unrecognized     []byte                  `json:"-"`
 	XXX_sizecache        int32                   `json:"-"`
@@ -741,6 +814,459 @@ func (m *ClientWatchConfigFileRequest) GetWatchFiles() []*ClientConfigFileInfo {
 	return nil
 }
 
+func (m *ClientWatchConfigFileRequest) GetLabels() map[string]string {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
+type ConfigFileGrayRule struct {
+	ClientIps            []*wrappers.StringValue `protobuf:"bytes,1,rep,name=client_ips,json=clientIps,proto3" json:"client_ips,omitempty"`
+	ClientLabels         map[string]string       `protobuf:"bytes,2,rep,name=client_labels,json=clientLabels,proto3" json:"client_labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
+}
+
+func (m *ConfigFileGrayRule) Reset()         { *m = ConfigFileGrayRule{} }
+func (m *ConfigFileGrayRule) String() string { return proto.CompactTextString(m) }
+func (*ConfigFileGrayRule) ProtoMessage()    {}
+func (*ConfigFileGrayRule) Descriptor() ([]byte, []int) {
+	return fileDescriptor_config_file_1b67d87a0ba5be64, []int{7}
+}
+func (m *ConfigFileGrayRule) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConfigFileGrayRule.Unmarshal(m, b)
+}
+func (m *ConfigFileGrayRule) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConfigFileGrayRule.Marshal(b, m, deterministic)
+}
+func (dst *ConfigFileGrayRule) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConfigFileGrayRule.Merge(dst, src)
+}
+func (m *ConfigFileGrayRule) XXX_Size() int {
+	return xxx_messageInfo_ConfigFileGrayRule.Size(m)
+}
+func (m *ConfigFileGrayRule) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConfigFileGrayRule.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConfigFileGrayRule proto.InternalMessageInfo
+
+func (m *ConfigFileGrayRule) GetClientIps() []*wrappers.StringValue {
+	if m != nil {
+		return m.ClientIps
+	}
+	return nil
+}
+
+func (m *ConfigFileGrayRule) GetClientLabels() map[string]string {
+	if m != nil {
+		return m.ClientLabels
+	}
+	return nil
+}
+
+type ConfigFileDiff struct {
+	From                 *wrappers.StringValue `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To                   *wrappers.StringValue `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	Format               *wrappers.StringValue `protobuf:"bytes,3,opt,name=format,proto3" json:"format,omitempty"`
+	UnifiedDiff          *wrappers.StringValue `protobuf:"bytes,4,opt,name=unified_diff,json=unifiedDiff,proto3" json:"unified_diff,omitempty"`
+	KeyDiffs             []*ConfigFileKeyDiff  `protobuf:"bytes,5,rep,name=key_diffs,json=keyDiffs,proto3" json:"key_diffs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *ConfigFileDiff) Reset()         { *m = ConfigFileDiff{} }
+func (m *ConfigFileDiff) String() string { return proto.CompactTextString(m) }
+func (*ConfigFileDiff) ProtoMessage()    {}
+func (*ConfigFileDiff) Descriptor() ([]byte, []int) {
+	return fileDescriptor_config_file_1b67d87a0ba5be64, []int{8}
+}
+func (m *ConfigFileDiff) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConfigFileDiff.Unmarshal(m, b)
+}
+func (m *ConfigFileDiff) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConfigFileDiff.Marshal(b, m, deterministic)
+}
+func (dst *ConfigFileDiff) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConfigFileDiff.Merge(dst, src)
+}
+func (m *ConfigFileDiff) XXX_Size() int {
+	return xxx_messageInfo_ConfigFileDiff.Size(m)
+}
+func (m *ConfigFileDiff) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConfigFileDiff.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConfigFileDiff proto.InternalMessageInfo
+
+func (m *ConfigFileDiff) GetFrom() *wrappers.StringValue {
+	if m != nil {
+		return m.From
+	}
+	return nil
+}
+
+func (m *ConfigFileDiff) GetTo() *wrappers.StringValue {
+	if m != nil {
+		return m.To
+	}
+	return nil
+}
+
+func (m *ConfigFileDiff) GetFormat() *wrappers.StringValue {
+	if m != nil {
+		return m.Format
+	}
+	return nil
+}
+
+func (m *ConfigFileDiff) GetUnifiedDiff() *wrappers.StringValue {
+	if m != nil {
+		return m.UnifiedDiff
+	}
+	return nil
+}
+
+func (m *ConfigFileDiff) GetKeyDiffs() []*ConfigFileKeyDiff {
+	if m != nil {
+		return m.KeyDiffs
+	}
+	return nil
+}
+
+type ConfigFileKeyDiff struct {
+	Key                  *wrappers.StringValue `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Type                 *wrappers.StringValue `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	OldValue             *wrappers.StringValue `protobuf:"bytes,3,opt,name=old_value,json=oldValue,proto3" json:"old_value,omitempty"`
+	NewValue             *wrappers.StringValue `protobuf:"bytes,4,opt,name=new_value,json=newValue,proto3" json:"new_value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *ConfigFileKeyDiff) Reset()         { *m = ConfigFileKeyDiff{} }
+func (m *ConfigFileKeyDiff) String() string { return proto.CompactTextString(m) }
+func (*ConfigFileKeyDiff) ProtoMessage()    {}
+func (*ConfigFileKeyDiff) Descriptor() ([]byte, []int) {
+	return fileDescriptor_config_file_1b67d87a0ba5be64, []int{9}
+}
+func (m *ConfigFileKeyDiff) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConfigFileKeyDiff.Unmarshal(m, b)
+}
+func (m *ConfigFileKeyDiff) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConfigFileKeyDiff.Marshal(b, m, deterministic)
+}
+func (dst *ConfigFileKeyDiff) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConfigFileKeyDiff.Merge(dst, src)
+}
+func (m *ConfigFileKeyDiff) XXX_Size() int {
+	return xxx_messageInfo_ConfigFileKeyDiff.Size(m)
+}
+func (m *ConfigFileKeyDiff) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConfigFileKeyDiff.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConfigFileKeyDiff proto.InternalMessageInfo
+
+func (m *ConfigFileKeyDiff) GetKey() *wrappers.StringValue {
+	if m != nil {
+		return m.Key
+	}
+	return nil
+}
+
+func (m *ConfigFileKeyDiff) GetType() *wrappers.StringValue {
+	if m != nil {
+		return m.Type
+	}
+	return nil
+}
+
+func (m *ConfigFileKeyDiff) GetOldValue() *wrappers.StringValue {
+	if m != nil {
+		return m.OldValue
+	}
+	return nil
+}
+
+func (m *ConfigFileKeyDiff) GetNewValue() *wrappers.StringValue {
+	if m != nil {
+		return m.NewValue
+	}
+	return nil
+}
+
+type ConfigFileListener struct {
+	ClientId             *wrappers.StringValue `protobuf:"bytes,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	ClientIp             *wrappers.StringValue `protobuf:"bytes,2,opt,name=client_ip,json=clientIp,proto3" json:"client_ip,omitempty"`
+	Namespace            *wrappers.StringValue `protobuf:"bytes,3,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Group                *wrappers.StringValue `protobuf:"bytes,4,opt,name=group,proto3" json:"group,omitempty"`
+	FileName             *wrappers.StringValue `protobuf:"bytes,5,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	Version              *wrappers.UInt64Value `protobuf:"bytes,6,opt,name=version,proto3" json:"version,omitempty"`
+	LastPollTime         *wrappers.StringValue `protobuf:"bytes,7,opt,name=last_poll_time,json=lastPollTime,proto3" json:"last_poll_time,omitempty"`
+	Server               *wrappers.StringValue `protobuf:"bytes,8,opt,name=server,proto3" json:"server,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *ConfigFileListener) Reset()         { *m = ConfigFileListener{} }
+func (m *ConfigFileListener) String() string { return proto.CompactTextString(m) }
+func (*ConfigFileListener) ProtoMessage()    {}
+func (*ConfigFileListener) Descriptor() ([]byte, []int) {
+	return fileDescriptor_config_file_1b67d87a0ba5be64, []int{10}
+}
+func (m *ConfigFileListener) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConfigFileListener.Unmarshal(m, b)
+}
+func (m *ConfigFileListener) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConfigFileListener.Marshal(b, m, deterministic)
+}
+func (dst *ConfigFileListener) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConfigFileListener.Merge(dst, src)
+}
+func (m *ConfigFileListener) XXX_Size() int {
+	return xxx_messageInfo_ConfigFileListener.Size(m)
+}
+func (m *ConfigFileListener) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConfigFileListener.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConfigFileListener proto.InternalMessageInfo
+
+func (m *ConfigFileListener) GetClientId() *wrappers.StringValue {
+	if m != nil {
+		return m.ClientId
+	}
+	return nil
+}
+
+func (m *ConfigFileListener) GetClientIp() *wrappers.StringValue {
+	if m != nil {
+		return m.ClientIp
+	}
+	return nil
+}
+
+func (m *ConfigFileListener) GetNamespace() *wrappers.StringValue {
+	if m != nil {
+		return m.Namespace
+	}
+	return nil
+}
+
+func (m *ConfigFileListener) GetGroup() *wrappers.StringValue {
+	if m != nil {
+		return m.Group
+	}
+	return nil
+}
+
+func (m *ConfigFileListener) GetFileName() *wrappers.StringValue {
+	if m != nil {
+		return m.FileName
+	}
+	return nil
+}
+
+func (m *ConfigFileListener) GetVersion() *wrappers.UInt64Value {
+	if m != nil {
+		return m.Version
+	}
+	return nil
+}
+
+func (m *ConfigFileListener) GetLastPollTime() *wrappers.StringValue {
+	if m != nil {
+		return m.LastPollTime
+	}
+	return nil
+}
+
+func (m *ConfigFileListener) GetServer() *wrappers.StringValue {
+	if m != nil {
+		return m.Server
+	}
+	return nil
+}
+
+type ConfigFilePublishRequest struct {
+	Id                   *wrappers.StringValue   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Namespace            *wrappers.StringValue   `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Group                *wrappers.StringValue   `protobuf:"bytes,3,opt,name=group,proto3" json:"group,omitempty"`
+	FileName             *wrappers.StringValue   `protobuf:"bytes,4,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	Comment              *wrappers.StringValue   `protobuf:"bytes,5,opt,name=comment,proto3" json:"comment,omitempty"`
+	Status               *wrappers.StringValue   `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
+	ApproveUsers         []*wrappers.StringValue `protobuf:"bytes,7,rep,name=approve_users,json=approveUsers,proto3" json:"approve_users,omitempty"`
+	ApproveGroups        []*wrappers.StringValue `protobuf:"bytes,8,rep,name=approve_groups,json=approveGroups,proto3" json:"approve_groups,omitempty"`
+	PlanTime             *wrappers.StringValue   `protobuf:"bytes,9,opt,name=plan_time,json=planTime,proto3" json:"plan_time,omitempty"`
+	ApproveBy            *wrappers.StringValue   `protobuf:"bytes,10,opt,name=approve_by,json=approveBy,proto3" json:"approve_by,omitempty"`
+	ApproveComment       *wrappers.StringValue   `protobuf:"bytes,11,opt,name=approve_comment,json=approveComment,proto3" json:"approve_comment,omitempty"`
+	ApproveTime          *wrappers.StringValue   `protobuf:"bytes,12,opt,name=approve_time,json=approveTime,proto3" json:"approve_time,omitempty"`
+	PublishTime          *wrappers.StringValue   `protobuf:"bytes,13,opt,name=publish_time,json=publishTime,proto3" json:"publish_time,omitempty"`
+	FailReason           *wrappers.StringValue   `protobuf:"bytes,14,opt,name=fail_reason,json=failReason,proto3" json:"fail_reason,omitempty"`
+	CreateTime           *wrappers.StringValue   `protobuf:"bytes,15,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	CreateBy             *wrappers.StringValue   `protobuf:"bytes,16,opt,name=create_by,json=createBy,proto3" json:"create_by,omitempty"`
+	ModifyTime           *wrappers.StringValue   `protobuf:"bytes,17,opt,name=modify_time,json=modifyTime,proto3" json:"modify_time,omitempty"`
+	ModifyBy             *wrappers.StringValue   `protobuf:"bytes,18,opt,name=modify_by,json=modifyBy,proto3" json:"modify_by,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
+}
+
+func (m *ConfigFilePublishRequest) Reset()         { *m = ConfigFilePublishRequest{} }
+func (m *ConfigFilePublishRequest) String() string { return proto.CompactTextString(m) }
+func (*ConfigFilePublishRequest) ProtoMessage()    {}
+func (*ConfigFilePublishRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_config_file_1b67d87a0ba5be64, []int{11}
+}
+func (m *ConfigFilePublishRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConfigFilePublishRequest.Unmarshal(m, b)
+}
+func (m *ConfigFilePublishRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConfigFilePublishRequest.Marshal(b, m, deterministic)
+}
+func (dst *ConfigFilePublishRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConfigFilePublishRequest.Merge(dst, src)
+}
+func (m *ConfigFilePublishRequest) XXX_Size() int {
+	return xxx_messageInfo_ConfigFilePublishRequest.Size(m)
+}
+func (m *ConfigFilePublishRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConfigFilePublishRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConfigFilePublishRequest proto.InternalMessageInfo
+
+func (m *ConfigFilePublishRequest) GetId() *wrappers.StringValue {
+	if m != nil {
+		return m.Id
+	}
+	return nil
+}
+
+func (m *ConfigFilePublishRequest) GetNamespace() *wrappers.StringValue {
+	if m != nil {
+		return m.Namespace
+	}
+	return nil
+}
+
+func (m *ConfigFilePublishRequest) GetGroup() *wrappers.StringValue {
+	if m != nil {
+		return m.Group
+	}
+	return nil
+}
+
+func (m *ConfigFilePublishRequest) GetFileName() *wrappers.StringValue {
+	if m != nil {
+		return m.FileName
+	}
+	return nil
+}
+
+func (m *ConfigFilePublishRequest) GetComment() *wrappers.StringValue {
+	if m != nil {
+		return m.Comment
+	}
+	return nil
+}
+
+func (m *ConfigFilePublishRequest) GetStatus() *wrappers.StringValue {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
+func (m *ConfigFilePublishRequest) GetApproveUsers() []*wrappers.StringValue {
+	if m != nil {
+		return m.ApproveUsers
+	}
+	return nil
+}
+
+func (m *ConfigFilePublishRequest) GetApproveGroups() []*wrappers.StringValue {
+	if m != nil {
+		return m.ApproveGroups
+	}
+	return nil
+}
+
+func (m *ConfigFilePublishRequest) GetPlanTime() *wrappers.StringValue {
+	if m != nil {
+		return m.PlanTime
+	}
+	return nil
+}
+
+func (m *ConfigFilePublishRequest) GetApproveBy() *wrappers.StringValue {
+	if m != nil {
+		return m.ApproveBy
+	}
+	return nil
+}
+
+func (m *ConfigFilePublishRequest) GetApproveComment() *wrappers.StringValue {
+	if m != nil {
+		return m.ApproveComment
+	}
+	return nil
+}
+
+func (m *ConfigFilePublishRequest) GetApproveTime() *wrappers.StringValue {
+	if m != nil {
+		return m.ApproveTime
+	}
+	return nil
+}
+
+func (m *ConfigFilePublishRequest) GetPublishTime() *wrappers.StringValue {
+	if m != nil {
+		return m.PublishTime
+	}
+	return nil
+}
+
+func (m *ConfigFilePublishRequest) GetFailReason() *wrappers.StringValue {
+	if m != nil {
+		return m.FailReason
+	}
+	return nil
+}
+
+func (m *ConfigFilePublishRequest) GetCreateTime() *wrappers.StringValue {
+	if m != nil {
+		return m.CreateTime
+	}
+	return nil
+}
+
+func (m *ConfigFilePublishRequest) GetCreateBy() *wrappers.StringValue {
+	if m != nil {
+		return m.CreateBy
+	}
+	return nil
+}
+
+func (m *ConfigFilePublishRequest) GetModifyTime() *wrappers.StringValue {
+	if m != nil {
+		return m.ModifyTime
+	}
+	return nil
+}
+
+func (m *ConfigFilePublishRequest) GetModifyBy() *wrappers.StringValue {
+	if m != nil {
+		return m.ModifyBy
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*ConfigFileGroup)(nil), "v1.ConfigFileGroup")
 	proto.RegisterType((*ConfigFile)(nil), "v1.ConfigFile")
@@ -748,54 +1274,101 @@ func init() {
 	proto.RegisterType((*ConfigFileRelease)(nil), "v1.ConfigFileRelease")
 	proto.RegisterType((*ConfigFileReleaseHistory)(nil), "v1.ConfigFileReleaseHistory")
 	proto.RegisterType((*ClientConfigFileInfo)(nil), "v1.ClientConfigFileInfo")
+	proto.RegisterMapType((map[string]string)(nil), "v1.ClientConfigFileInfo.LabelsEntry")
 	proto.RegisterType((*ClientWatchConfigFileRequest)(nil), "v1.ClientWatchConfigFileRequest")
+	proto.RegisterMapType((map[string]string)(nil), "v1.ClientWatchConfigFileRequest.LabelsEntry")
+	proto.RegisterType((*ConfigFileGrayRule)(nil), "v1.ConfigFileGrayRule")
+	proto.RegisterMapType((map[string]string)(nil), "v1.ConfigFileGrayRule.ClientLabelsEntry")
+	proto.RegisterType((*ConfigFileDiff)(nil), "v1.ConfigFileDiff")
+	proto.RegisterType((*ConfigFileKeyDiff)(nil), "v1.ConfigFileKeyDiff")
+	proto.RegisterType((*ConfigFileListener)(nil), "v1.ConfigFileListener")
+	proto.RegisterType((*ConfigFilePublishRequest)(nil), "v1.ConfigFilePublishRequest")
 }
 
 func init() { proto.RegisterFile("config_file.proto", fileDescriptor_config_file_1b67d87a0ba5be64) }
 
 var fileDescriptor_config_file_1b67d87a0ba5be64 = []byte{
-	// 684 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x97, 0x4f, 0x6b, 0xd4, 0x40,
-	0x18, 0xc6, 0xd9, 0x6c, 0x36, 0xbb, 0xfb, 0xa6, 0x7f, 0x6c, 0xf0, 0x30, 0x48, 0x91, 0xb2, 0x20,
-	0xf4, 0x20, 0xe9, 0x1f, 0x6b, 0xa1, 0x8a, 0x08, 0x5b, 0x50, 0x7b, 0xf1, 0xb0, 0x56, 0x05, 0x2f,
-	0x4b, 0x76, 0x77, 0x12, 0x07, 0x93, 0x4c, 0xcc, 0x4c, 0x52, 0xf2, 0x41, 0xfc, 0x60, 0x1e, 0xfc,
-	0x00, 0x5e, 0xfd, 0x10, 0x22, 0x33, 0x93, 0x90, 0xad, 0x5a, 0x98, 0x24, 0x17, 0x51, 0x4f, 0x25,
-	0xcd, 0xf3, 0xcc, 0x3b, 0xfb, 0xce, 0xf3, 0x7b, 0x67, 0x17, 0x76, 0x96, 0x34, 0xf6, 0x49, 0x30,
-	0xf7, 0x49, 0x88, 0xdd, 0x24, 0xa5, 0x9c, 0x3a, 0x46, 0x7e, 0x74, 0xe7, 0x6e, 0x40, 0x69, 0x10,
-	0xe2, 0x03, 0xf9, 0x9f, 0x45, 0xe6, 0x1f, 0x5c, 0xa5, 0x5e, 0x92, 0xe0, 0x94, 0x29, 0xcd, 0xe4,
-	0x93, 0x09, 0xdb, 0xe7, 0xd2, 0xf9, 0x8c, 0x84, 0xf8, 0x79, 0x4a, 0xb3, 0xc4, 0xb9, 0x0f, 0x06,
-	0x59, 0xa1, 0xde, 0x5e, 0x6f, 0xdf, 0x3e, 0xde, 0x75, 0xd5, 0x02, 0x6e, 0xb5, 0x80, 0xfb, 0xfa,
-	0x22, 0xe6, 0xa7, 0x27, 0x6f, 0xbc, 0x30, 0xc3, 0x33, 0x83, 0xac, 0x9c, 0x43, 0x30, 0x63, 0x2f,
-	0xc2, 0xc8, 0xb8, 0x41, 0xff, 0x8a, 0xa7, 0x24, 0x0e, 0x94, 0x5e, 0x2a, 0x9d, 0x47, 0x30, 0x16,
-	0x7f, 0x59, 0xe2, 0x2d, 0x31, 0xea, 0x6b, 0xd8, 0x6a, 0xb9, 0x73, 0x0a, 0xc3, 0x25, 0x8d, 0x22,
-	0x1c, 0x73, 0x64, 0x6a, 0x38, 0x2b, 0xb1, 0xf3, 0x04, 0xec, 0x65, 0x8a, 0x3d, 0x8e, 0xe7, 0x9c,
-	0x44, 0x18, 0x0d, 0x34, 0xbc, 0xa0, 0x0c, 0x97, 0x24, 0xc2, 0xce, 0x19, 0x8c, 0x4b, 0xfb, 0xa2,
-	0x40, 0x96, 0x86, 0x79, 0xa4, 0xe4, 0xd3, 0x42, 0x54, 0x8e, 0xe8, 0x8a, 0xf8, 0x85, 0xaa, 0x3c,
-	0xd4, 0xa9, 0xac, 0x0c, 0x55, 0xe5, 0xd2, 0xbe, 0x28, 0xd0, 0x48, 0xa7, 0xb2, 0x92, 0x4f, 0x0b,
-	0xd1, 0x67, 0x91, 0x86, 0x73, 0x9a, 0xc5, 0x1c, 0x8d, 0x35, 0x8e, 0xb3, 0x96, 0x4f, 0x3e, 0x5b,
-	0x00, 0x75, 0x2e, 0xfe, 0xe8, 0x48, 0x1c, 0xc3, 0x20, 0x10, 0xb9, 0xd5, 0x0a, 0x84, 0x92, 0xaa,
-	0x18, 0xc5, 0x5c, 0xc4, 0x68, 0xa0, 0x17, 0x23, 0x29, 0x76, 0x4e, 0xc0, 0xf2, 0x69, 0x1a, 0x79,
-	0x5c, 0x2b, 0x04, 0xa5, 0x76, 0x3d, 0xb4, 0xc3, 0x26, 0xa1, 0x3d, 0x01, 0x8b, 0x71, 0x8f, 0x67,
-	0x4c, 0xeb, 0xe0, 0x4b, 0xad, 0x73, 0x0f, 0x4c, 0xee, 0x05, 0x0c, 0x8d, 0xf7, 0xfa, 0xfb, 0xf6,
-	0xf1, 0x8e, 0x9b, 0x1f, 0xb9, 0xf5, 0x49, 0x5e, 0x7a, 0xc1, 0x4c, 0xbe, 0xfe, 0x99, 0x08, 0xe8,
-	0x42, 0x84, 0xdd, 0x85, 0x88, 0x8d, 0x2e, 0x44, 0x6c, 0x36, 0x22, 0xe2, 0x29, 0x6c, 0xa4, 0x38,
-	0xc4, 0x1e, 0x2b, 0x3f, 0xf4, 0x96, 0x86, 0xdb, 0x2e, 0x1d, 0xb2, 0xf6, 0x63, 0x80, 0x6a, 0x81,
-	0x45, 0x81, 0xb6, 0x75, 0x82, 0x5a, 0xea, 0xa7, 0xc5, 0x84, 0xc1, 0xe6, 0xb5, 0x83, 0x70, 0x5c,
-	0xe8, 0x7f, 0xc0, 0xc5, 0x8d, 0x58, 0xad, 0x2f, 0x23, 0x84, 0x22, 0xe9, 0xb9, 0x78, 0xd2, 0x02,
-	0x4b, 0x49, 0x27, 0xdf, 0x06, 0xb0, 0x53, 0x57, 0x9d, 0xa9, 0xcd, 0xfc, 0x75, 0x3c, 0x9f, 0xa9,
-	0x51, 0x37, 0x97, 0xdb, 0xd4, 0x21, 0x7a, 0x24, 0xe4, 0x2f, 0xc5, 0x56, 0xd7, 0x46, 0x81, 0xd5,
-	0x64, 0x14, 0xb4, 0x85, 0xda, 0x85, 0x7e, 0xb4, 0x7a, 0xa8, 0x45, 0xb4, 0x10, 0x8a, 0x3a, 0x39,
-	0x4e, 0x19, 0xa1, 0xb1, 0xd6, 0x0c, 0xaf, 0xc4, 0xff, 0x22, 0xdf, 0x93, 0xef, 0x16, 0xa0, 0x5f,
-	0xc2, 0xfe, 0x82, 0x30, 0x4e, 0xd3, 0xe2, 0x7f, 0xe6, 0xbb, 0x67, 0xbe, 0xbe, 0xfe, 0x86, 0xed,
-	0xae, 0xbf, 0x51, 0x0b, 0x52, 0xc6, 0xba, 0xa4, 0x1c, 0x82, 0xc9, 0x8b, 0x44, 0x2f, 0xea, 0x52,
-	0xb9, 0x76, 0xc1, 0xda, 0x2d, 0x2e, 0xd8, 0x8d, 0x46, 0x17, 0xec, 0x66, 0x17, 0x00, 0xb7, 0xba,
-	0x00, 0xb8, 0xdd, 0x05, 0xc0, 0x5b, 0x8d, 0x00, 0xfc, 0x6a, 0xc0, 0xed, 0xf3, 0x90, 0xe0, 0x98,
-	0xd7, 0x1d, 0xb9, 0x88, 0x7d, 0x7a, 0x1d, 0x8e, 0x5e, 0x4b, 0x38, 0x8c, 0x96, 0x70, 0xf4, 0xdb,
-	0xc2, 0x61, 0x36, 0xbc, 0x10, 0xaa, 0x41, 0x3d, 0x68, 0x32, 0xa8, 0xcb, 0x98, 0x5b, 0x9a, 0x31,
-	0x9f, 0x7c, 0xe9, 0xc1, 0xae, 0xea, 0xf1, 0x5b, 0x8f, 0x2f, 0xdf, 0xaf, 0xcf, 0xbb, 0x8f, 0x19,
-	0x66, 0x5c, 0x26, 0x47, 0xbe, 0x9f, 0x93, 0x44, 0xab, 0xd7, 0x23, 0x25, 0xbf, 0x48, 0xc4, 0x17,
-	0x24, 0x86, 0xd3, 0x9c, 0x2c, 0xcb, 0xce, 0xe9, 0x74, 0xdc, 0x2e, 0x1d, 0xb2, 0x79, 0x67, 0x60,
-	0x5f, 0x89, 0x5d, 0xc9, 0xdf, 0xa1, 0x0c, 0xf5, 0x25, 0x22, 0x48, 0x22, 0xf2, 0x9b, 0x58, 0xcc,
-	0x40, 0x8a, 0xc5, 0x23, 0x9b, 0x9a, 0xef, 0x8c, 0xfc, 0x68, 0x61, 0xc9, 0x1a, 0x0f, 0x7e, 0x04,
-	0x00, 0x00, 0xff, 0xff, 0xfe, 0x60, 0x65, 0xe6, 0xd0, 0x0e, 0x00, 0x00,
+	// 1303 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x59, 0x4d, 0x6f, 0x1b, 0x45,
+	0x18, 0x96, 0xd7, 0x1f, 0xf1, 0xbe, 0x6b, 0xc7, 0xf1, 0xaa, 0xa0, 0x55, 0x54, 0xa1, 0xca, 0x02,
+	0xa9, 0x87, 0xca, 0x6d, 0xd2, 0x50, 0x35, 0x85, 0x2a, 0xc2, 0x6e, 0x09, 0x11, 0x05, 0x55, 0x6e,
+	0x0b, 0x12, 0x17, 0x6b, 0xed, 0x9d, 0x75, 0x86, 0x8c, 0x77, 0x96, 0x99, 0xb1, 0xa3, 0x3d, 0x72,
+	0xe6, 0x27, 0xf0, 0xb7, 0x10, 0x12, 0x48, 0x1c, 0x38, 0x71, 0xe0, 0x4f, 0xa0, 0xf9, 0xd8, 0xd8,
+	0x6e, 0x52, 0x32, 0x6b, 0x73, 0xe0, 0xeb, 0x94, 0xb5, 0xfd, 0x3c, 0x33, 0xfb, 0xbe, 0xf3, 0x3e,
+	0xef, 0xc7, 0x04, 0xda, 0x63, 0x9a, 0xc4, 0x78, 0x32, 0x8c, 0x31, 0x41, 0xdd, 0x94, 0x51, 0x41,
+	0x7d, 0x67, 0xbe, 0xb7, 0xfb, 0xce, 0x84, 0xd2, 0x09, 0x41, 0x77, 0xd5, 0x37, 0xa3, 0x59, 0x7c,
+	0xf7, 0x9c, 0x85, 0x69, 0x8a, 0x18, 0xd7, 0x98, 0xce, 0x0f, 0x15, 0x68, 0xf5, 0x15, 0xf3, 0x63,
+	0x4c, 0xd0, 0x31, 0xa3, 0xb3, 0xd4, 0xbf, 0x03, 0x0e, 0x8e, 0x82, 0xd2, 0xad, 0xd2, 0x6d, 0x6f,
+	0xff, 0x66, 0x57, 0x2f, 0xd0, 0xcd, 0x17, 0xe8, 0xbe, 0x3a, 0x49, 0xc4, 0x83, 0x83, 0x2f, 0x42,
+	0x32, 0x43, 0x03, 0x07, 0x47, 0xfe, 0x3d, 0xa8, 0x24, 0xe1, 0x14, 0x05, 0xce, 0x1b, 0xf0, 0x2f,
+	0x04, 0xc3, 0xc9, 0x44, 0xe3, 0x15, 0xd2, 0x7f, 0x04, 0xae, 0xfc, 0xcb, 0xd3, 0x70, 0x8c, 0x82,
+	0xb2, 0x05, 0x6d, 0x01, 0xf7, 0x1f, 0xc0, 0xd6, 0x98, 0x4e, 0xa7, 0x28, 0x11, 0x41, 0xc5, 0x82,
+	0x99, 0x83, 0xfd, 0xc7, 0xe0, 0x8d, 0x19, 0x0a, 0x05, 0x1a, 0x0a, 0x3c, 0x45, 0x41, 0xd5, 0x82,
+	0x0b, 0x9a, 0xf0, 0x12, 0x4f, 0x91, 0x7f, 0x08, 0xae, 0xa1, 0x8f, 0xb2, 0xa0, 0x66, 0x41, 0xae,
+	0x6b, 0x78, 0x2f, 0x93, 0x3b, 0x4f, 0x69, 0x84, 0xe3, 0x4c, 0xef, 0xbc, 0x65, 0xb3, 0xb3, 0x26,
+	0xe4, 0x3b, 0x1b, 0xfa, 0x28, 0x0b, 0xea, 0x36, 0x3b, 0x6b, 0x78, 0x2f, 0x93, 0x7e, 0x96, 0xd1,
+	0xd0, 0xa7, 0xb3, 0x44, 0x04, 0xae, 0xc5, 0x71, 0x2e, 0xe0, 0xf2, 0xad, 0xbf, 0xe6, 0x34, 0x19,
+	0xf2, 0xf1, 0x29, 0x9a, 0x86, 0x01, 0xd8, 0xbc, 0xb5, 0x24, 0xbc, 0x50, 0xf8, 0xce, 0x77, 0x75,
+	0x80, 0x45, 0x58, 0xfd, 0xad, 0x23, 0x6a, 0x1f, 0xaa, 0x13, 0x19, 0xf6, 0x56, 0xf1, 0xa4, 0xa1,
+	0x3a, 0x0a, 0x13, 0x21, 0xa3, 0xb0, 0x6a, 0x17, 0x85, 0x0a, 0xec, 0x1f, 0x40, 0x2d, 0xa6, 0x6c,
+	0x1a, 0x0a, 0xab, 0x18, 0x32, 0xd8, 0xe5, 0x98, 0xdf, 0x2a, 0x12, 0xf3, 0x07, 0x50, 0xe3, 0x22,
+	0x14, 0x33, 0x6e, 0x15, 0x37, 0x06, 0xeb, 0xbf, 0x07, 0x15, 0x11, 0x4e, 0x78, 0xe0, 0xde, 0x2a,
+	0xdf, 0xf6, 0xf6, 0xdb, 0xdd, 0xf9, 0x5e, 0x77, 0x71, 0x92, 0x2f, 0xc3, 0xc9, 0x40, 0xfd, 0xfc,
+	0xba, 0xa0, 0x60, 0x13, 0x41, 0x79, 0x9b, 0x08, 0xaa, 0xb1, 0x89, 0xa0, 0x9a, 0x85, 0x04, 0x75,
+	0x04, 0x0d, 0x86, 0x08, 0x0a, 0xb9, 0x31, 0x7a, 0xdb, 0x82, 0xed, 0x19, 0x86, 0xda, 0xfb, 0x03,
+	0x80, 0x7c, 0x81, 0x51, 0x16, 0xb4, 0x6c, 0x02, 0xd5, 0xe0, 0x7b, 0x99, 0xff, 0x10, 0x5c, 0x94,
+	0x8c, 0x59, 0x96, 0x0a, 0x14, 0x05, 0x3b, 0x8a, 0xbb, 0x7b, 0x89, 0xdb, 0xa3, 0x94, 0x18, 0xe6,
+	0x05, 0x58, 0x9a, 0x3c, 0x92, 0x7b, 0x4a, 0x79, 0x07, 0x6d, 0x1b, 0x93, 0x25, 0x5c, 0x29, 0xb7,
+	0x0f, 0xdb, 0xf3, 0x90, 0xe1, 0x70, 0x44, 0x10, 0xd7, 0x7c, 0xdf, 0x82, 0xdf, 0xbc, 0xe0, 0xc8,
+	0x45, 0x3a, 0x1c, 0x9a, 0x2b, 0x21, 0xe4, 0x77, 0xa1, 0x7c, 0x86, 0xb2, 0xa0, 0x64, 0xb1, 0x94,
+	0x04, 0x4a, 0x8d, 0xce, 0xe5, 0x27, 0xab, 0x94, 0xa0, 0xa1, 0x9d, 0x9f, 0x6a, 0xd0, 0x5e, 0xec,
+	0x3a, 0xd0, 0x6e, 0xfc, 0xd7, 0x65, 0xa2, 0x43, 0x9d, 0xe3, 0x87, 0xea, 0x35, 0x6d, 0x72, 0x51,
+	0x5d, 0xc2, 0x3f, 0x97, 0xaf, 0xba, 0x94, 0xc4, 0x6a, 0x45, 0x92, 0xd8, 0xba, 0xe9, 0xa8, 0x0b,
+	0xe5, 0x69, 0xf4, 0xbe, 0x55, 0x2e, 0x92, 0x40, 0xb9, 0xcf, 0x1c, 0x31, 0x8e, 0x69, 0x62, 0x55,
+	0xbc, 0x72, 0xf0, 0x7f, 0x32, 0x33, 0xdd, 0x07, 0x77, 0xc2, 0xc2, 0x6c, 0xc8, 0x66, 0x24, 0x4f,
+	0x4b, 0x6f, 0xaf, 0x66, 0xee, 0x63, 0x16, 0x66, 0x83, 0x19, 0x41, 0x83, 0xfa, 0xc4, 0x3c, 0xad,
+	0x26, 0x94, 0x56, 0x81, 0x84, 0xd2, 0xf9, 0xb6, 0x0e, 0xc1, 0x25, 0x6d, 0x7d, 0x82, 0xb9, 0xa0,
+	0x2c, 0xfb, 0x5f, 0x62, 0x9b, 0x4b, 0x6c, 0xd1, 0x27, 0x6c, 0xad, 0xd7, 0x27, 0xd4, 0xd7, 0x10,
+	0xa6, 0x6b, 0x2b, 0xcc, 0x7b, 0x50, 0x11, 0x59, 0x6a, 0xa7, 0x2c, 0x85, 0x5c, 0xea, 0x44, 0xbc,
+	0x35, 0x3a, 0x91, 0x46, 0xa1, 0x4e, 0xa4, 0xb9, 0x89, 0xde, 0xb7, 0x37, 0xd1, 0x7b, 0x6b, 0x13,
+	0xbd, 0xef, 0x14, 0xd2, 0xfb, 0x8a, 0x74, 0xdb, 0x45, 0x7a, 0x81, 0x63, 0xd8, 0x11, 0x68, 0x9a,
+	0x12, 0x69, 0x70, 0x1e, 0x9b, 0x36, 0x25, 0xbd, 0x95, 0xb3, 0xfa, 0x9a, 0xd4, 0xf9, 0xb9, 0x0c,
+	0x37, 0xfa, 0x04, 0xa3, 0x44, 0x2c, 0x0e, 0xe5, 0x24, 0x89, 0xe9, 0xaa, 0x3e, 0x4b, 0x6b, 0xea,
+	0xd3, 0x59, 0x53, 0x9f, 0xe5, 0x75, 0xf5, 0x59, 0x29, 0x58, 0x02, 0xf3, 0xd2, 0x54, 0x2d, 0x52,
+	0x9a, 0x8c, 0xd2, 0x6a, 0xb6, 0x4a, 0xfb, 0x10, 0x6a, 0x24, 0x1c, 0x21, 0xc2, 0x83, 0x2d, 0xa5,
+	0x81, 0x77, 0x95, 0x06, 0xae, 0x70, 0x7a, 0xf7, 0x99, 0x82, 0x3d, 0x4d, 0x04, 0xcb, 0x06, 0x86,
+	0xb3, 0x7b, 0x08, 0xde, 0xd2, 0xd7, 0xfe, 0xce, 0xa2, 0xe9, 0x72, 0x75, 0x5b, 0x75, 0x63, 0xb9,
+	0xad, 0x72, 0x4d, 0xe3, 0xf4, 0xc8, 0x79, 0x58, 0xea, 0xfc, 0xe8, 0xc0, 0x4d, 0xbd, 0xcf, 0x97,
+	0xa1, 0x18, 0x9f, 0x2e, 0xe7, 0xfa, 0x6f, 0x66, 0x88, 0x0b, 0xa5, 0x1a, 0xf5, 0xfb, 0x10, 0xa7,
+	0x56, 0x87, 0x5c, 0xd7, 0xf0, 0x93, 0x54, 0x76, 0xd1, 0x1c, 0xb1, 0x39, 0x1e, 0x9b, 0x23, 0xb3,
+	0x39, 0x6a, 0xcf, 0x30, 0xd4, 0xa9, 0x1d, 0x82, 0x77, 0x2e, 0xdf, 0x4a, 0xf5, 0xa3, 0x3c, 0x28,
+	0x2b, 0xd7, 0x04, 0x6f, 0x72, 0xcd, 0x00, 0x14, 0x58, 0x7e, 0xe4, 0xfe, 0x93, 0x0b, 0x87, 0x56,
+	0x14, 0xeb, 0xce, 0x82, 0x75, 0xb5, 0xa1, 0x7f, 0xb5, 0x63, 0x7f, 0x2d, 0x81, 0x7f, 0xb9, 0x28,
+	0xcb, 0xc1, 0xe0, 0xc2, 0x9d, 0x3c, 0x28, 0xdd, 0x2a, 0x5f, 0xeb, 0x11, 0x37, 0xf7, 0x27, 0xf7,
+	0x3f, 0x83, 0xa6, 0x21, 0x1b, 0xdb, 0x1c, 0xc5, 0xbf, 0x7d, 0x75, 0x03, 0x60, 0xcc, 0x5d, 0xb6,
+	0xab, 0x31, 0x5e, 0xfa, 0x6a, 0xf7, 0x08, 0xda, 0x97, 0x20, 0x85, 0x6c, 0xfc, 0xde, 0x81, 0xed,
+	0xc5, 0xbe, 0x4f, 0x70, 0x1c, 0xcb, 0x92, 0x11, 0x33, 0x3a, 0xb5, 0x8a, 0x14, 0x85, 0x94, 0x5d,
+	0x84, 0xa0, 0x56, 0xb1, 0xe1, 0x08, 0xba, 0x54, 0x30, 0xcb, 0x05, 0x0a, 0xe6, 0x11, 0x34, 0x66,
+	0x09, 0x8e, 0x31, 0x8a, 0x86, 0x11, 0x8e, 0x63, 0xab, 0x1c, 0xe0, 0x19, 0x86, 0x32, 0x6b, 0x1f,
+	0xdc, 0x33, 0x94, 0x29, 0x32, 0x0f, 0xaa, 0xca, 0xeb, 0x6f, 0xad, 0x7a, 0xfd, 0x53, 0x94, 0x49,
+	0xe4, 0xa0, 0x7e, 0xa6, 0x1f, 0x78, 0xe7, 0xf7, 0x12, 0xb4, 0x2f, 0xfd, 0x5e, 0x78, 0x22, 0xca,
+	0x6b, 0xb0, 0x63, 0x5d, 0x83, 0x0f, 0xc1, 0xa5, 0x24, 0x1a, 0xea, 0x33, 0xb3, 0x4a, 0x93, 0x94,
+	0x44, 0xea, 0x49, 0x52, 0x13, 0x74, 0x6e, 0xa8, 0x36, 0x4e, 0xaa, 0x27, 0xe8, 0x5c, 0x3d, 0x75,
+	0x7e, 0x2b, 0x2f, 0xc7, 0xfb, 0x33, 0xcc, 0x05, 0x4a, 0x10, 0x5b, 0x4e, 0x1f, 0x51, 0xa1, 0xf4,
+	0x11, 0xad, 0x66, 0x1e, 0xa7, 0x50, 0xe6, 0xf9, 0x67, 0x75, 0x8e, 0x79, 0x85, 0xa9, 0x15, 0xa9,
+	0x30, 0x3d, 0xd8, 0x26, 0x21, 0x17, 0xc3, 0x94, 0x12, 0x62, 0x7f, 0xe1, 0xd8, 0x90, 0x9c, 0xe7,
+	0x94, 0x10, 0xd5, 0x97, 0xc8, 0x6e, 0x0d, 0xb1, 0x39, 0x62, 0x96, 0xf7, 0x46, 0x0a, 0xdb, 0xf9,
+	0x65, 0x65, 0x26, 0x78, 0x3e, 0x1b, 0x11, 0xcc, 0x4f, 0xf3, 0x72, 0xf1, 0xe7, 0x33, 0xc1, 0x8a,
+	0x9a, 0x71, 0xb4, 0x7a, 0x4e, 0xce, 0x9a, 0xe7, 0x54, 0x5e, 0xf3, 0x9c, 0x2a, 0xc5, 0x3b, 0x08,
+	0xdd, 0x73, 0x57, 0xd7, 0xbb, 0x9b, 0xab, 0x15, 0xe8, 0x88, 0x3f, 0x82, 0x66, 0x98, 0xa6, 0x8c,
+	0xce, 0xd1, 0x70, 0xc6, 0x11, 0xcb, 0xdb, 0x82, 0x6b, 0x0e, 0xd7, 0x50, 0x5e, 0x49, 0x86, 0xbc,
+	0xd0, 0xc9, 0x97, 0x50, 0xc6, 0xcb, 0xcb, 0xc1, 0xeb, 0xd7, 0xc8, 0xb7, 0x55, 0xff, 0x20, 0xe0,
+	0xd2, 0x61, 0x29, 0x09, 0x13, 0x1d, 0x60, 0x36, 0x73, 0x43, 0x5d, 0xc2, 0xf3, 0x2b, 0xb0, 0x7c,
+	0xff, 0x51, 0x66, 0x35, 0x42, 0xb8, 0x06, 0xdf, 0xcb, 0xfc, 0xa7, 0xd0, 0xca, 0xc9, 0xb9, 0xd7,
+	0x6d, 0x06, 0x8a, 0xdc, 0xe2, 0xbe, 0x71, 0xfe, 0x11, 0xe4, 0x3e, 0xb1, 0x1f, 0xd4, 0x3d, 0xc3,
+	0x50, 0x46, 0x1c, 0x41, 0x23, 0xd5, 0x01, 0x6e, 0x3f, 0x73, 0x78, 0x86, 0xa1, 0x16, 0x78, 0x0c,
+	0x5e, 0x1c, 0x62, 0x32, 0x64, 0x28, 0xe4, 0x34, 0xb1, 0x1a, 0x3b, 0x40, 0x12, 0x06, 0x0a, 0xff,
+	0xfa, 0xc8, 0xd3, 0xda, 0x64, 0xe4, 0xd9, 0xd9, 0x64, 0xe4, 0x69, 0x6f, 0x32, 0xf2, 0xf8, 0x45,
+	0x46, 0x9e, 0x5e, 0xe5, 0x2b, 0x67, 0xbe, 0x37, 0xaa, 0x29, 0xd4, 0xfd, 0x3f, 0x06, 0x00, 0x7c,
+	0xe3, 0xa8, 0xe6, 0xef, 0x1a, 0x00, 0x00,
 }
@@ -72,6 +72,8 @@ type ConfigResponse struct {
 	ConfigFile               *ConfigFile               `protobuf:"bytes,4,opt,name=configFile,proto3" json:"configFile,omitempty"`
 	ConfigFileRelease        *ConfigFileRelease        `protobuf:"bytes,5,opt,name=configFileRelease,proto3" json:"configFileRelease,omitempty"`
 	ConfigFileReleaseHistory *ConfigFileReleaseHistory `protobuf:"bytes,6,opt,name=configFileReleaseHistory,proto3" json:"configFileReleaseHistory,omitempty"`
+	ConfigFileDiff           *ConfigFileDiff           `protobuf:"bytes,7,opt,name=configFileDiff,proto3" json:"configFileDiff,omitempty"`
+	ConfigFilePublishRequest *ConfigFilePublishRequest `protobuf:"bytes,8,opt,name=configFilePublishRequest,proto3" json:"configFilePublishRequest,omitempty"`
 	XXX_NoUnkeyedLiteral     struct{}                  `json:"-"`
 	XXX_unrecognized         []byte                    `json:"-"`
 	XXX_sizecache            int32                     `json:"-"`
@@ -143,6 +145,20 @@ func (m *ConfigResponse) GetConfigFileReleaseHistory() *ConfigFileReleaseHistory
 	return nil
 }
 
+func (m *ConfigResponse) GetConfigFileDiff() *ConfigFileDiff {
+	if m != nil {
+		return m.ConfigFileDiff
+	}
+	return nil
+}
+
+func (m *ConfigResponse) GetConfigFilePublishRequest() *ConfigFilePublishRequest {
+	if m != nil {
+		return m.ConfigFilePublishRequest
+	}
+	return nil
+}
+
 type ConfigBatchWriteResponse struct {
 	Code                 *wrappers.UInt32Value `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
 	Info                 *wrappers.StringValue `protobuf:"bytes,2,opt,name=info,proto3" json:"info,omitempty"`
@@ -213,6 +229,8 @@ type ConfigBatchQueryResponse struct {
 	ConfigFiles                []*ConfigFile               `protobuf:"bytes,5,rep,name=configFiles,proto3" json:"configFiles,omitempty"`
 	ConfigFileReleases         []*ConfigFileRelease        `protobuf:"bytes,6,rep,name=configFileReleases,proto3" json:"configFileReleases,omitempty"`
 	ConfigFileReleaseHistories []*ConfigFileReleaseHistory `protobuf:"bytes,7,rep,name=configFileReleaseHistories,proto3" json:"configFileReleaseHistories,omitempty"`
+	ConfigFileListeners        []*ConfigFileListener       `protobuf:"bytes,8,rep,name=configFileListeners,proto3" json:"configFileListeners,omitempty"`
+	ConfigFilePublishRequests  []*ConfigFilePublishRequest `protobuf:"bytes,9,rep,name=configFilePublishRequests,proto3" json:"configFilePublishRequests,omitempty"`
 	XXX_NoUnkeyedLiteral       struct{}                    `json:"-"`
 	XXX_unrecognized           []byte                      `json:"-"`
 	XXX_sizecache              int32                       `json:"-"`
@@ -291,6 +309,20 @@ func (m *ConfigBatchQueryResponse) GetConfigFileReleaseHistories() []*ConfigFile
 	return nil
 }
 
+func (m *ConfigBatchQueryResponse) GetConfigFileListeners() []*ConfigFileListener {
+	if m != nil {
+		return m.ConfigFileListeners
+	}
+	return nil
+}
+
+func (m *ConfigBatchQueryResponse) GetConfigFilePublishRequests() []*ConfigFilePublishRequest {
+	if m != nil {
+		return m.ConfigFilePublishRequests
+	}
+	return nil
+}
+
 type ConfigClientResponse struct {
 	Code                 *wrappers.UInt32Value `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
 	Info                 *wrappers.StringValue `protobuf:"bytes,2,opt,name=info,proto3" json:"info,omitempty"`
@@ -358,32 +390,37 @@ func init() {
 }
 
 var fileDescriptor_config_file_response_477e879754493a54 = []byte{
-	// 430 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x94, 0x5d, 0xab, 0xda, 0x30,
-	0x1c, 0xc6, 0xa9, 0xad, 0x8e, 0x45, 0x70, 0x33, 0xdb, 0x20, 0xc8, 0x18, 0xd2, 0x2b, 0xaf, 0xaa,
-	0xd6, 0x9b, 0xdd, 0x8c, 0x81, 0x65, 0x2f, 0x5e, 0x2e, 0xb2, 0x17, 0xc6, 0x40, 0xaa, 0x4b, 0xbb,
-	0x40, 0xd6, 0x94, 0x24, 0x75, 0xb8, 0xcb, 0xf3, 0x99, 0xce, 0xa7, 0x38, 0x9f, 0xe3, 0x7c, 0x90,
-	0x83, 0x49, 0x4b, 0xdf, 0xd4, 0x4b, 0x39, 0x97, 0xfe, 0xf3, 0xfc, 0x9e, 0xe4, 0x31, 0x4f, 0x03,
-	0x46, 0x3b, 0x9e, 0x44, 0x34, 0xde, 0x44, 0x94, 0x91, 0x8d, 0x20, 0x32, 0xe5, 0x89, 0x24, 0x5e,
-	0x2a, 0xb8, 0xe2, 0xb0, 0xb3, 0x9f, 0x8f, 0xde, 0xc4, 0x9c, 0xc7, 0x8c, 0x4c, 0xf5, 0x64, 0x9b,
-	0x45, 0xd3, 0x7f, 0x22, 0x4c, 0x53, 0x22, 0xa4, 0xd1, 0x8c, 0x86, 0x15, 0xde, 0x8c, 0xdc, 0xff,
-	0xe0, 0x65, 0xa0, 0x87, 0x6b, 0xfa, 0x37, 0x65, 0x04, 0xe7, 0xa6, 0x70, 0x06, 0x9c, 0x1d, 0xff,
-	0x4d, 0x90, 0x35, 0xb6, 0x26, 0x7d, 0xff, 0xb5, 0x67, 0x9c, 0xbd, 0xc2, 0xd9, 0xfb, 0xba, 0x4a,
-	0xd4, 0xc2, 0xff, 0x16, 0xb2, 0x8c, 0x60, 0xad, 0x3c, 0x12, 0x34, 0x89, 0x38, 0xea, 0x9c, 0x21,
-	0xd6, 0x4a, 0xd0, 0x24, 0xce, 0x89, 0xa3, 0xd2, 0xbd, 0xb1, 0xc1, 0xc0, 0x6c, 0x7e, 0xcd, 0x6d,
-	0xe1, 0x3b, 0xf0, 0xcc, 0xfc, 0x0f, 0x1f, 0x29, 0x23, 0x9f, 0x04, 0xcf, 0x52, 0x64, 0x6b, 0xf8,
-	0x85, 0xb7, 0x9f, 0x7b, 0x41, 0x7d, 0x09, 0x37, 0xb5, 0xd0, 0x03, 0xa0, 0x1c, 0x21, 0x47, 0x93,
-	0x83, 0x3a, 0x89, 0x2b, 0x0a, 0x18, 0x80, 0x61, 0xf9, 0x0b, 0x13, 0x46, 0x42, 0x49, 0x50, 0x57,
-	0x63, 0xaf, 0x1a, 0x98, 0x59, 0xc4, 0x6d, 0x3d, 0xfc, 0x01, 0x50, 0x6b, 0xf8, 0x99, 0x4a, 0xc5,
-	0xc5, 0x01, 0xf5, 0xf2, 0xe4, 0xa7, 0xbc, 0x72, 0x0d, 0x3e, 0x4b, 0xbb, 0xf7, 0x16, 0x40, 0x06,
-	0x5b, 0x86, 0x6a, 0xf7, 0xe7, 0xbb, 0xa0, 0xea, 0xaa, 0x2d, 0x80, 0x3e, 0xe8, 0x2a, 0xae, 0x42,
-	0x96, 0x5f, 0xc2, 0xe5, 0x4d, 0x8c, 0x14, 0xce, 0xc0, 0xd3, 0xa2, 0xfe, 0x12, 0x39, 0x63, 0x7b,
-	0xd2, 0xf7, 0x61, 0x99, 0xbf, 0x38, 0x3e, 0x2e, 0x45, 0xee, 0x9d, 0x5d, 0x8b, 0xf9, 0x25, 0x23,
-	0xe2, 0xf0, 0xe8, 0x63, 0xbe, 0x07, 0xcf, 0x1b, 0xed, 0x2b, 0xd2, 0x9e, 0xac, 0x6a, 0x4b, 0x0c,
-	0x67, 0xa0, 0x5f, 0xce, 0x24, 0xea, 0x6a, 0xb6, 0x59, 0xd6, 0xaa, 0x04, 0x7e, 0x00, 0xb0, 0x55,
-	0x15, 0x89, 0x7a, 0x1a, 0x3c, 0x53, 0xd7, 0x13, 0x00, 0xfc, 0x55, 0xbc, 0x55, 0xad, 0xc6, 0x51,
-	0x22, 0xd1, 0x13, 0x6d, 0x77, 0xb9, 0xb1, 0x17, 0x78, 0xf7, 0xd6, 0x2a, 0x5e, 0xad, 0x80, 0x51,
-	0x92, 0xa8, 0xab, 0x5e, 0xe4, 0xdb, 0xda, 0xf7, 0x6f, 0x6e, 0x13, 0xe9, 0x28, 0xfa, 0x2c, 0x65,
-	0xa0, 0x55, 0x12, 0xf1, 0xea, 0x4b, 0xb0, 0x74, 0x7e, 0x76, 0xf6, 0xf3, 0x6d, 0x4f, 0x7b, 0x2f,
-	0x1e, 0x02, 0x00, 0x00, 0xff, 0xff, 0xd9, 0x8f, 0x39, 0x21, 0xcd, 0x05, 0x00, 0x00,
+	// 509 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x94, 0x5d, 0x6b, 0x13, 0x4f,
+	0x14, 0xc6, 0x49, 0x76, 0x93, 0xb6, 0xa7, 0x90, 0xff, 0xbf, 0x53, 0x95, 0x31, 0x88, 0x2c, 0x7b,
+	0xe5, 0xd5, 0x36, 0x49, 0x6f, 0x44, 0x10, 0xa1, 0xf1, 0xa5, 0x05, 0x2f, 0x74, 0x8a, 0x2f, 0x14,
+	0xa1, 0x6c, 0xe2, 0xd9, 0x74, 0x60, 0xdd, 0x59, 0x67, 0x66, 0x23, 0xf5, 0x93, 0xf8, 0x41, 0xfc,
+	0x48, 0x7e, 0x00, 0x3f, 0x82, 0x64, 0x26, 0xcb, 0xbe, 0x7b, 0x19, 0xbc, 0xdc, 0x73, 0x9e, 0xdf,
+	0x33, 0x73, 0x96, 0xf3, 0x0c, 0x8c, 0x97, 0x22, 0x89, 0xf8, 0xea, 0x3a, 0xe2, 0x31, 0x5e, 0x4b,
+	0x54, 0xa9, 0x48, 0x14, 0x06, 0xa9, 0x14, 0x5a, 0x90, 0xfe, 0x7a, 0x3a, 0x7e, 0xb8, 0x12, 0x62,
+	0x15, 0xe3, 0x89, 0xa9, 0x2c, 0xb2, 0xe8, 0xe4, 0x9b, 0x0c, 0xd3, 0x14, 0xa5, 0xb2, 0x9a, 0xf1,
+	0x51, 0x89, 0xb7, 0x25, 0xff, 0x3b, 0xdc, 0x99, 0x9b, 0xe2, 0x25, 0xff, 0x92, 0xc6, 0xc8, 0xb6,
+	0xa6, 0x64, 0x02, 0xee, 0x52, 0x7c, 0x46, 0xda, 0xf3, 0x7a, 0x8f, 0x0e, 0x67, 0x0f, 0x02, 0xeb,
+	0x1c, 0xe4, 0xce, 0xc1, 0xbb, 0x8b, 0x44, 0x9f, 0xce, 0xde, 0x87, 0x71, 0x86, 0xcc, 0x28, 0x37,
+	0x04, 0x4f, 0x22, 0x41, 0xfb, 0x1d, 0xc4, 0xa5, 0x96, 0x3c, 0x59, 0x6d, 0x89, 0x8d, 0xd2, 0xff,
+	0xe1, 0xc2, 0xc8, 0x1e, 0xbe, 0xcb, 0x63, 0xc9, 0x53, 0xf8, 0xcf, 0xfe, 0x87, 0x97, 0x3c, 0xc6,
+	0x57, 0x52, 0x64, 0x29, 0x75, 0x0c, 0x7c, 0x1c, 0xac, 0xa7, 0xc1, 0xbc, 0xda, 0x62, 0x75, 0x2d,
+	0x09, 0x00, 0x8a, 0x12, 0x75, 0x0d, 0x39, 0xaa, 0x92, 0xac, 0xa4, 0x20, 0x73, 0x38, 0x2a, 0xbe,
+	0x18, 0xc6, 0x18, 0x2a, 0xa4, 0x03, 0x83, 0xdd, 0xad, 0x61, 0xb6, 0xc9, 0x9a, 0x7a, 0xf2, 0x11,
+	0x68, 0xa3, 0x78, 0xce, 0x95, 0x16, 0xf2, 0x96, 0x0e, 0xb7, 0x93, 0xb7, 0x79, 0x6d, 0x35, 0xac,
+	0x93, 0x26, 0x4f, 0x60, 0x54, 0xf4, 0x9e, 0xf3, 0x28, 0xa2, 0x7b, 0xc6, 0x8f, 0x54, 0xfd, 0x36,
+	0x1d, 0x56, 0x53, 0x56, 0x6f, 0xf5, 0x26, 0x5b, 0xc4, 0x5c, 0xdd, 0x30, 0xfc, 0x9a, 0xa1, 0xd2,
+	0x74, 0xbf, 0xed, 0x56, 0x55, 0x0d, 0xeb, 0xa4, 0xfd, 0x5f, 0x3d, 0xa0, 0x16, 0x3b, 0x0b, 0xf5,
+	0xf2, 0xe6, 0x83, 0xe4, 0x7a, 0xa7, 0xbb, 0x49, 0x66, 0x30, 0xd0, 0x42, 0x87, 0x31, 0x75, 0x3a,
+	0x90, 0xf2, 0x21, 0x56, 0x4a, 0x26, 0x70, 0x90, 0x87, 0x52, 0x51, 0xd7, 0x73, 0xaa, 0x7f, 0x31,
+	0xbf, 0x3e, 0x2b, 0x44, 0xfe, 0x6f, 0xb7, 0x32, 0xe6, 0xdb, 0x0c, 0xe5, 0xed, 0x3f, 0x3f, 0xe6,
+	0x33, 0xf8, 0xbf, 0x96, 0x89, 0x7c, 0xda, 0xd6, 0x00, 0x35, 0xc4, 0x64, 0x02, 0x87, 0x45, 0x4d,
+	0xd1, 0x81, 0xe7, 0xb4, 0x44, 0xa8, 0x2c, 0x21, 0x2f, 0x80, 0x34, 0x16, 0x58, 0xd1, 0xa1, 0xe7,
+	0x74, 0x87, 0xa8, 0x05, 0x20, 0x9f, 0x60, 0xdc, 0xa8, 0xda, 0x1c, 0x70, 0x54, 0x74, 0xcf, 0x73,
+	0x9a, 0x1b, 0x5b, 0xcb, 0xd1, 0x5f, 0x78, 0x72, 0x0e, 0xc7, 0x45, 0xf7, 0x35, 0x57, 0x1a, 0x13,
+	0x94, 0x8a, 0xee, 0x1b, 0xdb, 0x7b, 0x55, 0xdb, 0xbc, 0xcd, 0xda, 0x10, 0x72, 0x05, 0xf7, 0xbb,
+	0x92, 0xa1, 0xe8, 0x41, 0xdb, 0x35, 0x6b, 0xc1, 0xea, 0xc6, 0xfd, 0x9f, 0xbd, 0xfc, 0xc5, 0x9f,
+	0xc7, 0x1c, 0x13, 0xbd, 0xd3, 0x75, 0x7b, 0x5c, 0x79, 0x3b, 0xed, 0xce, 0x51, 0x33, 0x89, 0xb9,
+	0x4b, 0x31, 0xcf, 0x45, 0x12, 0x89, 0xf2, 0x2b, 0x7a, 0xe6, 0x5e, 0xf5, 0xd7, 0xd3, 0xc5, 0xd0,
+	0x78, 0x9f, 0xfe, 0x19, 0x00, 0xb6, 0x4e, 0x75, 0xb6, 0x09, 0x07, 0x00, 0x00,
 }
@@ -69,6 +69,26 @@ func NewConfigFileReleaseHistoryBatchQueryResponse(code uint32, total uint32,
 	}
 }
 
+func NewConfigFileListenerBatchQueryResponse(code uint32, total uint32,
+	configFileListeners []*ConfigFileListener) *ConfigBatchQueryResponse {
+	return &ConfigBatchQueryResponse{
+		Code:                &wrappers.UInt32Value{Value: code},
+		Info:                &wrappers.StringValue{Value: code2info[code]},
+		Total:               &wrappers.UInt32Value{Value: total},
+		ConfigFileListeners: configFileListeners,
+	}
+}
+
+func NewConfigFilePublishRequestBatchQueryResponse(code uint32, total uint32,
+	publishRequests []*ConfigFilePublishRequest) *ConfigBatchQueryResponse {
+	return &ConfigBatchQueryResponse{
+		Code:                      &wrappers.UInt32Value{Value: code},
+		Info:                      &wrappers.StringValue{Value: code2info[code]},
+		Total:                     &wrappers.UInt32Value{Value: total},
+		ConfigFilePublishRequests: publishRequests,
+	}
+}
+
 func NewConfigFileResponse(code uint32, configFile *ConfigFile) *ConfigResponse {
 	return &ConfigResponse{
 		Code:       &wrappers.UInt32Value{Value: code},
@@ -115,3 +135,44 @@ func NewConfigFileReleaseHistoryResponse(code uint32, configFileReleaseHistory *
 		ConfigFileReleaseHistory: configFileReleaseHistory,
 	}
 }
+
+func NewConfigFilePublishRequestResponse(code uint32, publishRequest *ConfigFilePublishRequest) *ConfigResponse {
+	return &ConfigResponse{
+		Code:                     &wrappers.UInt32Value{Value: code},
+		Info:                     &wrappers.StringValue{Value: code2info[code]},
+		ConfigFilePublishRequest: publishRequest,
+	}
+}
+
+func NewConfigBatchWriteResponse(code uint32) *ConfigBatchWriteResponse {
+	return &ConfigBatchWriteResponse{
+		Code:  &wrappers.UInt32Value{Value: code},
+		Info:  &wrappers.StringValue{Value: code2info[code]},
+		Total: &wrappers.UInt32Value{Value: 0},
+	}
+}
+
+func NewConfigBatchWriteResponseWithMessage(code uint32, message string) *ConfigBatchWriteResponse {
+	resp := NewConfigBatchWriteResponse(code)
+	resp.Info.Value += ":" + message
+	return resp
+}
+
+// Collect 添加单个配置的写入结果，存在失败的结果时批量结果为失败
+func (b *ConfigBatchWriteResponse) Collect(response *ConfigResponse) {
+	if CalcCode(response) != 200 && CalcCode(b) == 200 {
+		b.Code.Value = ExecuteException
+		b.Info.Value = code2info[ExecuteException]
+	}
+
+	b.Total.Value++
+	b.Responses = append(b.Responses, response)
+}
+
+func NewConfigFileDiffResponse(code uint32, configFileDiff *ConfigFileDiff) *ConfigResponse {
+	return &ConfigResponse{
+		Code:           &wrappers.UInt32Value{Value: code},
+		Info:           &wrappers.StringValue{Value: code2info[code]},
+		ConfigFileDiff: configFileDiff,
+	}
+}
@@ -39,6 +39,8 @@ type PolarisConfigGRPCClient interface {
 	GetConfigFile(ctx context.Context, in *ClientConfigFileInfo, opts ...grpc.CallOption) (*ConfigClientResponse, error)
 	// 订阅配置变更
 	WatchConfigFiles(ctx context.Context, in *ClientWatchConfigFileRequest, opts ...grpc.CallOption) (*ConfigClientResponse, error)
+	// 通过双向流订阅配置变更，服务端主动推送
+	StreamWatchConfigFiles(ctx context.Context, opts ...grpc.CallOption) (PolarisConfigGRPC_StreamWatchConfigFilesClient, error)
 }
 
 type polarisConfigGRPCClient struct {
@@ -67,12 +69,45 @@ func (c *polarisConfigGRPCClient) WatchConfigFiles(ctx context.Context, in *Clie
 	return out, nil
 }
 
+func (c *polarisConfigGRPCClient) StreamWatchConfigFiles(ctx context.Context, opts ...grpc.CallOption) (PolarisConfigGRPC_StreamWatchConfigFilesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_PolarisConfigGRPC_serviceDesc.Streams[0], "/v1.PolarisConfigGRPC/StreamWatchConfigFiles", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &polarisConfigGRPCStreamWatchConfigFilesClient{stream}
+	return x, nil
+}
+
+type PolarisConfigGRPC_StreamWatchConfigFilesClient interface {
+	Send(*ClientWatchConfigFileRequest) error
+	Recv() (*ConfigClientResponse, error)
+	grpc.ClientStream
+}
+
+type polarisConfigGRPCStreamWatchConfigFilesClient struct {
+	grpc.ClientStream
+}
+
+func (x *polarisConfigGRPCStreamWatchConfigFilesClient) Send(m *ClientWatchConfigFileRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *polarisConfigGRPCStreamWatchConfigFilesClient) Recv() (*ConfigClientResponse, error) {
+	m := new(ConfigClientResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // PolarisConfigGRPCServer is the server API for PolarisConfigGRPC service.
 type PolarisConfigGRPCServer interface {
 	// 拉取配置
 	GetConfigFile(context.Context, *ClientConfigFileInfo) (*ConfigClientResponse, error)
 	// 订阅配置变更
 	WatchConfigFiles(context.Context, *ClientWatchConfigFileRequest) (*ConfigClientResponse, error)
+	// 通过双向流订阅配置变更，服务端主动推送
+	StreamWatchConfigFiles(PolarisConfigGRPC_StreamWatchConfigFilesServer) error
 }
 
 func RegisterPolarisConfigGRPCServer(s *grpc.Server, srv PolarisConfigGRPCServer) {
@@ -115,6 +150,32 @@ func _PolarisConfigGRPC_WatchConfigFiles_Handler(srv interface{}, ctx context.Co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PolarisConfigGRPC_StreamWatchConfigFiles_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(PolarisConfigGRPCServer).StreamWatchConfigFiles(&polarisConfigGRPCStreamWatchConfigFilesServer{stream})
+}
+
+type PolarisConfigGRPC_StreamWatchConfigFilesServer interface {
+	Send(*ConfigClientResponse) error
+	Recv() (*ClientWatchConfigFileRequest, error)
+	grpc.ServerStream
+}
+
+type polarisConfigGRPCStreamWatchConfigFilesServer struct {
+	grpc.ServerStream
+}
+
+func (x *polarisConfigGRPCStreamWatchConfigFilesServer) Send(m *ConfigClientResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *polarisConfigGRPCStreamWatchConfigFilesServer) Recv() (*ClientWatchConfigFileRequest, error) {
+	m := new(ClientWatchConfigFileRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 var _PolarisConfigGRPC_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "v1.PolarisConfigGRPC",
 	HandlerType: (*PolarisConfigGRPCServer)(nil),
@@ -128,7 +189,14 @@ var _PolarisConfigGRPC_serviceDesc = grpc.ServiceDesc{
 			Handler:    _PolarisConfigGRPC_WatchConfigFiles_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamWatchConfigFiles",
+			Handler:       _PolarisConfigGRPC_StreamWatchConfigFiles_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
 	Metadata: "grpc_config_api.proto",
 }
 
@@ -137,16 +205,17 @@ func init() {
 }
 
 var fileDescriptor_grpc_config_api_57c0c78d97511b1f = []byte{
-	// 174 bytes of a gzipped FileDescriptorProto
+	// 187 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x12, 0x4d, 0x2f, 0x2a, 0x48,
 	0x8e, 0x4f, 0xce, 0xcf, 0x4b, 0xcb, 0x4c, 0x8f, 0x4f, 0x2c, 0xc8, 0xd4, 0x2b, 0x28, 0xca, 0x2f,
 	0xc9, 0x17, 0x62, 0x2a, 0x33, 0x94, 0x12, 0x84, 0x8a, 0xa6, 0x65, 0xe6, 0xa4, 0x42, 0x84, 0xa5,
-	0xa4, 0x90, 0x84, 0xe2, 0x8b, 0x52, 0x8b, 0x0b, 0xf2, 0xf3, 0x8a, 0xa1, 0x72, 0x46, 0x6b, 0x18,
-	0xb9, 0x04, 0x03, 0xf2, 0x73, 0x12, 0x8b, 0x32, 0x8b, 0x9d, 0xc1, 0xaa, 0xdc, 0x83, 0x02, 0x9c,
-	0x85, 0x5c, 0xb9, 0x78, 0xdd, 0x53, 0x4b, 0x20, 0x02, 0x6e, 0x99, 0x39, 0xa9, 0x42, 0x12, 0x7a,
-	0x65, 0x86, 0x7a, 0xce, 0x39, 0x99, 0xa9, 0x79, 0x48, 0xa2, 0x9e, 0x79, 0x69, 0xf9, 0x52, 0x10,
-	0x19, 0xb0, 0x18, 0x44, 0x3e, 0x08, 0x6a, 0x81, 0x12, 0x83, 0x50, 0x00, 0x97, 0x40, 0x78, 0x62,
-	0x49, 0x72, 0x06, 0x42, 0x4b, 0xb1, 0x90, 0x02, 0xc2, 0x24, 0x34, 0xb9, 0xa0, 0xd4, 0xc2, 0xd2,
-	0xd4, 0xe2, 0x12, 0x7c, 0x26, 0x3a, 0xb1, 0x44, 0x31, 0x95, 0x19, 0x26, 0xb1, 0x81, 0xdd, 0x6e,
-	0x0c, 0x08, 0x00, 0x00, 0xff, 0xff, 0xe6, 0xb8, 0xa9, 0xf9, 0x07, 0x01, 0x00, 0x00,
+	0xa4, 0x90, 0x84, 0xe2, 0x8b, 0x52, 0x8b, 0x0b, 0xf2, 0xf3, 0x8a, 0xa1, 0x72, 0x46, 0x1d, 0x4c,
+	0x5c, 0x82, 0x01, 0xf9, 0x39, 0x89, 0x45, 0x99, 0xc5, 0xce, 0x60, 0x55, 0xee, 0x41, 0x01, 0xce,
+	0x42, 0xae, 0x5c, 0xbc, 0xee, 0xa9, 0x25, 0x10, 0x01, 0xb7, 0xcc, 0x9c, 0x54, 0x21, 0x09, 0xbd,
+	0x32, 0x43, 0x3d, 0xe7, 0x9c, 0xcc, 0xd4, 0x3c, 0x24, 0x51, 0xcf, 0xbc, 0xb4, 0x7c, 0x29, 0x88,
+	0x0c, 0x58, 0x0c, 0x22, 0x1f, 0x04, 0xb5, 0x40, 0x89, 0x41, 0x28, 0x80, 0x4b, 0x20, 0x3c, 0xb1,
+	0x24, 0x39, 0x03, 0xa1, 0xa5, 0x58, 0x48, 0x01, 0x61, 0x12, 0x9a, 0x5c, 0x50, 0x6a, 0x61, 0x69,
+	0x6a, 0x71, 0x09, 0x5e, 0x13, 0xa3, 0xb8, 0xc4, 0x82, 0x4b, 0x8a, 0x52, 0x13, 0x73, 0xa9, 0x6b,
+	0xae, 0x06, 0xa3, 0x01, 0xa3, 0x13, 0x4b, 0x14, 0x53, 0x99, 0x61, 0x12, 0x1b, 0x38, 0x5c, 0x8c,
+	0x01, 0x03, 0x00, 0xea, 0xd4, 0x7f, 0x97, 0x63, 0x01, 0x00, 0x00,
 }
@@ -22,17 +22,23 @@ const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 type MatchString_MatchStringType int32
 
 const (
-	MatchString_EXACT MatchString_MatchStringType = 0
-	MatchString_REGEX MatchString_MatchStringType = 1
+	MatchString_EXACT      MatchString_MatchStringType = 0
+	MatchString_REGEX      MatchString_MatchStringType = 1
+	MatchString_NOT_EQUALS MatchString_MatchStringType = 2
+	MatchString_IN         MatchString_MatchStringType = 3
 )
 
 var MatchString_MatchStringType_name = map[int32]string{
 	0: "EXACT",
 	1: "REGEX",
+	2: "NOT_EQUALS",
+	3: "IN",
 }
 var MatchString_MatchStringType_value = map[string]int32{
-	"EXACT": 0,
-	"REGEX": 1,
+	"EXACT":      0,
+	"REGEX":      1,
+	"NOT_EQUALS": 2,
+	"IN":         3,
 }
 
 func (x MatchString_MatchStringType) String() string {
@@ -226,28 +232,29 @@ func init() {
 func init() { proto.RegisterFile("model.proto", fileDescriptor_model_7699d3da81c352b2) }
 
 var fileDescriptor_model_7699d3da81c352b2 = []byte{
-	// 363 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x91, 0xc1, 0x6f, 0xa2, 0x40,
-	0x14, 0xc6, 0x77, 0x40, 0x5d, 0x79, 0xb8, 0xbb, 0x64, 0xb2, 0x07, 0xd6, 0xec, 0x6e, 0x0d, 0x97,
-	0x7a, 0xc2, 0x8a, 0x4d, 0x6a, 0xbc, 0xd1, 0x66, 0xd2, 0x34, 0xd1, 0xb4, 0x19, 0x89, 0xf1, 0xd6,
-	0x20, 0x9d, 0x22, 0xa9, 0x32, 0x04, 0x10, 0x43, 0xaf, 0x3d, 0xf7, 0xff, 0xe8, 0x9f, 0xd9, 0x30,
-	0x48, 0x43, 0x3c, 0x79, 0xfb, 0x86, 0xf7, 0x7b, 0xef, 0xfb, 0xf2, 0x01, 0xea, 0x96, 0x3f, 0xb1,
-	0x8d, 0x19, 0xc5, 0x3c, 0xe5, 0x58, 0xca, 0x86, 0xdd, 0xff, 0x3e, 0xe7, 0xfe, 0x86, 0x0d, 0xc4,
-	0x97, 0xd5, 0xee, 0x79, 0xb0, 0x8f, 0xdd, 0x28, 0x62, 0x71, 0x52, 0x32, 0xc6, 0x07, 0x82, 0xf6,
-	0x94, 0x7b, 0x6e, 0x1a, 0xf0, 0x10, 0x5f, 0x42, 0x2b, 0x66, 0x7e, 0xc0, 0x43, 0x1d, 0xf5, 0x50,
-	0x5f, 0xb5, 0xfe, 0x9a, 0xe5, 0xb6, 0x59, 0x6d, 0x9b, 0xf3, 0x34, 0x0e, 0x42, 0x7f, 0xe1, 0x6e,
-	0x76, 0x8c, 0x1e, 0x58, 0x7c, 0x01, 0x8d, 0x57, 0x1e, 0x32, 0x5d, 0x3a, 0x61, 0x47, 0x90, 0x85,
-	0x8f, 0xe7, 0x6e, 0xa3, 0x5d, 0xa2, 0xcb, 0xa7, 0xf8, 0x94, 0xac, 0xf1, 0x2e, 0x81, 0x3a, 0x73,
-	0x53, 0x6f, 0x5d, 0x0e, 0xf1, 0x08, 0x1a, 0x69, 0x1e, 0x31, 0x91, 0xf5, 0xa7, 0x75, 0x66, 0x66,
-	0x43, 0xb3, 0x36, 0xae, 0x6b, 0x27, 0x8f, 0x18, 0x15, 0x30, 0xb6, 0xa0, 0x99, 0x15, 0x57, 0x4f,
-	0x4a, 0x5b, 0xa2, 0x78, 0x0c, 0x20, 0xc4, 0xa3, 0xb0, 0x93, 0x85, 0xdd, 0x9f, 0x63, 0x3b, 0xb1,
-	0x21, 0x8c, 0x94, 0xac, 0x92, 0xc6, 0x39, 0xfc, 0x3a, 0x8a, 0x81, 0x15, 0x68, 0x92, 0xa5, 0x7d,
-	0xe3, 0x68, 0xdf, 0x0a, 0x49, 0xc9, 0x2d, 0x59, 0x6a, 0xc8, 0xb0, 0x40, 0xf9, 0x3a, 0x80, 0xdb,
-	0xd0, 0x70, 0xc8, 0xb2, 0x20, 0x7e, 0x80, 0xf2, 0x60, 0x53, 0x7b, 0x46, 0x1c, 0x42, 0x35, 0x84,
-	0x3b, 0xd0, 0x5e, 0xd8, 0xf4, 0xce, 0xbe, 0x9e, 0x12, 0x4d, 0x32, 0xde, 0x10, 0x74, 0xee, 0xa3,
-	0xe2, 0xc7, 0xcd, 0xf7, 0x41, 0xea, 0xad, 0xf1, 0x15, 0x7c, 0xe7, 0xe2, 0x9d, 0xe8, 0xa8, 0x27,
-	0xf7, 0x55, 0xeb, 0x5f, 0x11, 0xb2, 0x8e, 0x1c, 0x1e, 0x09, 0x09, 0xd3, 0x38, 0xa7, 0x15, 0xdd,
-	0x9d, 0x54, 0x87, 0xca, 0x01, 0xd6, 0x40, 0x7e, 0x61, 0xb9, 0x28, 0x56, 0xa1, 0x85, 0xc4, 0xbf,
-	0xeb, 0xb5, 0x29, 0x87, 0x62, 0x26, 0xd2, 0x18, 0xad, 0x5a, 0xa2, 0xb9, 0xd1, 0x67, 0x00, 0x00,
-	0x00, 0xff, 0xff, 0x01, 0x2d, 0x76, 0x22, 0x7a, 0x02, 0x00, 0x00,
+	// 379 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x91, 0x4f, 0xaf, 0x9a, 0x40,
+	0x14, 0xc5, 0x3b, 0xa0, 0x56, 0x2e, 0xd6, 0x92, 0x49, 0x17, 0xd4, 0xf4, 0x8f, 0x61, 0xe5, 0x0a,
+	0x2b, 0x36, 0xa9, 0x71, 0xd3, 0xd0, 0x66, 0xd2, 0x98, 0xf8, 0xa7, 0x1d, 0xa9, 0x71, 0x67, 0x90,
+	0x4e, 0x91, 0x3c, 0x65, 0x08, 0x20, 0x86, 0xb7, 0x7d, 0x9f, 0xe5, 0x25, 0xef, 0x63, 0xbe, 0x30,
+	0xe8, 0x0b, 0x71, 0xe5, 0xee, 0x0c, 0xf7, 0x77, 0xee, 0xb9, 0x39, 0x80, 0x7a, 0xe0, 0xff, 0xd8,
+	0xde, 0x8c, 0x62, 0x9e, 0x72, 0x2c, 0x65, 0x83, 0xce, 0x27, 0x9f, 0x73, 0x7f, 0xcf, 0xfa, 0xe2,
+	0xcb, 0xf6, 0xf8, 0xbf, 0x7f, 0x8a, 0xdd, 0x28, 0x62, 0x71, 0x52, 0x32, 0xc6, 0x13, 0x82, 0xe6,
+	0x94, 0x7b, 0x6e, 0x1a, 0xf0, 0x10, 0x7f, 0x85, 0x46, 0xcc, 0xfc, 0x80, 0x87, 0x3a, 0xea, 0xa2,
+	0x9e, 0x6a, 0x7d, 0x30, 0x4b, 0xb7, 0x79, 0x71, 0x9b, 0xcb, 0x34, 0x0e, 0x42, 0x7f, 0xe5, 0xee,
+	0x8f, 0x8c, 0x9e, 0x59, 0xfc, 0x05, 0x6a, 0xf7, 0x3c, 0x64, 0xba, 0x74, 0x83, 0x47, 0x90, 0x45,
+	0x8e, 0xe7, 0x1e, 0xa2, 0x63, 0xa2, 0xcb, 0xb7, 0xe4, 0x94, 0xac, 0xf1, 0x28, 0x81, 0x3a, 0x73,
+	0x53, 0x6f, 0x57, 0x0e, 0xf1, 0x10, 0x6a, 0x69, 0x1e, 0x31, 0x71, 0x6b, 0xdb, 0xfa, 0x6c, 0x66,
+	0x03, 0xb3, 0x32, 0xae, 0x6a, 0x27, 0x8f, 0x18, 0x15, 0x30, 0xb6, 0xa0, 0x9e, 0x15, 0x5b, 0x6f,
+	0xba, 0xb6, 0x44, 0xf1, 0x08, 0x40, 0x88, 0x8d, 0x88, 0x93, 0x45, 0xdc, 0xfb, 0xeb, 0x38, 0xe1,
+	0x10, 0x41, 0x4a, 0x76, 0x91, 0xc6, 0x77, 0x78, 0x7b, 0x75, 0x06, 0x56, 0xa0, 0x4e, 0xd6, 0xf6,
+	0x4f, 0x47, 0x7b, 0x55, 0x48, 0x4a, 0x7e, 0x91, 0xb5, 0x86, 0x70, 0x1b, 0x60, 0xbe, 0x70, 0x36,
+	0xe4, 0xcf, 0x5f, 0x7b, 0xba, 0xd4, 0x24, 0xdc, 0x00, 0x69, 0x32, 0xd7, 0x64, 0xc3, 0x02, 0xe5,
+	0x65, 0x31, 0x6e, 0x42, 0xcd, 0x21, 0xeb, 0xc2, 0xf9, 0x06, 0x94, 0xdf, 0x36, 0xb5, 0x67, 0xc4,
+	0x21, 0x54, 0x43, 0xb8, 0x05, 0xcd, 0x95, 0x4d, 0x27, 0xf6, 0x8f, 0x29, 0xd1, 0x24, 0xe3, 0x01,
+	0x41, 0x6b, 0x11, 0x15, 0x3f, 0x74, 0x79, 0x0a, 0x52, 0x6f, 0x87, 0xbf, 0xc1, 0x6b, 0x2e, 0xde,
+	0x89, 0x8e, 0xba, 0x72, 0x4f, 0xb5, 0x3e, 0x16, 0xc7, 0x57, 0x91, 0xf3, 0x23, 0x21, 0x61, 0x1a,
+	0xe7, 0xf4, 0x42, 0x77, 0xc6, 0xd0, 0xaa, 0x0e, 0xb0, 0x06, 0xf2, 0x1d, 0xcb, 0x45, 0xe1, 0x0a,
+	0x2d, 0x24, 0x7e, 0x57, 0xad, 0x53, 0x39, 0x17, 0x36, 0x96, 0x46, 0x68, 0xdb, 0x10, 0x8d, 0x0e,
+	0x9f, 0x07, 0x00, 0x16, 0x03, 0xe9, 0xbd, 0x92, 0x02, 0x00, 0x00,
 }
@@ -59,6 +59,7 @@ func (DiscoverRequest_DiscoverRequestType) EnumDescriptor() ([]byte, []int) {
 type DiscoverRequest struct {
 	Type                 DiscoverRequest_DiscoverRequestType `protobuf:"varint,1,opt,name=type,proto3,enum=v1.DiscoverRequest_DiscoverRequestType" json:"type,omitempty"`
 	Service              *Service                            `protobuf:"bytes,2,opt,name=service,proto3" json:"service,omitempty"`
+	Delta                bool                                `protobuf:"varint,5,opt,name=delta,proto3" json:"delta,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}                            `json:"-"`
 	XXX_unrecognized     []byte                              `json:"-"`
 	XXX_sizecache        int32                               `json:"-"`
@@ -102,6 +103,13 @@ func (m *DiscoverRequest) GetService() *Service {
 	return nil
 }
 
+func (m *DiscoverRequest) GetDelta() bool {
+	if m != nil {
+		return m.Delta
+	}
+	return false
+}
+
 func init() {
 	proto.RegisterType((*DiscoverRequest)(nil), "v1.DiscoverRequest")
 	proto.RegisterEnum("v1.DiscoverRequest_DiscoverRequestType", DiscoverRequest_DiscoverRequestType_name, DiscoverRequest_DiscoverRequestType_value)
@@ -110,21 +118,22 @@ func init() {
 func init() { proto.RegisterFile("request.proto", fileDescriptor_request_a05c6df9c1beb380) }
 
 var fileDescriptor_request_a05c6df9c1beb380 = []byte{
-	// 242 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x90, 0xbf, 0x4b, 0xc3, 0x40,
-	0x14, 0xc7, 0xbd, 0x34, 0x6d, 0xc2, 0x8b, 0x6d, 0x1f, 0xd7, 0xa5, 0x38, 0x95, 0x80, 0xd8, 0x29,
-	0xd0, 0x3a, 0x3a, 0xc5, 0xf3, 0x90, 0xb3, 0xf5, 0x0a, 0xef, 0x2e, 0x3a, 0x16, 0x2c, 0x37, 0x74,
-	0x4a, 0x4c, 0x62, 0xa0, 0xa3, 0xf8, 0x8f, 0x4b, 0x1a, 0x5d, 0x42, 0xc7, 0xef, 0xaf, 0xcf, 0x83,
-	0x07, 0xe3, 0xd2, 0x7d, 0x7e, 0xb9, 0xaa, 0x4e, 0x8a, 0x32, 0xaf, 0x73, 0xee, 0x35, 0xab, 0x9b,
-	0x71, 0xe5, 0xca, 0xe6, 0x78, 0x70, 0x9d, 0x15, 0x7f, 0x7b, 0x30, 0x7d, 0x3a, 0x56, 0x87, 0xbc,
-	0x71, 0x25, 0x75, 0x65, 0xfe, 0x00, 0x7e, 0x7d, 0x2a, 0xdc, 0x9c, 0x2d, 0xd8, 0x72, 0xb2, 0xbe,
-	0x4b, 0x9a, 0x55, 0xd2, 0xab, 0xf4, 0xb5, 0x3d, 0x15, 0x8e, 0xce, 0x23, 0x7e, 0x0b, 0xc1, 0xdf,
-	0x85, 0xb9, 0xb7, 0x60, 0xcb, 0x68, 0x1d, 0xb5, 0x7b, 0xd3, 0x59, 0xf4, 0x9f, 0xc5, 0x3f, 0x0c,
-	0x66, 0x17, 0x20, 0x3c, 0x82, 0x20, 0xd3, 0x1b, 0xbd, 0x7b, 0xd7, 0x78, 0xc5, 0xaf, 0x21, 0x54,
-	0xda, 0xd8, 0x54, 0x0b, 0x89, 0xac, 0x8d, 0xc4, 0x36, 0x33, 0x56, 0x12, 0x7a, 0xad, 0xa0, 0x5d,
-	0x66, 0x95, 0x7e, 0xc6, 0x01, 0x9f, 0x00, 0x50, 0x6a, 0xe5, 0x7e, 0xab, 0x5e, 0x95, 0x45, 0x9f,
-	0xcf, 0x60, 0x2a, 0x14, 0x89, 0x4c, 0xd9, 0xfd, 0x23, 0xc9, 0x74, 0x23, 0x09, 0x87, 0x2d, 0xcc,
-	0x48, 0x7a, 0x53, 0x42, 0x1a, 0x1c, 0xc5, 0x7e, 0x18, 0x60, 0xf4, 0xe2, 0x87, 0x03, 0x1c, 0x7e,
-	0x8c, 0xce, 0xaf, 0xb8, 0xff, 0x0d, 0x00, 0x00, 0xff, 0xff, 0x54, 0x58, 0xfb, 0xdf, 0x2e, 0x01,
-	0x00, 0x00,
+	// 257 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x90, 0x4d, 0x4b, 0xf3, 0x40,
+	0x10, 0xc7, 0x9f, 0x4d, 0x93, 0x26, 0x4c, 0x9e, 0xb6, 0xcb, 0xd6, 0x43, 0xf0, 0x14, 0x02, 0x62,
+	0x4e, 0x81, 0xd6, 0xa3, 0xa7, 0xb8, 0x2e, 0xb2, 0xb6, 0x6e, 0x61, 0x76, 0xa3, 0xc7, 0xa2, 0x75,
+	0x0f, 0x05, 0x21, 0x31, 0x89, 0x81, 0x9e, 0xfd, 0x10, 0x7e, 0x5d, 0x49, 0xa3, 0x97, 0xe2, 0xf1,
+	0xf7, 0x7f, 0x1b, 0x18, 0x98, 0xd4, 0xf6, 0xfd, 0xc3, 0x36, 0x6d, 0x56, 0xd5, 0x65, 0x5b, 0x32,
+	0xa7, 0x5b, 0x9c, 0x4f, 0x1a, 0x5b, 0x77, 0xfb, 0x9d, 0x1d, 0xa4, 0xe4, 0xcb, 0x81, 0xd9, 0xed,
+	0xbe, 0xd9, 0x95, 0x9d, 0xad, 0x71, 0x08, 0xb3, 0x6b, 0x70, 0xdb, 0x43, 0x65, 0x23, 0x12, 0x93,
+	0x74, 0xba, 0xbc, 0xcc, 0xba, 0x45, 0x76, 0x12, 0x39, 0x65, 0x73, 0xa8, 0x2c, 0x1e, 0x4b, 0xec,
+	0x02, 0xfc, 0x9f, 0x0b, 0x91, 0x13, 0x93, 0x34, 0x5c, 0x86, 0x7d, 0x5f, 0x0f, 0x12, 0xfe, 0x7a,
+	0xec, 0x0c, 0xbc, 0x57, 0xfb, 0xd6, 0x3e, 0x47, 0x5e, 0x4c, 0xd2, 0x00, 0x07, 0x48, 0x3e, 0x09,
+	0xcc, 0xff, 0x98, 0x66, 0x21, 0xf8, 0x85, 0x5a, 0xa9, 0xcd, 0x93, 0xa2, 0xff, 0xd8, 0x7f, 0x08,
+	0xa4, 0xd2, 0x26, 0x57, 0x5c, 0x50, 0xd2, 0x5b, 0x7c, 0x5d, 0x68, 0x23, 0x90, 0x3a, 0x3d, 0xe0,
+	0xa6, 0x30, 0x52, 0xdd, 0xd1, 0x11, 0x9b, 0x02, 0x60, 0x6e, 0xc4, 0x76, 0x2d, 0x1f, 0xa4, 0xa1,
+	0x2e, 0x9b, 0xc3, 0x8c, 0x4b, 0xe4, 0x85, 0x34, 0xdb, 0x1b, 0x14, 0xf9, 0x4a, 0x20, 0xf5, 0xfa,
+	0x31, 0x2d, 0xf0, 0x51, 0x72, 0xa1, 0xe9, 0x38, 0x71, 0x03, 0x9f, 0x86, 0xf7, 0x6e, 0x30, 0xa2,
+	0xde, 0xcb, 0xf8, 0xf8, 0xa0, 0xab, 0xef, 0x01, 0x00, 0x7d, 0xc4, 0x5d, 0xdc, 0x44, 0x01, 0x00,
+	0x00,
 }
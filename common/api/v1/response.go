@@ -165,6 +165,15 @@ func NewRoutingResponse(code uint32, routing *Routing) *Response {
 	}
 }
 
+/**
+ * @brief 创建带详细信息的路由配置回复信息
+ */
+func NewRoutingResponseWithMsg(code uint32, routing *Routing, msg string) *Response {
+	response := NewRoutingResponse(code, routing)
+	response.Info.Value += ": " + msg
+	return response
+}
+
 /**
  * @brief 创建回复带限流规则信息
  */
@@ -531,6 +531,8 @@ type DiscoverResponse struct {
 	RateLimit            *RateLimit                            `protobuf:"bytes,7,opt,name=rateLimit,proto3" json:"rateLimit,omitempty"`
 	CircuitBreaker       *CircuitBreaker                       `protobuf:"bytes,8,opt,name=circuitBreaker,proto3" json:"circuitBreaker,omitempty"`
 	Services             []*Service                            `protobuf:"bytes,9,rep,name=services,proto3" json:"services,omitempty"`
+	Delta                *wrappers.BoolValue                   `protobuf:"bytes,14,opt,name=delta,proto3" json:"delta,omitempty"`
+	RemovedInstances     []*Instance                           `protobuf:"bytes,15,rep,name=removedInstances,proto3" json:"removedInstances,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}                              `json:"-"`
 	XXX_unrecognized     []byte                                `json:"-"`
 	XXX_sizecache        int32                                 `json:"-"`
@@ -623,6 +625,20 @@ func (m *DiscoverResponse) GetServices() []*Service {
 	return nil
 }
 
+func (m *DiscoverResponse) GetDelta() *wrappers.BoolValue {
+	if m != nil {
+		return m.Delta
+	}
+	return nil
+}
+
+func (m *DiscoverResponse) GetRemovedInstances() []*Instance {
+	if m != nil {
+		return m.RemovedInstances
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*SimpleResponse)(nil), "v1.SimpleResponse")
 	proto.RegisterType((*Response)(nil), "v1.Response")
@@ -635,73 +651,76 @@ func init() {
 func init() { proto.RegisterFile("response.proto", fileDescriptor_response_c55a4a583271767a) }
 
 var fileDescriptor_response_c55a4a583271767a = []byte{
-	// 1075 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x55, 0xdf, 0x52, 0xe3, 0xb6,
-	0x17, 0xfe, 0xb1, 0x38, 0x89, 0x73, 0x02, 0x89, 0x57, 0x81, 0xfd, 0xa9, 0x74, 0x67, 0x67, 0x27,
-	0xd3, 0x6e, 0x69, 0x76, 0x9a, 0x2d, 0xb0, 0x33, 0xed, 0x74, 0x66, 0x2f, 0x20, 0x18, 0x1a, 0xfe,
-	0x84, 0x56, 0x4e, 0xa0, 0x77, 0x8c, 0x31, 0x22, 0x68, 0xea, 0xd8, 0x1e, 0xc9, 0x66, 0x87, 0x3e,
-	0x41, 0x6f, 0xf7, 0x85, 0xfa, 0x18, 0x7d, 0x9e, 0x8e, 0xe4, 0x3f, 0x72, 0x02, 0x4d, 0xf7, 0x8a,
-	0x1b, 0x88, 0xbe, 0xef, 0x3b, 0xb2, 0xce, 0xd1, 0xd1, 0x77, 0xa0, 0xc9, 0xa9, 0x88, 0xc2, 0x40,
-	0xd0, 0x5e, 0xc4, 0xc3, 0x38, 0x44, 0xcf, 0xee, 0xb6, 0x36, 0x5e, 0x4d, 0xc2, 0x70, 0xe2, 0xd3,
-	0x77, 0x0a, 0xb9, 0x4a, 0x6e, 0xde, 0x7d, 0xe4, 0x6e, 0x14, 0x51, 0x2e, 0x52, 0xcd, 0xc6, 0xaa,
-	0xa0, 0xfc, 0x8e, 0x79, 0x34, 0x5f, 0xf2, 0x30, 0x89, 0x59, 0x30, 0xc9, 0x96, 0x2b, 0x9e, 0xcf,
-	0x68, 0x10, 0x67, 0xab, 0x16, 0x77, 0x63, 0xea, 0xb3, 0x29, 0xcb, 0x81, 0x35, 0x8f, 0x71, 0x2f,
-	0x61, 0xf1, 0x15, 0xa7, 0xee, 0xef, 0x94, 0x67, 0x68, 0xdb, 0x0b, 0x83, 0x1b, 0x36, 0xe1, 0xd4,
-	0xa7, 0x6e, 0x7e, 0x96, 0x8d, 0x66, 0xe4, 0xbb, 0xf1, 0x4d, 0xc8, 0xa7, 0xd9, 0x1a, 0xdc, 0x24,
-	0xbe, 0xcd, 0x7e, 0x37, 0xa6, 0xe1, 0x35, 0xf5, 0xd3, 0x45, 0x27, 0x86, 0xa6, 0xc3, 0xa6, 0x91,
-	0x4f, 0x49, 0x96, 0x0c, 0xfa, 0x1e, 0x0c, 0x2f, 0xbc, 0xa6, 0x78, 0xe9, 0xf5, 0xd2, 0x66, 0x63,
-	0xfb, 0x65, 0x2f, 0xcd, 0xa8, 0x97, 0x67, 0xd4, 0x1b, 0x0f, 0x82, 0x78, 0x67, 0xfb, 0xdc, 0xf5,
-	0x13, 0x4a, 0x94, 0x52, 0x46, 0xb0, 0xe0, 0x26, 0xc4, 0xcf, 0xfe, 0x25, 0xc2, 0x89, 0x39, 0x0b,
-	0x26, 0x59, 0x84, 0x54, 0x76, 0x3e, 0x99, 0x60, 0x3e, 0xe5, 0x07, 0x51, 0x07, 0xaa, 0x69, 0x6d,
-	0xf1, 0xb2, 0x8a, 0x81, 0xde, 0xdd, 0x56, 0xaf, 0xaf, 0x10, 0x92, 0x31, 0xe8, 0x2d, 0xd4, 0x03,
-	0x77, 0x4a, 0x45, 0xe4, 0x7a, 0x14, 0x1b, 0x4a, 0xb6, 0x2a, 0x65, 0xc3, 0x1c, 0x24, 0x9a, 0x47,
-	0x5f, 0x43, 0x2d, 0xbb, 0x4a, 0x5c, 0x51, 0xd2, 0x86, 0x94, 0x3a, 0x29, 0x44, 0x72, 0x0e, 0x6d,
-	0x82, 0xc9, 0x02, 0x11, 0xbb, 0x81, 0x47, 0x71, 0x55, 0xe9, 0x56, 0xa4, 0x6e, 0x90, 0x61, 0xa4,
-	0x60, 0xe5, 0x86, 0x59, 0x33, 0xe0, 0x9a, 0xde, 0x90, 0xa4, 0x10, 0xc9, 0x39, 0xf4, 0x06, 0x2a,
-	0xae, 0xcf, 0x5c, 0x81, 0x4d, 0x25, 0xb2, 0x4a, 0x5f, 0xdd, 0x95, 0x38, 0x49, 0x69, 0xf4, 0x06,
-	0xea, 0xb2, 0x7d, 0x4e, 0x64, 0xfb, 0xe0, 0xba, 0xd2, 0x9a, 0x6a, 0xc3, 0xc4, 0xa7, 0x44, 0x53,
-	0xe8, 0x27, 0x68, 0x66, 0x5d, 0xb5, 0x97, 0x76, 0x15, 0x06, 0x25, 0x46, 0xaa, 0x40, 0x33, 0x0c,
-	0x99, 0x53, 0xa2, 0x1f, 0x60, 0x35, 0xed, 0x3d, 0x92, 0xf6, 0x1e, 0x6e, 0xa8, 0xd0, 0xe7, 0x2a,
-	0xb4, 0x4c, 0x90, 0x59, 0x9d, 0xac, 0x4a, 0xde, 0x9f, 0xb8, 0xa5, 0xab, 0xf2, 0x4b, 0x86, 0x91,
-	0x82, 0x45, 0x2f, 0xc1, 0x48, 0x04, 0xe5, 0xb8, 0xad, 0x33, 0x18, 0x0b, 0xca, 0x89, 0x42, 0xe5,
-	0x8d, 0xc9, 0xff, 0x87, 0x3c, 0x4c, 0x22, 0xbc, 0xa6, 0x6f, 0x6c, 0x9c, 0x83, 0x44, 0xf3, 0xe8,
-	0x3d, 0xac, 0xc8, 0x47, 0xe0, 0xc4, 0x32, 0xf9, 0xc9, 0x3d, 0x5e, 0xd7, 0x05, 0xdc, 0x2d, 0xe1,
-	0x64, 0x46, 0x85, 0xb6, 0xc0, 0xe4, 0xd4, 0x77, 0x63, 0x16, 0x06, 0xf8, 0x85, 0x8a, 0x58, 0x9f,
-	0xfd, 0x42, 0x46, 0x92, 0x42, 0x26, 0xcb, 0xe2, 0x87, 0x13, 0x16, 0xe4, 0x0d, 0x8e, 0xff, 0xaf,
-	0xcb, 0x72, 0x52, 0x26, 0xc8, 0xac, 0x0e, 0x1d, 0x00, 0x9a, 0x86, 0xd7, 0xec, 0xe6, 0xbe, 0x7c,
-	0x1e, 0x8c, 0x55, 0xf4, 0x0b, 0x19, 0x7d, 0xfa, 0x80, 0x25, 0x8f, 0x44, 0xa0, 0x0f, 0xd0, 0x4a,
-	0xd1, 0xe2, 0x94, 0xf8, 0x0b, 0xb5, 0x49, 0x5b, 0x6f, 0xa2, 0x13, 0x98, 0xd7, 0xa2, 0x1d, 0xa8,
-	0x73, 0x2a, 0xc2, 0x84, 0x7b, 0x54, 0xe0, 0x0d, 0x9d, 0x73, 0xf1, 0xcd, 0x9c, 0x24, 0x5a, 0x27,
-	0xab, 0x1b, 0x46, 0x32, 0x7d, 0xe7, 0x23, 0x8b, 0xbd, 0x5b, 0xfc, 0xa5, 0xae, 0xee, 0x59, 0x09,
-	0x27, 0x33, 0xaa, 0x23, 0xc3, 0x5c, 0xb1, 0x5a, 0x47, 0x86, 0x69, 0x59, 0xed, 0xce, 0xdf, 0x4b,
-	0x80, 0xf6, 0xdc, 0xd8, 0xbb, 0xbd, 0xe0, 0x2c, 0x7e, 0x52, 0x3b, 0x92, 0x11, 0x82, 0xfd, 0x41,
-	0x33, 0x6f, 0xf8, 0x8f, 0x6f, 0x48, 0x25, 0xea, 0xaa, 0x1a, 0xa9, 0x13, 0x0a, 0x6c, 0xbc, 0x5e,
-	0xce, 0x5b, 0xb8, 0xb8, 0x5a, 0x4d, 0x77, 0x3e, 0x55, 0xb3, 0xc4, 0x7e, 0x4d, 0x28, 0xbf, 0x7f,
-	0xd2, 0xc4, 0xde, 0x43, 0xd5, 0x9d, 0x86, 0x49, 0x61, 0x7b, 0x8b, 0xbf, 0x92, 0x69, 0x8b, 0x72,
-	0x18, 0x9f, 0x5d, 0x8e, 0xef, 0x00, 0x0a, 0x6b, 0x14, 0xb8, 0xa2, 0xea, 0x31, 0xe7, 0x9d, 0x25,
-	0x01, 0xfa, 0x06, 0xcc, 0xcc, 0x20, 0x05, 0xae, 0x2a, 0xf1, 0x8c, 0x7b, 0x16, 0xa4, 0x2c, 0x73,
-	0x6e, 0x90, 0x02, 0xd7, 0x74, 0x99, 0x0b, 0xff, 0xd4, 0xb4, 0xdc, 0x34, 0x33, 0x49, 0x69, 0x8e,
-	0xcb, 0xf3, 0x0e, 0x5a, 0x90, 0xa8, 0x0b, 0x35, 0xe5, 0x91, 0x54, 0xe0, 0xba, 0xd2, 0x3d, 0x34,
-	0xd1, 0x5c, 0x80, 0x36, 0x01, 0x0a, 0xaf, 0x14, 0x18, 0x94, 0x5c, 0xfb, 0x68, 0x89, 0x43, 0x36,
-	0xa0, 0xd4, 0xe4, 0x2e, 0x58, 0x7c, 0xeb, 0xe4, 0xd9, 0x35, 0x54, 0xc4, 0xba, 0x76, 0xc4, 0x12,
-	0x4b, 0x1e, 0x09, 0x90, 0x19, 0xe7, 0xe6, 0x27, 0x70, 0x4b, 0x67, 0x5c, 0x78, 0xa3, 0xa6, 0xd1,
-	0x2b, 0xa8, 0x48, 0x7b, 0x13, 0x18, 0xe9, 0x73, 0x29, 0x77, 0x4c, 0x61, 0x79, 0x2b, 0x85, 0xfd,
-	0x09, 0xdc, 0xd6, 0xb7, 0xa2, 0x1f, 0x7f, 0x49, 0x80, 0x7e, 0x84, 0x66, 0xc9, 0xfa, 0x18, 0x15,
-	0x78, 0x4d, 0x97, 0x67, 0xc6, 0x74, 0xe6, 0x74, 0xe8, 0x2b, 0xa8, 0xa5, 0x33, 0x54, 0xe0, 0x75,
-	0x15, 0x52, 0x1e, 0xaf, 0x39, 0x55, 0x7a, 0xec, 0xcf, 0x3b, 0x7f, 0x56, 0xc0, 0xda, 0x67, 0xc2,
-	0x0b, 0xef, 0x28, 0x7f, 0xd2, 0x17, 0xf1, 0x01, 0x8c, 0xf8, 0x3e, 0x4a, 0x9f, 0x7a, 0x73, 0xfb,
-	0x5b, 0x79, 0xce, 0xf9, 0x73, 0x3c, 0x00, 0x46, 0xf7, 0x11, 0x25, 0x2a, 0xac, 0x3c, 0xf6, 0x8d,
-	0x05, 0x63, 0x7f, 0xa6, 0x6f, 0x2b, 0x8b, 0xfb, 0xb6, 0x34, 0xf8, 0xab, 0x0b, 0x06, 0xff, 0xdb,
-	0xf2, 0x40, 0xaf, 0xe9, 0x59, 0x47, 0x72, 0x70, 0xf1, 0x54, 0x37, 0x3f, 0x7b, 0xaa, 0x97, 0x1f,
-	0x67, 0x7d, 0xc1, 0xe3, 0xec, 0xfc, 0xb5, 0x04, 0x6b, 0x8f, 0x95, 0x0a, 0x35, 0xa0, 0x36, 0x1e,
-	0x1e, 0x0f, 0xcf, 0x2e, 0x86, 0xd6, 0xff, 0xd0, 0x0a, 0x98, 0x83, 0xa1, 0x33, 0xda, 0x1d, 0xf6,
-	0x6d, 0x6b, 0x49, 0x52, 0xfd, 0x93, 0xb1, 0x33, 0xb2, 0x89, 0xf5, 0x4c, 0x2e, 0xc8, 0xd9, 0x78,
-	0x34, 0x18, 0x1e, 0x5a, 0xcb, 0xa8, 0x09, 0x40, 0x76, 0x47, 0xf6, 0xe5, 0xc9, 0xe0, 0x74, 0x30,
-	0xb2, 0x0c, 0xd4, 0x86, 0x56, 0x7f, 0x40, 0xfa, 0xe3, 0xc1, 0xe8, 0x72, 0x8f, 0xd8, 0xbb, 0xc7,
-	0x36, 0xb1, 0x2a, 0x72, 0x33, 0xc7, 0x26, 0xe7, 0x83, 0xbe, 0xed, 0x58, 0xd5, 0x8e, 0x61, 0xd6,
-	0xac, 0x46, 0xd7, 0x38, 0xb5, 0x9d, 0x9f, 0xbb, 0x0d, 0xf9, 0xf7, 0xb2, 0x7f, 0x36, 0x3c, 0x18,
-	0x1c, 0x76, 0x9b, 0x07, 0x27, 0xe3, 0xdf, 0x2e, 0xf7, 0xf7, 0x88, 0x7d, 0x40, 0x24, 0x69, 0xaa,
-	0xb5, 0xb3, 0x7f, 0xdc, 0x6d, 0xa4, 0xbf, 0x6c, 0x72, 0x6e, 0x93, 0x23, 0xc3, 0x04, 0xab, 0x79,
-	0x55, 0x55, 0xdd, 0xb2, 0xf3, 0x4f, 0x00, 0x00, 0x00, 0xff, 0xff, 0x93, 0x86, 0x8f, 0xb6, 0xcf,
-	0x0b, 0x00, 0x00,
+	// 1121 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x55, 0xdf, 0x6e, 0xdb, 0xb6,
+	0x17, 0xfe, 0xa5, 0x92, 0x6d, 0xf9, 0x38, 0xb5, 0x55, 0x3a, 0xed, 0x8f, 0xcb, 0x8a, 0xc2, 0x30,
+	0xb6, 0xae, 0x73, 0x31, 0xb7, 0x4d, 0x0a, 0xac, 0x18, 0xd0, 0x8b, 0xc4, 0x51, 0x32, 0xe7, 0x8f,
+	0xb3, 0x51, 0x76, 0xb2, 0xbb, 0x40, 0x51, 0x18, 0x47, 0x98, 0x2c, 0x19, 0xa4, 0x9c, 0x22, 0x7b,
+	0x8b, 0xbe, 0xd0, 0x1e, 0x63, 0x4f, 0xb2, 0x07, 0x18, 0x48, 0x89, 0xa2, 0x6c, 0xa7, 0x46, 0xaf,
+	0x72, 0x93, 0x98, 0xe7, 0xfb, 0x0e, 0xc5, 0xf3, 0xf1, 0xf0, 0x3b, 0x50, 0x67, 0x94, 0x4f, 0xe3,
+	0x88, 0xd3, 0xee, 0x94, 0xc5, 0x49, 0x8c, 0x1e, 0xdd, 0xbe, 0xdb, 0x7c, 0x31, 0x8e, 0xe3, 0x71,
+	0x48, 0xdf, 0xc8, 0xc8, 0xe5, 0xec, 0xfa, 0xcd, 0x27, 0xe6, 0x4d, 0xa7, 0x94, 0xf1, 0x94, 0xb3,
+	0xf9, 0x98, 0x53, 0x76, 0x1b, 0xf8, 0x54, 0x2d, 0x59, 0x3c, 0x4b, 0x82, 0x68, 0x9c, 0x2d, 0xd7,
+	0xfd, 0x30, 0xa0, 0x51, 0x92, 0xad, 0x1a, 0xcc, 0x4b, 0x68, 0x18, 0x4c, 0x02, 0x15, 0xd8, 0xf0,
+	0x03, 0xe6, 0xcf, 0x82, 0xe4, 0x92, 0x51, 0xef, 0x4f, 0xca, 0xb2, 0x68, 0xd3, 0x8f, 0xa3, 0xeb,
+	0x60, 0xcc, 0x68, 0x48, 0x3d, 0x75, 0x96, 0xcd, 0xfa, 0x34, 0xf4, 0x92, 0xeb, 0x98, 0x4d, 0xb2,
+	0x35, 0x78, 0xb3, 0xe4, 0x26, 0xfb, 0x5d, 0x9b, 0xc4, 0x57, 0x34, 0x4c, 0x17, 0xed, 0x04, 0xea,
+	0x6e, 0x30, 0x99, 0x86, 0x94, 0x64, 0xc5, 0xa0, 0xb7, 0x60, 0xfa, 0xf1, 0x15, 0xc5, 0x6b, 0xad,
+	0xb5, 0x57, 0xb5, 0xad, 0xe7, 0xdd, 0xb4, 0xa2, 0xae, 0xaa, 0xa8, 0x3b, 0xea, 0x47, 0xc9, 0xf6,
+	0xd6, 0x99, 0x17, 0xce, 0x28, 0x91, 0x4c, 0x91, 0x11, 0x44, 0xd7, 0x31, 0x7e, 0xf4, 0x85, 0x0c,
+	0x37, 0x61, 0x41, 0x34, 0xce, 0x32, 0x04, 0xb3, 0xfd, 0xd9, 0x02, 0xeb, 0x21, 0x3f, 0x88, 0xda,
+	0x50, 0x4e, 0xb5, 0xc5, 0x86, 0xcc, 0x81, 0xee, 0xed, 0xbb, 0x6e, 0x4f, 0x46, 0x48, 0x86, 0xa0,
+	0xd7, 0x50, 0x8d, 0xbc, 0x09, 0xe5, 0x53, 0xcf, 0xa7, 0xd8, 0x94, 0xb4, 0xc7, 0x82, 0x36, 0x50,
+	0x41, 0xa2, 0x71, 0xf4, 0x3d, 0x54, 0xb2, 0xab, 0xc4, 0x25, 0x49, 0xad, 0x09, 0xaa, 0x9b, 0x86,
+	0x88, 0xc2, 0xd0, 0x2b, 0xb0, 0x82, 0x88, 0x27, 0x5e, 0xe4, 0x53, 0x5c, 0x96, 0xbc, 0x75, 0xc1,
+	0xeb, 0x67, 0x31, 0x92, 0xa3, 0x62, 0xc3, 0xac, 0x19, 0x70, 0x45, 0x6f, 0x48, 0xd2, 0x10, 0x51,
+	0x18, 0x7a, 0x09, 0x25, 0x2f, 0x0c, 0x3c, 0x8e, 0x2d, 0x49, 0xb2, 0x0b, 0x5f, 0xdd, 0x11, 0x71,
+	0x92, 0xc2, 0xe8, 0x25, 0x54, 0x45, 0xfb, 0x1c, 0x8b, 0xf6, 0xc1, 0x55, 0xc9, 0xb5, 0xe4, 0x86,
+	0xb3, 0x90, 0x12, 0x0d, 0xa1, 0x5f, 0xa0, 0x9e, 0x75, 0xd5, 0x6e, 0xda, 0x55, 0x18, 0x24, 0x19,
+	0x49, 0x81, 0xe6, 0x10, 0xb2, 0xc0, 0x44, 0x3f, 0xc3, 0xe3, 0xb4, 0xf7, 0x48, 0xda, 0x7b, 0xb8,
+	0x26, 0x53, 0x9f, 0xc8, 0xd4, 0x22, 0x40, 0xe6, 0x79, 0x42, 0x15, 0xd5, 0x9f, 0xb8, 0xa1, 0x55,
+	0xf9, 0x2d, 0x8b, 0x91, 0x1c, 0x45, 0xcf, 0xc1, 0x9c, 0x71, 0xca, 0x70, 0x53, 0x57, 0x30, 0xe2,
+	0x94, 0x11, 0x19, 0x15, 0x37, 0x26, 0xfe, 0x1f, 0xb0, 0x78, 0x36, 0xc5, 0x1b, 0xfa, 0xc6, 0x46,
+	0x2a, 0x48, 0x34, 0x8e, 0xde, 0xc3, 0xba, 0x78, 0x04, 0x6e, 0x22, 0x8a, 0x1f, 0xdf, 0xe1, 0xa7,
+	0x5a, 0xc0, 0x9d, 0x42, 0x9c, 0xcc, 0xb1, 0xd0, 0x3b, 0xb0, 0x18, 0x0d, 0xbd, 0x24, 0x88, 0x23,
+	0xfc, 0x4c, 0x66, 0x3c, 0x9d, 0xff, 0x42, 0x06, 0x92, 0x9c, 0x26, 0x64, 0x09, 0xe3, 0x71, 0x10,
+	0xa9, 0x06, 0xc7, 0xff, 0xd7, 0xb2, 0x1c, 0x17, 0x01, 0x32, 0xcf, 0x43, 0xfb, 0x80, 0x26, 0xf1,
+	0x55, 0x70, 0x7d, 0x57, 0x3c, 0x0f, 0xc6, 0x32, 0xfb, 0x99, 0xc8, 0x3e, 0x59, 0x42, 0xc9, 0x3d,
+	0x19, 0xe8, 0x23, 0x34, 0xd2, 0x68, 0x7e, 0x4a, 0xfc, 0x8d, 0xdc, 0xa4, 0xa9, 0x37, 0xd1, 0x05,
+	0x2c, 0x72, 0xd1, 0x36, 0x54, 0x19, 0xe5, 0xf1, 0x8c, 0xf9, 0x94, 0xe3, 0x4d, 0x5d, 0x73, 0xfe,
+	0x4d, 0x05, 0x12, 0xcd, 0x13, 0xea, 0xc6, 0x53, 0x51, 0xbe, 0xfb, 0x29, 0x48, 0xfc, 0x1b, 0xfc,
+	0xad, 0x56, 0xf7, 0xb4, 0x10, 0x27, 0x73, 0xac, 0x43, 0xd3, 0x5a, 0xb7, 0x1b, 0x87, 0xa6, 0x65,
+	0xdb, 0xcd, 0xf6, 0x3f, 0x6b, 0x80, 0x76, 0xbd, 0xc4, 0xbf, 0x39, 0x67, 0x41, 0xf2, 0xa0, 0x76,
+	0x24, 0x32, 0x78, 0xf0, 0x17, 0xc5, 0xc6, 0x17, 0x32, 0xe6, 0xbe, 0x21, 0x98, 0xa8, 0x23, 0x35,
+	0x92, 0x27, 0xe4, 0xd8, 0x6c, 0x19, 0xaa, 0x85, 0xf3, 0xab, 0xd5, 0x70, 0xfb, 0x73, 0x39, 0x2b,
+	0xec, 0xf7, 0x19, 0x65, 0x77, 0x0f, 0x5a, 0xd8, 0x7b, 0x28, 0x7b, 0x93, 0x78, 0x96, 0xdb, 0xde,
+	0xea, 0xaf, 0x64, 0xdc, 0x5c, 0x0e, 0xf3, 0xab, 0xe5, 0xf8, 0x09, 0x20, 0xb7, 0x46, 0x8e, 0x4b,
+	0x2d, 0x43, 0xbd, 0x44, 0xed, 0x9d, 0x05, 0x02, 0xfa, 0x01, 0xac, 0xcc, 0x20, 0x39, 0x2e, 0xb7,
+	0x0c, 0x65, 0x76, 0xca, 0x3d, 0x73, 0x50, 0xc8, 0xac, 0x0c, 0x92, 0xe3, 0x4a, 0xcb, 0x58, 0xf2,
+	0x4f, 0x0d, 0x8b, 0x4d, 0x33, 0x93, 0x14, 0xe6, 0x68, 0x2c, 0x3a, 0x68, 0x0e, 0xa2, 0x0e, 0x54,
+	0xa4, 0x47, 0x52, 0x8e, 0xab, 0x2d, 0x43, 0x75, 0xe9, 0x9c, 0x89, 0x2a, 0x02, 0x7a, 0x05, 0x90,
+	0x7b, 0x25, 0xc7, 0xd0, 0x32, 0x94, 0x0b, 0x49, 0x1f, 0x2d, 0x60, 0xc8, 0x01, 0x94, 0x9a, 0xdc,
+	0x79, 0x90, 0xdc, 0xb8, 0xaa, 0xba, 0x5a, 0xcb, 0x50, 0xcf, 0xa7, 0xb7, 0x88, 0x92, 0x7b, 0x12,
+	0x44, 0xc5, 0xca, 0xfc, 0x38, 0x6e, 0xb4, 0x8c, 0x25, 0x6f, 0xd4, 0x30, 0x7a, 0x01, 0x25, 0x61,
+	0x6f, 0x1c, 0x23, 0x7d, 0x2e, 0xe9, 0x8e, 0x69, 0x58, 0xdc, 0x4a, 0x6e, 0x7f, 0x1c, 0x37, 0xf5,
+	0xad, 0xe8, 0xc7, 0x5f, 0x20, 0xa0, 0x0f, 0x50, 0x2f, 0x58, 0x5f, 0x40, 0x39, 0xde, 0xd0, 0xf2,
+	0xcc, 0x99, 0xce, 0x02, 0x0f, 0x7d, 0x07, 0x95, 0x74, 0x86, 0x72, 0xfc, 0xb4, 0x65, 0x2c, 0x8c,
+	0x57, 0x05, 0x15, 0x1e, 0xfb, 0x93, 0xf6, 0xbf, 0x25, 0xb0, 0xf7, 0x02, 0xee, 0xc7, 0xb7, 0x94,
+	0x3d, 0xe8, 0x8b, 0xf8, 0x08, 0x66, 0x72, 0x37, 0x4d, 0x9f, 0x7a, 0x7d, 0xeb, 0x47, 0x71, 0xce,
+	0xc5, 0x73, 0x2c, 0x05, 0x86, 0x77, 0x53, 0x4a, 0x64, 0x5a, 0x71, 0xec, 0x9b, 0x2b, 0xc6, 0xfe,
+	0x5c, 0xdf, 0x96, 0x56, 0xf7, 0x6d, 0x61, 0xf0, 0x97, 0x57, 0x0c, 0xfe, 0xd7, 0xc5, 0x81, 0x5e,
+	0xd1, 0xb3, 0x8e, 0xa8, 0xe0, 0xea, 0xa9, 0x6e, 0x7d, 0xf5, 0x54, 0x2f, 0x3e, 0xce, 0xea, 0xaa,
+	0xc7, 0xf9, 0x16, 0x4a, 0x57, 0x34, 0x4c, 0x3c, 0x5c, 0x97, 0x7b, 0x6f, 0x2e, 0xa9, 0xbf, 0x1b,
+	0xc7, 0x61, 0xaa, 0x7d, 0x4a, 0x44, 0x1f, 0xc0, 0x66, 0x74, 0x12, 0xdf, 0xd2, 0xab, 0x7e, 0xae,
+	0x4e, 0xe3, 0x1e, 0x75, 0x96, 0x58, 0xed, 0xbf, 0xd7, 0x60, 0xe3, 0xbe, 0x6b, 0x41, 0x35, 0xa8,
+	0x8c, 0x06, 0x47, 0x83, 0xd3, 0xf3, 0x81, 0xfd, 0x3f, 0xb4, 0x0e, 0x56, 0x7f, 0xe0, 0x0e, 0x77,
+	0x06, 0x3d, 0xc7, 0x5e, 0x13, 0x50, 0xef, 0x78, 0xe4, 0x0e, 0x1d, 0x62, 0x3f, 0x12, 0x0b, 0x72,
+	0x3a, 0x1a, 0xf6, 0x07, 0x07, 0xb6, 0x81, 0xea, 0x00, 0x64, 0x67, 0xe8, 0x5c, 0x1c, 0xf7, 0x4f,
+	0xfa, 0x43, 0xdb, 0x44, 0x4d, 0x68, 0xf4, 0xfa, 0xa4, 0x37, 0xea, 0x0f, 0x2f, 0x76, 0x89, 0xb3,
+	0x73, 0xe4, 0x10, 0xbb, 0x24, 0x36, 0x73, 0x1d, 0x72, 0xd6, 0xef, 0x39, 0xae, 0x5d, 0x6e, 0x9b,
+	0x56, 0xc5, 0xae, 0x75, 0xcc, 0x13, 0xc7, 0xfd, 0xb5, 0x53, 0x13, 0x7f, 0x2f, 0x7a, 0xa7, 0x83,
+	0xfd, 0xfe, 0x41, 0xa7, 0xbe, 0x7f, 0x3c, 0xfa, 0xe3, 0x62, 0x6f, 0x97, 0x38, 0xfb, 0x44, 0x80,
+	0x96, 0x5c, 0xbb, 0x7b, 0x47, 0x9d, 0x5a, 0xfa, 0xcb, 0x21, 0x67, 0x0e, 0x39, 0x34, 0x2d, 0xb0,
+	0xeb, 0x97, 0x65, 0xa9, 0xcd, 0xf6, 0x7f, 0x03, 0x00, 0x85, 0x5c, 0x03, 0x1f, 0x3b, 0x0c, 0x00,
+	0x00,
 }
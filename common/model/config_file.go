@@ -17,7 +17,10 @@
 
 package model
 
-import "time"
+import (
+	"strings"
+	"time"
+)
 
 /** ----------- DataObject ------------- */
 
@@ -27,6 +30,7 @@ type ConfigFileGroup struct {
 	Name       string
 	Namespace  string
 	Comment    string
+	JsonSchema string
 	CreateTime time.Time
 	CreateBy   string
 	ModifyTime time.Time
@@ -43,12 +47,51 @@ type ConfigFile struct {
 	Content    string
 	Comment    string
 	Format     string
+	Encrypted  bool
 	Flag       int
 	CreateTime time.Time
 	CreateBy   string
 	ModifyTime time.Time
 	ModifyBy   string
 	Valid      bool
+	// BaseFile 继承的基础配置文件，格式为 namespace/group/name，为空表示不继承
+	BaseFile string
+	// VariablesFile 模板占位符引用的变量文件，格式为 namespace/group/name，为空表示不替换占位符
+	VariablesFile string
+}
+
+// Ref 返回配置文件的引用
+func (f *ConfigFile) Ref() *ConfigFileRef {
+	return &ConfigFileRef{Namespace: f.Namespace, Group: f.Group, Name: f.Name}
+}
+
+// IsTemplate 配置文件是否为模板，模板发布时需要渲染
+func (f *ConfigFile) IsTemplate() bool {
+	return f.BaseFile != "" || f.VariablesFile != ""
+}
+
+// ConfigFileRef 配置文件引用
+type ConfigFileRef struct {
+	Namespace string
+	Group     string
+	Name      string
+}
+
+// String 返回 namespace/group/name 格式的引用，命名空间和分组不允许包含 /
+func (r *ConfigFileRef) String() string {
+	if r == nil {
+		return ""
+	}
+	return r.Namespace + "/" + r.Group + "/" + r.Name
+}
+
+// ParseConfigFileRef 解析 namespace/group/name 格式的引用，为空或者格式不合法时返回 nil
+func ParseConfigFileRef(ref string) *ConfigFileRef {
+	parts := strings.SplitN(ref, "/", 3)
+	if len(parts) != 3 {
+		return nil
+	}
+	return &ConfigFileRef{Namespace: parts[0], Group: parts[1], Name: parts[2]}
 }
 
 // ConfigFileRelease 配置文件发布数据持久化对象
@@ -68,6 +111,37 @@ type ConfigFileRelease struct {
 	ModifyTime time.Time
 	ModifyBy   string
 	Valid      bool
+	// GrayRule 灰度规则，只有灰度发布才有，为空表示正式发布
+	GrayRule *ConfigFileGrayRule
+}
+
+// ConfigFileGrayRule 配置文件灰度规则，客户端 IP 在列表中或者携带了全部的标签，即为灰度客户端
+type ConfigFileGrayRule struct {
+	ClientIPs    []string          `json:"client_ips,omitempty"`
+	ClientLabels map[string]string `json:"client_labels,omitempty"`
+}
+
+// Match 判断客户端是否命中灰度规则
+func (r *ConfigFileGrayRule) Match(clientIP string, labels map[string]string) bool {
+	if r == nil {
+		return false
+	}
+	if clientIP != "" {
+		for _, ip := range r.ClientIPs {
+			if ip == clientIP {
+				return true
+			}
+		}
+	}
+	if len(r.ClientLabels) == 0 {
+		return false
+	}
+	for key, value := range r.ClientLabels {
+		if clientValue, ok := labels[key]; !ok || clientValue != value {
+			return false
+		}
+	}
+	return true
 }
 
 // ConfigFileReleaseHistory 配置文件发布历史记录数据持久化对象
@@ -89,6 +163,8 @@ type ConfigFileReleaseHistory struct {
 	ModifyTime time.Time
 	ModifyBy   string
 	Valid      bool
+	// TemplateContent 模板配置发布前的原始内容，Content 为渲染后的内容
+	TemplateContent string
 }
 
 // ConfigFileTag 配置文件标签数据持久化对象
@@ -105,3 +181,43 @@ type ConfigFileTag struct {
 	ModifyBy   string
 	Valid      bool
 }
+
+// ConfigFileListener 订阅配置文件的客户端，由各个 server 节点定时上报，用于查询配置的推送进度
+type ConfigFileListener struct {
+	ClientId     string
+	ClientIP     string
+	Namespace    string
+	Group        string
+	FileName     string
+	Version      uint64
+	LastPollTime time.Time
+	// Server 客户端连接的 server 节点
+	Server     string
+	ModifyTime time.Time
+}
+
+// ConfigFilePublishRequest 配置发布申请，审批通过后手动发布或者在计划时间由调度器发布
+type ConfigFilePublishRequest struct {
+	Id        string
+	Namespace string
+	Group     string
+	FileName  string
+	Comment   string
+	Status    string
+	// ApproveUsers 审批用户 ID，ApproveGroups 审批用户组 ID，任一审批人审批即可
+	ApproveUsers  []string
+	ApproveGroups []string
+	// PlanTime 计划发布时间，零值表示审批通过后手动发布
+	PlanTime       time.Time
+	ApproveBy      string
+	ApproveComment string
+	ApproveTime    time.Time
+	// ApproveMd5 审批时草稿内容的 md5，草稿在审批之后被修改时拒绝发布
+	ApproveMd5  string
+	PublishTime time.Time
+	FailReason  string
+	CreateTime  time.Time
+	CreateBy    string
+	ModifyTime  time.Time
+	ModifyBy    string
+}
@@ -325,6 +325,24 @@ const (
 
 	// OUpdateGroup 更新用户-用户组关联关系
 	OUpdateGroup OperationType = "UpdateGroup"
+
+	// OSubmit 提交审批
+	OSubmit OperationType = "Submit"
+
+	// OApprove 审批通过
+	OApprove OperationType = "Approve"
+
+	// OReject 审批驳回
+	OReject OperationType = "Reject"
+
+	// OSchedule 设置计划执行时间
+	OSchedule OperationType = "Schedule"
+
+	// OPublish 发布
+	OPublish OperationType = "Publish"
+
+	// OCancel 取消
+	OCancel OperationType = "Cancel"
 )
 
 // Resource 操作资源
@@ -332,19 +350,20 @@ type Resource string
 
 // 定义包含的资源类型
 const (
-	RNamespace         Resource = "Namespace"
-	RService           Resource = "Service"
-	RRouting           Resource = "Routing"
-	RInstance          Resource = "Instance"
-	RRateLimit         Resource = "RateLimit"
-	RMeshResource      Resource = "MeshResource"
-	RMesh              Resource = "Mesh"
-	RMeshService       Resource = "MeshService"
-	RFluxRateLimit     Resource = "FluxRateLimit"
-	RUser              Resource = "User"
-	RUserGroup         Resource = "UserGroup"
-	RUserGroupRelation Resource = "UserGroupRelation"
-	RAuthStrategy      Resource = "AuthStrategy"
+	RNamespace                Resource = "Namespace"
+	RService                  Resource = "Service"
+	RRouting                  Resource = "Routing"
+	RInstance                 Resource = "Instance"
+	RRateLimit                Resource = "RateLimit"
+	RMeshResource             Resource = "MeshResource"
+	RMesh                     Resource = "Mesh"
+	RMeshService              Resource = "MeshService"
+	RFluxRateLimit            Resource = "FluxRateLimit"
+	RUser                     Resource = "User"
+	RUserGroup                Resource = "UserGroup"
+	RUserGroupRelation        Resource = "UserGroupRelation"
+	RAuthStrategy             Resource = "AuthStrategy"
+	RConfigFilePublishRequest Resource = "ConfigFilePublishRequest"
 )
 
 // ResourceType 资源类型
@@ -359,14 +378,15 @@ const (
 
 // ResourceTypeMap resource type map
 var ResourceTypeMap = map[Resource]ResourceType{
-	RNamespace:    ServiceType,
-	RService:      ServiceType,
-	RRouting:      ServiceType,
-	RInstance:     ServiceType,
-	RRateLimit:    ServiceType,
-	RMesh:         MeshType,
-	RMeshResource: MeshType,
-	RMeshService:  MeshType,
+	RNamespace:                ServiceType,
+	RService:                  ServiceType,
+	RRouting:                  ServiceType,
+	RInstance:                 ServiceType,
+	RRateLimit:                ServiceType,
+	RConfigFilePublishRequest: ServiceType,
+	RMesh:                     MeshType,
+	RMeshResource:             MeshType,
+	RMeshService:              MeshType,
 }
 
 // GetResourceType 获取资源的大类型
@@ -434,3 +454,13 @@ type NamespaceServiceCount struct {
 	// InstanceCnt 实例健康数/实例总数
 	InstanceCnt *InstanceCount
 }
+
+// ChangeLog 存储层记录的资源变更，同一类资源的序号单调递增
+type ChangeLog struct {
+	// Seq 变更序号
+	Seq uint64
+	// ID 发生变更的资源ID
+	ID string
+	// CreateTime 变更时间
+	CreateTime time.Time
+}
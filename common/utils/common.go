@@ -452,6 +452,24 @@ func ParsePlatformToken(ctx context.Context) string {
 	return pToken
 }
 
+// ParseClientIP 从ctx中获取客户端IP
+func ParseClientIP(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	clientIP, _ := ctx.Value(StringContext("client-ip")).(string)
+	return clientIP
+}
+
+// ParseClientLabels 从ctx中获取客户端标签
+func ParseClientLabels(ctx context.Context) map[string]string {
+	if ctx == nil {
+		return nil
+	}
+	labels, _ := ctx.Value(StringContext("client-labels")).(map[string]string)
+	return labels
+}
+
 // ZapRequestID 生成Request-ID的日志描述
 func ZapRequestID(id string) zap.Field {
 	return zap.String("request-id", id)
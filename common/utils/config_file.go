@@ -24,6 +24,14 @@ const (
 	ReleaseTypeNormal = "normal"
 	// ReleaseTypeDelete 发布类型，删除配置文件
 	ReleaseTypeDelete = "delete"
+	// ReleaseTypeGray 发布类型，灰度发布
+	ReleaseTypeGray = "gray"
+	// ReleaseTypeGrayPromote 发布类型，灰度发布转为全量发布
+	ReleaseTypeGrayPromote = "gray-promote"
+	// ReleaseTypeGrayAbort 发布类型，放弃灰度发布
+	ReleaseTypeGrayAbort = "gray-abort"
+	// ReleaseTypeRollback 发布类型，回滚到历史发布
+	ReleaseTypeRollback = "rollback"
 
 	// ReleaseStatusSuccess 发布成功状态
 	ReleaseStatusSuccess = "success"
@@ -32,6 +40,25 @@ const (
 	// ReleaseStatusToRelease 待发布状态
 	ReleaseStatusToRelease = "to-be-released"
 
+	// PublishRequestStatusDraft 发布申请状态，草稿
+	PublishRequestStatusDraft = "draft"
+	// PublishRequestStatusPending 发布申请状态，待审批
+	PublishRequestStatusPending = "pending"
+	// PublishRequestStatusRejected 发布申请状态，审批驳回，可以修改后重新提交
+	PublishRequestStatusRejected = "rejected"
+	// PublishRequestStatusApproved 发布申请状态，审批通过，等待手动发布或者设置计划发布时间
+	PublishRequestStatusApproved = "approved"
+	// PublishRequestStatusScheduled 发布申请状态，等待调度器在计划时间发布
+	PublishRequestStatusScheduled = "scheduled"
+	// PublishRequestStatusPublishing 发布申请状态，发布中
+	PublishRequestStatusPublishing = "publishing"
+	// PublishRequestStatusPublished 发布申请状态，已发布
+	PublishRequestStatusPublished = "published"
+	// PublishRequestStatusFailed 发布申请状态，发布失败
+	PublishRequestStatusFailed = "failed"
+	// PublishRequestStatusCancelled 发布申请状态，已取消
+	PublishRequestStatusCancelled = "cancelled"
+
 	// 文件格式
 	FileFormatText       = "text"
 	FileFormatYaml       = "yaml"
@@ -39,13 +66,16 @@ const (
 	FileFormatJson       = "json"
 	FileFormatHtml       = "html"
 	FileFormatProperties = "properties"
+	FileFormatToml       = "toml"
+	FileFormatIni        = "ini"
 
 	FileIdSeparator = "+"
 )
 
 func IsValidFileFormat(format string) bool {
 	return format == FileFormatText || format == FileFormatYaml || format == FileFormatXml ||
-		format == FileFormatJson || format == FileFormatHtml || format == FileFormatProperties
+		format == FileFormatJson || format == FileFormatHtml || format == FileFormatProperties ||
+		format == FileFormatToml || format == FileFormatIni
 }
 
 // GenFileId 生成文件 Id
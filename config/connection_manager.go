@@ -61,14 +61,15 @@ func NewConfigConnManager(ctx context.Context, watchCenter *watchCenter) *connMa
 	return cm
 }
 
-func (c *connManager) AddConn(clientId string, watchConfigFiles []*api.ClientConfigFileInfo, finishChan chan *api.ConfigClientResponse) {
+func (c *connManager) AddConn(ctx context.Context, clientId string, watchConfigFiles []*api.ClientConfigFileInfo,
+	finishChan chan *api.ConfigClientResponse) {
 	cm.conns.Store(clientId, &connection{
 		finishTime:       time.Now().Add(defaultLongPollingTimeout),
 		finishChan:       finishChan,
 		watchConfigFiles: watchConfigFiles,
 	})
 
-	c.watchCenter.AddWatcher(clientId, watchConfigFiles, func(clientId string, rsp *api.ConfigClientResponse) bool {
+	c.watchCenter.AddClientWatcher(ctx, clientId, watchConfigFiles, func(clientId string, rsp *api.ConfigClientResponse) bool {
 		connObj, ok := cm.conns.Load(clientId)
 		if ok {
 			conn := connObj.(*connection)
@@ -0,0 +1,75 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package config
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/polarismesh/polaris-server/common/log"
+	"github.com/polarismesh/polaris-server/store"
+)
+
+const (
+	listenerReportInterval = 30 * time.Second
+	// listenerExpireTime 订阅者超过该时间未被任何节点上报则认为已经断开
+	listenerExpireTime = 3 * listenerReportInterval
+)
+
+// listenerReporter 定时上报本节点的配置订阅者，所有节点的订阅者汇总在存储层，便于查询配置的推送进度
+type listenerReporter struct {
+	storage        store.Store
+	watchCenter    *watchCenter
+	reportInterval time.Duration
+}
+
+func startListenerReporter(ctx context.Context, storage store.Store, watchCenter *watchCenter,
+	reportInterval time.Duration) {
+	reporter := &listenerReporter{
+		storage:        storage,
+		watchCenter:    watchCenter,
+		reportInterval: reportInterval,
+	}
+	go reporter.run(ctx)
+}
+
+func (r *listenerReporter) run(ctx context.Context) {
+	t := time.NewTicker(r.reportInterval)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			r.report()
+		}
+	}
+}
+
+func (r *listenerReporter) report() {
+	listeners := r.watchCenter.Listeners()
+	if err := r.storage.UpsertConfigFileListeners(listeners); err != nil {
+		log.ConfigScope().Error("[Config][Listener] report config file listeners error.",
+			zap.Int("count", len(listeners)), zap.Error(err))
+	}
+	if err := r.storage.DeleteExpiredConfigFileListeners(time.Now().Add(-listenerExpireTime)); err != nil {
+		log.ConfigScope().Error("[Config][Listener] delete expired config file listeners error.", zap.Error(err))
+	}
+}
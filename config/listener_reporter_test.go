@@ -0,0 +1,97 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package config
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/wrappers"
+
+	api "github.com/polarismesh/polaris-server/common/api/v1"
+	"github.com/polarismesh/polaris-server/common/utils"
+	"github.com/polarismesh/polaris-server/store"
+	_ "github.com/polarismesh/polaris-server/store/boltdb"
+)
+
+// TestListenerReporter 同一个客户端多次长轮询，上报到存储后只保留一条订阅记录
+func TestListenerReporter(t *testing.T) {
+	dir, err := ioutil.TempDir("", "listener_reporter")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	s, err := store.NewStore("boltdbStore")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Initialize(&store.Config{Name: "boltdbStore",
+		Option: map[string]interface{}{"path": filepath.Join(dir, "polaris.bolt")}}); err != nil {
+		t.Fatal(err)
+	}
+	defer s.Destroy()
+
+	wc := &watchCenter{configFileWatchers: new(sync.Map), lock: new(sync.Mutex)}
+	reporter := &listenerReporter{storage: s, watchCenter: wc, reportInterval: time.Second}
+	files := []*api.ClientConfigFileInfo{{
+		Namespace: &wrappers.StringValue{Value: "default"},
+		Group:     &wrappers.StringValue{Value: "app"},
+		FileName:  &wrappers.StringValue{Value: "app.yaml"},
+	}}
+	clientCtx := func(ip string, labels map[string]string) context.Context {
+		ctx := context.WithValue(context.Background(), utils.StringContext("client-ip"), ip)
+		return context.WithValue(ctx, utils.StringContext("client-labels"), labels)
+	}
+	noop := func(string, *api.ConfigClientResponse) bool { return true }
+
+	// 每次长轮询的 clientId 都不同，前一次请求结束前新的请求可能已经到达
+	polls := []string{"127.0.0.1:5001@aaaaaaaa", "127.0.0.1:5002@bbbbbbbb", "127.0.0.1:5003@cccccccc"}
+	for i, clientId := range polls {
+		files[0].Version = &wrappers.UInt64Value{Value: uint64(i + 1)}
+		wc.AddClientWatcher(clientCtx("127.0.0.1", nil), clientId, files, noop)
+		if i > 0 && i%2 == 0 {
+			wc.RemoveWatcher(polls[i-2], files)
+		}
+		reporter.report()
+	}
+	// 同一个 IP 上标签不同的客户端单独记录
+	wc.AddClientWatcher(clientCtx("127.0.0.1", map[string]string{"env": "gray"}), "127.0.0.1:6001@dddddddd",
+		files, noop)
+	reporter.report()
+
+	total, listeners, err := s.QueryConfigFileListeners("default", "app", "app.yaml", 0, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 2 {
+		t.Fatalf("listeners: %d %+v", total, listeners)
+	}
+	for _, listener := range listeners {
+		if listener.ClientIP != "127.0.0.1" {
+			t.Fatalf("listener: %+v", listener)
+		}
+		if listener.ClientId == "127.0.0.1" && listener.Version != 3 {
+			t.Fatalf("listener should keep the latest poll: %+v", listener)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package config
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/polarismesh/polaris-server/common/log"
+	"github.com/polarismesh/polaris-server/config/service"
+)
+
+const (
+	publishScheduleInterval = 10 * time.Second
+)
+
+// publishScheduler 定时发布到达计划时间的发布申请，每个节点都会调度，由存储层的状态修改保证只发布一次
+type publishScheduler struct {
+	service          service.ConfigFilePublishRequestAPI
+	scheduleInterval time.Duration
+}
+
+func startPublishScheduler(ctx context.Context, service service.ConfigFilePublishRequestAPI,
+	scheduleInterval time.Duration) {
+	scheduler := &publishScheduler{
+		service:          service,
+		scheduleInterval: scheduleInterval,
+	}
+	go scheduler.run(ctx)
+}
+
+func (s *publishScheduler) run(ctx context.Context) {
+	t := time.NewTicker(s.scheduleInterval)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			s.schedule(ctx)
+		}
+	}
+}
+
+func (s *publishScheduler) schedule(ctx context.Context) {
+	if published := s.service.PublishDueConfigFilePublishRequests(ctx, time.Now()); published > 0 {
+		log.ConfigScope().Info("[Config][PublishScheduler] publish due publish requests success.",
+			zap.Int("count", published))
+	}
+}
@@ -0,0 +1,86 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package config
+
+import (
+	"encoding/json"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/polarismesh/polaris-server/common/log"
+	"github.com/polarismesh/polaris-server/common/model"
+	"github.com/polarismesh/polaris-server/config/service"
+	"github.com/polarismesh/polaris-server/plugin"
+)
+
+const (
+	// releaseEventTopic 配置发布变更的集群事件
+	releaseEventTopic = "config.release"
+	// fallbackScanInterval 使用集群事件通知时，兜底扫描配置发布的间隔
+	fallbackScanInterval = 30 * time.Second
+)
+
+// releaseEvent 配置发布变更事件，节点收到后从存储加载最新的发布
+type releaseEvent struct {
+	Namespace string `json:"namespace"`
+	Group     string `json:"group"`
+	FileName  string `json:"fileName"`
+}
+
+// newReleaseNotifier 发布节点把配置发布变更写入事件总线
+func newReleaseNotifier(bus plugin.EventBus) service.ReleaseNotifier {
+	return func(namespace, group, fileName string) {
+		payload, _ := json.Marshal(&releaseEvent{Namespace: namespace, Group: group, FileName: fileName})
+		if err := bus.Publish(releaseEventTopic, payload); err != nil {
+			log.ConfigScope().Error("[Config][Notifier] publish release event error.",
+				zap.String("namespace", namespace),
+				zap.String("group", group),
+				zap.String("fileName", fileName),
+				zap.Error(err))
+		}
+	}
+}
+
+// onReleaseEvent 收到配置发布变更事件，加载正式发布和灰度发布并广播给本节点的订阅者
+func (s *releaseMessageScanner) onReleaseEvent(payload []byte) {
+	event := &releaseEvent{}
+	if err := json.Unmarshal(payload, event); err != nil {
+		log.ConfigScope().Error("[Config][Notifier] invalid release event.", zap.Error(err))
+		return
+	}
+	release, err := s.storage.GetConfigFileReleaseWithAllFlag(nil, event.Namespace, event.Group, event.FileName)
+	if err != nil {
+		log.ConfigScope().Error("[Config][Notifier] get config file release error.", zap.Error(err))
+		return
+	}
+	grayRelease, err := s.storage.GetConfigFileGrayReleaseWithAllFlag(nil, event.Namespace, event.Group, event.FileName)
+	if err != nil {
+		log.ConfigScope().Error("[Config][Notifier] get config file gray release error.", zap.Error(err))
+		return
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	// 正式发布先于灰度发布处理，和扫描的顺序一致
+	for _, r := range []*model.ConfigFileRelease{release, grayRelease} {
+		if r != nil {
+			s.handlerRelease(false, r)
+		}
+	}
+}
@@ -19,6 +19,7 @@ package config
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	"go.uber.org/zap"
@@ -46,10 +47,13 @@ type releaseMessageScanner struct {
 	fileCache *cache.FileCache
 
 	eventCenter *Center
+
+	// lock 定时扫描和集群事件通知都会处理发布，串行处理
+	lock sync.Mutex
 }
 
 func initReleaseMessageScanner(ctx context.Context, storage store.Store, fileCache *cache.FileCache,
-	eventCenter *Center, scanInterval time.Duration) error {
+	eventCenter *Center, scanInterval time.Duration) (*releaseMessageScanner, error) {
 	scanner := &releaseMessageScanner{
 		storage:      storage,
 		fileCache:    fileCache,
@@ -59,19 +63,19 @@ func initReleaseMessageScanner(ctx context.Context, storage store.Store, fileCac
 
 	err := scanner.scanAtFirstTime()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	go scanner.startScanTask(ctx)
 
-	return nil
+	return scanner, nil
 }
 
 func (s *releaseMessageScanner) scanAtFirstTime() error {
 	t := time.Now().Add(FirstScanTimeOffset)
 	s.lastScannerTime = t
 
-	releases, err := s.storage.FindConfigFileReleaseByModifyTimeAfter(t)
+	releases, err := s.scanReleases(t)
 	if err != nil {
 		log.ConfigScope().Error("[Config][Scanner] scan config file release error.", zap.Error(err))
 		return err
@@ -102,7 +106,7 @@ func (s *releaseMessageScanner) startScanTask(ctx context.Context) {
 		case <-t.C:
 			// 为了避免丢失消息，扫描发布消息的时间点往前拨10s。因为处理消息是幂等的，所以即使捞出重复消息也能够正常处理
 			scanIdx := s.lastScannerTime.Add(DefaultScanTimeOffset)
-			releases, err := s.storage.FindConfigFileReleaseByModifyTimeAfter(scanIdx)
+			releases, err := s.scanReleases(scanIdx)
 
 			if err != nil {
 				log.ConfigScope().Error("[Config][Scanner] scan config file release error.", zap.Error(err))
@@ -117,11 +121,27 @@ func (s *releaseMessageScanner) startScanTask(ctx context.Context) {
 	}
 }
 
+// scanReleases 扫描正式发布和灰度发布，正式发布排在前面，保证先处理正式发布
+func (s *releaseMessageScanner) scanReleases(t time.Time) ([]*model.ConfigFileRelease, error) {
+	releases, err := s.storage.FindConfigFileReleaseByModifyTimeAfter(t)
+	if err != nil {
+		return nil, err
+	}
+	grayReleases, err := s.storage.FindConfigFileGrayReleaseByModifyTimeAfter(t)
+	if err != nil {
+		return nil, err
+	}
+	return append(releases, grayReleases...), nil
+}
+
 func (s *releaseMessageScanner) handlerReleases(firstTime bool, releases []*model.ConfigFileRelease) error {
 	if len(releases) == 0 {
 		return nil
 	}
 
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
 	maxModifyTime := s.lastScannerTime
 	newReleaseCnt := 0
 
@@ -130,30 +150,7 @@ func (s *releaseMessageScanner) handlerReleases(firstTime bool, releases []*mode
 			maxModifyTime = release.ModifyTime
 			newReleaseCnt++
 		}
-
-		entry, ok := s.fileCache.Get(release.Namespace, release.Group, release.FileName)
-
-		// 缓存不存在，或者缓存的版本号落后数据库的版本号则处理消息. 因为有版本号判断，所以能够幂等处理重复消息
-		if !ok || entry.Empty || release.Version > entry.Version {
-			if release.Flag == 1 {
-				// 删除的发布消息，因为缓存被清除了，所以会一直判断为新消息，所以通过判断消息是否过期来避免一直重复消费
-				if isExpireMessage(release) {
-					continue
-				}
-				// 删除配置文件，删除缓存
-				s.fileCache.Remove(release.Namespace, release.Group, release.FileName)
-			} else {
-				// 正常配置发布，更新缓存
-				_, _ = s.fileCache.ReLoad(release.Namespace, release.Group, release.FileName)
-			}
-
-			if !firstTime && !isExpireMessage(release) {
-				s.eventCenter.handleEvent(Event{
-					EventType: eventTypePublishConfigFile,
-					Message:   release,
-				})
-			}
-		}
+		s.handlerRelease(firstTime, release)
 	}
 
 	s.lastScannerTime = maxModifyTime
@@ -165,6 +162,50 @@ func (s *releaseMessageScanner) handlerReleases(firstTime bool, releases []*mode
 	return nil
 }
 
+// handlerRelease 刷新缓存并广播发布事件
+func (s *releaseMessageScanner) handlerRelease(firstTime bool, release *model.ConfigFileRelease) {
+	entry, ok := s.fileCache.Get(release.Namespace, release.Group, release.FileName)
+
+	// 缓存不存在，或者缓存的版本号落后数据库的版本号则处理消息. 因为有版本号判断，所以能够幂等处理重复消息
+	if !ok || entry.Empty || isNewRelease(release, entry) {
+		if release.GrayRule != nil {
+			// 灰度发布的变更只需要刷新缓存，结束灰度的消息同样通过过期时间避免重复消费
+			if release.Flag == 1 && isExpireMessage(release) {
+				return
+			}
+			_, _ = s.fileCache.ReLoad(release.Namespace, release.Group, release.FileName)
+		} else if release.Flag == 1 {
+			// 删除的发布消息，因为缓存被清除了，所以会一直判断为新消息，所以通过判断消息是否过期来避免一直重复消费
+			if isExpireMessage(release) {
+				return
+			}
+			// 删除配置文件，删除缓存
+			s.fileCache.Remove(release.Namespace, release.Group, release.FileName)
+		} else {
+			// 正常配置发布，更新缓存
+			_, _ = s.fileCache.ReLoad(release.Namespace, release.Group, release.FileName)
+		}
+
+		if !firstTime && !isExpireMessage(release) {
+			s.eventCenter.handleEvent(Event{
+				EventType: eventTypePublishConfigFile,
+				Message:   release,
+			})
+		}
+	}
+}
+
+// isNewRelease 判断发布是否比缓存新，灰度发布和缓存中的灰度版本比较
+func isNewRelease(release *model.ConfigFileRelease, entry *cache.Entry) bool {
+	if release.GrayRule == nil {
+		return release.Version > entry.Version
+	}
+	if release.Flag == 1 {
+		return entry.Gray != nil
+	}
+	return entry.Gray == nil || release.Version > entry.Gray.Version
+}
+
 func isExpireMessage(release *model.ConfigFileRelease) bool {
 	return release.ModifyTime.Before(time.Now().Add(MessageExpireTime))
 }
@@ -25,9 +25,11 @@ import (
 
 	"go.uber.org/zap"
 
+	"github.com/polarismesh/polaris-server/auth"
 	"github.com/polarismesh/polaris-server/cache"
 	"github.com/polarismesh/polaris-server/common/log"
 	"github.com/polarismesh/polaris-server/config/service"
+	"github.com/polarismesh/polaris-server/plugin"
 	"github.com/polarismesh/polaris-server/store"
 )
 
@@ -46,6 +48,8 @@ var (
 type StartupConfig struct {
 	Open  bool                   `yaml:"open"`
 	Cache map[string]interface{} `yaml:"cache"`
+	// AllowAnonymousDecrypt 未开启客户端鉴权时是否向客户端返回加密配置的明文，默认不返回
+	AllowAnonymousDecrypt bool `yaml:"allowAnonymousDecrypt"`
 }
 
 // Server 配置中心核心服务
@@ -102,24 +106,49 @@ func initialize(ctx context.Context, config StartupConfig) error {
 	fileCache := cache.NewFileCache(ctx, storage, cacheParam)
 	server.cache = fileCache
 
-	// 3. 初始化 service 模块
-	serviceImpl := service.NewServiceImpl(storage, fileCache)
+	// 3. 初始化 service 模块，鉴权模块未初始化时只有配置了允许匿名解密，客户端才能获取加密配置的明文
+	var authChecker auth.AuthChecker
+	if authServer, err := auth.GetAuthServer(); err == nil {
+		authChecker = authServer.GetAuthChecker()
+	}
+	// 配置了集群事件总线时，发布节点立即通知其他节点，扫描器只用于兜底
+	scanInterval := time.Second
+	var releaseNotifier service.ReleaseNotifier
+	eventBus := plugin.GetEventBus()
+	if eventBus != nil {
+		scanInterval = fallbackScanInterval
+		releaseNotifier = newReleaseNotifier(eventBus)
+	}
+	serviceImpl := service.NewServiceImpl(storage, fileCache, authChecker, releaseNotifier,
+		config.AllowAnonymousDecrypt)
 	server.service = serviceImpl
 
 	// 4. 初始化事件中心
 	eventCenter := NewEventCenter()
-	server.watchCenter = NewWatchCenter(eventCenter)
+	server.watchCenter = NewWatchCenter(eventCenter, fileCache)
 
 	// 5. 初始化连接管理器
 	connMng := NewConfigConnManager(ctx, server.watchCenter)
 	server.connManager = connMng
 
 	// 6. 初始化发布事件扫描器
-	err = initReleaseMessageScanner(ctx, storage, fileCache, eventCenter, time.Second)
+	scanner, err := initReleaseMessageScanner(ctx, storage, fileCache, eventCenter, scanInterval)
 	if err != nil {
 		log.ConfigScope().Error("[Config][Server] init release message scanner error. ", zap.Error(err))
 		return errors.New("init config module error")
 	}
+	if eventBus != nil {
+		if err := eventBus.Subscribe(releaseEventTopic, scanner.onReleaseEvent); err != nil {
+			log.ConfigScope().Error("[Config][Server] subscribe release event error. ", zap.Error(err))
+			return errors.New("init config module error")
+		}
+	}
+
+	// 7. 定时上报本节点的配置订阅者
+	startListenerReporter(ctx, storage, server.watchCenter, listenerReportInterval)
+
+	// 8. 定时发布到达计划时间的发布申请
+	startPublishScheduler(ctx, serviceImpl, publishScheduleInterval)
 
 	log.ConfigScope().Infof("[Config][Server] startup config module success.")
 
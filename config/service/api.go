@@ -19,10 +19,14 @@ package service
 
 import (
 	"context"
+	"io"
+	"time"
 
+	"github.com/polarismesh/polaris-server/auth"
 	"github.com/polarismesh/polaris-server/cache"
 	api "github.com/polarismesh/polaris-server/common/api/v1"
 	"github.com/polarismesh/polaris-server/common/model"
+	"github.com/polarismesh/polaris-server/plugin"
 	"github.com/polarismesh/polaris-server/store"
 )
 
@@ -40,6 +44,9 @@ type API interface {
 	ConfigFileReleaseAPI
 	ConfigFileReleaseHistoryAPI
 	ConfigFileClientAPI
+	ConfigFileListenerAPI
+	ConfigFileImportExportAPI
+	ConfigFilePublishRequestAPI
 }
 
 // ConfigFileGroupAPI 配置文件组接口
@@ -94,6 +101,21 @@ type ConfigFileReleaseAPI interface {
 
 	// DeleteConfigFileRelease 删除配置文件发布内容
 	DeleteConfigFileRelease(ctx context.Context, namespace, group, fileName, deleteBy string) *api.ConfigResponse
+
+	// RollbackConfigFile 回滚配置文件到指定的历史发布
+	RollbackConfigFile(ctx context.Context, namespace, group, fileName string, historyId uint64) *api.ConfigResponse
+
+	// GrayPublishConfigFile 灰度发布配置文件，只有命中灰度规则的客户端能获取到灰度发布的内容
+	GrayPublishConfigFile(ctx context.Context, configFileRelease *api.ConfigFileRelease) *api.ConfigResponse
+
+	// GetConfigFileGrayRelease 获取配置文件生效中的灰度发布
+	GetConfigFileGrayRelease(ctx context.Context, namespace, group, fileName string) *api.ConfigResponse
+
+	// PromoteConfigFileGrayRelease 灰度发布转为全量发布
+	PromoteConfigFileGrayRelease(ctx context.Context, namespace, group, fileName, operator string) *api.ConfigResponse
+
+	// AbortConfigFileGrayRelease 放弃灰度发布
+	AbortConfigFileGrayRelease(ctx context.Context, namespace, group, fileName, operator string) *api.ConfigResponse
 }
 
 // ConfigFileReleaseHistoryAPI 配置文件发布历史接口
@@ -106,6 +128,12 @@ type ConfigFileReleaseHistoryAPI interface {
 
 	// GetConfigFileLatestReleaseHistory 获取最后一次发布记录
 	GetConfigFileLatestReleaseHistory(ctx context.Context, namespace, group, fileName string) *api.ConfigResponse
+
+	// DiffConfigFileReleaseHistory 比较两条发布历史记录的内容差异
+	DiffConfigFileReleaseHistory(ctx context.Context, fromId, toId uint64) *api.ConfigResponse
+
+	// DiffConfigFileDraft 比较配置文件当前内容和生效中发布内容的差异
+	DiffConfigFileDraft(ctx context.Context, namespace, group, fileName string) *api.ConfigResponse
 }
 
 // ConfigFileTagAPI 配置文件标签相关的接口
@@ -136,19 +164,97 @@ type ConfigFileClientAPI interface {
 
 	// GetConfigFileForClient 获取配置文件
 	GetConfigFileForClient(ctx context.Context, namespace, group, fileName string, clientVersion uint64) *api.ConfigClientResponse
+
+	// CheckClientWritePermission 校验客户端直接修改配置文件的权限，有权限时返回空
+	CheckClientWritePermission(ctx context.Context, namespace, group string, operation model.ResourceOperation,
+		method string) *api.ConfigResponse
+}
+
+// ConfigFileListenerAPI 配置文件订阅者接口
+type ConfigFileListenerAPI interface {
+	// QueryConfigFileListeners 查询所有节点上订阅配置文件的客户端，参数为空时不过滤
+	QueryConfigFileListeners(ctx context.Context, namespace, group, fileName string, offset,
+		limit uint32) *api.ConfigBatchQueryResponse
+}
+
+// ConfigFileImportExportAPI 配置文件导入导出接口
+type ConfigFileImportExportAPI interface {
+	// ExportConfigFiles 把配置文件导出为 zip 压缩包写入 w，group 为空时导出命名空间下的全部配置文件
+	ExportConfigFiles(ctx context.Context, namespace, group string, w io.Writer) *api.ConfigResponse
+
+	// ImportConfigFiles 从 zip 压缩包导入配置文件，conflictPolicy 为 skip、overwrite 或 fail，返回每个配置文件的导入结果，
+	// publish 为 true 时每个导入成功的配置文件还会返回一条携带 configFileRelease 的发布结果
+	ImportConfigFiles(ctx context.Context, namespace string, archive []byte, conflictPolicy string, publish bool,
+		operator string) *api.ConfigBatchWriteResponse
 }
 
+// ConfigFilePublishRequestAPI 配置发布申请接口，发布申请审批通过后手动发布或者到达计划时间后自动发布
+type ConfigFilePublishRequestAPI interface {
+	// CreateConfigFilePublishRequest 创建发布申请
+	CreateConfigFilePublishRequest(ctx context.Context,
+		publishRequest *api.ConfigFilePublishRequest) *api.ConfigResponse
+
+	// SubmitConfigFilePublishRequest 提交发布申请等待审批
+	SubmitConfigFilePublishRequest(ctx context.Context,
+		publishRequest *api.ConfigFilePublishRequest) *api.ConfigResponse
+
+	// ApproveConfigFilePublishRequest 审批通过发布申请
+	ApproveConfigFilePublishRequest(ctx context.Context,
+		publishRequest *api.ConfigFilePublishRequest) *api.ConfigResponse
+
+	// RejectConfigFilePublishRequest 驳回发布申请
+	RejectConfigFilePublishRequest(ctx context.Context,
+		publishRequest *api.ConfigFilePublishRequest) *api.ConfigResponse
+
+	// ScheduleConfigFilePublishRequest 设置发布申请的计划发布时间
+	ScheduleConfigFilePublishRequest(ctx context.Context,
+		publishRequest *api.ConfigFilePublishRequest) *api.ConfigResponse
+
+	// ExecuteConfigFilePublishRequest 立即发布审批通过的发布申请
+	ExecuteConfigFilePublishRequest(ctx context.Context,
+		publishRequest *api.ConfigFilePublishRequest) *api.ConfigResponse
+
+	// CancelConfigFilePublishRequest 取消发布申请
+	CancelConfigFilePublishRequest(ctx context.Context,
+		publishRequest *api.ConfigFilePublishRequest) *api.ConfigResponse
+
+	// GetConfigFilePublishRequest 获取发布申请
+	GetConfigFilePublishRequest(ctx context.Context, id string) *api.ConfigResponse
+
+	// QueryConfigFilePublishRequests 查询发布申请，参数为空时不过滤
+	QueryConfigFilePublishRequests(ctx context.Context, namespace, group, fileName, status string, offset,
+		limit uint32) *api.ConfigBatchQueryResponse
+
+	// PublishDueConfigFilePublishRequests 发布到达计划时间的发布申请，返回发布成功的数量
+	PublishDueConfigFilePublishRequests(ctx context.Context, now time.Time) int
+}
+
+// ReleaseNotifier 配置发布变更的通知函数，用于立即通知集群内的其他节点
+type ReleaseNotifier func(namespace, group, fileName string)
+
 // Impl 服务接口实现类
 type Impl struct {
 	API
-	storage store.Store
-	cache   *cache.FileCache
+	storage         store.Store
+	cache           *cache.FileCache
+	authChecker     auth.AuthChecker
+	releaseNotifier ReleaseNotifier
+	history         plugin.History
+	// allowAnonymousDecrypt 未开启客户端鉴权时是否允许客户端获取加密配置的明文
+	allowAnonymousDecrypt bool
 }
 
-// NewServiceImpl 新建配置中心服务实现类
-func NewServiceImpl(storage store.Store, cache *cache.FileCache) API {
+// NewServiceImpl 新建配置中心服务实现类，authChecker 为空时不校验客户端的访问凭证，
+// releaseNotifier 为空时由各节点定时扫描配置发布，
+// allowAnonymousDecrypt 为 false 时未开启客户端鉴权的客户端拿不到加密配置的明文
+func NewServiceImpl(storage store.Store, cache *cache.FileCache, authChecker auth.AuthChecker,
+	releaseNotifier ReleaseNotifier, allowAnonymousDecrypt bool) API {
 	return &Impl{
-		storage: storage,
-		cache:   cache,
+		storage:               storage,
+		cache:                 cache,
+		authChecker:           authChecker,
+		releaseNotifier:       releaseNotifier,
+		history:               plugin.GetHistory(),
+		allowAnonymousDecrypt: allowAnonymousDecrypt,
 	}
 }
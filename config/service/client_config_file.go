@@ -19,12 +19,14 @@ package service
 
 import (
 	"context"
+	"errors"
 
 	"go.uber.org/zap"
 
 	"github.com/polarismesh/polaris-server/cache"
 	api "github.com/polarismesh/polaris-server/common/api/v1"
 	"github.com/polarismesh/polaris-server/common/log"
+	"github.com/polarismesh/polaris-server/common/model"
 	"github.com/polarismesh/polaris-server/common/utils"
 	utils2 "github.com/polarismesh/polaris-server/config/utils"
 )
@@ -52,6 +54,7 @@ func (cs *Impl) doCheckClientConfigFile(ctx context.Context, configFiles []*api.
 	}
 
 	requestID, _ := ctx.Value(utils.StringContext("request-id")).(string)
+	clientIP := utils.ParseClientIP(ctx)
 
 	for _, configFile := range configFiles {
 		namespace := configFile.Namespace.GetValue()
@@ -74,6 +77,8 @@ func (cs *Impl) doCheckClientConfigFile(ctx context.Context, configFiles []*api.
 			return api.NewConfigClientResponse(api.ExecuteException, nil)
 		}
 
+		// 命中灰度规则的客户端和灰度发布比较
+		entry = entry.ForClient(clientIP, clientLabels(ctx, configFile))
 		if checkFunc(configFile, entry) {
 			return utils2.GenConfigFileResponse(namespace, group, fileName, "", entry.Md5, entry.Version)
 		}
@@ -82,7 +87,8 @@ func (cs *Impl) doCheckClientConfigFile(ctx context.Context, configFiles []*api.
 	return api.NewConfigClientResponse(api.DataNoChange, nil)
 }
 
-// GetConfigFileForClient 从缓存中获取配置文件，如果客户端的版本号大于服务端，则服务端重新加载缓存
+// GetConfigFileForClient 从缓存中获取配置文件，如果客户端的版本号大于服务端，则服务端重新加载缓存。
+// 客户端命中灰度规则时返回灰度发布的内容，加密的配置解密后返回给有权限的客户端
 func (cs *Impl) GetConfigFileForClient(ctx context.Context, namespace, group, fileName string, clientVersion uint64) *api.ConfigClientResponse {
 	if namespace == "" || group == "" || fileName == "" {
 		return api.NewConfigClientResponseWithMessage(api.BadRequest, "namespace & group & fileName can not be empty")
@@ -105,6 +111,9 @@ func (cs *Impl) GetConfigFileForClient(ctx context.Context, namespace, group, fi
 		return api.NewConfigClientResponse(api.NotFoundResource, nil)
 	}
 
+	clientIP, labels := utils.ParseClientIP(ctx), utils.ParseClientLabels(ctx)
+	entry = entry.ForClient(clientIP, labels)
+
 	// 客户端版本号大于服务端版本号，服务端需要重新加载缓存
 	if clientVersion > entry.Version {
 		entry, err = cs.cache.ReLoad(namespace, group, fileName)
@@ -115,7 +124,82 @@ func (cs *Impl) GetConfigFileForClient(ctx context.Context, namespace, group, fi
 
 			return api.NewConfigClientResponseWithMessage(api.ExecuteException, "load config file error")
 		}
+		entry = entry.ForClient(clientIP, labels)
+	}
+
+	content := entry.Content
+	if utils2.IsEncryptedContent(content) {
+		if !cs.canDecrypt(ctx, namespace, group) {
+			return api.NewConfigClientResponseWithMessage(api.NotAllowedAccess,
+				"encrypted config file requires read permission on the config group")
+		}
+		if content, err = cs.decryptContent(content); err != nil {
+			log.ConfigScope().Error("[Config][Service] decrypt config file error.",
+				zap.String("requestId", requestID),
+				zap.String("fileName", fileName),
+				zap.Error(err))
+
+			return api.NewConfigClientResponseWithMessage(api.ConfigFileCryptoException, "decrypt config file error")
+		}
+	}
+
+	return utils2.GenConfigFileResponse(namespace, group, fileName, content, entry.Md5, entry.Version)
+}
+
+// CheckClientWritePermission 兼容其他协议的客户端直接修改配置文件时，通过鉴权模块校验客户端权限，
+// 鉴权模块未初始化或者未开启客户端鉴权时放通；配置分组不存在时只校验命名空间的权限
+func (cs *Impl) CheckClientWritePermission(ctx context.Context, namespace, group string,
+	operation model.ResourceOperation, method string) *api.ConfigResponse {
+	if cs.authChecker == nil || !cs.authChecker.IsOpenClientAuth() {
+		return nil
+	}
+
+	resources, err := cs.configGroupResources(namespace, group)
+	if err != nil {
+		log.ConfigScope().Error("[Config][Service] collect config group resources error.",
+			zap.String("namespace", namespace), zap.String("group", group), zap.Error(err))
+		return api.NewConfigFileResponse(api.StoreLayerException, nil)
+	}
+	if resources == nil {
+		ns, err := cs.storage.GetNamespace(namespace)
+		if err != nil {
+			return api.NewConfigFileResponse(api.StoreLayerException, nil)
+		}
+		if ns == nil {
+			return api.NewConfigFileResponse(api.NotFoundNamespace, nil)
+		}
+		resources = map[api.ResourceType][]model.ResourceEntry{
+			api.ResourceType_Namespaces: {
+				{ID: ns.Name, Owner: ns.Owner},
+			},
+		}
+	}
+
+	authCtx := model.NewAcquireContext(
+		model.WithRequestContext(ctx),
+		model.WithOperation(operation),
+		model.WithToken(utils.ParseAuthToken(ctx)),
+		model.WithModule(model.ConfigModule),
+		model.WithMethod(method),
+		model.WithAccessResources(resources),
+		model.WithFromClient(),
+	)
+	if _, err := cs.authChecker.CheckClientPermission(authCtx); err != nil {
+		code := api.NotAllowedAccess
+		if errors.Is(err, model.ErrorTokenNotExist) {
+			code = api.TokenNotExisted
+		} else if errors.Is(err, model.ErrorTokenDisabled) {
+			code = api.TokenDisabled
+		}
+		return api.NewConfigFileResponseWithMessage(code, err.Error())
 	}
+	return nil
+}
 
-	return utils2.GenConfigFileResponse(namespace, group, fileName, entry.Content, entry.Md5, entry.Version)
+// clientLabels 获取客户端标签，优先使用配置文件上携带的标签
+func clientLabels(ctx context.Context, configFile *api.ClientConfigFileInfo) map[string]string {
+	if len(configFile.GetLabels()) > 0 {
+		return configFile.GetLabels()
+	}
+	return utils.ParseClientLabels(ctx)
 }
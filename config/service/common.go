@@ -47,6 +47,14 @@ func (cs *Impl) getTx(ctx context.Context) store.Tx {
 	return tx.(store.Tx)
 }
 
+// notifyRelease 通知配置发布变更，事务内的变更由开启事务的地方在提交后通知
+func (cs *Impl) notifyRelease(ctx context.Context, namespace, group, fileName string) {
+	if cs.releaseNotifier == nil || cs.getTx(ctx) != nil {
+		return
+	}
+	cs.releaseNotifier(namespace, group, fileName)
+}
+
 func (cs *Impl) checkNamespaceExisted(namespaceName string) bool {
 	namespace, _ := cs.storage.GetNamespace(namespaceName)
 	return namespace != nil
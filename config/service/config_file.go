@@ -42,12 +42,21 @@ func (cs *Impl) CreateConfigFile(ctx context.Context, configFile *api.ConfigFile
 		return checkRsp
 	}
 
+	if checkRsp := checkConfigFileTemplate(transferConfigFileAPIModel2StoreModel(configFile)); checkRsp != nil {
+		return checkRsp
+	}
+
 	namespace := configFile.Namespace.GetValue()
 	group := configFile.Group.GetValue()
 	name := configFile.Name.GetValue()
 
 	requestID, _ := ctx.Value(utils.StringContext("request-id")).(string)
 
+	if checkRsp := cs.checkConfigFileContent(namespace, group, configFile.Format.GetValue(),
+		configFile.Content.GetValue(), requestID); checkRsp != nil {
+		return checkRsp
+	}
+
 	// 如果 namespace 不存在则自动创建
 	if err := cs.createNamespaceIfAbsent(namespace, configFile.CreateBy.GetValue(), requestID); err != nil {
 		log.ConfigScope().Error("[Config][Service] create config file error because of create namespace failed.",
@@ -91,6 +100,10 @@ func (cs *Impl) CreateConfigFile(ctx context.Context, configFile *api.ConfigFile
 	fileStoreModel := transferConfigFileAPIModel2StoreModel(configFile)
 	fileStoreModel.ModifyBy = fileStoreModel.CreateBy
 
+	if rsp := cs.encryptConfigFile(fileStoreModel, requestID); rsp != nil {
+		return rsp
+	}
+
 	// 创建配置文件
 	createdFile, err := cs.storage.CreateConfigFile(cs.getTx(ctx), fileStoreModel)
 	if err != nil {
@@ -293,6 +306,43 @@ func (cs *Impl) UpdateConfigFile(ctx context.Context, configFile *api.ConfigFile
 	if configFile.Format.GetValue() == "" {
 		toUpdateFile.Format = managedFile.Format
 	}
+	if configFile.GetEncrypted() == nil {
+		toUpdateFile.Encrypted = managedFile.Encrypted
+	}
+	// 没有传入模板引用时沿用原来的引用，传入空字符串表示取消引用
+	if configFile.GetBaseFile() == nil {
+		toUpdateFile.BaseFile = managedFile.BaseFile
+	}
+	if configFile.GetVariablesFile() == nil {
+		toUpdateFile.VariablesFile = managedFile.VariablesFile
+	}
+	if checkRsp := checkConfigFileTemplate(toUpdateFile); checkRsp != nil {
+		return checkRsp
+	}
+
+	// 控制台拿到的加密配置内容是掩码，内容没有修改时沿用原来的内容
+	if managedFile.Encrypted && toUpdateFile.Content == utils2.MaskedContent {
+		plainContent, err := cs.decryptContent(managedFile.Content)
+		if err != nil {
+			log.ConfigScope().Error("[Config][Service] decrypt config file error.",
+				zap.String("request-id", requestID),
+				zap.String("namespace", namespace),
+				zap.String("group", group),
+				zap.String("name", name),
+				zap.Error(err))
+			return api.NewConfigFileResponseWithMessage(api.ConfigFileCryptoException, err.Error())
+		}
+		toUpdateFile.Content = plainContent
+	}
+
+	if checkRsp := cs.checkConfigFileContent(namespace, group, toUpdateFile.Format, toUpdateFile.Content,
+		requestID); checkRsp != nil {
+		return checkRsp
+	}
+
+	if rsp := cs.encryptConfigFile(toUpdateFile, requestID); rsp != nil {
+		return rsp
+	}
 
 	updatedFile, err := cs.storage.UpdateConfigFile(cs.getTx(ctx), toUpdateFile)
 	if err != nil {
@@ -363,7 +413,19 @@ func (cs *Impl) DeleteConfigFile(ctx context.Context, namespace, group, name, de
 		return api.NewConfigFileResponse(deleteFileReleaseRsp.Code.GetValue(), nil)
 	}
 
-	// 2. 删除配置文件
+	// 2. 删除配置文件的灰度发布
+	err = cs.deleteConfigFileGrayRelease(tx, namespace, group, name, deleteBy)
+	if err != nil {
+		log.ConfigScope().Error("[Config][Service] delete config file gray release error.",
+			zap.String("request-id", requestID),
+			zap.String("namespace", namespace),
+			zap.String("group", group),
+			zap.String("name", name),
+			zap.Error(err))
+		return api.NewConfigFileResponse(api.StoreLayerException, nil)
+	}
+
+	// 3. 删除配置文件
 	err = cs.storage.DeleteConfigFile(tx, namespace, group, name)
 	if err != nil {
 		log.ConfigScope().Error("[Config][Service] delete config file error.",
@@ -375,7 +437,7 @@ func (cs *Impl) DeleteConfigFile(ctx context.Context, namespace, group, name, de
 		return api.NewConfigFileResponse(api.StoreLayerException, nil)
 	}
 
-	// 3. 删除配置文件关联的 tag
+	// 4. 删除配置文件关联的 tag
 	err = cs.DeleteTagByConfigFile(newCtx, namespace, group, name)
 	if err != nil {
 		log.ConfigScope().Error("[Config][Service] delete config file tags error.",
@@ -397,6 +459,7 @@ func (cs *Impl) DeleteConfigFile(ctx context.Context, namespace, group, name, de
 			zap.Error(err))
 		return api.NewConfigFileResponse(api.StoreLayerException, nil)
 	}
+	cs.notifyRelease(ctx, namespace, group, name)
 
 	return api.NewConfigFileResponse(api.ExecuteSuccess, nil)
 }
@@ -447,6 +510,54 @@ func checkConfigFileParams(configFile *api.ConfigFile, checkFormat bool) *api.Co
 	return nil
 }
 
+// checkConfigFileContent 按照配置格式校验配置内容，配置文件组设置了 JSON Schema 时同时校验内容是否满足约束
+func (cs *Impl) checkConfigFileContent(namespace, group, format, content, requestID string) *api.ConfigResponse {
+	if err := utils2.CheckContentFormat(format, content); err != nil {
+		return api.NewConfigFileResponseWithMessage(api.InvalidConfigFileContent, err.Error())
+	}
+
+	fileGroup, err := cs.storage.GetConfigFileGroup(namespace, group)
+	if err != nil {
+		log.ConfigScope().Error("[Config][Service] get config file group error.",
+			zap.String("request-id", requestID),
+			zap.String("namespace", namespace),
+			zap.String("group", group),
+			zap.Error(err))
+		return api.NewConfigFileResponse(api.StoreLayerException, nil)
+	}
+	if fileGroup == nil || fileGroup.JsonSchema == "" {
+		return nil
+	}
+
+	schema, err := utils2.ParseJsonSchema(fileGroup.JsonSchema)
+	if err != nil {
+		return api.NewConfigFileResponseWithMessage(api.InvalidConfigFileSchema, err.Error())
+	}
+	if err := schema.ValidateContent(format, content); err != nil {
+		return api.NewConfigFileResponseWithMessage(api.InvalidConfigFileContent, err.Error())
+	}
+	return nil
+}
+
+// encryptConfigFile 配置文件需要加密时，把明文内容替换为加密后的内容
+func (cs *Impl) encryptConfigFile(file *model.ConfigFile, requestID string) *api.ConfigResponse {
+	if !file.Encrypted {
+		return nil
+	}
+	content, err := cs.encryptContent(file.Content)
+	if err != nil {
+		log.ConfigScope().Error("[Config][Service] encrypt config file error.",
+			zap.String("request-id", requestID),
+			zap.String("namespace", file.Namespace),
+			zap.String("group", file.Group),
+			zap.String("name", file.Name),
+			zap.Error(err))
+		return api.NewConfigFileResponseWithMessage(api.ConfigFileCryptoException, err.Error())
+	}
+	file.Content = content
+	return nil
+}
+
 func transferConfigFileAPIModel2StoreModel(file *api.ConfigFile) *model.ConfigFile {
 	var comment string
 	if file.Comment != nil {
@@ -465,13 +576,16 @@ func transferConfigFileAPIModel2StoreModel(file *api.ConfigFile) *model.ConfigFi
 		format = file.Format.Value
 	}
 	return &model.ConfigFile{
-		Name:      file.Name.GetValue(),
-		Namespace: file.Namespace.GetValue(),
-		Group:     file.Group.GetValue(),
-		Content:   content,
-		Comment:   comment,
-		Format:    format,
-		CreateBy:  createBy,
+		Name:          file.Name.GetValue(),
+		Namespace:     file.Namespace.GetValue(),
+		Group:         file.Group.GetValue(),
+		Content:       content,
+		Comment:       comment,
+		Format:        format,
+		Encrypted:     file.GetEncrypted().GetValue(),
+		CreateBy:      createBy,
+		BaseFile:      file.GetBaseFile().GetValue(),
+		VariablesFile: file.GetVariablesFile().GetValue(),
 	}
 }
 
@@ -480,17 +594,20 @@ func transferConfigFileStoreModel2APIModel(file *model.ConfigFile) *api.ConfigFi
 		return nil
 	}
 	return &api.ConfigFile{
-		Id:         utils.NewUInt64Value(file.Id),
-		Name:       utils.NewStringValue(file.Name),
-		Namespace:  utils.NewStringValue(file.Namespace),
-		Group:      utils.NewStringValue(file.Group),
-		Content:    utils.NewStringValue(file.Content),
-		Comment:    utils.NewStringValue(file.Comment),
-		Format:     utils.NewStringValue(file.Format),
-		CreateBy:   utils.NewStringValue(file.CreateBy),
-		CreateTime: utils.NewStringValue(time.Time2String(file.CreateTime)),
-		ModifyBy:   utils.NewStringValue(file.ModifyBy),
-		ModifyTime: utils.NewStringValue(time.Time2String(file.ModifyTime)),
+		Id:            utils.NewUInt64Value(file.Id),
+		Name:          utils.NewStringValue(file.Name),
+		Namespace:     utils.NewStringValue(file.Namespace),
+		Group:         utils.NewStringValue(file.Group),
+		Content:       utils.NewStringValue(utils2.MaskContent(file.Content)),
+		Comment:       utils.NewStringValue(file.Comment),
+		Format:        utils.NewStringValue(file.Format),
+		Encrypted:     utils.NewBoolValue(file.Encrypted),
+		BaseFile:      utils.NewStringValue(file.BaseFile),
+		VariablesFile: utils.NewStringValue(file.VariablesFile),
+		CreateBy:      utils.NewStringValue(file.CreateBy),
+		CreateTime:    utils.NewStringValue(time.Time2String(file.CreateTime)),
+		ModifyBy:      utils.NewStringValue(file.ModifyBy),
+		ModifyTime:    utils.NewStringValue(time.Time2String(file.ModifyTime)),
 	}
 }
 
@@ -539,7 +656,16 @@ func (cs *Impl) enrich(ctx context.Context, baseConfigFile *api.ConfigFile, requ
 		baseConfigFile.ReleaseTime = latestRelease.CreateTime
 
 		// 如果最后一次发布的内容和当前文件内容一致，则展示最后一次发布状态。否则说明文件有修改，待发布
-		if latestRelease.Content.GetValue() == baseConfigFile.Content.GetValue() {
+		released := latestRelease.Content.GetValue() == baseConfigFile.Content.GetValue()
+		if baseConfigFile.GetEncrypted().GetValue() || latestRelease.GetEncrypted().GetValue() {
+			released = cs.isReleasedContent(ctx, namespace, group, name, latestRelease.Md5.GetValue())
+		}
+		// 模板配置发布的是渲染后的内容，使用发布前的模板内容比较
+		isTemplate := baseConfigFile.GetBaseFile().GetValue() != "" || baseConfigFile.GetVariablesFile().GetValue() != ""
+		if isTemplate && latestRelease.GetTemplateContent().GetValue() != "" {
+			released = latestRelease.GetTemplateContent().GetValue() == baseConfigFile.Content.GetValue()
+		}
+		if released {
 			baseConfigFile.Status = latestRelease.Status
 		} else {
 			baseConfigFile.Status = utils.NewStringValue(utils.ReleaseStatusToRelease)
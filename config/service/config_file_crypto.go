@@ -0,0 +1,116 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package service
+
+import (
+	"context"
+	"strconv"
+
+	"go.uber.org/zap"
+
+	"github.com/polarismesh/polaris-server/auth"
+	api "github.com/polarismesh/polaris-server/common/api/v1"
+	"github.com/polarismesh/polaris-server/common/log"
+	"github.com/polarismesh/polaris-server/common/model"
+	"github.com/polarismesh/polaris-server/common/utils"
+	utils2 "github.com/polarismesh/polaris-server/config/utils"
+	"github.com/polarismesh/polaris-server/plugin"
+)
+
+// encryptContent 使用密钥管理插件生成的数据密钥加密配置内容
+func (cs *Impl) encryptContent(content string) (string, error) {
+	return utils2.EncryptContent(plugin.GetKMS(), content)
+}
+
+// decryptContent 解密配置内容，未加密的内容原样返回
+func (cs *Impl) decryptContent(content string) (string, error) {
+	return utils2.DecryptContent(plugin.GetKMS(), content)
+}
+
+// contentMd5 计算配置内容的 md5，加密的内容按照明文计算，保证客户端拿到的内容和 md5 一致
+func (cs *Impl) contentMd5(content string) (string, error) {
+	plainContent, err := cs.decryptContent(content)
+	if err != nil {
+		return "", err
+	}
+	return utils2.CalMd5(plainContent), nil
+}
+
+// canDecrypt 判断客户端能否获取加密配置的明文
+// 未开启客户端鉴权时按照 allowAnonymousDecrypt 配置决定；开启时 token 需要对配置所在的命名空间和分组有读权限
+func (cs *Impl) canDecrypt(ctx context.Context, namespace, group string) bool {
+	if cs.authChecker == nil || !cs.authChecker.IsOpenClientAuth() {
+		return cs.allowAnonymousDecrypt
+	}
+	checker, ok := cs.authChecker.(auth.ReadPermissionChecker)
+	if !ok {
+		return false
+	}
+	resources, err := cs.configGroupResources(namespace, group)
+	if err != nil || resources == nil {
+		return false
+	}
+
+	authCtx := model.NewAcquireContext(
+		model.WithRequestContext(ctx),
+		model.WithOperation(model.Read),
+		model.WithToken(utils.ParseAuthToken(ctx)),
+		model.WithModule(model.ConfigModule),
+		model.WithMethod("GetConfigFileForClient"),
+		model.WithAccessResources(resources),
+		model.WithFromClient(),
+	)
+	allowed, err := checker.CheckReadPermission(authCtx)
+	if err != nil {
+		log.ConfigScope().Warn("[Config][Service] check read permission of encrypted config file.",
+			zap.String("namespace", namespace), zap.String("group", group), zap.Error(err))
+		return false
+	}
+	return allowed
+}
+
+// configGroupResources 收集配置分组的鉴权资源，配置分组没有负责人，沿用命名空间的负责人
+// 命名空间或者分组不存在时返回空
+func (cs *Impl) configGroupResources(namespace, group string) (map[api.ResourceType][]model.ResourceEntry, error) {
+	ns, err := cs.storage.GetNamespace(namespace)
+	if err != nil || ns == nil {
+		return nil, err
+	}
+	fileGroup, err := cs.storage.GetConfigFileGroup(namespace, group)
+	if err != nil || fileGroup == nil {
+		return nil, err
+	}
+	return map[api.ResourceType][]model.ResourceEntry{
+		api.ResourceType_Namespaces: {
+			{ID: ns.Name, Owner: ns.Owner},
+		},
+		api.ResourceType_ConfigGroups: {
+			{ID: strconv.FormatUint(fileGroup.Id, 10), Owner: ns.Owner},
+		},
+	}, nil
+}
+
+// isReleasedContent 加密配置返回给控制台的内容是掩码，通过明文的 md5 判断当前内容是否已经发布
+func (cs *Impl) isReleasedContent(ctx context.Context, namespace, group, name, releaseMd5 string) bool {
+	file, err := cs.storage.GetConfigFile(cs.getTx(ctx), namespace, group, name)
+	if err != nil || file == nil {
+		return false
+	}
+	md5, err := cs.contentMd5(file.Content)
+	return err == nil && md5 == releaseMd5
+}
@@ -0,0 +1,329 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package service
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	api "github.com/polarismesh/polaris-server/common/api/v1"
+	"github.com/polarismesh/polaris-server/common/log"
+	"github.com/polarismesh/polaris-server/common/model"
+	"github.com/polarismesh/polaris-server/common/utils"
+	utils2 "github.com/polarismesh/polaris-server/config/utils"
+	"github.com/polarismesh/polaris-server/store"
+)
+
+// GrayPublishConfigFile 灰度发布配置文件，命中灰度规则的客户端获取灰度内容，其余客户端继续使用正式发布的内容
+func (cs *Impl) GrayPublishConfigFile(ctx context.Context, configFileRelease *api.ConfigFileRelease) *api.ConfigResponse {
+	namespace := configFileRelease.Namespace.GetValue()
+	group := configFileRelease.Group.GetValue()
+	fileName := configFileRelease.FileName.GetValue()
+
+	if rsp := checkReleaseParams(namespace, group, fileName); rsp != nil {
+		return rsp
+	}
+
+	grayRule := transferGrayRuleAPIModel2StoreModel(configFileRelease.GrayRule)
+	if grayRule == nil || (len(grayRule.ClientIPs) == 0 && len(grayRule.ClientLabels) == 0) {
+		return api.NewConfigFileResponse(api.InvalidConfigFileGrayRule, nil)
+	}
+
+	if !cs.checkNamespaceExisted(namespace) {
+		return api.NewConfigFileReleaseResponse(api.NotFoundNamespace, configFileRelease)
+	}
+
+	requestID, _ := ctx.Value(utils.StringContext("request-id")).(string)
+
+	tx, newCtx, err := cs.StartTxAndSetToContext(ctx)
+	if err != nil {
+		log.ConfigScope().Error("[Config][Service] start tx error when gray publish config file.",
+			zap.String("request-id", requestID),
+			zap.Error(err))
+		return api.NewConfigFileResponse(api.StoreLayerException, nil)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	toPublishFile, err := cs.storage.GetConfigFile(tx, namespace, group, fileName)
+	if err != nil {
+		logReleaseError("get config file error.", requestID, namespace, group, fileName, err)
+		return api.NewConfigFileResponse(api.StoreLayerException, nil)
+	}
+	if toPublishFile == nil {
+		return api.NewConfigFileResponse(api.NotFoundResource, nil)
+	}
+
+	content, plainContent, renderRsp := cs.renderConfigFile(tx, toPublishFile, requestID)
+	if renderRsp != nil {
+		return renderRsp
+	}
+
+	if checkRsp := cs.checkConfigFileContent(namespace, group, toPublishFile.Format, plainContent,
+		requestID); checkRsp != nil {
+		return checkRsp
+	}
+
+	// 未命中灰度规则的客户端需要使用正式发布的内容，所以必须先有正式发布
+	mainRelease, err := cs.storage.GetConfigFileRelease(tx, namespace, group, fileName)
+	if err != nil {
+		logReleaseError("get config file release error.", requestID, namespace, group, fileName, err)
+		return api.NewConfigFileResponse(api.StoreLayerException, nil)
+	}
+	if mainRelease == nil {
+		return api.NewConfigFileResponse(api.NotReleasedConfigFile, nil)
+	}
+
+	managedGrayRelease, err := cs.storage.GetConfigFileGrayReleaseWithAllFlag(tx, namespace, group, fileName)
+	if err != nil {
+		logReleaseError("get config file gray release error.", requestID, namespace, group, fileName, err)
+		return api.NewConfigFileResponse(api.StoreLayerException, nil)
+	}
+
+	releaseName := configFileRelease.Name.GetValue()
+	if releaseName == "" {
+		releaseName = utils2.GenReleaseName(mainRelease.Name, fileName)
+	}
+
+	grayRelease := &model.ConfigFileRelease{
+		Name:      releaseName,
+		Namespace: namespace,
+		Group:     group,
+		FileName:  fileName,
+		Content:   content,
+		Comment:   configFileRelease.Comment.GetValue(),
+		Md5:       utils2.CalMd5(plainContent),
+		Version:   nextReleaseVersion(mainRelease, managedGrayRelease),
+		CreateBy:  configFileRelease.CreateBy.GetValue(),
+		ModifyBy:  configFileRelease.CreateBy.GetValue(),
+		GrayRule:  grayRule,
+	}
+
+	var savedRelease *model.ConfigFileRelease
+	if managedGrayRelease == nil {
+		savedRelease, err = cs.storage.CreateConfigFileGrayRelease(tx, grayRelease)
+	} else {
+		savedRelease, err = cs.storage.UpdateConfigFileGrayRelease(tx, grayRelease)
+	}
+	if err != nil {
+		logReleaseError("save config file gray release error.", requestID, namespace, group, fileName, err)
+		return api.NewConfigFileResponse(api.StoreLayerException, nil)
+	}
+
+	cs.recordReleaseHistory(newCtx, savedRelease, templateContent(toPublishFile), utils.ReleaseTypeGray,
+		utils.ReleaseStatusSuccess)
+
+	if err := tx.Commit(); err != nil {
+		logReleaseError("commit gray publish tx error.", requestID, namespace, group, fileName, err)
+		return api.NewConfigFileResponse(api.StoreLayerException, nil)
+	}
+	cs.notifyRelease(ctx, namespace, group, fileName)
+
+	return api.NewConfigFileReleaseResponse(api.ExecuteSuccess, transferConfigFileReleaseStoreModel2APIModel(savedRelease))
+}
+
+// GetConfigFileGrayRelease 获取配置文件生效中的灰度发布
+func (cs *Impl) GetConfigFileGrayRelease(ctx context.Context, namespace, group, fileName string) *api.ConfigResponse {
+	if rsp := checkReleaseParams(namespace, group, fileName); rsp != nil {
+		return rsp
+	}
+
+	grayRelease, err := cs.storage.GetConfigFileGrayRelease(cs.getTx(ctx), namespace, group, fileName)
+	if err != nil {
+		requestID, _ := ctx.Value(utils.StringContext("request-id")).(string)
+		logReleaseError("get config file gray release error.", requestID, namespace, group, fileName, err)
+		return api.NewConfigFileResponse(api.StoreLayerException, nil)
+	}
+
+	return api.NewConfigFileReleaseResponse(api.ExecuteSuccess, transferConfigFileReleaseStoreModel2APIModel(grayRelease))
+}
+
+// PromoteConfigFileGrayRelease 灰度发布转为全量发布，所有客户端都使用灰度发布的内容
+func (cs *Impl) PromoteConfigFileGrayRelease(ctx context.Context, namespace, group, fileName,
+	operator string) *api.ConfigResponse {
+	return cs.finishGrayRelease(ctx, namespace, group, fileName, operator, true)
+}
+
+// AbortConfigFileGrayRelease 放弃灰度发布，灰度客户端回到正式发布的内容
+func (cs *Impl) AbortConfigFileGrayRelease(ctx context.Context, namespace, group, fileName,
+	operator string) *api.ConfigResponse {
+	return cs.finishGrayRelease(ctx, namespace, group, fileName, operator, false)
+}
+
+// finishGrayRelease 结束灰度。无论全量还是放弃，正式发布都会使用新的版本号，这样灰度客户端才能感知到变化
+func (cs *Impl) finishGrayRelease(ctx context.Context, namespace, group, fileName, operator string,
+	promote bool) *api.ConfigResponse {
+	if rsp := checkReleaseParams(namespace, group, fileName); rsp != nil {
+		return rsp
+	}
+
+	requestID, _ := ctx.Value(utils.StringContext("request-id")).(string)
+
+	tx, newCtx, err := cs.StartTxAndSetToContext(ctx)
+	if err != nil {
+		log.ConfigScope().Error("[Config][Service] start tx error when finish gray release.",
+			zap.String("request-id", requestID),
+			zap.Error(err))
+		return api.NewConfigFileResponse(api.StoreLayerException, nil)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	grayRelease, err := cs.storage.GetConfigFileGrayRelease(tx, namespace, group, fileName)
+	if err != nil {
+		logReleaseError("get config file gray release error.", requestID, namespace, group, fileName, err)
+		return api.NewConfigFileResponse(api.StoreLayerException, nil)
+	}
+	if grayRelease == nil {
+		return api.NewConfigFileResponse(api.NotFoundResource, nil)
+	}
+
+	mainRelease, err := cs.storage.GetConfigFileReleaseWithAllFlag(tx, namespace, group, fileName)
+	if err != nil {
+		logReleaseError("get config file release error.", requestID, namespace, group, fileName, err)
+		return api.NewConfigFileResponse(api.StoreLayerException, nil)
+	}
+
+	releaseType := utils.ReleaseTypeGrayAbort
+	var fileRelease *model.ConfigFileRelease
+	if promote {
+		releaseType = utils.ReleaseTypeGrayPromote
+		fileRelease = &model.ConfigFileRelease{
+			Name:      grayRelease.Name,
+			Namespace: namespace,
+			Group:     group,
+			FileName:  fileName,
+			Content:   grayRelease.Content,
+			Comment:   grayRelease.Comment,
+			Md5:       grayRelease.Md5,
+			CreateBy:  operator,
+			ModifyBy:  operator,
+		}
+	} else if mainRelease != nil && mainRelease.Flag == 0 {
+		fileRelease = &model.ConfigFileRelease{
+			Name:      mainRelease.Name,
+			Namespace: namespace,
+			Group:     group,
+			FileName:  fileName,
+			Content:   mainRelease.Content,
+			Comment:   mainRelease.Comment,
+			Md5:       mainRelease.Md5,
+			CreateBy:  operator,
+			ModifyBy:  operator,
+		}
+	}
+
+	if fileRelease != nil {
+		fileRelease.Version = nextReleaseVersion(mainRelease, grayRelease)
+		if mainRelease == nil {
+			_, err = cs.storage.CreateConfigFileRelease(tx, fileRelease)
+		} else {
+			_, err = cs.storage.UpdateConfigFileRelease(tx, fileRelease)
+		}
+		if err != nil {
+			logReleaseError("save config file release error.", requestID, namespace, group, fileName, err)
+			return api.NewConfigFileResponse(api.StoreLayerException, nil)
+		}
+	}
+
+	if err := cs.storage.DeleteConfigFileGrayRelease(tx, namespace, group, fileName, operator); err != nil {
+		logReleaseError("delete config file gray release error.", requestID, namespace, group, fileName, err)
+		return api.NewConfigFileResponse(api.StoreLayerException, nil)
+	}
+
+	if fileRelease != nil {
+		cs.RecordConfigFileReleaseHistory(newCtx, fileRelease, releaseType, utils.ReleaseStatusSuccess)
+	} else {
+		// 正式发布已经被删除时放弃灰度不会发布任何内容，不记录发布历史，避免回滚到空内容
+		fileRelease = &model.ConfigFileRelease{Name: grayRelease.Name, Namespace: namespace, Group: group,
+			FileName: fileName, ModifyBy: operator}
+	}
+
+	if err := tx.Commit(); err != nil {
+		logReleaseError("commit finish gray release tx error.", requestID, namespace, group, fileName, err)
+		return api.NewConfigFileResponse(api.StoreLayerException, nil)
+	}
+	cs.notifyRelease(ctx, namespace, group, fileName)
+
+	return api.NewConfigFileReleaseResponse(api.ExecuteSuccess, transferConfigFileReleaseStoreModel2APIModel(fileRelease))
+}
+
+// deleteConfigFileGrayRelease 删除配置文件的时候，同步删除生效中的灰度发布
+func (cs *Impl) deleteConfigFileGrayRelease(tx store.Tx, namespace, group, fileName, deleteBy string) error {
+	grayRelease, err := cs.storage.GetConfigFileGrayRelease(tx, namespace, group, fileName)
+	if err != nil || grayRelease == nil {
+		return err
+	}
+	return cs.storage.DeleteConfigFileGrayRelease(tx, namespace, group, fileName, deleteBy)
+}
+
+// nextReleaseVersion 正式发布和灰度发布共用一个版本序列，客户端只需要比较版本号就能感知变更
+func nextReleaseVersion(releases ...*model.ConfigFileRelease) uint64 {
+	var version uint64
+	for _, release := range releases {
+		if release != nil && release.Version > version {
+			version = release.Version
+		}
+	}
+	return version + 1
+}
+
+func checkReleaseParams(namespace, group, fileName string) *api.ConfigResponse {
+	if err := utils2.CheckFileName(utils.NewStringValue(fileName)); err != nil {
+		return api.NewConfigFileResponse(api.InvalidConfigFileName, nil)
+	}
+	if err := utils2.CheckResourceName(utils.NewStringValue(namespace)); err != nil {
+		return api.NewConfigFileResponse(api.InvalidNamespaceName, nil)
+	}
+	if err := utils2.CheckResourceName(utils.NewStringValue(group)); err != nil {
+		return api.NewConfigFileResponse(api.InvalidConfigFileGroupName, nil)
+	}
+	return nil
+}
+
+func logReleaseError(msg, requestID, namespace, group, fileName string, err error) {
+	log.ConfigScope().Error("[Config][Service] "+msg,
+		zap.String("request-id", requestID),
+		zap.String("namespace", namespace),
+		zap.String("group", group),
+		zap.String("fileName", fileName),
+		zap.Error(err))
+}
+
+func transferGrayRuleAPIModel2StoreModel(rule *api.ConfigFileGrayRule) *model.ConfigFileGrayRule {
+	if rule == nil {
+		return nil
+	}
+	grayRule := &model.ConfigFileGrayRule{ClientLabels: rule.ClientLabels}
+	for _, ip := range rule.ClientIps {
+		if ip.GetValue() != "" {
+			grayRule.ClientIPs = append(grayRule.ClientIPs, ip.GetValue())
+		}
+	}
+	return grayRule
+}
+
+func transferGrayRuleStoreModel2APIModel(rule *model.ConfigFileGrayRule) *api.ConfigFileGrayRule {
+	if rule == nil {
+		return nil
+	}
+	grayRule := &api.ConfigFileGrayRule{ClientLabels: rule.ClientLabels}
+	for _, ip := range rule.ClientIPs {
+		grayRule.ClientIps = append(grayRule.ClientIps, utils.NewStringValue(ip))
+	}
+	return grayRule
+}
@@ -323,6 +323,10 @@ func (cs *Impl) UpdateConfigFileGroup(ctx context.Context, configFileGroup *api.
 
 	toUpdateGroup := transferConfigFileGroupAPIModel2StoreModel(configFileGroup)
 	toUpdateGroup.ModifyBy = configFileGroup.ModifyBy.GetValue()
+	// 没有传入 JSON Schema 时沿用原来的约束，传入空字符串表示取消约束
+	if configFileGroup.GetJsonSchema() == nil {
+		toUpdateGroup.JsonSchema = fileGroup.JsonSchema
+	}
 
 	updatedGroup, err := cs.storage.UpdateConfigFileGroup(toUpdateGroup)
 	if err != nil {
@@ -351,6 +355,12 @@ func checkConfigFileGroupParams(configFileGroup *api.ConfigFileGroup) *api.Confi
 		return api.NewConfigFileGroupResponse(api.InvalidNamespaceName, configFileGroup)
 	}
 
+	if schema := configFileGroup.GetJsonSchema().GetValue(); schema != "" {
+		if _, err := utils2.ParseJsonSchema(schema); err != nil {
+			return api.NewConfigFileGroupResponseWithMessage(api.InvalidConfigFileSchema, err.Error())
+		}
+	}
+
 	return nil
 }
 
@@ -364,11 +374,12 @@ func transferConfigFileGroupAPIModel2StoreModel(group *api.ConfigFileGroup) *mod
 		createBy = group.CreateBy.Value
 	}
 	return &model.ConfigFileGroup{
-		Name:      group.Name.GetValue(),
-		Namespace: group.Namespace.GetValue(),
-		Comment:   comment,
-		CreateBy:  createBy,
-		Valid:     true,
+		Name:       group.Name.GetValue(),
+		Namespace:  group.Namespace.GetValue(),
+		Comment:    comment,
+		JsonSchema: group.GetJsonSchema().GetValue(),
+		CreateBy:   createBy,
+		Valid:      true,
 	}
 }
 
@@ -381,6 +392,7 @@ func transferConfigFileGroupStoreModel2APIModel(group *model.ConfigFileGroup) *a
 		Name:       utils.NewStringValue(group.Name),
 		Namespace:  utils.NewStringValue(group.Namespace),
 		Comment:    utils.NewStringValue(group.Comment),
+		JsonSchema: utils.NewStringValue(group.JsonSchema),
 		CreateBy:   utils.NewStringValue(group.CreateBy),
 		ModifyBy:   utils.NewStringValue(group.ModifyBy),
 		CreateTime: utils.NewStringValue(time.Time2String(group.CreateTime)),
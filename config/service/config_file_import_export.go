@@ -0,0 +1,312 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package service
+
+import (
+	"context"
+	"io"
+
+	"go.uber.org/zap"
+
+	api "github.com/polarismesh/polaris-server/common/api/v1"
+	"github.com/polarismesh/polaris-server/common/log"
+	"github.com/polarismesh/polaris-server/common/model"
+	"github.com/polarismesh/polaris-server/common/utils"
+	utils2 "github.com/polarismesh/polaris-server/config/utils"
+)
+
+const (
+	// ImportConflictSkip 导入时跳过已存在的配置文件
+	ImportConflictSkip = "skip"
+	// ImportConflictOverwrite 导入时覆盖已存在的配置文件
+	ImportConflictOverwrite = "overwrite"
+	// ImportConflictFail 存在同名的配置文件时不导入任何配置文件
+	ImportConflictFail = "fail"
+
+	exportPageSize = MaxPageSize
+)
+
+// ExportConfigFiles 导出配置文件的草稿内容，加密配置导出密文以及加密的数据密钥，明文不会写入压缩包
+func (cs *Impl) ExportConfigFiles(ctx context.Context, namespace, group string, w io.Writer) *api.ConfigResponse {
+	if err := utils2.CheckResourceName(utils.NewStringValue(namespace)); err != nil {
+		return api.NewConfigFileResponse(api.InvalidNamespaceName, nil)
+	}
+	if group != "" {
+		if err := utils2.CheckResourceName(utils.NewStringValue(group)); err != nil {
+			return api.NewConfigFileResponse(api.InvalidConfigFileGroupName, nil)
+		}
+	}
+
+	requestID, _ := ctx.Value(utils.StringContext("request-id")).(string)
+
+	files, err := cs.queryGroupConfigFiles(namespace, group)
+	if err != nil {
+		log.ConfigScope().Error("[Config][Service] query config files to export error.",
+			zap.String("request-id", requestID),
+			zap.String("namespace", namespace),
+			zap.String("group", group),
+			zap.Error(err))
+		return api.NewConfigFileResponse(api.StoreLayerException, nil)
+	}
+
+	archiveFiles := make([]*utils2.ArchiveFile, 0, len(files))
+	for _, file := range files {
+		archiveFile, rsp := cs.toArchiveFile(ctx, file)
+		if rsp != nil {
+			return rsp
+		}
+		archiveFiles = append(archiveFiles, archiveFile)
+	}
+
+	if err := utils2.WriteConfigArchive(w, namespace, archiveFiles); err != nil {
+		log.ConfigScope().Error("[Config][Service] write config archive error.",
+			zap.String("request-id", requestID),
+			zap.String("namespace", namespace),
+			zap.String("group", group),
+			zap.Error(err))
+		return api.NewConfigFileResponseWithMessage(api.ExecuteException, err.Error())
+	}
+
+	log.ConfigScope().Info("[Config][Service] export config files success.",
+		zap.String("request-id", requestID),
+		zap.String("namespace", namespace),
+		zap.String("group", group),
+		zap.Int("count", len(archiveFiles)))
+
+	return api.NewConfigFileResponse(api.ExecuteSuccess, nil)
+}
+
+// queryGroupConfigFiles 查询配置文件组下的全部配置文件，group 为空时查询命名空间下的全部配置文件
+func (cs *Impl) queryGroupConfigFiles(namespace, group string) ([]*model.ConfigFile, error) {
+	var files []*model.ConfigFile
+	var offset uint32
+	for {
+		_, pageFiles, err := cs.storage.QueryConfigFiles(namespace, group, "", offset, exportPageSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range pageFiles {
+			// 存储层按照 group 模糊匹配
+			if group == "" || file.Group == group {
+				files = append(files, file)
+			}
+		}
+		if len(pageFiles) < exportPageSize {
+			return files, nil
+		}
+		offset += exportPageSize
+	}
+}
+
+// toArchiveFile 加密配置按照存储的内容导出，导入时使用密钥管理插件解开数据密钥
+func (cs *Impl) toArchiveFile(ctx context.Context, file *model.ConfigFile) (*utils2.ArchiveFile,
+	*api.ConfigResponse) {
+	tags, err := cs.QueryTagsByConfigFile(ctx, file.Namespace, file.Group, file.Name)
+	if err != nil {
+		return nil, api.NewConfigFileResponse(api.StoreLayerException, nil)
+	}
+	archiveTags := make([]utils2.ArchiveTag, 0, len(tags))
+	for _, tag := range tags {
+		archiveTags = append(archiveTags, utils2.ArchiveTag{Key: tag.Key, Value: tag.Value})
+	}
+
+	return &utils2.ArchiveFile{
+		Group:         file.Group,
+		Name:          file.Name,
+		Comment:       file.Comment,
+		Format:        file.Format,
+		Encrypted:     file.Encrypted,
+		BaseFile:      file.BaseFile,
+		VariablesFile: file.VariablesFile,
+		Tags:          archiveTags,
+		Content:       file.Content,
+	}, nil
+}
+
+// ImportConfigFiles 从导出的压缩包导入配置文件，namespace 为空时导入到压缩包记录的命名空间
+func (cs *Impl) ImportConfigFiles(ctx context.Context, namespace string, archive []byte, conflictPolicy string,
+	publish bool, operator string) *api.ConfigBatchWriteResponse {
+	if conflictPolicy == "" {
+		conflictPolicy = ImportConflictFail
+	}
+	if conflictPolicy != ImportConflictSkip && conflictPolicy != ImportConflictOverwrite &&
+		conflictPolicy != ImportConflictFail {
+		return api.NewConfigBatchWriteResponseWithMessage(api.InvalidParameter,
+			"conflict policy should be skip, overwrite or fail")
+	}
+
+	manifest, err := utils2.ReadConfigArchive(archive)
+	if err != nil {
+		return api.NewConfigBatchWriteResponseWithMessage(api.InvalidConfigFileArchive, err.Error())
+	}
+	if namespace == "" {
+		namespace = manifest.Namespace
+	}
+	if err := utils2.CheckResourceName(utils.NewStringValue(namespace)); err != nil {
+		return api.NewConfigBatchWriteResponse(api.InvalidNamespaceName)
+	}
+
+	requestID, _ := ctx.Value(utils.StringContext("request-id")).(string)
+
+	// 先找出已存在的配置文件，冲突策略为 fail 时不导入任何配置文件
+	existed := make(map[string]bool, len(manifest.Files))
+	for _, file := range manifest.Files {
+		managedFile, err := cs.storage.GetConfigFile(nil, namespace, file.Group, file.Name)
+		if err != nil {
+			log.ConfigScope().Error("[Config][Service] get config file to import error.",
+				zap.String("request-id", requestID),
+				zap.String("namespace", namespace),
+				zap.String("group", file.Group),
+				zap.String("name", file.Name),
+				zap.Error(err))
+			return api.NewConfigBatchWriteResponse(api.StoreLayerException)
+		}
+		if managedFile != nil {
+			existed[file.Path] = true
+		}
+	}
+	if conflictPolicy == ImportConflictFail && len(existed) > 0 {
+		batchRsp := api.NewConfigBatchWriteResponse(api.ExistedResource)
+		for _, file := range manifest.Files {
+			if existed[file.Path] {
+				batchRsp.Collect(api.NewConfigFileResponse(api.ExistedResource, importedFileKey(namespace, file)))
+			}
+		}
+		return batchRsp
+	}
+
+	batchRsp := api.NewConfigBatchWriteResponse(api.ExecuteSuccess)
+	for _, file := range manifest.Files {
+		for _, rsp := range cs.importConfigFile(ctx, namespace, file, existed[file.Path], conflictPolicy,
+			publish, operator) {
+			batchRsp.Collect(rsp)
+		}
+	}
+
+	log.ConfigScope().Info("[Config][Service] import config files finished.",
+		zap.String("request-id", requestID),
+		zap.String("namespace", namespace),
+		zap.String("conflict-policy", conflictPolicy),
+		zap.Bool("publish", publish),
+		zap.Uint32("code", batchRsp.Code.GetValue()),
+		zap.Int("count", len(manifest.Files)))
+
+	return batchRsp
+}
+
+// importConfigFile 导入单个配置文件，返回保存草稿的结果，结果中携带配置文件的主键信息。需要发布并且保存成功时，
+// 再返回一条携带发布信息的发布结果，发布失败时已经保存的草稿保留，由调用方重新发布
+func (cs *Impl) importConfigFile(ctx context.Context, namespace string, file *utils2.ArchiveFile, existed bool,
+	conflictPolicy string, publish bool, operator string) []*api.ConfigResponse {
+	fileKey := importedFileKey(namespace, file)
+	if existed && conflictPolicy == ImportConflictSkip {
+		return []*api.ConfigResponse{skippedImportResponse(fileKey)}
+	}
+
+	// 导出的加密配置是密文，解密后按照明文重新校验和加密，密钥管理插件不能解开数据密钥时导入失败
+	content := file.Content
+	if file.Encrypted {
+		plainContent, err := cs.decryptContent(content)
+		if err != nil {
+			log.ConfigScope().Error("[Config][Service] decrypt config file to import error.",
+				zap.String("request-id", utils.ParseRequestID(ctx)),
+				zap.String("namespace", namespace),
+				zap.String("group", file.Group),
+				zap.String("name", file.Name),
+				zap.Error(err))
+			rsp := api.NewConfigFileResponseWithMessage(api.ConfigFileCryptoException, err.Error())
+			rsp.ConfigFile = fileKey
+			return []*api.ConfigResponse{rsp}
+		}
+		content = plainContent
+	}
+
+	configFile := &api.ConfigFile{
+		Namespace:     utils.NewStringValue(namespace),
+		Group:         utils.NewStringValue(file.Group),
+		Name:          utils.NewStringValue(file.Name),
+		Content:       utils.NewStringValue(content),
+		Comment:       utils.NewStringValue(file.Comment),
+		Format:        utils.NewStringValue(file.Format),
+		Encrypted:     utils.NewBoolValue(file.Encrypted),
+		BaseFile:      utils.NewStringValue(file.BaseFile),
+		VariablesFile: utils.NewStringValue(file.VariablesFile),
+		CreateBy:      utils.NewStringValue(operator),
+		ModifyBy:      utils.NewStringValue(operator),
+	}
+	for _, tag := range file.Tags {
+		configFile.Tags = append(configFile.Tags, &api.ConfigFileTag{
+			Key:   utils.NewStringValue(tag.Key),
+			Value: utils.NewStringValue(tag.Value),
+		})
+	}
+
+	// 导入前的冲突检查与写入不是原子的，以写入时的结果为准重新按照冲突策略处理
+	var rsp *api.ConfigResponse
+	if existed {
+		rsp = cs.UpdateConfigFile(ctx, configFile)
+		if rsp.Code.GetValue() == api.NotFoundResource {
+			rsp = cs.CreateConfigFile(ctx, configFile)
+		}
+	} else {
+		rsp = cs.CreateConfigFile(ctx, configFile)
+		if rsp.Code.GetValue() == api.ExistedResource {
+			switch conflictPolicy {
+			case ImportConflictSkip:
+				return []*api.ConfigResponse{skippedImportResponse(fileKey)}
+			case ImportConflictOverwrite:
+				rsp = cs.UpdateConfigFile(ctx, configFile)
+			}
+		}
+	}
+	rsps := []*api.ConfigResponse{{Code: rsp.Code, Info: rsp.Info, ConfigFile: fileKey}}
+	if rsp.Code.GetValue() != api.ExecuteSuccess || !publish {
+		return rsps
+	}
+
+	release := &api.ConfigFileRelease{
+		Namespace: configFile.Namespace,
+		Group:     configFile.Group,
+		FileName:  configFile.Name,
+		CreateBy:  configFile.CreateBy,
+	}
+	publishRsp := cs.PublishConfigFile(ctx, release)
+	return append(rsps, &api.ConfigResponse{
+		Code: publishRsp.Code,
+		Info: publishRsp.Info,
+		ConfigFileRelease: &api.ConfigFileRelease{
+			Namespace: release.Namespace,
+			Group:     release.Group,
+			FileName:  release.FileName,
+		},
+	})
+}
+
+func skippedImportResponse(fileKey *api.ConfigFile) *api.ConfigResponse {
+	rsp := api.NewConfigFileResponse(api.NoNeedUpdate, fileKey)
+	rsp.Info = utils.NewStringValue("skip existed config file")
+	return rsp
+}
+
+func importedFileKey(namespace string, file *utils2.ArchiveFile) *api.ConfigFile {
+	return &api.ConfigFile{
+		Namespace: utils.NewStringValue(namespace),
+		Group:     utils.NewStringValue(file.Group),
+		Name:      utils.NewStringValue(file.Name),
+	}
+}
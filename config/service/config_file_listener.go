@@ -0,0 +1,69 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package service
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	api "github.com/polarismesh/polaris-server/common/api/v1"
+	"github.com/polarismesh/polaris-server/common/log"
+	"github.com/polarismesh/polaris-server/common/model"
+	"github.com/polarismesh/polaris-server/common/time"
+	"github.com/polarismesh/polaris-server/common/utils"
+)
+
+// QueryConfigFileListeners 查询所有节点上订阅配置文件的客户端，以及客户端持有的配置版本
+func (cs *Impl) QueryConfigFileListeners(ctx context.Context, namespace, group, fileName string, offset,
+	limit uint32) *api.ConfigBatchQueryResponse {
+	if limit <= 0 || limit > MaxPageSize {
+		return api.NewConfigFileListenerBatchQueryResponse(api.InvalidParameter, 0, nil)
+	}
+
+	count, listeners, err := cs.storage.QueryConfigFileListeners(namespace, group, fileName, offset, limit)
+	if err != nil {
+		requestID, _ := ctx.Value(utils.StringContext("request-id")).(string)
+		log.ConfigScope().Error("[Config][Service] query config file listeners error.",
+			zap.String("request-id", requestID),
+			zap.String("namespace", namespace),
+			zap.String("group", group),
+			zap.String("fileName", fileName),
+			zap.Error(err))
+		return api.NewConfigFileListenerBatchQueryResponse(api.StoreLayerException, 0, nil)
+	}
+
+	apiListeners := make([]*api.ConfigFileListener, 0, len(listeners))
+	for _, listener := range listeners {
+		apiListeners = append(apiListeners, transferListenerStoreModel2APIModel(listener))
+	}
+	return api.NewConfigFileListenerBatchQueryResponse(api.ExecuteSuccess, count, apiListeners)
+}
+
+func transferListenerStoreModel2APIModel(listener *model.ConfigFileListener) *api.ConfigFileListener {
+	return &api.ConfigFileListener{
+		ClientId:     utils.NewStringValue(listener.ClientId),
+		ClientIp:     utils.NewStringValue(listener.ClientIP),
+		Namespace:    utils.NewStringValue(listener.Namespace),
+		Group:        utils.NewStringValue(listener.Group),
+		FileName:     utils.NewStringValue(listener.FileName),
+		Version:      utils.NewUInt64Value(listener.Version),
+		LastPollTime: utils.NewStringValue(time.Time2String(listener.LastPollTime)),
+		Server:       utils.NewStringValue(listener.Server),
+	}
+}
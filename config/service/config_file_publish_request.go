@@ -0,0 +1,631 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"go.uber.org/zap"
+
+	"github.com/polarismesh/polaris-server/auth"
+	api "github.com/polarismesh/polaris-server/common/api/v1"
+	"github.com/polarismesh/polaris-server/common/log"
+	"github.com/polarismesh/polaris-server/common/model"
+	commontime "github.com/polarismesh/polaris-server/common/time"
+	"github.com/polarismesh/polaris-server/common/utils"
+	utils2 "github.com/polarismesh/polaris-server/config/utils"
+)
+
+const (
+	// planTimeLayout 计划发布时间的格式，与其他时间字段的展示格式一致，按照服务端所在时区解析
+	planTimeLayout = "2006-01-02 15:04:05"
+	// publishingTimeout 发布中的申请超过该时间没有更新，认为执行发布的节点已经退出，改为发布失败
+	publishingTimeout = 5 * time.Minute
+)
+
+// opScheduledPublish 调度器在计划时间发布，只用于校验状态，记录历史时与手动发布一样为 OPublish
+const opScheduledPublish model.OperationType = "ScheduledPublish"
+
+// publishRequestTransitions 发布申请的操作以及允许执行该操作的状态
+var publishRequestTransitions = map[model.OperationType][]string{
+	model.OSubmit:   {utils.PublishRequestStatusDraft, utils.PublishRequestStatusRejected},
+	model.OApprove:  {utils.PublishRequestStatusPending},
+	model.OReject:   {utils.PublishRequestStatusPending},
+	model.OSchedule: {utils.PublishRequestStatusApproved, utils.PublishRequestStatusScheduled},
+	model.OPublish:  {utils.PublishRequestStatusApproved},
+	// 调度器只发布到达计划时间的申请
+	opScheduledPublish: {utils.PublishRequestStatusScheduled},
+	model.OCancel: {utils.PublishRequestStatusDraft, utils.PublishRequestStatusPending,
+		utils.PublishRequestStatusRejected, utils.PublishRequestStatusApproved, utils.PublishRequestStatusScheduled},
+}
+
+// CreateConfigFilePublishRequest 创建配置发布申请，创建后为草稿状态，提交后才能审批
+func (cs *Impl) CreateConfigFilePublishRequest(ctx context.Context,
+	publishRequest *api.ConfigFilePublishRequest) *api.ConfigResponse {
+	namespace := publishRequest.GetNamespace().GetValue()
+	group := publishRequest.GetGroup().GetValue()
+	fileName := publishRequest.GetFileName().GetValue()
+
+	if err := utils2.CheckResourceName(utils.NewStringValue(namespace)); err != nil {
+		return api.NewConfigFileResponse(api.InvalidNamespaceName, nil)
+	}
+	if err := utils2.CheckResourceName(utils.NewStringValue(group)); err != nil {
+		return api.NewConfigFileResponse(api.InvalidConfigFileGroupName, nil)
+	}
+	if err := utils2.CheckFileName(utils.NewStringValue(fileName)); err != nil {
+		return api.NewConfigFileResponse(api.InvalidConfigFileName, nil)
+	}
+
+	approveUsers := stringValues(publishRequest.GetApproveUsers())
+	approveGroups := stringValues(publishRequest.GetApproveGroups())
+	if err := cs.checkApprovers(approveUsers, approveGroups); err != nil {
+		return api.NewConfigFileResponseWithMessage(api.InvalidPublishRequest, err.Error())
+	}
+	planTime, err := parsePlanTime(publishRequest.GetPlanTime().GetValue())
+	if err != nil {
+		return api.NewConfigFileResponseWithMessage(api.InvalidPublishRequest, err.Error())
+	}
+
+	operator, rsp := cs.publishRequestOperator(ctx, "CreateConfigFilePublishRequest")
+	if rsp != nil {
+		return rsp
+	}
+
+	requestID, _ := ctx.Value(utils.StringContext("request-id")).(string)
+
+	file, err := cs.storage.GetConfigFile(nil, namespace, group, fileName)
+	if err != nil {
+		log.ConfigScope().Error("[Config][Service] get config file to create publish request error.",
+			zap.String("request-id", requestID),
+			zap.String("namespace", namespace),
+			zap.String("group", group),
+			zap.String("fileName", fileName),
+			zap.Error(err))
+		return api.NewConfigFileResponse(api.StoreLayerException, nil)
+	}
+	if file == nil {
+		return api.NewConfigFileResponse(api.NotFoundResourceConfigFile, nil)
+	}
+
+	tn := time.Now()
+	request := &model.ConfigFilePublishRequest{
+		Id:            utils.NewUUID(),
+		Namespace:     namespace,
+		Group:         group,
+		FileName:      fileName,
+		Comment:       publishRequest.GetComment().GetValue(),
+		Status:        utils.PublishRequestStatusDraft,
+		ApproveUsers:  approveUsers,
+		ApproveGroups: approveGroups,
+		PlanTime:      planTime,
+		CreateTime:    tn,
+		CreateBy:      operator,
+		ModifyTime:    tn,
+		ModifyBy:      operator,
+	}
+	if err := cs.storage.CreateConfigFilePublishRequest(request); err != nil {
+		log.ConfigScope().Error("[Config][Service] create publish request error.",
+			zap.String("request-id", requestID),
+			zap.String("namespace", namespace),
+			zap.String("group", group),
+			zap.String("fileName", fileName),
+			zap.Error(err))
+		return api.NewConfigFileResponse(api.StoreLayerException, nil)
+	}
+
+	cs.recordPublishRequestHistory(request, model.OCreate, "", request.CreateBy)
+
+	return api.NewConfigFilePublishRequestResponse(api.ExecuteSuccess, transferPublishRequestStoreModel2APIModel(request))
+}
+
+// SubmitConfigFilePublishRequest 提交发布申请，草稿或者被驳回的申请提交后等待审批
+func (cs *Impl) SubmitConfigFilePublishRequest(ctx context.Context,
+	publishRequest *api.ConfigFilePublishRequest) *api.ConfigResponse {
+	operator, rsp := cs.publishRequestOperator(ctx, "SubmitConfigFilePublishRequest")
+	if rsp != nil {
+		return rsp
+	}
+	return cs.transitPublishRequest(ctx, publishRequest.GetId().GetValue(), model.OSubmit,
+		func(request *model.ConfigFilePublishRequest) *api.ConfigResponse {
+			request.Status = utils.PublishRequestStatusPending
+			request.ApproveBy = ""
+			request.ApproveComment = ""
+			request.ApproveTime = time.Time{}
+			request.ApproveMd5 = ""
+			request.ModifyBy = operator
+			return nil
+		})
+}
+
+// ApproveConfigFilePublishRequest 审批通过发布申请，设置了计划发布时间的申请进入调度，否则等待手动发布，
+// 审批时记录草稿内容的 md5，发布时草稿已经被修改则拒绝发布
+func (cs *Impl) ApproveConfigFilePublishRequest(ctx context.Context,
+	publishRequest *api.ConfigFilePublishRequest) *api.ConfigResponse {
+	return cs.transitPublishRequest(ctx, publishRequest.GetId().GetValue(), model.OApprove,
+		func(request *model.ConfigFilePublishRequest) *api.ConfigResponse {
+			approveBy, rsp := cs.checkApprover(ctx, request)
+			if rsp != nil {
+				return rsp
+			}
+			file, err := cs.storage.GetConfigFile(nil, request.Namespace, request.Group, request.FileName)
+			if err != nil {
+				requestID, _ := ctx.Value(utils.StringContext("request-id")).(string)
+				log.ConfigScope().Error("[Config][Service] get config file to approve publish request error.",
+					zap.String("request-id", requestID),
+					zap.String("id", request.Id),
+					zap.Error(err))
+				return api.NewConfigFileResponse(api.StoreLayerException, nil)
+			}
+			if file == nil {
+				return api.NewConfigFileResponse(api.NotFoundResourceConfigFile, nil)
+			}
+			request.Status = utils.PublishRequestStatusApproved
+			if !request.PlanTime.IsZero() {
+				request.Status = utils.PublishRequestStatusScheduled
+			}
+			request.ApproveBy = approveBy
+			request.ApproveComment = publishRequest.GetApproveComment().GetValue()
+			request.ApproveTime = time.Now()
+			request.ApproveMd5 = utils2.CalMd5(file.Content)
+			request.ModifyBy = approveBy
+			return nil
+		})
+}
+
+// RejectConfigFilePublishRequest 驳回发布申请，驳回后可以重新提交
+func (cs *Impl) RejectConfigFilePublishRequest(ctx context.Context,
+	publishRequest *api.ConfigFilePublishRequest) *api.ConfigResponse {
+	return cs.transitPublishRequest(ctx, publishRequest.GetId().GetValue(), model.OReject,
+		func(request *model.ConfigFilePublishRequest) *api.ConfigResponse {
+			approveBy, rsp := cs.checkApprover(ctx, request)
+			if rsp != nil {
+				return rsp
+			}
+			request.Status = utils.PublishRequestStatusRejected
+			request.ApproveBy = approveBy
+			request.ApproveComment = publishRequest.GetApproveComment().GetValue()
+			request.ApproveTime = time.Now()
+			request.ModifyBy = approveBy
+			return nil
+		})
+}
+
+// ScheduleConfigFilePublishRequest 设置审批通过的发布申请的计划发布时间，已经在调度中的申请可以修改计划发布时间
+func (cs *Impl) ScheduleConfigFilePublishRequest(ctx context.Context,
+	publishRequest *api.ConfigFilePublishRequest) *api.ConfigResponse {
+	planTime, err := parsePlanTime(publishRequest.GetPlanTime().GetValue())
+	if err != nil {
+		return api.NewConfigFileResponseWithMessage(api.InvalidPublishRequest, err.Error())
+	}
+	if planTime.IsZero() {
+		return api.NewConfigFileResponseWithMessage(api.InvalidPublishRequest, "plan time is required")
+	}
+	operator, rsp := cs.publishRequestOperator(ctx, "ScheduleConfigFilePublishRequest")
+	if rsp != nil {
+		return rsp
+	}
+	return cs.transitPublishRequest(ctx, publishRequest.GetId().GetValue(), model.OSchedule,
+		func(request *model.ConfigFilePublishRequest) *api.ConfigResponse {
+			request.Status = utils.PublishRequestStatusScheduled
+			request.PlanTime = planTime
+			request.ModifyBy = operator
+			return nil
+		})
+}
+
+// ExecuteConfigFilePublishRequest 立即发布审批通过的发布申请
+func (cs *Impl) ExecuteConfigFilePublishRequest(ctx context.Context,
+	publishRequest *api.ConfigFilePublishRequest) *api.ConfigResponse {
+	operator, rsp := cs.publishRequestOperator(ctx, "ExecuteConfigFilePublishRequest")
+	if rsp != nil {
+		return rsp
+	}
+	request, rsp := cs.loadPublishRequest(ctx, publishRequest.GetId().GetValue())
+	if rsp != nil {
+		return rsp
+	}
+	return cs.executePublishRequest(ctx, request, model.OPublish, operator)
+}
+
+// CancelConfigFilePublishRequest 取消未发布的发布申请
+func (cs *Impl) CancelConfigFilePublishRequest(ctx context.Context,
+	publishRequest *api.ConfigFilePublishRequest) *api.ConfigResponse {
+	operator, rsp := cs.publishRequestOperator(ctx, "CancelConfigFilePublishRequest")
+	if rsp != nil {
+		return rsp
+	}
+	return cs.transitPublishRequest(ctx, publishRequest.GetId().GetValue(), model.OCancel,
+		func(request *model.ConfigFilePublishRequest) *api.ConfigResponse {
+			request.Status = utils.PublishRequestStatusCancelled
+			request.ModifyBy = operator
+			return nil
+		})
+}
+
+// GetConfigFilePublishRequest 获取发布申请
+func (cs *Impl) GetConfigFilePublishRequest(ctx context.Context, id string) *api.ConfigResponse {
+	request, rsp := cs.loadPublishRequest(ctx, id)
+	if rsp != nil {
+		return rsp
+	}
+	return api.NewConfigFilePublishRequestResponse(api.ExecuteSuccess, transferPublishRequestStoreModel2APIModel(request))
+}
+
+// QueryConfigFilePublishRequests 查询发布申请，参数为空时不过滤
+func (cs *Impl) QueryConfigFilePublishRequests(ctx context.Context, namespace, group, fileName, status string,
+	offset, limit uint32) *api.ConfigBatchQueryResponse {
+	if limit == 0 || limit > MaxPageSize {
+		return api.NewConfigFilePublishRequestBatchQueryResponse(api.InvalidParameter, 0, nil)
+	}
+
+	total, requests, err := cs.storage.QueryConfigFilePublishRequests(namespace, group, fileName, status,
+		offset, limit)
+	if err != nil {
+		requestID, _ := ctx.Value(utils.StringContext("request-id")).(string)
+		log.ConfigScope().Error("[Config][Service] query publish requests error.",
+			zap.String("request-id", requestID),
+			zap.String("namespace", namespace),
+			zap.String("group", group),
+			zap.String("fileName", fileName),
+			zap.Error(err))
+		return api.NewConfigFilePublishRequestBatchQueryResponse(api.StoreLayerException, 0, nil)
+	}
+
+	apiRequests := make([]*api.ConfigFilePublishRequest, 0, len(requests))
+	for _, request := range requests {
+		apiRequests = append(apiRequests, transferPublishRequestStoreModel2APIModel(request))
+	}
+	return api.NewConfigFilePublishRequestBatchQueryResponse(api.ExecuteSuccess, total, apiRequests)
+}
+
+// PublishDueConfigFilePublishRequests 发布到达计划时间的发布申请，多个节点同时调度时只有一个节点能够发布，
+// 以审批人作为发布人，返回发布成功的数量。同时把长时间处于发布中的申请改为发布失败
+func (cs *Impl) PublishDueConfigFilePublishRequests(ctx context.Context, now time.Time) int {
+	cs.failStalePublishingRequests(ctx, now)
+
+	requests, err := cs.storage.FindDueConfigFilePublishRequests(now)
+	if err != nil {
+		log.ConfigScope().Error("[Config][Service] find due publish requests error.", zap.Error(err))
+		return 0
+	}
+
+	published := 0
+	for _, request := range requests {
+		rsp := cs.executePublishRequest(ctx, request, opScheduledPublish, request.ApproveBy)
+		if rsp.GetCode().GetValue() == api.ExecuteSuccess {
+			published++
+		}
+	}
+	return published
+}
+
+// executePublishRequest 先把申请改为发布中状态，只有修改成功的节点执行发布，再根据发布结果更新申请状态，
+// 只发布审批时的草稿内容，草稿在审批之后被修改时发布失败
+func (cs *Impl) executePublishRequest(ctx context.Context, request *model.ConfigFilePublishRequest,
+	operation model.OperationType, operator string) *api.ConfigResponse {
+	fromStatus := request.Status
+	if !isPublishRequestTransitionAllowed(operation, fromStatus) {
+		return api.NewConfigFileResponse(api.InvalidPublishRequestStatus, nil)
+	}
+	if request.ApproveMd5 == "" {
+		return api.NewConfigFileResponseWithMessage(api.InvalidPublishRequest, "approved content is unknown")
+	}
+	request.Status = utils.PublishRequestStatusPublishing
+	request.ModifyBy = operator
+	if rsp := cs.updatePublishRequest(ctx, request, fromStatus); rsp != nil {
+		return rsp
+	}
+
+	publishRsp := cs.publishConfigFile(ctx, &api.ConfigFileRelease{
+		Namespace: utils.NewStringValue(request.Namespace),
+		Group:     utils.NewStringValue(request.Group),
+		FileName:  utils.NewStringValue(request.FileName),
+		Comment:   utils.NewStringValue(request.Comment),
+		CreateBy:  utils.NewStringValue(operator),
+	}, request.ApproveMd5)
+
+	if publishRsp.GetCode().GetValue() == api.ExecuteSuccess {
+		request.Status = utils.PublishRequestStatusPublished
+		request.PublishTime = time.Now()
+		request.FailReason = ""
+	} else {
+		request.Status = utils.PublishRequestStatusFailed
+		request.FailReason = publishRsp.GetInfo().GetValue()
+	}
+	if rsp := cs.updatePublishRequest(ctx, request, utils.PublishRequestStatusPublishing); rsp != nil {
+		return rsp
+	}
+	cs.recordPublishRequestHistory(request, model.OPublish, fromStatus, operator)
+
+	if request.Status != utils.PublishRequestStatusPublished {
+		return &api.ConfigResponse{
+			Code:                     publishRsp.Code,
+			Info:                     publishRsp.Info,
+			ConfigFilePublishRequest: transferPublishRequestStoreModel2APIModel(request),
+		}
+	}
+	return api.NewConfigFilePublishRequestResponse(api.ExecuteSuccess, transferPublishRequestStoreModel2APIModel(request))
+}
+
+// failStalePublishingRequests 执行发布的节点在发布中退出时申请会一直处于发布中，超时后改为发布失败，
+// 由存储层的状态修改保证多个节点只修改一次
+func (cs *Impl) failStalePublishingRequests(ctx context.Context, now time.Time) {
+	_, requests, err := cs.storage.QueryConfigFilePublishRequests("", "", "",
+		utils.PublishRequestStatusPublishing, 0, MaxPageSize)
+	if err != nil {
+		log.ConfigScope().Error("[Config][Service] find publishing publish requests error.", zap.Error(err))
+		return
+	}
+	for _, request := range requests {
+		if now.Sub(request.ModifyTime) < publishingTimeout {
+			continue
+		}
+		request.Status = utils.PublishRequestStatusFailed
+		request.FailReason = "publish timeout"
+		if rsp := cs.updatePublishRequest(ctx, request, utils.PublishRequestStatusPublishing); rsp != nil {
+			continue
+		}
+		log.ConfigScope().Warn("[Config][Service] publishing publish request timeout.", zap.String("id", request.Id))
+		cs.recordPublishRequestHistory(request, model.OPublish, utils.PublishRequestStatusPublishing, request.ModifyBy)
+	}
+}
+
+// transitPublishRequest 校验当前状态是否允许执行操作，modify 修改申请的内容，返回非空时不修改
+func (cs *Impl) transitPublishRequest(ctx context.Context, id string, operation model.OperationType,
+	modify func(request *model.ConfigFilePublishRequest) *api.ConfigResponse) *api.ConfigResponse {
+	request, rsp := cs.loadPublishRequest(ctx, id)
+	if rsp != nil {
+		return rsp
+	}
+	fromStatus := request.Status
+	if !isPublishRequestTransitionAllowed(operation, fromStatus) {
+		return api.NewConfigFileResponse(api.InvalidPublishRequestStatus, nil)
+	}
+	if rsp := modify(request); rsp != nil {
+		return rsp
+	}
+	if rsp := cs.updatePublishRequest(ctx, request, fromStatus); rsp != nil {
+		return rsp
+	}
+
+	cs.recordPublishRequestHistory(request, operation, fromStatus, request.ModifyBy)
+
+	return api.NewConfigFilePublishRequestResponse(api.ExecuteSuccess, transferPublishRequestStoreModel2APIModel(request))
+}
+
+func (cs *Impl) loadPublishRequest(ctx context.Context, id string) (*model.ConfigFilePublishRequest,
+	*api.ConfigResponse) {
+	if id == "" {
+		return nil, api.NewConfigFileResponseWithMessage(api.InvalidPublishRequest, "id is required")
+	}
+	request, err := cs.storage.GetConfigFilePublishRequest(id)
+	if err != nil {
+		requestID, _ := ctx.Value(utils.StringContext("request-id")).(string)
+		log.ConfigScope().Error("[Config][Service] get publish request error.",
+			zap.String("request-id", requestID),
+			zap.String("id", id),
+			zap.Error(err))
+		return nil, api.NewConfigFileResponse(api.StoreLayerException, nil)
+	}
+	if request == nil {
+		return nil, api.NewConfigFileResponse(api.NotFoundResource, nil)
+	}
+	return request, nil
+}
+
+// updatePublishRequest 状态已经被其他请求或者节点修改时返回状态错误
+func (cs *Impl) updatePublishRequest(ctx context.Context, request *model.ConfigFilePublishRequest,
+	fromStatus string) *api.ConfigResponse {
+	request.ModifyTime = time.Now()
+	updated, err := cs.storage.UpdateConfigFilePublishRequest(request, fromStatus)
+	if err != nil {
+		requestID, _ := ctx.Value(utils.StringContext("request-id")).(string)
+		log.ConfigScope().Error("[Config][Service] update publish request error.",
+			zap.String("request-id", requestID),
+			zap.String("id", request.Id),
+			zap.String("from-status", fromStatus),
+			zap.String("to-status", request.Status),
+			zap.Error(err))
+		return api.NewConfigFileResponse(api.StoreLayerException, nil)
+	}
+	if !updated {
+		return api.NewConfigFileResponse(api.InvalidPublishRequestStatus, nil)
+	}
+	return nil
+}
+
+// checkApprovers 鉴权模块支持用户和用户组时，校验审批人是否存在
+func (cs *Impl) checkApprovers(users, groups []string) error {
+	if len(users) == 0 && len(groups) == 0 {
+		return fmt.Errorf("approvers is empty")
+	}
+	checker, ok := cs.approverChecker()
+	if !ok {
+		if len(users) == 0 {
+			return fmt.Errorf("approve users is required when auth module does not support approve groups")
+		}
+		return nil
+	}
+	return checker.CheckApprovers(users, groups)
+}
+
+// checkApprover 通过请求携带的 token 解析操作者并校验是否为审批人，返回审批人，
+// 不使用请求中的审批人字段，未开启控制台鉴权时也不能冒用其他用户审批
+func (cs *Impl) checkApprover(ctx context.Context, request *model.ConfigFilePublishRequest) (string,
+	*api.ConfigResponse) {
+	checker, ok := cs.approverChecker()
+	if !ok {
+		// 鉴权模块不支持审批人校验时，只认可鉴权流程写入请求上下文的用户
+		approveBy := utils.ParseUserID(ctx)
+		if approveBy == "" {
+			return "", api.NewConfigFileResponse(api.NotAllowedAccess, nil)
+		}
+		for _, user := range request.ApproveUsers {
+			if user == approveBy {
+				return approveBy, nil
+			}
+		}
+		return "", api.NewConfigFileResponse(api.NotAllowedAccess, nil)
+	}
+
+	authCtx := model.NewAcquireContext(
+		model.WithRequestContext(ctx),
+		model.WithOperation(model.Modify),
+		model.WithToken(utils.ParseAuthToken(ctx)),
+		model.WithModule(model.ConfigModule),
+		model.WithMethod("ApproveConfigFilePublishRequest"),
+	)
+	operatorID, isApprover, err := checker.IsApprover(authCtx, request.ApproveUsers, request.ApproveGroups)
+	if err != nil {
+		return "", api.NewConfigFileResponseWithMessage(api.NotAllowedAccess, err.Error())
+	}
+	if !isApprover {
+		return "", api.NewConfigFileResponse(api.NotAllowedAccess, nil)
+	}
+	return operatorID, nil
+}
+
+// publishRequestOperator 通过请求携带的 token 解析发布申请的操作者，不使用请求中的 createBy、modifyBy 字段，
+// 匿名用户以及被禁用的 token 不能操作发布申请
+func (cs *Impl) publishRequestOperator(ctx context.Context, method string) (string, *api.ConfigResponse) {
+	checker, ok := cs.approverChecker()
+	if !ok {
+		// 鉴权模块不支持解析操作者时，只认可鉴权流程写入请求上下文的用户
+		operator := utils.ParseUserID(ctx)
+		if operator == "" {
+			return "", api.NewConfigFileResponse(api.NotAllowedAccess, nil)
+		}
+		return operator, nil
+	}
+
+	authCtx := model.NewAcquireContext(
+		model.WithRequestContext(ctx),
+		model.WithOperation(model.Modify),
+		model.WithToken(utils.ParseAuthToken(ctx)),
+		model.WithModule(model.ConfigModule),
+		model.WithMethod(method),
+	)
+	// 不指定审批人时只解析操作者
+	operator, _, err := checker.IsApprover(authCtx, nil, nil)
+	if err != nil {
+		return "", api.NewConfigFileResponseWithMessage(api.NotAllowedAccess, err.Error())
+	}
+	if operator == "" {
+		return "", api.NewConfigFileResponse(api.NotAllowedAccess, nil)
+	}
+	return operator, nil
+}
+
+// approverChecker 鉴权模块支持审批人校验时返回，与是否开启控制台鉴权无关
+func (cs *Impl) approverChecker() (auth.ApproverChecker, bool) {
+	if cs.authChecker == nil {
+		return nil, false
+	}
+	checker, ok := cs.authChecker.(auth.ApproverChecker)
+	return checker, ok
+}
+
+// recordPublishRequestHistory 通过 history 插件记录发布申请的状态变更
+func (cs *Impl) recordPublishRequestHistory(request *model.ConfigFilePublishRequest,
+	operation model.OperationType, fromStatus, operator string) {
+	if cs.history == nil {
+		return
+	}
+	cs.history.Record(&model.RecordEntry{
+		ResourceType:  model.RConfigFilePublishRequest,
+		OperationType: operation,
+		Namespace:     request.Namespace,
+		Service:       request.Group + "/" + request.FileName,
+		Context: fmt.Sprintf("id:%s|from:%s|to:%s|fail_reason:%s", request.Id, fromStatus, request.Status,
+			request.FailReason),
+		Operator:   operator,
+		CreateTime: time.Now(),
+	})
+}
+
+func isPublishRequestTransitionAllowed(operation model.OperationType, status string) bool {
+	for _, allowed := range publishRequestTransitions[operation] {
+		if allowed == status {
+			return true
+		}
+	}
+	return false
+}
+
+// parsePlanTime 解析计划发布时间，为空表示审批通过后手动发布，不允许早于当前时间
+func parsePlanTime(planTime string) (time.Time, error) {
+	if planTime == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.ParseInLocation(planTimeLayout, planTime, time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("plan time should be formatted as %s", planTimeLayout)
+	}
+	if t.Before(time.Now()) {
+		return time.Time{}, fmt.Errorf("plan time should be later than now")
+	}
+	return t, nil
+}
+
+func stringValues(values []*wrappers.StringValue) []string {
+	ret := make([]string, 0, len(values))
+	for _, value := range values {
+		if value.GetValue() != "" {
+			ret = append(ret, value.GetValue())
+		}
+	}
+	return ret
+}
+
+func formatOptionalTime(t time.Time) *wrappers.StringValue {
+	if t.IsZero() {
+		return nil
+	}
+	return utils.NewStringValue(commontime.Time2String(t))
+}
+
+func transferPublishRequestStoreModel2APIModel(request *model.ConfigFilePublishRequest) *api.ConfigFilePublishRequest {
+	apiRequest := &api.ConfigFilePublishRequest{
+		Id:             utils.NewStringValue(request.Id),
+		Namespace:      utils.NewStringValue(request.Namespace),
+		Group:          utils.NewStringValue(request.Group),
+		FileName:       utils.NewStringValue(request.FileName),
+		Comment:        utils.NewStringValue(request.Comment),
+		Status:         utils.NewStringValue(request.Status),
+		PlanTime:       formatOptionalTime(request.PlanTime),
+		ApproveBy:      utils.NewStringValue(request.ApproveBy),
+		ApproveComment: utils.NewStringValue(request.ApproveComment),
+		ApproveTime:    formatOptionalTime(request.ApproveTime),
+		PublishTime:    formatOptionalTime(request.PublishTime),
+		FailReason:     utils.NewStringValue(request.FailReason),
+		CreateTime:     utils.NewStringValue(commontime.Time2String(request.CreateTime)),
+		CreateBy:       utils.NewStringValue(request.CreateBy),
+		ModifyTime:     utils.NewStringValue(commontime.Time2String(request.ModifyTime)),
+		ModifyBy:       utils.NewStringValue(request.ModifyBy),
+	}
+	for _, user := range request.ApproveUsers {
+		apiRequest.ApproveUsers = append(apiRequest.ApproveUsers, utils.NewStringValue(user))
+	}
+	for _, group := range request.ApproveGroups {
+		apiRequest.ApproveGroups = append(apiRequest.ApproveGroups, utils.NewStringValue(group))
+	}
+	return apiRequest
+}
@@ -32,6 +32,12 @@ import (
 
 // PublishConfigFile 发布配置文件
 func (cs *Impl) PublishConfigFile(ctx context.Context, configFileRelease *api.ConfigFileRelease) *api.ConfigResponse {
+	return cs.publishConfigFile(ctx, configFileRelease, "")
+}
+
+// publishConfigFile 发布配置文件，draftMd5 不为空时只有草稿内容的 md5 与之一致才发布
+func (cs *Impl) publishConfigFile(ctx context.Context, configFileRelease *api.ConfigFileRelease,
+	draftMd5 string) *api.ConfigResponse {
 	namespace := configFileRelease.Namespace.GetValue()
 	group := configFileRelease.Group.GetValue()
 	fileName := configFileRelease.FileName.GetValue()
@@ -74,7 +80,23 @@ func (cs *Impl) PublishConfigFile(ctx context.Context, configFileRelease *api.Co
 		return api.NewConfigFileResponse(api.NotFoundResource, nil)
 	}
 
-	md5 := utils2.CalMd5(toPublishFile.Content)
+	if draftMd5 != "" && utils2.CalMd5(toPublishFile.Content) != draftMd5 {
+		return api.NewConfigFileResponseWithMessage(api.InvalidPublishRequest,
+			"config file has been modified after the publish request was approved")
+	}
+
+	// 加密的配置按照明文校验和计算 md5，发布的内容仍然是密文；模板配置发布渲染后的内容
+	content, plainContent, renderRsp := cs.renderConfigFile(tx, toPublishFile, requestID)
+	if renderRsp != nil {
+		return renderRsp
+	}
+
+	if checkRsp := cs.checkConfigFileContent(namespace, group, toPublishFile.Format, plainContent,
+		requestID); checkRsp != nil {
+		return checkRsp
+	}
+
+	md5 := utils2.CalMd5(plainContent)
 
 	// 获取 configFileRelease 信息
 	managedFileRelease, err := cs.storage.GetConfigFileReleaseWithAllFlag(tx, namespace, group, fileName)
@@ -91,6 +113,21 @@ func (cs *Impl) PublishConfigFile(ctx context.Context, configFileRelease *api.Co
 		return api.NewConfigFileResponse(api.StoreLayerException, nil)
 	}
 
+	// 灰度发布和正式发布共用版本序列
+	managedGrayRelease, err := cs.storage.GetConfigFileGrayReleaseWithAllFlag(tx, namespace, group, fileName)
+	if err != nil {
+		log.ConfigScope().Error("[Config][Service] get config file gray release error.",
+			zap.String("request-id", requestID),
+			zap.String("namespace", namespace),
+			zap.String("group", group),
+			zap.String("fileName", fileName),
+			zap.Error(err))
+
+		cs.recordReleaseFail(transferConfigFileReleaseAPIModel2StoreModel(configFileRelease))
+
+		return api.NewConfigFileResponse(api.StoreLayerException, nil)
+	}
+
 	releaseName := configFileRelease.Name.GetValue()
 	if releaseName == "" {
 		if managedFileRelease == nil {
@@ -107,10 +144,10 @@ func (cs *Impl) PublishConfigFile(ctx context.Context, configFileRelease *api.Co
 			Namespace: namespace,
 			Group:     group,
 			FileName:  fileName,
-			Content:   toPublishFile.Content,
+			Content:   content,
 			Comment:   configFileRelease.Comment.GetValue(),
 			Md5:       md5,
-			Version:   1,
+			Version:   nextReleaseVersion(managedGrayRelease),
 			Flag:      0,
 			CreateBy:  configFileRelease.CreateBy.GetValue(),
 			ModifyBy:  configFileRelease.CreateBy.GetValue(),
@@ -130,7 +167,9 @@ func (cs *Impl) PublishConfigFile(ctx context.Context, configFileRelease *api.Co
 			return api.NewConfigFileResponse(api.StoreLayerException, nil)
 		}
 
-		cs.RecordConfigFileReleaseHistory(ctx, createdFileRelease, utils.ReleaseTypeNormal, utils.ReleaseStatusSuccess)
+		cs.recordReleaseHistory(ctx, createdFileRelease, templateContent(toPublishFile), utils.ReleaseTypeNormal,
+			utils.ReleaseStatusSuccess)
+		cs.notifyRelease(ctx, namespace, group, fileName)
 
 		return api.NewConfigFileReleaseResponse(api.ExecuteSuccess,
 			transferConfigFileReleaseStoreModel2APIModel(createdFileRelease))
@@ -142,10 +181,10 @@ func (cs *Impl) PublishConfigFile(ctx context.Context, configFileRelease *api.Co
 		Namespace: namespace,
 		Group:     group,
 		FileName:  fileName,
-		Content:   toPublishFile.Content,
+		Content:   content,
 		Comment:   configFileRelease.Comment.GetValue(),
 		Md5:       md5,
-		Version:   managedFileRelease.Version + 1,
+		Version:   nextReleaseVersion(managedFileRelease, managedGrayRelease),
 		ModifyBy:  configFileRelease.CreateBy.GetValue(),
 	}
 
@@ -163,7 +202,9 @@ func (cs *Impl) PublishConfigFile(ctx context.Context, configFileRelease *api.Co
 		return api.NewConfigFileResponse(api.StoreLayerException, nil)
 	}
 
-	cs.RecordConfigFileReleaseHistory(ctx, updatedFileRelease, utils.ReleaseTypeNormal, utils.ReleaseStatusSuccess)
+	cs.recordReleaseHistory(ctx, updatedFileRelease, templateContent(toPublishFile), utils.ReleaseTypeNormal,
+		utils.ReleaseStatusSuccess)
+	cs.notifyRelease(ctx, namespace, group, fileName)
 
 	return api.NewConfigFileReleaseResponse(api.ExecuteSuccess,
 		transferConfigFileReleaseStoreModel2APIModel(updatedFileRelease))
@@ -201,6 +242,100 @@ func (cs *Impl) GetConfigFileRelease(ctx context.Context, namespace, group, file
 		transferConfigFileReleaseStoreModel2APIModel(fileRelease))
 }
 
+// RollbackConfigFile 回滚配置文件，把历史发布的内容作为新版本重新发布
+func (cs *Impl) RollbackConfigFile(ctx context.Context, namespace, group, fileName string,
+	historyId uint64) *api.ConfigResponse {
+	if rsp := checkReleaseParams(namespace, group, fileName); rsp != nil {
+		return rsp
+	}
+
+	requestID, _ := ctx.Value(utils.StringContext("request-id")).(string)
+
+	history, err := cs.storage.GetConfigFileReleaseHistory(historyId)
+	if err != nil {
+		logReleaseError("get config file release history error.", requestID, namespace, group, fileName, err)
+		return api.NewConfigFileResponse(api.StoreLayerException, nil)
+	}
+	if history == nil || history.Namespace != namespace || history.Group != group || history.FileName != fileName {
+		return api.NewConfigFileResponse(api.NotFoundResource, nil)
+	}
+	// 删除和失败的发布记录没有可用的配置内容
+	if history.Type == utils.ReleaseTypeDelete || history.Status != utils.ReleaseStatusSuccess {
+		return api.NewConfigFileResponse(api.InvalidConfigFileHistory, nil)
+	}
+
+	tx, newCtx, err := cs.StartTxAndSetToContext(ctx)
+	if err != nil {
+		log.ConfigScope().Error("[Config][Service] start tx error when rollback config file.",
+			zap.String("request-id", requestID),
+			zap.Error(err))
+		return api.NewConfigFileResponse(api.StoreLayerException, nil)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	configFile, err := cs.storage.GetConfigFile(tx, namespace, group, fileName)
+	if err != nil {
+		logReleaseError("get config file error.", requestID, namespace, group, fileName, err)
+		return api.NewConfigFileResponse(api.StoreLayerException, nil)
+	}
+	if configFile == nil {
+		return api.NewConfigFileResponse(api.NotFoundResource, nil)
+	}
+
+	managedFileRelease, err := cs.storage.GetConfigFileReleaseWithAllFlag(tx, namespace, group, fileName)
+	if err != nil {
+		logReleaseError("get config file release error.", requestID, namespace, group, fileName, err)
+		return api.NewConfigFileResponse(api.StoreLayerException, nil)
+	}
+	managedGrayRelease, err := cs.storage.GetConfigFileGrayReleaseWithAllFlag(tx, namespace, group, fileName)
+	if err != nil {
+		logReleaseError("get config file gray release error.", requestID, namespace, group, fileName, err)
+		return api.NewConfigFileResponse(api.StoreLayerException, nil)
+	}
+
+	md5, err := cs.contentMd5(history.Content)
+	if err != nil {
+		logReleaseError("decrypt config file release history error.", requestID, namespace, group, fileName, err)
+		return api.NewConfigFileResponseWithMessage(api.ConfigFileCryptoException, err.Error())
+	}
+
+	operator := utils.ParseOperator(ctx)
+	fileRelease := &model.ConfigFileRelease{
+		Name:      history.Name,
+		Namespace: namespace,
+		Group:     group,
+		FileName:  fileName,
+		Content:   history.Content,
+		Comment:   history.Comment,
+		Md5:       md5,
+		Version:   nextReleaseVersion(managedFileRelease, managedGrayRelease),
+		CreateBy:  operator,
+		ModifyBy:  operator,
+	}
+
+	var savedRelease *model.ConfigFileRelease
+	if managedFileRelease == nil {
+		savedRelease, err = cs.storage.CreateConfigFileRelease(tx, fileRelease)
+	} else {
+		savedRelease, err = cs.storage.UpdateConfigFileRelease(tx, fileRelease)
+	}
+	if err != nil {
+		logReleaseError("save config file release error.", requestID, namespace, group, fileName, err)
+		return api.NewConfigFileResponse(api.StoreLayerException, nil)
+	}
+
+	cs.recordReleaseHistory(newCtx, savedRelease, history.TemplateContent, utils.ReleaseTypeRollback,
+		utils.ReleaseStatusSuccess)
+
+	if err := tx.Commit(); err != nil {
+		logReleaseError("commit rollback tx error.", requestID, namespace, group, fileName, err)
+		return api.NewConfigFileResponse(api.StoreLayerException, nil)
+	}
+	cs.notifyRelease(ctx, namespace, group, fileName)
+
+	return api.NewConfigFileReleaseResponse(api.ExecuteSuccess, transferConfigFileReleaseStoreModel2APIModel(savedRelease))
+}
+
 // DeleteConfigFileRelease 删除配置文件发布，删除配置文件的时候，同步删除配置文件发布数据
 func (cs *Impl) DeleteConfigFileRelease(ctx context.Context, namespace, group, fileName, deleteBy string) *api.ConfigResponse {
 	if err := utils2.CheckFileName(utils.NewStringValue(fileName)); err != nil {
@@ -274,6 +409,7 @@ func (cs *Impl) DeleteConfigFileRelease(ctx context.Context, namespace, group, f
 		FileName:  fileName,
 		ModifyBy:  deleteBy,
 	}, utils.ReleaseTypeDelete, utils.ReleaseStatusSuccess)
+	cs.notifyRelease(ctx, namespace, group, fileName)
 
 	return api.NewConfigFileReleaseResponse(api.ExecuteSuccess, nil)
 }
@@ -326,6 +462,7 @@ func transferConfigFileReleaseAPIModel2StoreModel(release *api.ConfigFileRelease
 		Version:   version,
 		CreateBy:  createBy,
 		ModifyBy:  modifyBy,
+		GrayRule:  transferGrayRuleAPIModel2StoreModel(release.GrayRule),
 	}
 }
 
@@ -340,7 +477,7 @@ func transferConfigFileReleaseStoreModel2APIModel(release *model.ConfigFileRelea
 		Namespace:  utils.NewStringValue(release.Namespace),
 		Group:      utils.NewStringValue(release.Group),
 		FileName:   utils.NewStringValue(release.FileName),
-		Content:    utils.NewStringValue(release.Content),
+		Content:    utils.NewStringValue(utils2.MaskContent(release.Content)),
 		Comment:    utils.NewStringValue(release.Comment),
 		Md5:        utils.NewStringValue(release.Md5),
 		Version:    utils.NewUInt64Value(release.Version),
@@ -348,5 +485,7 @@ func transferConfigFileReleaseStoreModel2APIModel(release *model.ConfigFileRelea
 		CreateTime: utils.NewStringValue(time.Time2String(release.CreateTime)),
 		ModifyBy:   utils.NewStringValue(release.ModifyBy),
 		ModifyTime: utils.NewStringValue(time.Time2String(release.ModifyTime)),
+		GrayRule:   transferGrayRuleStoreModel2APIModel(release.GrayRule),
+		Encrypted:  utils.NewBoolValue(utils2.IsEncryptedContent(release.Content)),
 	}
 }
@@ -19,6 +19,7 @@ package service
 
 import (
 	"context"
+	"fmt"
 
 	"go.uber.org/zap"
 
@@ -32,6 +33,12 @@ import (
 
 // RecordConfigFileReleaseHistory 新增配置文件发布历史记录
 func (cs *Impl) RecordConfigFileReleaseHistory(ctx context.Context, fileRelease *model.ConfigFileRelease, releaseType, status string) {
+	cs.recordReleaseHistory(ctx, fileRelease, "", releaseType, status)
+}
+
+// recordReleaseHistory 新增配置文件发布历史记录，模板配置同时记录渲染前的模板内容
+func (cs *Impl) recordReleaseHistory(ctx context.Context, fileRelease *model.ConfigFileRelease, templateContent,
+	releaseType, status string) {
 	requestID, _ := ctx.Value(utils.StringContext("request-id")).(string)
 
 	namespace, group, fileName := fileRelease.Namespace, fileRelease.Group, fileRelease.FileName
@@ -47,19 +54,20 @@ func (cs *Impl) RecordConfigFileReleaseHistory(ctx context.Context, fileRelease
 	tags, _ := cs.QueryTagsByConfigFileWithAPIModels(ctx, namespace, group, fileName)
 
 	releaseHistory := &model.ConfigFileReleaseHistory{
-		Name:      fileRelease.Name,
-		Namespace: namespace,
-		Group:     group,
-		FileName:  fileName,
-		Content:   fileRelease.Content,
-		Format:    format,
-		Tags:      utils2.ToTagJsonStr(tags),
-		Comment:   fileRelease.Comment,
-		Md5:       fileRelease.Md5,
-		Type:      releaseType,
-		Status:    status,
-		CreateBy:  fileRelease.ModifyBy,
-		ModifyBy:  fileRelease.ModifyBy,
+		Name:            fileRelease.Name,
+		Namespace:       namespace,
+		Group:           group,
+		FileName:        fileName,
+		Content:         fileRelease.Content,
+		TemplateContent: templateContent,
+		Format:          format,
+		Tags:            utils2.ToTagJsonStr(tags),
+		Comment:         fileRelease.Comment,
+		Md5:             fileRelease.Md5,
+		Type:            releaseType,
+		Status:          status,
+		CreateBy:        fileRelease.ModifyBy,
+		ModifyBy:        fileRelease.ModifyBy,
 	}
 
 	err := cs.storage.CreateConfigFileReleaseHistory(cs.getTx(ctx), releaseHistory)
@@ -142,26 +150,126 @@ func (cs *Impl) GetConfigFileLatestReleaseHistory(ctx context.Context, namespace
 	return api.NewConfigFileReleaseHistoryResponse(api.ExecuteSuccess, transferReleaseHistoryStoreModel2APIModel(history))
 }
 
+// DiffConfigFileReleaseHistory 比较同一个配置文件的两条发布历史记录的内容差异
+func (cs *Impl) DiffConfigFileReleaseHistory(ctx context.Context, fromId, toId uint64) *api.ConfigResponse {
+	requestID, _ := ctx.Value(utils.StringContext("request-id")).(string)
+
+	histories := make([]*model.ConfigFileReleaseHistory, 0, 2)
+	for _, id := range []uint64{fromId, toId} {
+		history, err := cs.storage.GetConfigFileReleaseHistory(id)
+		if err != nil {
+			log.ConfigScope().Error("[Config][Service] get config file release history error.",
+				zap.String("request-id", requestID),
+				zap.Uint64("id", id),
+				zap.Error(err))
+			return api.NewConfigFileDiffResponse(api.StoreLayerException, nil)
+		}
+		if history == nil {
+			return api.NewConfigFileDiffResponse(api.NotFoundResource, nil)
+		}
+		histories = append(histories, history)
+	}
+
+	from, to := histories[0], histories[1]
+	if from.Namespace != to.Namespace || from.Group != to.Group || from.FileName != to.FileName {
+		return api.NewConfigFileResponseWithMessage(api.InvalidParameter,
+			"release histories do not belong to the same config file")
+	}
+	// 加密的配置内容在控制台只展示掩码，不提供差异比较
+	if utils2.IsEncryptedContent(from.Content) || utils2.IsEncryptedContent(to.Content) {
+		return api.NewConfigFileDiffResponse(api.NotSupportEncryptedConfigFile, nil)
+	}
+	return api.NewConfigFileDiffResponse(api.ExecuteSuccess, genConfigFileDiff(requestID, to.Format,
+		fmt.Sprintf("history:%d", from.Id), from.Content, fmt.Sprintf("history:%d", to.Id), to.Content))
+}
+
+// DiffConfigFileDraft 比较配置文件当前编辑的内容和生效中的发布内容的差异
+func (cs *Impl) DiffConfigFileDraft(ctx context.Context, namespace, group, fileName string) *api.ConfigResponse {
+	if rsp := checkReleaseParams(namespace, group, fileName); rsp != nil {
+		return rsp
+	}
+
+	requestID, _ := ctx.Value(utils.StringContext("request-id")).(string)
+
+	configFile, err := cs.storage.GetConfigFile(cs.getTx(ctx), namespace, group, fileName)
+	if err != nil {
+		logReleaseError("get config file error.", requestID, namespace, group, fileName, err)
+		return api.NewConfigFileDiffResponse(api.StoreLayerException, nil)
+	}
+	if configFile == nil {
+		return api.NewConfigFileDiffResponse(api.NotFoundResource, nil)
+	}
+
+	release, err := cs.storage.GetConfigFileRelease(cs.getTx(ctx), namespace, group, fileName)
+	if err != nil {
+		logReleaseError("get config file release error.", requestID, namespace, group, fileName, err)
+		return api.NewConfigFileDiffResponse(api.StoreLayerException, nil)
+	}
+
+	// 没有发布过的配置文件和空内容比较
+	var releaseContent string
+	if release != nil {
+		releaseContent = release.Content
+	}
+	if utils2.IsEncryptedContent(releaseContent) || utils2.IsEncryptedContent(configFile.Content) {
+		return api.NewConfigFileDiffResponse(api.NotSupportEncryptedConfigFile, nil)
+	}
+
+	return api.NewConfigFileDiffResponse(api.ExecuteSuccess, genConfigFileDiff(requestID, configFile.Format,
+		"release", releaseContent, "draft", configFile.Content))
+}
+
+// genConfigFileDiff 生成行级差异，yaml、json、properties 格式额外按照配置项比较
+func genConfigFileDiff(requestID, format, fromName, from, toName, to string) *api.ConfigFileDiff {
+	diff := &api.ConfigFileDiff{
+		From:        utils.NewStringValue(fromName),
+		To:          utils.NewStringValue(toName),
+		Format:      utils.NewStringValue(format),
+		UnifiedDiff: utils.NewStringValue(utils2.UnifiedDiff(fromName, toName, from, to)),
+	}
+
+	keyDiffs, err := utils2.DiffKeys(format, from, to)
+	if err != nil {
+		// 内容不符合格式时只返回行级差异
+		log.ConfigScope().Warn("[Config][Service] parse config file content error when diff keys.",
+			zap.String("request-id", requestID),
+			zap.String("format", format),
+			zap.Error(err))
+		return diff
+	}
+	for _, keyDiff := range keyDiffs {
+		diff.KeyDiffs = append(diff.KeyDiffs, &api.ConfigFileKeyDiff{
+			Key:      utils.NewStringValue(keyDiff.Key),
+			Type:     utils.NewStringValue(keyDiff.Type),
+			OldValue: utils.NewStringValue(keyDiff.OldValue),
+			NewValue: utils.NewStringValue(keyDiff.NewValue),
+		})
+	}
+	return diff
+}
+
 func transferReleaseHistoryStoreModel2APIModel(releaseHistory *model.ConfigFileReleaseHistory) *api.ConfigFileReleaseHistory {
 	if releaseHistory == nil {
 		return nil
 	}
 	return &api.ConfigFileReleaseHistory{
-		Id:         utils.NewUInt64Value(releaseHistory.Id),
-		Name:       utils.NewStringValue(releaseHistory.Name),
-		Namespace:  utils.NewStringValue(releaseHistory.Namespace),
-		Group:      utils.NewStringValue(releaseHistory.Group),
-		FileName:   utils.NewStringValue(releaseHistory.FileName),
-		Content:    utils.NewStringValue(releaseHistory.Content),
-		Comment:    utils.NewStringValue(releaseHistory.Comment),
-		Format:     utils.NewStringValue(releaseHistory.Format),
-		Tags:       utils2.FromTagJson(releaseHistory.Tags),
-		Md5:        utils.NewStringValue(releaseHistory.Md5),
-		Type:       utils.NewStringValue(releaseHistory.Type),
-		Status:     utils.NewStringValue(releaseHistory.Status),
-		CreateBy:   utils.NewStringValue(releaseHistory.CreateBy),
-		CreateTime: utils.NewStringValue(time.Time2String(releaseHistory.CreateTime)),
-		ModifyBy:   utils.NewStringValue(releaseHistory.ModifyBy),
-		ModifyTime: utils.NewStringValue(time.Time2String(releaseHistory.ModifyTime)),
+		Id:              utils.NewUInt64Value(releaseHistory.Id),
+		Name:            utils.NewStringValue(releaseHistory.Name),
+		Namespace:       utils.NewStringValue(releaseHistory.Namespace),
+		Group:           utils.NewStringValue(releaseHistory.Group),
+		FileName:        utils.NewStringValue(releaseHistory.FileName),
+		Content:         utils.NewStringValue(utils2.MaskContent(releaseHistory.Content)),
+		Comment:         utils.NewStringValue(releaseHistory.Comment),
+		Format:          utils.NewStringValue(releaseHistory.Format),
+		Tags:            utils2.FromTagJson(releaseHistory.Tags),
+		Md5:             utils.NewStringValue(releaseHistory.Md5),
+		Type:            utils.NewStringValue(releaseHistory.Type),
+		Status:          utils.NewStringValue(releaseHistory.Status),
+		CreateBy:        utils.NewStringValue(releaseHistory.CreateBy),
+		CreateTime:      utils.NewStringValue(time.Time2String(releaseHistory.CreateTime)),
+		ModifyBy:        utils.NewStringValue(releaseHistory.ModifyBy),
+		ModifyTime:      utils.NewStringValue(time.Time2String(releaseHistory.ModifyTime)),
+		Encrypted:       utils.NewBoolValue(utils2.IsEncryptedContent(releaseHistory.Content)),
+		TemplateContent: utils.NewStringValue(releaseHistory.TemplateContent),
 	}
 }
@@ -0,0 +1,86 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package service
+
+import (
+	api "github.com/polarismesh/polaris-server/common/api/v1"
+	"github.com/polarismesh/polaris-server/common/model"
+	"github.com/polarismesh/polaris-server/common/utils"
+	utils2 "github.com/polarismesh/polaris-server/config/utils"
+	"github.com/polarismesh/polaris-server/store"
+)
+
+// checkConfigFileTemplate 校验模板引用的基础文件和变量文件，加密的配置不支持模板
+func checkConfigFileTemplate(file *model.ConfigFile) *api.ConfigResponse {
+	if !file.IsTemplate() {
+		return nil
+	}
+	if file.Encrypted {
+		return api.NewConfigFileResponse(api.NotSupportEncryptedConfigFile, nil)
+	}
+	for _, ref := range []string{file.BaseFile, file.VariablesFile} {
+		if ref == "" {
+			continue
+		}
+		parsed := model.ParseConfigFileRef(ref)
+		if parsed == nil || utils2.CheckResourceName(utils.NewStringValue(parsed.Namespace)) != nil ||
+			utils2.CheckResourceName(utils.NewStringValue(parsed.Group)) != nil ||
+			utils2.CheckFileName(utils.NewStringValue(parsed.Name)) != nil {
+			return api.NewConfigFileResponseWithMessage(api.InvalidConfigFileTemplate,
+				"invalid config file reference "+ref)
+		}
+		if parsed.String() == file.Ref().String() {
+			return api.NewConfigFileResponseWithMessage(api.InvalidConfigFileTemplate,
+				"config file can not reference itself")
+		}
+	}
+	return nil
+}
+
+// renderConfigFile 返回待发布的内容以及用于校验、计算 md5 的明文。模板配置发布渲染后的内容，加密配置发布密文
+func (cs *Impl) renderConfigFile(tx store.Tx, file *model.ConfigFile, requestID string) (string, string,
+	*api.ConfigResponse) {
+	if !file.IsTemplate() {
+		plainContent, err := cs.decryptContent(file.Content)
+		if err != nil {
+			logReleaseError("decrypt config file error.", requestID, file.Namespace, file.Group, file.Name, err)
+			return "", "", api.NewConfigFileResponseWithMessage(api.ConfigFileCryptoException, err.Error())
+		}
+		return file.Content, plainContent, nil
+	}
+
+	rendered, err := utils2.RenderTemplate(file, func(ref *model.ConfigFileRef) (*model.ConfigFile, error) {
+		return cs.storage.GetConfigFile(tx, ref.Namespace, ref.Group, ref.Name)
+	})
+	if err != nil {
+		if _, ok := err.(*utils2.TemplateError); ok {
+			return "", "", api.NewConfigFileResponseWithMessage(api.InvalidConfigFileTemplate, err.Error())
+		}
+		logReleaseError("render config file template error.", requestID, file.Namespace, file.Group, file.Name, err)
+		return "", "", api.NewConfigFileResponse(api.StoreLayerException, nil)
+	}
+	return rendered, rendered, nil
+}
+
+// templateContent 模板配置发布时在发布历史中记录渲染前的内容
+func templateContent(file *model.ConfigFile) string {
+	if !file.IsTemplate() {
+		return ""
+	}
+	return file.Content
+}
@@ -0,0 +1,171 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package config
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	api "github.com/polarismesh/polaris-server/common/api/v1"
+	"github.com/polarismesh/polaris-server/common/log"
+	"github.com/polarismesh/polaris-server/common/utils"
+)
+
+const (
+	streamPushQueueSize = 128
+)
+
+type checkClientFunc func(ctx context.Context, files []*api.ClientConfigFileInfo) *api.ConfigClientResponse
+
+// StreamWatcher 通过 gRPC 双向流订阅配置变更的客户端，配置发布时由 watchCenter 回调推送
+type StreamWatcher struct {
+	clientId     string
+	watchCenter  *watchCenter
+	checkFunc    checkClientFunc
+	pushChan     chan *api.ConfigClientResponse
+	overflow     chan struct{}
+	overflowOnce sync.Once
+	lock         sync.Mutex
+	watchFiles   []*api.ClientConfigFileInfo
+	// closed 连接断开后不再接受订阅，避免关闭之后到达的订阅请求留下无人消费的订阅者
+	closed bool
+}
+
+// NewStreamWatcher 创建长连接订阅者，一个客户端连接对应一个订阅者
+func (cs *Server) NewStreamWatcher(clientId string) *StreamWatcher {
+	return newStreamWatcher(clientId, cs.watchCenter, cs.service.CheckClientConfigFileByVersion)
+}
+
+func newStreamWatcher(clientId string, wc *watchCenter, checkFunc checkClientFunc) *StreamWatcher {
+	return &StreamWatcher{
+		clientId:    clientId,
+		watchCenter: wc,
+		checkFunc:   checkFunc,
+		pushChan:    make(chan *api.ConfigClientResponse, streamPushQueueSize),
+		overflow:    make(chan struct{}),
+	}
+}
+
+// Subscribe 使用客户端最新的订阅列表替换之前的订阅。客户端重连后重新订阅时，版本落后的配置立即推送
+func (w *StreamWatcher) Subscribe(ctx context.Context, watchFiles []*api.ClientConfigFileInfo) {
+	w.lock.Lock()
+	if w.closed {
+		w.lock.Unlock()
+		return
+	}
+	w.watchCenter.RemoveWatcher(w.clientId, w.watchFiles)
+	w.watchFiles = watchFiles
+	w.watchCenter.AddClientWatcher(ctx, w.clientId, watchFiles, w.onRelease)
+	w.lock.Unlock()
+
+	// 先注册再比较版本，避免遗漏两者之间的发布
+	for _, file := range watchFiles {
+		rsp := w.checkFunc(ctx, []*api.ClientConfigFileInfo{file})
+		if rsp.GetCode().GetValue() != api.DataNoChange {
+			w.push(rsp)
+		}
+	}
+}
+
+// Responses 待推送给客户端的配置变更
+func (w *StreamWatcher) Responses() <-chan *api.ConfigClientResponse {
+	return w.pushChan
+}
+
+// Overflow 客户端消费过慢导致推送队列已满时关闭，此时需要断开连接让客户端重连后重新订阅
+func (w *StreamWatcher) Overflow() <-chan struct{} {
+	return w.overflow
+}
+
+// WaitChanges 用于长轮询，等待订阅的配置发布，收到变更后一并取出已经到达的其他变更。
+// 超时、ctx 结束或者推送队列已满时返回已经收到的变更，同一个配置文件只返回最新的版本
+func (w *StreamWatcher) WaitChanges(ctx context.Context, timeout time.Duration) []*api.ClientConfigFileInfo {
+	t := time.NewTimer(timeout)
+	defer t.Stop()
+
+	var rsp *api.ConfigClientResponse
+	select {
+	case rsp = <-w.pushChan:
+	case <-w.overflow:
+	case <-ctx.Done():
+	case <-t.C:
+	}
+	if rsp == nil {
+		return nil
+	}
+
+	changes := []*api.ClientConfigFileInfo{rsp.GetConfigFile()}
+	for {
+		select {
+		case rsp = <-w.pushChan:
+			changes = append(changes, rsp.GetConfigFile())
+		default:
+			return latestChanges(changes)
+		}
+	}
+}
+
+func latestChanges(changes []*api.ClientConfigFileInfo) []*api.ClientConfigFileInfo {
+	indexes := make(map[string]int, len(changes))
+	latest := make([]*api.ClientConfigFileInfo, 0, len(changes))
+	for _, change := range changes {
+		if change == nil {
+			continue
+		}
+		fileId := utils.GenFileId(change.GetNamespace().GetValue(), change.GetGroup().GetValue(),
+			change.GetFileName().GetValue())
+		if idx, ok := indexes[fileId]; ok {
+			if change.GetVersion().GetValue() > latest[idx].GetVersion().GetValue() {
+				latest[idx] = change
+			}
+			continue
+		}
+		indexes[fileId] = len(latest)
+		latest = append(latest, change)
+	}
+	return latest
+}
+
+// Close 取消全部订阅，关闭之后的订阅请求直接忽略
+func (w *StreamWatcher) Close() {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	w.closed = true
+	w.watchCenter.RemoveWatcher(w.clientId, w.watchFiles)
+	w.watchFiles = nil
+}
+
+func (w *StreamWatcher) onRelease(clientId string, rsp *api.ConfigClientResponse) bool {
+	return w.push(rsp)
+}
+
+func (w *StreamWatcher) push(rsp *api.ConfigClientResponse) bool {
+	select {
+	case w.pushChan <- rsp:
+		return true
+	default:
+		w.overflowOnce.Do(func() {
+			log.ConfigScope().Warn("[Config][Watcher] stream push queue is full.", zap.String("clientId", w.clientId))
+			close(w.overflow)
+		})
+		return false
+	}
+}
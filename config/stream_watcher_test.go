@@ -0,0 +1,53 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package config
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/wrappers"
+
+	api "github.com/polarismesh/polaris-server/common/api/v1"
+)
+
+// TestStreamWatcherSubscribeAfterClose 连接关闭之后到达的订阅请求不能留下订阅者
+func TestStreamWatcherSubscribeAfterClose(t *testing.T) {
+	wc := &watchCenter{configFileWatchers: new(sync.Map), lock: new(sync.Mutex)}
+	checkFunc := func(context.Context, []*api.ClientConfigFileInfo) *api.ConfigClientResponse {
+		return &api.ConfigClientResponse{Code: &wrappers.UInt32Value{Value: api.DataNoChange}}
+	}
+	files := []*api.ClientConfigFileInfo{{
+		Namespace: &wrappers.StringValue{Value: "default"},
+		Group:     &wrappers.StringValue{Value: "app"},
+		FileName:  &wrappers.StringValue{Value: "app.yaml"},
+	}}
+
+	watcher := newStreamWatcher("127.0.0.1:5001@aaaaaaaa", wc, checkFunc)
+	watcher.Subscribe(context.Background(), files)
+	if listeners := wc.Listeners(); len(listeners) != 1 {
+		t.Fatalf("listeners: %+v", listeners)
+	}
+
+	watcher.Close()
+	watcher.Subscribe(context.Background(), files)
+	if listeners := wc.Listeners(); len(listeners) != 0 {
+		t.Fatalf("subscribe after close should be ignored: %+v", listeners)
+	}
+}
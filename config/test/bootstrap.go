@@ -35,6 +35,8 @@ import (
 
 	_ "github.com/go-sql-driver/mysql"
 
+	_ "github.com/polarismesh/polaris-server/plugin/eventbus/memory"
+	_ "github.com/polarismesh/polaris-server/plugin/kms/local"
 	_ "github.com/polarismesh/polaris-server/store/sqldb"
 )
 
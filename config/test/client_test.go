@@ -25,6 +25,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	api "github.com/polarismesh/polaris-server/common/api/v1"
+	"github.com/polarismesh/polaris-server/common/model"
 	"github.com/polarismesh/polaris-server/common/utils"
 	utils2 "github.com/polarismesh/polaris-server/config/utils"
 )
@@ -267,3 +268,64 @@ func TestDeleteConfigFile(t *testing.T) {
 	rsp4 := configService.Service().GetConfigFileForClient(defaultCtx, testNamespace, testGroup, testFile, 2)
 	assert.Equal(t, uint32(api.NotFoundResource), rsp4.Code.GetValue())
 }
+
+// TestStreamWatchConfigFile 测试长连接订阅，配置发布时推送，重新订阅时补推版本落后的配置
+func TestStreamWatchConfigFile(t *testing.T) {
+	if err := clearTestData(); err != nil {
+		t.FailNow()
+	}
+
+	watcher := configService.NewStreamWatcher(randomStr())
+	defer watcher.Close()
+	watcher.Subscribe(defaultCtx, assembleDefaultClientConfigFile(0))
+
+	configFile := assembleConfigFile()
+	rsp := configService.Service().CreateConfigFile(defaultCtx, configFile)
+	assert.Equal(t, api.ExecuteSuccess, rsp.Code.GetValue())
+
+	for i := 1; i <= 2; i++ {
+		rsp2 := configService.Service().PublishConfigFile(defaultCtx, assembleConfigFileRelease(configFile))
+		assert.Equal(t, api.ExecuteSuccess, rsp2.Code.GetValue())
+
+		select {
+		case pushed := <-watcher.Responses():
+			assert.Equal(t, uint64(i), pushed.ConfigFile.Version.GetValue())
+		case <-time.After(3 * time.Second):
+			t.Fatalf("config change of version %d not pushed", i)
+		}
+	}
+
+	// 客户端重连后使用旧版本重新订阅，立即推送最新版本
+	watcher.Subscribe(defaultCtx, assembleDefaultClientConfigFile(1))
+	select {
+	case pushed := <-watcher.Responses():
+		assert.Equal(t, uint64(2), pushed.ConfigFile.Version.GetValue())
+	case <-time.After(time.Second):
+		t.Fatal("outdated config file not pushed after resubscribe")
+	}
+}
+
+// TestConfigFileListeners 测试查询订阅配置文件的客户端以及客户端持有的版本
+func TestConfigFileListeners(t *testing.T) {
+	clientId := randomStr()
+	watchConfigFiles := assembleDefaultClientConfigFile(3)
+	configService.WatchCenter().AddWatcher(clientId, watchConfigFiles, func(clientId string, rsp *api.ConfigClientResponse) bool {
+		return true
+	})
+	defer configService.WatchCenter().RemoveWatcher(clientId, watchConfigFiles)
+
+	var found *model.ConfigFileListener
+	for _, listener := range configService.WatchCenter().Listeners() {
+		if listener.ClientId == clientId {
+			found = listener
+		}
+	}
+	assert.NotNil(t, found)
+	assert.Equal(t, testNamespace, found.Namespace)
+	assert.Equal(t, testGroup, found.Group)
+	assert.Equal(t, testFile, found.FileName)
+	assert.Equal(t, uint64(3), found.Version)
+
+	rsp := configService.Service().QueryConfigFileListeners(defaultCtx, testNamespace, testGroup, testFile, 0, 0)
+	assert.Equal(t, api.InvalidParameter, rsp.Code.GetValue())
+}
@@ -37,6 +37,10 @@ func clearTestData() error {
 	if err != nil {
 		return err
 	}
+	_, err = db.Exec("delete from config_file_gray_release where namespace = ? ", testNamespace)
+	if err != nil {
+		return err
+	}
 	_, err = db.Exec("delete from config_file_release_history where namespace = ? ", testNamespace)
 	if err != nil {
 		return err
@@ -45,6 +49,10 @@ func clearTestData() error {
 	if err != nil {
 		return err
 	}
+	_, err = db.Exec("delete from config_file_publish_request where namespace = ? ", testNamespace)
+	if err != nil {
+		return err
+	}
 	_, err = db.Exec("delete from namespace where name = ? ", testNamespace)
 	if err != nil {
 		return err
@@ -0,0 +1,82 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	api "github.com/polarismesh/polaris-server/common/api/v1"
+	"github.com/polarismesh/polaris-server/common/utils"
+	utils2 "github.com/polarismesh/polaris-server/config/utils"
+)
+
+// TestEncryptedConfigFile 测试加密配置文件，控制台只返回掩码，客户端拉取到明文
+func TestEncryptedConfigFile(t *testing.T) {
+	if err := clearTestData(); err != nil {
+		t.FailNow()
+	}
+
+	configFile := assembleConfigFile()
+	configFile.Encrypted = utils.NewBoolValue(true)
+	plainContent := configFile.Content.GetValue()
+	rsp := configService.Service().CreateConfigFile(defaultCtx, configFile)
+	assert.Equal(t, api.ExecuteSuccess, rsp.Code.GetValue())
+	assert.True(t, rsp.ConfigFile.Encrypted.GetValue())
+	assert.Equal(t, utils2.MaskedContent, rsp.ConfigFile.Content.GetValue())
+
+	// 存储的是密文
+	var storedContent string
+	err := db.QueryRow("select content from config_file where namespace = ? and `group` = ? and name = ?",
+		testNamespace, testGroup, testFile).Scan(&storedContent)
+	assert.Nil(t, err)
+	assert.True(t, utils2.IsEncryptedContent(storedContent))
+
+	rsp2 := configService.Service().PublishConfigFile(defaultCtx, assembleConfigFileRelease(configFile))
+	assert.Equal(t, api.ExecuteSuccess, rsp2.Code.GetValue())
+	assert.Equal(t, utils2.MaskedContent, rsp2.ConfigFileRelease.Content.GetValue())
+	assert.Equal(t, utils2.CalMd5(plainContent), rsp2.ConfigFileRelease.Md5.GetValue())
+
+	rsp3 := configService.Service().GetConfigFileRichInfo(defaultCtx, testNamespace, testGroup, testFile)
+	assert.Equal(t, api.ExecuteSuccess, rsp3.Code.GetValue())
+	assert.Equal(t, utils.ReleaseStatusSuccess, rsp3.ConfigFile.Status.GetValue())
+
+	rsp4 := configService.Service().GetConfigFileLatestReleaseHistory(defaultCtx, testNamespace, testGroup, testFile)
+	assert.Equal(t, api.ExecuteSuccess, rsp4.Code.GetValue())
+	assert.Equal(t, utils2.MaskedContent, rsp4.ConfigFileReleaseHistory.Content.GetValue())
+	assert.True(t, rsp4.ConfigFileReleaseHistory.Encrypted.GetValue())
+
+	rsp5 := configService.Service().GetConfigFileForClient(defaultCtx, testNamespace, testGroup, testFile, 0)
+	assert.Equal(t, api.ExecuteSuccess, rsp5.Code.GetValue())
+	assert.Equal(t, plainContent, rsp5.ConfigFile.Content.GetValue())
+	assert.Equal(t, utils2.CalMd5(plainContent), rsp5.ConfigFile.Md5.GetValue())
+
+	// 控制台提交掩码时沿用原来的内容，只修改备注
+	configFile.Content = utils.NewStringValue(utils2.MaskedContent)
+	configFile.Comment = utils.NewStringValue("only comment changed")
+	rsp6 := configService.Service().UpdateConfigFile(defaultCtx, configFile)
+	assert.Equal(t, api.ExecuteSuccess, rsp6.Code.GetValue())
+
+	rsp7 := configService.Service().PublishConfigFile(defaultCtx, assembleConfigFileRelease(configFile))
+	assert.Equal(t, api.ExecuteSuccess, rsp7.Code.GetValue())
+	assert.Equal(t, utils2.CalMd5(plainContent), rsp7.ConfigFileRelease.Md5.GetValue())
+
+	rsp8 := configService.Service().DiffConfigFileDraft(defaultCtx, testNamespace, testGroup, testFile)
+	assert.Equal(t, api.NotSupportEncryptedConfigFile, rsp8.Code.GetValue())
+}
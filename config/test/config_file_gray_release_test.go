@@ -0,0 +1,100 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"github.com/stretchr/testify/assert"
+
+	api "github.com/polarismesh/polaris-server/common/api/v1"
+	"github.com/polarismesh/polaris-server/common/utils"
+)
+
+// TestGrayPublishConfigFile 测试灰度发布，只有命中灰度规则的客户端获取到灰度内容
+func TestGrayPublishConfigFile(t *testing.T) {
+	if err := clearTestData(); err != nil {
+		t.FailNow()
+	}
+
+	configFile := assembleConfigFile()
+	rsp := configService.Service().CreateConfigFile(defaultCtx, configFile)
+	assert.Equal(t, api.ExecuteSuccess, rsp.Code.GetValue())
+
+	grayRelease := assembleConfigFileRelease(configFile)
+	grayRelease.GrayRule = &api.ConfigFileGrayRule{
+		ClientIps: []*wrappers.StringValue{utils.NewStringValue("127.0.0.2")},
+	}
+
+	// 没有正式发布时不能灰度发布
+	rsp2 := configService.Service().GrayPublishConfigFile(defaultCtx, grayRelease)
+	assert.Equal(t, api.NotReleasedConfigFile, rsp2.Code.GetValue())
+
+	rsp3 := configService.Service().PublishConfigFile(defaultCtx, assembleConfigFileRelease(configFile))
+	assert.Equal(t, api.ExecuteSuccess, rsp3.Code.GetValue())
+
+	configFile.Content = utils.NewStringValue("gray content")
+	rsp4 := configService.Service().UpdateConfigFile(defaultCtx, configFile)
+	assert.Equal(t, api.ExecuteSuccess, rsp4.Code.GetValue())
+	rsp5 := configService.Service().GrayPublishConfigFile(defaultCtx, grayRelease)
+	assert.Equal(t, api.ExecuteSuccess, rsp5.Code.GetValue())
+	assert.Equal(t, uint64(2), rsp5.ConfigFileRelease.Version.GetValue())
+
+	time.Sleep(1200 * time.Millisecond)
+
+	grayCtx := context.WithValue(defaultCtx, utils.StringContext("client-ip"), "127.0.0.2")
+	rsp6 := configService.Service().GetConfigFileForClient(grayCtx, testNamespace, testGroup, testFile, 0)
+	assert.Equal(t, api.ExecuteSuccess, rsp6.Code.GetValue())
+	assert.Equal(t, "gray content", rsp6.ConfigFile.Content.GetValue())
+	assert.Equal(t, uint64(2), rsp6.ConfigFile.Version.GetValue())
+
+	rsp7 := configService.Service().GetConfigFileForClient(defaultCtx, testNamespace, testGroup, testFile, 0)
+	assert.Equal(t, api.ExecuteSuccess, rsp7.Code.GetValue())
+	assert.Equal(t, "k1=v1,k2=v2", rsp7.ConfigFile.Content.GetValue())
+	assert.Equal(t, uint64(1), rsp7.ConfigFile.Version.GetValue())
+
+	// 全量发布后所有客户端都获取到灰度内容
+	rsp8 := configService.Service().PromoteConfigFileGrayRelease(defaultCtx, testNamespace, testGroup, testFile, operator)
+	assert.Equal(t, api.ExecuteSuccess, rsp8.Code.GetValue())
+
+	time.Sleep(1200 * time.Millisecond)
+
+	rsp9 := configService.Service().GetConfigFileForClient(defaultCtx, testNamespace, testGroup, testFile, 0)
+	assert.Equal(t, api.ExecuteSuccess, rsp9.Code.GetValue())
+	assert.Equal(t, "gray content", rsp9.ConfigFile.Content.GetValue())
+	assert.Equal(t, uint64(3), rsp9.ConfigFile.Version.GetValue())
+
+	rsp10 := configService.Service().GetConfigFileGrayRelease(defaultCtx, testNamespace, testGroup, testFile)
+	assert.Equal(t, api.ExecuteSuccess, rsp10.Code.GetValue())
+	assert.Nil(t, rsp10.ConfigFileRelease)
+
+	// 正式发布被删除后放弃灰度，不记录没有内容的发布历史
+	rsp11 := configService.Service().GrayPublishConfigFile(defaultCtx, grayRelease)
+	assert.Equal(t, api.ExecuteSuccess, rsp11.Code.GetValue())
+	rsp12 := configService.Service().DeleteConfigFileRelease(defaultCtx, testNamespace, testGroup, testFile, operator)
+	assert.Equal(t, api.ExecuteSuccess, rsp12.Code.GetValue())
+	rsp13 := configService.Service().AbortConfigFileGrayRelease(defaultCtx, testNamespace, testGroup, testFile, operator)
+	assert.Equal(t, api.ExecuteSuccess, rsp13.Code.GetValue())
+
+	rsp14 := configService.Service().GetConfigFileLatestReleaseHistory(defaultCtx, testNamespace, testGroup, testFile)
+	assert.Equal(t, api.ExecuteSuccess, rsp14.Code.GetValue())
+	assert.Equal(t, utils.ReleaseTypeDelete, rsp14.ConfigFileReleaseHistory.Type.GetValue())
+}
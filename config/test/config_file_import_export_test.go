@@ -0,0 +1,131 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	api "github.com/polarismesh/polaris-server/common/api/v1"
+	"github.com/polarismesh/polaris-server/common/utils"
+	"github.com/polarismesh/polaris-server/config/service"
+	utils2 "github.com/polarismesh/polaris-server/config/utils"
+	"github.com/polarismesh/polaris-server/plugin"
+)
+
+// TestConfigFileImportExport 测试导出配置文件组后按照不同的冲突策略导入
+func TestConfigFileImportExport(t *testing.T) {
+	if err := clearTestData(); err != nil {
+		t.FailNow()
+	}
+
+	configFile := assembleConfigFile()
+	configFile.Comment = utils.NewStringValue("exported")
+	rsp := configService.Service().CreateConfigFile(defaultCtx, configFile)
+	assert.Equal(t, api.ExecuteSuccess, rsp.Code.GetValue())
+
+	buf := &bytes.Buffer{}
+	rsp = configService.Service().ExportConfigFiles(defaultCtx, testNamespace, testGroup, buf)
+	assert.Equal(t, api.ExecuteSuccess, rsp.Code.GetValue())
+	archive := buf.Bytes()
+
+	t.Run("conflict-fail", func(t *testing.T) {
+		batchRsp := configService.Service().ImportConfigFiles(defaultCtx, "", archive, service.ImportConflictFail,
+			false, operator)
+		assert.Equal(t, api.ExistedResource, batchRsp.Code.GetValue())
+		assert.Equal(t, 1, len(batchRsp.Responses))
+		assert.Equal(t, testFile, batchRsp.Responses[0].ConfigFile.Name.GetValue())
+	})
+
+	t.Run("conflict-skip", func(t *testing.T) {
+		batchRsp := configService.Service().ImportConfigFiles(defaultCtx, "", archive, service.ImportConflictSkip,
+			false, operator)
+		assert.Equal(t, api.ExecuteSuccess, batchRsp.Code.GetValue())
+		assert.Equal(t, api.NoNeedUpdate, batchRsp.Responses[0].Code.GetValue())
+	})
+
+	t.Run("conflict-overwrite-and-publish", func(t *testing.T) {
+		configFile.Content = utils.NewStringValue("changed")
+		rsp := configService.Service().UpdateConfigFile(defaultCtx, configFile)
+		assert.Equal(t, api.ExecuteSuccess, rsp.Code.GetValue())
+
+		batchRsp := configService.Service().ImportConfigFiles(defaultCtx, "", archive, service.ImportConflictOverwrite,
+			true, operator)
+		assert.Equal(t, api.ExecuteSuccess, batchRsp.Code.GetValue())
+		// 保存草稿和发布的结果分开返回
+		assert.Equal(t, 2, len(batchRsp.Responses))
+		assert.Equal(t, testFile, batchRsp.Responses[0].ConfigFile.Name.GetValue())
+		assert.Equal(t, testFile, batchRsp.Responses[1].ConfigFileRelease.FileName.GetValue())
+		assert.Equal(t, api.ExecuteSuccess, batchRsp.Responses[1].Code.GetValue())
+
+		rsp = configService.Service().GetConfigFileRichInfo(defaultCtx, testNamespace, testGroup, testFile)
+		assert.Equal(t, "k1=v1,k2=v2", rsp.ConfigFile.Content.GetValue())
+		assert.Equal(t, "exported", rsp.ConfigFile.Comment.GetValue())
+		assert.Equal(t, 3, len(rsp.ConfigFile.Tags))
+		assert.Equal(t, utils.ReleaseStatusSuccess, rsp.ConfigFile.Status.GetValue())
+	})
+
+	t.Run("invalid-archive", func(t *testing.T) {
+		batchRsp := configService.Service().ImportConfigFiles(defaultCtx, "", []byte("invalid"),
+			service.ImportConflictSkip, false, operator)
+		assert.Equal(t, api.InvalidConfigFileArchive, batchRsp.Code.GetValue())
+
+		batchRsp = configService.Service().ImportConfigFiles(defaultCtx, "", archive, "unknown", false, operator)
+		assert.Equal(t, api.InvalidParameter, batchRsp.Code.GetValue())
+	})
+}
+
+// TestEncryptedConfigFileImportExport 测试加密配置导出密文，导入时解开数据密钥后重新加密
+func TestEncryptedConfigFileImportExport(t *testing.T) {
+	if err := clearTestData(); err != nil {
+		t.FailNow()
+	}
+
+	configFile := assembleConfigFile()
+	configFile.Encrypted = utils.NewBoolValue(true)
+	plainContent := configFile.Content.GetValue()
+	rsp := configService.Service().CreateConfigFile(defaultCtx, configFile)
+	assert.Equal(t, api.ExecuteSuccess, rsp.Code.GetValue())
+
+	buf := &bytes.Buffer{}
+	rsp = configService.Service().ExportConfigFiles(defaultCtx, testNamespace, testGroup, buf)
+	assert.Equal(t, api.ExecuteSuccess, rsp.Code.GetValue())
+
+	// 压缩包中只有密文
+	manifest, err := utils2.ReadConfigArchive(buf.Bytes())
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(manifest.Files))
+	assert.True(t, manifest.Files[0].Encrypted)
+	assert.True(t, utils2.IsEncryptedContent(manifest.Files[0].Content))
+	assert.NotContains(t, manifest.Files[0].Content, plainContent)
+
+	batchRsp := configService.Service().ImportConfigFiles(defaultCtx, "", buf.Bytes(), service.ImportConflictOverwrite,
+		false, operator)
+	assert.Equal(t, api.ExecuteSuccess, batchRsp.Code.GetValue())
+
+	var storedContent string
+	err = db.QueryRow("select content from config_file where namespace = ? and `group` = ? and name = ?",
+		testNamespace, testGroup, testFile).Scan(&storedContent)
+	assert.Nil(t, err)
+	assert.True(t, utils2.IsEncryptedContent(storedContent))
+	content, err := utils2.DecryptContent(plugin.GetKMS(), storedContent)
+	assert.Nil(t, err)
+	assert.Equal(t, plainContent, content)
+}
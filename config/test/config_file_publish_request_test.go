@@ -0,0 +1,218 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"github.com/stretchr/testify/assert"
+
+	api "github.com/polarismesh/polaris-server/common/api/v1"
+	"github.com/polarismesh/polaris-server/common/utils"
+)
+
+const (
+	testApprover = "approver"
+)
+
+func assemblePublishRequest(planTime string) *api.ConfigFilePublishRequest {
+	return &api.ConfigFilePublishRequest{
+		Namespace:    utils.NewStringValue(testNamespace),
+		Group:        utils.NewStringValue(testGroup),
+		FileName:     utils.NewStringValue(testFile),
+		Comment:      utils.NewStringValue("publish request"),
+		ApproveUsers: []*wrappers.StringValue{utils.NewStringValue(testApprover)},
+		PlanTime:     utils.NewStringValue(planTime),
+		CreateBy:     utils.NewStringValue(operator),
+	}
+}
+
+func operatePublishRequest(id string) *api.ConfigFilePublishRequest {
+	return &api.ConfigFilePublishRequest{
+		Id:       utils.NewStringValue(id),
+		ModifyBy: utils.NewStringValue(operator),
+	}
+}
+
+// userCtx 模拟鉴权流程解析 token 后写入请求上下文的用户
+func userCtx(userID string) context.Context {
+	return context.WithValue(defaultCtx, utils.ContextUserIDKey, userID)
+}
+
+// TestConfigFilePublishRequest 测试发布申请的审批流程以及到达计划时间后的自动发布
+func TestConfigFilePublishRequest(t *testing.T) {
+	if err := clearTestData(); err != nil {
+		t.FailNow()
+	}
+
+	rsp := configService.Service().CreateConfigFile(defaultCtx, assembleConfigFile())
+	assert.Equal(t, api.ExecuteSuccess, rsp.Code.GetValue())
+
+	t.Run("reject-and-resubmit-then-execute", func(t *testing.T) {
+		rsp := configService.Service().CreateConfigFilePublishRequest(userCtx(operator), assemblePublishRequest(""))
+		assert.Equal(t, api.ExecuteSuccess, rsp.Code.GetValue())
+		id := rsp.ConfigFilePublishRequest.Id.GetValue()
+		assert.Equal(t, utils.PublishRequestStatusDraft, rsp.ConfigFilePublishRequest.Status.GetValue())
+
+		// 未提交的申请不能审批
+		rsp = configService.Service().ApproveConfigFilePublishRequest(userCtx(testApprover), operatePublishRequest(id))
+		assert.Equal(t, api.InvalidPublishRequestStatus, rsp.Code.GetValue())
+
+		// 操作者以请求上下文中的用户为准，不能通过 modifyBy 字段冒用
+		rsp = configService.Service().SubmitConfigFilePublishRequest(defaultCtx, operatePublishRequest(id))
+		assert.Equal(t, api.NotAllowedAccess, rsp.Code.GetValue())
+
+		rsp = configService.Service().SubmitConfigFilePublishRequest(userCtx(operator), operatePublishRequest(id))
+		assert.Equal(t, api.ExecuteSuccess, rsp.Code.GetValue())
+
+		rsp = configService.Service().RejectConfigFilePublishRequest(userCtx(testApprover), operatePublishRequest(id))
+		assert.Equal(t, api.ExecuteSuccess, rsp.Code.GetValue())
+		assert.Equal(t, utils.PublishRequestStatusRejected, rsp.ConfigFilePublishRequest.Status.GetValue())
+
+		rsp = configService.Service().SubmitConfigFilePublishRequest(userCtx(operator), operatePublishRequest(id))
+		assert.Equal(t, api.ExecuteSuccess, rsp.Code.GetValue())
+
+		// 非审批人不能审批
+		rsp = configService.Service().ApproveConfigFilePublishRequest(userCtx(operator), operatePublishRequest(id))
+		assert.Equal(t, api.NotAllowedAccess, rsp.Code.GetValue())
+
+		// 审批人以请求上下文中的用户为准，不能通过审批人字段冒用
+		spoofRequest := operatePublishRequest(id)
+		spoofRequest.ApproveBy = utils.NewStringValue(testApprover)
+		rsp = configService.Service().ApproveConfigFilePublishRequest(defaultCtx, spoofRequest)
+		assert.Equal(t, api.NotAllowedAccess, rsp.Code.GetValue())
+
+		rsp = configService.Service().ApproveConfigFilePublishRequest(userCtx(testApprover), operatePublishRequest(id))
+		assert.Equal(t, api.ExecuteSuccess, rsp.Code.GetValue())
+		assert.Equal(t, utils.PublishRequestStatusApproved, rsp.ConfigFilePublishRequest.Status.GetValue())
+
+		rsp = configService.Service().ExecuteConfigFilePublishRequest(userCtx(operator), operatePublishRequest(id))
+		assert.Equal(t, api.ExecuteSuccess, rsp.Code.GetValue())
+		assert.Equal(t, utils.PublishRequestStatusPublished, rsp.ConfigFilePublishRequest.Status.GetValue())
+
+		rsp = configService.Service().GetConfigFileRelease(defaultCtx, testNamespace, testGroup, testFile)
+		assert.Equal(t, api.ExecuteSuccess, rsp.Code.GetValue())
+		assert.NotNil(t, rsp.ConfigFileRelease)
+
+		// 已经发布的申请不能取消
+		rsp = configService.Service().CancelConfigFilePublishRequest(userCtx(operator), operatePublishRequest(id))
+		assert.Equal(t, api.InvalidPublishRequestStatus, rsp.Code.GetValue())
+	})
+
+	t.Run("scheduled-publish", func(t *testing.T) {
+		planTime := time.Now().Add(time.Hour)
+		rsp := configService.Service().CreateConfigFilePublishRequest(userCtx(operator),
+			assemblePublishRequest(planTime.Format("2006-01-02 15:04:05")))
+		assert.Equal(t, api.ExecuteSuccess, rsp.Code.GetValue())
+		id := rsp.ConfigFilePublishRequest.Id.GetValue()
+
+		rsp = configService.Service().SubmitConfigFilePublishRequest(userCtx(operator), operatePublishRequest(id))
+		assert.Equal(t, api.ExecuteSuccess, rsp.Code.GetValue())
+		rsp = configService.Service().ApproveConfigFilePublishRequest(userCtx(testApprover), operatePublishRequest(id))
+		assert.Equal(t, api.ExecuteSuccess, rsp.Code.GetValue())
+		assert.Equal(t, utils.PublishRequestStatusScheduled, rsp.ConfigFilePublishRequest.Status.GetValue())
+
+		// 调度中的申请只能由调度器在计划时间发布
+		rsp = configService.Service().ExecuteConfigFilePublishRequest(userCtx(operator), operatePublishRequest(id))
+		assert.Equal(t, api.InvalidPublishRequestStatus, rsp.Code.GetValue())
+
+		// 未到计划时间不发布
+		published := configService.Service().PublishDueConfigFilePublishRequests(defaultCtx, time.Now())
+		assert.Equal(t, 0, published)
+
+		published = configService.Service().PublishDueConfigFilePublishRequests(defaultCtx, planTime.Add(time.Second))
+		assert.Equal(t, 1, published)
+
+		rsp = configService.Service().GetConfigFilePublishRequest(defaultCtx, id)
+		assert.Equal(t, utils.PublishRequestStatusPublished, rsp.ConfigFilePublishRequest.Status.GetValue())
+		assert.Equal(t, testApprover, rsp.ConfigFilePublishRequest.ApproveBy.GetValue())
+	})
+
+	t.Run("cancel-and-query", func(t *testing.T) {
+		rsp := configService.Service().CreateConfigFilePublishRequest(userCtx(operator), assemblePublishRequest(""))
+		assert.Equal(t, api.ExecuteSuccess, rsp.Code.GetValue())
+		id := rsp.ConfigFilePublishRequest.Id.GetValue()
+
+		rsp = configService.Service().CancelConfigFilePublishRequest(userCtx(operator), operatePublishRequest(id))
+		assert.Equal(t, api.ExecuteSuccess, rsp.Code.GetValue())
+
+		queryRsp := configService.Service().QueryConfigFilePublishRequests(defaultCtx, testNamespace, testGroup,
+			testFile, "", 0, 10)
+		assert.Equal(t, api.ExecuteSuccess, queryRsp.Code.GetValue())
+		assert.Equal(t, uint32(3), queryRsp.Total.GetValue())
+
+		queryRsp = configService.Service().QueryConfigFilePublishRequests(defaultCtx, testNamespace, testGroup,
+			testFile, utils.PublishRequestStatusCancelled, 0, 10)
+		assert.Equal(t, uint32(1), queryRsp.Total.GetValue())
+		assert.Equal(t, id, queryRsp.ConfigFilePublishRequests[0].Id.GetValue())
+	})
+
+	t.Run("modified-after-approval", func(t *testing.T) {
+		rsp := configService.Service().CreateConfigFilePublishRequest(userCtx(operator), assemblePublishRequest(""))
+		assert.Equal(t, api.ExecuteSuccess, rsp.Code.GetValue())
+		id := rsp.ConfigFilePublishRequest.Id.GetValue()
+
+		rsp = configService.Service().SubmitConfigFilePublishRequest(userCtx(operator), operatePublishRequest(id))
+		assert.Equal(t, api.ExecuteSuccess, rsp.Code.GetValue())
+		rsp = configService.Service().ApproveConfigFilePublishRequest(userCtx(testApprover),
+			operatePublishRequest(id))
+		assert.Equal(t, api.ExecuteSuccess, rsp.Code.GetValue())
+
+		// 审批之后修改草稿，发布时拒绝发布未经审批的内容
+		configFile := assembleConfigFile()
+		configFile.Content = utils.NewStringValue("modified after approval")
+		rsp = configService.Service().UpdateConfigFile(defaultCtx, configFile)
+		assert.Equal(t, api.ExecuteSuccess, rsp.Code.GetValue())
+
+		rsp = configService.Service().ExecuteConfigFilePublishRequest(userCtx(operator), operatePublishRequest(id))
+		assert.Equal(t, api.InvalidPublishRequest, rsp.Code.GetValue())
+		assert.Equal(t, utils.PublishRequestStatusFailed, rsp.ConfigFilePublishRequest.Status.GetValue())
+	})
+
+	t.Run("publishing-timeout", func(t *testing.T) {
+		rsp := configService.Service().CreateConfigFilePublishRequest(userCtx(operator), assemblePublishRequest(""))
+		assert.Equal(t, api.ExecuteSuccess, rsp.Code.GetValue())
+		id := rsp.ConfigFilePublishRequest.Id.GetValue()
+
+		// 模拟执行发布的节点在发布中退出
+		_, err := db.Exec("update config_file_publish_request set status = ?, modify_time = ? where id = ?",
+			utils.PublishRequestStatusPublishing, time.Now().Add(-time.Hour), id)
+		assert.Nil(t, err)
+
+		configService.Service().PublishDueConfigFilePublishRequests(defaultCtx, time.Now())
+		rsp = configService.Service().GetConfigFilePublishRequest(defaultCtx, id)
+		assert.Equal(t, utils.PublishRequestStatusFailed, rsp.ConfigFilePublishRequest.Status.GetValue())
+	})
+
+	t.Run("invalid-request", func(t *testing.T) {
+		publishRequest := assemblePublishRequest("")
+		publishRequest.ApproveUsers = nil
+		rsp := configService.Service().CreateConfigFilePublishRequest(userCtx(operator), publishRequest)
+		assert.Equal(t, api.InvalidPublishRequest, rsp.Code.GetValue())
+
+		rsp = configService.Service().CreateConfigFilePublishRequest(userCtx(operator),
+			assemblePublishRequest(time.Now().Add(-time.Hour).Format("2006-01-02 15:04:05")))
+		assert.Equal(t, api.InvalidPublishRequest, rsp.Code.GetValue())
+
+		rsp = configService.Service().GetConfigFilePublishRequest(defaultCtx, "not-exist")
+		assert.Equal(t, api.NotFoundResource, rsp.Code.GetValue())
+	})
+}
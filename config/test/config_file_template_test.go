@@ -0,0 +1,94 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	api "github.com/polarismesh/polaris-server/common/api/v1"
+	"github.com/polarismesh/polaris-server/common/utils"
+)
+
+// TestConfigFileTemplate 测试继承基础文件并替换变量的模板配置，发布渲染后的内容，发布历史同时保留模板内容
+func TestConfigFileTemplate(t *testing.T) {
+	if err := clearTestData(); err != nil {
+		t.FailNow()
+	}
+
+	newFile := func(group, name, content string) *api.ConfigFile {
+		return &api.ConfigFile{
+			Namespace: utils.NewStringValue(testNamespace),
+			Group:     utils.NewStringValue(group),
+			Name:      utils.NewStringValue(name),
+			Format:    utils.NewStringValue(utils.FileFormatProperties),
+			Content:   utils.NewStringValue(content),
+			CreateBy:  utils.NewStringValue(operator),
+		}
+	}
+
+	base := newFile("common", "app.properties", "host=${db.host}\nport=3306\n")
+	rsp := configService.Service().CreateConfigFile(defaultCtx, base)
+	assert.Equal(t, api.ExecuteSuccess, rsp.Code.GetValue())
+
+	vars := newFile("prod", "vars.properties", "db.host=10.0.0.1\ndb.port=3307\n")
+	rsp = configService.Service().CreateConfigFile(defaultCtx, vars)
+	assert.Equal(t, api.ExecuteSuccess, rsp.Code.GetValue())
+
+	child := newFile("prod", "app.properties", "port=${db.port}\n")
+	child.BaseFile = utils.NewStringValue(testNamespace + "/common/app.properties")
+	child.VariablesFile = utils.NewStringValue(testNamespace + "/prod/vars.properties")
+	rsp = configService.Service().CreateConfigFile(defaultCtx, child)
+	assert.Equal(t, api.ExecuteSuccess, rsp.Code.GetValue())
+
+	rsp2 := configService.Service().PublishConfigFile(defaultCtx, assembleConfigFileRelease(child))
+	assert.Equal(t, api.ExecuteSuccess, rsp2.Code.GetValue())
+	assert.Equal(t, "host=10.0.0.1\nport=3307\n", rsp2.ConfigFileRelease.Content.GetValue())
+
+	rsp3 := configService.Service().GetConfigFileLatestReleaseHistory(defaultCtx, testNamespace, "prod", "app.properties")
+	assert.Equal(t, api.ExecuteSuccess, rsp3.Code.GetValue())
+	assert.Equal(t, "host=10.0.0.1\nport=3307\n", rsp3.ConfigFileReleaseHistory.Content.GetValue())
+	assert.Equal(t, "port=${db.port}\n", rsp3.ConfigFileReleaseHistory.TemplateContent.GetValue())
+
+	rsp4 := configService.Service().GetConfigFileRichInfo(defaultCtx, testNamespace, "prod", "app.properties")
+	assert.Equal(t, api.ExecuteSuccess, rsp4.Code.GetValue())
+	assert.Equal(t, utils.ReleaseStatusSuccess, rsp4.ConfigFile.Status.GetValue())
+
+	// 缺失变量时发布失败
+	child.Content = utils.NewStringValue("port=${db.port}\nuser=${db.user}\n")
+	rsp = configService.Service().UpdateConfigFile(defaultCtx, child)
+	assert.Equal(t, api.ExecuteSuccess, rsp.Code.GetValue())
+	rsp2 = configService.Service().PublishConfigFile(defaultCtx, assembleConfigFileRelease(child))
+	assert.Equal(t, api.InvalidConfigFileTemplate, rsp2.Code.GetValue())
+
+	// 继承关系成环时发布失败
+	base.BaseFile = utils.NewStringValue(testNamespace + "/prod/app.properties")
+	rsp = configService.Service().UpdateConfigFile(defaultCtx, base)
+	assert.Equal(t, api.ExecuteSuccess, rsp.Code.GetValue())
+	child.Content = utils.NewStringValue("port=${db.port}\n")
+	rsp = configService.Service().UpdateConfigFile(defaultCtx, child)
+	assert.Equal(t, api.ExecuteSuccess, rsp.Code.GetValue())
+	rsp2 = configService.Service().PublishConfigFile(defaultCtx, assembleConfigFileRelease(child))
+	assert.Equal(t, api.InvalidConfigFileTemplate, rsp2.Code.GetValue())
+
+	// 加密的配置不支持模板
+	child.Encrypted = utils.NewBoolValue(true)
+	rsp = configService.Service().UpdateConfigFile(defaultCtx, child)
+	assert.Equal(t, api.NotSupportEncryptedConfigFile, rsp.Code.GetValue())
+}
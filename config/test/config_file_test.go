@@ -18,6 +18,7 @@
 package test
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -275,3 +276,153 @@ func TestPublishConfigFile(t *testing.T) {
 	assert.Equal(t, 2, len(rsp9.ConfigFileReleaseHistories))
 
 }
+
+// TestRollbackConfigFile 测试回滚配置文件到历史发布
+func TestRollbackConfigFile(t *testing.T) {
+	if err := clearTestData(); err != nil {
+		t.FailNow()
+	}
+
+	configFile := assembleConfigFile()
+	rsp := configService.Service().CreateConfigFile(defaultCtx, configFile)
+	assert.Equal(t, api.ExecuteSuccess, rsp.Code.GetValue())
+
+	firstContent := configFile.Content.GetValue()
+	rsp2 := configService.Service().PublishConfigFile(defaultCtx, assembleConfigFileRelease(configFile))
+	assert.Equal(t, api.ExecuteSuccess, rsp2.Code.GetValue())
+
+	rsp3 := configService.Service().GetConfigFileLatestReleaseHistory(defaultCtx, testNamespace, testGroup, testFile)
+	assert.Equal(t, api.ExecuteSuccess, rsp3.Code.GetValue())
+	historyId := rsp3.ConfigFileReleaseHistory.Id.GetValue()
+
+	configFile.Content = utils.NewStringValue("k3=v3")
+	rsp4 := configService.Service().UpdateConfigFile(defaultCtx, configFile)
+	assert.Equal(t, api.ExecuteSuccess, rsp4.Code.GetValue())
+	rsp5 := configService.Service().PublishConfigFile(defaultCtx, assembleConfigFileRelease(configFile))
+	assert.Equal(t, api.ExecuteSuccess, rsp5.Code.GetValue())
+
+	// 回滚到第一次发布，版本号递增
+	rsp6 := configService.Service().RollbackConfigFile(defaultCtx, testNamespace, testGroup, testFile, historyId)
+	assert.Equal(t, api.ExecuteSuccess, rsp6.Code.GetValue())
+	assert.Equal(t, firstContent, rsp6.ConfigFileRelease.Content.GetValue())
+	assert.Equal(t, uint64(3), rsp6.ConfigFileRelease.Version.GetValue())
+
+	rsp7 := configService.Service().GetConfigFileLatestReleaseHistory(defaultCtx, testNamespace, testGroup, testFile)
+	assert.Equal(t, api.ExecuteSuccess, rsp7.Code.GetValue())
+	assert.Equal(t, utils.ReleaseTypeRollback, rsp7.ConfigFileReleaseHistory.Type.GetValue())
+	assert.Equal(t, firstContent, rsp7.ConfigFileReleaseHistory.Content.GetValue())
+
+	// 历史记录不属于该配置文件
+	rsp8 := configService.Service().RollbackConfigFile(defaultCtx, testNamespace, testGroup, "otherFile", historyId)
+	assert.Equal(t, api.NotFoundResource, rsp8.Code.GetValue())
+}
+
+// TestDiffConfigFile 测试比较配置文件的内容差异
+func TestDiffConfigFile(t *testing.T) {
+	if err := clearTestData(); err != nil {
+		t.FailNow()
+	}
+
+	configFile := assembleConfigFile()
+	configFile.Format = utils.NewStringValue(utils.FileFormatProperties)
+	configFile.Content = utils.NewStringValue("k1=v1\nk2=v2")
+	rsp := configService.Service().CreateConfigFile(defaultCtx, configFile)
+	assert.Equal(t, api.ExecuteSuccess, rsp.Code.GetValue())
+
+	rsp2 := configService.Service().PublishConfigFile(defaultCtx, assembleConfigFileRelease(configFile))
+	assert.Equal(t, api.ExecuteSuccess, rsp2.Code.GetValue())
+	rsp3 := configService.Service().GetConfigFileLatestReleaseHistory(defaultCtx, testNamespace, testGroup, testFile)
+	fromId := rsp3.ConfigFileReleaseHistory.Id.GetValue()
+
+	configFile.Content = utils.NewStringValue("k1=v1\nk2=v3")
+	rsp4 := configService.Service().UpdateConfigFile(defaultCtx, configFile)
+	assert.Equal(t, api.ExecuteSuccess, rsp4.Code.GetValue())
+
+	// 当前内容和发布内容比较
+	rsp5 := configService.Service().DiffConfigFileDraft(defaultCtx, testNamespace, testGroup, testFile)
+	assert.Equal(t, api.ExecuteSuccess, rsp5.Code.GetValue())
+	assert.Equal(t, 1, len(rsp5.ConfigFileDiff.KeyDiffs))
+	assert.Equal(t, "k2", rsp5.ConfigFileDiff.KeyDiffs[0].Key.GetValue())
+	assert.Equal(t, "v3", rsp5.ConfigFileDiff.KeyDiffs[0].NewValue.GetValue())
+
+	rsp6 := configService.Service().PublishConfigFile(defaultCtx, assembleConfigFileRelease(configFile))
+	assert.Equal(t, api.ExecuteSuccess, rsp6.Code.GetValue())
+	rsp7 := configService.Service().GetConfigFileLatestReleaseHistory(defaultCtx, testNamespace, testGroup, testFile)
+	toId := rsp7.ConfigFileReleaseHistory.Id.GetValue()
+
+	// 两次发布历史比较
+	rsp8 := configService.Service().DiffConfigFileReleaseHistory(defaultCtx, fromId, toId)
+	assert.Equal(t, api.ExecuteSuccess, rsp8.Code.GetValue())
+	expect := fmt.Sprintf("--- history:%d\n+++ history:%d\n@@ -1,2 +1,2 @@\n k1=v1\n-k2=v2\n+k2=v3\n", fromId, toId)
+	assert.Equal(t, expect, rsp8.ConfigFileDiff.UnifiedDiff.GetValue())
+
+	// 不同配置文件的发布历史不能比较
+	otherFile := assembleConfigFile()
+	otherFile.Name = utils.NewStringValue("other.properties")
+	rsp9 := configService.Service().CreateConfigFile(defaultCtx, otherFile)
+	assert.Equal(t, api.ExecuteSuccess, rsp9.Code.GetValue())
+	rsp10 := configService.Service().PublishConfigFile(defaultCtx, assembleConfigFileRelease(otherFile))
+	assert.Equal(t, api.ExecuteSuccess, rsp10.Code.GetValue())
+	rsp11 := configService.Service().GetConfigFileLatestReleaseHistory(defaultCtx, testNamespace, testGroup,
+		"other.properties")
+	rsp12 := configService.Service().DiffConfigFileReleaseHistory(defaultCtx, fromId,
+		rsp11.ConfigFileReleaseHistory.Id.GetValue())
+	assert.Equal(t, api.InvalidParameter, rsp12.Code.GetValue())
+}
+
+func TestValidateConfigFileContent(t *testing.T) {
+	if err := clearTestData(); err != nil {
+		t.FailNow()
+	}
+
+	// 非法的 JSON Schema 不允许保存
+	group := assembleConfigFileGroup()
+	group.JsonSchema = utils.NewStringValue(`{"type": "unknown"}`)
+	rsp := configService.Service().CreateConfigFileGroup(defaultCtx, group)
+	assert.Equal(t, api.InvalidConfigFileSchema, rsp.Code.GetValue())
+
+	group.JsonSchema = utils.NewStringValue(`{"type": "object", "required": ["port"]}`)
+	rsp = configService.Service().CreateConfigFileGroup(defaultCtx, group)
+	assert.Equal(t, api.ExecuteSuccess, rsp.Code.GetValue())
+
+	configFile := assembleConfigFile()
+	configFile.Format = utils.NewStringValue(utils.FileFormatJson)
+	configFile.Content = utils.NewStringValue("{\"port\": 8080,}")
+	rsp2 := configService.Service().CreateConfigFile(defaultCtx, configFile)
+	assert.Equal(t, api.InvalidConfigFileContent, rsp2.Code.GetValue())
+
+	configFile.Content = utils.NewStringValue("{\"host\": \"localhost\"}")
+	rsp3 := configService.Service().CreateConfigFile(defaultCtx, configFile)
+	assert.Equal(t, api.InvalidConfigFileContent, rsp3.Code.GetValue())
+
+	configFile.Content = utils.NewStringValue("{\"port\": 8080}")
+	rsp4 := configService.Service().CreateConfigFile(defaultCtx, configFile)
+	assert.Equal(t, api.ExecuteSuccess, rsp4.Code.GetValue())
+
+	configFile.Content = utils.NewStringValue("{\"port\": 8080")
+	rsp5 := configService.Service().UpdateConfigFile(defaultCtx, configFile)
+	assert.Equal(t, api.InvalidConfigFileContent, rsp5.Code.GetValue())
+
+	// 分组的约束收紧后，已保存但不满足约束的配置不允许发布
+	group.JsonSchema = utils.NewStringValue(`{"type": "object", "required": ["port", "host"]}`)
+	rsp6 := configService.Service().UpdateConfigFileGroup(defaultCtx, group)
+	assert.Equal(t, api.ExecuteSuccess, rsp6.Code.GetValue())
+
+	rsp7 := configService.Service().PublishConfigFile(defaultCtx, assembleConfigFileRelease(configFile))
+	assert.Equal(t, api.InvalidConfigFileContent, rsp7.Code.GetValue())
+
+	// 更新分组时没有传入约束则保留原来的约束
+	group.JsonSchema = nil
+	group.Comment = utils.NewStringValue("keep schema")
+	rsp8 := configService.Service().UpdateConfigFileGroup(defaultCtx, group)
+	assert.Equal(t, api.ExecuteSuccess, rsp8.Code.GetValue())
+	rsp9 := configService.Service().PublishConfigFile(defaultCtx, assembleConfigFileRelease(configFile))
+	assert.Equal(t, api.InvalidConfigFileContent, rsp9.Code.GetValue())
+
+	// 显式传入空字符串取消约束
+	group.JsonSchema = utils.NewStringValue("")
+	rsp10 := configService.Service().UpdateConfigFileGroup(defaultCtx, group)
+	assert.Equal(t, api.ExecuteSuccess, rsp10.Code.GetValue())
+	rsp11 := configService.Service().PublishConfigFile(defaultCtx, assembleConfigFileRelease(configFile))
+	assert.Equal(t, api.ExecuteSuccess, rsp11.Code.GetValue())
+}
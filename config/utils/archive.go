@@ -0,0 +1,151 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package utils
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path"
+)
+
+const (
+	// ArchiveManifestName 配置压缩包中记录配置文件元信息的文件
+	ArchiveManifestName = "manifest.json"
+	// archiveVersion 配置压缩包的格式版本
+	archiveVersion = 1
+	// archiveManifestMaxLength manifest 的长度限制，避免解压出超大的文件
+	archiveManifestMaxLength = 10 * 1024 * 1024
+	// archiveEncryptedContentMaxLength 加密配置的内容是 base64 编码的密文并且携带加密的数据密钥，
+	// 按照每个字符最多 4 个字节计算 base64 之后的长度，再预留数据密钥的长度
+	archiveEncryptedContentMaxLength = fileContentMaxLength*4*4/3 + 4096
+)
+
+// ArchiveManifest 配置压缩包的元信息，配置内容按照 Path 存放在压缩包中
+type ArchiveManifest struct {
+	Version   int            `json:"version"`
+	Namespace string         `json:"namespace"`
+	Files     []*ArchiveFile `json:"files"`
+}
+
+// ArchiveFile 压缩包中的单个配置文件
+type ArchiveFile struct {
+	Path          string       `json:"path"`
+	Group         string       `json:"group"`
+	Name          string       `json:"name"`
+	Comment       string       `json:"comment,omitempty"`
+	Format        string       `json:"format"`
+	Encrypted     bool         `json:"encrypted,omitempty"`
+	BaseFile      string       `json:"baseFile,omitempty"`
+	VariablesFile string       `json:"variablesFile,omitempty"`
+	Tags          []ArchiveTag `json:"tags,omitempty"`
+	// Content 配置内容，不写入 manifest
+	Content string `json:"-"`
+}
+
+// ArchiveTag 配置文件标签
+type ArchiveTag struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// WriteConfigArchive 把配置文件写成 zip 压缩包，配置内容存放在 group/name 路径下
+func WriteConfigArchive(w io.Writer, namespace string, files []*ArchiveFile) error {
+	zw := zip.NewWriter(w)
+	manifest := &ArchiveManifest{Version: archiveVersion, Namespace: namespace, Files: files}
+	for _, file := range files {
+		file.Path = path.Join(file.Group, file.Name)
+		entry, err := zw.Create(file.Path)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(entry, file.Content); err != nil {
+			return err
+		}
+	}
+
+	entry, err := zw.Create(ArchiveManifestName)
+	if err != nil {
+		return err
+	}
+	encoder := json.NewEncoder(entry)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(manifest); err != nil {
+		return err
+	}
+	return zw.Close()
+}
+
+// ReadConfigArchive 读取 zip 压缩包中的配置文件，内容超过长度限制时只读取限制长度加一个字节，由调用方校验
+func ReadConfigArchive(data []byte) (*ArchiveManifest, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, err
+	}
+	entries := make(map[string]*zip.File, len(zr.File))
+	for _, entry := range zr.File {
+		entries[entry.Name] = entry
+	}
+
+	manifestEntry, ok := entries[ArchiveManifestName]
+	if !ok {
+		return nil, errors.New("manifest.json not found in archive")
+	}
+	manifestContent, err := readArchiveEntry(manifestEntry, archiveManifestMaxLength)
+	if err != nil {
+		return nil, err
+	}
+	manifest := &ArchiveManifest{}
+	if err := json.Unmarshal(manifestContent, manifest); err != nil {
+		return nil, fmt.Errorf("invalid manifest.json: %v", err)
+	}
+	if manifest.Version != archiveVersion {
+		return nil, fmt.Errorf("unsupported archive version %d", manifest.Version)
+	}
+
+	for _, file := range manifest.Files {
+		entry, ok := entries[file.Path]
+		if !ok {
+			return nil, fmt.Errorf("content of %s not found in archive", file.Path)
+		}
+		limit := int64(fileContentMaxLength)
+		if file.Encrypted {
+			limit = archiveEncryptedContentMaxLength
+		}
+		content, err := readArchiveEntry(entry, limit+1)
+		if err != nil {
+			return nil, err
+		}
+		file.Content = string(content)
+	}
+	return manifest, nil
+}
+
+// readArchiveEntry 读取压缩包中的文件，最多读取 limit 个字节
+func readArchiveEntry(entry *zip.File, limit int64) ([]byte, error) {
+	rc, err := entry.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	return ioutil.ReadAll(io.LimitReader(rc, limit))
+}
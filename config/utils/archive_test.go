@@ -0,0 +1,73 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package utils
+
+import (
+	"archive/zip"
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigArchive(t *testing.T) {
+	files := []*ArchiveFile{
+		{Group: "g1", Name: "app.yaml", Format: "yaml", Comment: "c", Content: "a: 1",
+			Tags: []ArchiveTag{{Key: "env", Value: "test"}}},
+		{Group: "g2", Name: "conf/db.properties", Format: "properties", Encrypted: true, Content: "k=v"},
+	}
+	buf := &bytes.Buffer{}
+	assert.Nil(t, WriteConfigArchive(buf, "ns", files))
+
+	manifest, err := ReadConfigArchive(buf.Bytes())
+	assert.Nil(t, err)
+	assert.Equal(t, "ns", manifest.Namespace)
+	assert.Equal(t, files, manifest.Files)
+	assert.Equal(t, "g2/conf/db.properties", manifest.Files[1].Path)
+
+	// 超长的内容只读取限制长度加一个字节
+	files[0].Content = strings.Repeat("a", fileContentMaxLength*2)
+	buf.Reset()
+	assert.Nil(t, WriteConfigArchive(buf, "ns", files))
+	manifest, err = ReadConfigArchive(buf.Bytes())
+	assert.Nil(t, err)
+	assert.NotNil(t, CheckContentLength(manifest.Files[0].Content))
+	assert.Equal(t, fileContentMaxLength+1, len(manifest.Files[0].Content))
+
+	// 加密配置的密文比明文长，按照密文的长度限制读取
+	files[1].Content = strings.Repeat("b", fileContentMaxLength*2)
+	buf.Reset()
+	assert.Nil(t, WriteConfigArchive(buf, "ns", files))
+	manifest, err = ReadConfigArchive(buf.Bytes())
+	assert.Nil(t, err)
+	assert.Equal(t, files[1].Content, manifest.Files[1].Content)
+}
+
+func TestReadConfigArchive_Invalid(t *testing.T) {
+	_, err := ReadConfigArchive([]byte("not a zip"))
+	assert.NotNil(t, err)
+
+	buf := &bytes.Buffer{}
+	zw := zip.NewWriter(buf)
+	entry, _ := zw.Create(ArchiveManifestName)
+	_, _ = entry.Write([]byte(`{"version":1,"files":[{"path":"g/a.txt","group":"g","name":"a.txt"}]}`))
+	assert.Nil(t, zw.Close())
+	_, err = ReadConfigArchive(buf.Bytes())
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,114 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package utils
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/polarismesh/polaris-server/plugin"
+)
+
+const (
+	// encryptedContentPrefix 加密后的配置内容前缀，格式为 prefix + 加密的数据密钥 + ":" + 密文
+	encryptedContentPrefix = "polaris-encrypted:v1:"
+	// MaskedContent 控制台返回加密配置时使用的掩码
+	MaskedContent = "******"
+)
+
+// IsEncryptedContent 判断配置内容是否为加密后的内容
+func IsEncryptedContent(content string) bool {
+	return strings.HasPrefix(content, encryptedContentPrefix)
+}
+
+// EncryptContent 生成数据密钥并使用 AES-GCM 加密配置内容，加密的数据密钥和密文一起保存
+func EncryptContent(kms plugin.KMS, content string) (string, error) {
+	if kms == nil {
+		return "", errors.New("kms plugin is not configured")
+	}
+	dataKey, cipherKey, err := kms.GenerateDataKey()
+	if err != nil {
+		return "", err
+	}
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", err
+	}
+	sealed := aead.Seal(nonce, nonce, []byte(content), nil)
+	return encryptedContentPrefix + cipherKey + ":" + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// DecryptContent 解密配置内容，未加密的内容原样返回
+func DecryptContent(kms plugin.KMS, content string) (string, error) {
+	if !IsEncryptedContent(content) {
+		return content, nil
+	}
+	if kms == nil {
+		return "", errors.New("kms plugin is not configured")
+	}
+	envelope := strings.TrimPrefix(content, encryptedContentPrefix)
+	index := strings.LastIndex(envelope, ":")
+	if index < 0 {
+		return "", errors.New("invalid encrypted content")
+	}
+	dataKey, err := kms.DecryptDataKey(envelope[:index])
+	if err != nil {
+		return "", err
+	}
+	sealed, err := base64.StdEncoding.DecodeString(envelope[index+1:])
+	if err != nil {
+		return "", err
+	}
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return "", err
+	}
+	if len(sealed) < aead.NonceSize() {
+		return "", errors.New("invalid encrypted content")
+	}
+	plain, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], nil)
+	if err != nil {
+		return "", err
+	}
+	return string(plain), nil
+}
+
+// MaskContent 加密的配置内容返回掩码，未加密的内容原样返回
+func MaskContent(content string) string {
+	if IsEncryptedContent(content) {
+		return MaskedContent
+	}
+	return content
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
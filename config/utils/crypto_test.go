@@ -0,0 +1,78 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package utils
+
+import (
+	"encoding/base64"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/polarismesh/polaris-server/plugin"
+)
+
+// testKMS 数据密钥固定为 32 字节，加密的数据密钥只做 base64 编码
+type testKMS struct {
+	plugin.KMS
+}
+
+func (k *testKMS) GenerateDataKey() ([]byte, string, error) {
+	key := []byte("0123456789abcdef0123456789abcdef")
+	return key, base64.StdEncoding.EncodeToString(key), nil
+}
+
+func (k *testKMS) DecryptDataKey(cipherKey string) ([]byte, error) {
+	if cipherKey == "" {
+		return nil, errors.New("empty key")
+	}
+	return base64.StdEncoding.DecodeString(cipherKey)
+}
+
+func TestEncryptContent(t *testing.T) {
+	kms := &testKMS{}
+	content := "db.password=123456"
+
+	encrypted, err := EncryptContent(kms, content)
+	assert.Nil(t, err)
+	assert.True(t, IsEncryptedContent(encrypted))
+	assert.NotContains(t, encrypted, "123456")
+	assert.Equal(t, MaskedContent, MaskContent(encrypted))
+
+	// 每次加密使用随机的 nonce
+	encrypted2, err := EncryptContent(kms, content)
+	assert.Nil(t, err)
+	assert.NotEqual(t, encrypted, encrypted2)
+
+	plain, err := DecryptContent(kms, encrypted)
+	assert.Nil(t, err)
+	assert.Equal(t, content, plain)
+
+	// 未加密的内容原样返回
+	plain, err = DecryptContent(nil, content)
+	assert.Nil(t, err)
+	assert.Equal(t, content, plain)
+	assert.Equal(t, content, MaskContent(content))
+
+	_, err = EncryptContent(nil, content)
+	assert.NotNil(t, err)
+	_, err = DecryptContent(nil, encrypted)
+	assert.NotNil(t, err)
+	_, err = DecryptContent(kms, encrypted[:len(encrypted)-4])
+	assert.NotNil(t, err)
+}
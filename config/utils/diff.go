@@ -0,0 +1,376 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/polarismesh/polaris-server/common/utils"
+)
+
+const (
+	// DiffTypeAdded 新增的 key
+	DiffTypeAdded = "added"
+	// DiffTypeDeleted 删除的 key
+	DiffTypeDeleted = "deleted"
+	// DiffTypeModified 修改的 key
+	DiffTypeModified = "modified"
+
+	diffContextLines = 3
+	// diffMaxEdits 按行比较时计算最短编辑路径的最大编辑距离，回溯需要保存的对角线数量与其平方成正比
+	diffMaxEdits = 1000
+)
+
+// KeyDiff 按照配置项 key 比较的差异
+type KeyDiff struct {
+	Key      string
+	Type     string
+	OldValue string
+	NewValue string
+}
+
+type lineOp struct {
+	kind byte // ' ', '-', '+'
+	text string
+}
+
+// UnifiedDiff 生成两段内容按行比较的 unified 格式差异，内容相同时返回空字符串
+func UnifiedDiff(fromName, toName, from, to string) string {
+	ops := diffLines(splitLines(from), splitLines(to))
+
+	changed := false
+	for _, op := range ops {
+		if op.kind != ' ' {
+			changed = true
+			break
+		}
+	}
+	if !changed {
+		return ""
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "--- %s\n+++ %s\n", fromName, toName)
+
+	for start := 0; start < len(ops); {
+		// 找到下一处变更，向前保留上下文
+		first := start
+		for first < len(ops) && ops[first].kind == ' ' {
+			first++
+		}
+		if first == len(ops) {
+			break
+		}
+		begin := first - diffContextLines
+		if begin < start {
+			begin = start
+		}
+		// 两处变更之间的相同行不超过两倍上下文时合并为一个 hunk
+		end := first
+		for end < len(ops) {
+			if ops[end].kind != ' ' {
+				end++
+				continue
+			}
+			same := end
+			for same < len(ops) && ops[same].kind == ' ' {
+				same++
+			}
+			if same == len(ops) || same-end > 2*diffContextLines {
+				end += minInt(diffContextLines, same-end)
+				break
+			}
+			end = same
+		}
+		writeHunk(&b, ops, begin, end)
+		start = end
+	}
+	return b.String()
+}
+
+func writeHunk(b *strings.Builder, ops []lineOp, begin, end int) {
+	fromLine, toLine := 1, 1
+	for _, op := range ops[:begin] {
+		if op.kind != '+' {
+			fromLine++
+		}
+		if op.kind != '-' {
+			toLine++
+		}
+	}
+	fromCount, toCount := 0, 0
+	for _, op := range ops[begin:end] {
+		if op.kind != '+' {
+			fromCount++
+		}
+		if op.kind != '-' {
+			toCount++
+		}
+	}
+	if fromCount == 0 {
+		fromLine--
+	}
+	if toCount == 0 {
+		toLine--
+	}
+	fmt.Fprintf(b, "@@ -%d,%d +%d,%d @@\n", fromLine, fromCount, toLine, toCount)
+	for _, op := range ops[begin:end] {
+		b.WriteByte(op.kind)
+		b.WriteString(op.text)
+		b.WriteByte('\n')
+	}
+}
+
+func splitLines(content string) []string {
+	if content == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(content, "\n"), "\n")
+}
+
+// diffLines 使用 Myers 算法计算最短编辑脚本，每一步只保存 [-d, d] 范围内的对角线用于回溯，
+// 编辑距离超过 diffMaxEdits 时不再计算最短路径，按照整个文件替换处理，避免差异过大时占用过多内存
+func diffLines(a, b []string) []lineOp {
+	n, m := len(a), len(b)
+	maxD := n + m
+	offset := maxD + 1
+	v := make([]int, 2*maxD+3)
+	// trace[d] 为第 d 步之后对角线 k 上能到达的最远 x，下标为 k+d
+	var trace [][]int
+
+	for d := 0; d <= maxD; d++ {
+		if d > diffMaxEdits {
+			return replaceLines(a, b)
+		}
+		found := false
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				found = true
+				break
+			}
+		}
+		step := make([]int, 2*d+1)
+		copy(step, v[offset-d:offset+d+1])
+		trace = append(trace, step)
+		if found {
+			break
+		}
+	}
+
+	// 回溯编辑路径
+	ops := make([]lineOp, 0, n+m)
+	x, y := n, m
+	for d := len(trace) - 1; d > 0; d-- {
+		prev := trace[d-1]
+		k := x - y
+		var prevK int
+		if k == -d || (k != d && prev[k-1+d-1] < prev[k+1+d-1]) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := prev[prevK+d-1]
+		prevY := prevX - prevK
+		for x > prevX && y > prevY {
+			x--
+			y--
+			ops = append(ops, lineOp{kind: ' ', text: a[x]})
+		}
+		if x == prevX {
+			y--
+			ops = append(ops, lineOp{kind: '+', text: b[y]})
+		} else {
+			x--
+			ops = append(ops, lineOp{kind: '-', text: a[x]})
+		}
+	}
+	// 第 0 步只有从起点开始的相同行
+	for x > 0 && y > 0 {
+		x--
+		y--
+		ops = append(ops, lineOp{kind: ' ', text: a[x]})
+	}
+	for i, j := 0, len(ops)-1; i < j; i, j = i+1, j-1 {
+		ops[i], ops[j] = ops[j], ops[i]
+	}
+	return ops
+}
+
+// replaceLines 删除全部旧的行再新增全部新的行
+func replaceLines(a, b []string) []lineOp {
+	ops := make([]lineOp, 0, len(a)+len(b))
+	for _, line := range a {
+		ops = append(ops, lineOp{kind: '-', text: line})
+	}
+	for _, line := range b {
+		ops = append(ops, lineOp{kind: '+', text: line})
+	}
+	return ops
+}
+
+// DiffKeys 按照配置格式解析出配置项后比较差异，只支持 yaml、json、properties 格式，其余格式返回空
+func DiffKeys(format, from, to string) ([]KeyDiff, error) {
+	fromKeys, err := FlattenContent(format, from)
+	if err != nil {
+		return nil, err
+	}
+	toKeys, err := FlattenContent(format, to)
+	if err != nil {
+		return nil, err
+	}
+	if fromKeys == nil && toKeys == nil {
+		return nil, nil
+	}
+
+	keys := make([]string, 0, len(fromKeys)+len(toKeys))
+	for key := range fromKeys {
+		keys = append(keys, key)
+	}
+	for key := range toKeys {
+		if _, ok := fromKeys[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	var diffs []KeyDiff
+	for _, key := range keys {
+		oldValue, inFrom := fromKeys[key]
+		newValue, inTo := toKeys[key]
+		switch {
+		case !inFrom:
+			diffs = append(diffs, KeyDiff{Key: key, Type: DiffTypeAdded, NewValue: newValue})
+		case !inTo:
+			diffs = append(diffs, KeyDiff{Key: key, Type: DiffTypeDeleted, OldValue: oldValue})
+		case oldValue != newValue:
+			diffs = append(diffs, KeyDiff{Key: key, Type: DiffTypeModified, OldValue: oldValue, NewValue: newValue})
+		}
+	}
+	return diffs, nil
+}
+
+// FlattenContent 把配置内容展开成 key -> value，嵌套的 key 使用 . 连接，数组下标使用 [i]
+func FlattenContent(format, content string) (map[string]string, error) {
+	result := make(map[string]string)
+	switch format {
+	case utils.FileFormatProperties:
+		return ParseProperties(content), nil
+	case utils.FileFormatJson:
+		if strings.TrimSpace(content) == "" {
+			return result, nil
+		}
+		var data interface{}
+		if err := json.Unmarshal([]byte(content), &data); err != nil {
+			return nil, err
+		}
+		flatten("", data, result)
+	case utils.FileFormatYaml:
+		var data interface{}
+		if err := yaml.Unmarshal([]byte(content), &data); err != nil {
+			return nil, err
+		}
+		flatten("", data, result)
+	default:
+		return nil, nil
+	}
+	return result, nil
+}
+
+func flatten(prefix string, data interface{}, result map[string]string) {
+	switch val := data.(type) {
+	case map[string]interface{}:
+		for k, v := range val {
+			flatten(joinKey(prefix, k), v, result)
+		}
+	case map[interface{}]interface{}:
+		for k, v := range val {
+			flatten(joinKey(prefix, fmt.Sprint(k)), v, result)
+		}
+	case []interface{}:
+		for i, v := range val {
+			flatten(prefix+"["+strconv.Itoa(i)+"]", v, result)
+		}
+	case nil:
+		if prefix != "" {
+			result[prefix] = ""
+		}
+	default:
+		result[prefix] = fmt.Sprint(val)
+	}
+}
+
+func joinKey(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
+
+// ParseProperties 解析 properties 格式，支持 = 和 : 分隔符、# 和 ! 注释以及 \ 续行
+func ParseProperties(content string) map[string]string {
+	result := make(map[string]string)
+	var logical string
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if logical == "" && (line == "" || line[0] == '#' || line[0] == '!') {
+			continue
+		}
+		if strings.HasSuffix(line, "\\") {
+			logical += strings.TrimSuffix(line, "\\")
+			continue
+		}
+		logical += line
+
+		idx := strings.IndexAny(logical, "=:")
+		if idx < 0 {
+			result[logical] = ""
+		} else {
+			result[strings.TrimSpace(logical[:idx])] = strings.TrimSpace(logical[idx+1:])
+		}
+		logical = ""
+	}
+	if logical != "" {
+		result[logical] = ""
+	}
+	return result
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,119 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package utils
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/polarismesh/polaris-server/common/utils"
+)
+
+func TestUnifiedDiff(t *testing.T) {
+	assert.Equal(t, "", UnifiedDiff("a", "b", "k1=v1\nk2=v2", "k1=v1\nk2=v2"))
+	assert.Equal(t, "", UnifiedDiff("a", "b", "", ""))
+
+	from := "l1\nl2\nl3\nl4\nl5\nl6\nl7\nl8\nl9\nl10\nl11\nl12"
+	to := "l1\nl2\nl3\nl4\nl5\nL6\nl7\nl8\nl9\nl10\nl11\nl12\nl13"
+	expect := "--- a\n+++ b\n" +
+		"@@ -3,10 +3,11 @@\n l3\n l4\n l5\n-l6\n+L6\n l7\n l8\n l9\n l10\n l11\n l12\n+l13\n"
+	assert.Equal(t, expect, UnifiedDiff("a", "b", from, to))
+
+	// 相隔超过两倍上下文的变更拆分为多个 hunk
+	from = "l1\nl2\nl3\nl4\nl5\nl6\nl7\nl8\nl9\nl10\nl11\nl12"
+	to = "L1\nl2\nl3\nl4\nl5\nl6\nl7\nl8\nl9\nl10\nl11\nL12"
+	expect = "--- a\n+++ b\n" +
+		"@@ -1,4 +1,4 @@\n-l1\n+L1\n l2\n l3\n l4\n" +
+		"@@ -9,4 +9,4 @@\n l9\n l10\n l11\n-l12\n+L12\n"
+	assert.Equal(t, expect, UnifiedDiff("a", "b", from, to))
+
+	assert.Equal(t, "--- a\n+++ b\n@@ -0,0 +1,1 @@\n+k1=v1\n", UnifiedDiff("a", "b", "", "k1=v1"))
+}
+
+func TestDiffLines(t *testing.T) {
+	// 编辑脚本能还原出两边的内容，并且编辑次数最少
+	a := []string{"a", "b", "c", "a", "b", "b", "a"}
+	b := []string{"c", "b", "a", "b", "a", "c"}
+	ops := diffLines(a, b)
+	from, to, edits := applyLineOps(ops)
+	assert.Equal(t, a, from)
+	assert.Equal(t, b, to)
+	assert.Equal(t, 5, edits)
+
+	// 编辑距离超过限制时按照整个文件替换
+	a, b = nil, nil
+	for i := 0; i < diffMaxEdits; i++ {
+		a = append(a, "a"+strconv.Itoa(i))
+		b = append(b, "b"+strconv.Itoa(i))
+	}
+	ops = diffLines(a, b)
+	from, to, edits = applyLineOps(ops)
+	assert.Equal(t, a, from)
+	assert.Equal(t, b, to)
+	assert.Equal(t, 2*diffMaxEdits, edits)
+	assert.Equal(t, byte('-'), ops[0].kind)
+	assert.Equal(t, byte('+'), ops[len(ops)-1].kind)
+}
+
+func applyLineOps(ops []lineOp) (from, to []string, edits int) {
+	for _, op := range ops {
+		if op.kind != '+' {
+			from = append(from, op.text)
+		}
+		if op.kind != '-' {
+			to = append(to, op.text)
+		}
+		if op.kind != ' ' {
+			edits++
+		}
+	}
+	return from, to, edits
+}
+
+func TestDiffKeys(t *testing.T) {
+	diffs, err := DiffKeys(utils.FileFormatProperties, "a=1\n# comment\nb=2\nc=3", "a=1\nb=20\nd:4")
+	assert.Nil(t, err)
+	assert.Equal(t, []KeyDiff{
+		{Key: "b", Type: DiffTypeModified, OldValue: "2", NewValue: "20"},
+		{Key: "c", Type: DiffTypeDeleted, OldValue: "3"},
+		{Key: "d", Type: DiffTypeAdded, NewValue: "4"},
+	}, diffs)
+
+	diffs, err = DiffKeys(utils.FileFormatYaml, "a:\n  b: 1\n  c: [x, z]\n", "a:\n  b: 2\n  c: [x]\n")
+	assert.Nil(t, err)
+	assert.Equal(t, []KeyDiff{
+		{Key: "a.b", Type: DiffTypeModified, OldValue: "1", NewValue: "2"},
+		{Key: "a.c[1]", Type: DiffTypeDeleted, OldValue: "z"},
+	}, diffs)
+
+	diffs, err = DiffKeys(utils.FileFormatJson, `{"a":{"b":true}}`, `{"a":{"b":false},"c":1}`)
+	assert.Nil(t, err)
+	assert.Equal(t, []KeyDiff{
+		{Key: "a.b", Type: DiffTypeModified, OldValue: "true", NewValue: "false"},
+		{Key: "c", Type: DiffTypeAdded, NewValue: "1"},
+	}, diffs)
+
+	_, err = DiffKeys(utils.FileFormatJson, `{"a":`, `{}`)
+	assert.NotNil(t, err)
+
+	diffs, err = DiffKeys(utils.FileFormatText, "a", "b")
+	assert.Nil(t, err)
+	assert.Nil(t, diffs)
+}
@@ -0,0 +1,429 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v2"
+
+	"github.com/polarismesh/polaris-server/common/utils"
+)
+
+// JsonSchema 配置文件组上的 JSON Schema 约束，支持常用的关键字：type、enum、properties、required、
+// additionalProperties、items、minItems、maxItems、minimum、maximum、minLength、maxLength、pattern，
+// 以及不影响校验的注解关键字，其余关键字在解析时拒绝，避免用户误以为约束已经生效
+type JsonSchema struct {
+	root     map[string]interface{}
+	patterns map[string]*regexp.Regexp
+}
+
+// SchemaError 配置内容不满足 JSON Schema 约束，Path 为出错字段的路径
+type SchemaError struct {
+	Path string
+	Msg  string
+}
+
+// Error 实现 error 接口
+func (e *SchemaError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Path, e.Msg)
+}
+
+var schemaTypes = map[string]bool{
+	"object": true, "array": true, "string": true, "number": true, "integer": true, "boolean": true, "null": true,
+}
+
+// annotationKeywords 只用于说明的关键字，不参与校验
+var annotationKeywords = map[string]bool{
+	"$schema": true, "$id": true, "$comment": true, "title": true, "description": true, "default": true,
+	"examples": true,
+}
+
+// ParseJsonSchema 解析并检查 JSON Schema
+func ParseJsonSchema(schema string) (*JsonSchema, error) {
+	var root map[string]interface{}
+	if err := json.Unmarshal([]byte(schema), &root); err != nil {
+		return nil, err
+	}
+	s := &JsonSchema{root: root, patterns: make(map[string]*regexp.Regexp)}
+	var unsupported []string
+	if err := s.check("#", root, &unsupported); err != nil {
+		return nil, err
+	}
+	if len(unsupported) > 0 {
+		sort.Strings(unsupported)
+		return nil, fmt.Errorf("unsupported keywords: %s", strings.Join(unsupported, ", "))
+	}
+	return s, nil
+}
+
+// check 检查关键字的取值，不支持的关键字记录在 unsupported 中
+func (s *JsonSchema) check(path string, schema map[string]interface{}, unsupported *[]string) error {
+	for keyword, value := range schema {
+		var ok bool
+		switch keyword {
+		case "type":
+			ok = checkSchemaType(value)
+		case "properties":
+			var properties map[string]interface{}
+			if properties, ok = value.(map[string]interface{}); ok {
+				for name, property := range properties {
+					sub, isObject := property.(map[string]interface{})
+					if !isObject {
+						return fmt.Errorf("%s/properties/%s: must be an object", path, name)
+					}
+					if err := s.check(path+"/properties/"+name, sub, unsupported); err != nil {
+						return err
+					}
+				}
+			}
+		case "items":
+			var sub map[string]interface{}
+			if sub, ok = value.(map[string]interface{}); ok {
+				if err := s.check(path+"/items", sub, unsupported); err != nil {
+					return err
+				}
+			}
+		case "additionalProperties":
+			switch v := value.(type) {
+			case bool:
+				ok = true
+			case map[string]interface{}:
+				ok = true
+				if err := s.check(path+"/additionalProperties", v, unsupported); err != nil {
+					return err
+				}
+			}
+		case "required":
+			ok = isStringArray(value)
+		case "enum":
+			_, ok = value.([]interface{})
+		case "minimum", "maximum", "minLength", "maxLength", "minItems", "maxItems":
+			_, ok = value.(float64)
+		case "pattern":
+			var pattern string
+			if pattern, ok = value.(string); ok {
+				re, err := regexp.Compile(pattern)
+				if err != nil {
+					return fmt.Errorf("%s/pattern: %v", path, err)
+				}
+				s.patterns[pattern] = re
+			}
+		default:
+			ok = true
+			if !annotationKeywords[keyword] {
+				*unsupported = append(*unsupported, path+"/"+keyword)
+			}
+		}
+		if !ok {
+			return fmt.Errorf("%s/%s: invalid value", path, keyword)
+		}
+	}
+	return nil
+}
+
+func checkSchemaType(value interface{}) bool {
+	switch v := value.(type) {
+	case string:
+		return schemaTypes[v]
+	case []interface{}:
+		for _, item := range v {
+			if name, ok := item.(string); !ok || !schemaTypes[name] {
+				return false
+			}
+		}
+		return len(v) > 0
+	}
+	return false
+}
+
+func isStringArray(value interface{}) bool {
+	items, ok := value.([]interface{})
+	if !ok {
+		return false
+	}
+	for _, item := range items {
+		if _, ok := item.(string); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// ValidateContent 按照配置格式解析配置内容后校验，只支持 json、yaml、toml、properties 格式，其余格式不校验
+func (s *JsonSchema) ValidateContent(format, content string) error {
+	data, ok, err := ParseContent(format, content)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return nil
+	}
+	return s.Validate(data)
+}
+
+// Validate 校验数据是否满足约束
+func (s *JsonSchema) Validate(data interface{}) error {
+	return s.validate("$", s.root, data)
+}
+
+func (s *JsonSchema) validate(path string, schema map[string]interface{}, data interface{}) error {
+	if t, ok := schema["type"]; ok && !matchSchemaType(t, data) {
+		return &SchemaError{Path: path, Msg: fmt.Sprintf("expected type %v", t)}
+	}
+	if enum, ok := schema["enum"].([]interface{}); ok && !inEnum(enum, data) {
+		return &SchemaError{Path: path, Msg: "value is not one of the enum values"}
+	}
+
+	switch v := data.(type) {
+	case map[string]interface{}:
+		return s.validateObject(path, schema, v)
+	case []interface{}:
+		return s.validateArray(path, schema, v)
+	case string:
+		return s.validateString(path, schema, v)
+	}
+	if number, ok := toFloat(data); ok {
+		if min, ok := schema["minimum"].(float64); ok && number < min {
+			return &SchemaError{Path: path, Msg: fmt.Sprintf("must be >= %v", min)}
+		}
+		if max, ok := schema["maximum"].(float64); ok && number > max {
+			return &SchemaError{Path: path, Msg: fmt.Sprintf("must be <= %v", max)}
+		}
+	}
+	return nil
+}
+
+func (s *JsonSchema) validateObject(path string, schema map[string]interface{}, data map[string]interface{}) error {
+	if required, ok := schema["required"].([]interface{}); ok {
+		for _, name := range required {
+			if _, exist := data[name.(string)]; !exist {
+				return &SchemaError{Path: path, Msg: fmt.Sprintf("missing required property %q", name)}
+			}
+		}
+	}
+
+	properties, _ := schema["properties"].(map[string]interface{})
+	// 按照 key 排序，保证多处错误时返回的结果稳定
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if property, ok := properties[key].(map[string]interface{}); ok {
+			if err := s.validate(path+"."+key, property, data[key]); err != nil {
+				return err
+			}
+			continue
+		}
+		switch additional := schema["additionalProperties"].(type) {
+		case bool:
+			if !additional {
+				return &SchemaError{Path: path, Msg: fmt.Sprintf("additional property %q is not allowed", key)}
+			}
+		case map[string]interface{}:
+			if err := s.validate(path+"."+key, additional, data[key]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (s *JsonSchema) validateArray(path string, schema map[string]interface{}, data []interface{}) error {
+	if min, ok := schema["minItems"].(float64); ok && float64(len(data)) < min {
+		return &SchemaError{Path: path, Msg: fmt.Sprintf("must have at least %v items", min)}
+	}
+	if max, ok := schema["maxItems"].(float64); ok && float64(len(data)) > max {
+		return &SchemaError{Path: path, Msg: fmt.Sprintf("must have at most %v items", max)}
+	}
+	if items, ok := schema["items"].(map[string]interface{}); ok {
+		for i, item := range data {
+			if err := s.validate(fmt.Sprintf("%s[%d]", path, i), items, item); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (s *JsonSchema) validateString(path string, schema map[string]interface{}, data string) error {
+	length := float64(len([]rune(data)))
+	if min, ok := schema["minLength"].(float64); ok && length < min {
+		return &SchemaError{Path: path, Msg: fmt.Sprintf("length must be >= %v", min)}
+	}
+	if max, ok := schema["maxLength"].(float64); ok && length > max {
+		return &SchemaError{Path: path, Msg: fmt.Sprintf("length must be <= %v", max)}
+	}
+	if pattern, ok := schema["pattern"].(string); ok && !s.patterns[pattern].MatchString(data) {
+		return &SchemaError{Path: path, Msg: fmt.Sprintf("does not match pattern %q", pattern)}
+	}
+	return nil
+}
+
+func matchSchemaType(t interface{}, data interface{}) bool {
+	if types, ok := t.([]interface{}); ok {
+		for _, item := range types {
+			if matchSchemaType(item, data) {
+				return true
+			}
+		}
+		return false
+	}
+	switch t {
+	case "object":
+		_, ok := data.(map[string]interface{})
+		return ok
+	case "array":
+		_, ok := data.([]interface{})
+		return ok
+	case "string":
+		_, ok := data.(string)
+		return ok
+	case "boolean":
+		_, ok := data.(bool)
+		return ok
+	case "null":
+		return data == nil
+	case "number":
+		_, ok := toFloat(data)
+		return ok
+	case "integer":
+		number, ok := toFloat(data)
+		return ok && number == math.Trunc(number)
+	}
+	return false
+}
+
+func inEnum(enum []interface{}, data interface{}) bool {
+	value, err := json.Marshal(data)
+	if err != nil {
+		return false
+	}
+	for _, item := range enum {
+		expect, _ := json.Marshal(item)
+		if string(expect) == string(value) {
+			return true
+		}
+	}
+	return false
+}
+
+func toFloat(data interface{}) (float64, bool) {
+	switch v := data.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	}
+	return 0, false
+}
+
+// ParseContent 按照配置格式把配置内容解析为 JSON 兼容的数据结构，不支持的格式返回 false
+func ParseContent(format, content string) (interface{}, bool, error) {
+	var data interface{}
+	switch format {
+	case utils.FileFormatJson:
+		if err := json.Unmarshal([]byte(content), &data); err != nil {
+			return nil, true, err
+		}
+	case utils.FileFormatYaml:
+		if err := yaml.Unmarshal([]byte(content), &data); err != nil {
+			return nil, true, err
+		}
+	case utils.FileFormatToml:
+		var table map[string]interface{}
+		if _, err := toml.Decode(content, &table); err != nil {
+			return nil, true, err
+		}
+		data = table
+	case utils.FileFormatProperties:
+		object := make(map[string]interface{})
+		for key, value := range ParseProperties(content) {
+			object[key] = value
+		}
+		return object, true, nil
+	default:
+		return nil, false, nil
+	}
+	normalized, err := normalize(data)
+	return normalized, true, err
+}
+
+// normalize 把 yaml、toml 解析出的 map 和数组统一转换为 JSON 解析的类型
+func normalize(data interface{}) (interface{}, error) {
+	switch v := data.(type) {
+	case map[interface{}]interface{}:
+		object := make(map[string]interface{}, len(v))
+		for key, value := range v {
+			item, err := normalize(value)
+			if err != nil {
+				return nil, err
+			}
+			object[fmt.Sprint(key)] = item
+		}
+		return object, nil
+	case map[string]interface{}:
+		for key, value := range v {
+			item, err := normalize(value)
+			if err != nil {
+				return nil, err
+			}
+			v[key] = item
+		}
+		return v, nil
+	case []map[string]interface{}:
+		array := make([]interface{}, 0, len(v))
+		for _, value := range v {
+			item, err := normalize(value)
+			if err != nil {
+				return nil, err
+			}
+			array = append(array, item)
+		}
+		return array, nil
+	case []interface{}:
+		for i, value := range v {
+			item, err := normalize(value)
+			if err != nil {
+				return nil, err
+			}
+			v[i] = item
+		}
+		return v, nil
+	case int64, uint64, int, float64, float32, string, bool, nil:
+		return v, nil
+	}
+	if stringer, ok := data.(fmt.Stringer); ok {
+		return stringer.String(), nil
+	}
+	return nil, fmt.Errorf("unsupported value type %T", data)
+}
@@ -0,0 +1,99 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/polarismesh/polaris-server/common/utils"
+)
+
+const testSchema = `{
+	"type": "object",
+	"required": ["port", "host"],
+	"additionalProperties": false,
+	"properties": {
+		"port": {"type": "integer", "minimum": 1, "maximum": 65535},
+		"host": {"type": "string", "minLength": 1, "pattern": "^[a-z0-9.]+$"},
+		"mode": {"enum": ["debug", "release"]},
+		"tags": {"type": "array", "maxItems": 2, "items": {"type": "string"}}
+	}
+}`
+
+func TestParseJsonSchema(t *testing.T) {
+	_, err := ParseJsonSchema(testSchema)
+	assert.Nil(t, err)
+
+	invalid := []string{
+		"not json",
+		`{"type": "unknown"}`,
+		`{"required": "port"}`,
+		`{"properties": {"a": 1}}`,
+		`{"properties": {"a": {"pattern": "("}}}`,
+		`{"minimum": "1"}`,
+	}
+	for _, schema := range invalid {
+		_, err := ParseJsonSchema(schema)
+		assert.NotNil(t, err, schema)
+	}
+
+	// 不支持的关键字在保存时拒绝，错误信息中列出全部不支持的关键字
+	_, err = ParseJsonSchema(`{"oneOf": [], "properties": {"a": {"format": "email", "$ref": "#/b"}}}`)
+	assert.NotNil(t, err)
+	assert.Equal(t, "unsupported keywords: #/oneOf, #/properties/a/$ref, #/properties/a/format", err.Error())
+
+	_, err = ParseJsonSchema(`{"$schema": "http://json-schema.org/draft-07/schema#", "title": "app",
+		"properties": {"a": {"description": "a", "type": "string", "default": "x"}}}`)
+	assert.Nil(t, err)
+}
+
+func TestJsonSchema_ValidateContent(t *testing.T) {
+	schema, err := ParseJsonSchema(testSchema)
+	assert.Nil(t, err)
+
+	assert.Nil(t, schema.ValidateContent(utils.FileFormatJson,
+		`{"port": 8080, "host": "127.0.0.1", "mode": "debug", "tags": ["a"]}`))
+	assert.Nil(t, schema.ValidateContent(utils.FileFormatYaml, "port: 8080\nhost: localhost\n"))
+	assert.Nil(t, schema.ValidateContent(utils.FileFormatToml, "port = 8080\nhost = \"localhost\"\n"))
+	// 不支持结构化解析的格式不做校验
+	assert.Nil(t, schema.ValidateContent(utils.FileFormatXml, "<a></a>"))
+
+	cases := map[string]string{
+		`{"host": "localhost"}`:                              "$: missing required property \"port\"",
+		`{"port": 8080.5, "host": "localhost"}`:              "$.port: expected type integer",
+		`{"port": 70000, "host": "localhost"}`:               "$.port: must be <= 65535",
+		`{"port": 80, "host": "Local Host"}`:                 "$.host: does not match pattern \"^[a-z0-9.]+$\"",
+		`{"port": 80, "host": "a", "mode": "test"}`:          "$.mode: value is not one of the enum values",
+		`{"port": 80, "host": "a", "tags": ["a", 1]}`:        "$.tags[1]: expected type string",
+		`{"port": 80, "host": "a", "tags": ["a", "b", "c"]}`: "$.tags: must have at most 2 items",
+		`{"port": 80, "host": "a", "other": 1}`:              "$: additional property \"other\" is not allowed",
+		`[1, 2]`:                                             "$: expected type object",
+	}
+	for content, expect := range cases {
+		err := schema.ValidateContent(utils.FileFormatJson, content)
+		if assert.NotNil(t, err, content) {
+			assert.Equal(t, expect, err.Error())
+		}
+	}
+
+	// properties 格式的值都是字符串
+	err = schema.ValidateContent(utils.FileFormatProperties, "port=8080\nhost=localhost")
+	assert.Equal(t, "$.port: expected type integer", err.Error())
+}
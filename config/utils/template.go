@@ -0,0 +1,334 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/polarismesh/polaris-server/common/model"
+	"github.com/polarismesh/polaris-server/common/utils"
+)
+
+var placeholderRegex = regexp.MustCompile(`\$?\$\{([^{}]*)\}`)
+
+// ConfigFileLoader 按照引用加载配置文件，文件不存在时返回 nil
+type ConfigFileLoader func(ref *model.ConfigFileRef) (*model.ConfigFile, error)
+
+// TemplateError 配置模板渲染错误，例如继承关系成环、引用的文件不存在、变量缺失
+type TemplateError struct {
+	Msg string
+}
+
+// Error 实现 error 接口
+func (e *TemplateError) Error() string {
+	return e.Msg
+}
+
+func templateErrorf(format string, args ...interface{}) error {
+	return &TemplateError{Msg: fmt.Sprintf(format, args...)}
+}
+
+// RenderTemplate 渲染配置模板：沿着继承链从最顶层的基础文件开始逐层合并内容，
+// 每一层的 ${group.key} 占位符使用待渲染文件引用的变量文件替换，$${...} 表示不替换的原文
+func RenderTemplate(file *model.ConfigFile, load ConfigFileLoader) (string, error) {
+	chain, err := resolveBaseChain(file, load)
+	if err != nil {
+		return "", err
+	}
+
+	variables, err := loadVariables(file.VariablesFile, load)
+	if err != nil {
+		return "", err
+	}
+
+	missing := make(map[string]struct{})
+	var rendered string
+	for i := len(chain) - 1; i >= 0; i-- {
+		content := chain[i].Content
+		if variables != nil {
+			content = substitute(content, variables, missing)
+		}
+		if i == len(chain)-1 {
+			rendered = content
+			continue
+		}
+		if rendered, err = mergeContent(file.Format, rendered, content); err != nil {
+			return "", templateErrorf("merge %s into base file error: %v", chain[i].Ref(), err)
+		}
+	}
+
+	if len(missing) > 0 {
+		names := make([]string, 0, len(missing))
+		for name := range missing {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return "", templateErrorf("missing template variables in %s: %s", file.VariablesFile,
+			strings.Join(names, ", "))
+	}
+	return rendered, nil
+}
+
+// resolveBaseChain 返回从待渲染文件到最顶层基础文件的继承链
+func resolveBaseChain(file *model.ConfigFile, load ConfigFileLoader) ([]*model.ConfigFile, error) {
+	chain := []*model.ConfigFile{file}
+	path := []string{file.Ref().String()}
+	visited := map[string]bool{path[0]: true}
+
+	for current := file; current.BaseFile != ""; {
+		ref := model.ParseConfigFileRef(current.BaseFile)
+		if ref == nil {
+			return nil, templateErrorf("invalid base file %s of %s", current.BaseFile, current.Ref())
+		}
+		key := ref.String()
+		if visited[key] {
+			return nil, templateErrorf("config file inheritance cycle: %s -> %s", strings.Join(path, " -> "), key)
+		}
+		base, err := load(ref)
+		if err != nil {
+			return nil, err
+		}
+		if base == nil {
+			return nil, templateErrorf("base file %s of %s not found", key, current.Ref())
+		}
+		if base.Format != file.Format {
+			return nil, templateErrorf("format of base file %s is %s, but %s is %s", key, base.Format,
+				file.Ref(), file.Format)
+		}
+		if base.Encrypted {
+			return nil, templateErrorf("base file %s is encrypted", key)
+		}
+		visited[key] = true
+		path = append(path, key)
+		chain = append(chain, base)
+		current = base
+	}
+	return chain, nil
+}
+
+// loadVariables 加载变量文件并展开为 key -> value，没有引用变量文件时返回 nil，不做替换
+func loadVariables(variablesFile string, load ConfigFileLoader) (map[string]string, error) {
+	if variablesFile == "" {
+		return nil, nil
+	}
+	ref := model.ParseConfigFileRef(variablesFile)
+	if ref == nil {
+		return nil, templateErrorf("invalid variables file %s", variablesFile)
+	}
+	file, err := load(ref)
+	if err != nil {
+		return nil, err
+	}
+	if file == nil {
+		return nil, templateErrorf("variables file %s not found", ref)
+	}
+	if file.Encrypted {
+		return nil, templateErrorf("variables file %s is encrypted", ref)
+	}
+	variables, err := FlattenContent(file.Format, file.Content)
+	if err != nil {
+		return nil, templateErrorf("parse variables file %s error: %v", ref, err)
+	}
+	if variables == nil {
+		return nil, templateErrorf("format %s of variables file %s is not supported", file.Format, ref)
+	}
+	return variables, nil
+}
+
+// substitute 替换内容中的占位符，缺失的变量记录到 missing 中
+func substitute(content string, variables map[string]string, missing map[string]struct{}) string {
+	return placeholderRegex.ReplaceAllStringFunc(content, func(placeholder string) string {
+		if strings.HasPrefix(placeholder, "$$") {
+			return placeholder[1:]
+		}
+		name := strings.TrimSpace(placeholder[2 : len(placeholder)-1])
+		value, ok := variables[name]
+		if !ok {
+			missing[name] = struct{}{}
+			return placeholder
+		}
+		return value
+	})
+}
+
+// mergeContent 合并基础文件和子文件的内容，yaml、json、properties 格式按照配置项覆盖，
+// 其余格式子文件内容为空时继承基础文件的内容，否则使用子文件的内容
+func mergeContent(format, base, child string) (string, error) {
+	if strings.TrimSpace(child) == "" {
+		return base, nil
+	}
+	if strings.TrimSpace(base) == "" {
+		return child, nil
+	}
+	switch format {
+	case utils.FileFormatProperties:
+		return mergeProperties(base, child), nil
+	case utils.FileFormatYaml:
+		return mergeYaml(base, child)
+	case utils.FileFormatJson:
+		return mergeJson(base, child)
+	default:
+		return child, nil
+	}
+}
+
+type propertiesEntry struct {
+	key string
+	raw string
+}
+
+// splitProperties 按照逻辑行拆分 properties 内容，注释和空行的 key 为空
+func splitProperties(content string) []propertiesEntry {
+	var (
+		entries []propertiesEntry
+		raw     []string
+		logical string
+	)
+	for _, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if len(raw) == 0 && (trimmed == "" || trimmed[0] == '#' || trimmed[0] == '!') {
+			entries = append(entries, propertiesEntry{raw: line})
+			continue
+		}
+		raw = append(raw, line)
+		if strings.HasSuffix(trimmed, "\\") {
+			logical += strings.TrimSuffix(trimmed, "\\")
+			continue
+		}
+		logical += trimmed
+		entries = append(entries, propertiesEntry{key: propertiesKey(logical), raw: strings.Join(raw, "\n")})
+		raw, logical = nil, ""
+	}
+	if len(raw) > 0 {
+		entries = append(entries, propertiesEntry{key: propertiesKey(logical), raw: strings.Join(raw, "\n")})
+	}
+	return entries
+}
+
+func propertiesKey(logical string) string {
+	if idx := strings.IndexAny(logical, "=:"); idx >= 0 {
+		return strings.TrimSpace(logical[:idx])
+	}
+	return logical
+}
+
+// mergeProperties 子文件中的配置项替换基础文件中同名的配置项，新增的配置项追加到末尾
+func mergeProperties(base, child string) string {
+	childEntries := splitProperties(child)
+	overrides := make(map[string]string)
+	for _, entry := range childEntries {
+		if entry.key != "" {
+			overrides[entry.key] = entry.raw
+		}
+	}
+
+	var lines []string
+	for _, entry := range splitProperties(strings.TrimRight(base, "\n")) {
+		if raw, ok := overrides[entry.key]; ok {
+			lines = append(lines, raw)
+			delete(overrides, entry.key)
+			continue
+		}
+		lines = append(lines, entry.raw)
+	}
+	for _, entry := range childEntries {
+		if _, ok := overrides[entry.key]; ok {
+			lines = append(lines, entry.raw)
+			delete(overrides, entry.key)
+		}
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// mergeYaml 递归合并 yaml 映射，保留基础文件中 key 的顺序
+func mergeYaml(base, child string) (string, error) {
+	var baseSlice, childSlice yaml.MapSlice
+	// 顶层不是映射时无法按照配置项合并，使用子文件的内容
+	if yaml.Unmarshal([]byte(base), &baseSlice) != nil || yaml.Unmarshal([]byte(child), &childSlice) != nil {
+		return child, nil
+	}
+	merged, err := yaml.Marshal(mergeMapSlice(baseSlice, childSlice))
+	if err != nil {
+		return "", err
+	}
+	return string(merged), nil
+}
+
+func mergeMapSlice(base, child yaml.MapSlice) yaml.MapSlice {
+	merged := make(yaml.MapSlice, len(base))
+	copy(merged, base)
+	for _, item := range child {
+		found := false
+		for i := range merged {
+			if merged[i].Key != item.Key {
+				continue
+			}
+			found = true
+			baseValue, baseOk := merged[i].Value.(yaml.MapSlice)
+			childValue, childOk := item.Value.(yaml.MapSlice)
+			if baseOk && childOk {
+				merged[i].Value = mergeMapSlice(baseValue, childValue)
+			} else {
+				merged[i].Value = item.Value
+			}
+			break
+		}
+		if !found {
+			merged = append(merged, item)
+		}
+	}
+	return merged
+}
+
+// mergeJson 递归合并 json 对象，顶层不是对象时使用子文件的内容
+func mergeJson(base, child string) (string, error) {
+	var baseData, childData interface{}
+	if err := json.Unmarshal([]byte(base), &baseData); err != nil {
+		return "", err
+	}
+	if err := json.Unmarshal([]byte(child), &childData); err != nil {
+		return "", err
+	}
+	merged, err := json.MarshalIndent(mergeJsonValue(baseData, childData), "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(merged), nil
+}
+
+func mergeJsonValue(base, child interface{}) interface{} {
+	baseObject, baseOk := base.(map[string]interface{})
+	childObject, childOk := child.(map[string]interface{})
+	if !baseOk || !childOk {
+		return child
+	}
+	for key, value := range childObject {
+		if baseValue, ok := baseObject[key]; ok {
+			baseObject[key] = mergeJsonValue(baseValue, value)
+		} else {
+			baseObject[key] = value
+		}
+	}
+	return baseObject
+}
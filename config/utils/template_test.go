@@ -0,0 +1,118 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package utils
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/polarismesh/polaris-server/common/model"
+	"github.com/polarismesh/polaris-server/common/utils"
+)
+
+func newTemplateLoader(files ...*model.ConfigFile) ConfigFileLoader {
+	return func(ref *model.ConfigFileRef) (*model.ConfigFile, error) {
+		for _, file := range files {
+			if file.Ref().String() == ref.String() {
+				return file, nil
+			}
+		}
+		return nil, nil
+	}
+}
+
+func TestRenderTemplate(t *testing.T) {
+	base := &model.ConfigFile{Namespace: "default", Group: "common", Name: "app.properties",
+		Format: utils.FileFormatProperties, Content: "# base\nhost=${db.host}\nport=3306\nname=app\n"}
+	vars := &model.ConfigFile{Namespace: "prod", Group: "vars", Name: "db.yaml",
+		Format: utils.FileFormatYaml, Content: "db:\n  host: 10.0.0.1\n  port: 3307\n"}
+	child := &model.ConfigFile{Namespace: "prod", Group: "app", Name: "app.properties",
+		Format: utils.FileFormatProperties, Content: "port=${db.port}\nextra=$${raw}\n",
+		BaseFile: "default/common/app.properties", VariablesFile: "prod/vars/db.yaml"}
+
+	rendered, err := RenderTemplate(child, newTemplateLoader(base, vars))
+	assert.Nil(t, err)
+	assert.Equal(t, "# base\nhost=10.0.0.1\nport=3307\nname=app\nextra=${raw}\n", rendered)
+
+	// 缺失的变量全部列出
+	child.Content = "port=${db.port}\nuser=${db.user}\npassword=${db.password}\n"
+	_, err = RenderTemplate(child, newTemplateLoader(base, vars))
+	assert.Equal(t, "missing template variables in prod/vars/db.yaml: db.password, db.user", err.Error())
+
+	// 不引用变量文件时保留占位符
+	child.VariablesFile = ""
+	child.Content = ""
+	rendered, err = RenderTemplate(child, newTemplateLoader(base))
+	assert.Nil(t, err)
+	assert.Equal(t, base.Content, rendered)
+
+	_, err = RenderTemplate(child, newTemplateLoader())
+	assert.Equal(t, "base file default/common/app.properties of prod/app/app.properties not found", err.Error())
+
+	loadErr := errors.New("store error")
+	_, err = RenderTemplate(child, func(ref *model.ConfigFileRef) (*model.ConfigFile, error) {
+		return nil, loadErr
+	})
+	assert.Equal(t, loadErr, err)
+}
+
+func TestRenderTemplateCycle(t *testing.T) {
+	a := &model.ConfigFile{Namespace: "ns", Group: "g", Name: "a", Format: utils.FileFormatText, BaseFile: "ns/g/b"}
+	b := &model.ConfigFile{Namespace: "ns", Group: "g", Name: "b", Format: utils.FileFormatText, BaseFile: "ns/g/c"}
+	c := &model.ConfigFile{Namespace: "ns", Group: "g", Name: "c", Format: utils.FileFormatText, BaseFile: "ns/g/a"}
+
+	_, err := RenderTemplate(a, newTemplateLoader(a, b, c))
+	_, ok := err.(*TemplateError)
+	assert.True(t, ok)
+	assert.Equal(t, "config file inheritance cycle: ns/g/a -> ns/g/b -> ns/g/c -> ns/g/a", err.Error())
+
+	c.BaseFile = ""
+	c.Content = "from c"
+	rendered, err := RenderTemplate(a, newTemplateLoader(a, b, c))
+	assert.Nil(t, err)
+	assert.Equal(t, "from c", rendered)
+
+	c.Format = utils.FileFormatJson
+	_, err = RenderTemplate(a, newTemplateLoader(a, b, c))
+	assert.Equal(t, "format of base file ns/g/c is json, but ns/g/a is text", err.Error())
+}
+
+func TestMergeContent(t *testing.T) {
+	merged, err := mergeContent(utils.FileFormatYaml, "server:\n  port: 80\n  host: a\nname: x\n",
+		"server:\n  port: 8080\nextra: true\n")
+	assert.Nil(t, err)
+	assert.Equal(t, "server:\n  port: 8080\n  host: a\nname: x\nextra: true\n", merged)
+
+	merged, err = mergeContent(utils.FileFormatJson, `{"a": {"b": 1, "c": 2}, "d": [1]}`, `{"a": {"b": 3}, "d": [2]}`)
+	assert.Nil(t, err)
+	assert.Equal(t, "{\n  \"a\": {\n    \"b\": 3,\n    \"c\": 2\n  },\n  \"d\": [\n    2\n  ]\n}", merged)
+
+	merged, err = mergeContent(utils.FileFormatProperties, "a=1\nb=2\\\n  3\nc=4", "b=5\nd=6")
+	assert.Nil(t, err)
+	assert.Equal(t, "a=1\nb=5\nc=4\nd=6\n", merged)
+
+	merged, err = mergeContent(utils.FileFormatXml, "<a/>", "<b/>")
+	assert.Nil(t, err)
+	assert.Equal(t, "<b/>", merged)
+
+	merged, err = mergeContent(utils.FileFormatXml, "<a/>", "  ")
+	assert.Nil(t, err)
+	assert.Equal(t, "<a/>", merged)
+}
@@ -0,0 +1,245 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package utils
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v2"
+
+	"github.com/polarismesh/polaris-server/common/utils"
+)
+
+// ContentValidator 配置内容校验器，校验失败时返回 *ContentError
+type ContentValidator func(content string) error
+
+// ContentError 配置内容错误，Line、Column 从 1 开始，为 0 表示无法定位
+type ContentError struct {
+	Line   int
+	Column int
+	Msg    string
+}
+
+// Error 实现 error 接口
+func (e *ContentError) Error() string {
+	if e.Line == 0 {
+		return e.Msg
+	}
+	if e.Column == 0 {
+		return fmt.Sprintf("line %d: %s", e.Line, e.Msg)
+	}
+	return fmt.Sprintf("line %d, column %d: %s", e.Line, e.Column, e.Msg)
+}
+
+var (
+	contentValidatorsLock sync.RWMutex
+	contentValidators     = map[string]ContentValidator{
+		utils.FileFormatJson:       validateJson,
+		utils.FileFormatYaml:       validateYaml,
+		utils.FileFormatXml:        validateXml,
+		utils.FileFormatProperties: validateProperties,
+		utils.FileFormatToml:       validateToml,
+		utils.FileFormatIni:        validateIni,
+	}
+
+	lineNumberRegex = regexp.MustCompile(`line (\d+)`)
+)
+
+// RegisterContentValidator 注册或者覆盖某种格式的配置内容校验器
+func RegisterContentValidator(format string, validator ContentValidator) {
+	contentValidatorsLock.Lock()
+	defer contentValidatorsLock.Unlock()
+	contentValidators[format] = validator
+}
+
+// CheckContentFormat 按照配置格式校验配置内容，没有注册校验器的格式以及空内容不校验
+func CheckContentFormat(format, content string) error {
+	if strings.TrimSpace(content) == "" {
+		return nil
+	}
+	contentValidatorsLock.RLock()
+	validator, ok := contentValidators[format]
+	contentValidatorsLock.RUnlock()
+	if !ok {
+		return nil
+	}
+	return validator(content)
+}
+
+func validateJson(content string) error {
+	reader := strings.NewReader(content)
+	decoder := json.NewDecoder(reader)
+	var data interface{}
+	if err := decoder.Decode(&data); err != nil {
+		var offset int64
+		switch e := err.(type) {
+		case *json.SyntaxError:
+			offset = e.Offset
+		case *json.UnmarshalTypeError:
+			offset = e.Offset
+		default:
+			offset = int64(len(content))
+		}
+		line, column := offsetToPosition(content, offset)
+		return &ContentError{Line: line, Column: column, Msg: err.Error()}
+	}
+	// 只允许有一个顶层的值
+	buffered, _ := ioutil.ReadAll(decoder.Buffered())
+	rest := string(buffered) + content[len(content)-reader.Len():]
+	if trimmed := strings.TrimSpace(rest); trimmed != "" {
+		offset := len(content) - len(rest) + strings.Index(rest, trimmed)
+		line, column := offsetToPosition(content, int64(offset))
+		return &ContentError{Line: line, Column: column, Msg: "invalid character after top-level value"}
+	}
+	return nil
+}
+
+func validateYaml(content string) error {
+	var data interface{}
+	if err := yaml.Unmarshal([]byte(content), &data); err != nil {
+		return &ContentError{Line: matchLineNumber(err.Error()), Msg: err.Error()}
+	}
+	return nil
+}
+
+func validateXml(content string) error {
+	decoder := xml.NewDecoder(strings.NewReader(content))
+	for {
+		_, err := decoder.Token()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			line, column := offsetToPosition(content, decoder.InputOffset())
+			if syntaxErr, ok := err.(*xml.SyntaxError); ok {
+				line = syntaxErr.Line
+			}
+			return &ContentError{Line: line, Column: column, Msg: err.Error()}
+		}
+	}
+}
+
+func validateToml(content string) error {
+	var data map[string]interface{}
+	if _, err := toml.Decode(content, &data); err != nil {
+		if parseErr, ok := err.(toml.ParseError); ok {
+			return &ContentError{Line: parseErr.Line, Msg: parseErr.Message}
+		}
+		return &ContentError{Line: matchLineNumber(err.Error()), Msg: err.Error()}
+	}
+	return nil
+}
+
+// validateProperties 校验每个配置项都有 key，以及 \uXXXX 转义字符是否合法
+func validateProperties(content string) error {
+	continued := false
+	for i, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		isValue := continued
+		continued = strings.HasSuffix(trimmed, "\\") && !strings.HasSuffix(trimmed, "\\\\")
+		if !isValue && (trimmed == "" || trimmed[0] == '#' || trimmed[0] == '!') {
+			continue
+		}
+		if !isValue && (trimmed[0] == '=' || trimmed[0] == ':') {
+			return &ContentError{Line: i + 1, Column: strings.Index(line, trimmed) + 1, Msg: "missing key"}
+		}
+		if column, err := checkUnicodeEscape(line); err != nil {
+			return &ContentError{Line: i + 1, Column: column, Msg: err.Error()}
+		}
+	}
+	return nil
+}
+
+func checkUnicodeEscape(line string) (int, error) {
+	for i := 0; i < len(line); i++ {
+		if line[i] != '\\' {
+			continue
+		}
+		if i+1 < len(line) && line[i+1] == 'u' {
+			if i+6 > len(line) {
+				return i + 1, errors.New("malformed \\uxxxx encoding")
+			}
+			if _, err := strconv.ParseUint(line[i+2:i+6], 16, 16); err != nil {
+				return i + 1, errors.New("malformed \\uxxxx encoding")
+			}
+		}
+		i++
+	}
+	return 0, nil
+}
+
+// validateIni 校验 [section] 和 key=value 的格式
+func validateIni(content string) error {
+	for i, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || trimmed[0] == ';' || trimmed[0] == '#' {
+			continue
+		}
+		column := strings.Index(line, trimmed) + 1
+		if trimmed[0] == '[' {
+			if !strings.HasSuffix(trimmed, "]") || strings.TrimSpace(trimmed[1:len(trimmed)-1]) == "" {
+				return &ContentError{Line: i + 1, Column: column, Msg: "invalid section"}
+			}
+			continue
+		}
+		idx := strings.IndexAny(trimmed, "=:")
+		if idx < 0 {
+			return &ContentError{Line: i + 1, Column: column, Msg: "missing '=' or ':' between key and value"}
+		}
+		if strings.TrimSpace(trimmed[:idx]) == "" {
+			return &ContentError{Line: i + 1, Column: column, Msg: "missing key"}
+		}
+	}
+	return nil
+}
+
+func matchLineNumber(msg string) int {
+	match := lineNumberRegex.FindStringSubmatch(msg)
+	if match == nil {
+		return 0
+	}
+	line, _ := strconv.Atoi(match[1])
+	return line
+}
+
+// offsetToPosition 把字节偏移量转换为行列号
+func offsetToPosition(content string, offset int64) (int, int) {
+	if offset > int64(len(content)) {
+		offset = int64(len(content))
+	}
+	line, column := 1, 1
+	for _, c := range content[:offset] {
+		if c == '\n' {
+			line++
+			column = 1
+			continue
+		}
+		column++
+	}
+	return line, column
+}
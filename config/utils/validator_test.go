@@ -0,0 +1,77 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/polarismesh/polaris-server/common/utils"
+)
+
+func TestCheckContentFormat(t *testing.T) {
+	valid := map[string]string{
+		utils.FileFormatJson:       "{\"a\": 1, \"b\": [1, 2]}",
+		utils.FileFormatYaml:       "a: 1\nb:\n  - 1\n  - 2\n",
+		utils.FileFormatXml:        "<a><b>1</b></a>",
+		utils.FileFormatToml:       "a = 1\n[b]\nc = \"d\"\n",
+		utils.FileFormatProperties: "# comment\na=1\nb:2\nc \\u4e2d\n",
+		utils.FileFormatIni:        "; comment\n[server]\nport = 8080\nhost: 127.0.0.1\n",
+		utils.FileFormatText:       "{ anything",
+	}
+	for format, content := range valid {
+		assert.Nil(t, CheckContentFormat(format, content), format)
+	}
+
+	cases := []struct {
+		format string
+		input  string
+		line   int
+	}{
+		{utils.FileFormatJson, "{\n  \"a\": 1,\n  \"b\" 2\n}", 3},
+		{utils.FileFormatJson, "{\"a\": 1}\n{\"b\": 2}", 2},
+		{utils.FileFormatYaml, "a: 1\nb: [1, 2\n", 2},
+		{utils.FileFormatXml, "<a>\n<b>1</c>\n</a>", 2},
+		{utils.FileFormatToml, "a = 1\nb = \n", 2},
+		{utils.FileFormatProperties, "a=1\n=2\n", 2},
+		{utils.FileFormatProperties, "a=1\nb=\\u12\n", 2},
+		{utils.FileFormatIni, "[server]\nport 8080\n", 2},
+		{utils.FileFormatIni, "[server\nport=8080\n", 1},
+	}
+	for _, c := range cases {
+		err := CheckContentFormat(c.format, c.input)
+		if !assert.NotNil(t, err, c.input) {
+			continue
+		}
+		contentErr, ok := err.(*ContentError)
+		assert.True(t, ok, c.input)
+		assert.Equal(t, c.line, contentErr.Line, c.input)
+	}
+}
+
+func TestRegisterContentValidator(t *testing.T) {
+	RegisterContentValidator("custom", func(content string) error {
+		if content != "ok" {
+			return &ContentError{Line: 1, Column: 1, Msg: "not ok"}
+		}
+		return nil
+	})
+	assert.Nil(t, CheckContentFormat("custom", "ok"))
+	assert.Equal(t, "line 1, column 1: not ok", CheckContentFormat("custom", "bad").Error())
+}
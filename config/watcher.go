@@ -18,10 +18,17 @@
 package config
 
 import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strings"
 	"sync"
+	"time"
 
 	"go.uber.org/zap"
 
+	"github.com/polarismesh/polaris-server/cache"
 	api "github.com/polarismesh/polaris-server/common/api/v1"
 	"github.com/polarismesh/polaris-server/common/log"
 	"github.com/polarismesh/polaris-server/common/model"
@@ -38,20 +45,29 @@ type FileReleaseCallback func(clientId string, rsp *api.ConfigClientResponse) bo
 type watchContext struct {
 	fileReleaseCb FileReleaseCallback
 	ClientVersion uint64
+	// 客户端 IP 和标签，用于匹配灰度规则
+	ClientIP string
+	Labels   map[string]string
+	// LastPollTime 客户端最后一次订阅的时间
+	LastPollTime time.Time
+	// ListenerId 客户端的稳定标识，长轮询每次请求的 clientId 都不同，上报订阅者时按照该标识聚合
+	ListenerId string
 }
 
 // watchCenter 处理客户端订阅配置请求，监听配置文件发布事件通知客户端
 type watchCenter struct {
 	eventCenter         *Center
+	fileCache           *cache.FileCache
 	configFileWatchers  *sync.Map // fileId -> clientId -> watchContext
 	lock                *sync.Mutex
 	releaseMessageQueue chan *model.ConfigFileRelease
 }
 
 // NewWatchCenter 创建一个客户端监听配置发布的处理中心
-func NewWatchCenter(eventCenter *Center) *watchCenter {
+func NewWatchCenter(eventCenter *Center, fileCache *cache.FileCache) *watchCenter {
 	wc := &watchCenter{
 		eventCenter:         eventCenter,
+		fileCache:           fileCache,
 		configFileWatchers:  new(sync.Map),
 		lock:                new(sync.Mutex),
 		releaseMessageQueue: make(chan *model.ConfigFileRelease, QueueSize),
@@ -70,10 +86,29 @@ func NewWatchCenter(eventCenter *Center) *watchCenter {
 // AddWatcher 新增订阅者
 func (wc *watchCenter) AddWatcher(clientId string, watchConfigFiles []*api.ClientConfigFileInfo,
 	fileReleaseCb FileReleaseCallback) {
+	wc.AddClientWatcher(context.Background(), clientId, watchConfigFiles, fileReleaseCb)
+}
+
+// AddClientWatcher 新增订阅者，同时从 ctx 中记录客户端的 IP 和标签，用于灰度发布
+func (wc *watchCenter) AddClientWatcher(ctx context.Context, clientId string,
+	watchConfigFiles []*api.ClientConfigFileInfo, fileReleaseCb FileReleaseCallback) {
 	if len(watchConfigFiles) == 0 {
 		return
 	}
+	clientIP, clientLabels := utils.ParseClientIP(ctx), utils.ParseClientLabels(ctx)
 	for _, file := range watchConfigFiles {
+		watchCtx := &watchContext{
+			fileReleaseCb: fileReleaseCb,
+			ClientVersion: file.Version.GetValue(),
+			ClientIP:      clientIP,
+			Labels:        clientLabels,
+			LastPollTime:  time.Now(),
+		}
+		if len(file.GetLabels()) > 0 {
+			watchCtx.Labels = file.GetLabels()
+		}
+		watchCtx.ListenerId = listenerId(clientId, clientIP, watchCtx.Labels)
+
 		watchFileId := utils.GenFileId(file.Namespace.GetValue(), file.Group.GetValue(), file.FileName.GetValue())
 		watchers, ok := wc.configFileWatchers.Load(watchFileId)
 		if !ok {
@@ -82,21 +117,17 @@ func (wc *watchCenter) AddWatcher(clientId string, watchConfigFiles []*api.Clien
 			watchers, ok = wc.configFileWatchers.Load(watchFileId)
 			if !ok {
 				newWatchers := new(sync.Map)
-				newWatchers.Store(clientId, &watchContext{
-					fileReleaseCb: fileReleaseCb,
-					ClientVersion: file.Version.GetValue(),
-				})
+				newWatchers.Store(clientId, watchCtx)
 				wc.configFileWatchers.Store(watchFileId, newWatchers)
 			}
 			wc.lock.Unlock()
-			continue
+			if !ok {
+				continue
+			}
 		}
 
 		watcherMap := watchers.(*sync.Map)
-		watcherMap.Store(clientId, &watchContext{
-			fileReleaseCb: fileReleaseCb,
-			ClientVersion: file.Version.GetValue(),
-		})
+		watcherMap.Store(clientId, watchCtx)
 	}
 }
 
@@ -117,6 +148,56 @@ func (wc *watchCenter) RemoveWatcher(clientId string, watchConfigFiles []*api.Cl
 	}
 }
 
+// Listeners 获取本节点订阅配置文件的客户端快照，同一个客户端的多次订阅只保留最后一次
+func (wc *watchCenter) Listeners() []*model.ConfigFileListener {
+	var listeners []*model.ConfigFileListener
+	wc.configFileWatchers.Range(func(fileId, watchers interface{}) bool {
+		namespace, group, fileName := utils.ParseFileId(fileId.(string))
+		fileListeners := make(map[string]*model.ConfigFileListener)
+		watchers.(*sync.Map).Range(func(_, watchCtx interface{}) bool {
+			c := watchCtx.(*watchContext)
+			if exist, ok := fileListeners[c.ListenerId]; ok && (exist.LastPollTime.After(c.LastPollTime) ||
+				(exist.LastPollTime.Equal(c.LastPollTime) && exist.Version >= c.ClientVersion)) {
+				return true
+			}
+			fileListeners[c.ListenerId] = &model.ConfigFileListener{
+				ClientId:     c.ListenerId,
+				ClientIP:     c.ClientIP,
+				Namespace:    namespace,
+				Group:        group,
+				FileName:     fileName,
+				Version:      c.ClientVersion,
+				LastPollTime: c.LastPollTime,
+				Server:       utils.LocalHost,
+			}
+			return true
+		})
+		for _, listener := range fileListeners {
+			listeners = append(listeners, listener)
+		}
+		return true
+	})
+	return listeners
+}
+
+// listenerId 按照客户端 IP 和标签生成订阅者的稳定标识，拿不到客户端 IP 时使用 clientId
+func listenerId(clientId, clientIP string, labels map[string]string) string {
+	if clientIP == "" {
+		return clientId
+	}
+	if len(labels) == 0 {
+		return clientIP
+	}
+	pairs := make([]string, 0, len(labels))
+	for k, v := range labels {
+		pairs = append(pairs, k+"="+v)
+	}
+	sort.Strings(pairs)
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(strings.Join(pairs, ",")))
+	return fmt.Sprintf("%s#%x", clientIP, h.Sum64())
+}
+
 func (wc *watchCenter) handleMessage() {
 	go func() {
 		defer func() {
@@ -141,19 +222,40 @@ func (wc *watchCenter) notifyToWatchers(publishConfigFile *model.ConfigFileRelea
 		return
 	}
 
+	// 灰度发布结束后会重新发布正式版本，由正式版本的事件通知所有客户端
+	if publishConfigFile.GrayRule != nil && publishConfigFile.Flag != 0 {
+		return
+	}
+
+	// 正式版本的事件不通知命中生效中灰度规则的客户端
+	grayRule := publishConfigFile.GrayRule
+	if grayRule == nil && wc.fileCache != nil {
+		entry, ok := wc.fileCache.Get(publishConfigFile.Namespace, publishConfigFile.Group, publishConfigFile.FileName)
+		if ok && entry.Gray != nil {
+			grayRule = entry.Gray.Rule
+		}
+	}
+	isGray := publishConfigFile.GrayRule != nil
+
 	response := utils2.GenConfigFileResponse(publishConfigFile.Namespace, publishConfigFile.Group,
 		publishConfigFile.FileName, "", publishConfigFile.Md5, publishConfigFile.Version)
 
 	watcherMap := watchers.(*sync.Map)
 	watcherMap.Range(func(clientId, watchCtx interface{}) bool {
+		c := watchCtx.(*watchContext)
+		if grayRule != nil && grayRule.Match(c.ClientIP, c.Labels) != isGray {
+			return true
+		}
+
 		log.ConfigScope().Info("[Config][Watcher] notify to client.",
 			zap.String("file", watchFileId),
 			zap.String("clientId", clientId.(string)),
+			zap.Bool("gray", isGray),
 			zap.Uint64("version", publishConfigFile.Version))
 
-		c := watchCtx.(*watchContext)
-		if c.ClientVersion < publishConfigFile.Version {
-			c.fileReleaseCb(clientId.(string), response)
+		// 长连接订阅者会持续收到通知，推送成功后更新客户端版本，避免重复推送
+		if c.ClientVersion < publishConfigFile.Version && c.fileReleaseCb(clientId.(string), response) {
+			c.ClientVersion = publishConfigFile.Version
 		}
 		return true
 	})
@@ -19,9 +19,11 @@ package maintain
 
 import (
 	"context"
+	"io"
 
 	api "github.com/polarismesh/polaris-server/common/api/v1"
 	"github.com/polarismesh/polaris-server/common/connlimit"
+	"github.com/polarismesh/polaris-server/store/migrate"
 )
 
 type ConnReq struct {
@@ -71,4 +73,10 @@ type MaintainOperateServer interface {
 
 	// SetLogOutputLevel Set log output level by scope
 	SetLogOutputLevel(ctx context.Context, scope string, level string) error
+
+	// Backup Export the data of the namespaces as a portable archive, all namespaces if empty
+	Backup(ctx context.Context, w io.Writer, namespaces []string) error
+
+	// Restore Restore the data of the namespaces from a backup archive, all namespaces if empty
+	Restore(ctx context.Context, r io.Reader, namespaces []string, dryRun bool) (*migrate.Report, error)
 }
@@ -25,6 +25,7 @@ import (
 	"github.com/polarismesh/polaris-server/auth"
 	"github.com/polarismesh/polaris-server/service"
 	"github.com/polarismesh/polaris-server/service/healthcheck"
+	"github.com/polarismesh/polaris-server/store"
 )
 
 var (
@@ -56,9 +57,15 @@ func initialize(ctx context.Context, namingService service.DiscoverServer, healt
 		return err
 	}
 
+	storage, err := store.GetStore()
+	if err != nil {
+		return err
+	}
+
 	maintainServer.freeMemMu = new(sync.Mutex)
 	maintainServer.namingServer = namingService
 	maintainServer.healthCheckServer = healthCheckServer
+	maintainServer.storage = storage
 
 	server = newServerAuthAbility(maintainServer, authServer)
 	return nil
@@ -20,12 +20,15 @@ package maintain
 import (
 	"context"
 	"errors"
+	"io"
 	"runtime/debug"
 	"time"
 
 	api "github.com/polarismesh/polaris-server/common/api/v1"
 	"github.com/polarismesh/polaris-server/common/connlimit"
 	commonlog "github.com/polarismesh/polaris-server/common/log"
+	"github.com/polarismesh/polaris-server/store/backup"
+	"github.com/polarismesh/polaris-server/store/migrate"
 )
 
 func (s *Server) GetServerConnections(ctx context.Context, req *ConnReq) (*ConnCountResp, error) {
@@ -149,3 +152,27 @@ func (s *Server) GetLogOutputLevel(ctx context.Context) (map[string]string, erro
 func (s *Server) SetLogOutputLevel(ctx context.Context, scope string, level string) error {
 	return commonlog.SetLogOutputLevel(scope, level)
 }
+
+func (s *Server) Backup(ctx context.Context, w io.Writer, namespaces []string) error {
+	log.Infof("[MAINTAIN] start doing backup, namespaces: %v", namespaces)
+	start := time.Now()
+	if _, err := backup.Export(s.storage, w, namespaces); err != nil {
+		log.Errorf("[MAINTAIN] backup err: %s", err.Error())
+		return err
+	}
+	log.Infof("[MAINTAIN] finish doing backup, used time: %v", time.Since(start))
+	return nil
+}
+
+func (s *Server) Restore(ctx context.Context, r io.Reader, namespaces []string,
+	dryRun bool) (*migrate.Report, error) {
+	log.Infof("[MAINTAIN] start doing restore, namespaces: %v, dry-run: %v", namespaces, dryRun)
+	start := time.Now()
+	report, err := backup.Restore(s.storage, r, namespaces, dryRun)
+	if err != nil {
+		log.Errorf("[MAINTAIN] restore err: %s", err.Error())
+		return nil, err
+	}
+	log.Infof("[MAINTAIN] finish doing restore, used time: %v", time.Since(start))
+	return report, nil
+}
@@ -19,9 +19,11 @@ package maintain
 
 import (
 	"context"
+	"io"
 
 	api "github.com/polarismesh/polaris-server/common/api/v1"
 	"github.com/polarismesh/polaris-server/common/model"
+	"github.com/polarismesh/polaris-server/store/migrate"
 )
 
 func (svr *serverAuthAbility) GetServerConnections(ctx context.Context, req *ConnReq) (*ConnCountResp, error) {
@@ -103,3 +105,24 @@ func (svr *serverAuthAbility) SetLogOutputLevel(ctx context.Context, scope strin
 
 	return svr.targetServer.SetLogOutputLevel(ctx, scope, level)
 }
+
+func (svr *serverAuthAbility) Backup(ctx context.Context, w io.Writer, namespaces []string) error {
+	authCtx := svr.collectMaintainAuthContext(ctx, model.Read, "Backup")
+	_, err := svr.authMgn.CheckConsolePermission(authCtx)
+	if err != nil {
+		return err
+	}
+
+	return svr.targetServer.Backup(ctx, w, namespaces)
+}
+
+func (svr *serverAuthAbility) Restore(ctx context.Context, r io.Reader, namespaces []string,
+	dryRun bool) (*migrate.Report, error) {
+	authCtx := svr.collectMaintainAuthContext(ctx, model.Create, "Restore")
+	_, err := svr.authMgn.CheckConsolePermission(authCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	return svr.targetServer.Restore(ctx, r, namespaces, dryRun)
+}
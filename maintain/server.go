@@ -22,10 +22,12 @@ import (
 
 	"github.com/polarismesh/polaris-server/service"
 	"github.com/polarismesh/polaris-server/service/healthcheck"
+	"github.com/polarismesh/polaris-server/store"
 )
 
 type Server struct {
 	freeMemMu         *sync.Mutex
 	namingServer      service.DiscoverServer
 	healthCheckServer *healthcheck.Server
+	storage           store.Store
 }
@@ -19,11 +19,14 @@ package main
 
 import (
 	// api-server 插件注册
+	_ "github.com/polarismesh/polaris-server/apiserver/apolloserver"
+	_ "github.com/polarismesh/polaris-server/apiserver/consulserver"
 	_ "github.com/polarismesh/polaris-server/apiserver/eurekaserver"
 	_ "github.com/polarismesh/polaris-server/apiserver/grpcserver/config"
 	_ "github.com/polarismesh/polaris-server/apiserver/grpcserver/discover"
 	_ "github.com/polarismesh/polaris-server/apiserver/httpserver"
 	_ "github.com/polarismesh/polaris-server/apiserver/l5pbserver"
+	_ "github.com/polarismesh/polaris-server/apiserver/nacosserver"
 	_ "github.com/polarismesh/polaris-server/apiserver/prometheussd"
 	_ "github.com/polarismesh/polaris-server/apiserver/xdsserverv3"
 
@@ -38,7 +41,10 @@ import (
 	_ "github.com/polarismesh/polaris-server/plugin/auth/platform"
 	_ "github.com/polarismesh/polaris-server/plugin/discoverevent/local"
 	_ "github.com/polarismesh/polaris-server/plugin/discoverstat/discoverlocal"
+	_ "github.com/polarismesh/polaris-server/plugin/eventbus/memory"
+	_ "github.com/polarismesh/polaris-server/plugin/eventbus/storeseq"
 	_ "github.com/polarismesh/polaris-server/plugin/history/logger"
+	_ "github.com/polarismesh/polaris-server/plugin/kms/local"
 	_ "github.com/polarismesh/polaris-server/plugin/password"
 	_ "github.com/polarismesh/polaris-server/plugin/ratelimit/lrurate"
 	_ "github.com/polarismesh/polaris-server/plugin/ratelimit/token"
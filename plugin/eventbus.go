@@ -0,0 +1,59 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package plugin
+
+import (
+	"os"
+	"sync"
+
+	"github.com/polarismesh/polaris-server/common/log"
+)
+
+var (
+	eventBusOnce = &sync.Once{}
+)
+
+// EventBusHandler 集群事件的处理函数
+type EventBusHandler func(payload []byte)
+
+// EventBus 集群事件总线插件，某个节点发布的事件会通知到集群内所有节点（包括自身）的订阅者
+type EventBus interface {
+	Plugin
+	// Publish 发布事件
+	Publish(topic string, payload []byte) error
+	// Subscribe 订阅事件，同一个 topic 的事件按发布顺序回调
+	Subscribe(topic string, handler EventBusHandler) error
+}
+
+// GetEventBus 获取集群事件总线插件，未配置时返回 nil
+func GetEventBus() EventBus {
+	c := &config.EventBus
+	plugin, exist := pluginSet[c.Name]
+	if !exist {
+		return nil
+	}
+
+	eventBusOnce.Do(func() {
+		if err := plugin.Initialize(c); err != nil {
+			log.Errorf("plugin init err: %s", err.Error())
+			os.Exit(-1)
+		}
+	})
+
+	return plugin.(EventBus)
+}
@@ -0,0 +1,106 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package memory
+
+import (
+	"sync"
+
+	"github.com/polarismesh/polaris-server/plugin"
+)
+
+const (
+	// PluginName 进程内事件总线插件名，仅适用于单节点部署
+	PluginName       = "memoryEventBus"
+	defaultQueueSize = 1024
+)
+
+func init() {
+	plugin.RegisterPlugin(PluginName, &EventBus{})
+}
+
+type event struct {
+	topic   string
+	payload []byte
+}
+
+// EventBus 进程内的事件总线，事件由单个协程按发布顺序分发给订阅者
+type EventBus struct {
+	lock     sync.RWMutex
+	handlers map[string][]plugin.EventBusHandler
+	eventCh  chan *event
+	stopCh   chan struct{}
+}
+
+// Name 返回插件名字
+func (m *EventBus) Name() string {
+	return PluginName
+}
+
+// Initialize 插件初始化
+func (m *EventBus) Initialize(c *plugin.ConfigEntry) error {
+	queueSize := defaultQueueSize
+	if size, ok := c.Option["queueSize"].(int); ok && size > 0 {
+		queueSize = size
+	}
+	m.handlers = make(map[string][]plugin.EventBusHandler)
+	m.eventCh = make(chan *event, queueSize)
+	m.stopCh = make(chan struct{})
+	go m.run()
+	return nil
+}
+
+// Destroy 销毁插件
+func (m *EventBus) Destroy() error {
+	if m.stopCh != nil {
+		close(m.stopCh)
+	}
+	return nil
+}
+
+// Publish 发布事件，队列满时阻塞直至有空位
+func (m *EventBus) Publish(topic string, payload []byte) error {
+	select {
+	case m.eventCh <- &event{topic: topic, payload: payload}:
+	case <-m.stopCh:
+	}
+	return nil
+}
+
+// Subscribe 订阅事件
+func (m *EventBus) Subscribe(topic string, handler plugin.EventBusHandler) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.handlers[topic] = append(m.handlers[topic], handler)
+	return nil
+}
+
+func (m *EventBus) run() {
+	for {
+		select {
+		case e := <-m.eventCh:
+			m.lock.RLock()
+			handlers := m.handlers[e.topic]
+			m.lock.RUnlock()
+			for _, handler := range handlers {
+				handler(e.payload)
+			}
+		case <-m.stopCh:
+			return
+		}
+	}
+}
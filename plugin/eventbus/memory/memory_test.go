@@ -0,0 +1,56 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package memory
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/polarismesh/polaris-server/plugin"
+)
+
+func TestEventBus(t *testing.T) {
+	bus := &EventBus{}
+	assert.Nil(t, bus.Initialize(&plugin.ConfigEntry{Option: map[string]interface{}{"queueSize": 4}}))
+	defer bus.Destroy()
+
+	received := make(chan string, 10)
+	assert.Nil(t, bus.Subscribe("a", func(payload []byte) {
+		received <- string(payload)
+	}))
+	for _, payload := range []string{"1", "2", "3"} {
+		assert.Nil(t, bus.Publish("a", []byte(payload)))
+	}
+	assert.Nil(t, bus.Publish("b", []byte("ignored")))
+
+	for _, expect := range []string{"1", "2", "3"} {
+		select {
+		case payload := <-received:
+			assert.Equal(t, expect, payload)
+		case <-time.After(time.Second):
+			t.Fatalf("event %s not received", expect)
+		}
+	}
+	select {
+	case payload := <-received:
+		t.Fatalf("unexpected event %s", payload)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
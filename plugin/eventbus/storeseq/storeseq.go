@@ -0,0 +1,184 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package storeseq
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/polarismesh/polaris-server/common/log"
+	"github.com/polarismesh/polaris-server/common/model"
+	"github.com/polarismesh/polaris-server/plugin"
+	"github.com/polarismesh/polaris-server/store"
+)
+
+const (
+	// PluginName 基于存储层自增序号的事件总线插件名，节点共享同一个存储即可互相通知
+	PluginName = "storeEventBus"
+
+	defaultPollInterval = 200 * time.Millisecond
+	defaultRetention    = 10 * time.Minute
+	defaultGapTimeout   = 3 * time.Second
+	cleanInterval       = time.Minute
+	batchSize           = 100
+)
+
+func init() {
+	plugin.RegisterPlugin(PluginName, &EventBus{})
+}
+
+// EventBus 发布节点把事件写入存储，所有节点按序号轮询新增的事件并回调订阅者
+type EventBus struct {
+	events       store.ClusterEventStore
+	pollInterval time.Duration
+	retention    time.Duration
+	gapTimeout   time.Duration
+
+	lock     sync.RWMutex
+	handlers map[string][]plugin.EventBusHandler
+	// seq 已经分发的最大序号
+	seq uint64
+	// gapSince 序号出现空洞的起始时间，数据库自增序号的事务可能乱序提交
+	gapSince time.Time
+	stopCh   chan struct{}
+}
+
+// Name 返回插件名字
+func (e *EventBus) Name() string {
+	return PluginName
+}
+
+// Initialize 插件初始化
+func (e *EventBus) Initialize(c *plugin.ConfigEntry) error {
+	s, err := store.GetStore()
+	if err != nil {
+		return err
+	}
+	events, ok := s.(store.ClusterEventStore)
+	if !ok {
+		return errors.New("store " + s.Name() + " not support cluster event")
+	}
+	e.events = events
+	e.pollInterval = defaultPollInterval
+	if interval, ok := c.Option["pollInterval"].(int); ok && interval > 0 {
+		e.pollInterval = time.Duration(interval) * time.Millisecond
+	}
+	e.retention = defaultRetention
+	if retention, ok := c.Option["retention"].(int); ok && retention > 0 {
+		e.retention = time.Duration(retention) * time.Second
+	}
+	e.gapTimeout = defaultGapTimeout
+	e.handlers = make(map[string][]plugin.EventBusHandler)
+	e.stopCh = make(chan struct{})
+
+	// 只关注启动之后发布的事件
+	if e.seq, err = e.events.GetLatestClusterEventSeq(); err != nil {
+		return err
+	}
+	go e.run()
+	return nil
+}
+
+// Destroy 销毁插件
+func (e *EventBus) Destroy() error {
+	if e.stopCh != nil {
+		close(e.stopCh)
+	}
+	return nil
+}
+
+// Publish 发布事件
+func (e *EventBus) Publish(topic string, payload []byte) error {
+	return e.events.AppendClusterEvent(topic, string(payload))
+}
+
+// Subscribe 订阅事件
+func (e *EventBus) Subscribe(topic string, handler plugin.EventBusHandler) error {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	e.handlers[topic] = append(e.handlers[topic], handler)
+	return nil
+}
+
+func (e *EventBus) run() {
+	pollTicker := time.NewTicker(e.pollInterval)
+	defer pollTicker.Stop()
+	cleanTicker := time.NewTicker(cleanInterval)
+	defer cleanTicker.Stop()
+	for {
+		select {
+		case <-pollTicker.C:
+			e.poll()
+		case <-cleanTicker.C:
+			if err := e.events.CleanClusterEvents(time.Now().Add(-e.retention)); err != nil {
+				log.Errorf("[EventBus] clean cluster events err: %s", err.Error())
+			}
+		case <-e.stopCh:
+			return
+		}
+	}
+}
+
+// poll 拉取新增的事件并按序号分发
+func (e *EventBus) poll() {
+	for {
+		events, err := e.events.GetClusterEvents(e.seq, batchSize)
+		if err != nil {
+			log.Errorf("[EventBus] get cluster events from %d err: %s", e.seq, err.Error())
+			return
+		}
+		for _, event := range events {
+			if !e.acceptSeq(event.Seq) {
+				return
+			}
+			e.dispatch(event)
+		}
+		if len(events) < batchSize {
+			return
+		}
+	}
+}
+
+// acceptSeq 序号连续时直接分发，出现空洞时等待未提交的事务，超时后跳过空洞
+func (e *EventBus) acceptSeq(seq uint64) bool {
+	if seq == e.seq+1 {
+		e.gapSince = time.Time{}
+		return true
+	}
+	if e.gapSince.IsZero() {
+		e.gapSince = time.Now()
+		return false
+	}
+	if time.Since(e.gapSince) < e.gapTimeout {
+		return false
+	}
+	log.Warnf("[EventBus] skip cluster events from %d to %d", e.seq+1, seq-1)
+	e.gapSince = time.Time{}
+	return true
+}
+
+func (e *EventBus) dispatch(event *model.ClusterEvent) {
+	e.seq = event.Seq
+	e.lock.RLock()
+	handlers := e.handlers[event.Topic]
+	e.lock.RUnlock()
+	for _, handler := range handlers {
+		handler([]byte(event.Payload))
+	}
+}
@@ -0,0 +1,99 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package storeseq
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/polarismesh/polaris-server/common/model"
+	"github.com/polarismesh/polaris-server/plugin"
+)
+
+// fakeEvents 按序号保存的事件，可以模拟未提交事务造成的序号空洞
+type fakeEvents struct {
+	events []*model.ClusterEvent
+}
+
+func (f *fakeEvents) AppendClusterEvent(topic, payload string) error {
+	f.add(uint64(len(f.events)+1), topic, payload)
+	return nil
+}
+
+func (f *fakeEvents) add(seq uint64, topic, payload string) {
+	f.events = append(f.events, &model.ClusterEvent{Seq: seq, Topic: topic, Payload: payload})
+}
+
+func (f *fakeEvents) GetLatestClusterEventSeq() (uint64, error) {
+	if len(f.events) == 0 {
+		return 0, nil
+	}
+	return f.events[len(f.events)-1].Seq, nil
+}
+
+func (f *fakeEvents) GetClusterEvents(seq uint64, limit uint32) ([]*model.ClusterEvent, error) {
+	ret := make([]*model.ClusterEvent, 0)
+	for _, event := range f.events {
+		if event.Seq > seq && uint32(len(ret)) < limit {
+			ret = append(ret, event)
+		}
+	}
+	return ret, nil
+}
+
+func (f *fakeEvents) CleanClusterEvents(ctime time.Time) error {
+	return nil
+}
+
+func TestEventBus_poll(t *testing.T) {
+	events := &fakeEvents{}
+	bus := &EventBus{
+		events:     events,
+		gapTimeout: 50 * time.Millisecond,
+		handlers:   make(map[string][]plugin.EventBusHandler),
+	}
+	var received []string
+	assert.Nil(t, bus.Subscribe("a", func(payload []byte) {
+		received = append(received, string(payload))
+	}))
+
+	assert.Nil(t, bus.Publish("a", []byte("1")))
+	assert.Nil(t, bus.Publish("b", []byte("2")))
+	bus.poll()
+	assert.Equal(t, []string{"1"}, received)
+	assert.Equal(t, uint64(2), bus.seq)
+
+	// 序号 3 的事务还未提交，先等待
+	events.add(4, "a", "4")
+	bus.poll()
+	assert.Equal(t, []string{"1"}, received)
+	events.events = append(events.events[:2], &model.ClusterEvent{Seq: 3, Topic: "a", Payload: "3"}, events.events[2])
+	bus.poll()
+	assert.Equal(t, []string{"1", "3", "4"}, received)
+
+	// 序号 5 一直没有出现，超时后跳过
+	events.add(6, "a", "6")
+	bus.poll()
+	assert.Equal(t, []string{"1", "3", "4"}, received)
+	time.Sleep(60 * time.Millisecond)
+	bus.poll()
+	assert.Equal(t, []string{"1", "3", "4", "6"}, received)
+	assert.Equal(t, uint64(6), bus.seq)
+}
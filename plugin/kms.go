@@ -0,0 +1,56 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package plugin
+
+import (
+	"os"
+	"sync"
+
+	"github.com/polarismesh/polaris-server/common/log"
+)
+
+var (
+	kmsOnce = &sync.Once{}
+)
+
+// KMS 密钥管理插件，为加密的配置文件生成数据密钥，数据密钥经主密钥加密后和密文一起保存
+type KMS interface {
+	Plugin
+	// GenerateDataKey 生成数据密钥，返回明文密钥以及经主密钥加密后的密钥
+	GenerateDataKey() ([]byte, string, error)
+	// DecryptDataKey 解密经主密钥加密的数据密钥
+	DecryptDataKey(cipherKey string) ([]byte, error)
+}
+
+// GetKMS 获取密钥管理插件
+func GetKMS() KMS {
+	c := &config.KMS
+	plugin, exist := pluginSet[c.Name]
+	if !exist {
+		return nil
+	}
+
+	kmsOnce.Do(func() {
+		if err := plugin.Initialize(c); err != nil {
+			log.Errorf("plugin init err: %s", err.Error())
+			os.Exit(-1)
+		}
+	})
+
+	return plugin.(KMS)
+}
@@ -0,0 +1,115 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package local
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"github.com/polarismesh/polaris-server/plugin"
+)
+
+const (
+	// PluginName 本地密钥文件插件名
+	PluginName = "localKeyFile"
+	// dataKeySize 数据密钥长度，使用 AES-256
+	dataKeySize = 32
+)
+
+func init() {
+	plugin.RegisterPlugin(PluginName, &KeyFile{})
+}
+
+// KeyFile 从本地文件读取主密钥的密钥管理插件，文件内容为 base64 编码的 16、24 或 32 字节的 AES 密钥
+type KeyFile struct {
+	masterKey cipher.AEAD
+}
+
+// Name 返回插件名字
+func (k *KeyFile) Name() string {
+	return PluginName
+}
+
+// Initialize 插件初始化，读取主密钥
+func (k *KeyFile) Initialize(c *plugin.ConfigEntry) error {
+	keyFile, _ := c.Option["keyFile"].(string)
+	if keyFile == "" {
+		return errors.New("kms plugin option keyFile is required")
+	}
+	data, err := ioutil.ReadFile(keyFile)
+	if err != nil {
+		return err
+	}
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(data)))
+	if err != nil {
+		return fmt.Errorf("decode master key from %s: %v", keyFile, err)
+	}
+	aead, err := newAEAD(key)
+	if err != nil {
+		return fmt.Errorf("invalid master key in %s: %v", keyFile, err)
+	}
+	k.masterKey = aead
+	return nil
+}
+
+// Destroy 销毁插件
+func (k *KeyFile) Destroy() error {
+	return nil
+}
+
+// GenerateDataKey 生成随机的数据密钥，并用主密钥加密
+func (k *KeyFile) GenerateDataKey() ([]byte, string, error) {
+	dataKey := make([]byte, dataKeySize)
+	if _, err := io.ReadFull(rand.Reader, dataKey); err != nil {
+		return nil, "", err
+	}
+	nonce := make([]byte, k.masterKey.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, "", err
+	}
+	sealed := k.masterKey.Seal(nonce, nonce, dataKey, nil)
+	return dataKey, base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// DecryptDataKey 用主密钥解密数据密钥
+func (k *KeyFile) DecryptDataKey(cipherKey string) ([]byte, error) {
+	sealed, err := base64.StdEncoding.DecodeString(cipherKey)
+	if err != nil {
+		return nil, err
+	}
+	nonceSize := k.masterKey.NonceSize()
+	if len(sealed) < nonceSize {
+		return nil, errors.New("invalid cipher data key")
+	}
+	return k.masterKey.Open(nil, sealed[:nonceSize], sealed[nonceSize:], nil)
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
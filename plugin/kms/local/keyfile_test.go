@@ -0,0 +1,63 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package local
+
+import (
+	"encoding/base64"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/polarismesh/polaris-server/plugin"
+)
+
+func TestKeyFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "kms")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	keyFile := filepath.Join(dir, "master.key")
+	masterKey := base64.StdEncoding.EncodeToString([]byte("0123456789abcdef0123456789abcdef"))
+	assert.Nil(t, ioutil.WriteFile(keyFile, []byte(masterKey+"\n"), 0600))
+
+	kms := &KeyFile{}
+	assert.Nil(t, kms.Initialize(&plugin.ConfigEntry{Option: map[string]interface{}{"keyFile": keyFile}}))
+
+	dataKey, cipherKey, err := kms.GenerateDataKey()
+	assert.Nil(t, err)
+	assert.Equal(t, dataKeySize, len(dataKey))
+
+	plainKey, err := kms.DecryptDataKey(cipherKey)
+	assert.Nil(t, err)
+	assert.Equal(t, dataKey, plainKey)
+
+	// 其他主密钥无法解密
+	other := &KeyFile{}
+	otherKey := base64.StdEncoding.EncodeToString([]byte("fedcba9876543210"))
+	assert.Nil(t, ioutil.WriteFile(keyFile, []byte(otherKey), 0600))
+	assert.Nil(t, other.Initialize(&plugin.ConfigEntry{Option: map[string]interface{}{"keyFile": keyFile}}))
+	_, err = other.DecryptDataKey(cipherKey)
+	assert.NotNil(t, err)
+
+	assert.Nil(t, ioutil.WriteFile(keyFile, []byte("short"), 0600))
+	assert.NotNil(t, (&KeyFile{}).Initialize(&plugin.ConfigEntry{Option: map[string]interface{}{"keyFile": keyFile}}))
+	assert.NotNil(t, (&KeyFile{}).Initialize(&plugin.ConfigEntry{}))
+}
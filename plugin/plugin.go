@@ -66,4 +66,6 @@ type Config struct {
 	Auth                 ConfigEntry `yaml:"auth"`
 	MeshResourceValidate ConfigEntry `yaml:"meshResourceValidate"`
 	DiscoverEvent        ConfigEntry `yaml:"discoverEvent"`
+	KMS                  ConfigEntry `yaml:"kms"`
+	EventBus             ConfigEntry `yaml:"eventBus"`
 }
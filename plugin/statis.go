@@ -21,6 +21,8 @@ import (
 	"os"
 	"sync"
 
+	"github.com/prometheus/client_golang/prometheus"
+
 	"github.com/polarismesh/polaris-server/common/log"
 )
 
@@ -42,6 +44,12 @@ type Statis interface {
 	AddRedisCall(api string, code int, duration int64) error
 }
 
+// MetricsRegistry 可选接口，统计插件对外提供 prometheus 指标时实现，其他模块的指标注册到该 registry 中
+type MetricsRegistry interface {
+	// GetRegistry 获取统计插件的 prometheus registry
+	GetRegistry() *prometheus.Registry
+}
+
 // GetStatis 获取统计插件
 func GetStatis() Statis {
 	c := &config.Statis
@@ -21,6 +21,8 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
+
 	"github.com/polarismesh/polaris-server/common/log"
 	"github.com/polarismesh/polaris-server/plugin"
 	"github.com/polarismesh/polaris-server/store"
@@ -105,6 +107,11 @@ func (s *StatisWorker) GetPrometheusHandler() http.Handler {
 	return s.acs.prometheusStatis.GetHttpHandler()
 }
 
+// GetRegistry 获取 prometheus registry，注册的指标通过 GetPrometheusHandler 对外提供
+func (s *StatisWorker) GetRegistry() *prometheus.Registry {
+	return s.acs.prometheusStatis.GetRegistry()
+}
+
 // Run 主流程
 func (s *StatisWorker) Run() {
 
@@ -190,6 +190,8 @@ type ClientServer interface {
 
 	// ServiceInstancesCache Used for client acquisition service instance information
 	ServiceInstancesCache(ctx context.Context, req *api.Service) *api.DiscoverResponse
+	// ServiceInstancesDeltaCache Used for client acquisition of instances changed since the revision it holds
+	ServiceInstancesDeltaCache(ctx context.Context, req *api.Service) *api.DiscoverResponse
 
 	// GetRoutingConfigWithCache User Client Get Service Routing Configuration Information
 	GetRoutingConfigWithCache(ctx context.Context, req *api.Service) *api.DiscoverResponse
@@ -199,6 +201,9 @@ type ClientServer interface {
 
 	// GetCircuitBreakerWithCache Fuse configuration information for obtaining services for clients
 	GetCircuitBreakerWithCache(ctx context.Context, req *api.Service) *api.DiscoverResponse
+
+	// NewInstanceSubscriber Create a subscriber that is notified when the instances of the subscribed services change
+	NewInstanceSubscriber() *InstanceSubscriber
 }
 
 // PlatformOperateServer Position of the platform
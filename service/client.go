@@ -188,6 +188,15 @@ func checkServiceMetadata(requestMeta map[string]string, service *model.Service,
 
 // ServiceInstancesCache 根据服务名查询服务实例列表
 func (s *Server) ServiceInstancesCache(ctx context.Context, req *api.Service) *api.DiscoverResponse {
+	return s.serviceInstancesCache(ctx, req, false)
+}
+
+// ServiceInstancesDeltaCache 根据客户端持有的版本号返回变更和删除的实例，版本号未知或者过旧时返回全量实例
+func (s *Server) ServiceInstancesDeltaCache(ctx context.Context, req *api.Service) *api.DiscoverResponse {
+	return s.serviceInstancesCache(ctx, req, true)
+}
+
+func (s *Server) serviceInstancesCache(ctx context.Context, req *api.Service, delta bool) *api.DiscoverResponse {
 	if req == nil {
 		return api.NewDiscoverInstanceResponse(api.EmptyRequest, req)
 	}
@@ -239,6 +248,9 @@ func (s *Server) ServiceInstancesCache(ctx context.Context, req *api.Service) *a
 	resp.Service.Revision.Value = revision
 	resp.Service.Namespace = req.GetNamespace()
 	resp.Service.Name = req.GetName() // 别名场景，response需要保持和request的服务名一致
+	if delta && s.fillInstancesDelta(req, service.ID, resp) {
+		return resp
+	}
 	// 填充instance数据
 	resp.Instances = make([]*api.Instance, 0) // TODO
 	_ = s.caches.Instance().
@@ -252,6 +264,28 @@ func (s *Server) ServiceInstancesCache(ctx context.Context, req *api.Service) *a
 	return resp
 }
 
+// fillInstancesDelta 填充客户端版本号之后的增量实例，无法计算增量时返回false
+func (s *Server) fillInstancesDelta(req *api.Service, serviceID string, resp *api.DiscoverResponse) bool {
+	changed, removed, ok := s.caches.Instance().GetInstanceChanges(serviceID, req.GetRevision().GetValue())
+	if !ok {
+		return false
+	}
+	resp.Delta = utils.NewBoolValue(true)
+	resp.Instances = make([]*api.Instance, 0, len(changed))
+	for _, instance := range changed {
+		resp.Instances = append(resp.Instances, s.getInstance(req, instance.Proto))
+	}
+	resp.RemovedInstances = make([]*api.Instance, 0, len(removed))
+	for _, instance := range removed {
+		resp.RemovedInstances = append(resp.RemovedInstances, &api.Instance{
+			Id:   utils.NewStringValue(instance.ID()),
+			Host: utils.NewStringValue(instance.Host()),
+			Port: utils.NewUInt32Value(instance.Port()),
+		})
+	}
+	return true
+}
+
 // GetRoutingConfigWithCache 获取缓存中的路由配置信息
 func (s *Server) GetRoutingConfigWithCache(ctx context.Context, req *api.Service) *api.DiscoverResponse {
 	if s.caches == nil {
@@ -77,6 +77,12 @@ func (svr *serverAuthAbility) ServiceInstancesCache(ctx context.Context, req *ap
 	return svr.targetServer.ServiceInstancesCache(ctx, req)
 }
 
+// ServiceInstancesDeltaCache is the interface for getting service instances changed since the revision
+func (svr *serverAuthAbility) ServiceInstancesDeltaCache(ctx context.Context,
+	req *api.Service) *api.DiscoverResponse {
+	return svr.targetServer.ServiceInstancesDeltaCache(ctx, req)
+}
+
 // GetRoutingConfigWithCache is the interface for getting routing config with cache
 func (svr *serverAuthAbility) GetRoutingConfigWithCache(ctx context.Context, req *api.Service) *api.DiscoverResponse {
 	return svr.targetServer.GetRoutingConfigWithCache(ctx, req)
@@ -91,3 +97,8 @@ func (svr *serverAuthAbility) GetRateLimitWithCache(ctx context.Context, req *ap
 func (svr *serverAuthAbility) GetCircuitBreakerWithCache(ctx context.Context, req *api.Service) *api.DiscoverResponse {
 	return svr.targetServer.GetCircuitBreakerWithCache(ctx, req)
 }
+
+// NewInstanceSubscriber is the interface for subscribing service instance changes
+func (svr *serverAuthAbility) NewInstanceSubscriber() *InstanceSubscriber {
+	return svr.targetServer.NewInstanceSubscriber()
+}
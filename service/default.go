@@ -140,6 +140,12 @@ func initialize(ctx context.Context, namingOpt *Config, cacheOpt *cache.Config,
 		}
 		log.Infof("[Naming][Server] cache is open, can access the client api function")
 		namingServer.caches = caches
+
+		// 客户端订阅的服务实例变更由实例缓存的更新事件驱动，需要在缓存启动之前注册
+		namingServer.instanceSubscribers = newInstanceSubscriberHub()
+		caches.AddListener(cache.CacheNameInstance, []cache.Listener{
+			&cache.WatchInstanceReload{Handler: namingServer.instanceSubscribers.onInstanceReload},
+		})
 	}
 
 	namingServer.bc = bc
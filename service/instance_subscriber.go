@@ -0,0 +1,211 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package service
+
+import (
+	"context"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"github.com/polarismesh/polaris-server/cache"
+	api "github.com/polarismesh/polaris-server/common/api/v1"
+	"github.com/polarismesh/polaris-server/common/utils"
+)
+
+// InstanceSubscriber 客户端在 Discover 流上订阅的服务，服务实例变化时通过 Notify 通知客户端拉取变更
+type InstanceSubscriber struct {
+	id       string
+	server   *Server
+	mutex    sync.Mutex
+	services map[string]*subscribedService
+	changed  map[string]bool
+	notifyCh chan struct{}
+}
+
+// subscribedService 订阅的服务以及已经发送给客户端的版本号
+type subscribedService struct {
+	namespace string
+	name      string
+	revision  string
+}
+
+// instanceSubscriberHub 维护服务 ID 到订阅者的关系，由实例缓存的更新事件驱动
+type instanceSubscriberHub struct {
+	mutex       sync.RWMutex
+	subscribers map[string]map[string]*InstanceSubscriber
+}
+
+func newInstanceSubscriberHub() *instanceSubscriberHub {
+	return &instanceSubscriberHub{subscribers: make(map[string]map[string]*InstanceSubscriber)}
+}
+
+// onInstanceReload 实例缓存更新后回调，参数为实例发生变化的服务 ID
+func (h *instanceSubscriberHub) onInstanceReload(value interface{}) {
+	serviceIDs, ok := value.(map[string]bool)
+	if !ok {
+		return
+	}
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+	for serviceID := range serviceIDs {
+		for _, subscriber := range h.subscribers[serviceID] {
+			subscriber.markChanged(serviceID)
+		}
+	}
+}
+
+func (h *instanceSubscriberHub) add(serviceID string, subscriber *InstanceSubscriber) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	subscribers, ok := h.subscribers[serviceID]
+	if !ok {
+		subscribers = make(map[string]*InstanceSubscriber)
+		h.subscribers[serviceID] = subscribers
+	}
+	subscribers[subscriber.id] = subscriber
+}
+
+func (h *instanceSubscriberHub) remove(serviceID string, subscriber *InstanceSubscriber) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	subscribers, ok := h.subscribers[serviceID]
+	if !ok {
+		return
+	}
+	delete(subscribers, subscriber.id)
+	if len(subscribers) == 0 {
+		delete(h.subscribers, serviceID)
+	}
+}
+
+// NewInstanceSubscriber 创建服务实例变更的订阅者，未开启缓存时返回 nil
+func (s *Server) NewInstanceSubscriber() *InstanceSubscriber {
+	if s.caches == nil || s.instanceSubscribers == nil {
+		return nil
+	}
+	return &InstanceSubscriber{
+		id:       utils.NewUUID(),
+		server:   s,
+		services: make(map[string]*subscribedService),
+		changed:  make(map[string]bool),
+		notifyCh: make(chan struct{}, 1),
+	}
+}
+
+// Subscribe 订阅服务的实例变更，revision 为已经发送给客户端的版本号，重复订阅时更新版本号
+func (sub *InstanceSubscriber) Subscribe(req *api.Service, revision string) {
+	// 别名服务订阅源服务的变更，推送时仍然使用客户端请求的服务名
+	service := sub.server.getServiceCache(req.GetName().GetValue(), req.GetNamespace().GetValue())
+	if service == nil {
+		return
+	}
+
+	sub.mutex.Lock()
+	_, existed := sub.services[service.ID]
+	sub.services[service.ID] = &subscribedService{
+		namespace: req.GetNamespace().GetValue(),
+		name:      req.GetName().GetValue(),
+		revision:  revision,
+	}
+	sub.mutex.Unlock()
+
+	if !existed {
+		sub.server.instanceSubscribers.add(service.ID, sub)
+	}
+}
+
+// Notify 订阅的服务实例发生变化时收到通知
+func (sub *InstanceSubscriber) Notify() <-chan struct{} {
+	return sub.notifyCh
+}
+
+// Changes 返回版本号和客户端不一致的服务实例，实例变化后又恢复原状的服务不推送
+func (sub *InstanceSubscriber) Changes(ctx context.Context) []*api.DiscoverResponse {
+	sub.mutex.Lock()
+	changed := sub.changed
+	sub.changed = make(map[string]bool)
+	sub.mutex.Unlock()
+
+	var responses []*api.DiscoverResponse
+	for serviceID := range changed {
+		sub.mutex.Lock()
+		subscribed, ok := sub.services[serviceID]
+		sub.mutex.Unlock()
+		if !ok {
+			continue
+		}
+
+		service := sub.server.caches.Service().GetServiceByID(serviceID)
+		if service == nil {
+			continue
+		}
+		// 缓存中的版本号由异步任务计算，这里直接计算实例当前的版本号
+		instances := sub.server.caches.Instance().GetInstancesByServiceID(serviceID)
+		revision, err := cache.ComputeRevision(service.Revision, instances)
+		if err != nil {
+			log.Error("[Server][Instance] compute subscribed service revision error",
+				utils.ZapRequestIDByCtx(ctx), zap.String("service-id", serviceID), zap.Error(err))
+			continue
+		}
+		if revision == subscribed.revision {
+			continue
+		}
+
+		resp := sub.server.ServiceInstancesCache(ctx, &api.Service{
+			Namespace: utils.NewStringValue(subscribed.namespace),
+			Name:      utils.NewStringValue(subscribed.name),
+		})
+		if resp.GetCode().GetValue() != api.ExecuteSuccess {
+			continue
+		}
+		resp.Service.Revision = utils.NewStringValue(revision)
+		responses = append(responses, resp)
+
+		sub.mutex.Lock()
+		subscribed.revision = revision
+		sub.mutex.Unlock()
+	}
+	return responses
+}
+
+// Close 取消全部的订阅
+func (sub *InstanceSubscriber) Close() {
+	sub.mutex.Lock()
+	serviceIDs := make([]string, 0, len(sub.services))
+	for serviceID := range sub.services {
+		serviceIDs = append(serviceIDs, serviceID)
+	}
+	sub.services = make(map[string]*subscribedService)
+	sub.mutex.Unlock()
+
+	for _, serviceID := range serviceIDs {
+		sub.server.instanceSubscribers.remove(serviceID, sub)
+	}
+}
+
+func (sub *InstanceSubscriber) markChanged(serviceID string) {
+	sub.mutex.Lock()
+	sub.changed[serviceID] = true
+	sub.mutex.Unlock()
+
+	select {
+	case sub.notifyCh <- struct{}{}:
+	default:
+	}
+}
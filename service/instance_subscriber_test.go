@@ -0,0 +1,76 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package service
+
+import (
+	"testing"
+)
+
+// Test_instanceSubscriberHub checks that cache reload events only notify subscribers of the affected services.
+func Test_instanceSubscriberHub(t *testing.T) {
+	hub := newInstanceSubscriberHub()
+	s := &Server{instanceSubscribers: hub}
+	newSubscriber := func(id string) *InstanceSubscriber {
+		return &InstanceSubscriber{
+			id:       id,
+			server:   s,
+			services: make(map[string]*subscribedService),
+			changed:  make(map[string]bool),
+			notifyCh: make(chan struct{}, 1),
+		}
+	}
+	sub1 := newSubscriber("sub-1")
+	sub2 := newSubscriber("sub-2")
+	sub1.services["svc-1"] = &subscribedService{}
+	hub.add("svc-1", sub1)
+	sub2.services["svc-2"] = &subscribedService{}
+	hub.add("svc-2", sub2)
+
+	hub.onInstanceReload(map[string]bool{"svc-1": true, "svc-3": true})
+	select {
+	case <-sub1.Notify():
+	default:
+		t.Fatal("subscriber of the changed service should be notified")
+	}
+	select {
+	case <-sub2.Notify():
+		t.Fatal("subscriber of the unchanged service should not be notified")
+	default:
+	}
+	if !sub1.changed["svc-1"] || len(sub1.changed) != 1 {
+		t.Fatalf("unexpected changed services: %v", sub1.changed)
+	}
+
+	// 多次变更只保留一个通知
+	hub.onInstanceReload(map[string]bool{"svc-2": true})
+	hub.onInstanceReload(map[string]bool{"svc-2": true})
+	<-sub2.Notify()
+	select {
+	case <-sub2.Notify():
+		t.Fatal("notifications should be merged")
+	default:
+	}
+
+	sub1.Close()
+	sub2.Close()
+	if len(hub.subscribers) != 0 {
+		t.Fatalf("subscribers should be removed after close: %v", hub.subscribers)
+	}
+	// 忽略无法识别的事件
+	hub.onInstanceReload("unknown")
+}
@@ -21,6 +21,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	api "github.com/polarismesh/polaris-server/common/api/v1"
@@ -287,10 +288,53 @@ func checkRoutingConfig(req *api.Routing) *api.Response {
 	if err := CheckDbStrFieldLen(req.GetServiceToken(), MaxDbServiceToken); err != nil {
 		return api.NewRoutingResponse(api.InvalidServiceToken, req)
 	}
+	if err := checkRoutingMatchStrings(req.GetInbounds()); err != nil {
+		return api.NewRoutingResponseWithMsg(api.InvalidParameter, req, err.Error())
+	}
+	if err := checkRoutingMatchStrings(req.GetOutbounds()); err != nil {
+		return api.NewRoutingResponseWithMsg(api.InvalidParameter, req, err.Error())
+	}
+
+	return nil
+}
 
+// checkRoutingMatchStrings 检查路由规则中的匹配方式
+// NOT_EQUALS 和 IN 只有 xDS 会下发给 envoy，SDK 不识别，因此只允许用于来源的文本请求标签，
+// 目标实例标签只能使用 SDK 支持的匹配方式
+func checkRoutingMatchStrings(routes []*api.Route) error {
+	for _, route := range routes {
+		for _, source := range route.GetSources() {
+			for key, matchString := range source.GetMetadata() {
+				if !isXdsOnlyMatchType(matchString.GetType()) {
+					continue
+				}
+				if matchString.GetValueType() != api.MatchString_TEXT {
+					return fmt.Errorf("source label %s: match type %s only supports text value",
+						key, matchString.GetType())
+				}
+				if matchString.GetType() == api.MatchString_IN && strings.TrimSpace(
+					strings.Replace(matchString.GetValue().GetValue(), ",", "", -1)) == "" {
+					return fmt.Errorf("source label %s: match type IN requires at least one value", key)
+				}
+			}
+		}
+		for _, destination := range route.GetDestinations() {
+			for key, matchString := range destination.GetMetadata() {
+				if isXdsOnlyMatchType(matchString.GetType()) {
+					return fmt.Errorf("destination label %s: match type %s is not supported",
+						key, matchString.GetType())
+				}
+			}
+		}
+	}
 	return nil
 }
 
+// isXdsOnlyMatchType 是否为只有 xDS 支持的匹配方式
+func isXdsOnlyMatchType(matchType api.MatchString_MatchStringType) bool {
+	return matchType == api.MatchString_NOT_EQUALS || matchType == api.MatchString_IN
+}
+
 // parseServiceRoutingToken 从routingConfig请求参数中获取token
 func parseServiceRoutingToken(ctx context.Context, req *api.Routing) string {
 	if reqToken := req.GetServiceToken().GetValue(); reqToken != "" {
@@ -0,0 +1,72 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package service
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/wrappers"
+
+	api "github.com/polarismesh/polaris-server/common/api/v1"
+)
+
+func TestCheckRoutingMatchStrings(t *testing.T) {
+	matchString := func(matchType api.MatchString_MatchStringType, value string) *api.MatchString {
+		return &api.MatchString{Type: matchType, Value: &wrappers.StringValue{Value: value}}
+	}
+	newRouting := func(source, destination map[string]*api.MatchString) *api.Routing {
+		return &api.Routing{
+			Service:   &wrappers.StringValue{Value: "svc"},
+			Namespace: &wrappers.StringValue{Value: "ns"},
+			Inbounds: []*api.Route{{
+				Sources:      []*api.Source{{Metadata: source}},
+				Destinations: []*api.Destination{{Metadata: destination}},
+			}},
+		}
+	}
+	exact := map[string]*api.MatchString{"env": matchString(api.MatchString_EXACT, "prod")}
+
+	cases := []struct {
+		name    string
+		routing *api.Routing
+		code    uint32
+	}{
+		{"来源标签使用不等于", newRouting(map[string]*api.MatchString{
+			"user": matchString(api.MatchString_NOT_EQUALS, "guest")}, exact), api.ExecuteSuccess},
+		{"来源标签使用包含", newRouting(map[string]*api.MatchString{
+			"$method": matchString(api.MatchString_IN, "GET, POST")}, exact), api.ExecuteSuccess},
+		{"包含没有任何值", newRouting(map[string]*api.MatchString{
+			"$method": matchString(api.MatchString_IN, " , ")}, exact), api.InvalidParameter},
+		{"不等于使用参数值", newRouting(map[string]*api.MatchString{"user": {
+			Type:      api.MatchString_NOT_EQUALS,
+			Value:     &wrappers.StringValue{Value: "uid"},
+			ValueType: api.MatchString_PARAMETER,
+		}}, exact), api.InvalidParameter},
+		{"目标标签使用包含", newRouting(exact, map[string]*api.MatchString{
+			"env": matchString(api.MatchString_IN, "prod,test")}), api.InvalidParameter},
+	}
+	for _, c := range cases {
+		code := api.ExecuteSuccess
+		if resp := checkRoutingConfig(c.routing); resp != nil {
+			code = resp.GetCode().GetValue()
+		}
+		if code != c.code {
+			t.Fatalf("%s: expect code %d, got %d", c.name, c.code, code)
+		}
+	}
+}
@@ -57,6 +57,8 @@ type Server struct {
 	createNamespaceSingle *singleflight.Group
 
 	hooks []ResourceHook
+
+	instanceSubscribers *instanceSubscriberHub
 }
 
 // HealthServer 健康检查Server
@@ -22,7 +22,9 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"sync"
@@ -70,6 +72,16 @@ func loadConfig() error {
 		return err
 	}
 
+	// 单机存储的数据文件放在临时目录中，避免测试产生的文件留在源码目录
+	if _, ok := cfg.Store.Option["path"]; ok {
+		dir, err := ioutil.TempDir("", "polaris-service-test")
+		if err != nil {
+			fmt.Printf("[ERROR] %v\n", err)
+			return err
+		}
+		cfg.Store.Option["path"] = filepath.Join(dir, "polaris.bolt")
+	}
+
 	return err
 }
 
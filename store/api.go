@@ -113,3 +113,19 @@ type ToolStore interface {
 	// GetNow Get the current time
 	GetNow() (int64, error)
 }
+
+// ClusterEventStore 集群事件的存储接口，存储插件可选实现
+// 各个节点按照序号拉取其他节点写入的事件，不依赖节点之间的时钟
+type ClusterEventStore interface {
+	// AppendClusterEvent 追加一条事件，序号由存储层分配
+	AppendClusterEvent(topic, payload string) error
+
+	// GetLatestClusterEventSeq 获取当前最大的事件序号
+	GetLatestClusterEventSeq() (uint64, error)
+
+	// GetClusterEvents 获取序号大于seq的事件，按照序号升序返回，最多limit条
+	GetClusterEvents(seq uint64, limit uint32) ([]*model.ClusterEvent, error)
+
+	// CleanClusterEvents 清理创建时间早于ctime的事件，保留最新的一条，避免清理后序号回退
+	CleanClusterEvents(ctime time.Time) error
+}
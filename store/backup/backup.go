@@ -0,0 +1,523 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package backup
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"time"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+
+	api "github.com/polarismesh/polaris-server/common/api/v1"
+	"github.com/polarismesh/polaris-server/common/model"
+	commontime "github.com/polarismesh/polaris-server/common/time"
+	"github.com/polarismesh/polaris-server/store"
+	"github.com/polarismesh/polaris-server/store/migrate"
+)
+
+const (
+	// ArchiveVersion 备份文件的格式版本
+	ArchiveVersion = "v1"
+
+	manifestFile                 = "manifest.json"
+	namespacesFile               = "namespaces.json"
+	servicesFile                 = "services.json"
+	aliasesFile                  = "aliases.json"
+	instancesFile                = "instances.json"
+	routingsFile                 = "routings.json"
+	rateLimitsFile               = "ratelimits.json"
+	circuitBreakersFile          = "circuitbreakers.json"
+	circuitBreakerReleasesFile   = "circuitbreaker_releases.json"
+	configFileGroupsFile         = "config_file_groups.json"
+	configFilesFile              = "config_files.json"
+	configFileReleasesFile       = "config_file_releases.json"
+	configFileReleaseHistoryFile = "config_file_release_histories.json"
+	usersFile                    = "users.json"
+	groupsFile                   = "groups.json"
+	strategiesFile               = "strategies.json"
+)
+
+// Manifest 备份文件的描述信息
+type Manifest struct {
+	Version    string         `json:"version"`
+	CreateTime string         `json:"create_time"`
+	Namespaces []string       `json:"namespaces,omitempty"`
+	Counts     map[string]int `json:"counts"`
+}
+
+// Export 将存储中的数据导出为 tar.gz 格式的备份文件，namespaces 不为空时只导出指定命名空间下的资源
+// 备份文件中每类资源一个json文件，内容为 common/api/v1 中对应结构的数组，与存储插件无关
+func Export(s store.Store, w io.Writer, namespaces []string) (*Manifest, error) {
+	data, err := migrate.LoadDataset(s)
+	if err != nil {
+		return nil, err
+	}
+	data = data.Filter(namespaces)
+
+	files, err := encodeDataset(data)
+	if err != nil {
+		return nil, err
+	}
+	manifest := &Manifest{
+		Version:    ArchiveVersion,
+		CreateTime: commontime.Time2String(time.Now()),
+		Namespaces: namespaces,
+		Counts:     make(map[string]int, len(files)),
+	}
+	for _, f := range files {
+		manifest.Counts[f.name] = len(f.messages)
+	}
+
+	gw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gw)
+	content, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := writeFile(tw, manifestFile, content); err != nil {
+		return nil, err
+	}
+	for _, f := range files {
+		content, err := marshalMessages(f.messages)
+		if err != nil {
+			return nil, fmt.Errorf("marshal %s: %v", f.name, err)
+		}
+		if err := writeFile(tw, f.name, content); err != nil {
+			return nil, err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	if err := gw.Close(); err != nil {
+		return nil, err
+	}
+	log.Infof("[Store][Backup] export archive with namespaces(%v), counts: %v", namespaces, manifest.Counts)
+	return manifest, nil
+}
+
+// Restore 将备份文件中的数据还原到存储中，namespaces 不为空时只还原指定命名空间下的资源
+// 存储中已经存在的数据会被跳过，dryRun 为 true 时只统计不写入
+func Restore(s store.Store, r io.Reader, namespaces []string, dryRun bool) (*migrate.Report, error) {
+	data, manifest, err := ReadArchive(r)
+	if err != nil {
+		return nil, err
+	}
+	log.Infof("[Store][Backup] restore archive created at %s, namespaces(%v)", manifest.CreateTime, namespaces)
+	return migrate.NewMigrator(s, dryRun).Import(data.Filter(namespaces))
+}
+
+// ReadArchive 读取备份文件
+func ReadArchive(r io.Reader) (*migrate.Dataset, *Manifest, error) {
+	gr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid backup archive: %v", err)
+	}
+	defer gr.Close()
+
+	files := make(map[string][]byte)
+	tr := tar.NewReader(gr)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid backup archive: %v", err)
+		}
+		content, err := ioutil.ReadAll(tr)
+		if err != nil {
+			return nil, nil, err
+		}
+		files[hdr.Name] = content
+	}
+
+	content, ok := files[manifestFile]
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid backup archive: %s not found", manifestFile)
+	}
+	manifest := &Manifest{}
+	if err := json.Unmarshal(content, manifest); err != nil {
+		return nil, nil, fmt.Errorf("invalid backup archive: %v", err)
+	}
+	if manifest.Version != ArchiveVersion {
+		return nil, nil, fmt.Errorf("unsupported backup archive version %s", manifest.Version)
+	}
+
+	data, err := decodeDataset(files)
+	if err != nil {
+		return nil, nil, err
+	}
+	return data, manifest, nil
+}
+
+// archiveFile 备份文件中的一个资源文件
+type archiveFile struct {
+	name     string
+	messages []proto.Message
+}
+
+// encodeDataset 将数据集转换为API结构
+func encodeDataset(data *migrate.Dataset) ([]*archiveFile, error) {
+	services := make(map[string]*model.Service, len(data.Services))
+	for _, svc := range data.Services {
+		services[svc.ID] = svc
+	}
+	tags := make(map[string][]*model.ConfigFileTag)
+	for _, tag := range data.ConfigFileTags {
+		key := fileKey(tag.Namespace, tag.Group, tag.FileName)
+		tags[key] = append(tags[key], tag)
+	}
+
+	files := make([]*archiveFile, 0, 15)
+	add := func(name string) *archiveFile {
+		f := &archiveFile{name: name, messages: make([]proto.Message, 0)}
+		files = append(files, f)
+		return f
+	}
+
+	f := add(namespacesFile)
+	for _, ns := range data.Namespaces {
+		f.messages = append(f.messages, namespace2API(ns))
+	}
+	f = add(servicesFile)
+	for _, svc := range data.Services {
+		f.messages = append(f.messages, service2API(svc))
+	}
+	f = add(aliasesFile)
+	for _, alias := range data.Aliases {
+		f.messages = append(f.messages, alias2API(alias, services[alias.Reference]))
+	}
+	f = add(instancesFile)
+	for _, ins := range data.Instances {
+		f.messages = append(f.messages, instance2API(ins, services[ins.ServiceID]))
+	}
+	f = add(routingsFile)
+	for _, routing := range data.Routings {
+		msg, err := routing2API(routing, services[routing.ID])
+		if err != nil {
+			return nil, fmt.Errorf("routing(%s): %v", routing.ID, err)
+		}
+		f.messages = append(f.messages, msg)
+	}
+	f = add(rateLimitsFile)
+	for _, limit := range data.RateLimits {
+		msg, err := rateLimit2API(limit, services[limit.ServiceID])
+		if err != nil {
+			return nil, fmt.Errorf("ratelimit(%s): %v", limit.ID, err)
+		}
+		f.messages = append(f.messages, msg)
+	}
+	f = add(circuitBreakersFile)
+	for _, rule := range data.CircuitBreakers {
+		msg, err := circuitBreaker2API(rule)
+		if err != nil {
+			return nil, fmt.Errorf("circuitbreaker(%s, %s): %v", rule.ID, rule.Version, err)
+		}
+		f.messages = append(f.messages, msg)
+	}
+	f = add(circuitBreakerReleasesFile)
+	for _, relation := range data.CircuitBreakerRelations {
+		f.messages = append(f.messages, circuitBreakerRelation2API(relation, services[relation.ServiceID]))
+	}
+	f = add(configFileGroupsFile)
+	for _, group := range data.ConfigFileGroups {
+		f.messages = append(f.messages, configFileGroup2API(group))
+	}
+	f = add(configFilesFile)
+	for _, file := range data.ConfigFiles {
+		f.messages = append(f.messages, configFile2API(file, tags[fileKey(file.Namespace, file.Group, file.Name)]))
+	}
+	// 已删除的发布在API结构中无法表示，不做备份
+	f = add(configFileReleasesFile)
+	for _, release := range data.ConfigFileReleases {
+		if release.Flag == 0 {
+			f.messages = append(f.messages, configFileRelease2API(release))
+		}
+	}
+	f = add(configFileReleaseHistoryFile)
+	for _, history := range data.ConfigFileReleaseHistories {
+		f.messages = append(f.messages, configFileReleaseHistory2API(history))
+	}
+	f = add(usersFile)
+	for _, user := range data.Users {
+		f.messages = append(f.messages, user2API(user))
+	}
+	f = add(groupsFile)
+	for _, group := range data.Groups {
+		f.messages = append(f.messages, group2API(group))
+	}
+	f = add(strategiesFile)
+	for _, strategy := range data.Strategies {
+		f.messages = append(f.messages, strategy2API(strategy))
+	}
+	return files, nil
+}
+
+// decodeDataset 将备份文件中的API结构转换为数据集
+func decodeDataset(files map[string][]byte) (*migrate.Dataset, error) {
+	data := &migrate.Dataset{}
+	// 备份文件中通过服务名和命名空间引用服务
+	services := make(map[string]string)
+	serviceID := func(namespace, name string) (string, error) {
+		id, ok := services[fileKey(namespace, name, "")]
+		if !ok {
+			return "", fmt.Errorf("service(%s, %s) not found in backup archive", namespace, name)
+		}
+		return id, nil
+	}
+
+	err := readMessages(files, namespacesFile, func() proto.Message { return &api.Namespace{} },
+		func(msg proto.Message) error {
+			data.Namespaces = append(data.Namespaces, api2Namespace(msg.(*api.Namespace)))
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+	err = readMessages(files, servicesFile, func() proto.Message { return &api.Service{} },
+		func(msg proto.Message) error {
+			svc := api2Service(msg.(*api.Service))
+			services[fileKey(svc.Namespace, svc.Name, "")] = svc.ID
+			data.Services = append(data.Services, svc)
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+	err = readMessages(files, aliasesFile, func() proto.Message { return &api.ServiceAlias{} },
+		func(msg proto.Message) error {
+			req := msg.(*api.ServiceAlias)
+			id, err := serviceID(req.GetNamespace().GetValue(), req.GetService().GetValue())
+			if err != nil {
+				return err
+			}
+			data.Aliases = append(data.Aliases, api2Alias(req, id))
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+	err = readMessages(files, instancesFile, func() proto.Message { return &api.Instance{} },
+		func(msg proto.Message) error {
+			req := msg.(*api.Instance)
+			id, err := serviceID(req.GetNamespace().GetValue(), req.GetService().GetValue())
+			if err != nil {
+				return err
+			}
+			data.Instances = append(data.Instances, api2Instance(req, id))
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+	err = readMessages(files, routingsFile, func() proto.Message { return &api.Routing{} },
+		func(msg proto.Message) error {
+			req := msg.(*api.Routing)
+			id, err := serviceID(req.GetNamespace().GetValue(), req.GetService().GetValue())
+			if err != nil {
+				return err
+			}
+			routing, err := api2Routing(req, id)
+			if err != nil {
+				return err
+			}
+			data.Routings = append(data.Routings, routing)
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+	err = readMessages(files, rateLimitsFile, func() proto.Message { return &api.Rule{} },
+		func(msg proto.Message) error {
+			req := msg.(*api.Rule)
+			id, err := serviceID(req.GetNamespace().GetValue(), req.GetService().GetValue())
+			if err != nil {
+				return err
+			}
+			limit, err := api2RateLimit(req, id)
+			if err != nil {
+				return err
+			}
+			data.RateLimits = append(data.RateLimits, limit)
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+	err = readMessages(files, circuitBreakersFile, func() proto.Message { return &api.CircuitBreaker{} },
+		func(msg proto.Message) error {
+			rule, err := api2CircuitBreaker(msg.(*api.CircuitBreaker))
+			if err != nil {
+				return err
+			}
+			data.CircuitBreakers = append(data.CircuitBreakers, rule)
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+	err = readMessages(files, circuitBreakerReleasesFile, func() proto.Message { return &api.ConfigRelease{} },
+		func(msg proto.Message) error {
+			req := msg.(*api.ConfigRelease)
+			id, err := serviceID(req.GetService().GetNamespace().GetValue(), req.GetService().GetName().GetValue())
+			if err != nil {
+				return err
+			}
+			data.CircuitBreakerRelations = append(data.CircuitBreakerRelations, api2CircuitBreakerRelation(req, id))
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+	err = readMessages(files, configFileGroupsFile, func() proto.Message { return &api.ConfigFileGroup{} },
+		func(msg proto.Message) error {
+			data.ConfigFileGroups = append(data.ConfigFileGroups, api2ConfigFileGroup(msg.(*api.ConfigFileGroup)))
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+	err = readMessages(files, configFilesFile, func() proto.Message { return &api.ConfigFile{} },
+		func(msg proto.Message) error {
+			file, tags := api2ConfigFile(msg.(*api.ConfigFile))
+			data.ConfigFiles = append(data.ConfigFiles, file)
+			data.ConfigFileTags = append(data.ConfigFileTags, tags...)
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+	err = readMessages(files, configFileReleasesFile, func() proto.Message { return &api.ConfigFileRelease{} },
+		func(msg proto.Message) error {
+			data.ConfigFileReleases = append(data.ConfigFileReleases,
+				api2ConfigFileRelease(msg.(*api.ConfigFileRelease)))
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+	err = readMessages(files, configFileReleaseHistoryFile,
+		func() proto.Message { return &api.ConfigFileReleaseHistory{} },
+		func(msg proto.Message) error {
+			data.ConfigFileReleaseHistories = append(data.ConfigFileReleaseHistories,
+				api2ConfigFileReleaseHistory(msg.(*api.ConfigFileReleaseHistory)))
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+	err = readMessages(files, usersFile, func() proto.Message { return &api.User{} },
+		func(msg proto.Message) error {
+			user, err := api2User(msg.(*api.User))
+			if err != nil {
+				return err
+			}
+			data.Users = append(data.Users, user)
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+	err = readMessages(files, groupsFile, func() proto.Message { return &api.UserGroup{} },
+		func(msg proto.Message) error {
+			data.Groups = append(data.Groups, api2Group(msg.(*api.UserGroup)))
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+	err = readMessages(files, strategiesFile, func() proto.Message { return &api.AuthStrategy{} },
+		func(msg proto.Message) error {
+			data.Strategies = append(data.Strategies, api2Strategy(msg.(*api.AuthStrategy)))
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// marshalMessages 将API结构序列化为json数组
+func marshalMessages(messages []proto.Message) ([]byte, error) {
+	marshaler := &jsonpb.Marshaler{OrigName: true}
+	buf := &bytes.Buffer{}
+	buf.WriteString("[")
+	for i, msg := range messages {
+		if i > 0 {
+			buf.WriteString(",")
+		}
+		buf.WriteString("\n")
+		if err := marshaler.Marshal(buf, msg); err != nil {
+			return nil, err
+		}
+	}
+	buf.WriteString("\n]\n")
+	return buf.Bytes(), nil
+}
+
+// readMessages 解析备份文件中的json数组，文件不存在时视为没有数据
+func readMessages(files map[string][]byte, name string, newMessage func() proto.Message,
+	handle func(msg proto.Message) error) error {
+	content, ok := files[name]
+	if !ok {
+		return nil
+	}
+	var items []json.RawMessage
+	if err := json.Unmarshal(content, &items); err != nil {
+		return fmt.Errorf("invalid %s in backup archive: %v", name, err)
+	}
+	unmarshaler := &jsonpb.Unmarshaler{AllowUnknownFields: true}
+	for _, item := range items {
+		msg := newMessage()
+		if err := unmarshaler.Unmarshal(bytes.NewReader(item), msg); err != nil {
+			return fmt.Errorf("invalid %s in backup archive: %v", name, err)
+		}
+		if err := handle(msg); err != nil {
+			return fmt.Errorf("invalid %s in backup archive: %v", name, err)
+		}
+	}
+	return nil
+}
+
+// writeFile 向 tar 中写入一个文件
+func writeFile(tw *tar.Writer, name string, content []byte) error {
+	hdr := &tar.Header{
+		Name:    name,
+		Mode:    0644,
+		Size:    int64(len(content)),
+		ModTime: time.Now(),
+	}
+	if err := tw.WriteHeader(hdr); err != nil {
+		return err
+	}
+	_, err := tw.Write(content)
+	return err
+}
+
+// fileKey 配置文件、服务等资源的唯一标识
+func fileKey(namespace, group, name string) string {
+	return fmt.Sprintf("%s@@%s@@%s", namespace, group, name)
+}
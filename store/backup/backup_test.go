@@ -0,0 +1,158 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package backup
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/wrappers"
+	. "github.com/smartystreets/goconvey/convey"
+
+	api "github.com/polarismesh/polaris-server/common/api/v1"
+	"github.com/polarismesh/polaris-server/common/model"
+	"github.com/polarismesh/polaris-server/store"
+	_ "github.com/polarismesh/polaris-server/store/boltdb"
+	"github.com/polarismesh/polaris-server/store/sqldb"
+)
+
+// newTestStore 在临时目录中创建并初始化存储
+func newTestStore(t *testing.T, name, path string) store.Store {
+	s, err := store.NewStore(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Initialize(&store.Config{Name: name, Option: map[string]interface{}{"path": path}}); err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+// prepareData 在两个命名空间下写入服务、实例和配置文件
+func prepareData(t *testing.T, s store.Store) {
+	Convey("准备备份数据", t, func() {
+		for _, ns := range []string{"ns", "other"} {
+			So(s.AddNamespace(&model.Namespace{Name: ns, Token: "t", Owner: "polaris", Valid: true}), ShouldBeNil)
+			So(s.AddService(&model.Service{ID: ns + "-svc", Name: "svc", Namespace: ns, Token: "t",
+				Owner: "polaris", Revision: "r1", Meta: map[string]string{"k": "v"}, Valid: true}), ShouldBeNil)
+			So(s.BatchAddInstances([]*model.Instance{{
+				ServiceID: ns + "-svc",
+				Proto: &api.Instance{
+					Id:       &wrappers.StringValue{Value: ns + "-ins"},
+					Host:     &wrappers.StringValue{Value: "127.0.0.1"},
+					Port:     &wrappers.UInt32Value{Value: 8080},
+					Revision: &wrappers.StringValue{Value: "r1"},
+					Healthy:  &wrappers.BoolValue{Value: true},
+					Metadata: map[string]string{"k": "v"},
+				},
+				Valid: true,
+			}}), ShouldBeNil)
+
+			_, err := s.CreateConfigFileGroup(&model.ConfigFileGroup{Name: "group", Namespace: ns,
+				CreateBy: "polaris", ModifyBy: "polaris"})
+			So(err, ShouldBeNil)
+			_, err = s.CreateConfigFile(nil, &model.ConfigFile{Name: "app.yaml", Namespace: ns, Group: "group",
+				Content: "a: 1", Format: "yaml", CreateBy: "polaris", ModifyBy: "polaris"})
+			So(err, ShouldBeNil)
+			So(s.CreateConfigFileTag(nil, &model.ConfigFileTag{Key: "env", Value: "test", Namespace: ns,
+				Group: "group", FileName: "app.yaml", CreateBy: "polaris", ModifyBy: "polaris"}), ShouldBeNil)
+		}
+	})
+}
+
+// TestBackupRestore 从 boltdb 导出备份并还原到 sqlite
+func TestBackupRestore(t *testing.T) {
+	if !sqldb.SQLiteEnabled {
+		t.Skip("sqlite store requires cgo")
+	}
+	dir, err := ioutil.TempDir("", "polaris-backup")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	source := newTestStore(t, "boltdbStore", filepath.Join(dir, "polaris.bolt"))
+	defer source.Destroy()
+	prepareData(t, source)
+
+	archive := &bytes.Buffer{}
+	Convey("导出全部数据", t, func() {
+		manifest, err := Export(source, archive, nil)
+		So(err, ShouldBeNil)
+		So(manifest.Version, ShouldEqual, ArchiveVersion)
+		So(manifest.Counts[instancesFile], ShouldEqual, 2)
+		So(manifest.Counts[configFilesFile], ShouldEqual, 2)
+
+		data, _, err := ReadArchive(bytes.NewReader(archive.Bytes()))
+		So(err, ShouldBeNil)
+		So(len(data.Instances), ShouldEqual, 2)
+		So(len(data.ConfigFileTags), ShouldEqual, 2)
+	})
+
+	Convey("只导出指定命名空间", t, func() {
+		manifest, err := Export(source, &bytes.Buffer{}, []string{"other"})
+		So(err, ShouldBeNil)
+		So(manifest.Counts[namespacesFile], ShouldEqual, 1)
+		So(manifest.Counts[servicesFile], ShouldEqual, 1)
+		So(manifest.Counts[configFilesFile], ShouldEqual, 1)
+	})
+
+	Convey("dry-run 不写入存储", t, func() {
+		target := newTestStore(t, "sqliteStore", filepath.Join(dir, "dry-run.db"))
+		defer target.Destroy()
+
+		report, err := Restore(target, bytes.NewReader(archive.Bytes()), nil, true)
+		So(err, ShouldBeNil)
+		So(report.DryRun, ShouldBeTrue)
+
+		ins, err := target.GetInstance("ns-ins")
+		So(err, ShouldBeNil)
+		So(ins, ShouldBeNil)
+	})
+
+	Convey("只还原指定命名空间", t, func() {
+		target := newTestStore(t, "sqliteStore", filepath.Join(dir, "polaris.db"))
+		defer target.Destroy()
+
+		report, err := Restore(target, bytes.NewReader(archive.Bytes()), []string{"ns"}, false)
+		So(err, ShouldBeNil)
+		So(report.Verified(), ShouldBeTrue)
+
+		svc, err := target.GetService("svc", "ns")
+		So(err, ShouldBeNil)
+		So(svc.Meta["k"], ShouldEqual, "v")
+		ins, err := target.GetInstance("ns-ins")
+		So(err, ShouldBeNil)
+		So(ins.Metadata()["k"], ShouldEqual, "v")
+		file, err := target.GetConfigFile(nil, "ns", "group", "app.yaml")
+		So(err, ShouldBeNil)
+		So(file.Content, ShouldEqual, "a: 1")
+
+		other, err := target.GetService("svc", "other")
+		So(err, ShouldBeNil)
+		So(other, ShouldBeNil)
+	})
+
+	Convey("拒绝无效的备份文件", t, func() {
+		_, err := Restore(source, bytes.NewReader([]byte("not an archive")), nil, false)
+		So(err, ShouldNotBeNil)
+	})
+}
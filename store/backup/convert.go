@@ -0,0 +1,639 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package backup
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/golang/protobuf/proto"
+
+	api "github.com/polarismesh/polaris-server/common/api/v1"
+	"github.com/polarismesh/polaris-server/common/model"
+	commontime "github.com/polarismesh/polaris-server/common/time"
+	"github.com/polarismesh/polaris-server/common/utils"
+	configutils "github.com/polarismesh/polaris-server/config/utils"
+)
+
+// namespace2API 命名空间转换为API结构，保留token
+func namespace2API(ns *model.Namespace) *api.Namespace {
+	return &api.Namespace{
+		Name:    utils.NewStringValue(ns.Name),
+		Comment: utils.NewStringValue(ns.Comment),
+		Owners:  utils.NewStringValue(ns.Owner),
+		Token:   utils.NewStringValue(ns.Token),
+		Ctime:   utils.NewStringValue(commontime.Time2String(ns.CreateTime)),
+		Mtime:   utils.NewStringValue(commontime.Time2String(ns.ModifyTime)),
+	}
+}
+
+func api2Namespace(req *api.Namespace) *model.Namespace {
+	return &model.Namespace{
+		Name:    req.GetName().GetValue(),
+		Comment: req.GetComment().GetValue(),
+		Owner:   req.GetOwners().GetValue(),
+		Token:   req.GetToken().GetValue(),
+		Valid:   true,
+	}
+}
+
+// service2API 服务转换为API结构，保留ID和token
+func service2API(svc *model.Service) *api.Service {
+	return &api.Service{
+		Id:         utils.NewStringValue(svc.ID),
+		Name:       utils.NewStringValue(svc.Name),
+		Namespace:  utils.NewStringValue(svc.Namespace),
+		Metadata:   svc.Meta,
+		Ports:      utils.NewStringValue(svc.Ports),
+		Business:   utils.NewStringValue(svc.Business),
+		Department: utils.NewStringValue(svc.Department),
+		Comment:    utils.NewStringValue(svc.Comment),
+		Owners:     utils.NewStringValue(svc.Owner),
+		Token:      utils.NewStringValue(svc.Token),
+		Revision:   utils.NewStringValue(svc.Revision),
+		PlatformId: utils.NewStringValue(svc.PlatformID),
+		Ctime:      utils.NewStringValue(commontime.Time2String(svc.CreateTime)),
+		Mtime:      utils.NewStringValue(commontime.Time2String(svc.ModifyTime)),
+	}
+}
+
+func api2Service(req *api.Service) *model.Service {
+	return &model.Service{
+		ID:         req.GetId().GetValue(),
+		Name:       req.GetName().GetValue(),
+		Namespace:  req.GetNamespace().GetValue(),
+		Meta:       req.GetMetadata(),
+		Ports:      req.GetPorts().GetValue(),
+		Business:   req.GetBusiness().GetValue(),
+		Department: req.GetDepartment().GetValue(),
+		Comment:    req.GetComment().GetValue(),
+		Owner:      req.GetOwners().GetValue(),
+		Token:      req.GetToken().GetValue(),
+		Revision:   req.GetRevision().GetValue(),
+		PlatformID: req.GetPlatformId().GetValue(),
+		Valid:      true,
+	}
+}
+
+// alias2API 服务别名转换为API结构，别名的token保存在 service_token 中
+func alias2API(alias *model.Service, reference *model.Service) *api.ServiceAlias {
+	return &api.ServiceAlias{
+		Id:             utils.NewStringValue(alias.ID),
+		Service:        utils.NewStringValue(reference.Name),
+		Namespace:      utils.NewStringValue(reference.Namespace),
+		Alias:          utils.NewStringValue(alias.Name),
+		AliasNamespace: utils.NewStringValue(alias.Namespace),
+		Owners:         utils.NewStringValue(alias.Owner),
+		Comment:        utils.NewStringValue(alias.Comment),
+		ServiceToken:   utils.NewStringValue(alias.Token),
+		Ctime:          utils.NewStringValue(commontime.Time2String(alias.CreateTime)),
+		Mtime:          utils.NewStringValue(commontime.Time2String(alias.ModifyTime)),
+	}
+}
+
+func api2Alias(req *api.ServiceAlias, referenceID string) *model.Service {
+	return &model.Service{
+		ID:        req.GetId().GetValue(),
+		Name:      req.GetAlias().GetValue(),
+		Namespace: req.GetAliasNamespace().GetValue(),
+		Reference: referenceID,
+		Owner:     req.GetOwners().GetValue(),
+		Comment:   req.GetComment().GetValue(),
+		Token:     req.GetServiceToken().GetValue(),
+		Revision:  utils.NewUUID(),
+		Valid:     true,
+	}
+}
+
+// instance2API 实例本身就是API结构，补充实例所属的服务
+func instance2API(ins *model.Instance, svc *model.Service) *api.Instance {
+	out := proto.Clone(ins.Proto).(*api.Instance)
+	out.Service = utils.NewStringValue(svc.Name)
+	out.Namespace = utils.NewStringValue(svc.Namespace)
+	return out
+}
+
+func api2Instance(req *api.Instance, serviceID string) *model.Instance {
+	return &model.Instance{
+		Proto:     req,
+		ServiceID: serviceID,
+		Valid:     true,
+	}
+}
+
+// routing2API 路由配置转换为API结构
+func routing2API(routing *model.RoutingConfig, svc *model.Service) (*api.Routing, error) {
+	out := &api.Routing{
+		Service:   utils.NewStringValue(svc.Name),
+		Namespace: utils.NewStringValue(svc.Namespace),
+		Revision:  utils.NewStringValue(routing.Revision),
+		Ctime:     utils.NewStringValue(commontime.Time2String(routing.CreateTime)),
+		Mtime:     utils.NewStringValue(commontime.Time2String(routing.ModifyTime)),
+	}
+	if err := unmarshalField(routing.InBounds, &out.Inbounds); err != nil {
+		return nil, err
+	}
+	if err := unmarshalField(routing.OutBounds, &out.Outbounds); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func api2Routing(req *api.Routing, serviceID string) (*model.RoutingConfig, error) {
+	inBounds, err := json.Marshal(req.GetInbounds())
+	if err != nil {
+		return nil, err
+	}
+	outBounds, err := json.Marshal(req.GetOutbounds())
+	if err != nil {
+		return nil, err
+	}
+	return &model.RoutingConfig{
+		ID:        serviceID,
+		InBounds:  string(inBounds),
+		OutBounds: string(outBounds),
+		Revision:  req.GetRevision().GetValue(),
+		Valid:     true,
+	}, nil
+}
+
+// rateLimit2API 限流规则转换为API结构，规则内容保存在 rule 字段中
+func rateLimit2API(limit *model.RateLimit, svc *model.Service) (*api.Rule, error) {
+	out := &api.Rule{}
+	if err := unmarshalField(limit.Rule, out); err != nil {
+		return nil, err
+	}
+	if err := unmarshalField(limit.Labels, &out.Labels); err != nil {
+		return nil, err
+	}
+	out.Id = utils.NewStringValue(limit.ID)
+	out.Service = utils.NewStringValue(svc.Name)
+	out.Namespace = utils.NewStringValue(svc.Namespace)
+	out.Priority = utils.NewUInt32Value(limit.Priority)
+	out.Revision = utils.NewStringValue(limit.Revision)
+	out.Ctime = utils.NewStringValue(commontime.Time2String(limit.CreateTime))
+	out.Mtime = utils.NewStringValue(commontime.Time2String(limit.ModifyTime))
+	return out, nil
+}
+
+// api2RateLimit 与服务端保存限流规则的方式保持一致，rule 中不包括规则的ID、服务、标签等信息
+func api2RateLimit(req *api.Rule, serviceID string) (*model.RateLimit, error) {
+	labels, err := json.Marshal(req.GetLabels())
+	if err != nil {
+		return nil, err
+	}
+	rule, err := json.Marshal(&api.Rule{
+		Subset:       req.GetSubset(),
+		Resource:     req.GetResource(),
+		Type:         req.GetType(),
+		Amounts:      req.GetAmounts(),
+		Action:       req.GetAction(),
+		Disable:      req.GetDisable(),
+		Report:       req.GetReport(),
+		Adjuster:     req.GetAdjuster(),
+		RegexCombine: req.GetRegexCombine(),
+		AmountMode:   req.GetAmountMode(),
+		Failover:     req.GetFailover(),
+		Cluster:      req.GetCluster(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &model.RateLimit{
+		ID:        req.GetId().GetValue(),
+		ServiceID: serviceID,
+		Labels:    string(labels),
+		Priority:  req.GetPriority().GetValue(),
+		Rule:      string(rule),
+		Revision:  req.GetRevision().GetValue(),
+		Valid:     true,
+	}, nil
+}
+
+// circuitBreaker2API 熔断规则转换为API结构，保留token
+func circuitBreaker2API(rule *model.CircuitBreaker) (*api.CircuitBreaker, error) {
+	out := &api.CircuitBreaker{
+		Id:         utils.NewStringValue(rule.ID),
+		Version:    utils.NewStringValue(rule.Version),
+		Name:       utils.NewStringValue(rule.Name),
+		Namespace:  utils.NewStringValue(rule.Namespace),
+		Owners:     utils.NewStringValue(rule.Owner),
+		Comment:    utils.NewStringValue(rule.Comment),
+		Business:   utils.NewStringValue(rule.Business),
+		Department: utils.NewStringValue(rule.Department),
+		Token:      utils.NewStringValue(rule.Token),
+		Revision:   utils.NewStringValue(rule.Revision),
+		Ctime:      utils.NewStringValue(commontime.Time2String(rule.CreateTime)),
+		Mtime:      utils.NewStringValue(commontime.Time2String(rule.ModifyTime)),
+	}
+	if err := unmarshalField(rule.Inbounds, &out.Inbounds); err != nil {
+		return nil, err
+	}
+	if err := unmarshalField(rule.Outbounds, &out.Outbounds); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func api2CircuitBreaker(req *api.CircuitBreaker) (*model.CircuitBreaker, error) {
+	inbounds, err := json.Marshal(req.GetInbounds())
+	if err != nil {
+		return nil, err
+	}
+	outbounds, err := json.Marshal(req.GetOutbounds())
+	if err != nil {
+		return nil, err
+	}
+	return &model.CircuitBreaker{
+		ID:         req.GetId().GetValue(),
+		Version:    req.GetVersion().GetValue(),
+		Name:       req.GetName().GetValue(),
+		Namespace:  req.GetNamespace().GetValue(),
+		Business:   req.GetBusiness().GetValue(),
+		Department: req.GetDepartment().GetValue(),
+		Comment:    req.GetComment().GetValue(),
+		Inbounds:   string(inbounds),
+		Outbounds:  string(outbounds),
+		Token:      req.GetToken().GetValue(),
+		Owner:      req.GetOwners().GetValue(),
+		Revision:   req.GetRevision().GetValue(),
+		Valid:      true,
+	}, nil
+}
+
+// circuitBreakerRelation2API 熔断规则的发布关系转换为API结构
+func circuitBreakerRelation2API(relation *model.CircuitBreakerRelation, svc *model.Service) *api.ConfigRelease {
+	return &api.ConfigRelease{
+		Service: &api.Service{
+			Name:      utils.NewStringValue(svc.Name),
+			Namespace: utils.NewStringValue(svc.Namespace),
+		},
+		CircuitBreaker: &api.CircuitBreaker{
+			Id:      utils.NewStringValue(relation.RuleID),
+			Version: utils.NewStringValue(relation.RuleVersion),
+		},
+		Ctime: utils.NewStringValue(commontime.Time2String(relation.CreateTime)),
+		Mtime: utils.NewStringValue(commontime.Time2String(relation.ModifyTime)),
+	}
+}
+
+func api2CircuitBreakerRelation(req *api.ConfigRelease, serviceID string) *model.CircuitBreakerRelation {
+	return &model.CircuitBreakerRelation{
+		ServiceID:   serviceID,
+		RuleID:      req.GetCircuitBreaker().GetId().GetValue(),
+		RuleVersion: req.GetCircuitBreaker().GetVersion().GetValue(),
+		Valid:       true,
+	}
+}
+
+// configFileGroup2API 配置分组转换为API结构，分组ID用于还原鉴权策略中的资源
+func configFileGroup2API(group *model.ConfigFileGroup) *api.ConfigFileGroup {
+	return &api.ConfigFileGroup{
+		Id:         utils.NewUInt64Value(group.Id),
+		Name:       utils.NewStringValue(group.Name),
+		Namespace:  utils.NewStringValue(group.Namespace),
+		Comment:    utils.NewStringValue(group.Comment),
+		JsonSchema: utils.NewStringValue(group.JsonSchema),
+		CreateBy:   utils.NewStringValue(group.CreateBy),
+		ModifyBy:   utils.NewStringValue(group.ModifyBy),
+		CreateTime: utils.NewStringValue(commontime.Time2String(group.CreateTime)),
+		ModifyTime: utils.NewStringValue(commontime.Time2String(group.ModifyTime)),
+	}
+}
+
+func api2ConfigFileGroup(req *api.ConfigFileGroup) *model.ConfigFileGroup {
+	return &model.ConfigFileGroup{
+		Id:         req.GetId().GetValue(),
+		Name:       req.GetName().GetValue(),
+		Namespace:  req.GetNamespace().GetValue(),
+		Comment:    req.GetComment().GetValue(),
+		JsonSchema: req.GetJsonSchema().GetValue(),
+		CreateBy:   req.GetCreateBy().GetValue(),
+		ModifyBy:   req.GetModifyBy().GetValue(),
+		Valid:      true,
+	}
+}
+
+// configFile2API 配置文件转换为API结构，配置文件的标签保存在 tags 中
+func configFile2API(file *model.ConfigFile, tags []*model.ConfigFileTag) *api.ConfigFile {
+	out := &api.ConfigFile{
+		Name:          utils.NewStringValue(file.Name),
+		Namespace:     utils.NewStringValue(file.Namespace),
+		Group:         utils.NewStringValue(file.Group),
+		Content:       utils.NewStringValue(file.Content),
+		Format:        utils.NewStringValue(file.Format),
+		Comment:       utils.NewStringValue(file.Comment),
+		Encrypted:     utils.NewBoolValue(file.Encrypted),
+		BaseFile:      utils.NewStringValue(file.BaseFile),
+		VariablesFile: utils.NewStringValue(file.VariablesFile),
+		CreateBy:      utils.NewStringValue(file.CreateBy),
+		ModifyBy:      utils.NewStringValue(file.ModifyBy),
+		CreateTime:    utils.NewStringValue(commontime.Time2String(file.CreateTime)),
+		ModifyTime:    utils.NewStringValue(commontime.Time2String(file.ModifyTime)),
+	}
+	for _, tag := range tags {
+		out.Tags = append(out.Tags, &api.ConfigFileTag{
+			Key:   utils.NewStringValue(tag.Key),
+			Value: utils.NewStringValue(tag.Value),
+		})
+	}
+	return out
+}
+
+func api2ConfigFile(req *api.ConfigFile) (*model.ConfigFile, []*model.ConfigFileTag) {
+	file := &model.ConfigFile{
+		Name:          req.GetName().GetValue(),
+		Namespace:     req.GetNamespace().GetValue(),
+		Group:         req.GetGroup().GetValue(),
+		Content:       req.GetContent().GetValue(),
+		Format:        req.GetFormat().GetValue(),
+		Comment:       req.GetComment().GetValue(),
+		Encrypted:     req.GetEncrypted().GetValue(),
+		BaseFile:      req.GetBaseFile().GetValue(),
+		VariablesFile: req.GetVariablesFile().GetValue(),
+		CreateBy:      req.GetCreateBy().GetValue(),
+		ModifyBy:      req.GetModifyBy().GetValue(),
+		Valid:         true,
+	}
+	tags := make([]*model.ConfigFileTag, 0, len(req.GetTags()))
+	for _, tag := range req.GetTags() {
+		tags = append(tags, &model.ConfigFileTag{
+			Key:       tag.GetKey().GetValue(),
+			Value:     tag.GetValue().GetValue(),
+			Namespace: file.Namespace,
+			Group:     file.Group,
+			FileName:  file.Name,
+			CreateBy:  file.CreateBy,
+			ModifyBy:  file.ModifyBy,
+			Valid:     true,
+		})
+	}
+	return file, tags
+}
+
+// configFileRelease2API 配置发布转换为API结构，md5 在还原时根据内容重新计算
+func configFileRelease2API(release *model.ConfigFileRelease) *api.ConfigFileRelease {
+	return &api.ConfigFileRelease{
+		Name:       utils.NewStringValue(release.Name),
+		Namespace:  utils.NewStringValue(release.Namespace),
+		Group:      utils.NewStringValue(release.Group),
+		FileName:   utils.NewStringValue(release.FileName),
+		Content:    utils.NewStringValue(release.Content),
+		Comment:    utils.NewStringValue(release.Comment),
+		Version:    utils.NewUInt64Value(release.Version),
+		CreateBy:   utils.NewStringValue(release.CreateBy),
+		ModifyBy:   utils.NewStringValue(release.ModifyBy),
+		CreateTime: utils.NewStringValue(commontime.Time2String(release.CreateTime)),
+		ModifyTime: utils.NewStringValue(commontime.Time2String(release.ModifyTime)),
+	}
+}
+
+func api2ConfigFileRelease(req *api.ConfigFileRelease) *model.ConfigFileRelease {
+	return &model.ConfigFileRelease{
+		Name:      req.GetName().GetValue(),
+		Namespace: req.GetNamespace().GetValue(),
+		Group:     req.GetGroup().GetValue(),
+		FileName:  req.GetFileName().GetValue(),
+		Content:   req.GetContent().GetValue(),
+		Comment:   req.GetComment().GetValue(),
+		Md5:       configutils.CalMd5(req.GetContent().GetValue()),
+		Version:   req.GetVersion().GetValue(),
+		CreateBy:  req.GetCreateBy().GetValue(),
+		ModifyBy:  req.GetModifyBy().GetValue(),
+		Valid:     true,
+	}
+}
+
+// configFileReleaseHistory2API 发布历史转换为API结构
+func configFileReleaseHistory2API(history *model.ConfigFileReleaseHistory) *api.ConfigFileReleaseHistory {
+	return &api.ConfigFileReleaseHistory{
+		Name:            utils.NewStringValue(history.Name),
+		Namespace:       utils.NewStringValue(history.Namespace),
+		Group:           utils.NewStringValue(history.Group),
+		FileName:        utils.NewStringValue(history.FileName),
+		Content:         utils.NewStringValue(history.Content),
+		TemplateContent: utils.NewStringValue(history.TemplateContent),
+		Format:          utils.NewStringValue(history.Format),
+		Comment:         utils.NewStringValue(history.Comment),
+		Type:            utils.NewStringValue(history.Type),
+		Status:          utils.NewStringValue(history.Status),
+		Tags:            configutils.FromTagJson(history.Tags),
+		CreateBy:        utils.NewStringValue(history.CreateBy),
+		ModifyBy:        utils.NewStringValue(history.ModifyBy),
+		CreateTime:      utils.NewStringValue(commontime.Time2String(history.CreateTime)),
+		ModifyTime:      utils.NewStringValue(commontime.Time2String(history.ModifyTime)),
+	}
+}
+
+func api2ConfigFileReleaseHistory(req *api.ConfigFileReleaseHistory) *model.ConfigFileReleaseHistory {
+	return &model.ConfigFileReleaseHistory{
+		Name:            req.GetName().GetValue(),
+		Namespace:       req.GetNamespace().GetValue(),
+		Group:           req.GetGroup().GetValue(),
+		FileName:        req.GetFileName().GetValue(),
+		Content:         req.GetContent().GetValue(),
+		TemplateContent: req.GetTemplateContent().GetValue(),
+		Format:          req.GetFormat().GetValue(),
+		Comment:         req.GetComment().GetValue(),
+		Type:            req.GetType().GetValue(),
+		Status:          req.GetStatus().GetValue(),
+		Tags:            configutils.ToTagJsonStr(req.GetTags()),
+		Md5:             configutils.CalMd5(req.GetContent().GetValue()),
+		CreateBy:        req.GetCreateBy().GetValue(),
+		ModifyBy:        req.GetModifyBy().GetValue(),
+		Valid:           true,
+	}
+}
+
+// user2API 用户转换为API结构，密码为加密后的值
+func user2API(user *model.User) *api.User {
+	return &api.User{
+		Id:          utils.NewStringValue(user.ID),
+		Name:        utils.NewStringValue(user.Name),
+		Password:    utils.NewStringValue(user.Password),
+		Owner:       utils.NewStringValue(user.Owner),
+		Source:      utils.NewStringValue(user.Source),
+		AuthToken:   utils.NewStringValue(user.Token),
+		TokenEnable: utils.NewBoolValue(user.TokenEnable),
+		Comment:     utils.NewStringValue(user.Comment),
+		UserType:    utils.NewStringValue(model.UserRoleNames[user.Type]),
+		Mobile:      utils.NewStringValue(user.Mobile),
+		Email:       utils.NewStringValue(user.Email),
+		Ctime:       utils.NewStringValue(commontime.Time2String(user.CreateTime)),
+		Mtime:       utils.NewStringValue(commontime.Time2String(user.ModifyTime)),
+	}
+}
+
+func api2User(req *api.User) (*model.User, error) {
+	userType := model.UnknownUserRole
+	for role, name := range model.UserRoleNames {
+		if name == req.GetUserType().GetValue() {
+			userType = role
+		}
+	}
+	if userType == model.UnknownUserRole {
+		return nil, fmt.Errorf("user(%s) has unknown user type %s", req.GetName().GetValue(),
+			req.GetUserType().GetValue())
+	}
+	return &model.User{
+		ID:          req.GetId().GetValue(),
+		Name:        req.GetName().GetValue(),
+		Password:    req.GetPassword().GetValue(),
+		Owner:       req.GetOwner().GetValue(),
+		Source:      req.GetSource().GetValue(),
+		Mobile:      req.GetMobile().GetValue(),
+		Email:       req.GetEmail().GetValue(),
+		Type:        userType,
+		Token:       req.GetAuthToken().GetValue(),
+		TokenEnable: req.GetTokenEnable().GetValue(),
+		Comment:     req.GetComment().GetValue(),
+		Valid:       true,
+	}, nil
+}
+
+// group2API 用户组转换为API结构，组内用户保存在 relation 中
+func group2API(group *model.UserGroupDetail) *api.UserGroup {
+	out := &api.UserGroup{
+		Id:          utils.NewStringValue(group.ID),
+		Name:        utils.NewStringValue(group.Name),
+		Owner:       utils.NewStringValue(group.Owner),
+		AuthToken:   utils.NewStringValue(group.Token),
+		TokenEnable: utils.NewBoolValue(group.TokenEnable),
+		Comment:     utils.NewStringValue(group.Comment),
+		Ctime:       utils.NewStringValue(commontime.Time2String(group.CreateTime)),
+		Mtime:       utils.NewStringValue(commontime.Time2String(group.ModifyTime)),
+		Relation: &api.UserGroupRelation{
+			GroupId: utils.NewStringValue(group.ID),
+		},
+	}
+	for _, id := range sortedKeys(group.UserIds) {
+		out.Relation.Users = append(out.Relation.Users, &api.User{Id: utils.NewStringValue(id)})
+	}
+	return out
+}
+
+func api2Group(req *api.UserGroup) *model.UserGroupDetail {
+	userIds := make(map[string]struct{}, len(req.GetRelation().GetUsers()))
+	for _, user := range req.GetRelation().GetUsers() {
+		userIds[user.GetId().GetValue()] = struct{}{}
+	}
+	return &model.UserGroupDetail{
+		UserGroup: &model.UserGroup{
+			ID:          req.GetId().GetValue(),
+			Name:        req.GetName().GetValue(),
+			Owner:       req.GetOwner().GetValue(),
+			Token:       req.GetAuthToken().GetValue(),
+			TokenEnable: req.GetTokenEnable().GetValue(),
+			Comment:     req.GetComment().GetValue(),
+			Valid:       true,
+		},
+		UserIds: userIds,
+	}
+}
+
+// strategy2API 鉴权策略转换为API结构
+func strategy2API(strategy *model.StrategyDetail) *api.AuthStrategy {
+	out := &api.AuthStrategy{
+		Id:              utils.NewStringValue(strategy.ID),
+		Name:            utils.NewStringValue(strategy.Name),
+		Action:          api.AuthAction(api.AuthAction_value[strategy.Action]),
+		Comment:         utils.NewStringValue(strategy.Comment),
+		Owner:           utils.NewStringValue(strategy.Owner),
+		DefaultStrategy: utils.NewBoolValue(strategy.Default),
+		Principals:      &api.Principals{},
+		Resources:       &api.StrategyResources{StrategyId: utils.NewStringValue(strategy.ID)},
+		Ctime:           utils.NewStringValue(commontime.Time2String(strategy.CreateTime)),
+		Mtime:           utils.NewStringValue(commontime.Time2String(strategy.ModifyTime)),
+	}
+	for _, principal := range strategy.Principals {
+		entry := &api.Principal{Id: utils.NewStringValue(principal.PrincipalID)}
+		if principal.PrincipalRole == model.PrincipalGroup {
+			out.Principals.Groups = append(out.Principals.Groups, entry)
+		} else {
+			out.Principals.Users = append(out.Principals.Users, entry)
+		}
+	}
+	for _, res := range strategy.Resources {
+		entry := &api.StrategyResourceEntry{Id: utils.NewStringValue(res.ResID)}
+		switch api.ResourceType(res.ResType) {
+		case api.ResourceType_Namespaces:
+			out.Resources.Namespaces = append(out.Resources.Namespaces, entry)
+		case api.ResourceType_Services:
+			out.Resources.Services = append(out.Resources.Services, entry)
+		case api.ResourceType_ConfigGroups:
+			out.Resources.ConfigGroups = append(out.Resources.ConfigGroups, entry)
+		}
+	}
+	return out
+}
+
+func api2Strategy(req *api.AuthStrategy) *model.StrategyDetail {
+	id := req.GetId().GetValue()
+	out := &model.StrategyDetail{
+		ID:       id,
+		Name:     req.GetName().GetValue(),
+		Action:   req.GetAction().String(),
+		Comment:  req.GetComment().GetValue(),
+		Owner:    req.GetOwner().GetValue(),
+		Default:  req.GetDefaultStrategy().GetValue(),
+		Revision: utils.NewUUID(),
+		Valid:    true,
+	}
+	for _, user := range req.GetPrincipals().GetUsers() {
+		out.Principals = append(out.Principals, model.Principal{StrategyID: id,
+			PrincipalID: user.GetId().GetValue(), PrincipalRole: model.PrincipalUser})
+	}
+	for _, group := range req.GetPrincipals().GetGroups() {
+		out.Principals = append(out.Principals, model.Principal{StrategyID: id,
+			PrincipalID: group.GetId().GetValue(), PrincipalRole: model.PrincipalGroup})
+	}
+	resources := []struct {
+		resType api.ResourceType
+		entries []*api.StrategyResourceEntry
+	}{
+		{api.ResourceType_Namespaces, req.GetResources().GetNamespaces()},
+		{api.ResourceType_Services, req.GetResources().GetServices()},
+		{api.ResourceType_ConfigGroups, req.GetResources().GetConfigGroups()},
+	}
+	for _, item := range resources {
+		for _, entry := range item.entries {
+			out.Resources = append(out.Resources, model.StrategyResource{StrategyID: id,
+				ResType: int32(item.resType), ResID: entry.GetId().GetValue()})
+		}
+	}
+	return out
+}
+
+// unmarshalField 解析存储中以json字符串保存的字段
+func unmarshalField(value string, v interface{}) error {
+	if value == "" {
+		return nil
+	}
+	return json.Unmarshal([]byte(value), v)
+}
+
+// sortedKeys 有序的ID列表，保证相同的数据导出的文件内容一致
+func sortedKeys(ids map[string]struct{}) []string {
+	out := make([]string, 0, len(ids))
+	for id := range ids {
+		out = append(out, id)
+	}
+	sort.Strings(out)
+	return out
+}
@@ -0,0 +1,290 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package boltdb
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/boltdb/bolt"
+
+	"github.com/polarismesh/polaris-server/common/model"
+	"github.com/polarismesh/polaris-server/store"
+)
+
+const (
+	tblServiceChangeLog  = "service_change_log"
+	tblInstanceChangeLog = "instance_change_log"
+
+	// defaultChangeLogRetention default retention of change log
+	defaultChangeLogRetention = time.Hour
+	// changeLogCleanInterval interval of cleaning expired change log
+	changeLogCleanInterval = time.Minute
+)
+
+var (
+	// changeLogTables data table -> change log bucket
+	changeLogTables = map[string]string{
+		tblNameService:  tblServiceChangeLog,
+		tblNameInstance: tblInstanceChangeLog,
+	}
+	// changeLogResources resource -> change log bucket
+	changeLogResources = map[string]string{
+		store.ChangeLogService:  tblServiceChangeLog,
+		store.ChangeLogInstance: tblInstanceChangeLog,
+	}
+)
+
+// recordChange append change records of the data table in the same tx,
+// nothing to do if the change log bucket not exists (change log disabled)
+func recordChange(tx *bolt.Tx, typ string, keys ...string) error {
+	name, ok := changeLogTables[typ]
+	if !ok {
+		return nil
+	}
+	bucket := tx.Bucket([]byte(name))
+	if bucket == nil {
+		return nil
+	}
+	now := time.Now()
+	for _, key := range keys {
+		seq, err := bucket.NextSequence()
+		if err != nil {
+			return err
+		}
+		if err := bucket.Put(encodeSeq(seq), encodeChange(now, key)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// encodeSeq big endian keeps the order of seq in bucket
+func encodeSeq(seq uint64) []byte {
+	buf := make([]byte, 8)
+	binary.BigEndian.PutUint64(buf, seq)
+	return buf
+}
+
+// encodeChange create time(unix nano) + id
+func encodeChange(ctime time.Time, id string) []byte {
+	buf := make([]byte, 8+len(id))
+	binary.BigEndian.PutUint64(buf, uint64(ctime.UnixNano()))
+	copy(buf[8:], id)
+	return buf
+}
+
+// decodeChange decode the change log record
+func decodeChange(key, value []byte) (*model.ChangeLog, error) {
+	if len(key) != 8 || len(value) < 8 {
+		return nil, fmt.Errorf("invalid change log record, key len %d, value len %d", len(key), len(value))
+	}
+	return &model.ChangeLog{
+		Seq:        binary.BigEndian.Uint64(key),
+		ID:         string(value[8:]),
+		CreateTime: time.Unix(0, int64(binary.BigEndian.Uint64(value))),
+	}, nil
+}
+
+// changeLogStore implement store.ChangeLogStore
+type changeLogStore struct {
+	handler   BoltHandler
+	enable    bool
+	retention time.Duration
+	stopCh    chan struct{}
+}
+
+// newChangeLogStore create or drop the change log buckets according to the config
+func newChangeLogStore(handler BoltHandler, enable bool, retention time.Duration) (*changeLogStore, error) {
+	c := &changeLogStore{
+		handler:   handler,
+		enable:    enable,
+		retention: retention,
+		stopCh:    make(chan struct{}),
+	}
+	err := handler.Execute(true, func(tx *bolt.Tx) error {
+		for _, name := range changeLogResources {
+			if !enable {
+				// drop the buckets, otherwise the records will be outdated when enabled again
+				if err := tx.DeleteBucket([]byte(name)); err != nil && err != bolt.ErrBucketNotFound {
+					return err
+				}
+				continue
+			}
+			if _, err := tx.CreateBucketIfNotExists([]byte(name)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if enable {
+		go c.cleanLoop()
+	}
+	return c, nil
+}
+
+// ChangeLogEnabled whether change log is enabled
+func (c *changeLogStore) ChangeLogEnabled() bool {
+	return c.enable
+}
+
+// GetLatestChangeSeq get the latest seq of the resource
+func (c *changeLogStore) GetLatestChangeSeq(resource string) (uint64, error) {
+	name, ok := changeLogResources[resource]
+	if !ok {
+		return 0, fmt.Errorf("change log of %s not found", resource)
+	}
+	var seq uint64
+	err := c.handler.Execute(false, func(tx *bolt.Tx) error {
+		if bucket := tx.Bucket([]byte(name)); bucket != nil {
+			seq = bucket.Sequence()
+		}
+		return nil
+	})
+	return seq, err
+}
+
+// GetServiceChanges get the changes after seq and the changed services
+func (c *changeLogStore) GetServiceChanges(seq uint64, limit uint32, needMeta bool) (
+	[]*model.ChangeLog, map[string]*model.Service, error) {
+	services := make(map[string]*model.Service)
+	logs, err := c.getChanges(tblServiceChangeLog, seq, limit, func(tx *bolt.Tx, ids []string) error {
+		values := make(map[string]interface{})
+		if err := loadValues(tx, tblNameService, ids, &model.Service{}, values); err != nil {
+			return err
+		}
+		for id, value := range values {
+			services[id] = value.(*model.Service)
+		}
+		return nil
+	})
+	if err != nil {
+		log.Errorf("[Store][boltdb] get service changes error, %v", err)
+		return nil, nil, err
+	}
+	return logs, services, nil
+}
+
+// GetInstanceChanges get the changes after seq and the changed instances
+func (c *changeLogStore) GetInstanceChanges(seq uint64, limit uint32, needMeta bool) (
+	[]*model.ChangeLog, map[string]*model.Instance, error) {
+	instances := make(map[string]*model.Instance)
+	logs, err := c.getChanges(tblInstanceChangeLog, seq, limit, func(tx *bolt.Tx, ids []string) error {
+		values := make(map[string]interface{})
+		if err := loadValues(tx, tblNameInstance, ids, &model.Instance{}, values); err != nil {
+			return err
+		}
+		for id, value := range values {
+			instances[id] = value.(*model.Instance)
+		}
+		return nil
+	})
+	if err != nil {
+		log.Errorf("[Store][boltdb] get instance changes error, %v", err)
+		return nil, nil, err
+	}
+	return logs, instances, nil
+}
+
+// getChanges read the change log and the changed values in one read tx
+func (c *changeLogStore) getChanges(name string, seq uint64, limit uint32,
+	fetch func(tx *bolt.Tx, ids []string) error) ([]*model.ChangeLog, error) {
+	logs := make([]*model.ChangeLog, 0)
+	err := c.handler.Execute(false, func(tx *bolt.Tx) error {
+		bucket := tx.Bucket([]byte(name))
+		if bucket == nil {
+			return nil
+		}
+		ids := make([]string, 0)
+		exists := make(map[string]bool)
+		cursor := bucket.Cursor()
+		for k, v := cursor.Seek(encodeSeq(seq + 1)); k != nil && uint32(len(logs)) < limit; k, v = cursor.Next() {
+			entry, err := decodeChange(k, v)
+			if err != nil {
+				return err
+			}
+			logs = append(logs, entry)
+			if !exists[entry.ID] {
+				exists[entry.ID] = true
+				ids = append(ids, entry.ID)
+			}
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+		return fetch(tx, ids)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return logs, nil
+}
+
+// cleanLoop clean expired change log periodically
+func (c *changeLogStore) cleanLoop() {
+	ticker := time.NewTicker(changeLogCleanInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := c.clean(); err != nil {
+				log.Errorf("[Store][boltdb] clean change log error, %v", err)
+			}
+		case <-c.stopCh:
+			return
+		}
+	}
+}
+
+// clean delete the change log before retention, the bucket sequence never goes back
+func (c *changeLogStore) clean() error {
+	expire := time.Now().Add(-c.retention)
+	return c.handler.Execute(true, func(tx *bolt.Tx) error {
+		for _, name := range changeLogResources {
+			bucket := tx.Bucket([]byte(name))
+			if bucket == nil {
+				continue
+			}
+			cursor := bucket.Cursor()
+			for k, v := cursor.First(); k != nil; k, v = cursor.First() {
+				entry, err := decodeChange(k, v)
+				if err != nil {
+					return err
+				}
+				if !entry.CreateTime.Before(expire) {
+					break
+				}
+				if err := cursor.Delete(); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// stop stop cleaning
+func (c *changeLogStore) stop() {
+	if c.enable {
+		close(c.stopCh)
+	}
+}
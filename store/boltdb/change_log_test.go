@@ -0,0 +1,113 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package boltdb
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/polarismesh/polaris-server/store"
+)
+
+func Test_changeLogStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "polaris-bolt-change-log")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	handler, err := NewBoltHandler(&BoltConfig{FileName: filepath.Join(dir, "polaris.bolt")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer handler.Close()
+
+	insStore := &instanceStore{handler: handler}
+	// change log disabled, nothing recorded
+	disabled, err := newChangeLogStore(handler, false, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	batchAddInstances(t, insStore, "svcid1", 1)
+	if seq, _ := disabled.GetLatestChangeSeq(store.ChangeLogInstance); seq != 0 {
+		t.Fatalf("expect no change log, got seq %d", seq)
+	}
+
+	changes, err := newChangeLogStore(handler, true, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer changes.stop()
+	batchAddInstances(t, insStore, "svcid2", 2)
+	if err := insStore.SetInstanceHealthStatus("insid0", 0, "rev"); err != nil {
+		t.Fatal(err)
+	}
+	if err := insStore.DeleteInstance("insid1"); err != nil {
+		t.Fatal(err)
+	}
+
+	latest, err := changes.GetLatestChangeSeq(store.ChangeLogInstance)
+	if err != nil {
+		t.Fatal(err)
+	}
+	logs, instances, err := changes.GetInstanceChanges(0, 100, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(logs) == 0 || logs[len(logs)-1].Seq != latest || logs[len(logs)-1].ID != "insid1" {
+		t.Fatalf("unexpected change logs %+v, latest %d", logs, latest)
+	}
+	if instances["insid0"].Healthy() || instances["insid1"].Valid {
+		t.Fatal("changed instances not match")
+	}
+	for i := 1; i < len(logs); i++ {
+		if logs[i].Seq <= logs[i-1].Seq {
+			t.Fatal("seq of change log should increase monotonically")
+		}
+	}
+
+	// pull by seq
+	logs, _, err = changes.GetInstanceChanges(latest-1, 100, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(logs) != 1 || logs[0].Seq != latest {
+		t.Fatalf("unexpected change logs %+v", logs)
+	}
+
+	services, err := changes.GetLatestChangeSeq(store.ChangeLogService)
+	if err != nil || services == 0 {
+		t.Fatalf("expect service change log, got %d, %v", services, err)
+	}
+
+	// clean expired change log, the seq never goes back
+	changes.retention = -time.Hour
+	if err := changes.clean(); err != nil {
+		t.Fatal(err)
+	}
+	logs, _, err = changes.GetInstanceChanges(0, 100, false)
+	if err != nil || len(logs) != 0 {
+		t.Fatalf("expect change log cleaned, got %d, %v", len(logs), err)
+	}
+	if seq, _ := changes.GetLatestChangeSeq(store.ChangeLogInstance); seq != latest {
+		t.Fatalf("expect seq %d, got %d", latest, seq)
+	}
+}
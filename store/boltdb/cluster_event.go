@@ -0,0 +1,150 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package boltdb
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"time"
+
+	"github.com/boltdb/bolt"
+
+	"github.com/polarismesh/polaris-server/common/model"
+	"github.com/polarismesh/polaris-server/store"
+)
+
+const (
+	tblClusterEvent = "cluster_event"
+)
+
+// clusterEventValue the stored value of cluster event, the seq is the key
+type clusterEventValue struct {
+	Topic      string
+	Payload    string
+	CreateTime time.Time
+}
+
+// clusterEventStore implement store.ClusterEventStore
+type clusterEventStore struct {
+	handler BoltHandler
+}
+
+// AppendClusterEvent append an event with the next seq of the bucket
+func (c *clusterEventStore) AppendClusterEvent(topic, payload string) error {
+	value, err := json.Marshal(&clusterEventValue{Topic: topic, Payload: payload, CreateTime: time.Now()})
+	if err != nil {
+		return err
+	}
+	err = c.handler.Execute(true, func(tx *bolt.Tx) error {
+		bucket, err := tx.CreateBucketIfNotExists([]byte(tblClusterEvent))
+		if err != nil {
+			return err
+		}
+		seq, err := bucket.NextSequence()
+		if err != nil {
+			return err
+		}
+		return bucket.Put(encodeSeq(seq), value)
+	})
+	if err != nil {
+		log.Errorf("[Store][boltdb] append cluster event error, %v", err)
+		return store.Error(err)
+	}
+	return nil
+}
+
+// GetLatestClusterEventSeq get the latest seq, the sequence of bucket never goes back after cleaning
+func (c *clusterEventStore) GetLatestClusterEventSeq() (uint64, error) {
+	var seq uint64
+	err := c.handler.Execute(false, func(tx *bolt.Tx) error {
+		if bucket := tx.Bucket([]byte(tblClusterEvent)); bucket != nil {
+			seq = bucket.Sequence()
+		}
+		return nil
+	})
+	return seq, err
+}
+
+// GetClusterEvents get the events after seq
+func (c *clusterEventStore) GetClusterEvents(seq uint64, limit uint32) ([]*model.ClusterEvent, error) {
+	events := make([]*model.ClusterEvent, 0)
+	err := c.handler.Execute(false, func(tx *bolt.Tx) error {
+		bucket := tx.Bucket([]byte(tblClusterEvent))
+		if bucket == nil {
+			return nil
+		}
+		cursor := bucket.Cursor()
+		for k, v := cursor.Seek(encodeSeq(seq + 1)); k != nil && uint32(len(events)) < limit; k, v = cursor.Next() {
+			value := &clusterEventValue{}
+			if err := json.Unmarshal(v, value); err != nil {
+				return err
+			}
+			events = append(events, &model.ClusterEvent{
+				Seq:        binary.BigEndian.Uint64(k),
+				Topic:      value.Topic,
+				Payload:    value.Payload,
+				CreateTime: value.CreateTime,
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		log.Errorf("[Store][boltdb] get cluster events error, %v", err)
+		return nil, err
+	}
+	return events, nil
+}
+
+// CleanClusterEvents delete the events created before ctime, the latest one is kept
+func (c *clusterEventStore) CleanClusterEvents(ctime time.Time) error {
+	err := c.handler.Execute(true, func(tx *bolt.Tx) error {
+		bucket := tx.Bucket([]byte(tblClusterEvent))
+		if bucket == nil {
+			return nil
+		}
+		lastKey, _ := bucket.Cursor().Last()
+		expired := make([][]byte, 0)
+		err := bucket.ForEach(func(k, v []byte) error {
+			if string(k) == string(lastKey) {
+				return nil
+			}
+			value := &clusterEventValue{}
+			if err := json.Unmarshal(v, value); err != nil {
+				return err
+			}
+			if value.CreateTime.Before(ctime) {
+				expired = append(expired, k)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		for _, k := range expired {
+			if err := bucket.Delete(k); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		log.Errorf("[Store][boltdb] clean cluster events error, %v", err)
+		return store.Error(err)
+	}
+	return nil
+}
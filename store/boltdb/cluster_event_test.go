@@ -0,0 +1,79 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package boltdb
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func Test_clusterEventStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "polaris-bolt-cluster-event")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	handler, err := NewBoltHandler(&BoltConfig{FileName: filepath.Join(dir, "polaris.bolt")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer handler.Close()
+
+	events := &clusterEventStore{handler: handler}
+	if seq, err := events.GetLatestClusterEventSeq(); err != nil || seq != 0 {
+		t.Fatalf("expect empty events, got seq %d, err %v", seq, err)
+	}
+	for _, payload := range []string{"a", "b", "c"} {
+		if err := events.AppendClusterEvent("topic", payload); err != nil {
+			t.Fatal(err)
+		}
+	}
+	latest, err := events.GetLatestClusterEventSeq()
+	if err != nil || latest != 3 {
+		t.Fatalf("expect latest seq 3, got %d, err %v", latest, err)
+	}
+	ret, err := events.GetClusterEvents(1, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ret) != 1 || ret[0].Seq != 2 || ret[0].Topic != "topic" || ret[0].Payload != "b" {
+		t.Fatalf("unexpected events %+v", ret)
+	}
+
+	// the latest event is kept after cleaning
+	if err := events.CleanClusterEvents(time.Now().Add(time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+	ret, err = events.GetClusterEvents(0, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ret) != 1 || ret[0].Seq != 3 {
+		t.Fatalf("unexpected events after clean %+v", ret)
+	}
+	if err := events.AppendClusterEvent("topic", "d"); err != nil {
+		t.Fatal(err)
+	}
+	if latest, _ := events.GetLatestClusterEventSeq(); latest != 4 {
+		t.Fatalf("expect latest seq 4, got %d", latest)
+	}
+}
@@ -41,6 +41,9 @@ const (
 	FileFieldContent    string = "Content"
 	FileFieldComment    string = "Comment"
 	FileFieldFormat     string = "Format"
+	FileFieldEncrypted  string = "Encrypted"
+	FileFieldBaseFile   string = "BaseFile"
+	FileFieldVariables  string = "VariablesFile"
 	FileFieldFlag       string = "Flag"
 	FileFieldCreateTime string = "CreateTime"
 	FileFieldCreateBy   string = "CreateBy"
@@ -215,6 +218,9 @@ func (cf *configFileStore) UpdateConfigFile(proxyTx store.Tx, file *model.Config
 		properties[FileFieldContent] = file.Content
 		properties[FileFieldComment] = file.Comment
 		properties[FileFieldFormat] = file.Format
+		properties[FileFieldEncrypted] = file.Encrypted
+		properties[FileFieldBaseFile] = file.BaseFile
+		properties[FileFieldVariables] = file.VariablesFile
 		properties[FileFieldModifyTime] = time.Now()
 		properties[FileFieldModifyBy] = file.ModifyBy
 		if err := updateValue(tx, tblConfigFile, key, properties); err != nil {
@@ -0,0 +1,291 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package boltdb
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/boltdb/bolt"
+	"go.uber.org/zap"
+
+	"github.com/polarismesh/polaris-server/common/model"
+	"github.com/polarismesh/polaris-server/store"
+)
+
+const (
+	tblConfigFileGrayRelease     string = "ConfigFileGrayRelease"
+	tblConfigFileGrayReleaseID   string = "ConfigFileGrayReleaseID"
+	FileGrayReleaseFieldGrayRule string = "GrayRule"
+)
+
+// grayReleaseObject 灰度发布的存储对象，灰度规则以 json 格式保存
+type grayReleaseObject struct {
+	Id         uint64
+	Name       string
+	Namespace  string
+	Group      string
+	FileName   string
+	Content    string
+	Comment    string
+	Md5        string
+	Version    uint64
+	GrayRule   string
+	Flag       int
+	CreateTime time.Time
+	CreateBy   string
+	ModifyTime time.Time
+	ModifyBy   string
+	Valid      bool
+}
+
+type configFileGrayReleaseStore struct {
+	id      uint64
+	handler BoltHandler
+}
+
+func newConfigFileGrayReleaseStore(handler BoltHandler) (*configFileGrayReleaseStore, error) {
+	s := &configFileGrayReleaseStore{handler: handler, id: 0}
+	ret, err := handler.LoadValues(tblConfigFileGrayReleaseID, []string{tblConfigFileGrayReleaseID}, &IDHolder{})
+	if err != nil {
+		return nil, err
+	}
+	if len(ret) == 0 {
+		return s, nil
+	}
+	val := ret[tblConfigFileGrayReleaseID].(*IDHolder)
+	s.id = val.ID
+	return s, nil
+}
+
+// CreateConfigFileGrayRelease 新建配置文件灰度发布
+func (cfr *configFileGrayReleaseStore) CreateConfigFileGrayRelease(proxyTx store.Tx,
+	fileRelease *model.ConfigFileRelease) (*model.ConfigFileRelease, error) {
+	ret, err := DoTransactionIfNeed(proxyTx, cfr.handler, func(tx *bolt.Tx) ([]interface{}, error) {
+		cfr.id++
+		fileRelease.Id = cfr.id
+		fileRelease.Valid = true
+
+		if err := saveValue(tx, tblConfigFileGrayReleaseID, tblConfigFileGrayReleaseID, &IDHolder{
+			ID: cfr.id,
+		}); err != nil {
+			log.Error("[ConfigFileGrayRelease] save auto_increment id", zap.Error(err))
+			return nil, err
+		}
+
+		tn := time.Now()
+		fileRelease.CreateTime = tn
+		fileRelease.ModifyTime = tn
+		object, err := convertToGrayReleaseObject(fileRelease)
+		if err != nil {
+			return nil, err
+		}
+		key := grayReleaseKey(fileRelease.Namespace, fileRelease.Group, fileRelease.FileName)
+		if err := saveValue(tx, tblConfigFileGrayRelease, key, object); err != nil {
+			log.Error("[ConfigFileGrayRelease] save info", zap.Error(err))
+			return nil, err
+		}
+
+		return cfr.getConfigFileGrayReleaseByFlag(tx, fileRelease.Namespace, fileRelease.Group,
+			fileRelease.FileName, false)
+	})
+	return firstGrayRelease(ret, err)
+}
+
+// UpdateConfigFileGrayRelease 更新配置文件灰度发布，被删除的灰度发布会重新生效
+func (cfr *configFileGrayReleaseStore) UpdateConfigFileGrayRelease(proxyTx store.Tx,
+	fileRelease *model.ConfigFileRelease) (*model.ConfigFileRelease, error) {
+	ret, err := DoTransactionIfNeed(proxyTx, cfr.handler, func(tx *bolt.Tx) ([]interface{}, error) {
+		rule, err := json.Marshal(fileRelease.GrayRule)
+		if err != nil {
+			return nil, err
+		}
+
+		properties := make(map[string]interface{})
+		properties[FileReleaseFieldName] = fileRelease.Name
+		properties[FileReleaseFieldContent] = fileRelease.Content
+		properties[FileReleaseFieldComment] = fileRelease.Comment
+		properties[FileReleaseFieldMd5] = fileRelease.Md5
+		properties[FileReleaseFieldVersion] = fileRelease.Version
+		properties[FileGrayReleaseFieldGrayRule] = string(rule)
+		properties[FileReleaseFieldValid] = true
+		properties[FileReleaseFieldFlag] = 0
+		properties[FileReleaseFieldModifyTime] = time.Now()
+		properties[FileReleaseFieldModifyBy] = fileRelease.ModifyBy
+
+		key := grayReleaseKey(fileRelease.Namespace, fileRelease.Group, fileRelease.FileName)
+		if err := updateValue(tx, tblConfigFileGrayRelease, key, properties); err != nil {
+			log.Error("[ConfigFileGrayRelease] update info", zap.Error(err))
+			return nil, err
+		}
+
+		return cfr.getConfigFileGrayReleaseByFlag(tx, fileRelease.Namespace, fileRelease.Group,
+			fileRelease.FileName, false)
+	})
+	return firstGrayRelease(ret, err)
+}
+
+// GetConfigFileGrayRelease 获取配置文件灰度发布，只返回 flag=0 的记录
+func (cfr *configFileGrayReleaseStore) GetConfigFileGrayRelease(proxyTx store.Tx,
+	namespace, group, fileName string) (*model.ConfigFileRelease, error) {
+	ret, err := DoTransactionIfNeed(proxyTx, cfr.handler, func(tx *bolt.Tx) ([]interface{}, error) {
+		return cfr.getConfigFileGrayReleaseByFlag(tx, namespace, group, fileName, false)
+	})
+	return firstGrayRelease(ret, err)
+}
+
+// GetConfigFileGrayReleaseWithAllFlag 获取配置文件灰度发布，包含已经删除的记录
+func (cfr *configFileGrayReleaseStore) GetConfigFileGrayReleaseWithAllFlag(proxyTx store.Tx,
+	namespace, group, fileName string) (*model.ConfigFileRelease, error) {
+	ret, err := DoTransactionIfNeed(proxyTx, cfr.handler, func(tx *bolt.Tx) ([]interface{}, error) {
+		return cfr.getConfigFileGrayReleaseByFlag(tx, namespace, group, fileName, true)
+	})
+	return firstGrayRelease(ret, err)
+}
+
+func (cfr *configFileGrayReleaseStore) getConfigFileGrayReleaseByFlag(tx *bolt.Tx, namespace, group, fileName string,
+	withAllFlag bool) ([]interface{}, error) {
+	key := grayReleaseKey(namespace, group, fileName)
+	ret := make(map[string]interface{})
+	if err := loadValues(tx, tblConfigFileGrayRelease, []string{key}, &grayReleaseObject{}, ret); err != nil {
+		return nil, err
+	}
+	object, ok := ret[key]
+	if !ok {
+		return nil, nil
+	}
+	release, err := convertToGrayRelease(object.(*grayReleaseObject))
+	if err != nil {
+		return nil, err
+	}
+	if !withAllFlag && !release.Valid {
+		return nil, nil
+	}
+	return []interface{}{release}, nil
+}
+
+// DeleteConfigFileGrayRelease 删除配置文件灰度发布，保留灰度规则，便于通知命中规则的客户端
+func (cfr *configFileGrayReleaseStore) DeleteConfigFileGrayRelease(proxyTx store.Tx, namespace, group, fileName,
+	deleteBy string) error {
+	_, err := DoTransactionIfNeed(proxyTx, cfr.handler, func(tx *bolt.Tx) ([]interface{}, error) {
+		properties := make(map[string]interface{})
+		properties[FileReleaseFieldValid] = false
+		properties[FileReleaseFieldFlag] = 1
+		properties[FileReleaseFieldModifyTime] = time.Now()
+		properties[FileReleaseFieldModifyBy] = deleteBy
+
+		key := grayReleaseKey(namespace, group, fileName)
+		if err := updateValue(tx, tblConfigFileGrayRelease, key, properties); err != nil {
+			log.Error("[ConfigFileGrayRelease] delete info", zap.Error(err))
+			return nil, err
+		}
+		return nil, nil
+	})
+	return err
+}
+
+// FindConfigFileGrayReleaseByModifyTimeAfter 获取最后更新时间大于某个时间点的灰度发布，包含 flag = 1 的记录
+func (cfr *configFileGrayReleaseStore) FindConfigFileGrayReleaseByModifyTimeAfter(
+	modifyTime time.Time) ([]*model.ConfigFileRelease, error) {
+	fields := []string{FileReleaseFieldModifyTime}
+	ret, err := cfr.handler.LoadValuesByFilter(tblConfigFileGrayRelease, fields, &grayReleaseObject{},
+		func(m map[string]interface{}) bool {
+			saveMt, _ := m[FileReleaseFieldModifyTime].(time.Time)
+			return saveMt.After(modifyTime)
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	releases := make([]*model.ConfigFileRelease, 0, len(ret))
+	for _, v := range ret {
+		release, err := convertToGrayRelease(v.(*grayReleaseObject))
+		if err != nil {
+			return nil, err
+		}
+		releases = append(releases, release)
+	}
+	return releases, nil
+}
+
+func grayReleaseKey(namespace, group, fileName string) string {
+	return fmt.Sprintf("%s@@%s@@%s", namespace, group, fileName)
+}
+
+func firstGrayRelease(ret []interface{}, err error) (*model.ConfigFileRelease, error) {
+	if err != nil {
+		return nil, err
+	}
+	if len(ret) == 0 {
+		return nil, nil
+	}
+	return ret[0].(*model.ConfigFileRelease), nil
+}
+
+func convertToGrayReleaseObject(release *model.ConfigFileRelease) (*grayReleaseObject, error) {
+	rule, err := json.Marshal(release.GrayRule)
+	if err != nil {
+		return nil, err
+	}
+	return &grayReleaseObject{
+		Id:         release.Id,
+		Name:       release.Name,
+		Namespace:  release.Namespace,
+		Group:      release.Group,
+		FileName:   release.FileName,
+		Content:    release.Content,
+		Comment:    release.Comment,
+		Md5:        release.Md5,
+		Version:    release.Version,
+		GrayRule:   string(rule),
+		Flag:       release.Flag,
+		CreateTime: release.CreateTime,
+		CreateBy:   release.CreateBy,
+		ModifyTime: release.ModifyTime,
+		ModifyBy:   release.ModifyBy,
+		Valid:      release.Valid,
+	}, nil
+}
+
+func convertToGrayRelease(object *grayReleaseObject) (*model.ConfigFileRelease, error) {
+	rule := &model.ConfigFileGrayRule{}
+	if object.GrayRule != "" {
+		if err := json.Unmarshal([]byte(object.GrayRule), rule); err != nil {
+			return nil, err
+		}
+	}
+	return &model.ConfigFileRelease{
+		Id:         object.Id,
+		Name:       object.Name,
+		Namespace:  object.Namespace,
+		Group:      object.Group,
+		FileName:   object.FileName,
+		Content:    object.Content,
+		Comment:    object.Comment,
+		Md5:        object.Md5,
+		Version:    object.Version,
+		GrayRule:   rule,
+		Flag:       object.Flag,
+		CreateTime: object.CreateTime,
+		CreateBy:   object.CreateBy,
+		ModifyTime: object.ModifyTime,
+		ModifyBy:   object.ModifyBy,
+		Valid:      object.Valid,
+	}, nil
+}
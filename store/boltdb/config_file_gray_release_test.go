@@ -0,0 +1,77 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package boltdb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/polarismesh/polaris-server/common/model"
+)
+
+func Test_configFileGrayReleaseStore(t *testing.T) {
+	CreateTableDBHandlerAndRun(t, tblConfigFileGrayRelease, func(t *testing.T, handler BoltHandler) {
+		s, err := newConfigFileGrayReleaseStore(handler)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		before := time.Now().Add(-time.Second)
+		rule := &model.ConfigFileGrayRule{ClientIPs: []string{"127.0.0.1"}, ClientLabels: map[string]string{"env": "gray"}}
+		created, err := s.CreateConfigFileGrayRelease(nil, &model.ConfigFileRelease{Name: "gray", Namespace: "default",
+			Group: "default", FileName: "app.yaml", Content: "gray", Md5: "md5", Version: 2, GrayRule: rule})
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, uint64(2), created.Version)
+		assert.Equal(t, rule, created.GrayRule)
+
+		if err := s.DeleteConfigFileGrayRelease(nil, "default", "default", "app.yaml", "polaris"); err != nil {
+			t.Fatal(err)
+		}
+		release, err := s.GetConfigFileGrayRelease(nil, "default", "default", "app.yaml")
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Nil(t, release)
+		release, err = s.GetConfigFileGrayReleaseWithAllFlag(nil, "default", "default", "app.yaml")
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, 1, release.Flag)
+		// 删除后仍然保留灰度规则
+		assert.Equal(t, rule, release.GrayRule)
+
+		release.Version = 3
+		release.GrayRule = &model.ConfigFileGrayRule{ClientIPs: []string{"127.0.0.2"}}
+		updated, err := s.UpdateConfigFileGrayRelease(nil, release)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, uint64(3), updated.Version)
+		assert.Equal(t, []string{"127.0.0.2"}, updated.GrayRule.ClientIPs)
+
+		releases, err := s.FindConfigFileGrayReleaseByModifyTimeAfter(before)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, 1, len(releases))
+	})
+}
@@ -39,6 +39,7 @@ const (
 	FileGroupFieldName       string = "Name"
 	FileGroupFieldNamespace  string = "Namespace"
 	FileGroupFieldComment    string = "Comment"
+	FileGroupFieldJsonSchema string = "JsonSchema"
 	FileGroupFieldCreateBy   string = "CreateBy"
 	FileGroupFieldModifyBy   string = "ModifyBy"
 	FileGroupFieldCreateTime string = "CreateTime"
@@ -228,6 +229,7 @@ func (fg *configFileGroupStore) UpdateConfigFileGroup(fileGroup *model.ConfigFil
 	key := fmt.Sprintf("%s@@%s", fileGroup.Namespace, fileGroup.Name)
 	properties := make(map[string]interface{})
 	properties[FileGroupFieldComment] = fileGroup.Comment
+	properties[FileGroupFieldJsonSchema] = fileGroup.JsonSchema
 	properties[FileGroupFieldModifyBy] = fileGroup.ModifyBy
 	properties[FileGroupFieldModifyTime] = time.Now()
 
@@ -0,0 +1,129 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package boltdb
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/boltdb/bolt"
+	"go.uber.org/zap"
+
+	"github.com/polarismesh/polaris-server/common/model"
+	"github.com/polarismesh/polaris-server/store"
+)
+
+const (
+	tblConfigFileListener string = "ConfigFileListener"
+
+	FileListenerFieldNamespace  string = "Namespace"
+	FileListenerFieldGroup      string = "Group"
+	FileListenerFieldFileName   string = "FileName"
+	FileListenerFieldModifyTime string = "ModifyTime"
+)
+
+type configFileListenerStore struct {
+	handler BoltHandler
+}
+
+func newConfigFileListenerStore(handler BoltHandler) (*configFileListenerStore, error) {
+	return &configFileListenerStore{handler: handler}, nil
+}
+
+// UpsertConfigFileListeners 批量新增或者更新配置文件订阅者
+func (ls *configFileListenerStore) UpsertConfigFileListeners(listeners []*model.ConfigFileListener) error {
+	if len(listeners) == 0 {
+		return nil
+	}
+	err := ls.handler.Execute(true, func(tx *bolt.Tx) error {
+		tn := time.Now()
+		for _, listener := range listeners {
+			listener.ModifyTime = tn
+			if err := saveValue(tx, tblConfigFileListener, listenerKey(listener), listener); err != nil {
+				log.Error("[ConfigFileListener] save info", zap.Error(err))
+				return err
+			}
+		}
+		return nil
+	})
+	return store.Error(err)
+}
+
+// QueryConfigFileListeners 翻页查询配置文件订阅者，按照 namespace、group、file_name 精确匹配
+func (ls *configFileListenerStore) QueryConfigFileListeners(namespace, group, fileName string,
+	offset, limit uint32) (uint32, []*model.ConfigFileListener, error) {
+	fields := []string{FileListenerFieldNamespace, FileListenerFieldGroup, FileListenerFieldFileName}
+	ret, err := ls.handler.LoadValuesByFilter(tblConfigFileListener, fields, &model.ConfigFileListener{},
+		func(m map[string]interface{}) bool {
+			saveNs, _ := m[FileListenerFieldNamespace].(string)
+			saveGroup, _ := m[FileListenerFieldGroup].(string)
+			saveFileName, _ := m[FileListenerFieldFileName].(string)
+			return (namespace == "" || namespace == saveNs) && (group == "" || group == saveGroup) &&
+				(fileName == "" || fileName == saveFileName)
+		})
+	if err != nil {
+		return 0, nil, err
+	}
+
+	keys := make([]string, 0, len(ret))
+	for k := range ret {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	total := uint32(len(keys))
+	if offset >= total {
+		return total, nil, nil
+	}
+	end := offset + limit
+	if end > total {
+		end = total
+	}
+	listeners := make([]*model.ConfigFileListener, 0, end-offset)
+	for _, k := range keys[offset:end] {
+		listeners = append(listeners, ret[k].(*model.ConfigFileListener))
+	}
+	return total, listeners, nil
+}
+
+// DeleteExpiredConfigFileListeners 删除超过一段时间未上报的订阅者
+func (ls *configFileListenerStore) DeleteExpiredConfigFileListeners(modifyTime time.Time) error {
+	fields := []string{FileListenerFieldModifyTime}
+	ret, err := ls.handler.LoadValuesByFilter(tblConfigFileListener, fields, &model.ConfigFileListener{},
+		func(m map[string]interface{}) bool {
+			saveMt, _ := m[FileListenerFieldModifyTime].(time.Time)
+			return saveMt.Before(modifyTime)
+		})
+	if err != nil {
+		return err
+	}
+	if len(ret) == 0 {
+		return nil
+	}
+
+	keys := make([]string, 0, len(ret))
+	for k := range ret {
+		keys = append(keys, k)
+	}
+	return ls.handler.DeleteValues(tblConfigFileListener, keys, false)
+}
+
+func listenerKey(listener *model.ConfigFileListener) string {
+	return fmt.Sprintf("%s@@%s@@%s@@%s", listener.Namespace, listener.Group, listener.FileName, listener.ClientId)
+}
@@ -0,0 +1,77 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package boltdb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/polarismesh/polaris-server/common/model"
+)
+
+func Test_configFileListenerStore(t *testing.T) {
+	CreateTableDBHandlerAndRun(t, tblConfigFileListener, func(t *testing.T, handler BoltHandler) {
+		s, err := newConfigFileListenerStore(handler)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		listeners := []*model.ConfigFileListener{
+			{ClientId: "c1", ClientIP: "127.0.0.1", Namespace: "default", Group: "default", FileName: "app.yaml",
+				Version: 1, LastPollTime: time.Now(), Server: "127.0.0.10"},
+			{ClientId: "c2", ClientIP: "127.0.0.2", Namespace: "default", Group: "default", FileName: "app.yaml",
+				Version: 2, LastPollTime: time.Now(), Server: "127.0.0.11"},
+			{ClientId: "c1", ClientIP: "127.0.0.1", Namespace: "default", Group: "default", FileName: "db.yaml",
+				Version: 1, LastPollTime: time.Now(), Server: "127.0.0.10"},
+		}
+		if err := s.UpsertConfigFileListeners(listeners); err != nil {
+			t.Fatal(err)
+		}
+		// 重复上报覆盖客户端版本
+		listeners[0].Version = 2
+		if err := s.UpsertConfigFileListeners(listeners[:1]); err != nil {
+			t.Fatal(err)
+		}
+
+		total, ret, err := s.QueryConfigFileListeners("default", "default", "app.yaml", 0, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, uint32(2), total)
+		assert.Equal(t, 1, len(ret))
+		assert.Equal(t, "c1", ret[0].ClientId)
+		assert.Equal(t, uint64(2), ret[0].Version)
+
+		total, _, err = s.QueryConfigFileListeners("", "", "", 0, 10)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, uint32(3), total)
+
+		if err := s.DeleteExpiredConfigFileListeners(time.Now().Add(time.Second)); err != nil {
+			t.Fatal(err)
+		}
+		total, _, err = s.QueryConfigFileListeners("", "", "", 0, 10)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, uint32(0), total)
+	})
+}
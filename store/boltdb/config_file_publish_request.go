@@ -0,0 +1,266 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package boltdb
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/boltdb/bolt"
+	"go.uber.org/zap"
+
+	"github.com/polarismesh/polaris-server/common/model"
+	"github.com/polarismesh/polaris-server/common/utils"
+	"github.com/polarismesh/polaris-server/store"
+)
+
+const (
+	tblConfigFilePublishRequest string = "ConfigFilePublishRequest"
+
+	FilePublishRequestFieldNamespace string = "Namespace"
+	FilePublishRequestFieldGroup     string = "Group"
+	FilePublishRequestFieldFileName  string = "FileName"
+	FilePublishRequestFieldStatus    string = "Status"
+	FilePublishRequestFieldPlanTime  string = "PlanTime"
+)
+
+// publishRequestObject 配置发布申请的存储对象，审批人以逗号分隔保存，
+// 计划发布、审批和发布时间可能为空，保存为秒级时间戳，0 表示为空
+type publishRequestObject struct {
+	Id             string
+	Namespace      string
+	Group          string
+	FileName       string
+	Comment        string
+	Status         string
+	ApproveUsers   string
+	ApproveGroups  string
+	PlanTime       int64
+	ApproveBy      string
+	ApproveComment string
+	ApproveTime    int64
+	ApproveMd5     string
+	PublishTime    int64
+	FailReason     string
+	CreateTime     time.Time
+	CreateBy       string
+	ModifyTime     time.Time
+	ModifyBy       string
+}
+
+type configFilePublishRequestStore struct {
+	handler BoltHandler
+}
+
+func newConfigFilePublishRequestStore(handler BoltHandler) (*configFilePublishRequestStore, error) {
+	return &configFilePublishRequestStore{handler: handler}, nil
+}
+
+// CreateConfigFilePublishRequest 创建配置发布申请
+func (ps *configFilePublishRequestStore) CreateConfigFilePublishRequest(
+	request *model.ConfigFilePublishRequest) error {
+	tn := time.Now()
+	request.CreateTime = tn
+	request.ModifyTime = tn
+	if err := ps.handler.SaveValue(tblConfigFilePublishRequest, request.Id,
+		convertToPublishRequestObject(request)); err != nil {
+		log.Error("[ConfigFilePublishRequest] save info", zap.Error(err))
+		return store.Error(err)
+	}
+	return nil
+}
+
+// GetConfigFilePublishRequest 根据 id 获取配置发布申请
+func (ps *configFilePublishRequestStore) GetConfigFilePublishRequest(
+	id string) (*model.ConfigFilePublishRequest, error) {
+	ret, err := ps.handler.LoadValues(tblConfigFilePublishRequest, []string{id}, &publishRequestObject{})
+	if err != nil {
+		return nil, err
+	}
+	if len(ret) == 0 {
+		return nil, nil
+	}
+	return convertToPublishRequest(ret[id].(*publishRequestObject)), nil
+}
+
+// QueryConfigFilePublishRequests 翻页查询配置发布申请，按照创建时间倒序排列
+func (ps *configFilePublishRequestStore) QueryConfigFilePublishRequests(namespace, group, fileName,
+	status string, offset, limit uint32) (uint32, []*model.ConfigFilePublishRequest, error) {
+	fields := []string{FilePublishRequestFieldNamespace, FilePublishRequestFieldGroup,
+		FilePublishRequestFieldFileName, FilePublishRequestFieldStatus}
+	ret, err := ps.handler.LoadValuesByFilter(tblConfigFilePublishRequest, fields, &publishRequestObject{},
+		func(m map[string]interface{}) bool {
+			saveNs, _ := m[FilePublishRequestFieldNamespace].(string)
+			saveGroup, _ := m[FilePublishRequestFieldGroup].(string)
+			saveFileName, _ := m[FilePublishRequestFieldFileName].(string)
+			saveStatus, _ := m[FilePublishRequestFieldStatus].(string)
+			return (namespace == "" || namespace == saveNs) && (group == "" || group == saveGroup) &&
+				(fileName == "" || fileName == saveFileName) && (status == "" || status == saveStatus)
+		})
+	if err != nil {
+		return 0, nil, err
+	}
+
+	requests := make([]*model.ConfigFilePublishRequest, 0, len(ret))
+	for _, v := range ret {
+		requests = append(requests, convertToPublishRequest(v.(*publishRequestObject)))
+	}
+	sort.Slice(requests, func(i, j int) bool {
+		if requests[i].CreateTime.Equal(requests[j].CreateTime) {
+			return requests[i].Id > requests[j].Id
+		}
+		return requests[i].CreateTime.After(requests[j].CreateTime)
+	})
+
+	total := uint32(len(requests))
+	if offset >= total {
+		return total, nil, nil
+	}
+	end := offset + limit
+	if end > total {
+		end = total
+	}
+	return total, requests[offset:end], nil
+}
+
+// UpdateConfigFilePublishRequest 在同一个事务内比较状态并更新配置发布申请
+func (ps *configFilePublishRequestStore) UpdateConfigFilePublishRequest(request *model.ConfigFilePublishRequest,
+	fromStatus string) (bool, error) {
+	updated := false
+	err := ps.handler.Execute(true, func(tx *bolt.Tx) error {
+		values := make(map[string]interface{})
+		if err := loadValues(tx, tblConfigFilePublishRequest, []string{request.Id}, &publishRequestObject{},
+			values); err != nil {
+			return err
+		}
+		saved, ok := values[request.Id]
+		if !ok || saved.(*publishRequestObject).Status != fromStatus {
+			return nil
+		}
+
+		object := convertToPublishRequestObject(request)
+		object.CreateTime = saved.(*publishRequestObject).CreateTime
+		object.CreateBy = saved.(*publishRequestObject).CreateBy
+		object.ModifyTime = time.Now()
+		if err := saveValue(tx, tblConfigFilePublishRequest, request.Id, object); err != nil {
+			log.Error("[ConfigFilePublishRequest] update info", zap.Error(err))
+			return err
+		}
+		request.ModifyTime = object.ModifyTime
+		updated = true
+		return nil
+	})
+	if err != nil {
+		return false, store.Error(err)
+	}
+	return updated, nil
+}
+
+// FindDueConfigFilePublishRequests 获取到达计划发布时间的待调度发布申请
+func (ps *configFilePublishRequestStore) FindDueConfigFilePublishRequests(
+	planTime time.Time) ([]*model.ConfigFilePublishRequest, error) {
+	fields := []string{FilePublishRequestFieldStatus, FilePublishRequestFieldPlanTime}
+	ret, err := ps.handler.LoadValuesByFilter(tblConfigFilePublishRequest, fields, &publishRequestObject{},
+		func(m map[string]interface{}) bool {
+			saveStatus, _ := m[FilePublishRequestFieldStatus].(string)
+			savePlanTime, _ := m[FilePublishRequestFieldPlanTime].(int64)
+			return saveStatus == utils.PublishRequestStatusScheduled && savePlanTime <= planTime.Unix()
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	requests := make([]*model.ConfigFilePublishRequest, 0, len(ret))
+	for _, v := range ret {
+		requests = append(requests, convertToPublishRequest(v.(*publishRequestObject)))
+	}
+	sort.Slice(requests, func(i, j int) bool {
+		return requests[i].PlanTime.Before(requests[j].PlanTime)
+	})
+	return requests, nil
+}
+
+func convertToPublishRequestObject(request *model.ConfigFilePublishRequest) *publishRequestObject {
+	return &publishRequestObject{
+		Id:             request.Id,
+		Namespace:      request.Namespace,
+		Group:          request.Group,
+		FileName:       request.FileName,
+		Comment:        request.Comment,
+		Status:         request.Status,
+		ApproveUsers:   strings.Join(request.ApproveUsers, ","),
+		ApproveGroups:  strings.Join(request.ApproveGroups, ","),
+		PlanTime:       toUnixSeconds(request.PlanTime),
+		ApproveBy:      request.ApproveBy,
+		ApproveComment: request.ApproveComment,
+		ApproveTime:    toUnixSeconds(request.ApproveTime),
+		ApproveMd5:     request.ApproveMd5,
+		PublishTime:    toUnixSeconds(request.PublishTime),
+		FailReason:     request.FailReason,
+		CreateTime:     request.CreateTime,
+		CreateBy:       request.CreateBy,
+		ModifyTime:     request.ModifyTime,
+		ModifyBy:       request.ModifyBy,
+	}
+}
+
+func convertToPublishRequest(object *publishRequestObject) *model.ConfigFilePublishRequest {
+	return &model.ConfigFilePublishRequest{
+		Id:             object.Id,
+		Namespace:      object.Namespace,
+		Group:          object.Group,
+		FileName:       object.FileName,
+		Comment:        object.Comment,
+		Status:         object.Status,
+		ApproveUsers:   splitApprovers(object.ApproveUsers),
+		ApproveGroups:  splitApprovers(object.ApproveGroups),
+		PlanTime:       fromUnixSeconds(object.PlanTime),
+		ApproveBy:      object.ApproveBy,
+		ApproveComment: object.ApproveComment,
+		ApproveTime:    fromUnixSeconds(object.ApproveTime),
+		ApproveMd5:     object.ApproveMd5,
+		PublishTime:    fromUnixSeconds(object.PublishTime),
+		FailReason:     object.FailReason,
+		CreateTime:     object.CreateTime,
+		CreateBy:       object.CreateBy,
+		ModifyTime:     object.ModifyTime,
+		ModifyBy:       object.ModifyBy,
+	}
+}
+
+func splitApprovers(approvers string) []string {
+	if approvers == "" {
+		return nil
+	}
+	return strings.Split(approvers, ",")
+}
+
+func toUnixSeconds(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.Unix()
+}
+
+func fromUnixSeconds(sec int64) time.Time {
+	if sec == 0 {
+		return time.Time{}
+	}
+	return time.Unix(sec, 0)
+}
@@ -0,0 +1,103 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package boltdb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/polarismesh/polaris-server/common/model"
+	"github.com/polarismesh/polaris-server/common/utils"
+)
+
+func Test_configFilePublishRequestStore(t *testing.T) {
+	CreateTableDBHandlerAndRun(t, tblConfigFilePublishRequest, func(t *testing.T, handler BoltHandler) {
+		s, err := newConfigFilePublishRequestStore(handler)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		planTime := time.Now().Add(time.Minute)
+		request := &model.ConfigFilePublishRequest{Id: "r1", Namespace: "default", Group: "default",
+			FileName: "app.yaml", Status: utils.PublishRequestStatusPending, ApproveUsers: []string{"u1", "u2"},
+			ApproveGroups: []string{"g1"}, PlanTime: planTime, CreateBy: "polaris", ModifyBy: "polaris"}
+		if err := s.CreateConfigFilePublishRequest(request); err != nil {
+			t.Fatal(err)
+		}
+		if err := s.CreateConfigFilePublishRequest(&model.ConfigFilePublishRequest{Id: "r2", Namespace: "default",
+			Group: "default", FileName: "db.yaml", Status: utils.PublishRequestStatusDraft,
+			ApproveUsers: []string{"u1"}}); err != nil {
+			t.Fatal(err)
+		}
+
+		saved, err := s.GetConfigFilePublishRequest("r1")
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, []string{"u1", "u2"}, saved.ApproveUsers)
+		assert.Equal(t, []string{"g1"}, saved.ApproveGroups)
+		assert.Equal(t, planTime.Unix(), saved.PlanTime.Unix())
+		assert.True(t, saved.ApproveTime.IsZero())
+
+		// 状态不匹配时不更新
+		saved.Status = utils.PublishRequestStatusScheduled
+		saved.ApproveBy = "u1"
+		saved.ApproveTime = time.Now()
+		saved.ApproveMd5 = "md5"
+		updated, err := s.UpdateConfigFilePublishRequest(saved, utils.PublishRequestStatusDraft)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.False(t, updated)
+		updated, err = s.UpdateConfigFilePublishRequest(saved, utils.PublishRequestStatusPending)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.True(t, updated)
+
+		due, err := s.FindDueConfigFilePublishRequests(time.Now())
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, 0, len(due))
+		due, err = s.FindDueConfigFilePublishRequests(planTime)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, 1, len(due))
+		assert.Equal(t, "u1", due[0].ApproveBy)
+		assert.Equal(t, "md5", due[0].ApproveMd5)
+		assert.Equal(t, "polaris", due[0].CreateBy)
+
+		total, ret, err := s.QueryConfigFilePublishRequests("default", "", "", "", 0, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, uint32(2), total)
+		assert.Equal(t, 1, len(ret))
+		total, ret, err = s.QueryConfigFilePublishRequests("default", "default", "db.yaml",
+			utils.PublishRequestStatusDraft, 0, 10)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, uint32(1), total)
+		assert.Equal(t, "r2", ret[0].Id)
+	})
+}
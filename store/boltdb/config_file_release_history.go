@@ -178,6 +178,24 @@ func (rh *configFileReleaseHistoryStore) GetLatestConfigFileReleaseHistory(names
 	return histories[0], nil
 }
 
+// GetConfigFileReleaseHistory 根据 id 获取配置文件发布历史记录
+func (rh *configFileReleaseHistoryStore) GetConfigFileReleaseHistory(id uint64) (*model.ConfigFileReleaseHistory, error) {
+	key := strconv.FormatUint(id, 10)
+	ret, err := rh.handler.LoadValues(tblConfigFileReleaseHistory, []string{key}, &model.ConfigFileReleaseHistory{})
+	if err != nil {
+		return nil, err
+	}
+	if len(ret) == 0 {
+		return nil, nil
+	}
+
+	history := ret[key].(*model.ConfigFileReleaseHistory)
+	if !history.Valid {
+		return nil, nil
+	}
+	return history, nil
+}
+
 // doConfigFileGroupPage 进行分页
 func doConfigFileHistoryPage(ret map[string]interface{}, offset, limit uint32) []*model.ConfigFileReleaseHistory {
 
@@ -61,8 +61,17 @@ type boltStore struct {
 	*configFileGroupStore
 	*configFileStore
 	*configFileReleaseStore
+	*configFileGrayReleaseStore
 	*configFileReleaseHistoryStore
 	*configFileTagStore
+	*configFileListenerStore
+	*configFilePublishRequestStore
+
+	// 服务和实例的变更日志
+	*changeLogStore
+
+	// 集群事件
+	*clusterEventStore
 
 	handler BoltHandler
 	start   bool
@@ -85,6 +94,11 @@ func (m *boltStore) Initialize(c *store.Config) error {
 		return err
 	}
 	m.handler = handler
+	m.changeLogStore, err = newChangeLogStore(handler, boltConfig.ChangeLog, boltConfig.ChangeLogRetention)
+	if err != nil {
+		_ = handler.Close()
+		return err
+	}
 	if err = m.newStore(); err != nil {
 		_ = handler.Close()
 		return err
@@ -320,11 +334,31 @@ func (m *boltStore) newConfigModuleStore() error {
 		return err
 	}
 
+	m.configFileGrayReleaseStore, err = newConfigFileGrayReleaseStore(m.handler)
+	if err != nil {
+		return err
+	}
+
+	m.configFileListenerStore, err = newConfigFileListenerStore(m.handler)
+	if err != nil {
+		return err
+	}
+
+	m.configFilePublishRequestStore, err = newConfigFilePublishRequestStore(m.handler)
+	if err != nil {
+		return err
+	}
+
+	m.clusterEventStore = &clusterEventStore{handler: m.handler}
+
 	return nil
 }
 
 // Destroy store
 func (m *boltStore) Destroy() error {
+	if m.changeLogStore != nil {
+		m.changeLogStore.stop()
+	}
 	if m.handler != nil {
 		return m.handler.Close()
 	}
@@ -344,4 +378,7 @@ func (m *boltStore) StartTx() (store.Tx, error) {
 func init() {
 	s := &boltStore{}
 	_ = store.RegisterStore(s)
+	_ = store.RegisterStoreCreator(s.Name(), func() store.Store {
+		return &boltStore{}
+	})
 }
@@ -84,11 +84,17 @@ type BoltHandler interface {
 type BoltConfig struct {
 	// FileName boltdb store file
 	FileName string
+	// ChangeLog record the changes of services and instances
+	ChangeLog bool
+	// ChangeLogRetention retention of the change log
+	ChangeLogRetention time.Duration
 }
 
 const (
-	confPath    = "path"
-	defaultPath = "./polaris.bolt"
+	confPath               = "path"
+	confChangeLog          = "changeLog"
+	confChangeLogRetention = "changeLogRetention"
+	defaultPath            = "./polaris.bolt"
 )
 
 // Parse parse yaml config
@@ -98,6 +104,11 @@ func (c *BoltConfig) Parse(opt map[string]interface{}) {
 	} else {
 		c.FileName = defaultPath
 	}
+	c.ChangeLog, _ = opt[confChangeLog].(bool)
+	c.ChangeLogRetention = defaultChangeLogRetention
+	if seconds, _ := opt[confChangeLogRetention].(int); seconds > 0 {
+		c.ChangeLogRetention = time.Duration(seconds) * time.Second
+	}
 }
 
 const (
@@ -171,7 +182,10 @@ func saveValue(tx *bolt.Tx, typ string, key string, value interface{}) error {
 		}
 		bucket.Put([]byte(toBucketField(DataValidFieldName)), encodeBoolBuffer(true))
 	}
-	return err
+	if err != nil {
+		return err
+	}
+	return recordChange(tx, typ, key)
 }
 
 // LoadValues load data objects by unique keys, return value is 'key->object' map
@@ -413,6 +427,9 @@ func deleteValues(tx *bolt.Tx, typ string, keys []string, logicDelete bool) erro
 					return err
 				}
 			}
+			if err := recordChange(tx, typ, key); err != nil {
+				return err
+			}
 		}
 	}
 	return nil
@@ -558,7 +575,7 @@ func updateValue(tx *bolt.Tx, typ string, key string, properties map[string]inte
 			return err
 		}
 	}
-	return nil
+	return recordChange(tx, typ, key)
 }
 
 // LoadValuesAll load all saved data objects, return value is 'key->object' map
@@ -48,7 +48,7 @@ func CreateTableDBHandlerAndRun(t *testing.T, tableName string, tf func(t *testi
 }
 
 func TestBoltHandler_SaveNamespace(t *testing.T) {
-	handler, err := NewBoltHandler(&BoltConfig{FileName: "./table.bolt"})
+	handler, err := NewBoltHandler(&BoltConfig{FileName: testBoltFile})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -69,7 +69,7 @@ func TestBoltHandler_SaveNamespace(t *testing.T) {
 }
 
 func TestBoltHandler_LoadNamespace(t *testing.T) {
-	handler, err := NewBoltHandler(&BoltConfig{FileName: "./table.bolt"})
+	handler, err := NewBoltHandler(&BoltConfig{FileName: testBoltFile})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -96,7 +96,7 @@ func TestBoltHandler_LoadNamespace(t *testing.T) {
 }
 
 func TestBoltHandler_DeleteNamespace(t *testing.T) {
-	handler, err := NewBoltHandler(&BoltConfig{FileName: "./table.bolt"})
+	handler, err := NewBoltHandler(&BoltConfig{FileName: testBoltFile})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -111,7 +111,7 @@ func TestBoltHandler_DeleteNamespace(t *testing.T) {
 }
 
 func TestBoltHandler_Service(t *testing.T) {
-	handler, err := NewBoltHandler(&BoltConfig{FileName: "./table.bolt"})
+	handler, err := NewBoltHandler(&BoltConfig{FileName: testBoltFile})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -157,7 +157,7 @@ func TestBoltHandler_Service(t *testing.T) {
 }
 
 func TestBoltHandler_Location(t *testing.T) {
-	handler, err := NewBoltHandler(&BoltConfig{FileName: "./table.bolt"})
+	handler, err := NewBoltHandler(&BoltConfig{FileName: testBoltFile})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -198,7 +198,7 @@ const (
 func TestBoltHandler_CountValues(t *testing.T) {
 
 	// 删除之前测试的遗留文件
-	_ = os.RemoveAll("./table.bolt")
+	_ = os.RemoveAll(testBoltFile)
 
 	count := 5
 	var idToServices = make(map[string]*model.Service)
@@ -219,13 +219,13 @@ func TestBoltHandler_CountValues(t *testing.T) {
 		idToServices[svcValue.ID] = svcValue
 		ids = append(ids, svcValue.ID)
 	}
-	handler, err := NewBoltHandler(&BoltConfig{FileName: "./table.bolt"})
+	handler, err := NewBoltHandler(&BoltConfig{FileName: testBoltFile})
 	if err != nil {
 		t.Fatal(err)
 	}
 	defer func() {
 		handler.Close()
-		_ = os.RemoveAll("./table.bolt")
+		_ = os.RemoveAll(testBoltFile)
 	}()
 	for id, svc := range idToServices {
 		err = handler.SaveValue(tblService, id, svc)
@@ -273,13 +273,13 @@ func TestBoltHandler_LoadValuesByFilter(t *testing.T) {
 		idToServices[svcValue.ID] = svcValue
 		ids = append(ids, svcValue.ID)
 	}
-	handler, err := NewBoltHandler(&BoltConfig{FileName: "./table.bolt"})
+	handler, err := NewBoltHandler(&BoltConfig{FileName: testBoltFile})
 	if err != nil {
 		t.Fatal(err)
 	}
 	defer func() {
 		handler.Close()
-		_ = os.RemoveAll("./table.bolt")
+		_ = os.RemoveAll(testBoltFile)
 	}()
 	for id, svc := range idToServices {
 		err = handler.SaveValue(tblService, id, svc)
@@ -324,13 +324,13 @@ func TestBoltHandler_IterateFields(t *testing.T) {
 		idToServices[svcValue.ID] = svcValue
 		ids = append(ids, svcValue.ID)
 	}
-	handler, err := NewBoltHandler(&BoltConfig{FileName: "./table.bolt"})
+	handler, err := NewBoltHandler(&BoltConfig{FileName: testBoltFile})
 	if err != nil {
 		t.Fatal(err)
 	}
 	defer func() {
 		handler.Close()
-		_ = os.RemoveAll("./table.bolt")
+		_ = os.RemoveAll(testBoltFile)
 	}()
 	for id, svc := range idToServices {
 		err = handler.SaveValue(tblService, id, svc)
@@ -374,13 +374,13 @@ func TestBoltHandler_UpdateValue(t *testing.T) {
 		idToServices[svcValue.ID] = svcValue
 		ids = append(ids, svcValue.ID)
 	}
-	handler, err := NewBoltHandler(&BoltConfig{FileName: "./table.bolt"})
+	handler, err := NewBoltHandler(&BoltConfig{FileName: testBoltFile})
 	if err != nil {
 		t.Fatal(err)
 	}
 	defer func() {
 		handler.Close()
-		_ = os.RemoveAll("./table.bolt")
+		_ = os.RemoveAll(testBoltFile)
 	}()
 	for id, svc := range idToServices {
 		err = handler.SaveValue(tblService, id, svc)
@@ -36,13 +36,13 @@ const (
 )
 
 func TestInstanceStore_AddInstance(t *testing.T) {
-	handler, err := NewBoltHandler(&BoltConfig{FileName: "./table.bolt"})
+	handler, err := NewBoltHandler(&BoltConfig{FileName: testBoltFile})
 	if err != nil {
 		t.Fatal(err)
 	}
 	defer func() {
 		handler.Close()
-		_ = os.RemoveAll("./table.bolt")
+		_ = os.RemoveAll(testBoltFile)
 	}()
 	insStore := &instanceStore{handler: handler}
 	batchAddInstances(t, insStore, "svcid1", insCount)
@@ -96,13 +96,13 @@ func batchAddInstances(t *testing.T, insStore *instanceStore, svcId string, insC
 }
 
 func TestInstanceStore_BatchAddInstances(t *testing.T) {
-	handler, err := NewBoltHandler(&BoltConfig{FileName: "./table.bolt"})
+	handler, err := NewBoltHandler(&BoltConfig{FileName: testBoltFile})
 	if err != nil {
 		t.Fatal(err)
 	}
 	defer func() {
 		handler.Close()
-		_ = os.RemoveAll("./table.bolt")
+		_ = os.RemoveAll(testBoltFile)
 	}()
 	insStore := &instanceStore{handler: handler}
 
@@ -145,14 +145,14 @@ func TestInstanceStore_BatchAddInstances(t *testing.T) {
 }
 
 func TestInstanceStore_GetExpandInstances(t *testing.T) {
-	_ = os.RemoveAll("./table.bolt")
-	handler, err := NewBoltHandler(&BoltConfig{FileName: "./table.bolt"})
+	_ = os.RemoveAll(testBoltFile)
+	handler, err := NewBoltHandler(&BoltConfig{FileName: testBoltFile})
 	if err != nil {
 		t.Fatal(err)
 	}
 	defer func() {
 		handler.Close()
-		_ = os.RemoveAll("./table.bolt")
+		_ = os.RemoveAll(testBoltFile)
 	}()
 	insStore := &instanceStore{handler: handler}
 	batchAddInstances(t, insStore, "svcid1", insCount)
@@ -168,13 +168,13 @@ func TestInstanceStore_GetExpandInstances(t *testing.T) {
 }
 
 func TestInstanceStore_GetMoreInstances(t *testing.T) {
-	handler, err := NewBoltHandler(&BoltConfig{FileName: "./table.bolt"})
+	handler, err := NewBoltHandler(&BoltConfig{FileName: testBoltFile})
 	if err != nil {
 		t.Fatal(err)
 	}
 	defer func() {
 		handler.Close()
-		_ = os.RemoveAll("./table.bolt")
+		_ = os.RemoveAll(testBoltFile)
 	}()
 	insStore := &instanceStore{handler: handler}
 	batchAddInstances(t, insStore, "svcid2", insCount)
@@ -192,13 +192,13 @@ func TestInstanceStore_GetMoreInstances(t *testing.T) {
 }
 
 func TestInstanceStore_SetInstanceHealthStatus(t *testing.T) {
-	handler, err := NewBoltHandler(&BoltConfig{FileName: "./table.bolt"})
+	handler, err := NewBoltHandler(&BoltConfig{FileName: testBoltFile})
 	if err != nil {
 		t.Fatal(err)
 	}
 	defer func() {
 		handler.Close()
-		_ = os.RemoveAll("./table.bolt")
+		_ = os.RemoveAll(testBoltFile)
 	}()
 	insStore := &instanceStore{handler: handler}
 	batchAddInstances(t, insStore, "svcid1", 8)
@@ -220,13 +220,13 @@ func TestInstanceStore_SetInstanceHealthStatus(t *testing.T) {
 }
 
 func TestInstanceStore_BatchSetInstanceIsolate(t *testing.T) {
-	handler, err := NewBoltHandler(&BoltConfig{FileName: "./table.bolt"})
+	handler, err := NewBoltHandler(&BoltConfig{FileName: testBoltFile})
 	if err != nil {
 		t.Fatal(err)
 	}
 	defer func() {
 		handler.Close()
-		_ = os.RemoveAll("./table.bolt")
+		_ = os.RemoveAll(testBoltFile)
 	}()
 	insStore := &instanceStore{handler: handler}
 	batchAddInstances(t, insStore, "svcid1", 10)
@@ -258,13 +258,13 @@ func TestInstanceStore_BatchSetInstanceIsolate(t *testing.T) {
 }
 
 func TestInstanceStore_GetInstancesMainByService(t *testing.T) {
-	handler, err := NewBoltHandler(&BoltConfig{FileName: "./table.bolt"})
+	handler, err := NewBoltHandler(&BoltConfig{FileName: testBoltFile})
 	if err != nil {
 		t.Fatal(err)
 	}
 	defer func() {
 		handler.Close()
-		_ = os.RemoveAll("./table.bolt")
+		_ = os.RemoveAll(testBoltFile)
 	}()
 	insStore := &instanceStore{handler: handler}
 	batchAddInstances(t, insStore, "svcid1", insCount)
@@ -280,13 +280,13 @@ func TestInstanceStore_GetInstancesMainByService(t *testing.T) {
 }
 
 func TestInstanceStore_UpdateInstance(t *testing.T) {
-	handler, err := NewBoltHandler(&BoltConfig{FileName: "./table.bolt"})
+	handler, err := NewBoltHandler(&BoltConfig{FileName: testBoltFile})
 	if err != nil {
 		t.Fatal(err)
 	}
 	defer func() {
 		handler.Close()
-		_ = os.RemoveAll("./table.bolt")
+		_ = os.RemoveAll(testBoltFile)
 	}()
 	insStore := &instanceStore{handler: handler}
 	batchAddInstances(t, insStore, "svcid1", insCount)
@@ -332,13 +332,13 @@ func TestInstanceStore_UpdateInstance(t *testing.T) {
 }
 
 func TestInstanceStore_GetInstancesBrief(t *testing.T) {
-	handler, err := NewBoltHandler(&BoltConfig{FileName: "./table.bolt"})
+	handler, err := NewBoltHandler(&BoltConfig{FileName: testBoltFile})
 	if err != nil {
 		t.Fatal(err)
 	}
 	defer func() {
 		handler.Close()
-		_ = os.RemoveAll("./table.bolt")
+		_ = os.RemoveAll(testBoltFile)
 	}()
 	insStore := &instanceStore{handler: handler}
 	batchAddInstances(t, insStore, "svcid2", 10)
@@ -424,13 +424,13 @@ func TestInstanceStore_GetInstancesBrief(t *testing.T) {
 }
 
 func TestInstanceStore_GetInstancesCount(t *testing.T) {
-	handler, err := NewBoltHandler(&BoltConfig{FileName: "./table.bolt"})
+	handler, err := NewBoltHandler(&BoltConfig{FileName: testBoltFile})
 	if err != nil {
 		t.Fatal(err)
 	}
 	defer func() {
 		handler.Close()
-		_ = os.RemoveAll("./table.bolt")
+		_ = os.RemoveAll(testBoltFile)
 	}()
 	insStore := &instanceStore{handler: handler}
 	batchAddInstances(t, insStore, "svcid1", insCount)
@@ -446,13 +446,13 @@ func TestInstanceStore_GetInstancesCount(t *testing.T) {
 }
 
 func TestInstanceStore_CheckInstancesExisted(t *testing.T) {
-	handler, err := NewBoltHandler(&BoltConfig{FileName: "./table.bolt"})
+	handler, err := NewBoltHandler(&BoltConfig{FileName: testBoltFile})
 	if err != nil {
 		t.Fatal(err)
 	}
 	defer func() {
 		handler.Close()
-		_ = os.RemoveAll("./table.bolt")
+		_ = os.RemoveAll(testBoltFile)
 	}()
 	insStore := &instanceStore{handler: handler}
 	batchAddInstances(t, insStore, "svcid1", insCount)
@@ -475,13 +475,13 @@ func TestInstanceStore_CheckInstancesExisted(t *testing.T) {
 }
 
 func TestInstanceStore_DeleteInstance(t *testing.T) {
-	handler, err := NewBoltHandler(&BoltConfig{FileName: "./table.bolt"})
+	handler, err := NewBoltHandler(&BoltConfig{FileName: testBoltFile})
 	if err != nil {
 		t.Fatal(err)
 	}
 	defer func() {
 		handler.Close()
-		_ = os.RemoveAll("./table.bolt")
+		_ = os.RemoveAll(testBoltFile)
 	}()
 	insStore := &instanceStore{handler: handler}
 	batchAddInstances(t, insStore, "svcid1", insCount)
@@ -503,13 +503,13 @@ func TestInstanceStore_DeleteInstance(t *testing.T) {
 }
 
 func TestInstanceStore_BatchDeleteInstances(t *testing.T) {
-	handler, err := NewBoltHandler(&BoltConfig{FileName: "./table.bolt"})
+	handler, err := NewBoltHandler(&BoltConfig{FileName: testBoltFile})
 	if err != nil {
 		t.Fatal(err)
 	}
 	defer func() {
 		handler.Close()
-		_ = os.RemoveAll("./table.bolt")
+		_ = os.RemoveAll(testBoltFile)
 	}()
 	insStore := &instanceStore{handler: handler}
 	batchAddInstances(t, insStore, "svcid1", insCount)
@@ -23,7 +23,7 @@ import (
 )
 
 func TestL5Store_GenNextL5Sid(t *testing.T) {
-	handler, err := NewBoltHandler(&BoltConfig{FileName: "./table.bolt"})
+	handler, err := NewBoltHandler(&BoltConfig{FileName: testBoltFile})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -53,8 +53,8 @@ func InitNamespaceData(nsStore *namespaceStore, nsCount int) error {
 }
 
 func TestNamespaceStore_AddNamespace(t *testing.T) {
-	_ = os.RemoveAll("./table.bolt")
-	handler, err := NewBoltHandler(&BoltConfig{FileName: "./table.bolt"})
+	_ = os.RemoveAll(testBoltFile)
+	handler, err := NewBoltHandler(&BoltConfig{FileName: testBoltFile})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -79,8 +79,8 @@ func TestNamespaceStore_AddNamespace(t *testing.T) {
 }
 
 func TestNamespaceStore_ListNamespaces(t *testing.T) {
-	_ = os.RemoveAll("./table.bolt")
-	handler, err := NewBoltHandler(&BoltConfig{FileName: "./table.bolt"})
+	_ = os.RemoveAll(testBoltFile)
+	handler, err := NewBoltHandler(&BoltConfig{FileName: testBoltFile})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -104,8 +104,8 @@ func TestNamespaceStore_ListNamespaces(t *testing.T) {
 }
 
 func TestNamespaceStore_GetNamespaces(t *testing.T) {
-	_ = os.RemoveAll("./table.bolt")
-	handler, err := NewBoltHandler(&BoltConfig{FileName: "./table.bolt"})
+	_ = os.RemoveAll(testBoltFile)
+	handler, err := NewBoltHandler(&BoltConfig{FileName: testBoltFile})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -199,8 +199,8 @@ func TestNamespaceStore_GetNamespaces(t *testing.T) {
 }
 
 func TestNamespaceStore_GetNamespace(t *testing.T) {
-	_ = os.RemoveAll("./table.bolt")
-	handler, err := NewBoltHandler(&BoltConfig{FileName: "./table.bolt"})
+	_ = os.RemoveAll(testBoltFile)
+	handler, err := NewBoltHandler(&BoltConfig{FileName: testBoltFile})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -224,8 +224,8 @@ func TestNamespaceStore_GetNamespace(t *testing.T) {
 }
 
 func TestNamespaceStore_UpdateNamespace(t *testing.T) {
-	_ = os.RemoveAll("./table.bolt")
-	handler, err := NewBoltHandler(&BoltConfig{FileName: "./table.bolt"})
+	_ = os.RemoveAll(testBoltFile)
+	handler, err := NewBoltHandler(&BoltConfig{FileName: testBoltFile})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -264,8 +264,8 @@ func TestNamespaceStore_UpdateNamespace(t *testing.T) {
 }
 
 func TestNamespaceStore_UpdateNamespaceToken(t *testing.T) {
-	_ = os.RemoveAll("./table.bolt")
-	handler, err := NewBoltHandler(&BoltConfig{FileName: "./table.bolt"})
+	_ = os.RemoveAll(testBoltFile)
+	handler, err := NewBoltHandler(&BoltConfig{FileName: testBoltFile})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -301,8 +301,8 @@ func TestNamespaceStore_UpdateNamespaceToken(t *testing.T) {
 }
 
 func TestNamespaceStore_GetMoreNamespaces(t *testing.T) {
-	_ = os.RemoveAll("./table.bolt")
-	handler, err := NewBoltHandler(&BoltConfig{FileName: "./table.bolt"})
+	_ = os.RemoveAll(testBoltFile)
+	handler, err := NewBoltHandler(&BoltConfig{FileName: testBoltFile})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -323,8 +323,8 @@ func TestNamespaceStore_GetMoreNamespaces(t *testing.T) {
 }
 
 func TestTransaction_LockNamespace(t *testing.T) {
-	_ = os.RemoveAll("./table.bolt")
-	handler, err := NewBoltHandler(&BoltConfig{FileName: "./table.bolt"})
+	_ = os.RemoveAll(testBoltFile)
+	handler, err := NewBoltHandler(&BoltConfig{FileName: testBoltFile})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -349,8 +349,8 @@ func TestTransaction_LockNamespace(t *testing.T) {
 }
 
 func TestTransaction_DeleteNamespace(t *testing.T) {
-	_ = os.RemoveAll("./table.bolt")
-	handler, err := NewBoltHandler(&BoltConfig{FileName: "./table.bolt"})
+	_ = os.RemoveAll(testBoltFile)
+	handler, err := NewBoltHandler(&BoltConfig{FileName: testBoltFile})
 	if err != nil {
 		t.Fatal(err)
 	}
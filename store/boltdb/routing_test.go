@@ -33,7 +33,7 @@ const (
 )
 
 func TestRoutingStore_CreateRoutingConfig(t *testing.T) {
-	handler, err := NewBoltHandler(&BoltConfig{FileName: "./table.bolt"})
+	handler, err := NewBoltHandler(&BoltConfig{FileName: testBoltFile})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -197,7 +197,7 @@ func TestRoutingStore_GetRoutingConfigsForCache(t *testing.T) {
 func TestRoutingStore_GetRoutingConfigWithService(t *testing.T) {
 
 	// find service
-	handler, err := NewBoltHandler(&BoltConfig{FileName: "./table.bolt"})
+	handler, err := NewBoltHandler(&BoltConfig{FileName: testBoltFile})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -225,7 +225,7 @@ func TestRoutingStore_GetRoutingConfigWithService(t *testing.T) {
 }
 
 func TestRoutingStore_GetRoutingConfigWithID(t *testing.T) {
-	handler, err := NewBoltHandler(&BoltConfig{FileName: "./table.bolt"})
+	handler, err := NewBoltHandler(&BoltConfig{FileName: testBoltFile})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -242,7 +242,7 @@ func TestRoutingStore_GetRoutingConfigWithID(t *testing.T) {
 }
 
 func TestRoutingStore_DeleteRoutingConfig(t *testing.T) {
-	handler, err := NewBoltHandler(&BoltConfig{FileName: "./table.bolt"})
+	handler, err := NewBoltHandler(&BoltConfig{FileName: testBoltFile})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -28,8 +28,11 @@ type ConfigFileModuleStore interface {
 	ConfigFileGroupStore
 	ConfigFileStore
 	ConfigFileReleaseStore
+	ConfigFileGrayReleaseStore
 	ConfigFileReleaseHistoryStore
 	ConfigFileTagStore
+	ConfigFileListenerStore
+	ConfigFilePublishRequestStore
 }
 
 // ConfigFileGroupStore 配置文件组存储接口
@@ -98,6 +101,28 @@ type ConfigFileReleaseStore interface {
 	FindConfigFileReleaseByModifyTimeAfter(modifyTime time.Time) ([]*model.ConfigFileRelease, error)
 }
 
+// ConfigFileGrayReleaseStore 配置文件灰度发布存储接口，一个配置文件同时只有一个生效的灰度发布
+type ConfigFileGrayReleaseStore interface {
+
+	// CreateConfigFileGrayRelease 创建配置文件灰度发布
+	CreateConfigFileGrayRelease(tx Tx, fileRelease *model.ConfigFileRelease) (*model.ConfigFileRelease, error)
+
+	// UpdateConfigFileGrayRelease 更新配置文件灰度发布
+	UpdateConfigFileGrayRelease(tx Tx, fileRelease *model.ConfigFileRelease) (*model.ConfigFileRelease, error)
+
+	// GetConfigFileGrayRelease 获取配置文件灰度发布内容，只获取 flag=0 的记录
+	GetConfigFileGrayRelease(tx Tx, namespace, group, fileName string) (*model.ConfigFileRelease, error)
+
+	// GetConfigFileGrayReleaseWithAllFlag 获取配置文件灰度发布内容，返回所有 flag 的记录
+	GetConfigFileGrayReleaseWithAllFlag(tx Tx, namespace, group, fileName string) (*model.ConfigFileRelease, error)
+
+	// DeleteConfigFileGrayRelease 删除配置文件灰度发布，用于灰度全量或者放弃灰度
+	DeleteConfigFileGrayRelease(tx Tx, namespace, group, fileName, deleteBy string) error
+
+	// FindConfigFileGrayReleaseByModifyTimeAfter 获取最近更新的配置文件灰度发布
+	FindConfigFileGrayReleaseByModifyTimeAfter(modifyTime time.Time) ([]*model.ConfigFileRelease, error)
+}
+
 // ConfigFileReleaseHistoryStore 配置文件发布历史存储接口
 type ConfigFileReleaseHistoryStore interface {
 
@@ -110,6 +135,9 @@ type ConfigFileReleaseHistoryStore interface {
 
 	// GetLatestConfigFileReleaseHistory 获取配置文件最后一次发布
 	GetLatestConfigFileReleaseHistory(namespace, group, fileName string) (*model.ConfigFileReleaseHistory, error)
+
+	// GetConfigFileReleaseHistory 根据 id 获取配置文件发布历史记录
+	GetConfigFileReleaseHistory(id uint64) (*model.ConfigFileReleaseHistory, error)
 }
 
 type ConfigFileTagStore interface {
@@ -129,3 +157,38 @@ type ConfigFileTagStore interface {
 	// DeleteTagByConfigFile 删除配置文件标签
 	DeleteTagByConfigFile(tx Tx, namespace, group, fileName string) error
 }
+
+// ConfigFileListenerStore 配置文件订阅者存储接口，各个 server 节点定时上报本节点的订阅者
+type ConfigFileListenerStore interface {
+
+	// UpsertConfigFileListeners 批量新增或者更新订阅者，以 namespace、group、file_name、client_id 唯一
+	UpsertConfigFileListeners(listeners []*model.ConfigFileListener) error
+
+	// QueryConfigFileListeners 翻页查询配置文件的订阅者，参数为空时不过滤
+	QueryConfigFileListeners(namespace, group, fileName string, offset,
+		limit uint32) (uint32, []*model.ConfigFileListener, error)
+
+	// DeleteExpiredConfigFileListeners 删除上报时间早于 modifyTime 的订阅者
+	DeleteExpiredConfigFileListeners(modifyTime time.Time) error
+}
+
+// ConfigFilePublishRequestStore 配置发布申请存储接口
+type ConfigFilePublishRequestStore interface {
+
+	// CreateConfigFilePublishRequest 创建配置发布申请
+	CreateConfigFilePublishRequest(request *model.ConfigFilePublishRequest) error
+
+	// GetConfigFilePublishRequest 根据 id 获取配置发布申请，不存在时返回 nil
+	GetConfigFilePublishRequest(id string) (*model.ConfigFilePublishRequest, error)
+
+	// QueryConfigFilePublishRequests 翻页查询配置发布申请，参数为空时不过滤
+	QueryConfigFilePublishRequests(namespace, group, fileName, status string, offset,
+		limit uint32) (uint32, []*model.ConfigFilePublishRequest, error)
+
+	// UpdateConfigFilePublishRequest 当前状态为 fromStatus 时更新配置发布申请，返回是否更新成功，
+	// 用于多个节点并发变更状态时只有一个节点成功
+	UpdateConfigFilePublishRequest(request *model.ConfigFilePublishRequest, fromStatus string) (bool, error)
+
+	// FindDueConfigFilePublishRequests 获取计划发布时间不晚于 planTime 的待调度发布申请
+	FindDueConfigFilePublishRequests(planTime time.Time) ([]*model.ConfigFilePublishRequest, error)
+}
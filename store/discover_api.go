@@ -339,3 +339,29 @@ type ClientStore interface {
 	// GetMoreClients 根据mtime获取增量clients，返回所有store的变更信息
 	GetMoreClients(mtime time.Time, firstUpdate bool) (map[string]*model.Client, error)
 }
+
+const (
+	// ChangeLogService 服务的变更日志
+	ChangeLogService = "service"
+	// ChangeLogInstance 实例的变更日志
+	ChangeLogInstance = "instance"
+)
+
+// ChangeLogStore 变更日志的存储接口，存储插件可选实现
+// 开启后服务和实例的每次写入都会在同一个事务中追加一条带有递增序号的变更记录，缓存按照序号拉取增量数据，
+// 不依赖 mtime，也不需要重复拉取时间窗口内的数据
+type ChangeLogStore interface {
+	// ChangeLogEnabled 是否开启了变更日志
+	ChangeLogEnabled() bool
+
+	// GetLatestChangeSeq 获取资源当前最大的变更序号
+	GetLatestChangeSeq(resource string) (uint64, error)
+
+	// GetServiceChanges 获取序号大于seq的服务变更记录，按照序号升序返回，最多limit条
+	// 同时返回变更记录对应的服务，包括已经删除（valid=false）的服务，已经被清理的服务不返回
+	GetServiceChanges(seq uint64, limit uint32, needMeta bool) ([]*model.ChangeLog, map[string]*model.Service, error)
+
+	// GetInstanceChanges 获取序号大于seq的实例变更记录，按照序号升序返回，最多limit条
+	// 同时返回变更记录对应的实例，包括已经删除（valid=false）的实例，已经被清理的实例不返回
+	GetInstanceChanges(seq uint64, limit uint32, needMeta bool) ([]*model.ChangeLog, map[string]*model.Instance, error)
+}
@@ -0,0 +1,218 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package migrate
+
+import (
+	"sort"
+	"time"
+
+	api "github.com/polarismesh/polaris-server/common/api/v1"
+	"github.com/polarismesh/polaris-server/common/model"
+	"github.com/polarismesh/polaris-server/store"
+)
+
+// loadUsers 加载全部有效的用户，主账户排在子账户之前
+func loadUsers(s store.Store) ([]*model.User, error) {
+	users, err := s.GetUsersForCache(time.Time{}, true)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*model.User, 0, len(users))
+	for _, user := range users {
+		if user.Valid {
+			out = append(out, user)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		return out[i].Owner == "" && out[j].Owner != ""
+	})
+	return out, nil
+}
+
+func countUsers(s store.Store) (int, error) {
+	users, err := loadUsers(s)
+	return len(users), err
+}
+
+// migrateUsers 目标存储中已存在同名用户时（例如初始化的 polaris 管理员）不再创建，
+// 记录用户ID的映射，子账户、用户组以及鉴权策略中引用的用户ID都会转换为目标存储中的ID
+func (m *Migrator) migrateUsers(data *Dataset, report *ResourceReport) error {
+	users := data.Users
+	report.Source = len(users)
+	for _, user := range users {
+		user.Owner = m.mapID(user.Owner)
+		exist, err := m.target.GetUserByName(user.Name, user.Owner)
+		if err != nil {
+			return err
+		}
+		if exist != nil {
+			m.ids[user.ID] = exist.ID
+			report.Skipped++
+			continue
+		}
+		if m.dryRun {
+			report.Created++
+			continue
+		}
+		if err := skipDuplicate(report, m.target.AddUser(user)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadGroups 加载全部有效的用户组
+func loadGroups(s store.Store) ([]*model.UserGroupDetail, error) {
+	groups, err := s.GetGroupsForCache(time.Time{}, true)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*model.UserGroupDetail, 0, len(groups))
+	for _, group := range groups {
+		if group.Valid {
+			out = append(out, group)
+		}
+	}
+	return out, nil
+}
+
+func countGroups(s store.Store) (int, error) {
+	groups, err := loadGroups(s)
+	return len(groups), err
+}
+
+func (m *Migrator) migrateGroups(data *Dataset, report *ResourceReport) error {
+	groups := data.Groups
+	report.Source = len(groups)
+	for _, group := range groups {
+		group.Owner = m.mapID(group.Owner)
+		userIds := make(map[string]struct{}, len(group.UserIds))
+		for id := range group.UserIds {
+			userIds[m.mapID(id)] = struct{}{}
+		}
+		group.UserIds = userIds
+
+		exist, err := m.target.GetGroupByName(group.Name, group.Owner)
+		if err != nil {
+			return err
+		}
+		if exist != nil {
+			m.ids[group.ID] = exist.ID
+			report.Skipped++
+			continue
+		}
+		if m.dryRun {
+			report.Created++
+			continue
+		}
+		if err := skipDuplicate(report, m.target.AddGroup(group)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadStrategies 加载全部有效的鉴权策略
+func loadStrategies(s store.Store) ([]*model.StrategyDetail, error) {
+	strategies, err := s.GetStrategyDetailsForCache(time.Time{}, true)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*model.StrategyDetail, 0, len(strategies))
+	for _, strategy := range strategies {
+		if strategy.Valid {
+			out = append(out, strategy)
+		}
+	}
+	return out, nil
+}
+
+func countStrategies(s store.Store) (int, error) {
+	strategies, err := loadStrategies(s)
+	return len(strategies), err
+}
+
+// migrateStrategies 默认策略在创建用户、用户组时已经自动生成，只需要合并策略中的资源
+func (m *Migrator) migrateStrategies(data *Dataset, report *ResourceReport) error {
+	strategies := data.Strategies
+	report.Source = len(strategies)
+	for _, strategy := range strategies {
+		m.mapStrategy(strategy)
+
+		var (
+			exist *model.StrategyDetail
+			err   error
+		)
+		if strategy.Default && len(strategy.Principals) > 0 {
+			principal := strategy.Principals[0]
+			exist, err = m.target.GetDefaultStrategyDetailByPrincipal(principal.PrincipalID, principal.PrincipalRole)
+		} else {
+			exist, err = m.target.GetStrategyDetail(strategy.ID, false)
+		}
+		if err != nil {
+			return err
+		}
+		if exist != nil {
+			if !m.dryRun {
+				if err := m.mergeStrategyResources(exist, strategy.Resources); err != nil {
+					return err
+				}
+			}
+			report.Skipped++
+			continue
+		}
+		if m.dryRun {
+			report.Created++
+			continue
+		}
+		if err := skipDuplicate(report, m.target.AddStrategy(strategy)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// mapStrategy 将策略中引用的用户、用户组以及配置分组的ID转换为目标存储中的ID
+func (m *Migrator) mapStrategy(strategy *model.StrategyDetail) {
+	strategy.Owner = m.mapID(strategy.Owner)
+	for i := range strategy.Principals {
+		strategy.Principals[i].PrincipalID = m.mapID(strategy.Principals[i].PrincipalID)
+	}
+	for i := range strategy.Resources {
+		// 命名空间的资源ID为名称，在两个存储中是一致的
+		if strategy.Resources[i].ResType != int32(api.ResourceType_Namespaces) {
+			strategy.Resources[i].ResID = m.mapID(strategy.Resources[i].ResID)
+		}
+	}
+}
+
+// mergeStrategyResources 将源存储中策略的资源合并到目标存储中已存在的策略
+func (m *Migrator) mergeStrategyResources(exist *model.StrategyDetail, resources []model.StrategyResource) error {
+	if len(resources) == 0 {
+		return nil
+	}
+	merged := make([]model.StrategyResource, 0, len(resources))
+	for _, res := range resources {
+		merged = append(merged, model.StrategyResource{
+			StrategyID: exist.ID,
+			ResType:    res.ResType,
+			ResID:      res.ResID,
+		})
+	}
+	return m.target.LooseAddStrategyResources(merged)
+}
@@ -0,0 +1,279 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package migrate
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/polarismesh/polaris-server/common/model"
+	"github.com/polarismesh/polaris-server/store"
+)
+
+// configFileKey 配置文件的唯一标识
+func configFileKey(namespace, group, name string) string {
+	return fmt.Sprintf("%s@@%s@@%s", namespace, group, name)
+}
+
+// loadConfigFileGroups 分页加载全部配置分组
+func loadConfigFileGroups(s store.Store) ([]*model.ConfigFileGroup, error) {
+	var out []*model.ConfigFileGroup
+	for offset := uint32(0); ; offset += pageSize {
+		_, groups, err := s.QueryConfigFileGroups("", "", offset, pageSize)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, groups...)
+		if len(groups) < pageSize {
+			return out, nil
+		}
+	}
+}
+
+func countConfigFileGroups(s store.Store) (int, error) {
+	groups, err := loadConfigFileGroups(s)
+	return len(groups), err
+}
+
+// migrateConfigFileGroups 配置分组的ID由存储自增生成，需要记录新旧ID的映射，用于迁移鉴权策略中的资源
+func (m *Migrator) migrateConfigFileGroups(data *Dataset, report *ResourceReport) error {
+	groups := data.ConfigFileGroups
+	report.Source = len(groups)
+	for _, group := range groups {
+		exist, err := m.target.GetConfigFileGroup(group.Namespace, group.Name)
+		if err != nil {
+			return err
+		}
+		if exist != nil {
+			m.mapConfigFileGroupID(group, exist)
+			report.Skipped++
+			continue
+		}
+		if m.dryRun {
+			report.Created++
+			continue
+		}
+		created, err := m.target.CreateConfigFileGroup(group)
+		if err := skipDuplicate(report, err); err != nil {
+			return err
+		}
+		if created != nil {
+			m.mapConfigFileGroupID(group, created)
+		}
+	}
+	return nil
+}
+
+func (m *Migrator) mapConfigFileGroupID(source, target *model.ConfigFileGroup) {
+	m.ids[strconv.FormatUint(source.Id, 10)] = strconv.FormatUint(target.Id, 10)
+}
+
+// loadConfigFiles 分页加载全部配置文件
+func loadConfigFiles(s store.Store) ([]*model.ConfigFile, error) {
+	var out []*model.ConfigFile
+	for offset := uint32(0); ; offset += pageSize {
+		_, files, err := s.QueryConfigFiles("", "", "", offset, pageSize)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, files...)
+		if len(files) < pageSize {
+			return out, nil
+		}
+	}
+}
+
+func countConfigFiles(s store.Store) (int, error) {
+	files, err := loadConfigFiles(s)
+	return len(files), err
+}
+
+func (m *Migrator) migrateConfigFiles(data *Dataset, report *ResourceReport) error {
+	files := data.ConfigFiles
+	report.Source = len(files)
+	for _, file := range files {
+		exist, err := m.target.GetConfigFile(nil, file.Namespace, file.Group, file.Name)
+		if err != nil {
+			return err
+		}
+		if exist != nil {
+			report.Skipped++
+			continue
+		}
+		if m.dryRun {
+			report.Created++
+			continue
+		}
+		_, err = m.target.CreateConfigFile(nil, file)
+		if err := skipDuplicate(report, err); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadConfigFileReleases 加载全部配置发布，包括已删除的发布，保证版本号可以继续递增
+func loadConfigFileReleases(s store.Store) ([]*model.ConfigFileRelease, error) {
+	return s.FindConfigFileReleaseByModifyTimeAfter(time.Time{})
+}
+
+func countConfigFileReleases(s store.Store) (int, error) {
+	releases, err := loadConfigFileReleases(s)
+	return len(releases), err
+}
+
+func (m *Migrator) migrateConfigFileReleases(data *Dataset, report *ResourceReport) error {
+	releases := data.ConfigFileReleases
+	report.Source = len(releases)
+	for _, release := range releases {
+		exist, err := m.target.GetConfigFileReleaseWithAllFlag(nil, release.Namespace, release.Group,
+			release.FileName)
+		if err != nil {
+			return err
+		}
+		if exist != nil {
+			report.Skipped++
+			continue
+		}
+		if m.dryRun {
+			report.Created++
+			continue
+		}
+		deleted := release.Flag == 1
+		release.Flag = 0
+		_, err = m.target.CreateConfigFileRelease(nil, release)
+		if err := skipDuplicate(report, err); err != nil {
+			return err
+		}
+		if deleted {
+			if err := m.target.DeleteConfigFileRelease(nil, release.Namespace, release.Group, release.FileName,
+				release.ModifyBy); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// loadConfigFileReleaseHistories 分页加载全部发布历史，按照发布的先后顺序排列
+func loadConfigFileReleaseHistories(s store.Store) ([]*model.ConfigFileReleaseHistory, error) {
+	var out []*model.ConfigFileReleaseHistory
+	for offset := uint32(0); ; offset += pageSize {
+		_, histories, err := s.QueryConfigFileReleaseHistories("", "", "", offset, pageSize, 0)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, histories...)
+		if len(histories) < pageSize {
+			break
+		}
+	}
+	// 查询结果按照ID倒序排列
+	for i, j := 0, len(out)-1; i < j; i, j = i+1, j-1 {
+		out[i], out[j] = out[j], out[i]
+	}
+	return out, nil
+}
+
+func countConfigFileReleaseHistories(s store.Store) (int, error) {
+	histories, err := loadConfigFileReleaseHistories(s)
+	return len(histories), err
+}
+
+// migrateConfigFileReleaseHistories 发布历史没有唯一键，目标存储中已有历史记录的配置文件不再迁移，避免重复执行时产生重复记录
+func (m *Migrator) migrateConfigFileReleaseHistories(data *Dataset, report *ResourceReport) error {
+	histories := data.ConfigFileReleaseHistories
+	report.Source = len(histories)
+	targetHistories, err := loadConfigFileReleaseHistories(m.target)
+	if err != nil {
+		return err
+	}
+	exists := make(map[string]bool, len(targetHistories))
+	for _, h := range targetHistories {
+		exists[configFileKey(h.Namespace, h.Group, h.FileName)] = true
+	}
+	for _, h := range histories {
+		if exists[configFileKey(h.Namespace, h.Group, h.FileName)] {
+			report.Skipped++
+			continue
+		}
+		if m.dryRun {
+			report.Created++
+			continue
+		}
+		if err := skipDuplicate(report, m.target.CreateConfigFileReleaseHistory(nil, h)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadConfigFileTags 加载配置文件的标签
+func loadConfigFileTags(s store.Store, files []*model.ConfigFile) ([]*model.ConfigFileTag, error) {
+	var out []*model.ConfigFileTag
+	for _, file := range files {
+		tags, err := s.QueryTagByConfigFile(file.Namespace, file.Group, file.Name)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, tags...)
+	}
+	return out, nil
+}
+
+func countConfigFileTags(s store.Store) (int, error) {
+	files, err := loadConfigFiles(s)
+	if err != nil {
+		return 0, err
+	}
+	tags, err := loadConfigFileTags(s, files)
+	return len(tags), err
+}
+
+func (m *Migrator) migrateConfigFileTags(data *Dataset, report *ResourceReport) error {
+	tags := data.ConfigFileTags
+	report.Source = len(tags)
+	for _, tag := range tags {
+		exists, err := m.target.QueryTagByConfigFile(tag.Namespace, tag.Group, tag.FileName)
+		if err != nil {
+			return err
+		}
+		if containsTag(exists, tag) {
+			report.Skipped++
+			continue
+		}
+		if m.dryRun {
+			report.Created++
+			continue
+		}
+		if err := skipDuplicate(report, m.target.CreateConfigFileTag(nil, tag)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func containsTag(tags []*model.ConfigFileTag, tag *model.ConfigFileTag) bool {
+	for _, t := range tags {
+		if t.Key == tag.Key && t.Value == tag.Value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,228 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package migrate
+
+import (
+	"strconv"
+
+	api "github.com/polarismesh/polaris-server/common/api/v1"
+	"github.com/polarismesh/polaris-server/common/model"
+	"github.com/polarismesh/polaris-server/store"
+)
+
+// Dataset 从存储中读取的全部资源，与具体的存储插件无关
+type Dataset struct {
+	Namespaces                 []*model.Namespace
+	Services                   []*model.Service
+	Aliases                    []*model.Service
+	Instances                  []*model.Instance
+	Routings                   []*model.RoutingConfig
+	RateLimits                 []*model.RateLimit
+	CircuitBreakers            []*model.CircuitBreaker
+	CircuitBreakerRelations    []*model.CircuitBreakerRelation
+	ConfigFileGroups           []*model.ConfigFileGroup
+	ConfigFiles                []*model.ConfigFile
+	ConfigFileReleases         []*model.ConfigFileRelease
+	ConfigFileReleaseHistories []*model.ConfigFileReleaseHistory
+	ConfigFileTags             []*model.ConfigFileTag
+	Users                      []*model.User
+	Groups                     []*model.UserGroupDetail
+	Strategies                 []*model.StrategyDetail
+}
+
+// LoadDataset 读取存储中的全部有效资源
+func LoadDataset(s store.Store) (*Dataset, error) {
+	var err error
+	data := &Dataset{}
+	if data.Namespaces, err = loadNamespaces(s); err != nil {
+		return nil, err
+	}
+	if data.Services, err = loadServices(s, false); err != nil {
+		return nil, err
+	}
+	if data.Aliases, err = loadServices(s, true); err != nil {
+		return nil, err
+	}
+	if data.Instances, err = loadInstances(s); err != nil {
+		return nil, err
+	}
+	if data.Routings, err = loadRoutings(s); err != nil {
+		return nil, err
+	}
+	if data.RateLimits, err = loadRateLimits(s); err != nil {
+		return nil, err
+	}
+	if data.CircuitBreakers, err = loadCircuitBreakers(s); err != nil {
+		return nil, err
+	}
+	if data.CircuitBreakerRelations, err = loadCircuitBreakerRelations(s, data.CircuitBreakers); err != nil {
+		return nil, err
+	}
+	if data.ConfigFileGroups, err = loadConfigFileGroups(s); err != nil {
+		return nil, err
+	}
+	if data.ConfigFiles, err = loadConfigFiles(s); err != nil {
+		return nil, err
+	}
+	if data.ConfigFileReleases, err = loadConfigFileReleases(s); err != nil {
+		return nil, err
+	}
+	if data.ConfigFileReleaseHistories, err = loadConfigFileReleaseHistories(s); err != nil {
+		return nil, err
+	}
+	if data.ConfigFileTags, err = loadConfigFileTags(s, data.ConfigFiles); err != nil {
+		return nil, err
+	}
+	if data.Users, err = loadUsers(s); err != nil {
+		return nil, err
+	}
+	if data.Groups, err = loadGroups(s); err != nil {
+		return nil, err
+	}
+	if data.Strategies, err = loadStrategies(s); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// Filter 只保留指定命名空间下的资源，namespaces 为空时保留全部命名空间
+// 存储并不是在一个事务中读取的，过滤时同时去掉引用了不存在资源的数据，保证数据集中的引用关系是完整的
+// 用户、用户组和鉴权策略不属于命名空间，会全部保留，策略中只保留数据集中存在的资源
+func (d *Dataset) Filter(namespaces []string) *Dataset {
+	nsSet := make(map[string]bool)
+	for _, ns := range d.Namespaces {
+		if len(namespaces) == 0 || containsString(namespaces, ns.Name) {
+			nsSet[ns.Name] = true
+		}
+	}
+
+	out := &Dataset{
+		Users:  d.Users,
+		Groups: d.Groups,
+	}
+	for _, ns := range d.Namespaces {
+		if nsSet[ns.Name] {
+			out.Namespaces = append(out.Namespaces, ns)
+		}
+	}
+	services := make(map[string]bool)
+	for _, svc := range d.Services {
+		if nsSet[svc.Namespace] {
+			services[svc.ID] = true
+			out.Services = append(out.Services, svc)
+		}
+	}
+	for _, alias := range d.Aliases {
+		if nsSet[alias.Namespace] && services[alias.Reference] {
+			out.Aliases = append(out.Aliases, alias)
+		}
+	}
+	for _, ins := range d.Instances {
+		if services[ins.ServiceID] {
+			out.Instances = append(out.Instances, ins)
+		}
+	}
+	for _, routing := range d.Routings {
+		if services[routing.ID] {
+			out.Routings = append(out.Routings, routing)
+		}
+	}
+	for _, limit := range d.RateLimits {
+		if services[limit.ServiceID] {
+			out.RateLimits = append(out.RateLimits, limit)
+		}
+	}
+	rules := make(map[string]bool)
+	for _, rule := range d.CircuitBreakers {
+		if !nsSet[rule.Namespace] {
+			continue
+		}
+		if rule.Version != masterVersion && !rules[rule.ID+"@@"+masterVersion] {
+			continue
+		}
+		rules[rule.ID+"@@"+rule.Version] = true
+		out.CircuitBreakers = append(out.CircuitBreakers, rule)
+	}
+	for _, relation := range d.CircuitBreakerRelations {
+		if services[relation.ServiceID] && rules[relation.RuleID+"@@"+relation.RuleVersion] {
+			out.CircuitBreakerRelations = append(out.CircuitBreakerRelations, relation)
+		}
+	}
+
+	groups := make(map[string]bool)
+	for _, group := range d.ConfigFileGroups {
+		if nsSet[group.Namespace] {
+			groups[strconv.FormatUint(group.Id, 10)] = true
+			groups[configFileKey(group.Namespace, group.Name, "")] = true
+			out.ConfigFileGroups = append(out.ConfigFileGroups, group)
+		}
+	}
+	files := make(map[string]bool)
+	for _, file := range d.ConfigFiles {
+		if groups[configFileKey(file.Namespace, file.Group, "")] {
+			files[configFileKey(file.Namespace, file.Group, file.Name)] = true
+			out.ConfigFiles = append(out.ConfigFiles, file)
+		}
+	}
+	for _, release := range d.ConfigFileReleases {
+		if files[configFileKey(release.Namespace, release.Group, release.FileName)] {
+			out.ConfigFileReleases = append(out.ConfigFileReleases, release)
+		}
+	}
+	for _, history := range d.ConfigFileReleaseHistories {
+		if files[configFileKey(history.Namespace, history.Group, history.FileName)] {
+			out.ConfigFileReleaseHistories = append(out.ConfigFileReleaseHistories, history)
+		}
+	}
+	for _, tag := range d.ConfigFileTags {
+		if files[configFileKey(tag.Namespace, tag.Group, tag.FileName)] {
+			out.ConfigFileTags = append(out.ConfigFileTags, tag)
+		}
+	}
+
+	for _, strategy := range d.Strategies {
+		filtered := *strategy
+		filtered.Resources = make([]model.StrategyResource, 0, len(strategy.Resources))
+		for _, res := range strategy.Resources {
+			var keep bool
+			switch api.ResourceType(res.ResType) {
+			case api.ResourceType_Namespaces:
+				keep = nsSet[res.ResID]
+			case api.ResourceType_Services:
+				keep = services[res.ResID]
+			case api.ResourceType_ConfigGroups:
+				keep = groups[res.ResID]
+			}
+			// * 表示全部资源
+			if keep || res.ResID == "*" {
+				filtered.Resources = append(filtered.Resources, res)
+			}
+		}
+		out.Strategies = append(out.Strategies, &filtered)
+	}
+	return out
+}
+
+func containsString(items []string, item string) bool {
+	for _, v := range items {
+		if v == item {
+			return true
+		}
+	}
+	return false
+}
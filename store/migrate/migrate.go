@@ -0,0 +1,205 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package migrate
+
+import (
+	"bytes"
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/polarismesh/polaris-server/store"
+)
+
+const (
+	// pageSize 分页读取及批量写入的大小
+	pageSize = 100
+)
+
+// ResourceReport 单类资源的迁移结果
+type ResourceReport struct {
+	// Resource 资源类型
+	Resource string
+	// Source 源存储中的数量
+	Source int
+	// Created 写入目标存储的数量，dry-run 时为需要写入的数量
+	Created int
+	// Skipped 目标存储中已经存在而跳过的数量
+	Skipped int
+	// Target 迁移完成后目标存储中的数量，dry-run 时为迁移前的数量
+	Target int
+	// Missing 迁移完成后按照唯一标识在目标存储中仍然找不到的源数据数量，dry-run 时不统计
+	Missing int
+}
+
+// Verified 每一条源数据都已经写入或者跳过，并且迁移完成后都能在目标存储中按照唯一标识找到，
+// 目标存储中已有的其他数据不影响校验结果
+func (r *ResourceReport) Verified() bool {
+	return r.Created+r.Skipped == r.Source && r.Missing == 0 && r.Target >= r.Source
+}
+
+// Report 迁移结果报告
+type Report struct {
+	DryRun    bool
+	Resources []*ResourceReport
+}
+
+// Verified 所有资源都校验通过
+func (r *Report) Verified() bool {
+	for _, res := range r.Resources {
+		if !res.Verified() {
+			return false
+		}
+	}
+	return true
+}
+
+// String 以表格的形式输出报告
+func (r *Report) String() string {
+	buf := &bytes.Buffer{}
+	w := tabwriter.NewWriter(buf, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "RESOURCE\tSOURCE\tCREATED\tSKIPPED\tTARGET\tMISSING\tVERIFIED")
+	for _, res := range r.Resources {
+		_, _ = fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%d\t%d\t%v\n",
+			res.Resource, res.Source, res.Created, res.Skipped, res.Target, res.Missing, res.Verified())
+	}
+	_ = w.Flush()
+	if r.DryRun {
+		buf.WriteString("dry-run: nothing was written to the target store\n")
+	}
+	return buf.String()
+}
+
+// step 一类资源的迁移步骤
+type step struct {
+	resource string
+	// migrate 将数据集中的数据写入目标存储，并记录到 report 中
+	migrate func(data *Dataset, report *ResourceReport) error
+	// count 统计存储中的数量，用于校验
+	count func(s store.Store) (int, error)
+}
+
+// Migrator 将数据写入目标存储，数据可以来自另一个 store 插件，也可以来自备份文件
+// 写入目标存储前会检查数据是否已经存在，已存在的数据会被跳过，因此可以重复执行
+type Migrator struct {
+	target store.Store
+	dryRun bool
+	// ids 源存储中的资源ID到目标存储中资源ID的映射
+	// 例如目标存储中已存在的同名用户、自增的配置分组ID
+	ids map[string]string
+}
+
+// NewMigrator 创建迁移工具，target 需要已经完成初始化
+func NewMigrator(target store.Store, dryRun bool) *Migrator {
+	return &Migrator{
+		target: target,
+		dryRun: dryRun,
+		ids:    make(map[string]string),
+	}
+}
+
+// Run 将源存储中的全部数据迁移到目标存储
+func (m *Migrator) Run(source store.Store) (*Report, error) {
+	data, err := LoadDataset(source)
+	if err != nil {
+		return nil, fmt.Errorf("load source store: %v", err)
+	}
+	return m.Import(data)
+}
+
+// Import 按照资源的依赖顺序写入数据，写入后以 dry-run 的方式重新执行一遍，按照唯一标识校验源数据都已经存在
+func (m *Migrator) Import(data *Dataset) (*Report, error) {
+	report := &Report{DryRun: m.dryRun}
+	verifySteps := (&Migrator{target: m.target, dryRun: true, ids: m.ids}).steps()
+	for i, s := range m.steps() {
+		res := &ResourceReport{Resource: s.resource}
+		if m.dryRun {
+			// dry-run 时先统计，保证 Target 为迁移前的数量
+			target, err := s.count(m.target)
+			if err != nil {
+				return nil, fmt.Errorf("count %s in target store: %v", s.resource, err)
+			}
+			res.Target = target
+		}
+		if err := s.migrate(data, res); err != nil {
+			return nil, fmt.Errorf("migrate %s: %v", s.resource, err)
+		}
+		if !m.dryRun {
+			target, err := s.count(m.target)
+			if err != nil {
+				return nil, fmt.Errorf("count %s in target store: %v", s.resource, err)
+			}
+			res.Target = target
+			// dry-run 时需要写入的数据就是目标存储中缺少的数据
+			check := &ResourceReport{Resource: s.resource}
+			if err := verifySteps[i].migrate(data, check); err != nil {
+				return nil, fmt.Errorf("verify %s: %v", s.resource, err)
+			}
+			res.Missing = check.Created
+		}
+		log.Infof("[Store][Migrate] %s source(%d) created(%d) skipped(%d) target(%d) missing(%d)",
+			res.Resource, res.Source, res.Created, res.Skipped, res.Target, res.Missing)
+		report.Resources = append(report.Resources, res)
+	}
+	return report, nil
+}
+
+// steps 迁移步骤，后面的资源依赖前面的资源
+// 鉴权资源放在最后，策略中关联的资源ID需要先完成映射
+func (m *Migrator) steps() []*step {
+	return []*step{
+		{resource: "namespace", migrate: m.migrateNamespaces, count: countNamespaces},
+		{resource: "service", migrate: m.migrateServices, count: countServices},
+		{resource: "alias", migrate: m.migrateAliases, count: countAliases},
+		{resource: "instance", migrate: m.migrateInstances, count: countInstances},
+		{resource: "routing", migrate: m.migrateRoutings, count: countRoutings},
+		{resource: "ratelimit", migrate: m.migrateRateLimits, count: countRateLimits},
+		{resource: "circuitbreaker", migrate: m.migrateCircuitBreakers, count: countCircuitBreakers},
+		{resource: "circuitbreaker_relation", migrate: m.migrateCircuitBreakerRelations,
+			count: countCircuitBreakerRelations},
+		{resource: "config_file_group", migrate: m.migrateConfigFileGroups, count: countConfigFileGroups},
+		{resource: "config_file", migrate: m.migrateConfigFiles, count: countConfigFiles},
+		{resource: "config_file_release", migrate: m.migrateConfigFileReleases, count: countConfigFileReleases},
+		{resource: "config_file_release_history", migrate: m.migrateConfigFileReleaseHistories,
+			count: countConfigFileReleaseHistories},
+		{resource: "config_file_tag", migrate: m.migrateConfigFileTags, count: countConfigFileTags},
+		{resource: "user", migrate: m.migrateUsers, count: countUsers},
+		{resource: "group", migrate: m.migrateGroups, count: countGroups},
+		{resource: "strategy", migrate: m.migrateStrategies, count: countStrategies},
+	}
+}
+
+// mapID 获取源存储ID在目标存储中对应的ID
+func (m *Migrator) mapID(id string) string {
+	if v, ok := m.ids[id]; ok {
+		return v
+	}
+	return id
+}
+
+// skipDuplicate 并发写入等场景下目标存储中已存在的数据视为跳过
+func skipDuplicate(report *ResourceReport, err error) error {
+	if err == nil {
+		report.Created++
+		return nil
+	}
+	if store.Code(err) == store.DuplicateEntryErr {
+		report.Skipped++
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,246 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package migrate
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/wrappers"
+	. "github.com/smartystreets/goconvey/convey"
+
+	api "github.com/polarismesh/polaris-server/common/api/v1"
+	"github.com/polarismesh/polaris-server/common/model"
+	"github.com/polarismesh/polaris-server/store"
+	_ "github.com/polarismesh/polaris-server/store/boltdb"
+	"github.com/polarismesh/polaris-server/store/sqldb"
+)
+
+const (
+	// boltAdminUserID boltdb 初始化数据中的管理员账号
+	boltAdminUserID = "04ae4ead86e1ecf5811e32a9fbca9bfa"
+)
+
+// newTestStore 在临时目录中创建并初始化存储
+func newTestStore(t *testing.T, name, option, path string) store.Store {
+	s, err := store.NewStore(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Initialize(&store.Config{Name: name, Option: map[string]interface{}{option: path}}); err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+// prepareSource 写入每一类资源的测试数据
+func prepareSource(t *testing.T, s store.Store) {
+	Convey("准备源存储数据", t, func() {
+		So(s.AddNamespace(&model.Namespace{Name: "ns", Token: "t", Owner: "polaris", Valid: true}), ShouldBeNil)
+		svc := &model.Service{ID: "svc", Name: "svc", Namespace: "ns", Token: "t", Owner: "polaris",
+			Revision: "r1", Meta: map[string]string{"k": "v"}, Valid: true}
+		So(s.AddService(svc), ShouldBeNil)
+		So(s.AddService(&model.Service{ID: "alias", Name: "alias", Namespace: "ns", Reference: "svc",
+			Token: "t", Owner: "polaris", Revision: "r1", Valid: true}), ShouldBeNil)
+		So(s.BatchAddInstances([]*model.Instance{{
+			ServiceID: "svc",
+			Proto: &api.Instance{
+				Id:       &wrappers.StringValue{Value: "ins"},
+				Service:  &wrappers.StringValue{Value: "svc"},
+				Host:     &wrappers.StringValue{Value: "127.0.0.1"},
+				Port:     &wrappers.UInt32Value{Value: 8080},
+				Revision: &wrappers.StringValue{Value: "r1"},
+				Healthy:  &wrappers.BoolValue{Value: true},
+				Metadata: map[string]string{"k": "v"},
+			},
+			Valid: true,
+		}}), ShouldBeNil)
+		So(s.CreateRoutingConfig(&model.RoutingConfig{ID: "svc", InBounds: "[]", OutBounds: "[]",
+			Revision: "r1", Valid: true}), ShouldBeNil)
+		So(s.CreateRateLimit(&model.RateLimit{ID: "rl", ServiceID: "svc", Labels: "{}", Rule: "{}",
+			Revision: "r1", Valid: true}), ShouldBeNil)
+
+		cb := &model.CircuitBreaker{ID: "cb", Version: masterVersion, Name: "cb", Namespace: "ns",
+			Inbounds: "[]", Outbounds: "[]", Token: "t", Owner: "polaris", Revision: "r1", Valid: true}
+		So(s.CreateCircuitBreaker(cb), ShouldBeNil)
+		tag := *cb
+		tag.Version = "v1"
+		tag.Revision = "r2"
+		So(s.TagCircuitBreaker(&tag), ShouldBeNil)
+		So(s.ReleaseCircuitBreaker(&model.CircuitBreakerRelation{ServiceID: "svc", RuleID: "cb",
+			RuleVersion: "v1", Valid: true}), ShouldBeNil)
+
+		group, err := s.CreateConfigFileGroup(&model.ConfigFileGroup{Name: "group", Namespace: "ns",
+			CreateBy: "polaris", ModifyBy: "polaris"})
+		So(err, ShouldBeNil)
+		_, err = s.CreateConfigFile(nil, &model.ConfigFile{Name: "app.yaml", Namespace: "ns", Group: "group",
+			Content: "a: 1", Format: "yaml", CreateBy: "polaris", ModifyBy: "polaris"})
+		So(err, ShouldBeNil)
+		_, err = s.CreateConfigFileRelease(nil, &model.ConfigFileRelease{Name: "app.yaml-v1", Namespace: "ns",
+			Group: "group", FileName: "app.yaml", Content: "a: 1", Md5: "md5", Version: 1,
+			CreateBy: "polaris", ModifyBy: "polaris"})
+		So(err, ShouldBeNil)
+		for _, content := range []string{"a: 0", "a: 1"} {
+			So(s.CreateConfigFileReleaseHistory(nil, &model.ConfigFileReleaseHistory{Name: "app.yaml",
+				Namespace: "ns", Group: "group", FileName: "app.yaml", Format: "yaml", Content: content,
+				Md5: "md5", Type: "normal", Status: "success", CreateBy: "polaris", ModifyBy: "polaris"}),
+				ShouldBeNil)
+		}
+		So(s.CreateConfigFileTag(nil, &model.ConfigFileTag{Key: "env", Value: "test", Namespace: "ns",
+			Group: "group", FileName: "app.yaml", CreateBy: "polaris", ModifyBy: "polaris"}), ShouldBeNil)
+
+		So(s.AddUser(&model.User{ID: "user", Name: "user", Password: "p", Owner: boltAdminUserID,
+			Source: "Polaris", Type: model.SubAccountUserRole, Token: "t", TokenEnable: true, Valid: true}),
+			ShouldBeNil)
+		So(s.AddGroup(&model.UserGroupDetail{
+			UserGroup: &model.UserGroup{ID: "group", Name: "group", Owner: boltAdminUserID, Token: "t",
+				TokenEnable: true, Valid: true},
+			UserIds: map[string]struct{}{"user": {}},
+		}), ShouldBeNil)
+		So(s.AddStrategy(&model.StrategyDetail{
+			ID: "strategy", Name: "strategy", Action: api.AuthAction_READ_WRITE.String(),
+			Principals: []model.Principal{{StrategyID: "strategy", PrincipalID: "user",
+				PrincipalRole: model.PrincipalUser}},
+			Owner: boltAdminUserID,
+			Resources: []model.StrategyResource{
+				{StrategyID: "strategy", ResType: int32(api.ResourceType_Services), ResID: "svc"},
+				{StrategyID: "strategy", ResType: int32(api.ResourceType_ConfigGroups),
+					ResID: strconv.FormatUint(group.Id, 10)},
+			},
+			Valid: true, Revision: "r1",
+		}), ShouldBeNil)
+	})
+}
+
+// TestMigrate 将 boltdb 中的数据迁移到 sqlite
+func TestMigrate(t *testing.T) {
+	if !sqldb.SQLiteEnabled {
+		t.Skip("sqlite store requires cgo")
+	}
+	dir, err := ioutil.TempDir("", "polaris-migrate")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	source := newTestStore(t, "boltdbStore", "path", filepath.Join(dir, "polaris.bolt"))
+	defer source.Destroy()
+	target := newTestStore(t, "sqliteStore", "path", filepath.Join(dir, "polaris.db"))
+	defer target.Destroy()
+
+	prepareSource(t, source)
+
+	Convey("dry-run 不写入目标存储", t, func() {
+		report, err := NewMigrator(target, true).Run(source)
+		So(err, ShouldBeNil)
+		So(report.DryRun, ShouldBeTrue)
+		So(resourceReport(report, "service").Created, ShouldEqual, 1)
+		So(resourceReport(report, "user").Created, ShouldEqual, 1)
+		So(report.Verified(), ShouldBeFalse)
+
+		ins, err := target.GetInstance("ins")
+		So(err, ShouldBeNil)
+		So(ins, ShouldBeNil)
+	})
+
+	Convey("迁移全部资源并校验数量", t, func() {
+		report, err := NewMigrator(target, false).Run(source)
+		So(err, ShouldBeNil)
+		So(report.Verified(), ShouldBeTrue)
+		So(report.String(), ShouldContainSubstring, "config_file_release_history")
+		for _, res := range report.Resources {
+			So(res.Source, ShouldBeGreaterThan, 0)
+			So(res.Missing, ShouldEqual, 0)
+		}
+		// 初始化的 polaris 管理员在两个存储中ID不同，按名称映射而不是重复创建
+		So(resourceReport(report, "user").Created, ShouldEqual, 1)
+		So(resourceReport(report, "user").Skipped, ShouldEqual, 1)
+
+		ins, err := target.GetInstance("ins")
+		So(err, ShouldBeNil)
+		So(ins.Metadata()["k"], ShouldEqual, "v")
+
+		alias, err := target.GetService("alias", "ns")
+		So(err, ShouldBeNil)
+		So(alias.Reference, ShouldEqual, "svc")
+
+		cb, err := target.GetCircuitBreaker("cb", "v1")
+		So(err, ShouldBeNil)
+		So(cb.Revision, ShouldEqual, "r2")
+		relations, err := target.GetCircuitBreakerRelation("cb", "v1")
+		So(err, ShouldBeNil)
+		So(len(relations), ShouldEqual, 1)
+
+		user, err := target.GetUser("user")
+		So(err, ShouldBeNil)
+		admin, err := target.GetUserByName("polaris", "")
+		So(err, ShouldBeNil)
+		So(user.Owner, ShouldEqual, admin.ID)
+
+		group, err := target.GetConfigFileGroup("ns", "group")
+		So(err, ShouldBeNil)
+		strategy, err := target.GetStrategyDetail("strategy", false)
+		So(err, ShouldBeNil)
+		So(strategy.Owner, ShouldEqual, admin.ID)
+		resIDs := make([]string, 0, len(strategy.Resources))
+		for _, res := range strategy.Resources {
+			resIDs = append(resIDs, res.ResID)
+		}
+		So(resIDs, ShouldContain, strconv.FormatUint(group.Id, 10))
+
+		histories, err := loadConfigFileReleaseHistories(target)
+		So(err, ShouldBeNil)
+		So(len(histories), ShouldEqual, 2)
+		So(histories[1].Content, ShouldEqual, "a: 1")
+	})
+
+	Convey("重复迁移时跳过已存在的数据", t, func() {
+		report, err := NewMigrator(target, false).Run(source)
+		So(err, ShouldBeNil)
+		So(report.Verified(), ShouldBeTrue)
+		for _, res := range report.Resources {
+			So(res.Created, ShouldEqual, 0)
+			So(res.Skipped, ShouldEqual, res.Source)
+		}
+	})
+}
+
+func TestResourceReportVerified(t *testing.T) {
+	Convey("目标存储数量足够但源数据缺失时校验失败", t, func() {
+		res := &ResourceReport{Source: 2, Created: 1, Skipped: 1, Target: 5, Missing: 1}
+		So(res.Verified(), ShouldBeFalse)
+		res.Missing = 0
+		So(res.Verified(), ShouldBeTrue)
+	})
+	Convey("存在既没有写入也没有跳过的源数据时校验失败", t, func() {
+		res := &ResourceReport{Source: 2, Created: 1, Target: 5}
+		So(res.Verified(), ShouldBeFalse)
+	})
+}
+
+func resourceReport(report *Report, resource string) *ResourceReport {
+	for _, res := range report.Resources {
+		if res.Resource == resource {
+			return res
+		}
+	}
+	return nil
+}
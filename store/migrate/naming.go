@@ -0,0 +1,421 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package migrate
+
+import (
+	"time"
+
+	"github.com/polarismesh/polaris-server/common/model"
+	"github.com/polarismesh/polaris-server/store"
+)
+
+// masterVersion 熔断规则的 master 版本
+const masterVersion = "master"
+
+// loadNamespaces 加载全部有效的命名空间
+func loadNamespaces(s store.Store) ([]*model.Namespace, error) {
+	namespaces, err := s.GetMoreNamespaces(time.Time{})
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*model.Namespace, 0, len(namespaces))
+	for _, ns := range namespaces {
+		if ns.Valid {
+			out = append(out, ns)
+		}
+	}
+	return out, nil
+}
+
+func countNamespaces(s store.Store) (int, error) {
+	namespaces, err := loadNamespaces(s)
+	return len(namespaces), err
+}
+
+func (m *Migrator) migrateNamespaces(data *Dataset, report *ResourceReport) error {
+	namespaces := data.Namespaces
+	report.Source = len(namespaces)
+	for _, ns := range namespaces {
+		exist, err := m.target.GetNamespace(ns.Name)
+		if err != nil {
+			return err
+		}
+		if exist != nil {
+			report.Skipped++
+			continue
+		}
+		if m.dryRun {
+			report.Created++
+			continue
+		}
+		if err := skipDuplicate(report, m.target.AddNamespace(ns)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadServices 加载全部有效的服务，alias 为 true 时只返回服务别名，否则只返回普通服务
+func loadServices(s store.Store, alias bool) ([]*model.Service, error) {
+	services, err := s.GetMoreServices(time.Time{}, true, false, true)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*model.Service, 0, len(services))
+	for _, svc := range services {
+		if !svc.Valid || alias != (svc.Reference != "") {
+			continue
+		}
+		out = append(out, svc)
+	}
+	return out, nil
+}
+
+func countServices(s store.Store) (int, error) {
+	services, err := loadServices(s, false)
+	return len(services), err
+}
+
+func countAliases(s store.Store) (int, error) {
+	services, err := loadServices(s, true)
+	return len(services), err
+}
+
+func (m *Migrator) migrateServices(data *Dataset, report *ResourceReport) error {
+	return m.addServices(data.Services, report)
+}
+
+// migrateAliases 别名依赖于被指向的服务，需要在服务之后迁移
+func (m *Migrator) migrateAliases(data *Dataset, report *ResourceReport) error {
+	return m.addServices(data.Aliases, report)
+}
+
+func (m *Migrator) addServices(services []*model.Service, report *ResourceReport) error {
+	report.Source = len(services)
+	for _, svc := range services {
+		exist, err := m.target.GetServiceByID(svc.ID)
+		if err != nil {
+			return err
+		}
+		if exist == nil {
+			// 系统服务等数据在两个存储中ID可能不一致
+			if exist, err = m.target.GetService(svc.Name, svc.Namespace); err != nil {
+				return err
+			}
+		}
+		if exist != nil {
+			m.ids[svc.ID] = exist.ID
+			report.Skipped++
+			continue
+		}
+		if m.dryRun {
+			report.Created++
+			continue
+		}
+		if err := skipDuplicate(report, m.target.AddService(svc)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadInstances 加载全部有效的实例，包括实例的元数据及健康检查信息
+func loadInstances(s store.Store) ([]*model.Instance, error) {
+	instances, err := s.GetMoreInstances(time.Time{}, true, true, nil)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*model.Instance, 0, len(instances))
+	for _, ins := range instances {
+		if ins.Valid {
+			out = append(out, ins)
+		}
+	}
+	return out, nil
+}
+
+func countInstances(s store.Store) (int, error) {
+	instances, err := loadInstances(s)
+	return len(instances), err
+}
+
+func (m *Migrator) migrateInstances(data *Dataset, report *ResourceReport) error {
+	instances := data.Instances
+	report.Source = len(instances)
+	batch := make([]*model.Instance, 0, pageSize)
+	for _, ins := range instances {
+		ins.ServiceID = m.mapID(ins.ServiceID)
+		exist, err := m.target.GetInstance(ins.ID())
+		if err != nil {
+			return err
+		}
+		if exist != nil {
+			report.Skipped++
+			continue
+		}
+		if m.dryRun {
+			report.Created++
+			continue
+		}
+		batch = append(batch, ins)
+		if len(batch) == pageSize {
+			if err := m.target.BatchAddInstances(batch); err != nil {
+				return err
+			}
+			report.Created += len(batch)
+			batch = batch[:0]
+		}
+	}
+	if len(batch) > 0 {
+		if err := m.target.BatchAddInstances(batch); err != nil {
+			return err
+		}
+		report.Created += len(batch)
+	}
+	return nil
+}
+
+// loadRoutings 加载全部有效的路由配置
+func loadRoutings(s store.Store) ([]*model.RoutingConfig, error) {
+	routings, err := s.GetRoutingConfigsForCache(time.Time{}, true)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*model.RoutingConfig, 0, len(routings))
+	for _, r := range routings {
+		if r.Valid {
+			out = append(out, r)
+		}
+	}
+	return out, nil
+}
+
+func countRoutings(s store.Store) (int, error) {
+	routings, err := loadRoutings(s)
+	return len(routings), err
+}
+
+func (m *Migrator) migrateRoutings(data *Dataset, report *ResourceReport) error {
+	routings := data.Routings
+	report.Source = len(routings)
+	for _, r := range routings {
+		// 路由配置的ID即为服务ID
+		r.ID = m.mapID(r.ID)
+		exist, err := m.target.GetRoutingConfigWithID(r.ID)
+		if err != nil {
+			return err
+		}
+		if exist != nil {
+			report.Skipped++
+			continue
+		}
+		if m.dryRun {
+			report.Created++
+			continue
+		}
+		if err := skipDuplicate(report, m.target.CreateRoutingConfig(r)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadRateLimits 加载全部有效的限流规则
+func loadRateLimits(s store.Store) ([]*model.RateLimit, error) {
+	limits, _, err := s.GetRateLimitsForCache(time.Time{}, true)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*model.RateLimit, 0, len(limits))
+	for _, l := range limits {
+		if l.Valid {
+			out = append(out, l)
+		}
+	}
+	return out, nil
+}
+
+func countRateLimits(s store.Store) (int, error) {
+	limits, err := loadRateLimits(s)
+	return len(limits), err
+}
+
+func (m *Migrator) migrateRateLimits(data *Dataset, report *ResourceReport) error {
+	limits := data.RateLimits
+	report.Source = len(limits)
+	for _, l := range limits {
+		l.ServiceID = m.mapID(l.ServiceID)
+		exist, err := m.target.GetRateLimitWithID(l.ID)
+		if err != nil {
+			return err
+		}
+		if exist != nil {
+			report.Skipped++
+			continue
+		}
+		if m.dryRun {
+			report.Created++
+			continue
+		}
+		if err := skipDuplicate(report, m.target.CreateRateLimit(l)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadCircuitBreakers 加载全部熔断规则，包括 master 版本及已标记的版本，master 版本在前
+func loadCircuitBreakers(s store.Store) ([]*model.CircuitBreaker, error) {
+	var out []*model.CircuitBreaker
+	for offset := uint32(0); ; offset += pageSize {
+		detail, err := s.ListMasterCircuitBreakers(nil, offset, pageSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, info := range detail.CircuitBreakerInfos {
+			rules, err := loadCircuitBreakerVersions(s, info.CircuitBreaker.ID)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, rules...)
+		}
+		if len(detail.CircuitBreakerInfos) < pageSize {
+			return out, nil
+		}
+	}
+}
+
+// loadCircuitBreakerVersions 加载熔断规则的全部版本
+func loadCircuitBreakerVersions(s store.Store, id string) ([]*model.CircuitBreaker, error) {
+	versions, err := s.GetCircuitBreakerVersions(id)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*model.CircuitBreaker, 0, len(versions))
+	master, err := s.GetCircuitBreaker(id, masterVersion)
+	if err != nil {
+		return nil, err
+	}
+	if master == nil {
+		return out, nil
+	}
+	out = append(out, master)
+	for _, version := range versions {
+		if version == masterVersion {
+			continue
+		}
+		rule, err := s.GetCircuitBreaker(id, version)
+		if err != nil {
+			return nil, err
+		}
+		if rule != nil {
+			out = append(out, rule)
+		}
+	}
+	return out, nil
+}
+
+func countCircuitBreakers(s store.Store) (int, error) {
+	rules, err := loadCircuitBreakers(s)
+	return len(rules), err
+}
+
+func (m *Migrator) migrateCircuitBreakers(data *Dataset, report *ResourceReport) error {
+	rules := data.CircuitBreakers
+	report.Source = len(rules)
+	for _, rule := range rules {
+		exist, err := m.target.GetCircuitBreaker(rule.ID, rule.Version)
+		if err != nil {
+			return err
+		}
+		if exist != nil {
+			report.Skipped++
+			continue
+		}
+		if m.dryRun {
+			report.Created++
+			continue
+		}
+		// 已标记的版本需要基于 master 版本创建
+		if rule.Version == masterVersion {
+			err = m.target.CreateCircuitBreaker(rule)
+		} else {
+			err = m.target.TagCircuitBreaker(rule)
+		}
+		if err := skipDuplicate(report, err); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadCircuitBreakerRelations 加载熔断规则各个版本与服务的绑定关系
+func loadCircuitBreakerRelations(s store.Store, rules []*model.CircuitBreaker) (
+	[]*model.CircuitBreakerRelation, error) {
+	var out []*model.CircuitBreakerRelation
+	for _, rule := range rules {
+		relations, err := s.GetCircuitBreakerRelation(rule.ID, rule.Version)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, relations...)
+	}
+	return out, nil
+}
+
+func countCircuitBreakerRelations(s store.Store) (int, error) {
+	rules, err := loadCircuitBreakers(s)
+	if err != nil {
+		return 0, err
+	}
+	relations, err := loadCircuitBreakerRelations(s, rules)
+	return len(relations), err
+}
+
+func (m *Migrator) migrateCircuitBreakerRelations(data *Dataset, report *ResourceReport) error {
+	relations := data.CircuitBreakerRelations
+	report.Source = len(relations)
+	for _, relation := range relations {
+		relation.ServiceID = m.mapID(relation.ServiceID)
+		exists, err := m.target.GetCircuitBreakerRelation(relation.RuleID, relation.RuleVersion)
+		if err != nil {
+			return err
+		}
+		if containsRelation(exists, relation) {
+			report.Skipped++
+			continue
+		}
+		if m.dryRun {
+			report.Created++
+			continue
+		}
+		if err := skipDuplicate(report, m.target.ReleaseCircuitBreaker(relation)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func containsRelation(relations []*model.CircuitBreakerRelation, relation *model.CircuitBreakerRelation) bool {
+	for _, r := range relations {
+		if r.ServiceID == relation.ServiceID {
+			return true
+		}
+	}
+	return false
+}
@@ -2033,6 +2033,95 @@ func (mr *MockStoreMockRecorder) FindConfigFileReleaseByModifyTimeAfter(modifyTi
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindConfigFileReleaseByModifyTimeAfter", reflect.TypeOf((*MockStore)(nil).FindConfigFileReleaseByModifyTimeAfter), modifyTime)
 }
 
+// CreateConfigFileGrayRelease mocks base method
+func (m *MockStore) CreateConfigFileGrayRelease(tx store.Tx, fileRelease *model.ConfigFileRelease) (*model.ConfigFileRelease, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateConfigFileGrayRelease", tx, fileRelease)
+	ret0, _ := ret[0].(*model.ConfigFileRelease)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateConfigFileGrayRelease indicates an expected call of CreateConfigFileGrayRelease
+func (mr *MockStoreMockRecorder) CreateConfigFileGrayRelease(tx, fileRelease interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateConfigFileGrayRelease", reflect.TypeOf((*MockStore)(nil).CreateConfigFileGrayRelease), tx, fileRelease)
+}
+
+// UpdateConfigFileGrayRelease mocks base method
+func (m *MockStore) UpdateConfigFileGrayRelease(tx store.Tx, fileRelease *model.ConfigFileRelease) (*model.ConfigFileRelease, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateConfigFileGrayRelease", tx, fileRelease)
+	ret0, _ := ret[0].(*model.ConfigFileRelease)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateConfigFileGrayRelease indicates an expected call of UpdateConfigFileGrayRelease
+func (mr *MockStoreMockRecorder) UpdateConfigFileGrayRelease(tx, fileRelease interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateConfigFileGrayRelease", reflect.TypeOf((*MockStore)(nil).UpdateConfigFileGrayRelease), tx, fileRelease)
+}
+
+// GetConfigFileGrayRelease mocks base method
+func (m *MockStore) GetConfigFileGrayRelease(tx store.Tx, namespace, group, fileName string) (*model.ConfigFileRelease, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConfigFileGrayRelease", tx, namespace, group, fileName)
+	ret0, _ := ret[0].(*model.ConfigFileRelease)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetConfigFileGrayRelease indicates an expected call of GetConfigFileGrayRelease
+func (mr *MockStoreMockRecorder) GetConfigFileGrayRelease(tx, namespace, group, fileName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConfigFileGrayRelease", reflect.TypeOf((*MockStore)(nil).GetConfigFileGrayRelease), tx, namespace, group, fileName)
+}
+
+// GetConfigFileGrayReleaseWithAllFlag mocks base method
+func (m *MockStore) GetConfigFileGrayReleaseWithAllFlag(tx store.Tx, namespace, group, fileName string) (*model.ConfigFileRelease, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConfigFileGrayReleaseWithAllFlag", tx, namespace, group, fileName)
+	ret0, _ := ret[0].(*model.ConfigFileRelease)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetConfigFileGrayReleaseWithAllFlag indicates an expected call of GetConfigFileGrayReleaseWithAllFlag
+func (mr *MockStoreMockRecorder) GetConfigFileGrayReleaseWithAllFlag(tx, namespace, group, fileName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConfigFileGrayReleaseWithAllFlag", reflect.TypeOf((*MockStore)(nil).GetConfigFileGrayReleaseWithAllFlag), tx, namespace, group, fileName)
+}
+
+// DeleteConfigFileGrayRelease mocks base method
+func (m *MockStore) DeleteConfigFileGrayRelease(tx store.Tx, namespace, group, fileName, deleteBy string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteConfigFileGrayRelease", tx, namespace, group, fileName, deleteBy)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteConfigFileGrayRelease indicates an expected call of DeleteConfigFileGrayRelease
+func (mr *MockStoreMockRecorder) DeleteConfigFileGrayRelease(tx, namespace, group, fileName, deleteBy interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteConfigFileGrayRelease", reflect.TypeOf((*MockStore)(nil).DeleteConfigFileGrayRelease), tx, namespace, group, fileName, deleteBy)
+}
+
+// FindConfigFileGrayReleaseByModifyTimeAfter mocks base method
+func (m *MockStore) FindConfigFileGrayReleaseByModifyTimeAfter(modifyTime time.Time) ([]*model.ConfigFileRelease, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindConfigFileGrayReleaseByModifyTimeAfter", modifyTime)
+	ret0, _ := ret[0].([]*model.ConfigFileRelease)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindConfigFileGrayReleaseByModifyTimeAfter indicates an expected call of FindConfigFileGrayReleaseByModifyTimeAfter
+func (mr *MockStoreMockRecorder) FindConfigFileGrayReleaseByModifyTimeAfter(modifyTime interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindConfigFileGrayReleaseByModifyTimeAfter", reflect.TypeOf((*MockStore)(nil).FindConfigFileGrayReleaseByModifyTimeAfter), modifyTime)
+}
+
 // CreateConfigFileReleaseHistory mocks base method
 func (m *MockStore) CreateConfigFileReleaseHistory(tx store.Tx, fileReleaseHistory *model.ConfigFileReleaseHistory) error {
 	m.ctrl.T.Helper()
@@ -2078,6 +2167,21 @@ func (mr *MockStoreMockRecorder) GetLatestConfigFileReleaseHistory(namespace, gr
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestConfigFileReleaseHistory", reflect.TypeOf((*MockStore)(nil).GetLatestConfigFileReleaseHistory), namespace, group, fileName)
 }
 
+// GetConfigFileReleaseHistory mocks base method
+func (m *MockStore) GetConfigFileReleaseHistory(id uint64) (*model.ConfigFileReleaseHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConfigFileReleaseHistory", id)
+	ret0, _ := ret[0].(*model.ConfigFileReleaseHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetConfigFileReleaseHistory indicates an expected call of GetConfigFileReleaseHistory
+func (mr *MockStoreMockRecorder) GetConfigFileReleaseHistory(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConfigFileReleaseHistory", reflect.TypeOf((*MockStore)(nil).GetConfigFileReleaseHistory), id)
+}
+
 // CreateConfigFileTag mocks base method
 func (m *MockStore) CreateConfigFileTag(tx store.Tx, fileTag *model.ConfigFileTag) error {
 	m.ctrl.T.Helper()
@@ -2155,6 +2259,125 @@ func (mr *MockStoreMockRecorder) DeleteTagByConfigFile(tx, namespace, group, fil
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTagByConfigFile", reflect.TypeOf((*MockStore)(nil).DeleteTagByConfigFile), tx, namespace, group, fileName)
 }
 
+// UpsertConfigFileListeners mocks base method
+func (m *MockStore) UpsertConfigFileListeners(listeners []*model.ConfigFileListener) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertConfigFileListeners", listeners)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertConfigFileListeners indicates an expected call of UpsertConfigFileListeners
+func (mr *MockStoreMockRecorder) UpsertConfigFileListeners(listeners interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertConfigFileListeners", reflect.TypeOf((*MockStore)(nil).UpsertConfigFileListeners), listeners)
+}
+
+// QueryConfigFileListeners mocks base method
+func (m *MockStore) QueryConfigFileListeners(namespace, group, fileName string, offset, limit uint32) (uint32, []*model.ConfigFileListener, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryConfigFileListeners", namespace, group, fileName, offset, limit)
+	ret0, _ := ret[0].(uint32)
+	ret1, _ := ret[1].([]*model.ConfigFileListener)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// QueryConfigFileListeners indicates an expected call of QueryConfigFileListeners
+func (mr *MockStoreMockRecorder) QueryConfigFileListeners(namespace, group, fileName, offset, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryConfigFileListeners", reflect.TypeOf((*MockStore)(nil).QueryConfigFileListeners), namespace, group, fileName, offset, limit)
+}
+
+// DeleteExpiredConfigFileListeners mocks base method
+func (m *MockStore) DeleteExpiredConfigFileListeners(modifyTime time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpiredConfigFileListeners", modifyTime)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteExpiredConfigFileListeners indicates an expected call of DeleteExpiredConfigFileListeners
+func (mr *MockStoreMockRecorder) DeleteExpiredConfigFileListeners(modifyTime interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiredConfigFileListeners", reflect.TypeOf((*MockStore)(nil).DeleteExpiredConfigFileListeners), modifyTime)
+}
+
+// CreateConfigFilePublishRequest mocks base method
+func (m *MockStore) CreateConfigFilePublishRequest(request *model.ConfigFilePublishRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateConfigFilePublishRequest", request)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateConfigFilePublishRequest indicates an expected call of CreateConfigFilePublishRequest
+func (mr *MockStoreMockRecorder) CreateConfigFilePublishRequest(request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateConfigFilePublishRequest", reflect.TypeOf((*MockStore)(nil).CreateConfigFilePublishRequest), request)
+}
+
+// GetConfigFilePublishRequest mocks base method
+func (m *MockStore) GetConfigFilePublishRequest(id string) (*model.ConfigFilePublishRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConfigFilePublishRequest", id)
+	ret0, _ := ret[0].(*model.ConfigFilePublishRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetConfigFilePublishRequest indicates an expected call of GetConfigFilePublishRequest
+func (mr *MockStoreMockRecorder) GetConfigFilePublishRequest(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConfigFilePublishRequest", reflect.TypeOf((*MockStore)(nil).GetConfigFilePublishRequest), id)
+}
+
+// QueryConfigFilePublishRequests mocks base method
+func (m *MockStore) QueryConfigFilePublishRequests(namespace, group, fileName, status string, offset, limit uint32) (uint32, []*model.ConfigFilePublishRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryConfigFilePublishRequests", namespace, group, fileName, status, offset, limit)
+	ret0, _ := ret[0].(uint32)
+	ret1, _ := ret[1].([]*model.ConfigFilePublishRequest)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// QueryConfigFilePublishRequests indicates an expected call of QueryConfigFilePublishRequests
+func (mr *MockStoreMockRecorder) QueryConfigFilePublishRequests(namespace, group, fileName, status, offset, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryConfigFilePublishRequests", reflect.TypeOf((*MockStore)(nil).QueryConfigFilePublishRequests), namespace, group, fileName, status, offset, limit)
+}
+
+// UpdateConfigFilePublishRequest mocks base method
+func (m *MockStore) UpdateConfigFilePublishRequest(request *model.ConfigFilePublishRequest, fromStatus string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateConfigFilePublishRequest", request, fromStatus)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateConfigFilePublishRequest indicates an expected call of UpdateConfigFilePublishRequest
+func (mr *MockStoreMockRecorder) UpdateConfigFilePublishRequest(request, fromStatus interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateConfigFilePublishRequest", reflect.TypeOf((*MockStore)(nil).UpdateConfigFilePublishRequest), request, fromStatus)
+}
+
+// FindDueConfigFilePublishRequests mocks base method
+func (m *MockStore) FindDueConfigFilePublishRequests(planTime time.Time) ([]*model.ConfigFilePublishRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindDueConfigFilePublishRequests", planTime)
+	ret0, _ := ret[0].([]*model.ConfigFilePublishRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindDueConfigFilePublishRequests indicates an expected call of FindDueConfigFilePublishRequests
+func (mr *MockStoreMockRecorder) FindDueConfigFilePublishRequests(planTime interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindDueConfigFilePublishRequests", reflect.TypeOf((*MockStore)(nil).FindDueConfigFilePublishRequests), planTime)
+}
+
 // BatchAddClients mocks base method
 func (m *MockStore) BatchAddClients(clients []*model.Client) error {
 	m.ctrl.T.Helper()
@@ -20,7 +20,6 @@ package sqldb
 import (
 	"context"
 	"database/sql"
-	"fmt"
 	"strings"
 	"time"
 
@@ -28,7 +27,7 @@ import (
 )
 
 // db抛出的异常，需要重试的字符串组
-var errMsg = []string{"Deadlock", "bad connection", "invalid connection"}
+var errMsg = []string{"Deadlock", "deadlock detected", "database is locked", "bad connection", "invalid connection"}
 
 // BaseDB 对sql.DB的封装
 type BaseDB struct {
@@ -36,6 +35,7 @@ type BaseDB struct {
 	cfg            *dbConfig
 	isolationLevel sql.IsolationLevel
 	parsePwd       plugin.ParsePassword
+	dialect        dialect
 }
 
 // dbConfig store的配置
@@ -49,11 +49,16 @@ type dbConfig struct {
 	maxIdleConns     int
 	connMaxLifetime  int
 	txIsolationLevel int
+	sslMode          string
 }
 
 // NewBaseDB 新建一个BaseDB
 func NewBaseDB(cfg *dbConfig, parsePwd plugin.ParsePassword) (*BaseDB, error) {
-	baseDb := &BaseDB{cfg: cfg, parsePwd: parsePwd}
+	d, err := getDialect(cfg.dbType)
+	if err != nil {
+		return nil, err
+	}
+	baseDb := &BaseDB{cfg: cfg, parsePwd: parsePwd, dialect: d}
 	if cfg.txIsolationLevel > 0 {
 		baseDb.isolationLevel = sql.IsolationLevel(cfg.txIsolationLevel)
 		log.Infof("[Store][database] use isolation level: %s", baseDb.isolationLevel.String())
@@ -80,9 +85,7 @@ func (b *BaseDB) openDatabase() error {
 		c.dbPwd = pwd
 	}
 
-	dns := fmt.Sprintf("%s:%s@tcp(%s)/%s", c.dbUser, c.dbPwd, c.dbAddr, c.dbName)
-
-	db, err := sql.Open(c.dbType, dns)
+	db, err := sql.Open(b.dialect.DriverName(), b.dialect.DSN(c))
 	if err != nil {
 		log.Errorf("[Store][database] sql open err: %s", err.Error())
 		return err
@@ -112,6 +115,7 @@ func (b *BaseDB) openDatabase() error {
 func (b *BaseDB) Exec(query string, args ...interface{}) (sql.Result, error) {
 	var result sql.Result
 	var err error
+	query, args = b.dialect.Rebind(query), b.dialect.BindArgs(args)
 	Retry("exec "+query, func() error {
 		result, err = b.DB.Exec(query, args...)
 		return err
@@ -124,6 +128,7 @@ func (b *BaseDB) Exec(query string, args ...interface{}) (sql.Result, error) {
 func (b *BaseDB) Query(query string, args ...interface{}) (*sql.Rows, error) {
 	var rows *sql.Rows
 	var err error
+	query, args = b.dialect.Rebind(query), b.dialect.BindArgs(args)
 	Retry("query "+query, func() error {
 		rows, err = b.DB.Query(query, args...)
 		return err
@@ -132,6 +137,16 @@ func (b *BaseDB) Query(query string, args ...interface{}) (*sql.Rows, error) {
 	return rows, err
 }
 
+// QueryRow 重写db.QueryRow函数
+func (b *BaseDB) QueryRow(query string, args ...interface{}) *sql.Row {
+	return b.DB.QueryRow(b.dialect.Rebind(query), b.dialect.BindArgs(args)...)
+}
+
+// Prepare 重写db.Prepare函数
+func (b *BaseDB) Prepare(query string) (*sql.Stmt, error) {
+	return b.DB.Prepare(b.dialect.Rebind(query))
+}
+
 // Begin 重写db.Begin
 func (b *BaseDB) Begin() (*BaseTx, error) {
 	var tx *sql.Tx
@@ -145,12 +160,33 @@ func (b *BaseDB) Begin() (*BaseTx, error) {
 		return err
 	})
 
-	return &BaseTx{Tx: tx}, err
+	return &BaseTx{Tx: tx, dialect: b.dialect}, err
 }
 
 // BaseTx 对sql.Tx的封装
 type BaseTx struct {
 	*sql.Tx
+	dialect dialect
+}
+
+// Exec 重写tx.Exec函数，按照方言改写SQL
+func (t *BaseTx) Exec(query string, args ...interface{}) (sql.Result, error) {
+	return t.Tx.Exec(t.dialect.Rebind(query), t.dialect.BindArgs(args)...)
+}
+
+// Query 重写tx.Query函数，按照方言改写SQL
+func (t *BaseTx) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	return t.Tx.Query(t.dialect.Rebind(query), t.dialect.BindArgs(args)...)
+}
+
+// QueryRow 重写tx.QueryRow函数，按照方言改写SQL
+func (t *BaseTx) QueryRow(query string, args ...interface{}) *sql.Row {
+	return t.Tx.QueryRow(t.dialect.Rebind(query), t.dialect.BindArgs(args)...)
+}
+
+// Prepare 重写tx.Prepare函数，按照方言改写SQL
+func (t *BaseTx) Prepare(query string) (*sql.Stmt, error) {
+	return t.Tx.Prepare(t.dialect.Rebind(query))
 }
 
 // Retry 重试主函数
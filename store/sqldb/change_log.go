@@ -0,0 +1,268 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package sqldb
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/polarismesh/polaris-server/common/model"
+	"github.com/polarismesh/polaris-server/store"
+)
+
+const (
+	// DefaultChangeLogRetention 变更日志默认的保留时间，单位秒
+	DefaultChangeLogRetention = 3600
+	// changeLogCleanInterval 清理过期变更日志的间隔
+	changeLogCleanInterval = time.Minute
+)
+
+// changeLogTables 资源对应的变更日志表，每类资源单独使用一个自增序号
+var changeLogTables = map[string]string{
+	store.ChangeLogService:  "service_change_log",
+	store.ChangeLogInstance: "instance_change_log",
+}
+
+// execer 执行写入，BaseDB 和 BaseTx 都实现了该接口
+type execer interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+}
+
+// changeLogStore 实现了ChangeLogStore
+type changeLogStore struct {
+	master    *BaseDB
+	slave     *replicaSet
+	enable    bool
+	retention time.Duration
+	stopCh    chan struct{}
+}
+
+// newChangeLogStore 创建变更日志存储，开启时启动过期日志的清理
+func newChangeLogStore(master *BaseDB, slave *replicaSet, enable bool, retention time.Duration) *changeLogStore {
+	c := &changeLogStore{
+		master:    master,
+		slave:     slave,
+		enable:    enable,
+		retention: retention,
+		stopCh:    make(chan struct{}),
+	}
+	if enable {
+		go c.cleanLoop()
+	}
+	return c
+}
+
+// ChangeLogEnabled 是否开启了变更日志
+func (c *changeLogStore) ChangeLogEnabled() bool {
+	return c.enable
+}
+
+// record 追加变更记录，需要与资源的写入使用同一个事务，未开启变更日志时不做任何操作
+func (c *changeLogStore) record(e execer, resource string, ids ...interface{}) error {
+	if c == nil || !c.enable || len(ids) == 0 {
+		return nil
+	}
+	return BatchOperation("record-change-log", ids, func(objects []interface{}) error {
+		str := fmt.Sprintf("insert into %s(id) values %s", changeLogTables[resource],
+			strings.TrimSuffix(strings.Repeat("(?),", len(objects)), ","))
+		if _, err := e.Exec(str, objects...); err != nil {
+			log.Errorf("[Store][database] record %s change log err: %s", resource, err.Error())
+			return err
+		}
+		return nil
+	})
+}
+
+// recordBySelect 根据查询语句追加变更记录，适用于按照名字等条件批量修改的场景
+func (c *changeLogStore) recordBySelect(e execer, resource string, selectSQL string, args ...interface{}) error {
+	if c == nil || !c.enable {
+		return nil
+	}
+	str := fmt.Sprintf("insert into %s(id) %s", changeLogTables[resource], selectSQL)
+	if _, err := e.Exec(str, args...); err != nil {
+		log.Errorf("[Store][database] record %s change log err: %s", resource, err.Error())
+		return err
+	}
+	return nil
+}
+
+// execWithRecord 开启变更日志时，将写入和变更记录放到同一个事务中执行，否则直接在db上执行
+func (c *changeLogStore) execWithRecord(db *BaseDB, handle func(e execer) error) error {
+	if c == nil || !c.enable {
+		return handle(db)
+	}
+	tx, err := db.Begin()
+	if err != nil {
+		log.Errorf("[Store][database] exec with change log begin tx err: %s", err.Error())
+		return err
+	}
+	if err := handle(tx); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// GetLatestChangeSeq 获取资源当前最大的变更序号
+func (c *changeLogStore) GetLatestChangeSeq(resource string) (uint64, error) {
+	table, ok := changeLogTables[resource]
+	if !ok {
+		return 0, fmt.Errorf("change log of %s not found", resource)
+	}
+	var seq uint64
+	err := c.master.QueryRow("select COALESCE(max(seq), 0) from " + table).Scan(&seq)
+	if err != nil {
+		log.Errorf("[Store][database] get latest %s change seq err: %s", resource, err.Error())
+		return 0, err
+	}
+	return seq, nil
+}
+
+// GetServiceChanges 获取服务的变更记录以及对应的服务
+func (c *changeLogStore) GetServiceChanges(seq uint64, limit uint32, needMeta bool) (
+	[]*model.ChangeLog, map[string]*model.Service, error) {
+	var services map[string]*model.Service
+	logs, err := c.getChanges(store.ChangeLogService, seq, limit, func(tx *BaseTx, ids []interface{}) error {
+		var err error
+		services, err = getServicesByIDs(tx.Query, ids, needMeta)
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return logs, services, nil
+}
+
+// GetInstanceChanges 获取实例的变更记录以及对应的实例
+func (c *changeLogStore) GetInstanceChanges(seq uint64, limit uint32, needMeta bool) (
+	[]*model.ChangeLog, map[string]*model.Instance, error) {
+	var instances map[string]*model.Instance
+	logs, err := c.getChanges(store.ChangeLogInstance, seq, limit, func(tx *BaseTx, ids []interface{}) error {
+		var err error
+		instances, err = getInstancesByIDs(tx.Query, ids, needMeta)
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return logs, instances, nil
+}
+
+// getChanges 在同一个只读事务中查询变更记录以及对应的资源，保证两者读取的是同一个库
+func (c *changeLogStore) getChanges(resource string, seq uint64, limit uint32,
+	fetch func(tx *BaseTx, ids []interface{}) error) ([]*model.ChangeLog, error) {
+	tx, err := c.slave.Begin()
+	if err != nil {
+		log.Errorf("[Store][database] get %s changes begin tx err: %s", resource, err.Error())
+		return nil, err
+	}
+	defer func() { _ = tx.Commit() }()
+
+	str := fmt.Sprintf("select seq, id, UNIX_TIMESTAMP(ctime) from %s where seq > ? order by seq limit ?",
+		changeLogTables[resource])
+	rows, err := tx.Query(str, seq, limit)
+	if err != nil {
+		log.Errorf("[Store][database] get %s changes query err: %s", resource, err.Error())
+		return nil, err
+	}
+	logs, err := fetchChangeLogRows(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(logs) == 0 {
+		return logs, nil
+	}
+
+	ids := make([]interface{}, 0, len(logs))
+	exists := make(map[string]bool, len(logs))
+	for _, entry := range logs {
+		if !exists[entry.ID] {
+			exists[entry.ID] = true
+			ids = append(ids, entry.ID)
+		}
+	}
+	if err := fetch(tx, ids); err != nil {
+		log.Errorf("[Store][database] get %s changes fetch err: %s", resource, err.Error())
+		return nil, err
+	}
+	return logs, nil
+}
+
+// fetchChangeLogRows 读取变更记录
+func fetchChangeLogRows(rows *sql.Rows) ([]*model.ChangeLog, error) {
+	defer rows.Close()
+	out := make([]*model.ChangeLog, 0)
+	for rows.Next() {
+		var entry model.ChangeLog
+		var ctime int64
+		if err := rows.Scan(&entry.Seq, &entry.ID, &ctime); err != nil {
+			log.Errorf("[Store][database] fetch change log rows scan err: %s", err.Error())
+			return nil, err
+		}
+		entry.CreateTime = time.Unix(ctime, 0)
+		out = append(out, &entry)
+	}
+	if err := rows.Err(); err != nil {
+		log.Errorf("[Store][database] fetch change log rows next err: %s", err.Error())
+		return nil, err
+	}
+	return out, nil
+}
+
+// cleanLoop 定时清理过期的变更日志
+func (c *changeLogStore) cleanLoop() {
+	ticker := time.NewTicker(changeLogCleanInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			c.clean()
+		case <-c.stopCh:
+			return
+		}
+	}
+}
+
+// clean 清理超过保留时间的变更日志，保留最新的一条记录，避免清理后最大序号回退
+func (c *changeLogStore) clean() {
+	expire := time.Now().Add(-c.retention).Unix()
+	for resource, table := range changeLogTables {
+		latest, err := c.GetLatestChangeSeq(resource)
+		if err != nil {
+			continue
+		}
+		result, err := c.master.Exec("delete from "+table+" where ctime < FROM_UNIXTIME(?) and seq < ?",
+			expire, latest)
+		if err != nil {
+			log.Errorf("[Store][database] clean %s change log err: %s", resource, err.Error())
+			continue
+		}
+		if n, _ := result.RowsAffected(); n > 0 {
+			log.Infof("[Store][database] clean %d %s change logs before %d", n, resource, expire)
+		}
+	}
+}
+
+// stop 停止清理
+func (c *changeLogStore) stop() {
+	if c.enable {
+		close(c.stopCh)
+	}
+}
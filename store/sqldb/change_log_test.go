@@ -0,0 +1,123 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package sqldb
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/polarismesh/polaris-server/common/model"
+	"github.com/polarismesh/polaris-server/store"
+)
+
+// TestChangeLog 使用sqlite测试服务和实例的变更日志
+func TestChangeLog(t *testing.T) {
+	if !SQLiteEnabled {
+		t.Skip("sqlite store requires cgo")
+	}
+	dir, err := ioutil.TempDir("", "polaris-change-log")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	s := &stableStore{name: SQLiteStoreName, dbType: SQLiteDialect}
+	err = s.Initialize(&store.Config{
+		Name:   SQLiteStoreName,
+		Option: map[string]interface{}{"path": filepath.Join(dir, "polaris.db"), "changeLog": true},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Destroy()
+
+	var cs store.ChangeLogStore = s
+	if !cs.ChangeLogEnabled() {
+		t.Fatal("change log should be enabled")
+	}
+	if err := s.AddNamespace(&model.Namespace{Name: "cl", Token: "t", Owner: "polaris"}); err != nil {
+		t.Fatal(err)
+	}
+	svc := &model.Service{ID: "cl-svc", Name: "cl-svc", Namespace: "cl", Token: "t", Owner: "polaris",
+		Revision: "r1", Meta: map[string]string{"k": "v"}}
+
+	Convey("服务的写入记录变更", t, func() {
+		So(s.AddService(svc), ShouldBeNil)
+		So(s.UpdateServiceToken(svc.ID, "t2", "r2"), ShouldBeNil)
+
+		latest, err := cs.GetLatestChangeSeq(store.ChangeLogService)
+		So(err, ShouldBeNil)
+		So(latest, ShouldEqual, 2)
+
+		logs, services, err := cs.GetServiceChanges(0, 10, true)
+		So(err, ShouldBeNil)
+		So(len(logs), ShouldEqual, 2)
+		So(logs[0].Seq, ShouldBeLessThan, logs[1].Seq)
+		So(logs[1].ID, ShouldEqual, svc.ID)
+		So(services[svc.ID].Token, ShouldEqual, "t2")
+		So(services[svc.ID].Meta["k"], ShouldEqual, "v")
+
+		So(s.DeleteService(svc.ID, svc.Name, svc.Namespace), ShouldBeNil)
+		logs, services, err = cs.GetServiceChanges(latest, 10, false)
+		So(err, ShouldBeNil)
+		So(len(logs), ShouldEqual, 1)
+		So(services[svc.ID].Valid, ShouldBeFalse)
+	})
+
+	Convey("实例的写入记录变更", t, func() {
+		So(s.AddService(&model.Service{ID: "cl-svc2", Name: "cl-svc2", Namespace: "cl", Token: "t",
+			Owner: "polaris", Revision: "r1"}), ShouldBeNil)
+		So(s.BatchAddInstances([]*model.Instance{
+			newTestInstance("cl-svc2", "cl-ins1", "127.0.0.1", 8080),
+			newTestInstance("cl-svc2", "cl-ins2", "127.0.0.1", 8081),
+		}), ShouldBeNil)
+		So(s.SetInstanceHealthStatus("cl-ins1", 0, "r2"), ShouldBeNil)
+		So(s.BatchDeleteInstances([]interface{}{"cl-ins2"}), ShouldBeNil)
+
+		logs, instances, err := cs.GetInstanceChanges(0, 10, true)
+		So(err, ShouldBeNil)
+		So(len(logs), ShouldEqual, 4)
+		So(len(instances), ShouldEqual, 2)
+		So(instances["cl-ins1"].Healthy(), ShouldBeFalse)
+		So(instances["cl-ins1"].Metadata()["k"], ShouldEqual, "v")
+		So(instances["cl-ins2"].Valid, ShouldBeFalse)
+
+		// 按照序号分页拉取
+		logs, _, err = cs.GetInstanceChanges(logs[1].Seq, 1, false)
+		So(err, ShouldBeNil)
+		So(len(logs), ShouldEqual, 1)
+		So(logs[0].ID, ShouldEqual, "cl-ins1")
+	})
+
+	Convey("清理过期的变更日志", t, func() {
+		s.changeLogStore.retention = -time.Hour
+		s.changeLogStore.clean()
+		// 保留最新的一条，保证清理后序号不会回退
+		logs, _, err := cs.GetInstanceChanges(0, 10, false)
+		So(err, ShouldBeNil)
+		So(len(logs), ShouldEqual, 1)
+		latest, err := cs.GetLatestChangeSeq(store.ChangeLogInstance)
+		So(err, ShouldBeNil)
+		So(latest, ShouldEqual, logs[0].Seq)
+	})
+}
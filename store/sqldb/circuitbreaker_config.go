@@ -30,7 +30,7 @@ import (
 // circuitBreakerStore 的实现
 type circuitBreakerStore struct {
 	master *BaseDB
-	slave  *BaseDB
+	slave  *replicaSet
 }
 
 // CreateCircuitBreaker 创建一个新的熔断规则
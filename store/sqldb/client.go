@@ -31,7 +31,7 @@ import (
 
 type clientStore struct {
 	master *BaseDB
-	slave  *BaseDB // 缓存相关的读取，请求到slave
+	slave  *replicaSet // 缓存相关的读取，请求到slave
 }
 
 // CreateClient insert the client info
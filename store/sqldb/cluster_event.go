@@ -0,0 +1,93 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package sqldb
+
+import (
+	"time"
+
+	"github.com/polarismesh/polaris-server/common/model"
+	"github.com/polarismesh/polaris-server/store"
+)
+
+// clusterEventStore 实现了 ClusterEventStore，事件需要尽快被其他节点感知，读写都使用主库
+type clusterEventStore struct {
+	master *BaseDB
+}
+
+// AppendClusterEvent 追加一条事件
+func (c *clusterEventStore) AppendClusterEvent(topic, payload string) error {
+	_, err := c.master.Exec("insert into cluster_event(topic, payload, ctime) values(?, ?, sysdate())", topic, payload)
+	if err != nil {
+		log.Errorf("[Store][database] append cluster event err: %s", err.Error())
+		return store.Error(err)
+	}
+	return nil
+}
+
+// GetLatestClusterEventSeq 获取当前最大的事件序号
+func (c *clusterEventStore) GetLatestClusterEventSeq() (uint64, error) {
+	var seq uint64
+	if err := c.master.QueryRow("select COALESCE(max(seq), 0) from cluster_event").Scan(&seq); err != nil {
+		log.Errorf("[Store][database] get latest cluster event seq err: %s", err.Error())
+		return 0, err
+	}
+	return seq, nil
+}
+
+// GetClusterEvents 获取序号大于seq的事件
+func (c *clusterEventStore) GetClusterEvents(seq uint64, limit uint32) ([]*model.ClusterEvent, error) {
+	rows, err := c.master.Query("select seq, topic, payload, UNIX_TIMESTAMP(ctime) from cluster_event "+
+		"where seq > ? order by seq limit ?", seq, limit)
+	if err != nil {
+		log.Errorf("[Store][database] get cluster events err: %s", err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := make([]*model.ClusterEvent, 0)
+	for rows.Next() {
+		event := &model.ClusterEvent{}
+		var ctime int64
+		if err := rows.Scan(&event.Seq, &event.Topic, &event.Payload, &ctime); err != nil {
+			log.Errorf("[Store][database] fetch cluster event rows scan err: %s", err.Error())
+			return nil, err
+		}
+		event.CreateTime = time.Unix(ctime, 0)
+		events = append(events, event)
+	}
+	if err := rows.Err(); err != nil {
+		log.Errorf("[Store][database] fetch cluster event rows next err: %s", err.Error())
+		return nil, err
+	}
+	return events, nil
+}
+
+// CleanClusterEvents 清理过期的事件，保留最新的一条
+func (c *clusterEventStore) CleanClusterEvents(ctime time.Time) error {
+	latest, err := c.GetLatestClusterEventSeq()
+	if err != nil {
+		return err
+	}
+	_, err = c.master.Exec("delete from cluster_event where ctime < FROM_UNIXTIME(?) and seq < ?",
+		ctime.Unix(), latest)
+	if err != nil {
+		log.Errorf("[Store][database] clean cluster events err: %s", err.Error())
+		return store.Error(err)
+	}
+	return nil
+}
@@ -89,7 +89,7 @@ func BatchOperation(label string, data []interface{}, handler BatchHandler) erro
 }
 
 // queryEntryCount 单独查询count个数的执行函数
-func queryEntryCount(conn *BaseDB, str string, args []interface{}) (uint32, error) {
+func queryEntryCount(conn querier, str string, args []interface{}) (uint32, error) {
 	var count uint32
 	var err error
 	Retry("queryRow", func() error {
@@ -26,7 +26,8 @@ import (
 )
 
 type configFileStore struct {
-	db *BaseDB
+	db    *BaseDB
+	slave *replicaSet
 }
 
 // CreateConfigFile 创建配置文件
@@ -36,12 +37,12 @@ func (cf *configFileStore) CreateConfigFile(tx store.Tx, file *model.ConfigFile)
 		return nil, err
 	}
 
-	createSql := "insert into config_file(name,namespace,`group`,content,comment,format,create_time,create_by,modify_time,modify_by) values " +
-		"(?,?,?,?,?,?,sysdate(),?,sysdate(),?)"
+	createSql := "insert into config_file(name,namespace,`group`,content,comment,format,encrypted,base_file,variables_file,create_time,create_by,modify_time,modify_by) values " +
+		"(?,?,?,?,?,?,?,?,?,sysdate(),?,sysdate(),?)"
 	if tx != nil {
-		_, err = tx.GetDelegateTx().(*BaseTx).Exec(createSql, file.Name, file.Namespace, file.Group, file.Content, file.Comment, file.Format, file.CreateBy, file.ModifyBy)
+		_, err = tx.GetDelegateTx().(*BaseTx).Exec(createSql, file.Name, file.Namespace, file.Group, file.Content, file.Comment, file.Format, boolToInt(file.Encrypted), file.BaseFile, file.VariablesFile, file.CreateBy, file.ModifyBy)
 	} else {
-		_, err = cf.db.Exec(createSql, file.Name, file.Namespace, file.Group, file.Content, file.Comment, file.Format, file.CreateBy, file.ModifyBy)
+		_, err = cf.db.Exec(createSql, file.Name, file.Namespace, file.Group, file.Content, file.Comment, file.Format, boolToInt(file.Encrypted), file.BaseFile, file.VariablesFile, file.CreateBy, file.ModifyBy)
 	}
 	if err != nil {
 		return nil, store.Error(err)
@@ -83,13 +84,13 @@ func (cf *configFileStore) QueryConfigFiles(namespace, group, name string, offse
 		countSql := "select count(*) from config_file where `group` like ? and name like ? and flag = 0"
 
 		var count uint32
-		err := cf.db.QueryRow(countSql, group, name).Scan(&count)
+		err := cf.slave.console().QueryRow(countSql, group, name).Scan(&count)
 		if err != nil {
 			return 0, nil, err
 		}
 
 		querySql := cf.baseSelectConfigFileSql() + "where `group` like ? and name like ? and flag = 0 order by id desc limit ?,?"
-		rows, err := cf.db.Query(querySql, group, name, offset, limit)
+		rows, err := cf.slave.console().Query(querySql, group, name, offset, limit)
 		if err != nil {
 			return 0, nil, err
 		}
@@ -108,13 +109,13 @@ func (cf *configFileStore) QueryConfigFiles(namespace, group, name string, offse
 	countSql := "select count(*) from config_file where namespace = ? and `group` like ? and name like ? and flag = 0"
 
 	var count uint32
-	err := cf.db.QueryRow(countSql, namespace, group, name).Scan(&count)
+	err := cf.slave.console().QueryRow(countSql, namespace, group, name).Scan(&count)
 	if err != nil {
 		return 0, nil, err
 	}
 
 	querySql := cf.baseSelectConfigFileSql() + "where namespace = ? and `group` like ? and name like ? and flag = 0 order by id desc limit ?,?"
-	rows, err := cf.db.Query(querySql, namespace, group, name, offset, limit)
+	rows, err := cf.slave.console().Query(querySql, namespace, group, name, offset, limit)
 	if err != nil {
 		return 0, nil, err
 	}
@@ -130,12 +131,12 @@ func (cf *configFileStore) QueryConfigFiles(namespace, group, name string, offse
 
 // UpdateConfigFile 更新配置文件
 func (cf *configFileStore) UpdateConfigFile(tx store.Tx, file *model.ConfigFile) (*model.ConfigFile, error) {
-	updateSql := "update config_file set content = ? , comment = ?, format = ?, modify_time = sysdate(), modify_by = ? where namespace = ? and `group` = ? and name = ?"
+	updateSql := "update config_file set content = ? , comment = ?, format = ?, encrypted = ?, base_file = ?, variables_file = ?, modify_time = sysdate(), modify_by = ? where namespace = ? and `group` = ? and name = ?"
 	var err error
 	if tx != nil {
-		_, err = tx.GetDelegateTx().(*BaseTx).Exec(updateSql, file.Content, file.Comment, file.Format, file.ModifyBy, file.Namespace, file.Group, file.Name)
+		_, err = tx.GetDelegateTx().(*BaseTx).Exec(updateSql, file.Content, file.Comment, file.Format, boolToInt(file.Encrypted), file.BaseFile, file.VariablesFile, file.ModifyBy, file.Namespace, file.Group, file.Name)
 	} else {
-		_, err = cf.db.Exec(updateSql, file.Content, file.Comment, file.Format, file.ModifyBy, file.Namespace, file.Group, file.Name)
+		_, err = cf.db.Exec(updateSql, file.Content, file.Comment, file.Format, boolToInt(file.Encrypted), file.BaseFile, file.VariablesFile, file.ModifyBy, file.Namespace, file.Group, file.Name)
 	}
 	if err != nil {
 		return nil, store.Error(err)
@@ -169,7 +170,7 @@ func (cf *configFileStore) CountByConfigFileGroup(namespace, group string) (uint
 }
 
 func (cf *configFileStore) baseSelectConfigFileSql() string {
-	return "select id, name,namespace,`group`,content,IFNULL(comment, ''),format, UNIX_TIMESTAMP(create_time),IFNULL(create_by, ''),UNIX_TIMESTAMP(modify_time),IFNULL(modify_by, '') from config_file "
+	return "select id, name,namespace,`group`,content,IFNULL(comment, ''),format,encrypted,IFNULL(base_file, ''),IFNULL(variables_file, ''), UNIX_TIMESTAMP(create_time),IFNULL(create_by, ''),UNIX_TIMESTAMP(modify_time),IFNULL(modify_by, '') from config_file "
 }
 
 func (cf *configFileStore) hardDeleteConfigFile(namespace, group, name string) error {
@@ -196,10 +197,13 @@ func (cf *configFileStore) transferRows(rows *sql.Rows) ([]*model.ConfigFile, er
 	for rows.Next() {
 		file := &model.ConfigFile{}
 		var ctime, mtime int64
-		err := rows.Scan(&file.Id, &file.Name, &file.Namespace, &file.Group, &file.Content, &file.Comment, &file.Format, &ctime, &file.CreateBy, &mtime, &file.ModifyBy)
+		var encrypted int
+		err := rows.Scan(&file.Id, &file.Name, &file.Namespace, &file.Group, &file.Content, &file.Comment, &file.Format, &encrypted,
+			&file.BaseFile, &file.VariablesFile, &ctime, &file.CreateBy, &mtime, &file.ModifyBy)
 		if err != nil {
 			return nil, err
 		}
+		file.Encrypted = encrypted == 1
 		file.CreateTime = time.Unix(ctime, 0)
 		file.ModifyTime = time.Unix(mtime, 0)
 
@@ -0,0 +1,192 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package sqldb
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/polarismesh/polaris-server/common/model"
+	commontime "github.com/polarismesh/polaris-server/common/time"
+	"github.com/polarismesh/polaris-server/store"
+)
+
+type configFileGrayReleaseStore struct {
+	db    *BaseDB
+	slave *replicaSet
+}
+
+// CreateConfigFileGrayRelease 新建配置文件灰度发布
+func (cfr *configFileGrayReleaseStore) CreateConfigFileGrayRelease(tx store.Tx,
+	fileRelease *model.ConfigFileRelease) (*model.ConfigFileRelease, error) {
+	rule, err := marshalGrayRule(fileRelease.GrayRule)
+	if err != nil {
+		return nil, err
+	}
+	sql := "insert into config_file_gray_release(name, namespace, `group`, file_name, content, comment, md5, version, gray_rule, create_time, create_by, modify_time, modify_by) values" +
+		"(?,?,?,?,?,?,?,?,?, sysdate(),?,sysdate(),?)"
+	args := []interface{}{fileRelease.Name, fileRelease.Namespace, fileRelease.Group, fileRelease.FileName,
+		fileRelease.Content, fileRelease.Comment, fileRelease.Md5, fileRelease.Version, rule, fileRelease.CreateBy,
+		fileRelease.ModifyBy}
+	if tx != nil {
+		_, err = tx.GetDelegateTx().(*BaseTx).Exec(sql, args...)
+	} else {
+		_, err = cfr.db.Exec(sql, args...)
+	}
+	if err != nil {
+		return nil, store.Error(err)
+	}
+	return cfr.GetConfigFileGrayRelease(tx, fileRelease.Namespace, fileRelease.Group, fileRelease.FileName)
+}
+
+// UpdateConfigFileGrayRelease 更新配置文件灰度发布，被删除的灰度发布会重新生效
+func (cfr *configFileGrayReleaseStore) UpdateConfigFileGrayRelease(tx store.Tx,
+	fileRelease *model.ConfigFileRelease) (*model.ConfigFileRelease, error) {
+	rule, err := marshalGrayRule(fileRelease.GrayRule)
+	if err != nil {
+		return nil, err
+	}
+	sql := "update config_file_gray_release set name = ? , content = ?, comment = ?, md5 = ?, version = ?, gray_rule = ?, flag = 0, modify_time = sysdate(), modify_by = ? where namespace = ? and `group` = ? and file_name = ?"
+	args := []interface{}{fileRelease.Name, fileRelease.Content, fileRelease.Comment, fileRelease.Md5,
+		fileRelease.Version, rule, fileRelease.ModifyBy, fileRelease.Namespace, fileRelease.Group, fileRelease.FileName}
+	if tx != nil {
+		_, err = tx.GetDelegateTx().(*BaseTx).Exec(sql, args...)
+	} else {
+		_, err = cfr.db.Exec(sql, args...)
+	}
+	if err != nil {
+		return nil, store.Error(err)
+	}
+	return cfr.GetConfigFileGrayRelease(tx, fileRelease.Namespace, fileRelease.Group, fileRelease.FileName)
+}
+
+// GetConfigFileGrayRelease 获取配置文件灰度发布，只返回 flag=0 的记录
+func (cfr *configFileGrayReleaseStore) GetConfigFileGrayRelease(tx store.Tx,
+	namespace, group, fileName string) (*model.ConfigFileRelease, error) {
+	return cfr.getConfigFileGrayReleaseByFlag(tx, namespace, group, fileName, false)
+}
+
+// GetConfigFileGrayReleaseWithAllFlag 获取配置文件灰度发布，包含已经删除的记录
+func (cfr *configFileGrayReleaseStore) GetConfigFileGrayReleaseWithAllFlag(tx store.Tx,
+	namespace, group, fileName string) (*model.ConfigFileRelease, error) {
+	return cfr.getConfigFileGrayReleaseByFlag(tx, namespace, group, fileName, true)
+}
+
+func (cfr *configFileGrayReleaseStore) getConfigFileGrayReleaseByFlag(tx store.Tx, namespace, group, fileName string,
+	withAllFlag bool) (*model.ConfigFileRelease, error) {
+	querySql := cfr.baseQuerySql() + "where namespace = ? and `group` = ? and file_name = ?"
+	if !withAllFlag {
+		querySql += " and flag = 0"
+	}
+	var rows *sql.Rows
+	var err error
+	if tx != nil {
+		rows, err = tx.GetDelegateTx().(*BaseTx).Query(querySql, namespace, group, fileName)
+	} else {
+		rows, err = cfr.db.Query(querySql, namespace, group, fileName)
+	}
+	if err != nil {
+		return nil, err
+	}
+	fileReleases, err := cfr.transferRows(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(fileReleases) > 0 {
+		return fileReleases[0], nil
+	}
+	return nil, nil
+}
+
+// DeleteConfigFileGrayRelease 删除配置文件灰度发布，保留灰度规则，便于通知命中规则的客户端
+func (cfr *configFileGrayReleaseStore) DeleteConfigFileGrayRelease(tx store.Tx, namespace, group, fileName,
+	deleteBy string) error {
+	sql := "update config_file_gray_release set flag = 1, modify_time = sysdate(), modify_by = ? where namespace = ? and `group` = ? and file_name = ?"
+	var err error
+	if tx != nil {
+		_, err = tx.GetDelegateTx().(*BaseTx).Exec(sql, deleteBy, namespace, group, fileName)
+	} else {
+		_, err = cfr.db.Exec(sql, deleteBy, namespace, group, fileName)
+	}
+	if err != nil {
+		return store.Error(err)
+	}
+	return nil
+}
+
+// FindConfigFileGrayReleaseByModifyTimeAfter 获取最后更新时间大于某个时间点的灰度发布，包含 flag = 1 的记录
+func (cfr *configFileGrayReleaseStore) FindConfigFileGrayReleaseByModifyTimeAfter(
+	modifyTime time.Time) ([]*model.ConfigFileRelease, error) {
+	sql := cfr.baseQuerySql() + " where modify_time > ?"
+	rows, err := cfr.slave.Query(sql, commontime.Time2String(modifyTime))
+	if err != nil {
+		return nil, err
+	}
+	return cfr.transferRows(rows)
+}
+
+func (cfr *configFileGrayReleaseStore) baseQuerySql() string {
+	return "select id, name, namespace, `group`, file_name, content, IFNULL(comment, ''), md5, version, gray_rule, UNIX_TIMESTAMP(create_time), IFNULL(create_by, ''), UNIX_TIMESTAMP(modify_time), IFNULL(modify_by, ''), flag from config_file_gray_release "
+}
+
+func (cfr *configFileGrayReleaseStore) transferRows(rows *sql.Rows) ([]*model.ConfigFileRelease, error) {
+	if rows == nil {
+		return nil, nil
+	}
+	defer rows.Close()
+
+	var fileReleases []*model.ConfigFileRelease
+
+	for rows.Next() {
+		fileRelease := &model.ConfigFileRelease{}
+		var ctime, mtime int64
+		var rule string
+		err := rows.Scan(&fileRelease.Id, &fileRelease.Name, &fileRelease.Namespace, &fileRelease.Group,
+			&fileRelease.FileName, &fileRelease.Content, &fileRelease.Comment, &fileRelease.Md5, &fileRelease.Version,
+			&rule, &ctime, &fileRelease.CreateBy, &mtime, &fileRelease.ModifyBy, &fileRelease.Flag)
+		if err != nil {
+			return nil, err
+		}
+		fileRelease.CreateTime = time.Unix(ctime, 0)
+		fileRelease.ModifyTime = time.Unix(mtime, 0)
+		fileRelease.GrayRule = &model.ConfigFileGrayRule{}
+		if err := json.Unmarshal([]byte(rule), fileRelease.GrayRule); err != nil {
+			return nil, err
+		}
+
+		fileReleases = append(fileReleases, fileRelease)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return fileReleases, nil
+}
+
+func marshalGrayRule(rule *model.ConfigFileGrayRule) (string, error) {
+	if rule == nil {
+		rule = &model.ConfigFileGrayRule{}
+	}
+	data, err := json.Marshal(rule)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
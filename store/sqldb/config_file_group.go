@@ -28,14 +28,16 @@ import (
 )
 
 type configFileGroupStore struct {
-	db *BaseDB
+	db    *BaseDB
+	slave *replicaSet
 }
 
 // CreateConfigFileGroup 创建配置文件组
 func (fg *configFileGroupStore) CreateConfigFileGroup(fileGroup *model.ConfigFileGroup) (*model.ConfigFileGroup, error) {
-	createSql := "insert into config_file_group(name, namespace,comment,create_time, create_by, modify_time, modify_by)" +
-		"value (?,?,?,sysdate(),?,sysdate(),?)"
-	_, err := fg.db.Exec(createSql, fileGroup.Name, fileGroup.Namespace, fileGroup.Comment, fileGroup.CreateBy, fileGroup.ModifyBy)
+	createSql := "insert into config_file_group(name, namespace,comment,json_schema,create_time, create_by, modify_time, modify_by)" +
+		"value (?,?,?,?,sysdate(),?,sysdate(),?)"
+	_, err := fg.db.Exec(createSql, fileGroup.Name, fileGroup.Namespace, fileGroup.Comment, fileGroup.JsonSchema,
+		fileGroup.CreateBy, fileGroup.ModifyBy)
 	if err != nil {
 		return nil, store.Error(err)
 	}
@@ -68,13 +70,13 @@ func (fg *configFileGroupStore) QueryConfigFileGroups(namespace, name string, of
 	if namespace == "" {
 		countSql := "select count(*) from config_file_group where name like ?"
 		var count uint32
-		err := fg.db.QueryRow(countSql, name).Scan(&count)
+		err := fg.slave.console().QueryRow(countSql, name).Scan(&count)
 		if err != nil {
 			return count, nil, err
 		}
 
 		sql := fg.genConfigFileGroupSelectSql() + " where name like ? order by id desc limit ?,?"
-		rows, err := fg.db.Query(sql, name, offset, limit)
+		rows, err := fg.slave.console().Query(sql, name, offset, limit)
 		if err != nil {
 			return 0, nil, err
 		}
@@ -89,13 +91,13 @@ func (fg *configFileGroupStore) QueryConfigFileGroups(namespace, name string, of
 	// 特定 namespace
 	countSql := "select count(*) from config_file_group where namespace=? and name like ?"
 	var count uint32
-	err := fg.db.QueryRow(countSql, namespace, name).Scan(&count)
+	err := fg.slave.console().QueryRow(countSql, namespace, name).Scan(&count)
 	if err != nil {
 		return count, nil, err
 	}
 
 	sql := fg.genConfigFileGroupSelectSql() + " where namespace=? and name like ? order by id desc limit ?,? "
-	rows, err := fg.db.Query(sql, namespace, name, offset, limit)
+	rows, err := fg.slave.console().Query(sql, namespace, name, offset, limit)
 	if err != nil {
 		return 0, nil, err
 	}
@@ -121,8 +123,10 @@ func (fg *configFileGroupStore) DeleteConfigFileGroup(namespace, name string) er
 
 // UpdateConfigFileGroup 更新配置文件组信息
 func (fg *configFileGroupStore) UpdateConfigFileGroup(fileGroup *model.ConfigFileGroup) (*model.ConfigFileGroup, error) {
-	updateSql := "update config_file_group set comment = ?, modify_time = sysdate(), modify_by = ? where namespace = ? and name = ?"
-	_, err := fg.db.Exec(updateSql, fileGroup.Comment, fileGroup.ModifyBy, fileGroup.Namespace, fileGroup.Name)
+	updateSql := "update config_file_group set comment = ?, json_schema = ?, modify_time = sysdate(), modify_by = ? " +
+		"where namespace = ? and name = ?"
+	_, err := fg.db.Exec(updateSql, fileGroup.Comment, fileGroup.JsonSchema, fileGroup.ModifyBy, fileGroup.Namespace,
+		fileGroup.Name)
 	if err != nil {
 		return nil, store.Error(err)
 	}
@@ -160,7 +164,7 @@ func (fg *configFileGroupStore) FindConfigFileGroups(namespace string, names []s
 }
 
 func (fg *configFileGroupStore) genConfigFileGroupSelectSql() string {
-	return "select id,name,namespace,IFNULL(comment,''),UNIX_TIMESTAMP(create_time),IFNULL(create_by,'')," +
+	return "select id,name,namespace,IFNULL(comment,''),IFNULL(json_schema,''),UNIX_TIMESTAMP(create_time),IFNULL(create_by,'')," +
 		"UNIX_TIMESTAMP(modify_time),IFNULL(modify_by,'') from config_file_group"
 }
 
@@ -175,7 +179,8 @@ func (fg *configFileGroupStore) transferRows(rows *sql.Rows) ([]*model.ConfigFil
 	for rows.Next() {
 		fileGroup := &model.ConfigFileGroup{}
 		var ctime, mtime int64
-		err := rows.Scan(&fileGroup.Id, &fileGroup.Name, &fileGroup.Namespace, &fileGroup.Comment, &ctime, &fileGroup.CreateBy, &mtime, &fileGroup.ModifyBy)
+		err := rows.Scan(&fileGroup.Id, &fileGroup.Name, &fileGroup.Namespace, &fileGroup.Comment, &fileGroup.JsonSchema,
+			&ctime, &fileGroup.CreateBy, &mtime, &fileGroup.ModifyBy)
 		if err != nil {
 			return nil, err
 		}
@@ -0,0 +1,144 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package sqldb
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/polarismesh/polaris-server/common/model"
+	"github.com/polarismesh/polaris-server/store"
+)
+
+const (
+	// listenerBatchSize 批量上报订阅者时单条SQL写入的最大记录数
+	listenerBatchSize = 100
+)
+
+type configFileListenerStore struct {
+	db    *BaseDB
+	slave *replicaSet
+}
+
+// UpsertConfigFileListeners 批量新增或者更新配置文件订阅者
+func (ls *configFileListenerStore) UpsertConfigFileListeners(listeners []*model.ConfigFileListener) error {
+	for i := 0; i < len(listeners); i += listenerBatchSize {
+		end := i + listenerBatchSize
+		if end > len(listeners) {
+			end = len(listeners)
+		}
+		if err := ls.batchUpsert(listeners[i:end]); err != nil {
+			return store.Error(err)
+		}
+	}
+	return nil
+}
+
+func (ls *configFileListenerStore) batchUpsert(listeners []*model.ConfigFileListener) error {
+	str := "replace into config_file_listener(namespace, `group`, file_name, client_id, client_ip, version, " +
+		"last_poll_time, server, modify_time) values"
+	args := make([]interface{}, 0, len(listeners)*8)
+	for i, listener := range listeners {
+		if i > 0 {
+			str += ","
+		}
+		str += "(?,?,?,?,?,?,FROM_UNIXTIME(?),?,sysdate())"
+		args = append(args, listener.Namespace, listener.Group, listener.FileName, listener.ClientId,
+			listener.ClientIP, listener.Version, listener.LastPollTime.Unix(), listener.Server)
+	}
+	_, err := ls.db.Exec(str, args...)
+	return err
+}
+
+// QueryConfigFileListeners 翻页查询配置文件订阅者，按照 namespace、group、file_name 精确匹配
+func (ls *configFileListenerStore) QueryConfigFileListeners(namespace, group, fileName string,
+	offset, limit uint32) (uint32, []*model.ConfigFileListener, error) {
+	where := " where 1 = 1"
+	var queryParams []interface{}
+	if namespace != "" {
+		where += " and namespace = ?"
+		queryParams = append(queryParams, namespace)
+	}
+	if group != "" {
+		where += " and `group` = ?"
+		queryParams = append(queryParams, group)
+	}
+	if fileName != "" {
+		where += " and file_name = ?"
+		queryParams = append(queryParams, fileName)
+	}
+
+	var count uint32
+	err := ls.slave.console().QueryRow("select count(*) from config_file_listener"+where, queryParams...).Scan(&count)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	querySql := "select namespace, `group`, file_name, client_id, client_ip, version, UNIX_TIMESTAMP(last_poll_time), " +
+		"server, UNIX_TIMESTAMP(modify_time) from config_file_listener" + where +
+		" order by namespace, `group`, file_name, client_id limit ?, ?"
+	queryParams = append(queryParams, offset, limit)
+	rows, err := ls.slave.console().Query(querySql, queryParams...)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	listeners, err := ls.transferRows(rows)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return count, listeners, nil
+}
+
+// DeleteExpiredConfigFileListeners 删除超过一段时间未上报的订阅者
+func (ls *configFileListenerStore) DeleteExpiredConfigFileListeners(modifyTime time.Time) error {
+	_, err := ls.db.Exec("delete from config_file_listener where modify_time < FROM_UNIXTIME(?)", modifyTime.Unix())
+	if err != nil {
+		return store.Error(err)
+	}
+	return nil
+}
+
+func (ls *configFileListenerStore) transferRows(rows *sql.Rows) ([]*model.ConfigFileListener, error) {
+	if rows == nil {
+		return nil, nil
+	}
+	defer rows.Close()
+
+	var listeners []*model.ConfigFileListener
+	for rows.Next() {
+		listener := &model.ConfigFileListener{}
+		var pollTime, mtime int64
+		err := rows.Scan(&listener.Namespace, &listener.Group, &listener.FileName, &listener.ClientId,
+			&listener.ClientIP, &listener.Version, &pollTime, &listener.Server, &mtime)
+		if err != nil {
+			return nil, err
+		}
+		listener.LastPollTime = time.Unix(pollTime, 0)
+		listener.ModifyTime = time.Unix(mtime, 0)
+
+		listeners = append(listeners, listener)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return listeners, nil
+}
@@ -0,0 +1,206 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package sqldb
+
+import (
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/polarismesh/polaris-server/common/model"
+	"github.com/polarismesh/polaris-server/common/utils"
+	"github.com/polarismesh/polaris-server/store"
+)
+
+type configFilePublishRequestStore struct {
+	db    *BaseDB
+	slave *replicaSet
+}
+
+// CreateConfigFilePublishRequest 创建配置发布申请，审批人以逗号分隔保存，
+// 计划发布、审批和发布时间保存为秒级时间戳，0 表示为空
+func (ps *configFilePublishRequestStore) CreateConfigFilePublishRequest(
+	request *model.ConfigFilePublishRequest) error {
+	createSql := "insert into config_file_publish_request(id, namespace, `group`, file_name, comment, status, " +
+		"approve_users, approve_groups, plan_time, approve_by, approve_comment, approve_time, approve_md5, " +
+		"publish_time, fail_reason, create_time, create_by, modify_time, modify_by) " +
+		"values (?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,sysdate(),?,sysdate(),?)"
+	_, err := ps.db.Exec(createSql, request.Id, request.Namespace, request.Group, request.FileName, request.Comment,
+		request.Status, strings.Join(request.ApproveUsers, ","), strings.Join(request.ApproveGroups, ","),
+		toUnixSeconds(request.PlanTime), request.ApproveBy, request.ApproveComment,
+		toUnixSeconds(request.ApproveTime), request.ApproveMd5, toUnixSeconds(request.PublishTime),
+		request.FailReason, request.CreateBy, request.ModifyBy)
+	if err != nil {
+		return store.Error(err)
+	}
+	return nil
+}
+
+// GetConfigFilePublishRequest 根据 id 获取配置发布申请
+func (ps *configFilePublishRequestStore) GetConfigFilePublishRequest(
+	id string) (*model.ConfigFilePublishRequest, error) {
+	rows, err := ps.db.Query(ps.genSelectSql()+" where id = ?", id)
+	if err != nil {
+		return nil, store.Error(err)
+	}
+	requests, err := ps.transferRows(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(requests) == 0 {
+		return nil, nil
+	}
+	return requests[0], nil
+}
+
+// QueryConfigFilePublishRequests 翻页查询配置发布申请，按照 namespace、group、file_name、status 精确匹配
+func (ps *configFilePublishRequestStore) QueryConfigFilePublishRequests(namespace, group, fileName,
+	status string, offset, limit uint32) (uint32, []*model.ConfigFilePublishRequest, error) {
+	where := " where 1 = 1"
+	var queryParams []interface{}
+	if namespace != "" {
+		where += " and namespace = ?"
+		queryParams = append(queryParams, namespace)
+	}
+	if group != "" {
+		where += " and `group` = ?"
+		queryParams = append(queryParams, group)
+	}
+	if fileName != "" {
+		where += " and file_name = ?"
+		queryParams = append(queryParams, fileName)
+	}
+	if status != "" {
+		where += " and status = ?"
+		queryParams = append(queryParams, status)
+	}
+
+	var count uint32
+	err := ps.slave.console().QueryRow("select count(*) from config_file_publish_request"+where,
+		queryParams...).Scan(&count)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	querySql := ps.genSelectSql() + where + " order by create_time desc, id desc limit ?, ?"
+	queryParams = append(queryParams, offset, limit)
+	rows, err := ps.slave.console().Query(querySql, queryParams...)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	requests, err := ps.transferRows(rows)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return count, requests, nil
+}
+
+// UpdateConfigFilePublishRequest 通过 where 条件中的状态比较实现并发更新时只有一个节点成功
+func (ps *configFilePublishRequestStore) UpdateConfigFilePublishRequest(request *model.ConfigFilePublishRequest,
+	fromStatus string) (bool, error) {
+	updateSql := "update config_file_publish_request set comment = ?, status = ?, plan_time = ?, approve_by = ?, " +
+		"approve_comment = ?, approve_time = ?, approve_md5 = ?, publish_time = ?, fail_reason = ?, " +
+		"modify_time = sysdate(), modify_by = ? where id = ? and status = ?"
+	result, err := ps.db.Exec(updateSql, request.Comment, request.Status, toUnixSeconds(request.PlanTime),
+		request.ApproveBy, request.ApproveComment, toUnixSeconds(request.ApproveTime), request.ApproveMd5,
+		toUnixSeconds(request.PublishTime), request.FailReason, request.ModifyBy, request.Id, fromStatus)
+	if err != nil {
+		return false, store.Error(err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, store.Error(err)
+	}
+	return affected > 0, nil
+}
+
+// FindDueConfigFilePublishRequests 获取到达计划发布时间的待调度发布申请
+func (ps *configFilePublishRequestStore) FindDueConfigFilePublishRequests(
+	planTime time.Time) ([]*model.ConfigFilePublishRequest, error) {
+	rows, err := ps.db.Query(ps.genSelectSql()+" where status = ? and plan_time <= ? order by plan_time",
+		utils.PublishRequestStatusScheduled, planTime.Unix())
+	if err != nil {
+		return nil, store.Error(err)
+	}
+	return ps.transferRows(rows)
+}
+
+func (ps *configFilePublishRequestStore) genSelectSql() string {
+	return "select id, namespace, `group`, file_name, comment, status, approve_users, approve_groups, plan_time, " +
+		"approve_by, approve_comment, approve_time, approve_md5, publish_time, fail_reason, " +
+		"UNIX_TIMESTAMP(create_time), create_by, UNIX_TIMESTAMP(modify_time), modify_by " +
+		"from config_file_publish_request"
+}
+
+func (ps *configFilePublishRequestStore) transferRows(rows *sql.Rows) ([]*model.ConfigFilePublishRequest, error) {
+	if rows == nil {
+		return nil, nil
+	}
+	defer rows.Close()
+
+	var requests []*model.ConfigFilePublishRequest
+	for rows.Next() {
+		request := &model.ConfigFilePublishRequest{}
+		var approveUsers, approveGroups string
+		var planTime, approveTime, publishTime, ctime, mtime int64
+		err := rows.Scan(&request.Id, &request.Namespace, &request.Group, &request.FileName, &request.Comment,
+			&request.Status, &approveUsers, &approveGroups, &planTime, &request.ApproveBy, &request.ApproveComment,
+			&approveTime, &request.ApproveMd5, &publishTime, &request.FailReason, &ctime, &request.CreateBy, &mtime, &request.ModifyBy)
+		if err != nil {
+			return nil, err
+		}
+		request.ApproveUsers = splitApprovers(approveUsers)
+		request.ApproveGroups = splitApprovers(approveGroups)
+		request.PlanTime = fromUnixSeconds(planTime)
+		request.ApproveTime = fromUnixSeconds(approveTime)
+		request.PublishTime = fromUnixSeconds(publishTime)
+		request.CreateTime = time.Unix(ctime, 0)
+		request.ModifyTime = time.Unix(mtime, 0)
+
+		requests = append(requests, request)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return requests, nil
+}
+
+func splitApprovers(approvers string) []string {
+	if approvers == "" {
+		return nil
+	}
+	return strings.Split(approvers, ",")
+}
+
+func toUnixSeconds(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.Unix()
+}
+
+func fromUnixSeconds(sec int64) time.Time {
+	if sec == 0 {
+		return time.Time{}
+	}
+	return time.Unix(sec, 0)
+}
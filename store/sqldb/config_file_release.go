@@ -27,7 +27,8 @@ import (
 )
 
 type configFileReleaseStore struct {
-	db *BaseDB
+	db    *BaseDB
+	slave *replicaSet
 }
 
 // CreateConfigFileRelease 新建配置文件发布
@@ -119,7 +120,7 @@ func (cfr *configFileReleaseStore) DeleteConfigFileRelease(tx store.Tx, namespac
 // FindConfigFileReleaseByModifyTimeAfter 获取最后更新时间大于某个时间点的发布，注意包含 flag = 1 的，为了能够获取被删除的 release
 func (cfr *configFileReleaseStore) FindConfigFileReleaseByModifyTimeAfter(modifyTime time.Time) ([]*model.ConfigFileRelease, error) {
 	sql := cfr.baseQuerySql() + " where modify_time > ?"
-	rows, err := cfr.db.Query(sql, commontime.Time2String(modifyTime))
+	rows, err := cfr.slave.Query(sql, commontime.Time2String(modifyTime))
 	if err != nil {
 		return nil, err
 	}
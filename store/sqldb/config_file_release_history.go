@@ -26,23 +26,24 @@ import (
 )
 
 type configFileReleaseHistoryStore struct {
-	db *BaseDB
+	db    *BaseDB
+	slave *replicaSet
 }
 
 // CreateConfigFileReleaseHistory 创建配置文件发布历史记录
 func (rh *configFileReleaseHistoryStore) CreateConfigFileReleaseHistory(tx store.Tx, fileReleaseHistory *model.ConfigFileReleaseHistory) error {
-	sql := "insert into config_file_release_history(name, namespace, `group`, file_name, content, comment, md5, type, status, format, tags, " +
+	sql := "insert into config_file_release_history(name, namespace, `group`, file_name, content, template_content, comment, md5, type, status, format, tags, " +
 		"create_time, create_by, modify_time, modify_by) values " +
-		"(?,?,?,?,?,?,?,?,?,?,?,sysdate(),?,sysdate(),?)"
+		"(?,?,?,?,?,?,?,?,?,?,?,?,sysdate(),?,sysdate(),?)"
 	var err error
 	if tx != nil {
 		_, err = tx.GetDelegateTx().(*BaseTx).Exec(sql, fileReleaseHistory.Name, fileReleaseHistory.Namespace, fileReleaseHistory.Group,
-			fileReleaseHistory.FileName, fileReleaseHistory.Content, fileReleaseHistory.Comment, fileReleaseHistory.Md5,
+			fileReleaseHistory.FileName, fileReleaseHistory.Content, fileReleaseHistory.TemplateContent, fileReleaseHistory.Comment, fileReleaseHistory.Md5,
 			fileReleaseHistory.Type, fileReleaseHistory.Status, fileReleaseHistory.Format, fileReleaseHistory.Tags,
 			fileReleaseHistory.CreateBy, fileReleaseHistory.ModifyBy)
 	} else {
 		_, err = rh.db.Exec(sql, fileReleaseHistory.Name, fileReleaseHistory.Namespace, fileReleaseHistory.Group,
-			fileReleaseHistory.FileName, fileReleaseHistory.Content, fileReleaseHistory.Comment, fileReleaseHistory.Md5,
+			fileReleaseHistory.FileName, fileReleaseHistory.Content, fileReleaseHistory.TemplateContent, fileReleaseHistory.Comment, fileReleaseHistory.Md5,
 			fileReleaseHistory.Type, fileReleaseHistory.Status, fileReleaseHistory.Format, fileReleaseHistory.Tags,
 			fileReleaseHistory.CreateBy, fileReleaseHistory.ModifyBy)
 	}
@@ -76,14 +77,14 @@ func (rh *configFileReleaseHistoryStore) QueryConfigFileReleaseHistories(namespa
 	queryParams = append(queryParams, "%"+fileName+"%")
 
 	var count uint32
-	err := rh.db.QueryRow(countSql, queryParams...).Scan(&count)
+	err := rh.slave.console().QueryRow(countSql, queryParams...).Scan(&count)
 	if err != nil {
 		return 0, nil, err
 	}
 
 	queryParams = append(queryParams, offset)
 	queryParams = append(queryParams, limit)
-	rows, err := rh.db.Query(querySql, queryParams...)
+	rows, err := rh.slave.console().Query(querySql, queryParams...)
 	if err != nil {
 		return 0, nil, err
 	}
@@ -116,8 +117,29 @@ func (rh *configFileReleaseHistoryStore) GetLatestConfigFileReleaseHistory(names
 	return fileReleaseHistories[0], nil
 }
 
+// GetConfigFileReleaseHistory 根据 id 获取配置文件发布历史记录
+func (rh *configFileReleaseHistoryStore) GetConfigFileReleaseHistory(id uint64) (*model.ConfigFileReleaseHistory, error) {
+	sql := rh.genSelectSql() + "where id = ?"
+
+	rows, err := rh.db.Query(sql, id)
+	if err != nil {
+		return nil, err
+	}
+
+	fileReleaseHistories, err := rh.transferRows(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(fileReleaseHistories) == 0 {
+		return nil, nil
+	}
+
+	return fileReleaseHistories[0], nil
+}
+
 func (rh *configFileReleaseHistoryStore) genSelectSql() string {
-	return "select id, name, namespace, `group`, file_name, content, IFNULL(comment, ''), md5, format, tags, type, status, UNIX_TIMESTAMP(create_time), IFNULL(create_by, ''), UNIX_TIMESTAMP(modify_time), " +
+	return "select id, name, namespace, `group`, file_name, content, IFNULL(template_content, ''), IFNULL(comment, ''), md5, format, tags, type, status, UNIX_TIMESTAMP(create_time), IFNULL(create_by, ''), UNIX_TIMESTAMP(modify_time), " +
 		"IFNULL(modify_by, '') from config_file_release_history "
 }
 func (rh *configFileReleaseHistoryStore) transferRows(rows *sql.Rows) ([]*model.ConfigFileReleaseHistory, error) {
@@ -132,7 +154,7 @@ func (rh *configFileReleaseHistoryStore) transferRows(rows *sql.Rows) ([]*model.
 		fileReleaseHistory := &model.ConfigFileReleaseHistory{}
 		var ctime, mtime int64
 		err := rows.Scan(&fileReleaseHistory.Id, &fileReleaseHistory.Name, &fileReleaseHistory.Namespace, &fileReleaseHistory.Group,
-			&fileReleaseHistory.FileName, &fileReleaseHistory.Content,
+			&fileReleaseHistory.FileName, &fileReleaseHistory.Content, &fileReleaseHistory.TemplateContent,
 			&fileReleaseHistory.Comment, &fileReleaseHistory.Md5, &fileReleaseHistory.Format, &fileReleaseHistory.Tags,
 			&fileReleaseHistory.Type, &fileReleaseHistory.Status,
 			&ctime, &fileReleaseHistory.CreateBy, &mtime, &fileReleaseHistory.ModifyBy)
@@ -27,7 +27,8 @@ import (
 )
 
 type configFileTagStore struct {
-	db *BaseDB
+	db    *BaseDB
+	slave *replicaSet
 }
 
 // CreateConfigFileTag 创建配置文件标签
@@ -64,7 +65,7 @@ func (t *configFileTagStore) QueryConfigFileByTag(namespace, group, fileName str
 	for _, tag := range tags {
 		params = append(params, tag)
 	}
-	rows, err := t.db.Query(querySql, params...)
+	rows, err := t.slave.console().Query(querySql, params...)
 	if err != nil {
 		return nil, store.Error(err)
 	}
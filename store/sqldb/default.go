@@ -20,6 +20,7 @@ package sqldb
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	_ "github.com/go-sql-driver/mysql"
 
@@ -30,13 +31,27 @@ import (
 const (
 	SystemNamespace        = "Polaris"
 	STORENAME              = "defaultStore"
+	PostgreSQLStoreName    = "postgresqlStore"
+	SQLiteStoreName        = "sqliteStore"
 	DefaultConnMaxLifetime = 60 * 30 // 默认是30分钟
 )
 
 // init 自动引入包初始化函数
 func init() {
-	s := &stableStore{}
-	_ = store.RegisterStore(s)
+	_ = store.RegisterStore(&stableStore{name: STORENAME, dbType: MySQLDialect})
+	_ = store.RegisterStore(&stableStore{name: PostgreSQLStoreName, dbType: PostgreSQLDialect})
+	_ = store.RegisterStore(&stableStore{name: SQLiteStoreName, dbType: SQLiteDialect})
+
+	registerStoreCreator(STORENAME, MySQLDialect)
+	registerStoreCreator(PostgreSQLStoreName, PostgreSQLDialect)
+	registerStoreCreator(SQLiteStoreName, SQLiteDialect)
+}
+
+// registerStoreCreator 注册独立的存储实例的创建函数
+func registerStoreCreator(name, dbType string) {
+	_ = store.RegisterStoreCreator(name, func() store.Store {
+		return &stableStore{name: name, dbType: dbType}
+	})
 }
 
 // stableStore 实现了Store接口
@@ -59,25 +74,39 @@ type stableStore struct {
 	*configFileGroupStore
 	*configFileStore
 	*configFileReleaseStore
+	*configFileGrayReleaseStore
 	*configFileReleaseHistoryStore
 	*configFileTagStore
+	*configFileListenerStore
+	*configFilePublishRequestStore
 
 	//client info stores
 	*clientStore
 
+	// 服务和实例的变更日志
+	*changeLogStore
+
+	// 集群事件
+	*clusterEventStore
+
 	// 主数据库，可以进行读写
 	master *BaseDB
 	// 对主数据库的事务操作，可读写
 	masterTx *BaseDB
-	// 备数据库，提供只读
-	slave    *BaseDB
+	// 备数据库集合，提供只读，没有配置备库时由主库处理读请求
+	slave    *replicaSet
 	start    bool
 	metaTask *TaskManager
+
+	// 插件名
+	name string
+	// 默认的数据库类型，配置中未指定 dbType 时使用
+	dbType string
 }
 
 // Name 实现Name函数
 func (s *stableStore) Name() string {
-	return STORENAME
+	return s.name
 }
 
 // Initialize 初始化函数
@@ -86,80 +115,145 @@ func (s *stableStore) Initialize(conf *store.Config) error {
 		return errors.New("store has been Initialize")
 	}
 
-	masterConfig, slaveConfig, err := parseDatabaseConf(conf.Option)
+	masterConfig, slaveConfigs, err := parseDatabaseConf(conf.Option, s.name, s.dbType)
+	if err != nil {
+		return err
+	}
+	replicaCfg, err := parseReplicaConf(conf.Option, s.name)
 	if err != nil {
 		return err
 	}
+	enableChangeLog, changeLogRetention := parseChangeLogConf(conf.Option)
 	master, err := NewBaseDB(masterConfig, plugin.GetParsePassword())
 	if err != nil {
 		return err
 	}
 	s.master = master
+	if initializer, ok := master.dialect.(schemaInitializer); ok {
+		if err := initializer.InitSchema(master.DB); err != nil {
+			log.Errorf("[Store][database] init schema err: %s", err.Error())
+			return err
+		}
+	}
 
-	masterTx, err := NewBaseDB(masterConfig, plugin.GetParsePassword())
-	if err != nil {
-		return err
+	// sqlite 同一时间只允许一个写事务，主库和事务共用一个连接池，避免多个连接池之间互相等待文件锁
+	if masterConfig.dbType == SQLiteDialect {
+		s.masterTx = master
+	} else {
+		masterTx, err := NewBaseDB(masterConfig, plugin.GetParsePassword())
+		if err != nil {
+			return err
+		}
+		s.masterTx = masterTx
 	}
-	s.masterTx = masterTx
 
-	if slaveConfig != nil {
-		log.Infof("[Store][database] use slave database config: %+v", slaveConfig)
+	slaves := make([]*BaseDB, 0, len(slaveConfigs))
+	for _, slaveConfig := range slaveConfigs {
+		log.Infof("[Store][database] use slave database: %s/%s", slaveConfig.dbAddr, slaveConfig.dbName)
 		slave, err := NewBaseDB(slaveConfig, plugin.GetParsePassword())
 		if err != nil {
 			return err
 		}
-		s.slave = slave
-	}
-	// 如果slave为空，意味着slaveConfig为空，用master数据库替代
-	if s.slave == nil {
-		s.slave = s.master
+		slaves = append(slaves, slave)
 	}
+	// 如果slaves为空，意味着没有配置备库，读请求由master数据库处理
+	s.slave = newReplicaSet(s.name, s.master, slaves, replicaCfg)
 
 	log.Infof("[Store][database] connect the database successfully")
 
 	s.start = true
+	s.changeLogStore = newChangeLogStore(s.master, s.slave, enableChangeLog, changeLogRetention)
 	s.newStore()
 	return nil
 }
 
-// parseDatabaseConf return slave, master, error
-func parseDatabaseConf(opt map[string]interface{}) (*dbConfig, *dbConfig, error) {
+// parseDatabaseConf return master, slaves, error
+func parseDatabaseConf(opt map[string]interface{}, name, dbType string) (*dbConfig, []*dbConfig, error) {
+	if dbType == SQLiteDialect {
+		masterConfig, err := parseSQLiteConf(opt, name)
+		return masterConfig, nil, err
+	}
 	// 必填
 	masterEnter, ok := opt["master"]
 	if !ok || masterEnter == nil {
 		return nil, nil, errors.New("database master db config is missing")
 	}
-	masterConfig, err := parseStoreConfig(masterEnter)
+	masterConfig, err := parseStoreConfig(masterEnter, name, dbType)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	// 只读数据库可选
+	// 只读数据库可选，可以配置一个或者多个
 	slaveEntry, ok := opt["slave"]
 	if !ok || slaveEntry == nil {
 		return masterConfig, nil, nil
 	}
-	slaveConfig, err := parseStoreConfig(slaveEntry)
-	if err != nil {
-		return nil, nil, err
+	slaveEntries, ok := slaveEntry.([]interface{})
+	if !ok {
+		slaveEntries = []interface{}{slaveEntry}
+	}
+	slaveConfigs := make([]*dbConfig, 0, len(slaveEntries))
+	for _, entry := range slaveEntries {
+		slaveConfig, err := parseStoreConfig(entry, name, dbType)
+		if err != nil {
+			return nil, nil, err
+		}
+		slaveConfigs = append(slaveConfigs, slaveConfig)
 	}
 
-	return masterConfig, slaveConfig, nil
+	return masterConfig, slaveConfigs, nil
+}
+
+// parseReplicaConf 解析备库路由的配置
+func parseReplicaConf(opt map[string]interface{}, name string) (*replicaConfig, error) {
+	c := &replicaConfig{
+		readMode:      SlaveReadCache,
+		probeInterval: DefaultReplicaProbeInterval * time.Second,
+	}
+	if readMode, _ := opt["slaveReadMode"].(string); readMode != "" {
+		if readMode != SlaveReadCache && readMode != SlaveReadAll {
+			return nil, fmt.Errorf("config Plugin %s slaveReadMode %s is invalid", name, readMode)
+		}
+		c.readMode = readMode
+	}
+	if probeInterval, _ := opt["slaveProbeInterval"].(int); probeInterval > 0 {
+		c.probeInterval = time.Duration(probeInterval) * time.Second
+	}
+	if maxLag, _ := opt["slaveMaxLag"].(int); maxLag > 0 {
+		c.maxLag = time.Duration(maxLag) * time.Second
+	}
+	return c, nil
+}
+
+// parseChangeLogConf 解析变更日志的配置，默认不开启
+func parseChangeLogConf(opt map[string]interface{}) (bool, time.Duration) {
+	enable, _ := opt["changeLog"].(bool)
+	retention := DefaultChangeLogRetention * time.Second
+	if seconds, _ := opt["changeLogRetention"].(int); seconds > 0 {
+		retention = time.Duration(seconds) * time.Second
+	}
+	return enable, retention
 }
 
 // parseStoreConfig 解析store的配置
-func parseStoreConfig(opts interface{}) (*dbConfig, error) {
+func parseStoreConfig(opts interface{}, name, defaultDBType string) (*dbConfig, error) {
 	obj, ok := opts.(map[interface{}]interface{})
 	if !ok {
-		return nil, errors.New("database config is error")
+		return nil, fmt.Errorf("config Plugin %s database config is error", name)
 	}
 	dbType, _ := obj["dbType"].(string)
+	if dbType == "" {
+		dbType = defaultDBType
+	}
 	dbUser, _ := obj["dbUser"].(string)
 	dbPwd, _ := obj["dbPwd"].(string)
 	dbAddr, _ := obj["dbAddr"].(string)
 	dbName, _ := obj["dbName"].(string)
 	if dbType == "" || dbUser == "" || dbPwd == "" || dbAddr == "" || dbName == "" {
-		return nil, fmt.Errorf("config Plugin %s missing database param", STORENAME)
+		return nil, fmt.Errorf("config Plugin %s missing database param", name)
+	}
+	if _, err := getDialect(dbType); err != nil {
+		return nil, err
 	}
 
 	c := &dbConfig{
@@ -183,6 +277,27 @@ func parseStoreConfig(opts interface{}) (*dbConfig, error) {
 	if isolationLevel, _ := obj["txIsolationLevel"].(int); isolationLevel > 0 {
 		c.txIsolationLevel = isolationLevel
 	}
+	c.sslMode, _ = obj["sslMode"].(string)
+	return c, nil
+}
+
+// parseSQLiteConf 解析sqlite的配置，只需要指定数据库文件路径，不区分主备
+func parseSQLiteConf(opt map[string]interface{}, name string) (*dbConfig, error) {
+	path, _ := opt["path"].(string)
+	if path == "" {
+		return nil, fmt.Errorf("config Plugin %s missing database param path", name)
+	}
+	c := &dbConfig{
+		dbType:          SQLiteDialect,
+		dbName:          path,
+		connMaxLifetime: DefaultConnMaxLifetime,
+	}
+	if maxOpenConns, _ := opt["maxOpenConns"].(int); maxOpenConns > 0 {
+		c.maxOpenConns = maxOpenConns
+	}
+	if connMaxLifetime, _ := opt["connMaxLifetime"].(int); connMaxLifetime > 0 {
+		c.connMaxLifetime = connMaxLifetime
+	}
 	return c, nil
 }
 
@@ -197,6 +312,9 @@ func (s *stableStore) Destroy() error {
 	if s.slave != nil {
 		_ = s.slave.Close()
 	}
+	if s.changeLogStore != nil {
+		s.changeLogStore.stop()
+	}
 
 	return nil
 }
@@ -206,7 +324,7 @@ func (s *stableStore) CreateTransaction() (store.Transaction, error) {
 	// 每次创建事务前，还是需要ping一下
 	_ = s.masterTx.Ping()
 
-	nt := &transaction{}
+	nt := &transaction{changes: s.changeLogStore}
 	tx, err := s.masterTx.Begin()
 	if err != nil {
 		log.Errorf("[Store][database] database begin err: %s", err.Error())
@@ -227,19 +345,19 @@ func (s *stableStore) StartTx() (store.Tx, error) {
 
 // newStore 初始化子类
 func (s *stableStore) newStore() {
-	s.namespaceStore = &namespaceStore{db: s.master}
+	s.namespaceStore = &namespaceStore{db: s.master, slave: s.slave}
 
 	s.businessStore = &businessStore{db: s.master}
 
-	s.serviceStore = &serviceStore{master: s.master, slave: s.slave}
+	s.serviceStore = &serviceStore{master: s.master, slave: s.slave, changes: s.changeLogStore}
 
-	s.instanceStore = &instanceStore{master: s.master, slave: s.slave}
+	s.instanceStore = &instanceStore{master: s.master, slave: s.slave, changes: s.changeLogStore}
 
 	s.routingConfigStore = &routingConfigStore{master: s.master, slave: s.slave}
 
 	s.l5Store = &l5Store{db: s.master}
 
-	s.rateLimitStore = &rateLimitStore{db: s.master}
+	s.rateLimitStore = &rateLimitStore{db: s.master, slave: s.slave}
 
 	s.circuitBreakerStore = &circuitBreakerStore{master: s.master, slave: s.slave}
 
@@ -253,15 +371,22 @@ func (s *stableStore) newStore() {
 
 	s.strategyStore = &strategyStore{master: s.master, slave: s.slave}
 
-	s.configFileGroupStore = &configFileGroupStore{db: s.master}
+	s.configFileGroupStore = &configFileGroupStore{db: s.master, slave: s.slave}
+
+	s.configFileStore = &configFileStore{db: s.master, slave: s.slave}
+
+	s.configFileReleaseStore = &configFileReleaseStore{db: s.master, slave: s.slave}
+	s.configFileGrayReleaseStore = &configFileGrayReleaseStore{db: s.master, slave: s.slave}
+
+	s.configFileReleaseHistoryStore = &configFileReleaseHistoryStore{db: s.master, slave: s.slave}
 
-	s.configFileStore = &configFileStore{db: s.master}
+	s.configFileTagStore = &configFileTagStore{db: s.master, slave: s.slave}
 
-	s.configFileReleaseStore = &configFileReleaseStore{db: s.master}
+	s.configFileListenerStore = &configFileListenerStore{db: s.master, slave: s.slave}
 
-	s.configFileReleaseHistoryStore = &configFileReleaseHistoryStore{db: s.master}
+	s.configFilePublishRequestStore = &configFilePublishRequestStore{db: s.master, slave: s.slave}
 
-	s.configFileTagStore = &configFileTagStore{db: s.master}
+	s.clusterEventStore = &clusterEventStore{master: s.master}
 
 	s.clientStore = &clientStore{master: s.master, slave: s.slave}
 }
@@ -0,0 +1,112 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package sqldb
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// TestParseDatabaseConf 测试数据库配置解析
+func TestParseDatabaseConf(t *testing.T) {
+	Convey("未指定dbType时使用插件默认的数据库类型", t, func() {
+		master, slave, err := parseDatabaseConf(map[string]interface{}{
+			"master": map[interface{}]interface{}{
+				"dbUser": "u", "dbPwd": "p", "dbAddr": "127.0.0.1", "dbName": "polaris", "sslMode": "require",
+			},
+		}, PostgreSQLStoreName, PostgreSQLDialect)
+		So(err, ShouldBeNil)
+		So(slave, ShouldBeNil)
+		So(master.dbType, ShouldEqual, PostgreSQLDialect)
+		So(master.sslMode, ShouldEqual, "require")
+	})
+	Convey("备库可以配置一个或者多个", t, func() {
+		slave := map[interface{}]interface{}{
+			"dbUser": "u", "dbPwd": "p", "dbAddr": "127.0.0.2", "dbName": "polaris",
+		}
+		master := map[interface{}]interface{}{
+			"dbUser": "u", "dbPwd": "p", "dbAddr": "127.0.0.1", "dbName": "polaris",
+		}
+		_, slaves, err := parseDatabaseConf(map[string]interface{}{"master": master, "slave": slave},
+			STORENAME, MySQLDialect)
+		So(err, ShouldBeNil)
+		So(len(slaves), ShouldEqual, 1)
+		So(slaves[0].dbAddr, ShouldEqual, "127.0.0.2")
+
+		_, slaves, err = parseDatabaseConf(map[string]interface{}{
+			"master": master, "slave": []interface{}{slave, slave},
+		}, STORENAME, MySQLDialect)
+		So(err, ShouldBeNil)
+		So(len(slaves), ShouldEqual, 2)
+	})
+	Convey("缺少参数时错误信息包含插件名", t, func() {
+		_, _, err := parseDatabaseConf(map[string]interface{}{
+			"master": map[interface{}]interface{}{"dbUser": "u"},
+		}, PostgreSQLStoreName, PostgreSQLDialect)
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, PostgreSQLStoreName)
+	})
+	Convey("不支持的数据库类型", t, func() {
+		_, _, err := parseDatabaseConf(map[string]interface{}{
+			"master": map[interface{}]interface{}{
+				"dbType": "oracle", "dbUser": "u", "dbPwd": "p", "dbAddr": "127.0.0.1", "dbName": "polaris",
+			},
+		}, STORENAME, MySQLDialect)
+		So(err, ShouldNotBeNil)
+	})
+}
+
+// TestParseReplicaConf 测试备库路由配置解析
+func TestParseReplicaConf(t *testing.T) {
+	Convey("默认只有缓存读取备库", t, func() {
+		c, err := parseReplicaConf(map[string]interface{}{}, STORENAME)
+		So(err, ShouldBeNil)
+		So(c.readMode, ShouldEqual, SlaveReadCache)
+		So(c.probeInterval, ShouldEqual, DefaultReplicaProbeInterval*time.Second)
+		So(c.maxLag, ShouldEqual, 0)
+	})
+	Convey("指定读取模式和复制延迟", t, func() {
+		c, err := parseReplicaConf(map[string]interface{}{
+			"slaveReadMode": "all", "slaveProbeInterval": 1, "slaveMaxLag": 10,
+		}, STORENAME)
+		So(err, ShouldBeNil)
+		So(c.readMode, ShouldEqual, SlaveReadAll)
+		So(c.probeInterval, ShouldEqual, time.Second)
+		So(c.maxLag, ShouldEqual, 10*time.Second)
+	})
+	Convey("不支持的读取模式", t, func() {
+		_, err := parseReplicaConf(map[string]interface{}{"slaveReadMode": "console"}, STORENAME)
+		So(err, ShouldNotBeNil)
+	})
+}
+
+// TestParseChangeLogConf 测试变更日志配置的解析
+func TestParseChangeLogConf(t *testing.T) {
+	Convey("默认不开启变更日志", t, func() {
+		enable, retention := parseChangeLogConf(map[string]interface{}{})
+		So(enable, ShouldBeFalse)
+		So(retention, ShouldEqual, DefaultChangeLogRetention*time.Second)
+	})
+	Convey("开启变更日志并指定保留时间", t, func() {
+		enable, retention := parseChangeLogConf(map[string]interface{}{"changeLog": true, "changeLogRetention": 60})
+		So(enable, ShouldBeTrue)
+		So(retention, ShouldEqual, time.Minute)
+	})
+}
@@ -0,0 +1,329 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package sqldb
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// dialect SQL方言
+// sqldb 中的SQL语句统一按照 MySQL 的语法编写，其他数据库在执行前通过 dialect 改写为自身的语法
+type dialect interface {
+	// Name 方言名称，与配置中的 dbType 对应
+	Name() string
+
+	// DriverName database/sql 中注册的驱动名
+	DriverName() string
+
+	// DSN 根据配置生成数据库连接串
+	DSN(c *dbConfig) string
+
+	// Rebind 将 MySQL 语法的SQL改写为当前数据库的语法
+	Rebind(query string) string
+
+	// BindArgs 将SQL参数转换为当前数据库驱动可以接受的类型
+	BindArgs(args []interface{}) []interface{}
+}
+
+const (
+	// MySQLDialect mysql 方言
+	MySQLDialect = "mysql"
+	// PostgreSQLDialect postgresql 方言
+	PostgreSQLDialect = "postgres"
+	// SQLiteDialect sqlite 方言
+	SQLiteDialect = "sqlite3"
+)
+
+// schemaInitializer 可选接口，嵌入式数据库在启动时自动建表
+type schemaInitializer interface {
+	// InitSchema 初始化表结构，需要保证可以重复执行
+	InitSchema(db *sql.DB) error
+}
+
+// replicationLagger 可选接口，查询备库的复制延迟
+type replicationLagger interface {
+	// ReplicationLag 返回备库的复制延迟，不是备库时返回0，没有权限查询时返回 lagUnknownError
+	ReplicationLag(db *sql.DB) (time.Duration, error)
+}
+
+// lagUnknownError 无法得知备库的复制延迟，备库仍然可以提供读服务
+type lagUnknownError struct {
+	cause error
+}
+
+// Error 错误信息
+func (e *lagUnknownError) Error() string {
+	return "replication lag is unknown: " + e.cause.Error()
+}
+
+// mysqlSpecificAccessDenied 缺少 SUPER、REPLICATION CLIENT 等权限时的错误码
+const mysqlSpecificAccessDenied = 1227
+
+var dialects = map[string]dialect{}
+
+// registerDialect 注册一个方言
+func registerDialect(d dialect) {
+	dialects[d.Name()] = d
+}
+
+// getDialect 根据名字获取方言
+func getDialect(name string) (dialect, error) {
+	d, ok := dialects[name]
+	if !ok {
+		return nil, fmt.Errorf("sql dialect `%s` not found", name)
+	}
+	return d, nil
+}
+
+func init() {
+	registerDialect(&mysqlDialect{})
+}
+
+// mysqlDialect MySQL方言，SQL语句原样执行
+type mysqlDialect struct {
+}
+
+// Name 方言名称
+func (m *mysqlDialect) Name() string {
+	return MySQLDialect
+}
+
+// DriverName 驱动名
+func (m *mysqlDialect) DriverName() string {
+	return "mysql"
+}
+
+// DSN 连接串
+func (m *mysqlDialect) DSN(c *dbConfig) string {
+	return fmt.Sprintf("%s:%s@tcp(%s)/%s", c.dbUser, c.dbPwd, c.dbAddr, c.dbName)
+}
+
+// Rebind MySQL不需要改写
+func (m *mysqlDialect) Rebind(query string) string {
+	return query
+}
+
+// BindArgs MySQL不需要转换参数
+func (m *mysqlDialect) BindArgs(args []interface{}) []interface{} {
+	return args
+}
+
+// ReplicationLag 通过 show slave status 中的 Seconds_Behind_Master 获取复制延迟
+func (m *mysqlDialect) ReplicationLag(db *sql.DB) (time.Duration, error) {
+	rows, err := db.Query("show slave status")
+	if err != nil {
+		// 账号没有 REPLICATION CLIENT 权限时无法查询复制延迟，不影响备库提供读服务
+		if mysqlErr, ok := err.(*mysql.MySQLError); ok && mysqlErr.Number == mysqlSpecificAccessDenied {
+			return 0, &lagUnknownError{cause: err}
+		}
+		return 0, err
+	}
+	defer rows.Close()
+	if !rows.Next() {
+		return 0, rows.Err()
+	}
+	columns, err := rows.Columns()
+	if err != nil {
+		return 0, err
+	}
+	values := make([]sql.RawBytes, len(columns))
+	dest := make([]interface{}, len(columns))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+	if err := rows.Scan(dest...); err != nil {
+		return 0, err
+	}
+	for i, column := range columns {
+		if column != "Seconds_Behind_Master" {
+			continue
+		}
+		// 复制线程没有运行时为 NULL
+		if values[i] == nil {
+			return 0, errors.New("replication is not running")
+		}
+		seconds, err := strconv.ParseInt(string(values[i]), 10, 64)
+		if err != nil {
+			return 0, err
+		}
+		return time.Duration(seconds) * time.Second, nil
+	}
+	return 0, nil
+}
+
+// conflictKeys 使用 replace into、on duplicate key update 的表的唯一键
+// 非 MySQL 的数据库需要显式指定冲突的列，新增此类SQL时需要同步维护，否则SQL不会被改写
+// 与关键字同名的列需要使用反引号包裹
+var conflictKeys = map[string][]string{
+	"client":                       {"id"},
+	"instance":                     {"id"},
+	"health_check":                 {"id"},
+	"auth_strategy_resource":       {"strategy_id", "res_type", "res_id"},
+	"auth_principal":               {"strategy_id", "principal_id", "principal_role"},
+	"circuitbreaker_rule_relation": {"service_id"},
+	"ratelimit_revision":           {"service_id"},
+	"config_file_listener":         {"namespace", "`group`", "file_name", "client_id"},
+}
+
+var (
+	forceIndexRegex     = regexp.MustCompile(`(?i)\s*force\s+index\s*\([^)]*\)`)
+	shareModeRegex      = regexp.MustCompile(`(?i)\block\s+in\s+share\s+mode\b`)
+	limitOffsetRegex    = regexp.MustCompile(`(?i)\blimit\s+(\?|\d+)\s*,\s*(\?|\d+)`)
+	ifNullRegex         = regexp.MustCompile(`(?i)\bifnull\s*\(`)
+	replaceIntoRegex    = regexp.MustCompile("(?is)^\\s*replace\\s+into\\s+`?(\\w+)`?\\s*\\(([^)]*)\\)")
+	insertIgnoreRegex   = regexp.MustCompile(`(?i)^\s*insert\s+ignore\s+into\b`)
+	insertIntoRegex     = regexp.MustCompile("(?i)^\\s*insert\\s+into\\s+`?(\\w+)`?")
+	onDuplicateKeyRegex = regexp.MustCompile(`(?i)\bon\s+duplicate\s+key\s+update\b`)
+	// MySQL 允许使用 insert into t(...) value (...)，其他数据库只支持 values
+	valueKeywordRegex = regexp.MustCompile(`(?i)\)\s*value\s*\(`)
+)
+
+// rewriteReplaceInto 将 replace into 改写为 insert into ... on conflict do update
+func rewriteReplaceInto(query string) string {
+	m := replaceIntoRegex.FindStringSubmatch(query)
+	if m == nil {
+		return query
+	}
+	keys := conflictKeys[m[1]]
+	if len(keys) == 0 {
+		log.Errorf("[Store][database] table %s missing conflict keys, replace into is not rewritten", m[1])
+		return query
+	}
+	sets := make([]string, 0)
+	for _, col := range strings.Split(m[2], ",") {
+		col = strings.Trim(strings.TrimSpace(col), "`")
+		if col == "" || containsString(keys, col) || containsString(keys, backQuote(col)) {
+			continue
+		}
+		sets = append(sets, fmt.Sprintf("%s = excluded.%s", backQuote(col), backQuote(col)))
+	}
+	query = replaceIntoRegex.ReplaceAllString(query, "insert into $1($2)")
+	if len(sets) == 0 {
+		return query + " on conflict do nothing"
+	}
+	return fmt.Sprintf("%s on conflict (%s) do update set %s", query, strings.Join(keys, ", "),
+		strings.Join(sets, ", "))
+}
+
+// rewriteInsertIgnore 将 insert ignore 改写为 insert into ... on conflict do nothing
+func rewriteInsertIgnore(query string) string {
+	if !insertIgnoreRegex.MatchString(query) {
+		return query
+	}
+	return insertIgnoreRegex.ReplaceAllString(query, "insert into") + " on conflict do nothing"
+}
+
+// rewriteOnDuplicateKey 将 on duplicate key update 改写为 on conflict (...) do update set
+func rewriteOnDuplicateKey(query string) string {
+	if !onDuplicateKeyRegex.MatchString(query) {
+		return query
+	}
+	m := insertIntoRegex.FindStringSubmatch(query)
+	if m == nil {
+		return query
+	}
+	keys := conflictKeys[m[1]]
+	if len(keys) == 0 {
+		log.Errorf("[Store][database] table %s missing conflict keys, on duplicate key update is not rewritten", m[1])
+		return query
+	}
+	target := fmt.Sprintf("on conflict (%s) do update set", strings.Join(keys, ", "))
+	return onDuplicateKeyRegex.ReplaceAllString(query, target)
+}
+
+// rewriteLiterals 逐字符扫描SQL，处理字符串常量、标识符引号以及占位符
+// MySQL 中的双引号字符串会被改写为单引号字符串，反引号标识符会被改写为双引号标识符
+// placeholder 为 nil 时保留 ? 占位符，否则使用 placeholder 生成第 n 个占位符
+func rewriteLiterals(query string, placeholder func(n int) string) string {
+	var (
+		builder strings.Builder
+		n       int
+	)
+	builder.Grow(len(query) + 16)
+	for i := 0; i < len(query); i++ {
+		c := query[i]
+		switch c {
+		case '\'', '"':
+			end := i + 1
+			for end < len(query) {
+				if query[end] == '\\' {
+					end += 2
+					continue
+				}
+				if query[end] == c {
+					// 连续两个引号表示转义
+					if end+1 < len(query) && query[end+1] == c {
+						end += 2
+						continue
+					}
+					break
+				}
+				end++
+			}
+			if end >= len(query) {
+				// 引号未闭合，原样输出剩余部分
+				builder.WriteString(query[i:])
+				return builder.String()
+			}
+			content := query[i+1 : end]
+			if c == '"' {
+				content = strings.ReplaceAll(content, `""`, `"`)
+				content = strings.ReplaceAll(content, "'", "''")
+			}
+			builder.WriteByte('\'')
+			builder.WriteString(content)
+			builder.WriteByte('\'')
+			i = end
+		case '`':
+			builder.WriteByte('"')
+		case '?':
+			if placeholder == nil {
+				builder.WriteByte(c)
+				continue
+			}
+			n++
+			builder.WriteString(placeholder(n))
+		default:
+			builder.WriteByte(c)
+		}
+	}
+	return builder.String()
+}
+
+// backQuote 使用反引号包裹标识符，改写后的SQL仍然是 MySQL 语法，由 rewriteLiterals 统一转换
+func backQuote(name string) string {
+	return "`" + name + "`"
+}
+
+// containsString 判断字符串是否在列表中
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,123 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package sqldb
+
+import (
+	"database/sql"
+	"net"
+	"net/url"
+	"regexp"
+	"strconv"
+	"time"
+
+	_ "github.com/lib/pq"
+)
+
+func init() {
+	registerDialect(&postgresDialect{})
+}
+
+var (
+	// user 在 postgresql 中是保留字，作为表名时需要加引号
+	pgUserTableRegex = regexp.MustCompile(`(?i)\b(from|into|update|join)\s+user\b`)
+)
+
+// postgresDialect PostgreSQL 方言
+// sysdate、unix_timestamp、from_unixtime 等 MySQL 函数由 scripts/postgresql 中的建表脚本提供兼容实现
+type postgresDialect struct {
+}
+
+// Name 方言名称
+func (p *postgresDialect) Name() string {
+	return PostgreSQLDialect
+}
+
+// DriverName 驱动名
+func (p *postgresDialect) DriverName() string {
+	return "postgres"
+}
+
+// DSN 连接串，会话时区固定为UTC，与 BindArgs 中的时间参数保持一致
+func (p *postgresDialect) DSN(c *dbConfig) string {
+	sslMode := c.sslMode
+	if sslMode == "" {
+		sslMode = "disable"
+	}
+	host := c.dbAddr
+	if _, _, err := net.SplitHostPort(host); err != nil {
+		host = net.JoinHostPort(host, "5432")
+	}
+	dsn := url.URL{
+		Scheme: "postgres",
+		User:   url.UserPassword(c.dbUser, c.dbPwd),
+		Host:   host,
+		Path:   "/" + c.dbName,
+		RawQuery: url.Values{
+			"sslmode":  []string{sslMode},
+			"timezone": []string{"UTC"},
+		}.Encode(),
+	}
+	return dsn.String()
+}
+
+// ReplicationLag 根据最后回放的事务时间计算复制延迟，不是备库时返回0
+func (p *postgresDialect) ReplicationLag(db *sql.DB) (time.Duration, error) {
+	var seconds float64
+	err := db.QueryRow("select case when pg_is_in_recovery() then " +
+		"coalesce(extract(epoch from now() - pg_last_xact_replay_timestamp()), 0) else 0 end").Scan(&seconds)
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(seconds * float64(time.Second)), nil
+}
+
+// Rebind 将 MySQL 语法改写为 PostgreSQL 语法
+func (p *postgresDialect) Rebind(query string) string {
+	query = forceIndexRegex.ReplaceAllString(query, "")
+	query = shareModeRegex.ReplaceAllString(query, "for share")
+	query = limitOffsetRegex.ReplaceAllString(query, "offset $1 limit $2")
+	query = ifNullRegex.ReplaceAllString(query, "coalesce(")
+	query = valueKeywordRegex.ReplaceAllString(query, ") values (")
+	query = pgUserTableRegex.ReplaceAllString(query, "$1 `user`")
+	query = rewriteReplaceInto(query)
+	query = rewriteInsertIgnore(query)
+	query = rewriteOnDuplicateKey(query)
+	return rewriteLiterals(query, func(n int) string {
+		return "$" + strconv.Itoa(n)
+	})
+}
+
+// BindArgs bool 转换为 0/1 以匹配 smallint 字段，时间统一转换为UTC
+func (p *postgresDialect) BindArgs(args []interface{}) []interface{} {
+	out := make([]interface{}, len(args))
+	for i := range args {
+		switch v := args[i].(type) {
+		case bool:
+			if v {
+				out[i] = 1
+			} else {
+				out[i] = 0
+			}
+		case time.Time:
+			out[i] = v.UTC()
+		default:
+			out[i] = v
+		}
+	}
+	return out
+}
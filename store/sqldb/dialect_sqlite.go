@@ -0,0 +1,149 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package sqldb
+
+import (
+	"database/sql"
+	"fmt"
+	"net/url"
+	"regexp"
+	"time"
+)
+
+const (
+	// sqliteDriverName 注册了 MySQL 兼容函数的 sqlite 驱动
+	sqliteDriverName = "polaris_sqlite3"
+	// sqliteTimeLayout sqlite 中时间字段的存储格式，与 CURRENT_TIMESTAMP 保持一致
+	sqliteTimeLayout = "2006-01-02 15:04:05"
+)
+
+func init() {
+	registerDialect(&sqliteDialect{})
+}
+
+var (
+	// sqliteTimeLayouts sqlite 中可能出现的时间格式，按照从精确到粗略的顺序解析
+	sqliteTimeLayouts = []string{
+		"2006-01-02 15:04:05.999999999-07:00",
+		"2006-01-02T15:04:05.999999999-07:00",
+		"2006-01-02 15:04:05.999999999",
+		"2006-01-02T15:04:05.999999999",
+		sqliteTimeLayout,
+		"2006-01-02T15:04:05",
+		"2006-01-02 15:04",
+		"2006-01-02T15:04",
+		"2006-01-02",
+	}
+
+	sqliteLockRegex    = regexp.MustCompile(`(?i)\s*(\block\s+in\s+share\s+mode\b|\bfor\s+update\b)`)
+	sqliteDefaultRegex = regexp.MustCompile(`(?i)\.default\b`)
+	insertIgnoreInto   = "insert or ignore into"
+)
+
+// sqliteDialect SQLite 方言
+// sqlite 本身支持 replace into、ifnull 以及 limit offset, count 语法，只需要处理锁语句等少量差异
+type sqliteDialect struct {
+}
+
+// Name 方言名称
+func (s *sqliteDialect) Name() string {
+	return SQLiteDialect
+}
+
+// DriverName 驱动名
+func (s *sqliteDialect) DriverName() string {
+	return sqliteDriverName
+}
+
+// DSN 连接串，dbName 为数据库文件路径
+// 使用 WAL 模式，读不阻塞写；写事务在 begin 时即加锁，避免并发升级锁导致的死锁
+func (s *sqliteDialect) DSN(c *dbConfig) string {
+	params := url.Values{
+		"_busy_timeout": []string{"10000"},
+		"_journal_mode": []string{"WAL"},
+		"_foreign_keys": []string{"1"},
+		"_txlock":       []string{"immediate"},
+	}
+	return fmt.Sprintf("file:%s?%s", c.dbName, params.Encode())
+}
+
+// Rebind 将 MySQL 语法改写为 SQLite 语法
+func (s *sqliteDialect) Rebind(query string) string {
+	query = forceIndexRegex.ReplaceAllString(query, "")
+	query = sqliteLockRegex.ReplaceAllString(query, "")
+	query = sqliteDefaultRegex.ReplaceAllString(query, ".`default`")
+	query = valueKeywordRegex.ReplaceAllString(query, ") values (")
+	query = insertIgnoreRegex.ReplaceAllString(query, insertIgnoreInto)
+	query = rewriteOnDuplicateKey(query)
+	return rewriteLiterals(query, nil)
+}
+
+// BindArgs 时间统一转换为UTC的字符串，与 sysdate 的格式保持一致，保证可以直接比较
+func (s *sqliteDialect) BindArgs(args []interface{}) []interface{} {
+	out := make([]interface{}, len(args))
+	for i := range args {
+		if v, ok := args[i].(time.Time); ok {
+			out[i] = v.UTC().Format(sqliteTimeLayout)
+			continue
+		}
+		out[i] = args[i]
+	}
+	return out
+}
+
+// InitSchema 自动建表
+func (s *sqliteDialect) InitSchema(db *sql.DB) error {
+	_, err := db.Exec(sqliteSchema)
+	return err
+}
+
+// sqliteSysdate 当前时间
+func sqliteSysdate() string {
+	return time.Now().UTC().Format(sqliteTimeLayout)
+}
+
+// sqliteUnixTimestamp 时间转换为秒级时间戳
+func sqliteUnixTimestamp(v interface{}) int64 {
+	switch t := v.(type) {
+	case string:
+		return parseSQLiteTime(t)
+	case []byte:
+		return parseSQLiteTime(string(t))
+	case time.Time:
+		return t.Unix()
+	case int64:
+		return t
+	default:
+		return 0
+	}
+}
+
+// sqliteFromUnixtime 秒级时间戳转换为时间
+func sqliteFromUnixtime(sec int64) string {
+	return time.Unix(sec, 0).UTC().Format(sqliteTimeLayout)
+}
+
+// parseSQLiteTime 解析 sqlite 中存储的时间
+func parseSQLiteTime(value string) int64 {
+	for _, layout := range sqliteTimeLayouts {
+		if t, err := time.ParseInLocation(layout, value, time.UTC); err == nil {
+			return t.Unix()
+		}
+	}
+	return 0
+}
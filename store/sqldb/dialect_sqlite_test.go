@@ -0,0 +1,115 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package sqldb
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/polarismesh/polaris-server/store"
+)
+
+// TestSQLiteDialectRebind 测试sqlite的SQL改写
+func TestSQLiteDialectRebind(t *testing.T) {
+	d, err := getDialect(SQLiteDialect)
+	if err != nil {
+		t.Fatal(err)
+	}
+	Convey("保留占位符和分页，去掉锁语句", t, func() {
+		So(d.Rebind("select name from namespace where name = ? and comment = \"\" lock in share mode"),
+			ShouldEqual, "select name from namespace where name = ? and comment = ''")
+		So(d.Rebind("select module_id from cl5_module limit 0, 1 for update"),
+			ShouldEqual, "select module_id from cl5_module limit 0, 1")
+		So(d.Rebind("select id from instance force index(service_id, host) where host = ?"),
+			ShouldEqual, "select id from instance where host = ?")
+	})
+	Convey("default列名", t, func() {
+		So(d.Rebind("SELECT ag.id, ag.default FROM auth_strategy ag WHERE ag.default = 1"),
+			ShouldEqual, "SELECT ag.id, ag.\"default\" FROM auth_strategy ag WHERE ag.\"default\" = 1")
+	})
+	Convey("insert ignore 以及 on duplicate key update", t, func() {
+		So(d.Rebind("INSERT IGNORE INTO auth_principal(strategy_id) VALUES (?)"),
+			ShouldEqual, "insert or ignore into auth_principal(strategy_id) VALUES (?)")
+		So(d.Rebind("insert into ratelimit_revision(service_id,last_revision) values(?,?) "+
+			"on duplicate key update last_revision = ?"),
+			ShouldEqual, "insert into ratelimit_revision(service_id,last_revision) values(?,?) "+
+				"on conflict (service_id) do update set last_revision = ?")
+	})
+	Convey("value 关键字", t, func() {
+		So(d.Rebind("insert into config_file_group(name, namespace)value (?,?)"),
+			ShouldEqual, "insert into config_file_group(name, namespace) values (?,?)")
+	})
+	Convey("时间转换为UTC字符串", t, func() {
+		now := time.Date(2022, 1, 2, 11, 4, 5, 0, time.FixedZone("UTC+8", 8*3600))
+		out := d.BindArgs([]interface{}{now, "a"})
+		So(out[0], ShouldEqual, "2022-01-02 03:04:05")
+		So(out[1], ShouldEqual, "a")
+	})
+	Convey("解析sqlite中存储的时间", t, func() {
+		sec := time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC).Unix()
+		So(sqliteUnixTimestamp("2022-01-02 03:04:05"), ShouldEqual, sec)
+		So(sqliteUnixTimestamp([]byte("2022-01-02T03:04:05")), ShouldEqual, sec)
+		So(sqliteUnixTimestamp("2022-01-02 11:04:05.123+08:00"), ShouldEqual, sec)
+		So(sqliteFromUnixtime(sec), ShouldEqual, "2022-01-02 03:04:05")
+		So(sqliteUnixTimestamp("invalid"), ShouldEqual, 0)
+	})
+}
+
+// TestSQLiteStore 使用临时文件测试sqlite存储的基本读写
+func TestSQLiteStore(t *testing.T) {
+	if !SQLiteEnabled {
+		t.Skip("sqlite store requires cgo")
+	}
+	dir, err := ioutil.TempDir("", "polaris-sqlite")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	s := &stableStore{name: SQLiteStoreName, dbType: SQLiteDialect}
+	err = s.Initialize(&store.Config{
+		Name:   SQLiteStoreName,
+		Option: map[string]interface{}{"path": filepath.Join(dir, "polaris.db")},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Destroy()
+
+	testStableStore(t, s)
+
+	Convey("主库和事务共用一个连接池", t, func() {
+		So(s.masterTx, ShouldEqual, s.master)
+		db, _ := s.slave.pick()
+		So(db, ShouldEqual, s.master)
+	})
+	Convey("默认不记录变更日志", t, func() {
+		So(s.ChangeLogEnabled(), ShouldBeFalse)
+		latest, err := s.GetLatestChangeSeq(store.ChangeLogInstance)
+		So(err, ShouldBeNil)
+		So(latest, ShouldEqual, 0)
+	})
+	Convey("重复初始化表结构不报错", t, func() {
+		So(s.master.dialect.(schemaInitializer).InitSchema(s.master.DB), ShouldBeNil)
+	})
+}
@@ -55,7 +55,7 @@ var (
 
 type groupStore struct {
 	master *BaseDB
-	slave  *BaseDB
+	slave  *replicaSet
 }
 
 // AddGroup 创建一个用户组
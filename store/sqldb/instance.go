@@ -31,8 +31,9 @@ import (
 
 // instanceStore 实现了InstanceStore接口
 type instanceStore struct {
-	master *BaseDB // 大部分操作都用主数据库
-	slave  *BaseDB // 缓存相关的读取，请求到slave
+	master  *BaseDB         // 大部分操作都用主数据库
+	slave   *replicaSet     // 缓存相关的读取，请求到slave
+	changes *changeLogStore // 实例的变更日志
 }
 
 // AddInstance 添加实例
@@ -82,6 +83,10 @@ func (ins *instanceStore) addInstance(instance *model.Instance) error {
 		return err
 	}
 
+	if err := ins.changes.record(tx, store.ChangeLogInstance, instance.ID()); err != nil {
+		return err
+	}
+
 	if err := tx.Commit(); err != nil {
 		log.Errorf("[Store][database] add instance commit tx err: %s", err.Error())
 		return err
@@ -130,6 +135,14 @@ func (ins *instanceStore) batchAddInstances(instances []*model.Instance) error {
 		return err
 	}
 
+	ids := make([]interface{}, 0, len(instances))
+	for _, instance := range instances {
+		ids = append(ids, instance.ID())
+	}
+	if err := ins.changes.record(tx, store.ChangeLogInstance, ids...); err != nil {
+		return err
+	}
+
 	if err := tx.Commit(); err != nil {
 		log.Errorf("[Store][database] batch add instance commit tx err: %s", err.Error())
 		return err
@@ -210,6 +223,10 @@ func (ins *instanceStore) updateInstance(instance *model.Instance) error {
 		return err
 	}
 
+	if err := ins.changes.record(tx, store.ChangeLogInstance, instance.ID()); err != nil {
+		return err
+	}
+
 	if err := tx.Commit(); err != nil {
 		log.Errorf("[Store][database] update instance commit tx err: %s", err.Error())
 		return err
@@ -237,7 +254,12 @@ func (ins *instanceStore) DeleteInstance(instanceID string) error {
 	}
 
 	str := "update instance set flag = 1, mtime = sysdate() where `id` = ?"
-	_, err := ins.master.Exec(str, instanceID)
+	err := ins.changes.execWithRecord(ins.master, func(e execer) error {
+		if _, err := e.Exec(str, instanceID); err != nil {
+			return err
+		}
+		return ins.changes.record(e, store.ChangeLogInstance, instanceID)
+	})
 	return store.Error(err)
 }
 
@@ -248,7 +270,12 @@ func (ins *instanceStore) BatchDeleteInstances(ids []interface{}) error {
 			return nil
 		}
 		str := `update instance set flag = 1, mtime = sysdate() where id in ( ` + PlaceholdersN(len(objects)) + `)`
-		_, err := ins.master.Exec(str, objects...)
+		err := ins.changes.execWithRecord(ins.master, func(e execer) error {
+			if _, err := e.Exec(str, objects...); err != nil {
+				return err
+			}
+			return ins.changes.record(e, store.ChangeLogInstance, objects...)
+		})
 		return store.Error(err)
 	})
 }
@@ -415,7 +442,7 @@ func (ins *instanceStore) getExpandInstances(filter, metaFilter map[string]strin
 	order := &Order{"instance.mtime", "desc"}
 	str, args := genWhereSQLAndArgs(str, filter, metaFilter, order, offset, limit)
 
-	rows, err := ins.master.Query(str, args...)
+	rows, err := ins.slave.console().Query(str, args...)
 	if err != nil {
 		log.Errorf("[Store][database] get instance by filters query err: %s, str: %s, args: %v", err.Error(), str, args)
 		return nil, err
@@ -444,7 +471,7 @@ func (ins *instanceStore) getExpandInstancesCount(filter, metaFilter map[string]
 	var count uint32
 	var err error
 	Retry("query-instance-row", func() error {
-		err = ins.master.QueryRow(str, args...).Scan(&count)
+		err = ins.slave.console().QueryRow(str, args...).Scan(&count)
 		return err
 	})
 	switch {
@@ -510,7 +537,12 @@ func (ins *instanceStore) GetInstanceMeta(instanceID string) (map[string]string,
 // SetInstanceHealthStatus 设置实例健康状态
 func (ins *instanceStore) SetInstanceHealthStatus(instanceID string, flag int, revision string) error {
 	str := "update instance set health_status = ?, revision = ?, mtime = sysdate() where `id` = ?"
-	_, err := ins.master.Exec(str, flag, revision, instanceID)
+	err := ins.changes.execWithRecord(ins.master, func(e execer) error {
+		if _, err := e.Exec(str, flag, revision, instanceID); err != nil {
+			return err
+		}
+		return ins.changes.record(e, store.ChangeLogInstance, instanceID)
+	})
 	return store.Error(err)
 }
 
@@ -526,7 +558,12 @@ func (ins *instanceStore) BatchSetInstanceHealthStatus(ids []interface{}, isolat
 		args = append(args, isolate)
 		args = append(args, revision)
 		args = append(args, objects...)
-		_, err := ins.master.Exec(str, args...)
+		err := ins.changes.execWithRecord(ins.master, func(e execer) error {
+			if _, err := e.Exec(str, args...); err != nil {
+				return err
+			}
+			return ins.changes.record(e, store.ChangeLogInstance, objects...)
+		})
 		return store.Error(err)
 	})
 }
@@ -543,7 +580,12 @@ func (ins *instanceStore) BatchSetInstanceIsolate(ids []interface{}, isolate int
 		args = append(args, isolate)
 		args = append(args, revision)
 		args = append(args, objects...)
-		_, err := ins.master.Exec(str, args...)
+		err := ins.changes.execWithRecord(ins.master, func(e execer) error {
+			if _, err := e.Exec(str, args...); err != nil {
+				return err
+			}
+			return ins.changes.record(e, store.ChangeLogInstance, objects...)
+		})
 		return store.Error(err)
 	})
 }
@@ -769,6 +811,42 @@ func (ins *instanceStore) batchAcquireInstanceMetadata(instances []interface{})
 	return nil
 }
 
+// getInstancesByIDs 根据ID获取实例，包括已经被标记删除的实例
+func getInstancesByIDs(queryHandler QueryHandler, ids []interface{}, needMeta bool) (
+	map[string]*model.Instance, error) {
+	out := make(map[string]*model.Instance, len(ids))
+	err := BatchOperation("get-instances-by-ids", ids, func(objects []interface{}) error {
+		str := genInstanceSelectSQL()
+		if needMeta {
+			str = genCompleteInstanceSelectSQL()
+		}
+		str += "where instance.id in (" + PlaceholdersN(len(objects)) + ")"
+		rows, err := queryHandler(str, objects...)
+		if err != nil {
+			log.Errorf("[Store][database] get instances by ids query err: %s", err.Error())
+			return err
+		}
+		if needMeta {
+			instances, err := fetchInstanceWithMetaRows(rows)
+			if err != nil {
+				return err
+			}
+			for id, instance := range instances {
+				out[id] = instance
+			}
+			return nil
+		}
+		return callFetchInstanceRows(rows, func(entry *model.InstanceStore) (bool, error) {
+			out[entry.ID] = model.Store2Instance(entry)
+			return true, nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // batchQueryMetadata 批量查找metadata
 func batchQueryMetadata(queryHandler QueryHandler, instances []interface{}) (*sql.Rows, error) {
 	if len(instances) == 0 {
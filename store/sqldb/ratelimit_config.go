@@ -30,7 +30,8 @@ import (
 
 // rateLimitStore RateLimitStore的实现
 type rateLimitStore struct {
-	db *BaseDB
+	db    *BaseDB
+	slave *replicaSet
 }
 
 // CreateRateLimit 新建限流规则
@@ -221,7 +222,7 @@ func (rls *rateLimitStore) GetRateLimitsForCache(mtime time.Time,
 	if firstUpdate {
 		str += " and flag != 1" // nolint
 	}
-	rows, err := rls.db.Query(str, commontime.Time2String(mtime))
+	rows, err := rls.slave.Query(str, commontime.Time2String(mtime))
 	if err != nil {
 		log.Errorf("[Store][database] query rate limits with mtime err: %s", err.Error())
 		return nil, nil, err
@@ -245,7 +246,7 @@ func (rls *rateLimitStore) getExpandRateLimits(filter map[string]string, offset
 	args = append(args, offset, limit)
 	str = str + queryStr + ` order by ratelimit_config.mtime desc limit ?, ?`
 
-	rows, err := rls.db.Query(str, args...)
+	rows, err := rls.slave.console().Query(str, args...)
 	if err != nil {
 		log.Errorf("[Store][database] query rate limits err: %s", err.Error())
 		return nil, err
@@ -265,7 +266,7 @@ func (rls *rateLimitStore) getExpandRateLimitsCount(filter map[string]string) (u
 	queryStr, args := genFilterRateLimitSQL(filter)
 	str = str + queryStr
 	var total uint32
-	err := rls.db.QueryRow(str, args...).Scan(&total)
+	err := rls.slave.console().QueryRow(str, args...).Scan(&total)
 	switch {
 	case err == sql.ErrNoRows:
 		return 0, nil
@@ -0,0 +1,300 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package sqldb
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/polarismesh/polaris-server/plugin"
+)
+
+const (
+	// SlaveReadCache 只有缓存的增量拉取读取备库
+	SlaveReadCache = "cache"
+	// SlaveReadAll 缓存的增量拉取以及控制台的列表查询都读取备库
+	SlaveReadAll = "all"
+
+	// DefaultReplicaProbeInterval 默认的备库探测间隔，单位秒
+	DefaultReplicaProbeInterval = 5
+
+	// masterTarget 读请求回退到主库时的指标标签
+	masterTarget = "master"
+)
+
+var (
+	storeReadTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "store_read_total",
+		Help: "read-only queries of the sql store, by target database",
+	}, []string{"store", "target"})
+	storeReadFallbackTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "store_read_fallback_total",
+		Help: "read-only queries that fall back to the master database, by reason",
+	}, []string{"store", "reason"})
+	storeReplicaUp = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "store_replica_up",
+		Help: "whether the replica database can serve read-only queries",
+	}, []string{"store", "replica"})
+	storeReplicaLag = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "store_replica_lag_seconds",
+		Help: "replication lag of the replica database",
+	}, []string{"store", "replica"})
+)
+
+var replicaMetricsOnce sync.Once
+
+// registerReplicaMetrics 统计插件提供 prometheus registry 时，把备库路由的指标注册到统计插件中
+func registerReplicaMetrics() {
+	replicaMetricsOnce.Do(func() {
+		registry, ok := plugin.GetStatis().(plugin.MetricsRegistry)
+		if !ok {
+			return
+		}
+		for _, collector := range []prometheus.Collector{storeReadTotal, storeReadFallbackTotal, storeReplicaUp,
+			storeReplicaLag} {
+			if err := registry.GetRegistry().Register(collector); err != nil {
+				log.Errorf("[Store][database] register replica metrics err: %s", err.Error())
+			}
+		}
+	})
+}
+
+// querier 只读查询，BaseDB 和 replicaSet 都实现了该接口
+type querier interface {
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
+// replicaConfig 备库路由的配置
+type replicaConfig struct {
+	// readMode 哪些读请求路由到备库
+	readMode string
+	// probeInterval 健康探测以及复制延迟探测的间隔
+	probeInterval time.Duration
+	// maxLag 允许的最大复制延迟，超过后读请求回退到主库，为0时不检查复制延迟
+	maxLag time.Duration
+}
+
+// replica 一个只读备库
+type replica struct {
+	db   *BaseDB
+	addr string
+	// up 为1时表示最近一次探测成功
+	up int32
+	// lag 最近一次探测到的复制延迟，单位纳秒
+	lag int64
+	// lagUnknown 为1时表示无法查询复制延迟，只打印一次日志
+	lagUnknown int32
+}
+
+// available 备库是否可以提供读服务
+func (r *replica) available(maxLag time.Duration) bool {
+	if atomic.LoadInt32(&r.up) != 1 {
+		return false
+	}
+	return maxLag <= 0 || time.Duration(atomic.LoadInt64(&r.lag)) <= maxLag
+}
+
+// replicaSet 备库集合，读请求轮询健康且复制延迟在范围内的备库，没有可用的备库时回退到主库
+type replicaSet struct {
+	store    string
+	master   *BaseDB
+	replicas []*replica
+	cfg      *replicaConfig
+	next     uint32
+	stopCh   chan struct{}
+	stopOnce sync.Once
+}
+
+// newReplicaSet 创建备库集合，并启动后台探测，replicas 为空时所有读请求都由主库处理
+func newReplicaSet(store string, master *BaseDB, replicas []*BaseDB, cfg *replicaConfig) *replicaSet {
+	rs := &replicaSet{
+		store:  store,
+		master: master,
+		cfg:    cfg,
+		stopCh: make(chan struct{}),
+	}
+	for _, db := range replicas {
+		rs.replicas = append(rs.replicas, &replica{db: db, addr: db.cfg.dbAddr})
+	}
+	if len(rs.replicas) == 0 {
+		return rs
+	}
+	registerReplicaMetrics()
+	rs.probe()
+	go rs.run()
+	return rs
+}
+
+// run 定时探测备库
+func (rs *replicaSet) run() {
+	ticker := time.NewTicker(rs.cfg.probeInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			rs.probe()
+		case <-rs.stopCh:
+			return
+		}
+	}
+}
+
+// probe 探测所有备库的连通性以及复制延迟
+func (rs *replicaSet) probe() {
+	for _, r := range rs.replicas {
+		lag, err := probeReplica(r.db)
+		lagKnown := true
+		if unknownErr, ok := err.(*lagUnknownError); ok {
+			if atomic.SwapInt32(&r.lagUnknown, 1) == 0 {
+				log.Warnf("[Store][database] replica(%s) %s, skip the lag check", r.addr, unknownErr.Error())
+			}
+			lag, err, lagKnown = 0, nil, false
+		}
+		if err != nil {
+			if atomic.SwapInt32(&r.up, 0) == 1 {
+				log.Errorf("[Store][database] replica(%s) is down: %s", r.addr, err.Error())
+			}
+			storeReplicaUp.WithLabelValues(rs.store, r.addr).Set(0)
+			continue
+		}
+		if atomic.SwapInt32(&r.up, 1) == 0 {
+			log.Infof("[Store][database] replica(%s) is up, replication lag %v", r.addr, lag)
+		}
+		atomic.StoreInt64(&r.lag, int64(lag))
+		storeReplicaUp.WithLabelValues(rs.store, r.addr).Set(1)
+		if lagKnown {
+			storeReplicaLag.WithLabelValues(rs.store, r.addr).Set(lag.Seconds())
+		}
+	}
+}
+
+// probeReplica 检查备库是否可以连通，方言支持时查询复制延迟
+func probeReplica(db *BaseDB) (time.Duration, error) {
+	if err := db.Ping(); err != nil {
+		return 0, err
+	}
+	lagger, ok := db.dialect.(replicationLagger)
+	if !ok {
+		return 0, nil
+	}
+	return lagger.ReplicationLag(db.DB)
+}
+
+// pick 选择处理读请求的数据库
+func (rs *replicaSet) pick() (*BaseDB, *replica) {
+	if len(rs.replicas) == 0 {
+		return rs.master, nil
+	}
+	lagging := false
+	for i := 0; i < len(rs.replicas); i++ {
+		r := rs.replicas[int(atomic.AddUint32(&rs.next, 1))%len(rs.replicas)]
+		if r.available(rs.cfg.maxLag) {
+			storeReadTotal.WithLabelValues(rs.store, r.addr).Inc()
+			return r.db, r
+		}
+		if atomic.LoadInt32(&r.up) == 1 {
+			lagging = true
+		}
+	}
+	reason := "down"
+	if lagging {
+		reason = "lag"
+	}
+	storeReadFallbackTotal.WithLabelValues(rs.store, reason).Inc()
+	storeReadTotal.WithLabelValues(rs.store, masterTarget).Inc()
+	return rs.master, nil
+}
+
+// fallback 备库执行失败，连接类的错误将备库标记为不可用，然后由主库重新执行
+func (rs *replicaSet) fallback(r *replica, err error) *BaseDB {
+	log.Warnf("[Store][database] query on replica(%s) err: %s, fallback to master", r.addr, err.Error())
+	if isConnError(err) && atomic.SwapInt32(&r.up, 0) == 1 {
+		storeReplicaUp.WithLabelValues(rs.store, r.addr).Set(0)
+	}
+	storeReadFallbackTotal.WithLabelValues(rs.store, "error").Inc()
+	storeReadTotal.WithLabelValues(rs.store, masterTarget).Inc()
+	return rs.master
+}
+
+// Query 在备库上执行查询，失败时回退到主库
+func (rs *replicaSet) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	db, r := rs.pick()
+	rows, err := db.Query(query, args...)
+	if err != nil && r != nil {
+		return rs.fallback(r, err).Query(query, args...)
+	}
+	return rows, err
+}
+
+// QueryRow 在备库上执行单行查询，错误在 Scan 时才返回，因此不做回退
+func (rs *replicaSet) QueryRow(query string, args ...interface{}) *sql.Row {
+	db, _ := rs.pick()
+	return db.QueryRow(query, args...)
+}
+
+// Begin 在备库上开启只读的事务，失败时回退到主库
+func (rs *replicaSet) Begin() (*BaseTx, error) {
+	db, r := rs.pick()
+	tx, err := db.Begin()
+	if err != nil && r != nil {
+		return rs.fallback(r, err).Begin()
+	}
+	return tx, err
+}
+
+// console 控制台的列表查询使用的数据库，只有配置为 all 时才读取备库
+func (rs *replicaSet) console() querier {
+	if rs.cfg.readMode == SlaveReadAll {
+		return rs
+	}
+	return rs.master
+}
+
+// Close 停止探测并关闭备库的连接
+func (rs *replicaSet) Close() error {
+	rs.stopOnce.Do(func() { close(rs.stopCh) })
+	for _, r := range rs.replicas {
+		_ = r.db.Close()
+		storeReplicaUp.DeleteLabelValues(rs.store, r.addr)
+		storeReplicaLag.DeleteLabelValues(rs.store, r.addr)
+	}
+	return nil
+}
+
+// isConnError 是否为连接类的错误
+func isConnError(err error) bool {
+	if errors.Is(err, driver.ErrBadConn) {
+		return true
+	}
+	msg := err.Error()
+	for _, s := range []string{"bad connection", "invalid connection", "connection refused", "broken pipe",
+		"i/o timeout", "database is closed"} {
+		if strings.Contains(msg, s) {
+			return true
+		}
+	}
+	return false
+}
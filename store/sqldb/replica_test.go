@@ -0,0 +1,126 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package sqldb
+
+import (
+	"database/sql"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// newValueDB 创建只有一行数据的sqlite数据库，用于区分读请求落在哪个库上
+func newValueDB(t *testing.T, path, value string) *BaseDB {
+	db, err := NewBaseDB(&dbConfig{dbType: SQLiteDialect, dbAddr: path, dbName: path}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Exec("create table t (v varchar(32))"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Exec("insert into t(v) values(?)", value); err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+// lagDeniedDialect 模拟没有权限查询复制延迟的备库
+type lagDeniedDialect struct {
+	dialect
+}
+
+// ReplicationLag 返回无法得知复制延迟
+func (d *lagDeniedDialect) ReplicationLag(db *sql.DB) (time.Duration, error) {
+	return 0, &lagUnknownError{cause: errors.New("access denied")}
+}
+
+// readValue 通过 querier 读取数据，返回读取到的库
+func readValue(q querier) string {
+	rows, err := q.Query("select v from t")
+	So(err, ShouldBeNil)
+	defer rows.Close()
+	var v string
+	So(rows.Next(), ShouldBeTrue)
+	So(rows.Scan(&v), ShouldBeNil)
+	return v
+}
+
+// TestReplicaSet 测试读请求在备库和主库之间的路由
+func TestReplicaSet(t *testing.T) {
+	if !SQLiteEnabled {
+		t.Skip("sqlite store requires cgo")
+	}
+	dir, err := ioutil.TempDir("", "polaris-replica")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	master := newValueDB(t, filepath.Join(dir, "master.db"), "master")
+	defer master.Close()
+	slave := newValueDB(t, filepath.Join(dir, "slave.db"), "slave")
+	rs := newReplicaSet(SQLiteStoreName, master, []*BaseDB{slave},
+		&replicaConfig{readMode: SlaveReadCache, probeInterval: time.Hour, maxLag: time.Second})
+	defer rs.Close()
+	r := rs.replicas[0]
+
+	Convey("备库可用时读取备库", t, func() {
+		So(atomic.LoadInt32(&r.up), ShouldEqual, 1)
+		So(readValue(rs), ShouldEqual, "slave")
+	})
+	Convey("控制台查询按照配置决定是否读取备库", t, func() {
+		So(readValue(rs.console()), ShouldEqual, "master")
+		rs.cfg.readMode = SlaveReadAll
+		So(readValue(rs.console()), ShouldEqual, "slave")
+	})
+	Convey("复制延迟超过阈值时回退到主库", t, func() {
+		atomic.StoreInt64(&r.lag, int64(2*time.Second))
+		So(readValue(rs), ShouldEqual, "master")
+		atomic.StoreInt64(&r.lag, 0)
+		So(readValue(rs), ShouldEqual, "slave")
+	})
+	Convey("备库查询失败时回退到主库，并标记备库不可用", t, func() {
+		So(slave.Close(), ShouldBeNil)
+		So(readValue(rs), ShouldEqual, "master")
+		So(atomic.LoadInt32(&r.up), ShouldEqual, 0)
+		rs.probe()
+		So(atomic.LoadInt32(&r.up), ShouldEqual, 0)
+	})
+	Convey("没有权限查询复制延迟时备库仍然可用", t, func() {
+		denied := newValueDB(t, filepath.Join(dir, "denied.db"), "denied")
+		denied.dialect = &lagDeniedDialect{dialect: denied.dialect}
+		deniedSet := newReplicaSet(SQLiteStoreName, master, []*BaseDB{denied},
+			&replicaConfig{readMode: SlaveReadCache, probeInterval: time.Hour, maxLag: time.Second})
+		defer deniedSet.Close()
+		So(atomic.LoadInt32(&deniedSet.replicas[0].up), ShouldEqual, 1)
+		So(atomic.LoadInt32(&deniedSet.replicas[0].lagUnknown), ShouldEqual, 1)
+		So(readValue(deniedSet), ShouldEqual, "denied")
+	})
+	Convey("没有配置备库时读取主库", t, func() {
+		empty := newReplicaSet(SQLiteStoreName, master, nil, &replicaConfig{readMode: SlaveReadAll})
+		defer empty.Close()
+		So(readValue(empty), ShouldEqual, "master")
+		So(readValue(empty.console()), ShouldEqual, "master")
+	})
+}
@@ -30,7 +30,7 @@ import (
 // RoutingConfigStore的实现
 type routingConfigStore struct {
 	master *BaseDB
-	slave  *BaseDB
+	slave  *replicaSet
 }
 
 // 新建RoutingConfig
@@ -176,7 +176,7 @@ func (rs *routingConfigStore) GetRoutingConfigs(filter map[string]string,
 	filterStr, args := genFilterRoutingConfigSQL(filter)
 	countStr := genQueryRoutingConfigCountSQL() + filterStr
 	var total uint32
-	err := rs.master.QueryRow(countStr, args...).Scan(&total)
+	err := rs.slave.console().QueryRow(countStr, args...).Scan(&total)
 	switch {
 	case err == sql.ErrNoRows:
 		return 0, nil, nil
@@ -188,7 +188,7 @@ func (rs *routingConfigStore) GetRoutingConfigs(filter map[string]string,
 
 	str := genQueryRoutingConfigSQL() + filterStr + " order by routing_config.mtime desc limit ?, ?"
 	args = append(args, offset, limit)
-	rows, err := rs.master.Query(str, args...)
+	rows, err := rs.slave.console().Query(str, args...)
 	if err != nil {
 		log.Errorf("[Store][database] get routing configs query err: %s", err.Error())
 		return 0, nil, err
@@ -0,0 +1,76 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package sqldb
+
+import (
+	"io/ioutil"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+var (
+	createTableRegex  = regexp.MustCompile("(?is)CREATE\\s+TABLE\\s+(?:IF\\s+NOT\\s+EXISTS\\s+)?[`\"](\\w+)[`\"]\\s*\\((.*?)\\n\\s*\\)")
+	tableColumnRegex  = regexp.MustCompile("(?m)^\\s*[`\"](\\w+)[`\"]\\s+\\w+")
+	mysqlScriptPath   = "scripts/polaris_server.sql"
+	postgresqlScripts = "scripts/postgresql/polaris_server.sql"
+)
+
+// schemaColumns 解析建表语句，返回 表名 -> 排序后的列名
+func schemaColumns(schema string) map[string][]string {
+	tables := make(map[string][]string)
+	for _, m := range createTableRegex.FindAllStringSubmatch(schema, -1) {
+		columns := make([]string, 0)
+		for _, col := range tableColumnRegex.FindAllStringSubmatch(m[2], -1) {
+			columns = append(columns, strings.ToLower(col[1]))
+		}
+		sort.Strings(columns)
+		tables[strings.ToLower(m[1])] = columns
+	}
+	return tables
+}
+
+// readSchema 读取建表脚本
+func readSchema(t *testing.T, path string) string {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(content)
+}
+
+// TestPostgreSQLSchema postgresql 建表脚本的表和列需要与 mysql 保持一致
+func TestPostgreSQLSchema(t *testing.T) {
+	mysqlTables := schemaColumns(readSchema(t, mysqlScriptPath))
+	Convey("表和列与mysql一致", t, func() {
+		So(len(mysqlTables), ShouldBeGreaterThan, 0)
+		So(schemaColumns(readSchema(t, postgresqlScripts)), ShouldResemble, mysqlTables)
+	})
+}
+
+// TestSQLiteSchema sqlite 的建表语句需要与 mysql 的建表脚本保持一致
+// sqlite 只会创建缺失的表，修改已有表的字段时需要同步修改 sqliteSchema 并考虑存量数据
+func TestSQLiteSchema(t *testing.T) {
+	mysqlTables := schemaColumns(readSchema(t, mysqlScriptPath))
+	Convey("表和列与mysql一致", t, func() {
+		So(schemaColumns(sqliteSchema), ShouldResemble, mysqlTables)
+	})
+}
@@ -0,0 +1,159 @@
+/*
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+CREATE TABLE `service_change_log` (
+    `seq` bigint(20) unsigned NOT NULL AUTO_INCREMENT comment 'change sequence, increase monotonically',
+    `id` varchar(128) COLLATE utf8_bin NOT NULL comment 'changed service id',
+    `ctime` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP comment 'create time',
+    PRIMARY KEY (`seq`),
+    KEY `ctime` (`ctime`)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8 COLLATE = utf8_bin;
+
+CREATE TABLE `instance_change_log` (
+    `seq` bigint(20) unsigned NOT NULL AUTO_INCREMENT comment 'change sequence, increase monotonically',
+    `id` varchar(128) COLLATE utf8_bin NOT NULL comment 'changed instance id',
+    `ctime` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP comment 'create time',
+    PRIMARY KEY (`seq`),
+    KEY `ctime` (`ctime`)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8 COLLATE = utf8_bin;
+
+-- --------------------------------------------------------
+--
+-- Table structure `config_file_gray_release`
+--
+CREATE TABLE `config_file_gray_release`
+(
+    `id`          bigint unsigned               NOT NULL AUTO_INCREMENT COMMENT '主键',
+    `name`        varchar(128) COLLATE utf8_bin          DEFAULT NULL COMMENT '发布标题',
+    `namespace`   varchar(64) COLLATE utf8_bin  NOT NULL COMMENT '所属的namespace',
+    `group`       varchar(128) COLLATE utf8_bin NOT NULL COMMENT '所属的文件组',
+    `file_name`   varchar(128) COLLATE utf8_bin NOT NULL COMMENT '配置文件名',
+    `content`     longtext COLLATE utf8_bin     NOT NULL COMMENT '文件内容',
+    `comment`     varchar(512) COLLATE utf8_bin          DEFAULT NULL COMMENT '备注信息',
+    `md5`         varchar(128) COLLATE utf8_bin NOT NULL COMMENT 'content的md5值',
+    `version`     int(11)                       NOT NULL COMMENT '版本号，与正式发布共用版本序列',
+    `gray_rule`   text COLLATE utf8_bin         NOT NULL COMMENT '灰度规则，json格式的客户端IP列表和标签',
+    `flag`        tinyint(4)                    NOT NULL DEFAULT '0' COMMENT '是否被删除',
+    `create_time` timestamp                     NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+    `create_by`   varchar(32) COLLATE utf8_bin           DEFAULT NULL COMMENT '创建人',
+    `modify_time` timestamp                     NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '最后更新时间',
+    `modify_by`   varchar(32) COLLATE utf8_bin           DEFAULT NULL COMMENT '最后更新人',
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uk_file` (`namespace`, `group`, `file_name`),
+    KEY `idx_modify_time` (`modify_time`)
+) ENGINE = InnoDB
+  AUTO_INCREMENT = 1
+  DEFAULT CHARSET = utf8
+  COLLATE = utf8_bin COMMENT ='配置文件灰度发布表';
+
+-- --------------------------------------------------------
+--
+-- Alter table `config_file_group`
+--
+ALTER TABLE `config_file_group`
+    ADD COLUMN `json_schema` text COLLATE utf8_bin DEFAULT NULL COMMENT '配置文件内容的JSON Schema约束' AFTER `owner`;
+
+-- --------------------------------------------------------
+--
+-- Alter table `config_file`
+--
+ALTER TABLE `config_file`
+    ADD COLUMN `encrypted` tinyint(4) NOT NULL DEFAULT '0' COMMENT '文件内容是否加密' AFTER `comment`;
+
+-- --------------------------------------------------------
+--
+-- Alter table `config_file`
+--
+ALTER TABLE `config_file`
+    ADD COLUMN `base_file` varchar(512) COLLATE utf8_bin DEFAULT NULL COMMENT '继承的基础配置文件，格式为namespace/group/name' AFTER `encrypted`,
+    ADD COLUMN `variables_file` varchar(512) COLLATE utf8_bin DEFAULT NULL COMMENT '模板变量文件，格式为namespace/group/name' AFTER `base_file`;
+
+-- --------------------------------------------------------
+--
+-- Alter table `config_file_release_history`
+--
+ALTER TABLE `config_file_release_history`
+    ADD COLUMN `template_content` longtext COLLATE utf8_bin DEFAULT NULL COMMENT '模板渲染前的文件内容' AFTER `content`;
+
+-- --------------------------------------------------------
+--
+-- Table structure `config_file_listener`
+--
+CREATE TABLE `config_file_listener`
+(
+    `id`             bigint unsigned               NOT NULL AUTO_INCREMENT COMMENT '主键',
+    `namespace`      varchar(64) COLLATE utf8_bin  NOT NULL COMMENT '所属的namespace',
+    `group`          varchar(128) COLLATE utf8_bin NOT NULL COMMENT '所属的文件组',
+    `file_name`      varchar(128) COLLATE utf8_bin NOT NULL COMMENT '配置文件名',
+    `client_id`      varchar(128) COLLATE utf8_bin NOT NULL COMMENT '客户端ID',
+    `client_ip`      varchar(64) COLLATE utf8_bin  NOT NULL DEFAULT '' COMMENT '客户端IP',
+    `version`        bigint unsigned               NOT NULL DEFAULT 0 COMMENT '客户端持有的配置版本号',
+    `last_poll_time` timestamp                     NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '客户端最后一次订阅的时间',
+    `server`         varchar(64) COLLATE utf8_bin  NOT NULL DEFAULT '' COMMENT '客户端连接的server节点',
+    `modify_time`    timestamp                     NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '最后上报时间',
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uk_listener` (`namespace`, `group`, `file_name`, `client_id`),
+    KEY `idx_modify_time` (`modify_time`)
+) ENGINE = InnoDB
+  AUTO_INCREMENT = 1
+  DEFAULT CHARSET = utf8
+  COLLATE = utf8_bin COMMENT ='配置文件订阅者表';
+
+-- --------------------------------------------------------
+--
+-- Table structure `cluster_event`
+--
+CREATE TABLE `cluster_event` (
+    `seq` bigint(20) unsigned NOT NULL AUTO_INCREMENT comment 'event sequence, increase monotonically',
+    `topic` varchar(64) COLLATE utf8_bin NOT NULL comment 'event topic',
+    `payload` text COLLATE utf8_bin NOT NULL comment 'event payload',
+    `ctime` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP comment 'create time',
+    PRIMARY KEY (`seq`),
+    KEY `ctime` (`ctime`)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8 COLLATE = utf8_bin;
+
+-- --------------------------------------------------------
+--
+-- Table structure `config_file_publish_request`
+--
+CREATE TABLE `config_file_publish_request`
+(
+    `id`              varchar(128) COLLATE utf8_bin NOT NULL COMMENT '主键',
+    `namespace`       varchar(64) COLLATE utf8_bin  NOT NULL COMMENT '所属的namespace',
+    `group`           varchar(128) COLLATE utf8_bin NOT NULL COMMENT '所属的文件组',
+    `file_name`       varchar(128) COLLATE utf8_bin NOT NULL COMMENT '配置文件名',
+    `comment`         varchar(512) COLLATE utf8_bin NOT NULL DEFAULT '' COMMENT '发布说明',
+    `status`          varchar(32) COLLATE utf8_bin  NOT NULL COMMENT '申请状态',
+    `approve_users`   text COLLATE utf8_bin         NOT NULL COMMENT '审批用户ID，逗号分隔',
+    `approve_groups`  text COLLATE utf8_bin         NOT NULL COMMENT '审批用户组ID，逗号分隔',
+    `plan_time`       bigint                        NOT NULL DEFAULT 0 COMMENT '计划发布时间，秒级时间戳，0表示审批通过后手动发布',
+    `approve_by`      varchar(128) COLLATE utf8_bin NOT NULL DEFAULT '' COMMENT '审批人',
+    `approve_comment` varchar(512) COLLATE utf8_bin NOT NULL DEFAULT '' COMMENT '审批意见',
+    `approve_time`    bigint                        NOT NULL DEFAULT 0 COMMENT '审批时间，秒级时间戳',
+    `approve_md5`     varchar(128) COLLATE utf8_bin NOT NULL DEFAULT '' COMMENT '审批时草稿内容的md5，发布时校验草稿未被修改',
+    `publish_time`    bigint                        NOT NULL DEFAULT 0 COMMENT '发布时间，秒级时间戳',
+    `fail_reason`     varchar(1024) COLLATE utf8_bin NOT NULL DEFAULT '' COMMENT '发布失败原因',
+    `create_time`     timestamp                     NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+    `create_by`       varchar(32) COLLATE utf8_bin  NOT NULL DEFAULT '' COMMENT '创建人',
+    `modify_time`     timestamp                     NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '最后更新时间',
+    `modify_by`       varchar(32) COLLATE utf8_bin  NOT NULL DEFAULT '' COMMENT '最后更新人',
+    PRIMARY KEY (`id`),
+    KEY `idx_file` (`namespace`, `group`, `file_name`),
+    KEY `idx_status_plan_time` (`status`, `plan_time`)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8
+  COLLATE = utf8_bin COMMENT ='配置发布申请表';
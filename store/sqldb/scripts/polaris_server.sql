@@ -696,6 +696,9 @@ CREATE TABLE `config_file`
     `content`     longtext COLLATE utf8_bin     NOT NULL COMMENT '文件内容',
     `format`      varchar(16) COLLATE utf8_bin           DEFAULT 'text' COMMENT '文件格式，枚举值',
     `comment`     varchar(512) COLLATE utf8_bin          DEFAULT NULL COMMENT '备注信息',
+    `encrypted`   tinyint(4)                    NOT NULL DEFAULT '0' COMMENT '文件内容是否加密',
+    `base_file`   varchar(512) COLLATE utf8_bin          DEFAULT NULL COMMENT '继承的基础配置文件，格式为namespace/group/name',
+    `variables_file` varchar(512) COLLATE utf8_bin       DEFAULT NULL COMMENT '模板变量文件，格式为namespace/group/name',
     `flag`        tinyint(4)                    NOT NULL DEFAULT '0' COMMENT '软删除标记位',
     `create_time` timestamp                     NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
     `create_by`   varchar(32) COLLATE utf8_bin           DEFAULT NULL COMMENT '创建人',
@@ -719,6 +722,7 @@ CREATE TABLE `config_file_group`
     `namespace`   varchar(64) COLLATE utf8_bin  NOT NULL COMMENT '所属的namespace',
     `comment`     varchar(512) COLLATE utf8_bin          DEFAULT NULL COMMENT '备注信息',
     `owner`       varchar(1024) COLLATE utf8_bin         DEFAULT NULL COMMENT '负责人',
+    `json_schema` text COLLATE utf8_bin                  DEFAULT NULL COMMENT '配置文件内容的JSON Schema约束',
     `create_time` timestamp                     NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
     `create_by`   varchar(32) COLLATE utf8_bin           DEFAULT NULL COMMENT '创建人',
     `modify_time` timestamp                     NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '最后更新时间',
@@ -758,6 +762,35 @@ CREATE TABLE `config_file_release`
   DEFAULT CHARSET = utf8
   COLLATE = utf8_bin COMMENT ='配置文件发布表';
 
+-- --------------------------------------------------------
+--
+-- Table structure `config_file_gray_release`
+--
+CREATE TABLE `config_file_gray_release`
+(
+    `id`          bigint unsigned               NOT NULL AUTO_INCREMENT COMMENT '主键',
+    `name`        varchar(128) COLLATE utf8_bin          DEFAULT NULL COMMENT '发布标题',
+    `namespace`   varchar(64) COLLATE utf8_bin  NOT NULL COMMENT '所属的namespace',
+    `group`       varchar(128) COLLATE utf8_bin NOT NULL COMMENT '所属的文件组',
+    `file_name`   varchar(128) COLLATE utf8_bin NOT NULL COMMENT '配置文件名',
+    `content`     longtext COLLATE utf8_bin     NOT NULL COMMENT '文件内容',
+    `comment`     varchar(512) COLLATE utf8_bin          DEFAULT NULL COMMENT '备注信息',
+    `md5`         varchar(128) COLLATE utf8_bin NOT NULL COMMENT 'content的md5值',
+    `version`     int(11)                       NOT NULL COMMENT '版本号，与正式发布共用版本序列',
+    `gray_rule`   text COLLATE utf8_bin         NOT NULL COMMENT '灰度规则，json格式的客户端IP列表和标签',
+    `flag`        tinyint(4)                    NOT NULL DEFAULT '0' COMMENT '是否被删除',
+    `create_time` timestamp                     NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+    `create_by`   varchar(32) COLLATE utf8_bin           DEFAULT NULL COMMENT '创建人',
+    `modify_time` timestamp                     NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '最后更新时间',
+    `modify_by`   varchar(32) COLLATE utf8_bin           DEFAULT NULL COMMENT '最后更新人',
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uk_file` (`namespace`, `group`, `file_name`),
+    KEY `idx_modify_time` (`modify_time`)
+) ENGINE = InnoDB
+  AUTO_INCREMENT = 1
+  DEFAULT CHARSET = utf8
+  COLLATE = utf8_bin COMMENT ='配置文件灰度发布表';
+
 -- --------------------------------------------------------
 --
 -- Table structure `config_file_release_history`
@@ -770,6 +803,7 @@ CREATE TABLE `config_file_release_history`
     `group`       varchar(128) COLLATE utf8_bin NOT NULL COMMENT '所属的文件组',
     `file_name`   varchar(128) COLLATE utf8_bin NOT NULL COMMENT '配置文件名',
     `content`     longtext COLLATE utf8_bin     NOT NULL COMMENT '文件内容',
+    `template_content` longtext COLLATE utf8_bin         DEFAULT NULL COMMENT '模板渲染前的文件内容',
     `format`      varchar(16) COLLATE utf8_bin           DEFAULT 'text' COMMENT '文件格式',
     `tags`        varchar(2048) COLLATE utf8_bin         DEFAULT '' COMMENT '文件标签',
     `comment`     varchar(512) COLLATE utf8_bin          DEFAULT NULL COMMENT '备注信息',
@@ -811,6 +845,62 @@ CREATE TABLE `config_file_tag`
   DEFAULT CHARSET = utf8
   COLLATE = utf8_bin COMMENT ='配置文件标签表';
 
+-- --------------------------------------------------------
+--
+-- Table structure `config_file_listener`
+--
+CREATE TABLE `config_file_listener`
+(
+    `id`             bigint unsigned               NOT NULL AUTO_INCREMENT COMMENT '主键',
+    `namespace`      varchar(64) COLLATE utf8_bin  NOT NULL COMMENT '所属的namespace',
+    `group`          varchar(128) COLLATE utf8_bin NOT NULL COMMENT '所属的文件组',
+    `file_name`      varchar(128) COLLATE utf8_bin NOT NULL COMMENT '配置文件名',
+    `client_id`      varchar(128) COLLATE utf8_bin NOT NULL COMMENT '客户端ID',
+    `client_ip`      varchar(64) COLLATE utf8_bin  NOT NULL DEFAULT '' COMMENT '客户端IP',
+    `version`        bigint unsigned               NOT NULL DEFAULT 0 COMMENT '客户端持有的配置版本号',
+    `last_poll_time` timestamp                     NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '客户端最后一次订阅的时间',
+    `server`         varchar(64) COLLATE utf8_bin  NOT NULL DEFAULT '' COMMENT '客户端连接的server节点',
+    `modify_time`    timestamp                     NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '最后上报时间',
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uk_listener` (`namespace`, `group`, `file_name`, `client_id`),
+    KEY `idx_modify_time` (`modify_time`)
+) ENGINE = InnoDB
+  AUTO_INCREMENT = 1
+  DEFAULT CHARSET = utf8
+  COLLATE = utf8_bin COMMENT ='配置文件订阅者表';
+
+-- --------------------------------------------------------
+--
+-- Table structure `config_file_publish_request`
+--
+CREATE TABLE `config_file_publish_request`
+(
+    `id`              varchar(128) COLLATE utf8_bin NOT NULL COMMENT '主键',
+    `namespace`       varchar(64) COLLATE utf8_bin  NOT NULL COMMENT '所属的namespace',
+    `group`           varchar(128) COLLATE utf8_bin NOT NULL COMMENT '所属的文件组',
+    `file_name`       varchar(128) COLLATE utf8_bin NOT NULL COMMENT '配置文件名',
+    `comment`         varchar(512) COLLATE utf8_bin NOT NULL DEFAULT '' COMMENT '发布说明',
+    `status`          varchar(32) COLLATE utf8_bin  NOT NULL COMMENT '申请状态',
+    `approve_users`   text COLLATE utf8_bin         NOT NULL COMMENT '审批用户ID，逗号分隔',
+    `approve_groups`  text COLLATE utf8_bin         NOT NULL COMMENT '审批用户组ID，逗号分隔',
+    `plan_time`       bigint                        NOT NULL DEFAULT 0 COMMENT '计划发布时间，秒级时间戳，0表示审批通过后手动发布',
+    `approve_by`      varchar(128) COLLATE utf8_bin NOT NULL DEFAULT '' COMMENT '审批人',
+    `approve_comment` varchar(512) COLLATE utf8_bin NOT NULL DEFAULT '' COMMENT '审批意见',
+    `approve_time`    bigint                        NOT NULL DEFAULT 0 COMMENT '审批时间，秒级时间戳',
+    `approve_md5`     varchar(128) COLLATE utf8_bin NOT NULL DEFAULT '' COMMENT '审批时草稿内容的md5，发布时校验草稿未被修改',
+    `publish_time`    bigint                        NOT NULL DEFAULT 0 COMMENT '发布时间，秒级时间戳',
+    `fail_reason`     varchar(1024) COLLATE utf8_bin NOT NULL DEFAULT '' COMMENT '发布失败原因',
+    `create_time`     timestamp                     NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+    `create_by`       varchar(32) COLLATE utf8_bin  NOT NULL DEFAULT '' COMMENT '创建人',
+    `modify_time`     timestamp                     NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '最后更新时间',
+    `modify_by`       varchar(32) COLLATE utf8_bin  NOT NULL DEFAULT '' COMMENT '最后更新人',
+    PRIMARY KEY (`id`),
+    KEY `idx_file` (`namespace`, `group`, `file_name`),
+    KEY `idx_status_plan_time` (`status`, `plan_time`)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8
+  COLLATE = utf8_bin COMMENT ='配置发布申请表';
+
 /*!40101 SET CHARACTER_SET_CLIENT = @OLD_CHARACTER_SET_CLIENT */;
 /*!40101 SET CHARACTER_SET_RESULTS = @OLD_CHARACTER_SET_RESULTS */;
 /*!40101 SET COLLATION_CONNECTION = @OLD_COLLATION_CONNECTION */;
@@ -1014,4 +1104,31 @@ CREATE TABLE `client_stat` (
     `protocol` VARCHAR(100) COLLATE utf8_bin NOT NULL comment 'stat info transport protocol',
     `path` VARCHAR(128) COLLATE utf8_bin NOT NULL comment 'stat metric path',
     PRIMARY KEY (`client_id`, `target`, `port`)
-)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8 COLLATE = utf8_bin;
+
+-- v1.9.0, support change log of service and instance
+CREATE TABLE `service_change_log` (
+    `seq` bigint(20) unsigned NOT NULL AUTO_INCREMENT comment 'change sequence, increase monotonically',
+    `id` varchar(128) COLLATE utf8_bin NOT NULL comment 'changed service id',
+    `ctime` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP comment 'create time',
+    PRIMARY KEY (`seq`),
+    KEY `ctime` (`ctime`)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8 COLLATE = utf8_bin;
+
+CREATE TABLE `instance_change_log` (
+    `seq` bigint(20) unsigned NOT NULL AUTO_INCREMENT comment 'change sequence, increase monotonically',
+    `id` varchar(128) COLLATE utf8_bin NOT NULL comment 'changed instance id',
+    `ctime` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP comment 'create time',
+    PRIMARY KEY (`seq`),
+    KEY `ctime` (`ctime`)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8 COLLATE = utf8_bin;
+
+-- cluster event bus of config release notification
+CREATE TABLE `cluster_event` (
+    `seq` bigint(20) unsigned NOT NULL AUTO_INCREMENT comment 'event sequence, increase monotonically',
+    `topic` varchar(64) COLLATE utf8_bin NOT NULL comment 'event topic',
+    `payload` text COLLATE utf8_bin NOT NULL comment 'event payload',
+    `ctime` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP comment 'create time',
+    PRIMARY KEY (`seq`),
+    KEY `ctime` (`ctime`)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8 COLLATE = utf8_bin;
@@ -0,0 +1,161 @@
+/*
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+--
+-- PostgreSQL delta script for upgrading polaris_server from v1.6.0 to v1.7.0
+--
+
+INSERT INTO "service" ("id", "name", "namespace", "comment", "business", "token", "revision", "owner", "flag",
+                       "ctime", "mtime")
+VALUES ('e6542db1a2cc846c1866010b40b7f51f', 'polaris.config', 'Polaris', 'polaris config service', 'polaris',
+        'c874d9a0a4b45c82c93e6bf285518c7b', '769ec01f58875088faf2cb9e44a4b2d2', 'polaris', 0,
+        '2021-09-06 07:55:07', '2021-09-06 07:55:11');
+
+-- Support polarismesh Authentication Ability
+
+--
+-- Table structure `user`
+--
+CREATE TABLE "user"
+(
+    "id" varchar(128) NOT NULL, -- User ID
+    "name" varchar(100) NOT NULL, -- user name
+    "password" varchar(100) NOT NULL, -- user password
+    "owner" varchar(128) NOT NULL, -- Main account ID
+    "source" varchar(32) NOT NULL, -- Account source
+    "mobile" varchar(12) NOT NULL DEFAULT '', -- Account mobile phone number
+    "email" varchar(64) NOT NULL DEFAULT '', -- Account mailbox
+    "token" varchar(255) NOT NULL, -- The token information owned by the account can be used for SDK access authentication
+    "token_enable" smallint NOT NULL DEFAULT 1,
+    "user_type" integer NOT NULL DEFAULT 20, -- Account type, 0 is the admin super account, 20 is the primary account, 50 for the child account
+    "comment" varchar(255) NOT NULL, -- describe
+    "flag" smallint NOT NULL DEFAULT 0, -- Whether the rules are valid, 0 is valid, 1 is invalid, it is deleted
+    "ctime" timestamp NOT NULL DEFAULT LOCALTIMESTAMP(0), -- Create time
+    "mtime" timestamp NOT NULL DEFAULT LOCALTIMESTAMP(0), -- Last updated time
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX "user_uk" ON "user" ("name", "owner");
+CREATE INDEX "user_owner" ON "user" ("owner");
+CREATE INDEX "user_mtime" ON "user" ("mtime");
+CREATE TRIGGER "user_mtime_on_update" BEFORE UPDATE ON "user" FOR EACH ROW EXECUTE PROCEDURE polaris_on_update_mtime();
+
+--
+-- Table structure `user_group`
+--
+CREATE TABLE "user_group"
+(
+    "id" varchar(128) NOT NULL, -- User group ID
+    "name" varchar(100) NOT NULL, -- User group name
+    "owner" varchar(128) NOT NULL, -- The main account ID of the user group
+    "token" varchar(255) NOT NULL, -- TOKEN information of this user group
+    "comment" varchar(255) NOT NULL, -- Description
+    "token_enable" smallint NOT NULL DEFAULT 1,
+    "flag" smallint NOT NULL DEFAULT 0, -- Whether the rules are valid, 0 is valid, 1 is invalid, it is deleted
+    "ctime" timestamp NOT NULL DEFAULT LOCALTIMESTAMP(0), -- Create time
+    "mtime" timestamp NOT NULL DEFAULT LOCALTIMESTAMP(0), -- Last updated time
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX "user_group_uk" ON "user_group" ("name", "owner");
+CREATE INDEX "user_group_owner" ON "user_group" ("owner");
+CREATE INDEX "user_group_mtime" ON "user_group" ("mtime");
+CREATE TRIGGER "user_group_mtime_on_update" BEFORE UPDATE ON "user_group" FOR EACH ROW EXECUTE PROCEDURE polaris_on_update_mtime();
+
+--
+-- Table structure `user_group_relation`
+--
+CREATE TABLE "user_group_relation"
+(
+    "user_id" varchar(128) NOT NULL, -- User ID
+    "group_id" varchar(128) NOT NULL, -- User group ID
+    "ctime" timestamp NOT NULL DEFAULT LOCALTIMESTAMP(0), -- Create time
+    "mtime" timestamp NOT NULL DEFAULT LOCALTIMESTAMP(0), -- Last updated time
+    PRIMARY KEY ("user_id", "group_id")
+);
+CREATE INDEX "user_group_relation_mtime" ON "user_group_relation" ("mtime");
+CREATE TRIGGER "user_group_relation_mtime_on_update" BEFORE UPDATE ON "user_group_relation" FOR EACH ROW EXECUTE PROCEDURE polaris_on_update_mtime();
+
+--
+-- Table structure `auth_strategy`
+--
+CREATE TABLE "auth_strategy"
+(
+    "id" varchar(128) NOT NULL, -- Strategy ID
+    "name" varchar(100) NOT NULL, -- Policy name
+    "action" varchar(32) NOT NULL, -- Read and write permission for this policy, only_read = 0, read_write = 1
+    "owner" varchar(128) NOT NULL, -- The account ID to which this policy is
+    "comment" varchar(255) NOT NULL, -- describe
+    "default" smallint NOT NULL DEFAULT 0,
+    "revision" varchar(128) NOT NULL, -- Authentication rule version
+    "flag" smallint NOT NULL DEFAULT 0, -- Whether the rules are valid, 0 is valid, 1 is invalid, it is deleted
+    "ctime" timestamp NOT NULL DEFAULT LOCALTIMESTAMP(0), -- Create time
+    "mtime" timestamp NOT NULL DEFAULT LOCALTIMESTAMP(0), -- Last updated time
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX "auth_strategy_uk" ON "auth_strategy" ("name", "owner");
+CREATE INDEX "auth_strategy_owner" ON "auth_strategy" ("owner");
+CREATE INDEX "auth_strategy_mtime" ON "auth_strategy" ("mtime");
+CREATE TRIGGER "auth_strategy_mtime_on_update" BEFORE UPDATE ON "auth_strategy" FOR EACH ROW EXECUTE PROCEDURE polaris_on_update_mtime();
+
+--
+-- Table structure `auth_principal`
+--
+CREATE TABLE "auth_principal"
+(
+    "strategy_id" varchar(128) NOT NULL, -- Strategy ID
+    "principal_id" varchar(128) NOT NULL, -- Principal ID
+    "principal_role" integer NOT NULL, -- PRINCIPAL type, 1 is User, 2 is Group
+    PRIMARY KEY ("strategy_id", "principal_id", "principal_role")
+);
+
+--
+-- Table structure `auth_strategy_resource`
+--
+CREATE TABLE "auth_strategy_resource"
+(
+    "strategy_id" varchar(128) NOT NULL, -- Strategy ID
+    "res_type" integer NOT NULL, -- Resource Type, Namespaces = 0, Service = 1, configgroups = 2
+    "res_id" varchar(128) NOT NULL, -- Resource ID
+    "ctime" timestamp NOT NULL DEFAULT LOCALTIMESTAMP(0), -- Create time
+    "mtime" timestamp NOT NULL DEFAULT LOCALTIMESTAMP(0), -- Last updated time
+    PRIMARY KEY ("strategy_id", "res_type", "res_id")
+);
+CREATE INDEX "auth_strategy_resource_mtime" ON "auth_strategy_resource" ("mtime");
+CREATE TRIGGER "auth_strategy_resource_mtime_on_update" BEFORE UPDATE ON "auth_strategy_resource" FOR EACH ROW EXECUTE PROCEDURE polaris_on_update_mtime();
+
+-- Create a default master account, password is Polarismesh @ 2021
+INSERT INTO "user" ("id", "name", "password", "source", "token", "token_enable", "user_type", "comment", "owner")
+VALUES ('65e4789a6d5b49669adf1e9e8387549c', 'polaris',
+        '$2a$10$3izWuZtE5SBdAtSZci.gs.iZ2pAn9I8hEqYrC6gwJp1dyjqQnrrum', 'Polaris',
+        'nu/0WRA4EqSR1FagrjRj0fZwPXuGlMpX+zCuWu4uMqy8xr1vRjisSbA25aAC3mtU8MeeRsKhQiDAynUR09I=', 1, 20,
+        'default polaris admin account', '');
+
+-- Permissions policy inserted into Polaris-Admin
+INSERT INTO "auth_strategy" ("id", "name", "action", "owner", "comment", "default", "revision", "flag",
+                             "ctime", "mtime")
+VALUES ('fbca9bfa04ae4ead86e1ecf5811e32a9', '(用户) polaris的默认策略', 'READ_WRITE',
+        '65e4789a6d5b49669adf1e9e8387549c', 'default admin', 1, 'fbca9bfa04ae4ead86e1ecf5811e32a9', 0,
+        sysdate(), sysdate());
+
+-- Sport rules inserted into Polaris-Admin to access
+INSERT INTO "auth_strategy_resource" ("strategy_id", "res_type", "res_id", "ctime", "mtime")
+VALUES ('fbca9bfa04ae4ead86e1ecf5811e32a9', 0, '*', sysdate(), sysdate()),
+       ('fbca9bfa04ae4ead86e1ecf5811e32a9', 1, '*', sysdate(), sysdate()),
+       ('fbca9bfa04ae4ead86e1ecf5811e32a9', 2, '*', sysdate(), sysdate());
+
+-- Insert permission policies and association relationships for Polaris-Admin accounts
+INSERT INTO "auth_principal" ("strategy_id", "principal_id", "principal_role")
+VALUES ('fbca9bfa04ae4ead86e1ecf5811e32a9', '65e4789a6d5b49669adf1e9e8387549c', 1);
@@ -0,0 +1,53 @@
+/*
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+--
+-- PostgreSQL delta script for upgrading polaris_server from v1.7.0 to v1.8.0
+--
+
+--
+-- Table structure `client`
+--
+CREATE TABLE "client"
+(
+    "id" varchar(128) NOT NULL, -- client id
+    "host" varchar(100) NOT NULL, -- client host IP
+    "type" varchar(100) NOT NULL, -- client type: polaris-java/polaris-go
+    "version" varchar(32) NOT NULL, -- client SDK version
+    "region" varchar(128) DEFAULT NULL, -- region info for client
+    "zone" varchar(128) DEFAULT NULL, -- zone info for client
+    "campus" varchar(128) DEFAULT NULL, -- campus info for client
+    "flag" smallint NOT NULL DEFAULT 0, -- 0 is valid, 1 is invalid(deleted)
+    "ctime" timestamp NOT NULL DEFAULT LOCALTIMESTAMP(0), -- create time
+    "mtime" timestamp NOT NULL DEFAULT LOCALTIMESTAMP(0), -- last updated time
+    PRIMARY KEY ("id")
+);
+CREATE INDEX "client_mtime" ON "client" ("mtime");
+CREATE TRIGGER "client_mtime_on_update" BEFORE UPDATE ON "client" FOR EACH ROW EXECUTE PROCEDURE polaris_on_update_mtime();
+
+--
+-- Table structure `client_stat`
+--
+CREATE TABLE "client_stat"
+(
+    "client_id" varchar(128) NOT NULL, -- client id
+    "target" varchar(100) NOT NULL, -- target stat platform
+    "port" integer NOT NULL, -- client port to get stat information
+    "protocol" varchar(100) NOT NULL, -- stat info transport protocol
+    "path" varchar(128) NOT NULL, -- stat metric path
+    PRIMARY KEY ("client_id", "target", "port")
+);
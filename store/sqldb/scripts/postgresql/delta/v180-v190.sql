@@ -0,0 +1,163 @@
+/*
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+--
+-- PostgreSQL delta script for upgrading polaris_server from v1.8.0 to v1.9.0
+--
+
+--
+-- Table structure `service_change_log`
+--
+CREATE TABLE "service_change_log"
+(
+    "seq" bigserial NOT NULL, -- change sequence, increase monotonically
+    "id" varchar(128) NOT NULL, -- changed service id
+    "ctime" timestamp NOT NULL DEFAULT LOCALTIMESTAMP(0), -- create time
+    PRIMARY KEY ("seq")
+);
+CREATE INDEX "service_change_log_ctime" ON "service_change_log" ("ctime");
+
+--
+-- Table structure `instance_change_log`
+--
+CREATE TABLE "instance_change_log"
+(
+    "seq" bigserial NOT NULL, -- change sequence, increase monotonically
+    "id" varchar(128) NOT NULL, -- changed instance id
+    "ctime" timestamp NOT NULL DEFAULT LOCALTIMESTAMP(0), -- create time
+    PRIMARY KEY ("seq")
+);
+CREATE INDEX "instance_change_log_ctime" ON "instance_change_log" ("ctime");
+
+-- --------------------------------------------------------
+--
+-- Table structure `config_file_gray_release`
+--
+CREATE TABLE "config_file_gray_release"
+(
+    "id" bigserial, -- 主键
+    "name" varchar(128) DEFAULT NULL, -- 发布标题
+    "namespace" varchar(64) NOT NULL, -- 所属的namespace
+    "group" varchar(128) NOT NULL, -- 所属的文件组
+    "file_name" varchar(128) NOT NULL, -- 配置文件名
+    "content" text NOT NULL, -- 文件内容
+    "comment" varchar(512) DEFAULT NULL, -- 备注信息
+    "md5" varchar(128) NOT NULL, -- content的md5值
+    "version" integer NOT NULL, -- 版本号，与正式发布共用版本序列
+    "gray_rule" text NOT NULL, -- 灰度规则，json格式的客户端IP列表和标签
+    "flag" smallint NOT NULL DEFAULT 0, -- 是否被删除
+    "create_time" timestamp NOT NULL DEFAULT LOCALTIMESTAMP(0), -- 创建时间
+    "create_by" varchar(32) DEFAULT NULL, -- 创建人
+    "modify_time" timestamp NOT NULL DEFAULT LOCALTIMESTAMP(0), -- 最后更新时间
+    "modify_by" varchar(32) DEFAULT NULL, -- 最后更新人
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX "config_file_gray_release_uk_file" ON "config_file_gray_release" ("namespace", "group", "file_name");
+CREATE INDEX "config_file_gray_release_idx_modify_time" ON "config_file_gray_release" ("modify_time");
+CREATE TRIGGER "config_file_gray_release_modify_time_on_update" BEFORE UPDATE ON "config_file_gray_release" FOR EACH ROW EXECUTE PROCEDURE polaris_on_update_modify_time();
+
+-- --------------------------------------------------------
+--
+-- Alter table `config_file_group`
+--
+ALTER TABLE "config_file_group" ADD COLUMN "json_schema" text DEFAULT NULL; -- 配置文件内容的JSON Schema约束
+
+-- --------------------------------------------------------
+--
+-- Alter table `config_file`
+--
+ALTER TABLE "config_file" ADD COLUMN "encrypted" smallint NOT NULL DEFAULT 0; -- 文件内容是否加密
+
+-- --------------------------------------------------------
+--
+-- Alter table `config_file`
+--
+ALTER TABLE "config_file" ADD COLUMN "base_file" varchar(512) DEFAULT NULL; -- 继承的基础配置文件，格式为namespace/group/name
+ALTER TABLE "config_file" ADD COLUMN "variables_file" varchar(512) DEFAULT NULL; -- 模板变量文件，格式为namespace/group/name
+
+-- --------------------------------------------------------
+--
+-- Alter table `config_file_release_history`
+--
+ALTER TABLE "config_file_release_history" ADD COLUMN "template_content" text DEFAULT NULL; -- 模板渲染前的文件内容
+
+-- --------------------------------------------------------
+--
+-- Table structure `config_file_listener`
+--
+CREATE TABLE "config_file_listener"
+(
+    "id" bigserial, -- 主键
+    "namespace" varchar(64) NOT NULL, -- 所属的namespace
+    "group" varchar(128) NOT NULL, -- 所属的文件组
+    "file_name" varchar(128) NOT NULL, -- 配置文件名
+    "client_id" varchar(128) NOT NULL, -- 客户端ID
+    "client_ip" varchar(64) NOT NULL DEFAULT '', -- 客户端IP
+    "version" bigint NOT NULL DEFAULT 0, -- 客户端持有的配置版本号
+    "last_poll_time" timestamp NOT NULL DEFAULT LOCALTIMESTAMP(0), -- 客户端最后一次订阅的时间
+    "server" varchar(64) NOT NULL DEFAULT '', -- 客户端连接的server节点
+    "modify_time" timestamp NOT NULL DEFAULT LOCALTIMESTAMP(0), -- 最后上报时间
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX "config_file_listener_uk_listener" ON "config_file_listener" ("namespace", "group", "file_name", "client_id");
+CREATE INDEX "config_file_listener_idx_modify_time" ON "config_file_listener" ("modify_time");
+CREATE TRIGGER "config_file_listener_modify_time_on_update" BEFORE UPDATE ON "config_file_listener" FOR EACH ROW EXECUTE PROCEDURE polaris_on_update_modify_time();
+
+-- --------------------------------------------------------
+--
+-- Table structure `cluster_event`
+--
+CREATE TABLE "cluster_event"
+(
+    "seq" bigserial NOT NULL, -- event sequence, increase monotonically
+    "topic" varchar(64) NOT NULL, -- event topic
+    "payload" text NOT NULL, -- event payload
+    "ctime" timestamp NOT NULL DEFAULT LOCALTIMESTAMP(0), -- create time
+    PRIMARY KEY ("seq")
+);
+CREATE INDEX "cluster_event_ctime" ON "cluster_event" ("ctime");
+
+-- --------------------------------------------------------
+--
+-- Table structure `config_file_publish_request`
+--
+CREATE TABLE "config_file_publish_request"
+(
+    "id" varchar(128) NOT NULL, -- 主键
+    "namespace" varchar(64) NOT NULL, -- 所属的namespace
+    "group" varchar(128) NOT NULL, -- 所属的文件组
+    "file_name" varchar(128) NOT NULL, -- 配置文件名
+    "comment" varchar(512) NOT NULL DEFAULT '', -- 发布说明
+    "status" varchar(32) NOT NULL, -- 申请状态
+    "approve_users" text NOT NULL, -- 审批用户ID，逗号分隔
+    "approve_groups" text NOT NULL, -- 审批用户组ID，逗号分隔
+    "plan_time" bigint NOT NULL DEFAULT 0, -- 计划发布时间，秒级时间戳，0表示审批通过后手动发布
+    "approve_by" varchar(128) NOT NULL DEFAULT '', -- 审批人
+    "approve_comment" varchar(512) NOT NULL DEFAULT '', -- 审批意见
+    "approve_time" bigint NOT NULL DEFAULT 0, -- 审批时间，秒级时间戳
+    "approve_md5" varchar(128) NOT NULL DEFAULT '', -- 审批时草稿内容的md5，发布时校验草稿未被修改
+    "publish_time" bigint NOT NULL DEFAULT 0, -- 发布时间，秒级时间戳
+    "fail_reason" varchar(1024) NOT NULL DEFAULT '', -- 发布失败原因
+    "create_time" timestamp NOT NULL DEFAULT LOCALTIMESTAMP(0), -- 创建时间
+    "create_by" varchar(32) NOT NULL DEFAULT '', -- 创建人
+    "modify_time" timestamp NOT NULL DEFAULT LOCALTIMESTAMP(0), -- 最后更新时间
+    "modify_by" varchar(32) NOT NULL DEFAULT '', -- 最后更新人
+    PRIMARY KEY ("id")
+);
+CREATE INDEX "config_file_publish_request_idx_file" ON "config_file_publish_request" ("namespace", "group", "file_name");
+CREATE INDEX "config_file_publish_request_idx_status_plan_time" ON "config_file_publish_request" ("status", "plan_time");
+CREATE TRIGGER "config_file_publish_request_modify_time_on_update" BEFORE UPDATE ON "config_file_publish_request" FOR EACH ROW EXECUTE PROCEDURE polaris_on_update_modify_time();
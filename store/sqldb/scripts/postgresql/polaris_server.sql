@@ -0,0 +1,1047 @@
+/*
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+--
+-- PostgreSQL schema for the postgresqlStore plugin.
+-- Usage: createdb polaris_server && psql -d polaris_server -f polaris_server.sql
+--
+SET client_encoding = 'UTF8';
+SET TIME ZONE 'UTC';
+
+-- --------------------------------------------------------
+--
+-- MySQL compatible functions used by store/sqldb
+--
+CREATE OR REPLACE FUNCTION sysdate() RETURNS timestamp AS
+$$
+SELECT LOCALTIMESTAMP(0)
+$$ LANGUAGE sql VOLATILE;
+
+CREATE OR REPLACE FUNCTION unix_timestamp(ts timestamp) RETURNS bigint AS
+$$
+SELECT CAST(EXTRACT(EPOCH FROM (ts AT TIME ZONE current_setting('TimeZone'))) AS bigint)
+$$ LANGUAGE sql STABLE;
+
+CREATE OR REPLACE FUNCTION from_unixtime(sec bigint) RETURNS timestamp AS
+$$
+SELECT CAST(to_timestamp(sec) AS timestamp)
+$$ LANGUAGE sql STABLE;
+
+--
+-- Emulate MySQL "ON UPDATE CURRENT_TIMESTAMP"
+--
+CREATE OR REPLACE FUNCTION polaris_on_update_mtime() RETURNS trigger AS
+$$
+BEGIN
+    IF NEW.mtime = OLD.mtime THEN
+        NEW.mtime := LOCALTIMESTAMP(0);
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION polaris_on_update_modify_time() RETURNS trigger AS
+$$
+BEGIN
+    IF NEW.modify_time = OLD.modify_time THEN
+        NEW.modify_time := LOCALTIMESTAMP(0);
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+-- --------------------------------------------------------
+--
+-- Table structure `business`
+--
+CREATE TABLE "business"
+(
+    "id" varchar(32) NOT NULL, -- Unique ID
+    "name" varchar(64) NOT NULL, -- business name
+    "token" varchar(64) NOT NULL, -- Token ID of the business
+    "owner" varchar(1024) NOT NULL, -- The business is responsible for Owner
+    "flag" smallint NOT NULL DEFAULT 0, -- Logic delete flag, 0 means visible, 1 means that it has been logically deleted
+    "ctime" timestamp NOT NULL DEFAULT LOCALTIMESTAMP(0), -- Create time
+    "mtime" timestamp NOT NULL DEFAULT LOCALTIMESTAMP(0), -- Last updated time
+    PRIMARY KEY ("id")
+);
+CREATE TRIGGER "business_mtime_on_update" BEFORE UPDATE ON "business" FOR EACH ROW EXECUTE PROCEDURE polaris_on_update_mtime();
+
+-- --------------------------------------------------------
+--
+-- Table structure `instance`
+--
+CREATE TABLE "instance"
+(
+    "id" varchar(128) NOT NULL, -- Unique ID
+    "service_id" varchar(32) NOT NULL, -- Service ID
+    "vpc_id" varchar(64) DEFAULT NULL, -- VPC ID
+    "host" varchar(128) NOT NULL, -- instance Host Information
+    "port" integer NOT NULL, -- instance port information
+    "protocol" varchar(32) DEFAULT NULL, -- Listening protocols for corresponding ports, such as TPC, UDP, GRPC, DUBBO, etc.
+    "version" varchar(32) DEFAULT NULL, -- The version of the instance can be used for version routing
+    "health_status" smallint NOT NULL DEFAULT 1, -- The health status of the instance, 1 is health, 0 is unhealthy
+    "isolate" smallint NOT NULL DEFAULT 0, -- Example isolation status flag, 0 is not isolated, 1 is isolated
+    "weight" smallint NOT NULL DEFAULT 100, -- The weight of the instance is mainly used for LoadBalance, default is 100
+    "enable_health_check" smallint NOT NULL DEFAULT 0, -- Whether to open a heartbeat on an instance, check the logic, 0 is not open, 1 is open
+    "logic_set" varchar(128) DEFAULT NULL, -- Example logic packet information
+    "cmdb_region" varchar(128) DEFAULT NULL, -- The region information of the instance is mainly used to close the route
+    "cmdb_zone" varchar(128) DEFAULT NULL, -- The ZONE information of the instance is mainly used to close the route.
+    "cmdb_idc" varchar(128) DEFAULT NULL, -- The IDC information of the instance is mainly used to close the route
+    "priority" smallint NOT NULL DEFAULT 0, -- Example priority, currently useless
+    "revision" varchar(32) NOT NULL, -- Instance version information
+    "flag" smallint NOT NULL DEFAULT 0, -- Logic delete flag, 0 means visible, 1 means that it has been logically deleted
+    "ctime" timestamp NOT NULL DEFAULT LOCALTIMESTAMP(0), -- Create time
+    "mtime" timestamp NOT NULL DEFAULT LOCALTIMESTAMP(0), -- Last updated time
+    PRIMARY KEY ("id")
+);
+CREATE INDEX "instance_service_id" ON "instance" ("service_id");
+CREATE INDEX "instance_mtime" ON "instance" ("mtime");
+CREATE INDEX "instance_host" ON "instance" ("host");
+CREATE TRIGGER "instance_mtime_on_update" BEFORE UPDATE ON "instance" FOR EACH ROW EXECUTE PROCEDURE polaris_on_update_mtime();
+
+-- --------------------------------------------------------
+--
+-- Table structure `health_check`
+--
+CREATE TABLE "health_check"
+(
+    "id" varchar(128) NOT NULL, -- Instance ID
+    "type" smallint NOT NULL DEFAULT 0, -- Instance health check type
+    "ttl" integer NOT NULL, -- TTL time jumping
+    PRIMARY KEY ("id"),
+    CONSTRAINT "health_check_ibfk_1" FOREIGN KEY ("id") REFERENCES "instance" ("id") ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+-- --------------------------------------------------------
+--
+-- Table structure `instance_metadata`
+--
+CREATE TABLE "instance_metadata"
+(
+    "id" varchar(128) NOT NULL, -- Instance ID
+    "mkey" varchar(128) NOT NULL, -- instance label of Key
+    "mvalue" varchar(4096) NOT NULL, -- instance label Value
+    "ctime" timestamp NOT NULL DEFAULT LOCALTIMESTAMP(0), -- Create time
+    "mtime" timestamp NOT NULL DEFAULT LOCALTIMESTAMP(0), -- Last updated time
+    PRIMARY KEY ("id", "mkey"),
+    CONSTRAINT "instance_metadata_ibfk_1" FOREIGN KEY ("id") REFERENCES "instance" ("id") ON DELETE CASCADE ON UPDATE CASCADE
+);
+CREATE INDEX "instance_metadata_mkey" ON "instance_metadata" ("mkey");
+CREATE TRIGGER "instance_metadata_mtime_on_update" BEFORE UPDATE ON "instance_metadata" FOR EACH ROW EXECUTE PROCEDURE polaris_on_update_mtime();
+
+-- --------------------------------------------------------
+--
+-- Table structure `namespace`
+--
+CREATE TABLE "namespace"
+(
+    "name" varchar(64) NOT NULL, -- Namespace name, unique
+    "comment" varchar(1024) DEFAULT NULL, -- Description of namespace
+    "token" varchar(64) NOT NULL, -- TOKEN named space for write operation check
+    "owner" varchar(1024) NOT NULL, -- Responsible for named space Owner
+    "flag" smallint NOT NULL DEFAULT 0, -- Logic delete flag, 0 means visible, 1 means that it has been logically deleted
+    "ctime" timestamp NOT NULL DEFAULT LOCALTIMESTAMP(0), -- Create time
+    "mtime" timestamp NOT NULL DEFAULT LOCALTIMESTAMP(0), -- Last updated time
+    PRIMARY KEY ("name")
+);
+CREATE TRIGGER "namespace_mtime_on_update" BEFORE UPDATE ON "namespace" FOR EACH ROW EXECUTE PROCEDURE polaris_on_update_mtime();
+
+-- --------------------------------------------------------
+--
+-- Table structure `routing_config`
+--
+CREATE TABLE "routing_config"
+(
+    "id" varchar(32) NOT NULL, -- Routing configuration ID
+    "in_bounds" text, -- Service is routing rules
+    "out_bounds" text, -- Service main routing rules
+    "revision" varchar(40) NOT NULL, -- Routing rule version
+    "flag" smallint NOT NULL DEFAULT 0, -- Logic delete flag, 0 means visible, 1 means that it has been logically deleted
+    "ctime" timestamp NOT NULL DEFAULT LOCALTIMESTAMP(0), -- Create time
+    "mtime" timestamp NOT NULL DEFAULT LOCALTIMESTAMP(0), -- Last updated time
+    PRIMARY KEY ("id")
+);
+CREATE INDEX "routing_config_mtime" ON "routing_config" ("mtime");
+CREATE TRIGGER "routing_config_mtime_on_update" BEFORE UPDATE ON "routing_config" FOR EACH ROW EXECUTE PROCEDURE polaris_on_update_mtime();
+
+-- --------------------------------------------------------
+--
+-- Table structure `ratelimit_config`
+--
+CREATE TABLE "ratelimit_config"
+(
+    "id" varchar(32) NOT NULL, -- ratelimit rule ID
+    "service_id" varchar(32) NOT NULL, -- Service ID
+    "cluster_id" varchar(32) NOT NULL, -- Cluster ID, no use
+    "labels" text NOT NULL, -- Conductive flow for a specific label
+    "priority" smallint NOT NULL DEFAULT 0, -- ratelimit rule priority
+    "rule" text NOT NULL, -- Current limiting rules
+    "revision" varchar(32) NOT NULL, -- Limiting version
+    "flag" smallint NOT NULL DEFAULT 0, -- Logic delete flag, 0 means visible, 1 means that it has been logically deleted
+    "ctime" timestamp NOT NULL DEFAULT LOCALTIMESTAMP(0), -- Create time
+    "mtime" timestamp NOT NULL DEFAULT LOCALTIMESTAMP(0), -- Last updated time
+    PRIMARY KEY ("id")
+);
+CREATE INDEX "ratelimit_config_mtime" ON "ratelimit_config" ("mtime");
+CREATE INDEX "ratelimit_config_service_id" ON "ratelimit_config" ("service_id");
+CREATE TRIGGER "ratelimit_config_mtime_on_update" BEFORE UPDATE ON "ratelimit_config" FOR EACH ROW EXECUTE PROCEDURE polaris_on_update_mtime();
+
+-- --------------------------------------------------------
+--
+-- Table structure `ratelimit_revision`
+--
+CREATE TABLE "ratelimit_revision"
+(
+    "service_id" varchar(32) NOT NULL, -- Service ID
+    "last_revision" varchar(40) NOT NULL, -- The latest limited limiting rule version of the corresponding service
+    "mtime" timestamp NOT NULL DEFAULT LOCALTIMESTAMP(0), -- Last updated time
+    PRIMARY KEY ("service_id")
+);
+CREATE INDEX "ratelimit_revision_service_id" ON "ratelimit_revision" ("service_id");
+CREATE INDEX "ratelimit_revision_mtime" ON "ratelimit_revision" ("mtime");
+CREATE TRIGGER "ratelimit_revision_mtime_on_update" BEFORE UPDATE ON "ratelimit_revision" FOR EACH ROW EXECUTE PROCEDURE polaris_on_update_mtime();
+
+-- --------------------------------------------------------
+--
+-- Table structure `service`
+--
+CREATE TABLE "service"
+(
+    "id" varchar(32) NOT NULL, -- Service ID
+    "name" varchar(128) NOT NULL, -- Service name, only under the namespace
+    "namespace" varchar(64) NOT NULL, -- Namespace belongs to the service
+    "ports" varchar(8192) DEFAULT NULL, -- Service will have a list of all port information of the external exposure (single process exposing multiple protocols)
+    "business" varchar(64) DEFAULT NULL, -- Service business information
+    "department" varchar(1024) DEFAULT NULL, -- Service department information
+    "cmdb_mod1" varchar(1024) DEFAULT NULL,
+    "cmdb_mod2" varchar(1024) DEFAULT NULL,
+    "cmdb_mod3" varchar(1024) DEFAULT NULL,
+    "comment" varchar(1024) DEFAULT NULL, -- Description information
+    "token" varchar(2048) NOT NULL, -- Service token, used to handle all the services involved in the service
+    "revision" varchar(32) NOT NULL, -- Service version information
+    "owner" varchar(1024) NOT NULL, -- Owner information belonging to the service
+    "flag" smallint NOT NULL DEFAULT 0, -- Logic delete flag, 0 means visible, 1 means that it has been logically deleted
+    "reference" varchar(32) DEFAULT NULL, -- Service alias, what is the actual service name that the service is actually pointed out?
+    "refer_filter" varchar(1024) DEFAULT NULL,
+    "platform_id" varchar(32) DEFAULT '', -- The platform ID to which the service belongs
+    "ctime" timestamp NOT NULL DEFAULT LOCALTIMESTAMP(0), -- Create time
+    "mtime" timestamp NOT NULL DEFAULT LOCALTIMESTAMP(0), -- Last updated time
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX "service_name" ON "service" ("name", "namespace");
+CREATE INDEX "service_namespace" ON "service" ("namespace");
+CREATE INDEX "service_mtime" ON "service" ("mtime");
+CREATE INDEX "service_reference" ON "service" ("reference");
+CREATE INDEX "service_platform_id" ON "service" ("platform_id");
+CREATE TRIGGER "service_mtime_on_update" BEFORE UPDATE ON "service" FOR EACH ROW EXECUTE PROCEDURE polaris_on_update_mtime();
+
+-- --------------------------------------------------------
+--
+-- Table structure `service_metadata`
+--
+CREATE TABLE "service_metadata"
+(
+    "id" varchar(32) NOT NULL, -- Service ID
+    "mkey" varchar(128) NOT NULL, -- Service label key
+    "mvalue" varchar(4096) NOT NULL, -- Service label Value
+    "ctime" timestamp NOT NULL DEFAULT LOCALTIMESTAMP(0), -- Create time
+    "mtime" timestamp NOT NULL DEFAULT LOCALTIMESTAMP(0), -- Last updated time
+    PRIMARY KEY ("id", "mkey"),
+    CONSTRAINT "service_metadata_ibfk_1" FOREIGN KEY ("id") REFERENCES "service" ("id") ON DELETE CASCADE ON UPDATE CASCADE
+);
+CREATE INDEX "service_metadata_mkey" ON "service_metadata" ("mkey");
+CREATE TRIGGER "service_metadata_mtime_on_update" BEFORE UPDATE ON "service_metadata" FOR EACH ROW EXECUTE PROCEDURE polaris_on_update_mtime();
+
+-- --------------------------------------------------------
+--
+-- Table structure `owner_service_map`
+--
+CREATE TABLE "owner_service_map"
+(
+    "id" varchar(32) NOT NULL,
+    "owner" varchar(32) NOT NULL, -- Service Owner
+    "service" varchar(128) NOT NULL, -- service name
+    "namespace" varchar(64) NOT NULL, -- namespace name
+    PRIMARY KEY ("id")
+);
+CREATE INDEX "owner_service_map_owner" ON "owner_service_map" ("owner");
+CREATE INDEX "owner_service_map_name" ON "owner_service_map" ("service", "namespace");
+
+-- --------------------------------------------------------
+--
+-- Table structure `circuitbreaker_rule`
+--
+CREATE TABLE "circuitbreaker_rule"
+(
+    "id" varchar(97) NOT NULL, -- Melting rule ID
+    "version" varchar(32) NOT NULL DEFAULT 'master', -- Melting rule version, default is MASTR
+    "name" varchar(128) NOT NULL, -- Melting rule name
+    "namespace" varchar(64) NOT NULL, -- Melting rule belongs to name space
+    "business" varchar(64) DEFAULT NULL, -- Business information of fuse regular
+    "department" varchar(1024) DEFAULT NULL, -- Department information to which the fuse regular belongs
+    "comment" varchar(1024) DEFAULT NULL, -- Description of the fuse rule
+    "inbounds" text NOT NULL, -- Service-tuned fuse rule
+    "outbounds" text NOT NULL, -- Service Motoring Fuse Rule
+    "token" varchar(32) NOT NULL, -- Token, which is fucking, mainly for writing operation check
+    "owner" varchar(1024) NOT NULL, -- Melting rule Owner information
+    "revision" varchar(32) NOT NULL, -- Melt rule version information
+    "flag" smallint NOT NULL DEFAULT 0, -- Logic delete flag, 0 means visible, 1 means that it has been logically deleted
+    "ctime" timestamp NOT NULL DEFAULT LOCALTIMESTAMP(0), -- Create time
+    "mtime" timestamp NOT NULL DEFAULT LOCALTIMESTAMP(0), -- Last updated time
+    PRIMARY KEY ("id", "version")
+);
+CREATE UNIQUE INDEX "circuitbreaker_rule_name" ON "circuitbreaker_rule" ("name", "namespace", "version");
+CREATE INDEX "circuitbreaker_rule_mtime" ON "circuitbreaker_rule" ("mtime");
+CREATE TRIGGER "circuitbreaker_rule_mtime_on_update" BEFORE UPDATE ON "circuitbreaker_rule" FOR EACH ROW EXECUTE PROCEDURE polaris_on_update_mtime();
+
+-- --------------------------------------------------------
+--
+-- Table structure `circuitbreaker_rule_relation`
+--
+CREATE TABLE "circuitbreaker_rule_relation"
+(
+    "service_id" varchar(32) NOT NULL, -- Service ID
+    "rule_id" varchar(97) NOT NULL, -- Melting rule ID
+    "rule_version" varchar(32) NOT NULL, -- Melting rule version
+    "flag" smallint NOT NULL DEFAULT 0, -- Logic delete flag, 0 means visible, 1 means that it has been logically deleted
+    "ctime" timestamp NOT NULL DEFAULT LOCALTIMESTAMP(0), -- Create time
+    "mtime" timestamp NOT NULL DEFAULT LOCALTIMESTAMP(0), -- Last updated time
+    PRIMARY KEY ("service_id"),
+    CONSTRAINT "circuitbreaker_rule_relation_ibfk_1" FOREIGN KEY ("service_id") REFERENCES "service" ("id") ON DELETE CASCADE ON UPDATE CASCADE
+);
+CREATE INDEX "circuitbreaker_rule_relation_mtime" ON "circuitbreaker_rule_relation" ("mtime");
+CREATE INDEX "circuitbreaker_rule_relation_rule_id" ON "circuitbreaker_rule_relation" ("rule_id");
+CREATE TRIGGER "circuitbreaker_rule_relation_mtime_on_update" BEFORE UPDATE ON "circuitbreaker_rule_relation" FOR EACH ROW EXECUTE PROCEDURE polaris_on_update_mtime();
+
+-- --------------------------------------------------------
+--
+-- Table structure `platform`
+--
+CREATE TABLE "platform"
+(
+    "id" varchar(32) NOT NULL, -- Platform ID
+    "name" varchar(128) NOT NULL, -- Platform name
+    "domain" varchar(1024) NOT NULL, -- Platform domain name
+    "qps" smallint NOT NULL, -- QPS restrictions set for a platform
+    "token" varchar(32) NOT NULL, -- Platform token
+    "owner" varchar(1024) NOT NULL, -- Platform is responsible for Owner
+    "department" varchar(1024) DEFAULT NULL, -- Platform department
+    "comment" varchar(1024) DEFAULT NULL, -- Platform description
+    "flag" smallint NOT NULL DEFAULT 0, -- Logic delete flag, 0 means visible, 1 means that it has been logically deleted
+    "ctime" timestamp NOT NULL DEFAULT LOCALTIMESTAMP(0), -- Create time
+    "mtime" timestamp NOT NULL DEFAULT LOCALTIMESTAMP(0), -- Last updated time
+    PRIMARY KEY ("id")
+);
+CREATE INDEX "platform_mtime" ON "platform" ("mtime");
+CREATE TRIGGER "platform_mtime_on_update" BEFORE UPDATE ON "platform" FOR EACH ROW EXECUTE PROCEDURE polaris_on_update_mtime();
+
+-- --------------------------------------------------------
+--
+-- Table structure `t_ip_config`
+--
+CREATE TABLE "t_ip_config"
+(
+    "fip" bigint NOT NULL, -- Machine IP
+    "fareaid" bigint NOT NULL, -- Area number
+    "fcityid" bigint NOT NULL, -- City number
+    "fidcid" bigint NOT NULL, -- IDC number
+    "fflag" smallint DEFAULT 0,
+    "fstamp" timestamp NOT NULL,
+    "fflow" bigint NOT NULL,
+    PRIMARY KEY ("fip")
+);
+CREATE INDEX "t_ip_config_idx_fflow" ON "t_ip_config" ("fflow");
+
+-- --------------------------------------------------------
+--
+-- Table structure `t_policy`
+--
+CREATE TABLE "t_policy"
+(
+    "fmodid" bigint NOT NULL,
+    "fdiv" bigint NOT NULL,
+    "fmod" bigint NOT NULL,
+    "fflag" smallint DEFAULT 0,
+    "fstamp" timestamp NOT NULL,
+    "fflow" bigint NOT NULL,
+    PRIMARY KEY ("fmodid")
+);
+
+-- --------------------------------------------------------
+--
+-- Table structure `t_route`
+--
+CREATE TABLE "t_route"
+(
+    "fip" bigint NOT NULL,
+    "fmodid" bigint NOT NULL,
+    "fcmdid" bigint NOT NULL,
+    "fsetid" varchar(32) NOT NULL,
+    "fflag" smallint DEFAULT 0,
+    "fstamp" timestamp NOT NULL,
+    "fflow" bigint NOT NULL,
+    PRIMARY KEY ("fip", "fmodid", "fcmdid")
+);
+CREATE INDEX "t_route_fflow" ON "t_route" ("fflow");
+CREATE INDEX "t_route_idx1" ON "t_route" ("fmodid", "fcmdid", "fsetid");
+
+-- --------------------------------------------------------
+--
+-- Table structure `t_section`
+--
+CREATE TABLE "t_section"
+(
+    "fmodid" bigint NOT NULL,
+    "ffrom" bigint NOT NULL,
+    "fto" bigint NOT NULL,
+    "fxid" bigint NOT NULL,
+    "fflag" smallint DEFAULT 0,
+    "fstamp" timestamp NOT NULL,
+    "fflow" bigint NOT NULL,
+    PRIMARY KEY ("fmodid", "ffrom", "fto")
+);
+
+-- --------------------------------------------------------
+--
+-- Table structure `start_lock`
+--
+CREATE TABLE "start_lock"
+(
+    "lock_id" integer NOT NULL, -- 锁序号
+    "lock_key" varchar(32) NOT NULL, -- Lock name
+    "server" varchar(32) NOT NULL, -- SERVER holding launch lock
+    "mtime" timestamp NOT NULL DEFAULT LOCALTIMESTAMP(0), -- Update time
+    PRIMARY KEY ("lock_id", "lock_key")
+);
+CREATE TRIGGER "start_lock_mtime_on_update" BEFORE UPDATE ON "start_lock" FOR EACH ROW EXECUTE PROCEDURE polaris_on_update_mtime();
+
+-- --------------------------------------------------------
+--
+-- Table structure `cl5_module`
+--
+CREATE TABLE "cl5_module"
+(
+    "module_id" integer NOT NULL, -- Module ID
+    "interface_id" integer NOT NULL, -- Interface ID
+    "range_num" integer NOT NULL,
+    "mtime" timestamp NOT NULL DEFAULT LOCALTIMESTAMP(0), -- Last updated time
+    PRIMARY KEY ("module_id")
+);
+CREATE TRIGGER "cl5_module_mtime_on_update" BEFORE UPDATE ON "cl5_module" FOR EACH ROW EXECUTE PROCEDURE polaris_on_update_mtime();
+
+-- --------------------------------------------------------
+--
+-- Table structure `mesh`
+--
+CREATE TABLE "mesh"
+(
+    "id" varchar(32) NOT NULL, -- mesh ID
+    "name" varchar(128) NOT NULL, -- mesh name
+    "department" varchar(1024) DEFAULT NULL, -- mesh department
+    "business" varchar(128) NOT NULL, -- mesh service
+    "managed" smallint NOT NULL, -- Whether to managed
+    "istio_version" varchar(64), -- ISTIO version
+    "data_cluster" varchar(1024), -- Data surface cluster
+    "revision" varchar(32) NOT NULL, -- Rule version number
+    "comment" varchar(1024) DEFAULT NULL, -- Rule description
+    "token" varchar(32) NOT NULL, -- Rule Authentication Token
+    "owner" varchar(1024) NOT NULL, -- Rule owner
+    "flag" smallint NOT NULL DEFAULT 0, -- Whether the rules are valid, 0 is valid, 1 is invalid, it is deleted
+    "ctime" timestamp NOT NULL DEFAULT LOCALTIMESTAMP(0), -- Create time
+    "mtime" timestamp NOT NULL DEFAULT LOCALTIMESTAMP(0), -- Last updated time
+    PRIMARY KEY ("id")
+);
+CREATE INDEX "mesh_name" ON "mesh" ("name");
+CREATE INDEX "mesh_mtime" ON "mesh" ("mtime");
+CREATE TRIGGER "mesh_mtime_on_update" BEFORE UPDATE ON "mesh" FOR EACH ROW EXECUTE PROCEDURE polaris_on_update_mtime();
+
+-- --------------------------------------------------------
+--
+-- Table structure `mesh_service`
+--
+CREATE TABLE "mesh_service"
+(
+    "id" varchar(32) NOT NULL, -- mesh rule ID
+    "mesh_id" varchar(32) NOT NULL, -- mesh name
+    "service_id" varchar(32) NOT NULL, -- Service ID
+    "namespace" varchar(64) NOT NULL, -- Service namespace
+    "service" varchar(128) NOT NULL, -- Service Name
+    "mesh_namespace" varchar(64) NOT NULL, -- Map to the mesh namespace
+    "mesh_service" varchar(128) NOT NULL, -- Mapping to the mesh service name
+    "location" varchar(16) NOT NULL, -- Which location is in the mesh
+    "export_to" varchar(1024) NOT NULL, -- What is the service you can be seen by the namespace
+    "revision" varchar(32) NOT NULL, -- Rule version number
+    "flag" smallint NOT NULL DEFAULT 0, -- Whether the rules are valid, 0 is valid, 1 is invalid, it is deleted
+    "ctime" timestamp NOT NULL DEFAULT LOCALTIMESTAMP(0), -- Create time
+    "mtime" timestamp NOT NULL DEFAULT LOCALTIMESTAMP(0), -- Last updated time
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX "mesh_service_relation" ON "mesh_service" ("mesh_id", "mesh_namespace", "mesh_service");
+CREATE INDEX "mesh_service_namespace" ON "mesh_service" ("namespace");
+CREATE INDEX "mesh_service_service" ON "mesh_service" ("service");
+CREATE INDEX "mesh_service_location" ON "mesh_service" ("location");
+CREATE INDEX "mesh_service_export_to" ON "mesh_service" ("export_to");
+CREATE INDEX "mesh_service_mtime" ON "mesh_service" ("mtime");
+CREATE INDEX "mesh_service_flag" ON "mesh_service" ("flag");
+CREATE TRIGGER "mesh_service_mtime_on_update" BEFORE UPDATE ON "mesh_service" FOR EACH ROW EXECUTE PROCEDURE polaris_on_update_mtime();
+
+-- --------------------------------------------------------
+--
+-- Table structure `mesh_service_revision`
+--
+CREATE TABLE "mesh_service_revision"
+(
+    "mesh_id" varchar(32) NOT NULL, -- mesh name
+    "revision" varchar(32) NOT NULL, -- Rule version number
+    "ctime" timestamp NOT NULL DEFAULT LOCALTIMESTAMP(0), -- Create time
+    "mtime" timestamp NOT NULL DEFAULT LOCALTIMESTAMP(0), -- Last updated time
+    PRIMARY KEY ("mesh_id")
+);
+CREATE INDEX "mesh_service_revision_mtime" ON "mesh_service_revision" ("mtime");
+CREATE TRIGGER "mesh_service_revision_mtime_on_update" BEFORE UPDATE ON "mesh_service_revision" FOR EACH ROW EXECUTE PROCEDURE polaris_on_update_mtime();
+
+-- --------------------------------------------------------
+--
+-- Table structure `mesh_resource`
+--
+CREATE TABLE "mesh_resource"
+(
+    "id" varchar(32) NOT NULL, -- mesh rule ID
+    "mesh_id" varchar(32) NOT NULL, -- mesh name
+    "name" varchar(64) NOT NULL, -- Rule name
+    "mesh_namespace" varchar(64) NOT NULL, -- mesh namespace where the rules are located
+    "type_url" varchar(96) NOT NULL, -- Rule type, such as VirtualService
+    "revision" varchar(32) NOT NULL, -- Rule version number
+    "body" text, -- Rule content, JSON format string
+    "flag" smallint NOT NULL DEFAULT 0, -- Whether the rules are valid, 0 is valid, 1 is invalid, it is deleted
+    "ctime" timestamp NOT NULL DEFAULT LOCALTIMESTAMP(0), -- Create time
+    "mtime" timestamp NOT NULL DEFAULT LOCALTIMESTAMP(0), -- Last updated time
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX "mesh_resource_name" ON "mesh_resource" ("mesh_id", "name", "mesh_namespace", "type_url");
+CREATE INDEX "mesh_resource_mtime" ON "mesh_resource" ("mtime");
+CREATE TRIGGER "mesh_resource_mtime_on_update" BEFORE UPDATE ON "mesh_resource" FOR EACH ROW EXECUTE PROCEDURE polaris_on_update_mtime();
+
+-- --------------------------------------------------------
+--
+-- Table structure `mesh_resource_revision`
+--
+CREATE TABLE "mesh_resource_revision"
+(
+    "mesh_id" varchar(32) NOT NULL, -- Rules, mesh ID
+    "type_url" varchar(96) NOT NULL, -- Rule type, such as VirtualService
+    "revision" varchar(32) NOT NULL, -- The version number of the rules collection, the overall version number of all rule collections below the same mesh
+    "ctime" timestamp NOT NULL DEFAULT LOCALTIMESTAMP(0), -- Create time
+    "mtime" timestamp NOT NULL DEFAULT LOCALTIMESTAMP(0), -- Last updated time
+    PRIMARY KEY ("mesh_id", "type_url")
+);
+CREATE INDEX "mesh_resource_revision_mtime" ON "mesh_resource_revision" ("mtime");
+CREATE TRIGGER "mesh_resource_revision_mtime_on_update" BEFORE UPDATE ON "mesh_resource_revision" FOR EACH ROW EXECUTE PROCEDURE polaris_on_update_mtime();
+
+-- --------------------------------------------------------
+--
+-- Table structure `ratelimit_flux_rule_config`
+--
+CREATE TABLE "ratelimit_flux_rule_config"
+(
+    "id" varchar(32) NOT NULL,
+    "revision" varchar(32) NOT NULL,
+    "callee_service_id" varchar(32) NOT NULL,
+    "callee_service_env" varchar(64) NOT NULL,
+    "callee_service_name" varchar(250) NOT NULL DEFAULT '',
+    "caller_service_business" varchar(250) NOT NULL DEFAULT '',
+    "name" varchar(128) NOT NULL DEFAULT '',
+    "description" varchar(500) NOT NULL DEFAULT '',
+    "type" smallint NOT NULL DEFAULT 0,
+    "set_key" varchar(250) NOT NULL DEFAULT '',
+    "set_alert_qps" varchar(10) NOT NULL DEFAULT '',
+    "set_warning_qps" varchar(10) NOT NULL DEFAULT '',
+    "set_remark" varchar(500) NOT NULL DEFAULT '',
+    "default_key" varchar(250) NOT NULL DEFAULT '',
+    "default_alert_qps" varchar(10) NOT NULL DEFAULT '',
+    "default_warning_qps" varchar(10) NOT NULL DEFAULT '',
+    "default_remark" varchar(500) NOT NULL DEFAULT '',
+    "creator" varchar(32) NOT NULL DEFAULT '',
+    "updater" varchar(32) NOT NULL DEFAULT '',
+    "status" smallint NOT NULL DEFAULT 0,
+    "flag" smallint NOT NULL DEFAULT 0,
+    "ctime" timestamp NOT NULL DEFAULT LOCALTIMESTAMP(0), -- Create time
+    "mtime" timestamp NOT NULL DEFAULT LOCALTIMESTAMP(0), -- Last updated time
+    "flux_server_id" varchar(32) NOT NULL DEFAULT '',
+    "monitor_server_id" varchar(32) NOT NULL DEFAULT '',
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX "ratelimit_flux_rule_config_unique_service" ON "ratelimit_flux_rule_config" ("callee_service_id", "caller_service_business", "set_key");
+CREATE INDEX "ratelimit_flux_rule_config_mtime" ON "ratelimit_flux_rule_config" ("mtime");
+CREATE INDEX "ratelimit_flux_rule_config_name" ON "ratelimit_flux_rule_config" ("name");
+CREATE INDEX "ratelimit_flux_rule_config_creator" ON "ratelimit_flux_rule_config" ("creator");
+CREATE INDEX "ratelimit_flux_rule_config_callee_service" ON "ratelimit_flux_rule_config" ("callee_service_env", "callee_service_name");
+CREATE TRIGGER "ratelimit_flux_rule_config_mtime_on_update" BEFORE UPDATE ON "ratelimit_flux_rule_config" FOR EACH ROW EXECUTE PROCEDURE polaris_on_update_mtime();
+
+-- --------------------------------------------------------
+--
+-- Table structure `ratelimit_flux_rule_revision`
+--
+CREATE TABLE "ratelimit_flux_rule_revision"
+(
+    "service_id" varchar(32) NOT NULL, -- Service ID
+    "last_revision" varchar(40) NOT NULL, -- Latest version of the FLUX rule
+    "mtime" timestamp NOT NULL DEFAULT LOCALTIMESTAMP(0), -- Last updated time
+    PRIMARY KEY ("service_id")
+);
+CREATE TRIGGER "ratelimit_flux_rule_revision_mtime_on_update" BEFORE UPDATE ON "ratelimit_flux_rule_revision" FOR EACH ROW EXECUTE PROCEDURE polaris_on_update_mtime();
+
+-- --------------------------------------------------------
+--
+-- Table structure `config_file`
+--
+CREATE TABLE "config_file"
+(
+    "id" bigserial, -- 主键
+    "namespace" varchar(64) NOT NULL, -- 所属的namespace
+    "group" varchar(128) NOT NULL DEFAULT '', -- 所属的文件组
+    "name" varchar(128) NOT NULL, -- 配置文件名
+    "content" text NOT NULL, -- 文件内容
+    "format" varchar(16) DEFAULT 'text', -- 文件格式，枚举值
+    "comment" varchar(512) DEFAULT NULL, -- 备注信息
+    "encrypted" smallint NOT NULL DEFAULT 0, -- 文件内容是否加密
+    "base_file" varchar(512) DEFAULT NULL, -- 继承的基础配置文件，格式为namespace/group/name
+    "variables_file" varchar(512) DEFAULT NULL, -- 模板变量文件，格式为namespace/group/name
+    "flag" smallint NOT NULL DEFAULT 0, -- 软删除标记位
+    "create_time" timestamp NOT NULL DEFAULT LOCALTIMESTAMP(0), -- 创建时间
+    "create_by" varchar(32) DEFAULT NULL, -- 创建人
+    "modify_time" timestamp NOT NULL DEFAULT LOCALTIMESTAMP(0), -- 最后更新时间
+    "modify_by" varchar(32) DEFAULT NULL, -- 最后更新人
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX "config_file_uk_file" ON "config_file" ("namespace", "group", "name");
+CREATE TRIGGER "config_file_modify_time_on_update" BEFORE UPDATE ON "config_file" FOR EACH ROW EXECUTE PROCEDURE polaris_on_update_modify_time();
+
+-- --------------------------------------------------------
+--
+-- Table structure `config_file_group`
+--
+CREATE TABLE "config_file_group"
+(
+    "id" bigserial, -- 主键
+    "name" varchar(128) NOT NULL, -- 配置文件分组名
+    "namespace" varchar(64) NOT NULL, -- 所属的namespace
+    "comment" varchar(512) DEFAULT NULL, -- 备注信息
+    "owner" varchar(1024) DEFAULT NULL, -- 负责人
+    "json_schema" text DEFAULT NULL, -- 配置文件内容的JSON Schema约束
+    "create_time" timestamp NOT NULL DEFAULT LOCALTIMESTAMP(0), -- 创建时间
+    "create_by" varchar(32) DEFAULT NULL, -- 创建人
+    "modify_time" timestamp NOT NULL DEFAULT LOCALTIMESTAMP(0), -- 最后更新时间
+    "modify_by" varchar(32) DEFAULT NULL, -- 最后更新人
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX "config_file_group_uk_name" ON "config_file_group" ("namespace", "name");
+CREATE TRIGGER "config_file_group_modify_time_on_update" BEFORE UPDATE ON "config_file_group" FOR EACH ROW EXECUTE PROCEDURE polaris_on_update_modify_time();
+
+-- --------------------------------------------------------
+--
+-- Table structure `config_file_release`
+--
+CREATE TABLE "config_file_release"
+(
+    "id" bigserial, -- 主键
+    "name" varchar(128) DEFAULT NULL, -- 发布标题
+    "namespace" varchar(64) NOT NULL, -- 所属的namespace
+    "group" varchar(128) NOT NULL, -- 所属的文件组
+    "file_name" varchar(128) NOT NULL, -- 配置文件名
+    "content" text NOT NULL, -- 文件内容
+    "comment" varchar(512) DEFAULT NULL, -- 备注信息
+    "md5" varchar(128) NOT NULL, -- content的md5值
+    "version" integer NOT NULL, -- 版本号，每次发布自增1
+    "flag" smallint NOT NULL DEFAULT 0, -- 是否被删除
+    "create_time" timestamp NOT NULL DEFAULT LOCALTIMESTAMP(0), -- 创建时间
+    "create_by" varchar(32) DEFAULT NULL, -- 创建人
+    "modify_time" timestamp NOT NULL DEFAULT LOCALTIMESTAMP(0), -- 最后更新时间
+    "modify_by" varchar(32) DEFAULT NULL, -- 最后更新人
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX "config_file_release_uk_file" ON "config_file_release" ("namespace", "group", "file_name");
+CREATE INDEX "config_file_release_idx_modify_time" ON "config_file_release" ("modify_time");
+CREATE TRIGGER "config_file_release_modify_time_on_update" BEFORE UPDATE ON "config_file_release" FOR EACH ROW EXECUTE PROCEDURE polaris_on_update_modify_time();
+
+-- --------------------------------------------------------
+--
+-- Table structure `config_file_gray_release`
+--
+CREATE TABLE "config_file_gray_release"
+(
+    "id" bigserial, -- 主键
+    "name" varchar(128) DEFAULT NULL, -- 发布标题
+    "namespace" varchar(64) NOT NULL, -- 所属的namespace
+    "group" varchar(128) NOT NULL, -- 所属的文件组
+    "file_name" varchar(128) NOT NULL, -- 配置文件名
+    "content" text NOT NULL, -- 文件内容
+    "comment" varchar(512) DEFAULT NULL, -- 备注信息
+    "md5" varchar(128) NOT NULL, -- content的md5值
+    "version" integer NOT NULL, -- 版本号，与正式发布共用版本序列
+    "gray_rule" text NOT NULL, -- 灰度规则，json格式的客户端IP列表和标签
+    "flag" smallint NOT NULL DEFAULT 0, -- 是否被删除
+    "create_time" timestamp NOT NULL DEFAULT LOCALTIMESTAMP(0), -- 创建时间
+    "create_by" varchar(32) DEFAULT NULL, -- 创建人
+    "modify_time" timestamp NOT NULL DEFAULT LOCALTIMESTAMP(0), -- 最后更新时间
+    "modify_by" varchar(32) DEFAULT NULL, -- 最后更新人
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX "config_file_gray_release_uk_file" ON "config_file_gray_release" ("namespace", "group", "file_name");
+CREATE INDEX "config_file_gray_release_idx_modify_time" ON "config_file_gray_release" ("modify_time");
+CREATE TRIGGER "config_file_gray_release_modify_time_on_update" BEFORE UPDATE ON "config_file_gray_release" FOR EACH ROW EXECUTE PROCEDURE polaris_on_update_modify_time();
+
+-- --------------------------------------------------------
+--
+-- Table structure `config_file_release_history`
+--
+CREATE TABLE "config_file_release_history"
+(
+    "id" bigserial, -- 主键
+    "name" varchar(64) DEFAULT '', -- 发布名称
+    "namespace" varchar(64) NOT NULL, -- 所属的namespace
+    "group" varchar(128) NOT NULL, -- 所属的文件组
+    "file_name" varchar(128) NOT NULL, -- 配置文件名
+    "content" text NOT NULL, -- 文件内容
+    "template_content" text DEFAULT NULL, -- 模板渲染前的文件内容
+    "format" varchar(16) DEFAULT 'text', -- 文件格式
+    "tags" varchar(2048) DEFAULT '', -- 文件标签
+    "comment" varchar(512) DEFAULT NULL, -- 备注信息
+    "md5" varchar(128) NOT NULL, -- content的md5值
+    "type" varchar(32) NOT NULL, -- 发布类型，例如全量发布、灰度发布
+    "status" varchar(16) NOT NULL DEFAULT 'success', -- 发布状态，success表示成功，fail 表示失败
+    "create_time" timestamp NOT NULL DEFAULT LOCALTIMESTAMP(0), -- 创建时间
+    "create_by" varchar(32) DEFAULT NULL, -- 创建人
+    "modify_time" timestamp NOT NULL DEFAULT LOCALTIMESTAMP(0), -- 最后更新时间
+    "modify_by" varchar(32) DEFAULT NULL, -- 最后更新人
+    PRIMARY KEY ("id")
+);
+CREATE INDEX "config_file_release_history_idx_file" ON "config_file_release_history" ("namespace", "group", "file_name");
+CREATE TRIGGER "config_file_release_history_modify_time_on_update" BEFORE UPDATE ON "config_file_release_history" FOR EACH ROW EXECUTE PROCEDURE polaris_on_update_modify_time();
+
+-- --------------------------------------------------------
+--
+-- Table structure `config_file_tag`
+--
+CREATE TABLE "config_file_tag"
+(
+    "id" bigserial, -- 主键
+    "key" varchar(128) NOT NULL, -- tag 的键
+    "value" varchar(128) NOT NULL, -- tag 的值
+    "namespace" varchar(64) NOT NULL, -- 所属的namespace
+    "group" varchar(128) NOT NULL DEFAULT '', -- 所属的文件组
+    "file_name" varchar(128) NOT NULL, -- 配置文件名
+    "create_time" timestamp NOT NULL DEFAULT LOCALTIMESTAMP(0), -- 创建时间
+    "create_by" varchar(32) DEFAULT NULL, -- 创建人
+    "modify_time" timestamp NOT NULL DEFAULT LOCALTIMESTAMP(0), -- 最后更新时间
+    "modify_by" varchar(32) DEFAULT NULL, -- 最后更新人
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX "config_file_tag_uk_tag" ON "config_file_tag" ("key", "value", "namespace", "group", "file_name");
+CREATE INDEX "config_file_tag_idx_file" ON "config_file_tag" ("namespace", "group", "file_name");
+CREATE TRIGGER "config_file_tag_modify_time_on_update" BEFORE UPDATE ON "config_file_tag" FOR EACH ROW EXECUTE PROCEDURE polaris_on_update_modify_time();
+
+-- --------------------------------------------------------
+--
+-- Table structure `config_file_listener`
+--
+CREATE TABLE "config_file_listener"
+(
+    "id" bigserial, -- 主键
+    "namespace" varchar(64) NOT NULL, -- 所属的namespace
+    "group" varchar(128) NOT NULL, -- 所属的文件组
+    "file_name" varchar(128) NOT NULL, -- 配置文件名
+    "client_id" varchar(128) NOT NULL, -- 客户端ID
+    "client_ip" varchar(64) NOT NULL DEFAULT '', -- 客户端IP
+    "version" bigint NOT NULL DEFAULT 0, -- 客户端持有的配置版本号
+    "last_poll_time" timestamp NOT NULL DEFAULT LOCALTIMESTAMP(0), -- 客户端最后一次订阅的时间
+    "server" varchar(64) NOT NULL DEFAULT '', -- 客户端连接的server节点
+    "modify_time" timestamp NOT NULL DEFAULT LOCALTIMESTAMP(0), -- 最后上报时间
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX "config_file_listener_uk_listener" ON "config_file_listener" ("namespace", "group", "file_name", "client_id");
+CREATE INDEX "config_file_listener_idx_modify_time" ON "config_file_listener" ("modify_time");
+CREATE TRIGGER "config_file_listener_modify_time_on_update" BEFORE UPDATE ON "config_file_listener" FOR EACH ROW EXECUTE PROCEDURE polaris_on_update_modify_time();
+
+-- --------------------------------------------------------
+--
+-- Table structure `config_file_publish_request`
+--
+CREATE TABLE "config_file_publish_request"
+(
+    "id" varchar(128) NOT NULL, -- 主键
+    "namespace" varchar(64) NOT NULL, -- 所属的namespace
+    "group" varchar(128) NOT NULL, -- 所属的文件组
+    "file_name" varchar(128) NOT NULL, -- 配置文件名
+    "comment" varchar(512) NOT NULL DEFAULT '', -- 发布说明
+    "status" varchar(32) NOT NULL, -- 申请状态
+    "approve_users" text NOT NULL, -- 审批用户ID，逗号分隔
+    "approve_groups" text NOT NULL, -- 审批用户组ID，逗号分隔
+    "plan_time" bigint NOT NULL DEFAULT 0, -- 计划发布时间，秒级时间戳，0表示审批通过后手动发布
+    "approve_by" varchar(128) NOT NULL DEFAULT '', -- 审批人
+    "approve_comment" varchar(512) NOT NULL DEFAULT '', -- 审批意见
+    "approve_time" bigint NOT NULL DEFAULT 0, -- 审批时间，秒级时间戳
+    "approve_md5" varchar(128) NOT NULL DEFAULT '', -- 审批时草稿内容的md5，发布时校验草稿未被修改
+    "publish_time" bigint NOT NULL DEFAULT 0, -- 发布时间，秒级时间戳
+    "fail_reason" varchar(1024) NOT NULL DEFAULT '', -- 发布失败原因
+    "create_time" timestamp NOT NULL DEFAULT LOCALTIMESTAMP(0), -- 创建时间
+    "create_by" varchar(32) NOT NULL DEFAULT '', -- 创建人
+    "modify_time" timestamp NOT NULL DEFAULT LOCALTIMESTAMP(0), -- 最后更新时间
+    "modify_by" varchar(32) NOT NULL DEFAULT '', -- 最后更新人
+    PRIMARY KEY ("id")
+);
+CREATE INDEX "config_file_publish_request_idx_file" ON "config_file_publish_request" ("namespace", "group", "file_name");
+CREATE INDEX "config_file_publish_request_idx_status_plan_time" ON "config_file_publish_request" ("status", "plan_time");
+CREATE TRIGGER "config_file_publish_request_modify_time_on_update" BEFORE UPDATE ON "config_file_publish_request" FOR EACH ROW EXECUTE PROCEDURE polaris_on_update_modify_time();
+
+-- --------------------------------------------------------
+--
+-- Table structure `user`
+--
+CREATE TABLE "user"
+(
+    "id" varchar(128) NOT NULL, -- User ID
+    "name" varchar(100) NOT NULL, -- user name
+    "password" varchar(100) NOT NULL, -- user password
+    "owner" varchar(128) NOT NULL, -- Main account ID
+    "source" varchar(32) NOT NULL, -- Account source
+    "mobile" varchar(12) NOT NULL DEFAULT '', -- Account mobile phone number
+    "email" varchar(64) NOT NULL DEFAULT '', -- Account mailbox
+    "token" varchar(255) NOT NULL, -- The token information owned by the account can be used for SDK access authentication
+    "token_enable" smallint NOT NULL DEFAULT 1,
+    "user_type" integer NOT NULL DEFAULT 20, -- Account type, 0 is the admin super account, 20 is the primary account, 50 for the child account
+    "comment" varchar(255) NOT NULL, -- describe
+    "flag" smallint NOT NULL DEFAULT 0, -- Whether the rules are valid, 0 is valid, 1 is invalid, it is deleted
+    "ctime" timestamp NOT NULL DEFAULT LOCALTIMESTAMP(0), -- Create time
+    "mtime" timestamp NOT NULL DEFAULT LOCALTIMESTAMP(0), -- Last updated time
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX "user_uk" ON "user" ("name", "owner");
+CREATE INDEX "user_owner" ON "user" ("owner");
+CREATE INDEX "user_mtime" ON "user" ("mtime");
+CREATE TRIGGER "user_mtime_on_update" BEFORE UPDATE ON "user" FOR EACH ROW EXECUTE PROCEDURE polaris_on_update_mtime();
+
+-- --------------------------------------------------------
+--
+-- Table structure `user_group`
+--
+CREATE TABLE "user_group"
+(
+    "id" varchar(128) NOT NULL, -- User group ID
+    "name" varchar(100) NOT NULL, -- User group name
+    "owner" varchar(128) NOT NULL, -- The main account ID of the user group
+    "token" varchar(255) NOT NULL, -- TOKEN information of this user group
+    "comment" varchar(255) NOT NULL, -- Description
+    "token_enable" smallint NOT NULL DEFAULT 1,
+    "flag" smallint NOT NULL DEFAULT 0, -- Whether the rules are valid, 0 is valid, 1 is invalid, it is deleted
+    "ctime" timestamp NOT NULL DEFAULT LOCALTIMESTAMP(0), -- Create time
+    "mtime" timestamp NOT NULL DEFAULT LOCALTIMESTAMP(0), -- Last updated time
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX "user_group_uk" ON "user_group" ("name", "owner");
+CREATE INDEX "user_group_owner" ON "user_group" ("owner");
+CREATE INDEX "user_group_mtime" ON "user_group" ("mtime");
+CREATE TRIGGER "user_group_mtime_on_update" BEFORE UPDATE ON "user_group" FOR EACH ROW EXECUTE PROCEDURE polaris_on_update_mtime();
+
+-- --------------------------------------------------------
+--
+-- Table structure `user_group_relation`
+--
+CREATE TABLE "user_group_relation"
+(
+    "user_id" varchar(128) NOT NULL, -- User ID
+    "group_id" varchar(128) NOT NULL, -- User group ID
+    "ctime" timestamp NOT NULL DEFAULT LOCALTIMESTAMP(0), -- Create time
+    "mtime" timestamp NOT NULL DEFAULT LOCALTIMESTAMP(0), -- Last updated time
+    PRIMARY KEY ("user_id", "group_id")
+);
+CREATE INDEX "user_group_relation_mtime" ON "user_group_relation" ("mtime");
+CREATE TRIGGER "user_group_relation_mtime_on_update" BEFORE UPDATE ON "user_group_relation" FOR EACH ROW EXECUTE PROCEDURE polaris_on_update_mtime();
+
+-- --------------------------------------------------------
+--
+-- Table structure `auth_strategy`
+--
+CREATE TABLE "auth_strategy"
+(
+    "id" varchar(128) NOT NULL, -- Strategy ID
+    "name" varchar(100) NOT NULL, -- Policy name
+    "action" varchar(32) NOT NULL, -- Read and write permission for this policy, only_read = 0, read_write = 1
+    "owner" varchar(128) NOT NULL, -- The account ID to which this policy is
+    "comment" varchar(255) NOT NULL, -- describe
+    "default" smallint NOT NULL DEFAULT 0,
+    "revision" varchar(128) NOT NULL, -- Authentication rule version
+    "flag" smallint NOT NULL DEFAULT 0, -- Whether the rules are valid, 0 is valid, 1 is invalid, it is deleted
+    "ctime" timestamp NOT NULL DEFAULT LOCALTIMESTAMP(0), -- Create time
+    "mtime" timestamp NOT NULL DEFAULT LOCALTIMESTAMP(0), -- Last updated time
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX "auth_strategy_uk" ON "auth_strategy" ("name", "owner");
+CREATE INDEX "auth_strategy_owner" ON "auth_strategy" ("owner");
+CREATE INDEX "auth_strategy_mtime" ON "auth_strategy" ("mtime");
+CREATE TRIGGER "auth_strategy_mtime_on_update" BEFORE UPDATE ON "auth_strategy" FOR EACH ROW EXECUTE PROCEDURE polaris_on_update_mtime();
+
+-- --------------------------------------------------------
+--
+-- Table structure `auth_principal`
+--
+CREATE TABLE "auth_principal"
+(
+    "strategy_id" varchar(128) NOT NULL, -- Strategy ID
+    "principal_id" varchar(128) NOT NULL, -- Principal ID
+    "principal_role" integer NOT NULL, -- PRINCIPAL type, 1 is User, 2 is Group
+    PRIMARY KEY ("strategy_id", "principal_id", "principal_role")
+);
+
+-- --------------------------------------------------------
+--
+-- Table structure `auth_strategy_resource`
+--
+CREATE TABLE "auth_strategy_resource"
+(
+    "strategy_id" varchar(128) NOT NULL, -- Strategy ID
+    "res_type" integer NOT NULL, -- Resource Type, Namespaces = 0, Service = 1, configgroups = 2
+    "res_id" varchar(128) NOT NULL, -- Resource ID
+    "ctime" timestamp NOT NULL DEFAULT LOCALTIMESTAMP(0), -- Create time
+    "mtime" timestamp NOT NULL DEFAULT LOCALTIMESTAMP(0), -- Last updated time
+    PRIMARY KEY ("strategy_id", "res_type", "res_id")
+);
+CREATE INDEX "auth_strategy_resource_mtime" ON "auth_strategy_resource" ("mtime");
+CREATE TRIGGER "auth_strategy_resource_mtime_on_update" BEFORE UPDATE ON "auth_strategy_resource" FOR EACH ROW EXECUTE PROCEDURE polaris_on_update_mtime();
+
+-- --------------------------------------------------------
+--
+-- Table structure `client`
+--
+CREATE TABLE "client"
+(
+    "id" varchar(128) NOT NULL, -- client id
+    "host" varchar(100) NOT NULL, -- client host IP
+    "type" varchar(100) NOT NULL, -- client type: polaris-java/polaris-go
+    "version" varchar(32) NOT NULL, -- client SDK version
+    "region" varchar(128) DEFAULT NULL, -- region info for client
+    "zone" varchar(128) DEFAULT NULL, -- zone info for client
+    "campus" varchar(128) DEFAULT NULL, -- campus info for client
+    "flag" smallint NOT NULL DEFAULT 0, -- 0 is valid, 1 is invalid(deleted)
+    "ctime" timestamp NOT NULL DEFAULT LOCALTIMESTAMP(0), -- create time
+    "mtime" timestamp NOT NULL DEFAULT LOCALTIMESTAMP(0), -- last updated time
+    PRIMARY KEY ("id")
+);
+CREATE INDEX "client_mtime" ON "client" ("mtime");
+CREATE TRIGGER "client_mtime_on_update" BEFORE UPDATE ON "client" FOR EACH ROW EXECUTE PROCEDURE polaris_on_update_mtime();
+
+-- --------------------------------------------------------
+--
+-- Table structure `client_stat`
+--
+CREATE TABLE "client_stat"
+(
+    "client_id" varchar(128) NOT NULL, -- client id
+    "target" varchar(100) NOT NULL, -- target stat platform
+    "port" integer NOT NULL, -- client port to get stat information
+    "protocol" varchar(100) NOT NULL, -- stat info transport protocol
+    "path" varchar(128) NOT NULL, -- stat metric path
+    PRIMARY KEY ("client_id", "target", "port")
+);
+
+-- --------------------------------------------------------
+--
+-- Table structure `service_change_log`
+--
+CREATE TABLE "service_change_log"
+(
+    "seq" bigserial NOT NULL, -- change sequence, increase monotonically
+    "id" varchar(128) NOT NULL, -- changed service id
+    "ctime" timestamp NOT NULL DEFAULT LOCALTIMESTAMP(0), -- create time
+    PRIMARY KEY ("seq")
+);
+CREATE INDEX "service_change_log_ctime" ON "service_change_log" ("ctime");
+
+--
+-- Table structure `instance_change_log`
+--
+CREATE TABLE "instance_change_log"
+(
+    "seq" bigserial NOT NULL, -- change sequence, increase monotonically
+    "id" varchar(128) NOT NULL, -- changed instance id
+    "ctime" timestamp NOT NULL DEFAULT LOCALTIMESTAMP(0), -- create time
+    PRIMARY KEY ("seq")
+);
+CREATE INDEX "instance_change_log_ctime" ON "instance_change_log" ("ctime");
+
+-- --------------------------------------------------------
+--
+-- Table structure `cluster_event`
+--
+CREATE TABLE "cluster_event"
+(
+    "seq" bigserial NOT NULL, -- event sequence, increase monotonically
+    "topic" varchar(64) NOT NULL, -- event topic
+    "payload" text NOT NULL, -- event payload
+    "ctime" timestamp NOT NULL DEFAULT LOCALTIMESTAMP(0), -- create time
+    PRIMARY KEY ("seq")
+);
+CREATE INDEX "cluster_event_ctime" ON "cluster_event" ("ctime");
+
+-- --------------------------------------------------------
+--
+-- Initial data
+--
+INSERT INTO "namespace" ("name", "comment", "token", "owner", "flag", "ctime", "mtime")
+VALUES ('Polaris', 'Polaris-server', '2d1bfe5d12e04d54b8ee69e62494c7fd', 'polaris', 0,
+        '2019-09-06 07:55:07', '2019-09-06 07:55:07'),
+       ('default', 'Default Environment', 'e2e473081d3d4306b52264e49f7ce227', 'polaris', 0,
+        '2021-07-27 19:37:37', '2021-07-27 19:37:37');
+
+INSERT INTO "service" ("id", "name", "namespace", "comment", "business", "token", "revision", "owner", "flag",
+                       "ctime", "mtime")
+VALUES ('fbca9bfa04ae4ead86e1ecf5811e32a9', 'polaris.checker', 'Polaris', 'polaris checker service', 'polaris',
+        '7d19c46de327408d8709ee7392b7700b', '301b1e9f0bbd47a6b697e26e99dfe012', 'polaris', 0,
+        '2021-09-06 07:55:07', '2021-09-06 07:55:09'),
+       ('bbfdda174ea64e11ac862adf14593c03', 'polaris.monitor', 'Polaris', 'polaris monitor service', 'polaris',
+        '50b4e7d8affa4634b52523d398d1a369', '3649b17283d94d7baee5fb5d8160a225', 'polaris', 0,
+        '2021-09-06 07:55:07', '2021-09-06 07:55:11'),
+       ('e6542db1a2cc846c1866010b40b7f51f', 'polaris.config', 'Polaris', 'polaris config service', 'polaris',
+        'c874d9a0a4b45c82c93e6bf285518c7b', '769ec01f58875088faf2cb9e44a4b2d2', 'polaris', 0,
+        '2021-09-06 07:55:07', '2021-09-06 07:55:11');
+
+INSERT INTO "start_lock" ("lock_id", "lock_key", "server", "mtime")
+VALUES (1, 'sz', 'aaa', '2019-12-05 08:35:49');
+
+INSERT INTO "cl5_module" ("module_id", "interface_id", "range_num")
+VALUES (3000001, 1, 0);
+
+INSERT INTO "user" ("id", "name", "password", "source", "token", "token_enable", "user_type", "comment", "owner")
+VALUES ('65e4789a6d5b49669adf1e9e8387549c', 'polaris',
+        '$2a$10$3izWuZtE5SBdAtSZci.gs.iZ2pAn9I8hEqYrC6gwJp1dyjqQnrrum', 'Polaris',
+        'nu/0WRA4EqSR1FagrjRj0fZwPXuGlMpX+zCuWu4uMqy8xr1vRjisSbA25aAC3mtU8MeeRsKhQiDAynUR09I=', 1, 20,
+        'default polaris admin account', '');
+
+INSERT INTO "auth_strategy" ("id", "name", "action", "owner", "comment", "default", "revision", "flag",
+                             "ctime", "mtime")
+VALUES ('fbca9bfa04ae4ead86e1ecf5811e32a9', '(用户) polaris的默认策略', 'READ_WRITE',
+        '65e4789a6d5b49669adf1e9e8387549c', 'default admin', 1, 'fbca9bfa04ae4ead86e1ecf5811e32a9', 0,
+        sysdate(), sysdate());
+
+INSERT INTO "auth_strategy_resource" ("strategy_id", "res_type", "res_id", "ctime", "mtime")
+VALUES ('fbca9bfa04ae4ead86e1ecf5811e32a9', 0, '*', sysdate(), sysdate()),
+       ('fbca9bfa04ae4ead86e1ecf5811e32a9', 1, '*', sysdate(), sysdate()),
+       ('fbca9bfa04ae4ead86e1ecf5811e32a9', 2, '*', sysdate(), sysdate());
+
+INSERT INTO "auth_principal" ("strategy_id", "principal_id", "principal_role")
+VALUES ('fbca9bfa04ae4ead86e1ecf5811e32a9', '65e4789a6d5b49669adf1e9e8387549c', 1);
@@ -31,8 +31,9 @@ import (
 
 // serviceStore 实现了ServiceStore
 type serviceStore struct {
-	master *BaseDB
-	slave  *BaseDB
+	master  *BaseDB
+	slave   *replicaSet
+	changes *changeLogStore
 }
 
 // AddService 增加服务
@@ -92,6 +93,10 @@ func (ss *serviceStore) addService(s *model.Service) error {
 		return err
 	}
 
+	if err = ss.changes.record(tx, store.ChangeLogService, s.ID); err != nil {
+		return err
+	}
+
 	if err := tx.Commit(); err != nil {
 		log.Errorf("[Store][database] add service tx commit err: %s", err.Error())
 		return err
@@ -143,6 +148,10 @@ func (ss *serviceStore) deleteService(id, serviceName, namespaceName string) err
 		return err
 	}
 
+	if err = ss.changes.record(tx, store.ChangeLogService, id); err != nil {
+		return err
+	}
+
 	if err := tx.Commit(); err != nil {
 		log.Errorf("[Store][database] add service tx commit err: %s", err.Error())
 		return err
@@ -165,7 +174,14 @@ func deleteServiceByID(tx *BaseTx, id string) error {
 // DeleteServiceAlias 删除服务别名
 func (ss *serviceStore) DeleteServiceAlias(name string, namespace string) error {
 	str := "update service set flag = 1, mtime = sysdate() where name = ? and namespace = ?"
-	if _, err := ss.master.Exec(str, name, namespace); err != nil {
+	err := ss.changes.execWithRecord(ss.master, func(e execer) error {
+		if _, err := e.Exec(str, name, namespace); err != nil {
+			return err
+		}
+		return ss.changes.recordBySelect(e, store.ChangeLogService,
+			"select id from service where name = ? and namespace = ?", name, namespace)
+	})
+	if err != nil {
 		log.Errorf("[Store][database] delete service alias err: %s", err.Error())
 		return store.Error(err)
 	}
@@ -227,6 +243,10 @@ func (ss *serviceStore) updateServiceAlias(alias *model.Service, needUpdateOwner
 		}
 	}
 
+	if err = ss.changes.record(tx, store.ChangeLogService, alias.ID); err != nil {
+		return err
+	}
+
 	if err := tx.Commit(); err != nil {
 		log.Errorf("[Store][database] update service alias tx commit err: %s", err.Error())
 		return err
@@ -311,6 +331,10 @@ func (ss *serviceStore) updateService(service *model.Service, needUpdateOwner bo
 		}
 	}
 
+	if err = ss.changes.record(tx, store.ChangeLogService, service.ID); err != nil {
+		return err
+	}
+
 	if err := tx.Commit(); err != nil {
 		log.Errorf("[Store][database] update service tx commit err: %s", err.Error())
 		return err
@@ -321,7 +345,12 @@ func (ss *serviceStore) updateService(service *model.Service, needUpdateOwner bo
 // UpdateServiceToken 更新服务token
 func (ss *serviceStore) UpdateServiceToken(id string, token string, revision string) error {
 	str := `update service set token = ?, revision = ?, mtime = sysdate() where id = ?`
-	_, err := ss.master.Exec(str, token, revision, id)
+	err := ss.changes.execWithRecord(ss.master, func(e execer) error {
+		if _, err := e.Exec(str, token, revision, id); err != nil {
+			return err
+		}
+		return ss.changes.record(e, store.ChangeLogService, id)
+	})
 	if err != nil {
 		log.Errorf("[Store][database] update service(%s) token err: %s", id, err.Error())
 		return store.Error(err)
@@ -552,7 +581,7 @@ func (ss *serviceStore) getServices(sFilters, sMetas map[string]string, iFilters
 
 	str += opStr
 	args = append(args, opArgs...)
-	rows, err := ss.master.Query(str, args...)
+	rows, err := ss.slave.console().Query(str, args...)
 	if err != nil {
 		log.Errorf("[Store][database] get services by filter query(%s) err: %s", str, err.Error())
 		return nil, err
@@ -588,7 +617,7 @@ func (ss *serviceStore) getServicesCount(
 		str += " and " + filterStr
 		args = append(args, filterArgs...)
 	}
-	return queryEntryCount(ss.master, str, args)
+	return queryEntryCount(ss.slave.console(), str, args)
 }
 
 // fetchRowServices 根据rows，获取到services，并且批量获取对应的metadata
@@ -864,6 +893,45 @@ func getMoreServiceMain(queryHandler QueryHandler, mtime time.Time,
 	return out, nil
 }
 
+// getServicesByIDs 根据ID获取服务，包括已经被标记删除的服务
+func getServicesByIDs(queryHandler QueryHandler, ids []interface{}, needMeta bool) (
+	map[string]*model.Service, error) {
+	out := make(map[string]*model.Service, len(ids))
+	err := BatchOperation("get-services-by-ids", ids, func(objects []interface{}) error {
+		var str string
+		if needMeta {
+			str = genServiceSelectSQL() + `, IFNULL(service_metadata.id, ""), IFNULL(mkey, ""), ` +
+				`IFNULL(mvalue, "") from service left join service_metadata on service.id = service_metadata.id `
+		} else {
+			str = genServiceSelectSQL() + " from service "
+		}
+		str += "where service.id in (" + PlaceholdersN(len(objects)) + ")"
+		rows, err := queryHandler(str, objects...)
+		if err != nil {
+			log.Errorf("[Store][database] get services by ids query err: %s", err.Error())
+			return err
+		}
+		if needMeta {
+			services, err := fetchServiceWithMetaRows(rows)
+			if err != nil {
+				return err
+			}
+			for id, service := range services {
+				out[id] = service
+			}
+			return nil
+		}
+		return callFetchServiceRows(rows, func(entry *model.Service) (bool, error) {
+			out[entry.ID] = entry
+			return true, nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // batchQueryServiceMeta 批量查询service meta的封装
 func batchQueryServiceMeta(handler QueryHandler, services []interface{}) (*sql.Rows, error) {
 	if len(services) == 0 {
@@ -0,0 +1,45 @@
+//go:build cgo
+// +build cgo
+
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package sqldb
+
+import (
+	"database/sql"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+// SQLiteEnabled 当前二进制是否支持 sqlite 存储，sqlite 驱动依赖 cgo
+const SQLiteEnabled = true
+
+func init() {
+	sql.Register(sqliteDriverName, &sqlite3.SQLiteDriver{ConnectHook: registerSQLiteFunctions})
+}
+
+// registerSQLiteFunctions 注册 sqldb 中使用到的 MySQL 函数
+func registerSQLiteFunctions(conn *sqlite3.SQLiteConn) error {
+	if err := conn.RegisterFunc("sysdate", sqliteSysdate, false); err != nil {
+		return err
+	}
+	if err := conn.RegisterFunc("unix_timestamp", sqliteUnixTimestamp, true); err != nil {
+		return err
+	}
+	return conn.RegisterFunc("from_unixtime", sqliteFromUnixtime, true)
+}
@@ -0,0 +1,43 @@
+//go:build !cgo
+// +build !cgo
+
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package sqldb
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+)
+
+// SQLiteEnabled 当前二进制是否支持 sqlite 存储，sqlite 驱动依赖 cgo
+const SQLiteEnabled = false
+
+func init() {
+	sql.Register(sqliteDriverName, &sqliteUnsupportedDriver{})
+}
+
+// sqliteUnsupportedDriver 关闭 cgo 编译时的占位驱动，连接时返回明确的错误
+type sqliteUnsupportedDriver struct {
+}
+
+// Open 关闭 cgo 时无法打开 sqlite 数据库
+func (d *sqliteUnsupportedDriver) Open(name string) (driver.Conn, error) {
+	return nil, errors.New("sqlite store is not supported by this binary, build it with CGO_ENABLED=1")
+}
@@ -0,0 +1,912 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package sqldb
+
+// sqliteSchema sqlite 的建表语句，表和列与 scripts/polaris_server.sql 保持一致，由 TestSQLiteSchema 校验
+// 所有语句均可重复执行，sqliteStore 初始化时会自动执行；只会创建缺失的表，不会变更已有表的结构
+const sqliteSchema = `
+CREATE TABLE IF NOT EXISTS "business"
+(
+    "id" varchar(32) NOT NULL,
+    "name" varchar(64) NOT NULL,
+    "token" varchar(64) NOT NULL,
+    "owner" varchar(1024) NOT NULL,
+    "flag" smallint NOT NULL DEFAULT 0,
+    "ctime" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "mtime" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY ("id")
+);
+CREATE TRIGGER IF NOT EXISTS "business_mtime_on_update" AFTER UPDATE ON "business" FOR EACH ROW WHEN NEW."mtime" = OLD."mtime"
+BEGIN
+    UPDATE "business" SET "mtime" = sysdate() WHERE rowid = NEW.rowid;
+END;
+
+CREATE TABLE IF NOT EXISTS "instance"
+(
+    "id" varchar(128) NOT NULL,
+    "service_id" varchar(32) NOT NULL,
+    "vpc_id" varchar(64) DEFAULT NULL,
+    "host" varchar(128) NOT NULL,
+    "port" integer NOT NULL,
+    "protocol" varchar(32) DEFAULT NULL,
+    "version" varchar(32) DEFAULT NULL,
+    "health_status" smallint NOT NULL DEFAULT 1,
+    "isolate" smallint NOT NULL DEFAULT 0,
+    "weight" smallint NOT NULL DEFAULT 100,
+    "enable_health_check" smallint NOT NULL DEFAULT 0,
+    "logic_set" varchar(128) DEFAULT NULL,
+    "cmdb_region" varchar(128) DEFAULT NULL,
+    "cmdb_zone" varchar(128) DEFAULT NULL,
+    "cmdb_idc" varchar(128) DEFAULT NULL,
+    "priority" smallint NOT NULL DEFAULT 0,
+    "revision" varchar(32) NOT NULL,
+    "flag" smallint NOT NULL DEFAULT 0,
+    "ctime" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "mtime" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "instance_service_id" ON "instance" ("service_id");
+CREATE INDEX IF NOT EXISTS "instance_mtime" ON "instance" ("mtime");
+CREATE INDEX IF NOT EXISTS "instance_host" ON "instance" ("host");
+CREATE TRIGGER IF NOT EXISTS "instance_mtime_on_update" AFTER UPDATE ON "instance" FOR EACH ROW WHEN NEW."mtime" = OLD."mtime"
+BEGIN
+    UPDATE "instance" SET "mtime" = sysdate() WHERE rowid = NEW.rowid;
+END;
+
+CREATE TABLE IF NOT EXISTS "health_check"
+(
+    "id" varchar(128) NOT NULL,
+    "type" smallint NOT NULL DEFAULT 0,
+    "ttl" integer NOT NULL,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "health_check_ibfk_1" FOREIGN KEY ("id") REFERENCES "instance" ("id") ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS "instance_metadata"
+(
+    "id" varchar(128) NOT NULL,
+    "mkey" varchar(128) NOT NULL,
+    "mvalue" varchar(4096) NOT NULL,
+    "ctime" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "mtime" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY ("id", "mkey"),
+    CONSTRAINT "instance_metadata_ibfk_1" FOREIGN KEY ("id") REFERENCES "instance" ("id") ON DELETE CASCADE ON UPDATE CASCADE
+);
+CREATE INDEX IF NOT EXISTS "instance_metadata_mkey" ON "instance_metadata" ("mkey");
+CREATE TRIGGER IF NOT EXISTS "instance_metadata_mtime_on_update" AFTER UPDATE ON "instance_metadata" FOR EACH ROW WHEN NEW."mtime" = OLD."mtime"
+BEGIN
+    UPDATE "instance_metadata" SET "mtime" = sysdate() WHERE rowid = NEW.rowid;
+END;
+
+CREATE TABLE IF NOT EXISTS "namespace"
+(
+    "name" varchar(64) NOT NULL,
+    "comment" varchar(1024) DEFAULT NULL,
+    "token" varchar(64) NOT NULL,
+    "owner" varchar(1024) NOT NULL,
+    "flag" smallint NOT NULL DEFAULT 0,
+    "ctime" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "mtime" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY ("name")
+);
+CREATE TRIGGER IF NOT EXISTS "namespace_mtime_on_update" AFTER UPDATE ON "namespace" FOR EACH ROW WHEN NEW."mtime" = OLD."mtime"
+BEGIN
+    UPDATE "namespace" SET "mtime" = sysdate() WHERE rowid = NEW.rowid;
+END;
+
+CREATE TABLE IF NOT EXISTS "routing_config"
+(
+    "id" varchar(32) NOT NULL,
+    "in_bounds" text,
+    "out_bounds" text,
+    "revision" varchar(40) NOT NULL,
+    "flag" smallint NOT NULL DEFAULT 0,
+    "ctime" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "mtime" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "routing_config_mtime" ON "routing_config" ("mtime");
+CREATE TRIGGER IF NOT EXISTS "routing_config_mtime_on_update" AFTER UPDATE ON "routing_config" FOR EACH ROW WHEN NEW."mtime" = OLD."mtime"
+BEGIN
+    UPDATE "routing_config" SET "mtime" = sysdate() WHERE rowid = NEW.rowid;
+END;
+
+CREATE TABLE IF NOT EXISTS "ratelimit_config"
+(
+    "id" varchar(32) NOT NULL,
+    "service_id" varchar(32) NOT NULL,
+    "cluster_id" varchar(32) NOT NULL,
+    "labels" text NOT NULL,
+    "priority" smallint NOT NULL DEFAULT 0,
+    "rule" text NOT NULL,
+    "revision" varchar(32) NOT NULL,
+    "flag" smallint NOT NULL DEFAULT 0,
+    "ctime" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "mtime" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "ratelimit_config_mtime" ON "ratelimit_config" ("mtime");
+CREATE INDEX IF NOT EXISTS "ratelimit_config_service_id" ON "ratelimit_config" ("service_id");
+CREATE TRIGGER IF NOT EXISTS "ratelimit_config_mtime_on_update" AFTER UPDATE ON "ratelimit_config" FOR EACH ROW WHEN NEW."mtime" = OLD."mtime"
+BEGIN
+    UPDATE "ratelimit_config" SET "mtime" = sysdate() WHERE rowid = NEW.rowid;
+END;
+
+CREATE TABLE IF NOT EXISTS "ratelimit_revision"
+(
+    "service_id" varchar(32) NOT NULL,
+    "last_revision" varchar(40) NOT NULL,
+    "mtime" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY ("service_id")
+);
+CREATE INDEX IF NOT EXISTS "ratelimit_revision_service_id" ON "ratelimit_revision" ("service_id");
+CREATE INDEX IF NOT EXISTS "ratelimit_revision_mtime" ON "ratelimit_revision" ("mtime");
+CREATE TRIGGER IF NOT EXISTS "ratelimit_revision_mtime_on_update" AFTER UPDATE ON "ratelimit_revision" FOR EACH ROW WHEN NEW."mtime" = OLD."mtime"
+BEGIN
+    UPDATE "ratelimit_revision" SET "mtime" = sysdate() WHERE rowid = NEW.rowid;
+END;
+
+CREATE TABLE IF NOT EXISTS "service"
+(
+    "id" varchar(32) NOT NULL,
+    "name" varchar(128) NOT NULL,
+    "namespace" varchar(64) NOT NULL,
+    "ports" varchar(8192) DEFAULT NULL,
+    "business" varchar(64) DEFAULT NULL,
+    "department" varchar(1024) DEFAULT NULL,
+    "cmdb_mod1" varchar(1024) DEFAULT NULL,
+    "cmdb_mod2" varchar(1024) DEFAULT NULL,
+    "cmdb_mod3" varchar(1024) DEFAULT NULL,
+    "comment" varchar(1024) DEFAULT NULL,
+    "token" varchar(2048) NOT NULL,
+    "revision" varchar(32) NOT NULL,
+    "owner" varchar(1024) NOT NULL,
+    "flag" smallint NOT NULL DEFAULT 0,
+    "reference" varchar(32) DEFAULT NULL,
+    "refer_filter" varchar(1024) DEFAULT NULL,
+    "platform_id" varchar(32) DEFAULT '',
+    "ctime" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "mtime" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "service_name" ON "service" ("name", "namespace");
+CREATE INDEX IF NOT EXISTS "service_namespace" ON "service" ("namespace");
+CREATE INDEX IF NOT EXISTS "service_mtime" ON "service" ("mtime");
+CREATE INDEX IF NOT EXISTS "service_reference" ON "service" ("reference");
+CREATE INDEX IF NOT EXISTS "service_platform_id" ON "service" ("platform_id");
+CREATE TRIGGER IF NOT EXISTS "service_mtime_on_update" AFTER UPDATE ON "service" FOR EACH ROW WHEN NEW."mtime" = OLD."mtime"
+BEGIN
+    UPDATE "service" SET "mtime" = sysdate() WHERE rowid = NEW.rowid;
+END;
+
+CREATE TABLE IF NOT EXISTS "service_metadata"
+(
+    "id" varchar(32) NOT NULL,
+    "mkey" varchar(128) NOT NULL,
+    "mvalue" varchar(4096) NOT NULL,
+    "ctime" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "mtime" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY ("id", "mkey"),
+    CONSTRAINT "service_metadata_ibfk_1" FOREIGN KEY ("id") REFERENCES "service" ("id") ON DELETE CASCADE ON UPDATE CASCADE
+);
+CREATE INDEX IF NOT EXISTS "service_metadata_mkey" ON "service_metadata" ("mkey");
+CREATE TRIGGER IF NOT EXISTS "service_metadata_mtime_on_update" AFTER UPDATE ON "service_metadata" FOR EACH ROW WHEN NEW."mtime" = OLD."mtime"
+BEGIN
+    UPDATE "service_metadata" SET "mtime" = sysdate() WHERE rowid = NEW.rowid;
+END;
+
+CREATE TABLE IF NOT EXISTS "owner_service_map"
+(
+    "id" varchar(32) NOT NULL,
+    "owner" varchar(32) NOT NULL,
+    "service" varchar(128) NOT NULL,
+    "namespace" varchar(64) NOT NULL,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "owner_service_map_owner" ON "owner_service_map" ("owner");
+CREATE INDEX IF NOT EXISTS "owner_service_map_name" ON "owner_service_map" ("service", "namespace");
+
+CREATE TABLE IF NOT EXISTS "circuitbreaker_rule"
+(
+    "id" varchar(97) NOT NULL,
+    "version" varchar(32) NOT NULL DEFAULT 'master',
+    "name" varchar(128) NOT NULL,
+    "namespace" varchar(64) NOT NULL,
+    "business" varchar(64) DEFAULT NULL,
+    "department" varchar(1024) DEFAULT NULL,
+    "comment" varchar(1024) DEFAULT NULL,
+    "inbounds" text NOT NULL,
+    "outbounds" text NOT NULL,
+    "token" varchar(32) NOT NULL,
+    "owner" varchar(1024) NOT NULL,
+    "revision" varchar(32) NOT NULL,
+    "flag" smallint NOT NULL DEFAULT 0,
+    "ctime" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "mtime" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY ("id", "version")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "circuitbreaker_rule_name" ON "circuitbreaker_rule" ("name", "namespace", "version");
+CREATE INDEX IF NOT EXISTS "circuitbreaker_rule_mtime" ON "circuitbreaker_rule" ("mtime");
+CREATE TRIGGER IF NOT EXISTS "circuitbreaker_rule_mtime_on_update" AFTER UPDATE ON "circuitbreaker_rule" FOR EACH ROW WHEN NEW."mtime" = OLD."mtime"
+BEGIN
+    UPDATE "circuitbreaker_rule" SET "mtime" = sysdate() WHERE rowid = NEW.rowid;
+END;
+
+CREATE TABLE IF NOT EXISTS "circuitbreaker_rule_relation"
+(
+    "service_id" varchar(32) NOT NULL,
+    "rule_id" varchar(97) NOT NULL,
+    "rule_version" varchar(32) NOT NULL,
+    "flag" smallint NOT NULL DEFAULT 0,
+    "ctime" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "mtime" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY ("service_id"),
+    CONSTRAINT "circuitbreaker_rule_relation_ibfk_1" FOREIGN KEY ("service_id") REFERENCES "service" ("id") ON DELETE CASCADE ON UPDATE CASCADE
+);
+CREATE INDEX IF NOT EXISTS "circuitbreaker_rule_relation_mtime" ON "circuitbreaker_rule_relation" ("mtime");
+CREATE INDEX IF NOT EXISTS "circuitbreaker_rule_relation_rule_id" ON "circuitbreaker_rule_relation" ("rule_id");
+CREATE TRIGGER IF NOT EXISTS "circuitbreaker_rule_relation_mtime_on_update" AFTER UPDATE ON "circuitbreaker_rule_relation" FOR EACH ROW WHEN NEW."mtime" = OLD."mtime"
+BEGIN
+    UPDATE "circuitbreaker_rule_relation" SET "mtime" = sysdate() WHERE rowid = NEW.rowid;
+END;
+
+CREATE TABLE IF NOT EXISTS "platform"
+(
+    "id" varchar(32) NOT NULL,
+    "name" varchar(128) NOT NULL,
+    "domain" varchar(1024) NOT NULL,
+    "qps" smallint NOT NULL,
+    "token" varchar(32) NOT NULL,
+    "owner" varchar(1024) NOT NULL,
+    "department" varchar(1024) DEFAULT NULL,
+    "comment" varchar(1024) DEFAULT NULL,
+    "flag" smallint NOT NULL DEFAULT 0,
+    "ctime" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "mtime" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "platform_mtime" ON "platform" ("mtime");
+CREATE TRIGGER IF NOT EXISTS "platform_mtime_on_update" AFTER UPDATE ON "platform" FOR EACH ROW WHEN NEW."mtime" = OLD."mtime"
+BEGIN
+    UPDATE "platform" SET "mtime" = sysdate() WHERE rowid = NEW.rowid;
+END;
+
+CREATE TABLE IF NOT EXISTS "t_ip_config"
+(
+    "fip" integer NOT NULL,
+    "fareaid" integer NOT NULL,
+    "fcityid" integer NOT NULL,
+    "fidcid" integer NOT NULL,
+    "fflag" smallint DEFAULT 0,
+    "fstamp" timestamp NOT NULL,
+    "fflow" integer NOT NULL,
+    PRIMARY KEY ("fip")
+);
+CREATE INDEX IF NOT EXISTS "t_ip_config_idx_fflow" ON "t_ip_config" ("fflow");
+
+CREATE TABLE IF NOT EXISTS "t_policy"
+(
+    "fmodid" integer NOT NULL,
+    "fdiv" integer NOT NULL,
+    "fmod" integer NOT NULL,
+    "fflag" smallint DEFAULT 0,
+    "fstamp" timestamp NOT NULL,
+    "fflow" integer NOT NULL,
+    PRIMARY KEY ("fmodid")
+);
+
+CREATE TABLE IF NOT EXISTS "t_route"
+(
+    "fip" integer NOT NULL,
+    "fmodid" integer NOT NULL,
+    "fcmdid" integer NOT NULL,
+    "fsetid" varchar(32) NOT NULL,
+    "fflag" smallint DEFAULT 0,
+    "fstamp" timestamp NOT NULL,
+    "fflow" integer NOT NULL,
+    PRIMARY KEY ("fip", "fmodid", "fcmdid")
+);
+CREATE INDEX IF NOT EXISTS "t_route_fflow" ON "t_route" ("fflow");
+CREATE INDEX IF NOT EXISTS "t_route_idx1" ON "t_route" ("fmodid", "fcmdid", "fsetid");
+
+CREATE TABLE IF NOT EXISTS "t_section"
+(
+    "fmodid" integer NOT NULL,
+    "ffrom" integer NOT NULL,
+    "fto" integer NOT NULL,
+    "fxid" integer NOT NULL,
+    "fflag" smallint DEFAULT 0,
+    "fstamp" timestamp NOT NULL,
+    "fflow" integer NOT NULL,
+    PRIMARY KEY ("fmodid", "ffrom", "fto")
+);
+
+CREATE TABLE IF NOT EXISTS "start_lock"
+(
+    "lock_id" integer NOT NULL,
+    "lock_key" varchar(32) NOT NULL,
+    "server" varchar(32) NOT NULL,
+    "mtime" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY ("lock_id", "lock_key")
+);
+CREATE TRIGGER IF NOT EXISTS "start_lock_mtime_on_update" AFTER UPDATE ON "start_lock" FOR EACH ROW WHEN NEW."mtime" = OLD."mtime"
+BEGIN
+    UPDATE "start_lock" SET "mtime" = sysdate() WHERE rowid = NEW.rowid;
+END;
+
+CREATE TABLE IF NOT EXISTS "cl5_module"
+(
+    "module_id" integer NOT NULL,
+    "interface_id" integer NOT NULL,
+    "range_num" integer NOT NULL,
+    "mtime" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY ("module_id")
+);
+CREATE TRIGGER IF NOT EXISTS "cl5_module_mtime_on_update" AFTER UPDATE ON "cl5_module" FOR EACH ROW WHEN NEW."mtime" = OLD."mtime"
+BEGIN
+    UPDATE "cl5_module" SET "mtime" = sysdate() WHERE rowid = NEW.rowid;
+END;
+
+CREATE TABLE IF NOT EXISTS "mesh"
+(
+    "id" varchar(32) NOT NULL,
+    "name" varchar(128) NOT NULL,
+    "department" varchar(1024) DEFAULT NULL,
+    "business" varchar(128) NOT NULL,
+    "managed" smallint NOT NULL,
+    "istio_version" varchar(64),
+    "data_cluster" varchar(1024),
+    "revision" varchar(32) NOT NULL,
+    "comment" varchar(1024) DEFAULT NULL,
+    "token" varchar(32) NOT NULL,
+    "owner" varchar(1024) NOT NULL,
+    "flag" smallint NOT NULL DEFAULT 0,
+    "ctime" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "mtime" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "mesh_name" ON "mesh" ("name");
+CREATE INDEX IF NOT EXISTS "mesh_mtime" ON "mesh" ("mtime");
+CREATE TRIGGER IF NOT EXISTS "mesh_mtime_on_update" AFTER UPDATE ON "mesh" FOR EACH ROW WHEN NEW."mtime" = OLD."mtime"
+BEGIN
+    UPDATE "mesh" SET "mtime" = sysdate() WHERE rowid = NEW.rowid;
+END;
+
+CREATE TABLE IF NOT EXISTS "mesh_service"
+(
+    "id" varchar(32) NOT NULL,
+    "mesh_id" varchar(32) NOT NULL,
+    "service_id" varchar(32) NOT NULL,
+    "namespace" varchar(64) NOT NULL,
+    "service" varchar(128) NOT NULL,
+    "mesh_namespace" varchar(64) NOT NULL,
+    "mesh_service" varchar(128) NOT NULL,
+    "location" varchar(16) NOT NULL,
+    "export_to" varchar(1024) NOT NULL,
+    "revision" varchar(32) NOT NULL,
+    "flag" smallint NOT NULL DEFAULT 0,
+    "ctime" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "mtime" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "mesh_service_relation" ON "mesh_service" ("mesh_id", "mesh_namespace", "mesh_service");
+CREATE INDEX IF NOT EXISTS "mesh_service_namespace" ON "mesh_service" ("namespace");
+CREATE INDEX IF NOT EXISTS "mesh_service_service" ON "mesh_service" ("service");
+CREATE INDEX IF NOT EXISTS "mesh_service_location" ON "mesh_service" ("location");
+CREATE INDEX IF NOT EXISTS "mesh_service_export_to" ON "mesh_service" ("export_to");
+CREATE INDEX IF NOT EXISTS "mesh_service_mtime" ON "mesh_service" ("mtime");
+CREATE INDEX IF NOT EXISTS "mesh_service_flag" ON "mesh_service" ("flag");
+CREATE TRIGGER IF NOT EXISTS "mesh_service_mtime_on_update" AFTER UPDATE ON "mesh_service" FOR EACH ROW WHEN NEW."mtime" = OLD."mtime"
+BEGIN
+    UPDATE "mesh_service" SET "mtime" = sysdate() WHERE rowid = NEW.rowid;
+END;
+
+CREATE TABLE IF NOT EXISTS "mesh_service_revision"
+(
+    "mesh_id" varchar(32) NOT NULL,
+    "revision" varchar(32) NOT NULL,
+    "ctime" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "mtime" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY ("mesh_id")
+);
+CREATE INDEX IF NOT EXISTS "mesh_service_revision_mtime" ON "mesh_service_revision" ("mtime");
+CREATE TRIGGER IF NOT EXISTS "mesh_service_revision_mtime_on_update" AFTER UPDATE ON "mesh_service_revision" FOR EACH ROW WHEN NEW."mtime" = OLD."mtime"
+BEGIN
+    UPDATE "mesh_service_revision" SET "mtime" = sysdate() WHERE rowid = NEW.rowid;
+END;
+
+CREATE TABLE IF NOT EXISTS "mesh_resource"
+(
+    "id" varchar(32) NOT NULL,
+    "mesh_id" varchar(32) NOT NULL,
+    "name" varchar(64) NOT NULL,
+    "mesh_namespace" varchar(64) NOT NULL,
+    "type_url" varchar(96) NOT NULL,
+    "revision" varchar(32) NOT NULL,
+    "body" text,
+    "flag" smallint NOT NULL DEFAULT 0,
+    "ctime" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "mtime" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "mesh_resource_name" ON "mesh_resource" ("mesh_id", "name", "mesh_namespace", "type_url");
+CREATE INDEX IF NOT EXISTS "mesh_resource_mtime" ON "mesh_resource" ("mtime");
+CREATE TRIGGER IF NOT EXISTS "mesh_resource_mtime_on_update" AFTER UPDATE ON "mesh_resource" FOR EACH ROW WHEN NEW."mtime" = OLD."mtime"
+BEGIN
+    UPDATE "mesh_resource" SET "mtime" = sysdate() WHERE rowid = NEW.rowid;
+END;
+
+CREATE TABLE IF NOT EXISTS "mesh_resource_revision"
+(
+    "mesh_id" varchar(32) NOT NULL,
+    "type_url" varchar(96) NOT NULL,
+    "revision" varchar(32) NOT NULL,
+    "ctime" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "mtime" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY ("mesh_id", "type_url")
+);
+CREATE INDEX IF NOT EXISTS "mesh_resource_revision_mtime" ON "mesh_resource_revision" ("mtime");
+CREATE TRIGGER IF NOT EXISTS "mesh_resource_revision_mtime_on_update" AFTER UPDATE ON "mesh_resource_revision" FOR EACH ROW WHEN NEW."mtime" = OLD."mtime"
+BEGIN
+    UPDATE "mesh_resource_revision" SET "mtime" = sysdate() WHERE rowid = NEW.rowid;
+END;
+
+CREATE TABLE IF NOT EXISTS "ratelimit_flux_rule_config"
+(
+    "id" varchar(32) NOT NULL,
+    "revision" varchar(32) NOT NULL,
+    "callee_service_id" varchar(32) NOT NULL,
+    "callee_service_env" varchar(64) NOT NULL,
+    "callee_service_name" varchar(250) NOT NULL DEFAULT '',
+    "caller_service_business" varchar(250) NOT NULL DEFAULT '',
+    "name" varchar(128) NOT NULL DEFAULT '',
+    "description" varchar(500) NOT NULL DEFAULT '',
+    "type" smallint NOT NULL DEFAULT 0,
+    "set_key" varchar(250) NOT NULL DEFAULT '',
+    "set_alert_qps" varchar(10) NOT NULL DEFAULT '',
+    "set_warning_qps" varchar(10) NOT NULL DEFAULT '',
+    "set_remark" varchar(500) NOT NULL DEFAULT '',
+    "default_key" varchar(250) NOT NULL DEFAULT '',
+    "default_alert_qps" varchar(10) NOT NULL DEFAULT '',
+    "default_warning_qps" varchar(10) NOT NULL DEFAULT '',
+    "default_remark" varchar(500) NOT NULL DEFAULT '',
+    "creator" varchar(32) NOT NULL DEFAULT '',
+    "updater" varchar(32) NOT NULL DEFAULT '',
+    "status" smallint NOT NULL DEFAULT 0,
+    "flag" smallint NOT NULL DEFAULT 0,
+    "ctime" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "mtime" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "flux_server_id" varchar(32) NOT NULL DEFAULT '',
+    "monitor_server_id" varchar(32) NOT NULL DEFAULT '',
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "ratelimit_flux_rule_config_unique_service" ON "ratelimit_flux_rule_config" ("callee_service_id", "caller_service_business", "set_key");
+CREATE INDEX IF NOT EXISTS "ratelimit_flux_rule_config_mtime" ON "ratelimit_flux_rule_config" ("mtime");
+CREATE INDEX IF NOT EXISTS "ratelimit_flux_rule_config_name" ON "ratelimit_flux_rule_config" ("name");
+CREATE INDEX IF NOT EXISTS "ratelimit_flux_rule_config_creator" ON "ratelimit_flux_rule_config" ("creator");
+CREATE INDEX IF NOT EXISTS "ratelimit_flux_rule_config_callee_service" ON "ratelimit_flux_rule_config" ("callee_service_env", "callee_service_name");
+CREATE TRIGGER IF NOT EXISTS "ratelimit_flux_rule_config_mtime_on_update" AFTER UPDATE ON "ratelimit_flux_rule_config" FOR EACH ROW WHEN NEW."mtime" = OLD."mtime"
+BEGIN
+    UPDATE "ratelimit_flux_rule_config" SET "mtime" = sysdate() WHERE rowid = NEW.rowid;
+END;
+
+CREATE TABLE IF NOT EXISTS "ratelimit_flux_rule_revision"
+(
+    "service_id" varchar(32) NOT NULL,
+    "last_revision" varchar(40) NOT NULL,
+    "mtime" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY ("service_id")
+);
+CREATE TRIGGER IF NOT EXISTS "ratelimit_flux_rule_revision_mtime_on_update" AFTER UPDATE ON "ratelimit_flux_rule_revision" FOR EACH ROW WHEN NEW."mtime" = OLD."mtime"
+BEGIN
+    UPDATE "ratelimit_flux_rule_revision" SET "mtime" = sysdate() WHERE rowid = NEW.rowid;
+END;
+
+CREATE TABLE IF NOT EXISTS "config_file"
+(
+    "id" integer PRIMARY KEY AUTOINCREMENT,
+    "namespace" varchar(64) NOT NULL,
+    "group" varchar(128) NOT NULL DEFAULT '',
+    "name" varchar(128) NOT NULL,
+    "content" text NOT NULL,
+    "format" varchar(16) DEFAULT 'text',
+    "comment" varchar(512) DEFAULT NULL,
+    "encrypted" smallint NOT NULL DEFAULT 0,
+    "base_file" varchar(512) DEFAULT NULL,
+    "variables_file" varchar(512) DEFAULT NULL,
+    "flag" smallint NOT NULL DEFAULT 0,
+    "create_time" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "create_by" varchar(32) DEFAULT NULL,
+    "modify_time" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "modify_by" varchar(32) DEFAULT NULL
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "config_file_uk_file" ON "config_file" ("namespace", "group", "name");
+CREATE TRIGGER IF NOT EXISTS "config_file_modify_time_on_update" AFTER UPDATE ON "config_file" FOR EACH ROW WHEN NEW."modify_time" = OLD."modify_time"
+BEGIN
+    UPDATE "config_file" SET "modify_time" = sysdate() WHERE rowid = NEW.rowid;
+END;
+
+CREATE TABLE IF NOT EXISTS "config_file_group"
+(
+    "id" integer PRIMARY KEY AUTOINCREMENT,
+    "name" varchar(128) NOT NULL,
+    "namespace" varchar(64) NOT NULL,
+    "comment" varchar(512) DEFAULT NULL,
+    "owner" varchar(1024) DEFAULT NULL,
+    "json_schema" text DEFAULT NULL,
+    "create_time" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "create_by" varchar(32) DEFAULT NULL,
+    "modify_time" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "modify_by" varchar(32) DEFAULT NULL
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "config_file_group_uk_name" ON "config_file_group" ("namespace", "name");
+CREATE TRIGGER IF NOT EXISTS "config_file_group_modify_time_on_update" AFTER UPDATE ON "config_file_group" FOR EACH ROW WHEN NEW."modify_time" = OLD."modify_time"
+BEGIN
+    UPDATE "config_file_group" SET "modify_time" = sysdate() WHERE rowid = NEW.rowid;
+END;
+
+CREATE TABLE IF NOT EXISTS "config_file_release"
+(
+    "id" integer PRIMARY KEY AUTOINCREMENT,
+    "name" varchar(128) DEFAULT NULL,
+    "namespace" varchar(64) NOT NULL,
+    "group" varchar(128) NOT NULL,
+    "file_name" varchar(128) NOT NULL,
+    "content" text NOT NULL,
+    "comment" varchar(512) DEFAULT NULL,
+    "md5" varchar(128) NOT NULL,
+    "version" integer NOT NULL,
+    "flag" smallint NOT NULL DEFAULT 0,
+    "create_time" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "create_by" varchar(32) DEFAULT NULL,
+    "modify_time" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "modify_by" varchar(32) DEFAULT NULL
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "config_file_release_uk_file" ON "config_file_release" ("namespace", "group", "file_name");
+CREATE INDEX IF NOT EXISTS "config_file_release_idx_modify_time" ON "config_file_release" ("modify_time");
+CREATE TRIGGER IF NOT EXISTS "config_file_release_modify_time_on_update" AFTER UPDATE ON "config_file_release" FOR EACH ROW WHEN NEW."modify_time" = OLD."modify_time"
+BEGIN
+    UPDATE "config_file_release" SET "modify_time" = sysdate() WHERE rowid = NEW.rowid;
+END;
+
+CREATE TABLE IF NOT EXISTS "config_file_gray_release"
+(
+    "id" integer PRIMARY KEY AUTOINCREMENT,
+    "name" varchar(128) DEFAULT NULL,
+    "namespace" varchar(64) NOT NULL,
+    "group" varchar(128) NOT NULL,
+    "file_name" varchar(128) NOT NULL,
+    "content" text NOT NULL,
+    "comment" varchar(512) DEFAULT NULL,
+    "md5" varchar(128) NOT NULL,
+    "version" integer NOT NULL,
+    "gray_rule" text NOT NULL,
+    "flag" smallint NOT NULL DEFAULT 0,
+    "create_time" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "create_by" varchar(32) DEFAULT NULL,
+    "modify_time" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "modify_by" varchar(32) DEFAULT NULL
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "config_file_gray_release_uk_file" ON "config_file_gray_release" ("namespace", "group", "file_name");
+CREATE INDEX IF NOT EXISTS "config_file_gray_release_idx_modify_time" ON "config_file_gray_release" ("modify_time");
+CREATE TRIGGER IF NOT EXISTS "config_file_gray_release_modify_time_on_update" AFTER UPDATE ON "config_file_gray_release" FOR EACH ROW WHEN NEW."modify_time" = OLD."modify_time"
+BEGIN
+    UPDATE "config_file_gray_release" SET "modify_time" = sysdate() WHERE rowid = NEW.rowid;
+END;
+
+CREATE TABLE IF NOT EXISTS "config_file_release_history"
+(
+    "id" integer PRIMARY KEY AUTOINCREMENT,
+    "name" varchar(64) DEFAULT '',
+    "namespace" varchar(64) NOT NULL,
+    "group" varchar(128) NOT NULL,
+    "file_name" varchar(128) NOT NULL,
+    "content" text NOT NULL,
+    "template_content" text DEFAULT NULL,
+    "format" varchar(16) DEFAULT 'text',
+    "tags" varchar(2048) DEFAULT '',
+    "comment" varchar(512) DEFAULT NULL,
+    "md5" varchar(128) NOT NULL,
+    "type" varchar(32) NOT NULL,
+    "status" varchar(16) NOT NULL DEFAULT 'success',
+    "create_time" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "create_by" varchar(32) DEFAULT NULL,
+    "modify_time" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "modify_by" varchar(32) DEFAULT NULL
+);
+CREATE INDEX IF NOT EXISTS "config_file_release_history_idx_file" ON "config_file_release_history" ("namespace", "group", "file_name");
+CREATE TRIGGER IF NOT EXISTS "config_file_release_history_modify_time_on_update" AFTER UPDATE ON "config_file_release_history" FOR EACH ROW WHEN NEW."modify_time" = OLD."modify_time"
+BEGIN
+    UPDATE "config_file_release_history" SET "modify_time" = sysdate() WHERE rowid = NEW.rowid;
+END;
+
+CREATE TABLE IF NOT EXISTS "config_file_tag"
+(
+    "id" integer PRIMARY KEY AUTOINCREMENT,
+    "key" varchar(128) NOT NULL,
+    "value" varchar(128) NOT NULL,
+    "namespace" varchar(64) NOT NULL,
+    "group" varchar(128) NOT NULL DEFAULT '',
+    "file_name" varchar(128) NOT NULL,
+    "create_time" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "create_by" varchar(32) DEFAULT NULL,
+    "modify_time" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "modify_by" varchar(32) DEFAULT NULL
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "config_file_tag_uk_tag" ON "config_file_tag" ("key", "value", "namespace", "group", "file_name");
+CREATE INDEX IF NOT EXISTS "config_file_tag_idx_file" ON "config_file_tag" ("namespace", "group", "file_name");
+CREATE TRIGGER IF NOT EXISTS "config_file_tag_modify_time_on_update" AFTER UPDATE ON "config_file_tag" FOR EACH ROW WHEN NEW."modify_time" = OLD."modify_time"
+BEGIN
+    UPDATE "config_file_tag" SET "modify_time" = sysdate() WHERE rowid = NEW.rowid;
+END;
+
+CREATE TABLE IF NOT EXISTS "config_file_listener"
+(
+    "id" integer PRIMARY KEY AUTOINCREMENT,
+    "namespace" varchar(64) NOT NULL,
+    "group" varchar(128) NOT NULL,
+    "file_name" varchar(128) NOT NULL,
+    "client_id" varchar(128) NOT NULL,
+    "client_ip" varchar(64) NOT NULL DEFAULT '',
+    "version" bigint NOT NULL DEFAULT 0,
+    "last_poll_time" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "server" varchar(64) NOT NULL DEFAULT '',
+    "modify_time" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "config_file_listener_uk_listener" ON "config_file_listener" ("namespace", "group", "file_name", "client_id");
+CREATE INDEX IF NOT EXISTS "config_file_listener_idx_modify_time" ON "config_file_listener" ("modify_time");
+CREATE TRIGGER IF NOT EXISTS "config_file_listener_modify_time_on_update" AFTER UPDATE ON "config_file_listener" FOR EACH ROW WHEN NEW."modify_time" = OLD."modify_time"
+BEGIN
+    UPDATE "config_file_listener" SET "modify_time" = sysdate() WHERE rowid = NEW.rowid;
+END;
+
+CREATE TABLE IF NOT EXISTS "config_file_publish_request"
+(
+    "id" varchar(128) NOT NULL PRIMARY KEY,
+    "namespace" varchar(64) NOT NULL,
+    "group" varchar(128) NOT NULL,
+    "file_name" varchar(128) NOT NULL,
+    "comment" varchar(512) NOT NULL DEFAULT '',
+    "status" varchar(32) NOT NULL,
+    "approve_users" text NOT NULL,
+    "approve_groups" text NOT NULL,
+    "plan_time" bigint NOT NULL DEFAULT 0,
+    "approve_by" varchar(128) NOT NULL DEFAULT '',
+    "approve_comment" varchar(512) NOT NULL DEFAULT '',
+    "approve_time" bigint NOT NULL DEFAULT 0,
+    "approve_md5" varchar(128) NOT NULL DEFAULT '',
+    "publish_time" bigint NOT NULL DEFAULT 0,
+    "fail_reason" varchar(1024) NOT NULL DEFAULT '',
+    "create_time" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "create_by" varchar(32) NOT NULL DEFAULT '',
+    "modify_time" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "modify_by" varchar(32) NOT NULL DEFAULT ''
+);
+CREATE INDEX IF NOT EXISTS "config_file_publish_request_idx_file" ON "config_file_publish_request" ("namespace", "group", "file_name");
+CREATE INDEX IF NOT EXISTS "config_file_publish_request_idx_status_plan_time" ON "config_file_publish_request" ("status", "plan_time");
+CREATE TRIGGER IF NOT EXISTS "config_file_publish_request_modify_time_on_update" AFTER UPDATE ON "config_file_publish_request" FOR EACH ROW WHEN NEW."modify_time" = OLD."modify_time"
+BEGIN
+    UPDATE "config_file_publish_request" SET "modify_time" = sysdate() WHERE rowid = NEW.rowid;
+END;
+
+CREATE TABLE IF NOT EXISTS "user"
+(
+    "id" varchar(128) NOT NULL,
+    "name" varchar(100) NOT NULL,
+    "password" varchar(100) NOT NULL,
+    "owner" varchar(128) NOT NULL,
+    "source" varchar(32) NOT NULL,
+    "mobile" varchar(12) NOT NULL DEFAULT '',
+    "email" varchar(64) NOT NULL DEFAULT '',
+    "token" varchar(255) NOT NULL,
+    "token_enable" smallint NOT NULL DEFAULT 1,
+    "user_type" integer NOT NULL DEFAULT 20,
+    "comment" varchar(255) NOT NULL,
+    "flag" smallint NOT NULL DEFAULT 0,
+    "ctime" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "mtime" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "user_uk" ON "user" ("name", "owner");
+CREATE INDEX IF NOT EXISTS "user_owner" ON "user" ("owner");
+CREATE INDEX IF NOT EXISTS "user_mtime" ON "user" ("mtime");
+CREATE TRIGGER IF NOT EXISTS "user_mtime_on_update" AFTER UPDATE ON "user" FOR EACH ROW WHEN NEW."mtime" = OLD."mtime"
+BEGIN
+    UPDATE "user" SET "mtime" = sysdate() WHERE rowid = NEW.rowid;
+END;
+
+CREATE TABLE IF NOT EXISTS "user_group"
+(
+    "id" varchar(128) NOT NULL,
+    "name" varchar(100) NOT NULL,
+    "owner" varchar(128) NOT NULL,
+    "token" varchar(255) NOT NULL,
+    "comment" varchar(255) NOT NULL,
+    "token_enable" smallint NOT NULL DEFAULT 1,
+    "flag" smallint NOT NULL DEFAULT 0,
+    "ctime" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "mtime" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "user_group_uk" ON "user_group" ("name", "owner");
+CREATE INDEX IF NOT EXISTS "user_group_owner" ON "user_group" ("owner");
+CREATE INDEX IF NOT EXISTS "user_group_mtime" ON "user_group" ("mtime");
+CREATE TRIGGER IF NOT EXISTS "user_group_mtime_on_update" AFTER UPDATE ON "user_group" FOR EACH ROW WHEN NEW."mtime" = OLD."mtime"
+BEGIN
+    UPDATE "user_group" SET "mtime" = sysdate() WHERE rowid = NEW.rowid;
+END;
+
+CREATE TABLE IF NOT EXISTS "user_group_relation"
+(
+    "user_id" varchar(128) NOT NULL,
+    "group_id" varchar(128) NOT NULL,
+    "ctime" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "mtime" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY ("user_id", "group_id")
+);
+CREATE INDEX IF NOT EXISTS "user_group_relation_mtime" ON "user_group_relation" ("mtime");
+CREATE TRIGGER IF NOT EXISTS "user_group_relation_mtime_on_update" AFTER UPDATE ON "user_group_relation" FOR EACH ROW WHEN NEW."mtime" = OLD."mtime"
+BEGIN
+    UPDATE "user_group_relation" SET "mtime" = sysdate() WHERE rowid = NEW.rowid;
+END;
+
+CREATE TABLE IF NOT EXISTS "auth_strategy"
+(
+    "id" varchar(128) NOT NULL,
+    "name" varchar(100) NOT NULL,
+    "action" varchar(32) NOT NULL,
+    "owner" varchar(128) NOT NULL,
+    "comment" varchar(255) NOT NULL,
+    "default" smallint NOT NULL DEFAULT 0,
+    "revision" varchar(128) NOT NULL,
+    "flag" smallint NOT NULL DEFAULT 0,
+    "ctime" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "mtime" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "auth_strategy_uk" ON "auth_strategy" ("name", "owner");
+CREATE INDEX IF NOT EXISTS "auth_strategy_owner" ON "auth_strategy" ("owner");
+CREATE INDEX IF NOT EXISTS "auth_strategy_mtime" ON "auth_strategy" ("mtime");
+CREATE TRIGGER IF NOT EXISTS "auth_strategy_mtime_on_update" AFTER UPDATE ON "auth_strategy" FOR EACH ROW WHEN NEW."mtime" = OLD."mtime"
+BEGIN
+    UPDATE "auth_strategy" SET "mtime" = sysdate() WHERE rowid = NEW.rowid;
+END;
+
+CREATE TABLE IF NOT EXISTS "auth_principal"
+(
+    "strategy_id" varchar(128) NOT NULL,
+    "principal_id" varchar(128) NOT NULL,
+    "principal_role" integer NOT NULL,
+    PRIMARY KEY ("strategy_id", "principal_id", "principal_role")
+);
+
+CREATE TABLE IF NOT EXISTS "auth_strategy_resource"
+(
+    "strategy_id" varchar(128) NOT NULL,
+    "res_type" integer NOT NULL,
+    "res_id" varchar(128) NOT NULL,
+    "ctime" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "mtime" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY ("strategy_id", "res_type", "res_id")
+);
+CREATE INDEX IF NOT EXISTS "auth_strategy_resource_mtime" ON "auth_strategy_resource" ("mtime");
+CREATE TRIGGER IF NOT EXISTS "auth_strategy_resource_mtime_on_update" AFTER UPDATE ON "auth_strategy_resource" FOR EACH ROW WHEN NEW."mtime" = OLD."mtime"
+BEGIN
+    UPDATE "auth_strategy_resource" SET "mtime" = sysdate() WHERE rowid = NEW.rowid;
+END;
+
+CREATE TABLE IF NOT EXISTS "client"
+(
+    "id" varchar(128) NOT NULL,
+    "host" varchar(100) NOT NULL,
+    "type" varchar(100) NOT NULL,
+    "version" varchar(32) NOT NULL,
+    "region" varchar(128) DEFAULT NULL,
+    "zone" varchar(128) DEFAULT NULL,
+    "campus" varchar(128) DEFAULT NULL,
+    "flag" smallint NOT NULL DEFAULT 0,
+    "ctime" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "mtime" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "client_mtime" ON "client" ("mtime");
+CREATE TRIGGER IF NOT EXISTS "client_mtime_on_update" AFTER UPDATE ON "client" FOR EACH ROW WHEN NEW."mtime" = OLD."mtime"
+BEGIN
+    UPDATE "client" SET "mtime" = sysdate() WHERE rowid = NEW.rowid;
+END;
+
+CREATE TABLE IF NOT EXISTS "client_stat"
+(
+    "client_id" varchar(128) NOT NULL,
+    "target" varchar(100) NOT NULL,
+    "port" integer NOT NULL,
+    "protocol" varchar(100) NOT NULL,
+    "path" varchar(128) NOT NULL,
+    PRIMARY KEY ("client_id", "target", "port")
+);
+
+CREATE TABLE IF NOT EXISTS "service_change_log"
+(
+    "seq" integer PRIMARY KEY AUTOINCREMENT,
+    "id" varchar(128) NOT NULL,
+    "ctime" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS "service_change_log_ctime" ON "service_change_log" ("ctime");
+
+CREATE TABLE IF NOT EXISTS "instance_change_log"
+(
+    "seq" integer PRIMARY KEY AUTOINCREMENT,
+    "id" varchar(128) NOT NULL,
+    "ctime" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS "instance_change_log_ctime" ON "instance_change_log" ("ctime");
+
+CREATE TABLE IF NOT EXISTS "cluster_event"
+(
+    "seq" integer PRIMARY KEY AUTOINCREMENT,
+    "topic" varchar(64) NOT NULL,
+    "payload" text NOT NULL,
+    "ctime" timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS "cluster_event_ctime" ON "cluster_event" ("ctime");
+
+INSERT OR IGNORE INTO "namespace" ("name", "comment", "token", "owner", "flag", "ctime", "mtime")
+VALUES ('Polaris', 'Polaris-server', '2d1bfe5d12e04d54b8ee69e62494c7fd', 'polaris', 0,
+        '2019-09-06 07:55:07', '2019-09-06 07:55:07'),
+       ('default', 'Default Environment', 'e2e473081d3d4306b52264e49f7ce227', 'polaris', 0,
+        '2021-07-27 19:37:37', '2021-07-27 19:37:37');
+
+INSERT OR IGNORE INTO "service" ("id", "name", "namespace", "comment", "business", "token", "revision", "owner",
+                                 "flag", "ctime", "mtime")
+VALUES ('fbca9bfa04ae4ead86e1ecf5811e32a9', 'polaris.checker', 'Polaris', 'polaris checker service', 'polaris',
+        '7d19c46de327408d8709ee7392b7700b', '301b1e9f0bbd47a6b697e26e99dfe012', 'polaris', 0,
+        '2021-09-06 07:55:07', '2021-09-06 07:55:09'),
+       ('bbfdda174ea64e11ac862adf14593c03', 'polaris.monitor', 'Polaris', 'polaris monitor service', 'polaris',
+        '50b4e7d8affa4634b52523d398d1a369', '3649b17283d94d7baee5fb5d8160a225', 'polaris', 0,
+        '2021-09-06 07:55:07', '2021-09-06 07:55:11'),
+       ('e6542db1a2cc846c1866010b40b7f51f', 'polaris.config', 'Polaris', 'polaris config service', 'polaris',
+        'c874d9a0a4b45c82c93e6bf285518c7b', '769ec01f58875088faf2cb9e44a4b2d2', 'polaris', 0,
+        '2021-09-06 07:55:07', '2021-09-06 07:55:11');
+
+INSERT OR IGNORE INTO "start_lock" ("lock_id", "lock_key", "server", "mtime")
+VALUES (1, 'sz', 'aaa', '2019-12-05 08:35:49');
+
+INSERT OR IGNORE INTO "cl5_module" ("module_id", "interface_id", "range_num")
+VALUES (3000001, 1, 0);
+
+INSERT OR IGNORE INTO "user" ("id", "name", "password", "source", "token", "token_enable", "user_type", "comment",
+                              "owner")
+VALUES ('65e4789a6d5b49669adf1e9e8387549c', 'polaris',
+        '$2a$10$3izWuZtE5SBdAtSZci.gs.iZ2pAn9I8hEqYrC6gwJp1dyjqQnrrum', 'Polaris',
+        'nu/0WRA4EqSR1FagrjRj0fZwPXuGlMpX+zCuWu4uMqy8xr1vRjisSbA25aAC3mtU8MeeRsKhQiDAynUR09I=', 1, 20,
+        'default polaris admin account', '');
+
+INSERT OR IGNORE INTO "auth_strategy" ("id", "name", "action", "owner", "comment", "default", "revision", "flag",
+                                       "ctime", "mtime")
+VALUES ('fbca9bfa04ae4ead86e1ecf5811e32a9', '(用户) polaris的默认策略', 'READ_WRITE',
+        '65e4789a6d5b49669adf1e9e8387549c', 'default admin', 1, 'fbca9bfa04ae4ead86e1ecf5811e32a9', 0,
+        sysdate(), sysdate());
+
+INSERT OR IGNORE INTO "auth_strategy_resource" ("strategy_id", "res_type", "res_id", "ctime", "mtime")
+VALUES ('fbca9bfa04ae4ead86e1ecf5811e32a9', 0, '*', sysdate(), sysdate()),
+       ('fbca9bfa04ae4ead86e1ecf5811e32a9', 1, '*', sysdate(), sysdate()),
+       ('fbca9bfa04ae4ead86e1ecf5811e32a9', 2, '*', sysdate(), sysdate());
+
+INSERT OR IGNORE INTO "auth_principal" ("strategy_id", "principal_id", "principal_role")
+VALUES ('fbca9bfa04ae4ead86e1ecf5811e32a9', '65e4789a6d5b49669adf1e9e8387549c', 1);
+`
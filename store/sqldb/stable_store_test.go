@@ -0,0 +1,429 @@
+/**
+ * Tencent is pleased to support the open source community by making Polaris available.
+ *
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ *
+ * Licensed under the BSD 3-Clause License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * https://opensource.org/licenses/BSD-3-Clause
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed
+ * under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+ * CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package sqldb
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/wrappers"
+	. "github.com/smartystreets/goconvey/convey"
+
+	api "github.com/polarismesh/polaris-server/common/api/v1"
+	"github.com/polarismesh/polaris-server/common/model"
+	"github.com/polarismesh/polaris-server/common/utils"
+	"github.com/polarismesh/polaris-server/store"
+)
+
+const (
+	// 初始化数据中的默认管理员账号
+	adminUserID = "65e4789a6d5b49669adf1e9e8387549c"
+)
+
+// TestPostgreSQLStore 配置了 POLARIS_TEST_PG_ADDR 时，使用真实的 PostgreSQL 运行存储层测试
+// 数据库需要提前导入 scripts/postgresql/polaris_server.sql
+func TestPostgreSQLStore(t *testing.T) {
+	addr := os.Getenv("POLARIS_TEST_PG_ADDR")
+	if addr == "" {
+		t.Skip("POLARIS_TEST_PG_ADDR is not set, skip postgresql store test")
+	}
+	s := &stableStore{name: PostgreSQLStoreName, dbType: PostgreSQLDialect}
+	err := s.Initialize(&store.Config{
+		Name: PostgreSQLStoreName,
+		Option: map[string]interface{}{
+			"master": map[interface{}]interface{}{
+				"dbAddr":  addr,
+				"dbUser":  os.Getenv("POLARIS_TEST_PG_USER"),
+				"dbPwd":   os.Getenv("POLARIS_TEST_PG_PWD"),
+				"dbName":  os.Getenv("POLARIS_TEST_PG_DB"),
+				"sslMode": os.Getenv("POLARIS_TEST_PG_SSLMODE"),
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Destroy()
+
+	testStableStore(t, s)
+}
+
+// testStableStore 各类数据库共用的存储层测试，覆盖方言改写涉及到的主要SQL
+// 测试数据的名字带有随机后缀，可以在同一个数据库中重复执行
+func testStableStore(t *testing.T, s *stableStore) {
+	suffix := utils.NewUUID()[:8]
+	// 部分数据库的时间精度为秒
+	before := time.Now().Add(-2 * time.Second)
+	nsName := "ns-" + suffix
+
+	Convey("初始化数据已写入", t, func() {
+		ns, err := s.GetNamespace(SystemNamespace)
+		So(err, ShouldBeNil)
+		So(ns, ShouldNotBeNil)
+		So(ns.Valid, ShouldBeTrue)
+
+		services, err := s.GetSystemServices()
+		So(err, ShouldBeNil)
+		So(len(services), ShouldBeGreaterThanOrEqualTo, 3)
+
+		user, err := s.GetUser(adminUserID)
+		So(err, ShouldBeNil)
+		So(user, ShouldNotBeNil)
+		So(user.Name, ShouldEqual, "polaris")
+
+		strategy, err := s.GetDefaultStrategyDetailByPrincipal(adminUserID, model.PrincipalUser)
+		So(err, ShouldBeNil)
+		So(strategy, ShouldNotBeNil)
+		So(strategy.Default, ShouldBeTrue)
+	})
+
+	Convey("命名空间", t, func() {
+		err := s.AddNamespace(&model.Namespace{Name: nsName, Comment: "c", Token: "t", Owner: "polaris"})
+		So(err, ShouldBeNil)
+		ns, err := s.GetNamespace(nsName)
+		So(err, ShouldBeNil)
+		So(ns.Comment, ShouldEqual, "c")
+		So(ns.ModifyTime.IsZero(), ShouldBeFalse)
+
+		// 主键冲突需要识别为 DuplicateEntryErr
+		_, err = s.master.Exec("insert into namespace(name, comment, token, owner, ctime, mtime) "+
+			"values(?, '', 't', 'polaris', sysdate(), sysdate())", nsName)
+		So(store.Code(store.Error(err)), ShouldEqual, store.DuplicateEntryErr)
+
+		So(s.UpdateNamespace(&model.Namespace{Name: nsName, Comment: "c2", Owner: "polaris"}), ShouldBeNil)
+		nss, err := s.GetMoreNamespaces(before)
+		So(err, ShouldBeNil)
+		found := false
+		for _, item := range nss {
+			if item.Name == nsName {
+				found = item.Comment == "c2"
+			}
+		}
+		So(found, ShouldBeTrue)
+	})
+
+	svc := &model.Service{ID: "svc-test-" + suffix, Name: "svc-test-" + suffix, Namespace: nsName,
+		Token: "t", Owner: "polaris", Revision: "r1", Meta: map[string]string{"env": "test-" + suffix}}
+	Convey("服务以及按照元数据过滤", t, func() {
+		So(s.AddService(svc), ShouldBeNil)
+		So(s.AddService(&model.Service{ID: "svc-prod-" + suffix, Name: "svc-prod-" + suffix, Namespace: nsName,
+			Token: "t", Owner: "polaris", Revision: "r1", Meta: map[string]string{"env": "prod-" + suffix}}),
+			ShouldBeNil)
+
+		got, err := s.GetService(svc.Name, nsName)
+		So(err, ShouldBeNil)
+		So(got.Meta["env"], ShouldEqual, "test-"+suffix)
+
+		count, services, err := s.GetServices(map[string]string{"namespace": nsName},
+			map[string]string{"env": "test-" + suffix}, nil, 0, 10)
+		So(err, ShouldBeNil)
+		So(count, ShouldEqual, 1)
+		So(len(services), ShouldEqual, 1)
+		So(services[0].Name, ShouldEqual, svc.Name)
+
+		count, _, err = s.GetServices(map[string]string{"namespace": nsName}, nil, nil, 0, 10)
+		So(err, ShouldBeNil)
+		So(count, ShouldEqual, 2)
+
+		more, err := s.GetMoreServices(before, false, false, true)
+		So(err, ShouldBeNil)
+		So(more[svc.ID], ShouldNotBeNil)
+	})
+
+	insID := "ins-" + suffix
+	Convey("实例以及健康检查", t, func() {
+		ins := newTestInstance(svc.ID, insID, "127.0.0.1", 8080)
+		So(s.AddInstance(ins), ShouldBeNil)
+		got, err := s.GetInstance(insID)
+		So(err, ShouldBeNil)
+		So(got, ShouldNotBeNil)
+		So(got.Host(), ShouldEqual, "127.0.0.1")
+		So(got.Metadata()["k"], ShouldEqual, "v")
+		So(got.HealthCheck().GetHeartbeat().GetTtl().GetValue(), ShouldEqual, 5)
+
+		// replace into 在其他数据库中改写为 on conflict
+		ins.Proto.HealthCheck.Heartbeat.Ttl.Value = 10
+		So(s.BatchAddInstances([]*model.Instance{ins,
+			newTestInstance(svc.ID, insID+"-2", "127.0.0.2", 8080)}), ShouldBeNil)
+		got, err = s.GetInstance(insID)
+		So(err, ShouldBeNil)
+		So(got.HealthCheck().GetHeartbeat().GetTtl().GetValue(), ShouldEqual, 10)
+
+		count, list, err := s.GetExpandInstances(map[string]string{"service_id": svc.ID}, nil, 0, 10)
+		So(err, ShouldBeNil)
+		So(count, ShouldEqual, 2)
+		So(len(list), ShouldEqual, 2)
+
+		So(s.SetInstanceHealthStatus(insID, 0, "r2"), ShouldBeNil)
+		more, err := s.GetMoreInstances(before, false, true, []string{svc.ID})
+		So(err, ShouldBeNil)
+		So(more[insID], ShouldNotBeNil)
+		So(more[insID].Healthy(), ShouldBeFalse)
+	})
+
+	Convey("限流规则的版本号", t, func() {
+		for _, revision := range []string{"rv1", "rv2"} {
+			So(s.CreateRateLimit(&model.RateLimit{ID: "rl-" + revision + "-" + suffix, ServiceID: svc.ID,
+				Labels: "{}", Rule: "{}", Revision: revision}), ShouldBeNil)
+		}
+		_, revisions, err := s.GetRateLimitsForCache(before, false)
+		So(err, ShouldBeNil)
+		last := ""
+		for _, item := range revisions {
+			if item.ServiceID == svc.ID {
+				last = item.LastRevision
+			}
+		}
+		So(last, ShouldEqual, "rv2")
+	})
+
+	Convey("配置文件", t, func() {
+		group, err := s.CreateConfigFileGroup(&model.ConfigFileGroup{Name: "group-" + suffix, Namespace: nsName,
+			CreateBy: "polaris", ModifyBy: "polaris"})
+		So(err, ShouldBeNil)
+		So(group.Id, ShouldBeGreaterThan, 0)
+
+		file, err := s.CreateConfigFile(nil, &model.ConfigFile{Name: "app.yaml", Namespace: nsName,
+			Group: group.Name, Content: "a: 1", Format: "yaml", CreateBy: "polaris", ModifyBy: "polaris"})
+		So(err, ShouldBeNil)
+		So(file.Content, ShouldEqual, "a: 1")
+
+		count, files, err := s.QueryConfigFiles(nsName, group.Name, "app", 0, 10)
+		So(err, ShouldBeNil)
+		So(count, ShouldEqual, 1)
+		So(len(files), ShouldEqual, 1)
+
+		release, err := s.CreateConfigFileRelease(nil, &model.ConfigFileRelease{Name: "release", Namespace: nsName,
+			Group: group.Name, FileName: file.Name, Content: file.Content, Md5: "md5", Version: 1,
+			CreateBy: "polaris", ModifyBy: "polaris"})
+		So(err, ShouldBeNil)
+		So(release.Version, ShouldEqual, 1)
+
+		releases, err := s.FindConfigFileReleaseByModifyTimeAfter(before)
+		So(err, ShouldBeNil)
+		found := false
+		for _, item := range releases {
+			found = found || (item.Namespace == nsName && item.FileName == file.Name)
+		}
+		So(found, ShouldBeTrue)
+
+		rule := &model.ConfigFileGrayRule{ClientIPs: []string{"127.0.0.1"}, ClientLabels: map[string]string{"env": "gray"}}
+		gray, err := s.CreateConfigFileGrayRelease(nil, &model.ConfigFileRelease{Name: "gray", Namespace: nsName,
+			Group: group.Name, FileName: file.Name, Content: "gray", Md5: "md5-gray", Version: 2, GrayRule: rule,
+			CreateBy: "polaris", ModifyBy: "polaris"})
+		So(err, ShouldBeNil)
+		So(gray.Version, ShouldEqual, 2)
+		So(gray.GrayRule, ShouldResemble, rule)
+
+		So(s.DeleteConfigFileGrayRelease(nil, nsName, group.Name, file.Name, "polaris"), ShouldBeNil)
+		gray, err = s.GetConfigFileGrayRelease(nil, nsName, group.Name, file.Name)
+		So(err, ShouldBeNil)
+		So(gray, ShouldBeNil)
+		gray, err = s.GetConfigFileGrayReleaseWithAllFlag(nil, nsName, group.Name, file.Name)
+		So(err, ShouldBeNil)
+		So(gray.Flag, ShouldEqual, 1)
+
+		gray.Version, gray.Content = 3, "gray again"
+		gray, err = s.UpdateConfigFileGrayRelease(nil, gray)
+		So(err, ShouldBeNil)
+		So(gray.Version, ShouldEqual, 3)
+		grays, err := s.FindConfigFileGrayReleaseByModifyTimeAfter(before)
+		So(err, ShouldBeNil)
+		So(len(grays), ShouldBeGreaterThan, 0)
+	})
+
+	Convey("集群事件", t, func() {
+		latest, err := s.GetLatestClusterEventSeq()
+		So(err, ShouldBeNil)
+		So(s.AppendClusterEvent("config", "e1"), ShouldBeNil)
+		So(s.AppendClusterEvent("config", "e2"), ShouldBeNil)
+
+		events, err := s.GetClusterEvents(latest, 10)
+		So(err, ShouldBeNil)
+		So(len(events), ShouldEqual, 2)
+		So(events[0].Payload, ShouldEqual, "e1")
+		So(events[1].Seq, ShouldBeGreaterThan, events[0].Seq)
+
+		// 清理后保留最新的一条，序号不回退
+		So(s.CleanClusterEvents(time.Now().Add(time.Minute)), ShouldBeNil)
+		events, err = s.GetClusterEvents(latest, 10)
+		So(err, ShouldBeNil)
+		So(len(events), ShouldEqual, 1)
+		So(events[0].Payload, ShouldEqual, "e2")
+	})
+
+	Convey("配置文件订阅者", t, func() {
+		listener := &model.ConfigFileListener{ClientId: "client-" + suffix, ClientIP: "127.0.0.1", Namespace: nsName,
+			Group: "group-" + suffix, FileName: "app.yaml", Version: 1, LastPollTime: time.Now(), Server: "127.0.0.2"}
+		So(s.UpsertConfigFileListeners([]*model.ConfigFileListener{listener}), ShouldBeNil)
+		// 重复上报更新客户端版本
+		listener.Version = 2
+		So(s.UpsertConfigFileListeners([]*model.ConfigFileListener{listener}), ShouldBeNil)
+
+		count, listeners, err := s.QueryConfigFileListeners(nsName, listener.Group, listener.FileName, 0, 10)
+		So(err, ShouldBeNil)
+		So(count, ShouldEqual, 1)
+		So(listeners[0].Version, ShouldEqual, 2)
+		So(listeners[0].Server, ShouldEqual, "127.0.0.2")
+
+		So(s.DeleteExpiredConfigFileListeners(time.Now().Add(time.Minute)), ShouldBeNil)
+		count, _, err = s.QueryConfigFileListeners(nsName, listener.Group, listener.FileName, 0, 10)
+		So(err, ShouldBeNil)
+		So(count, ShouldEqual, 0)
+	})
+
+	Convey("配置发布申请", t, func() {
+		planTime := time.Now().Add(time.Minute)
+		request := &model.ConfigFilePublishRequest{Id: "request-" + suffix, Namespace: nsName,
+			Group: "group-" + suffix, FileName: "app.yaml", Status: utils.PublishRequestStatusPending,
+			ApproveUsers: []string{"u1", "u2"}, PlanTime: planTime, CreateBy: "polaris", ModifyBy: "polaris"}
+		So(s.CreateConfigFilePublishRequest(request), ShouldBeNil)
+
+		saved, err := s.GetConfigFilePublishRequest(request.Id)
+		So(err, ShouldBeNil)
+		So(saved.ApproveUsers, ShouldResemble, []string{"u1", "u2"})
+		So(len(saved.ApproveGroups), ShouldEqual, 0)
+		So(saved.PlanTime.Unix(), ShouldEqual, planTime.Unix())
+		So(saved.ApproveTime.IsZero(), ShouldBeTrue)
+
+		// 只有状态匹配时才更新
+		saved.Status = utils.PublishRequestStatusScheduled
+		saved.ApproveBy = "u1"
+		saved.ApproveTime = time.Now()
+		saved.ApproveMd5 = "md5"
+		updated, err := s.UpdateConfigFilePublishRequest(saved, utils.PublishRequestStatusDraft)
+		So(err, ShouldBeNil)
+		So(updated, ShouldBeFalse)
+		updated, err = s.UpdateConfigFilePublishRequest(saved, utils.PublishRequestStatusPending)
+		So(err, ShouldBeNil)
+		So(updated, ShouldBeTrue)
+
+		due, err := s.FindDueConfigFilePublishRequests(planTime)
+		So(err, ShouldBeNil)
+		found := false
+		for _, item := range due {
+			found = found || item.Id == request.Id
+		}
+		So(found, ShouldBeTrue)
+
+		count, requests, err := s.QueryConfigFilePublishRequests(nsName, request.Group, request.FileName,
+			utils.PublishRequestStatusScheduled, 0, 10)
+		So(err, ShouldBeNil)
+		So(count, ShouldEqual, 1)
+		So(requests[0].ApproveBy, ShouldEqual, "u1")
+		So(requests[0].ApproveMd5, ShouldEqual, "md5")
+	})
+
+	userID := "user-" + suffix
+	Convey("用户以及鉴权策略", t, func() {
+		So(s.AddUser(&model.User{ID: userID, Name: userID, Password: "p", Owner: adminUserID, Source: "Polaris",
+			Type: model.SubAccountUserRole, Token: "t", TokenEnable: true, Valid: true}), ShouldBeNil)
+		user, err := s.GetUserByName(userID, adminUserID)
+		So(err, ShouldBeNil)
+		So(user.ID, ShouldEqual, userID)
+		users, err := s.GetUsersForCache(before, false)
+		So(err, ShouldBeNil)
+		So(len(users), ShouldBeGreaterThan, 0)
+
+		// 新建用户时会同时创建用户的默认策略
+		defaultStrategy, err := s.GetDefaultStrategyDetailByPrincipal(userID, model.PrincipalUser)
+		So(err, ShouldBeNil)
+		So(defaultStrategy, ShouldNotBeNil)
+
+		strategyID := "strategy-" + suffix
+		principal := model.Principal{StrategyID: strategyID, PrincipalID: userID, PrincipalRole: model.PrincipalUser}
+		So(s.AddStrategy(&model.StrategyDetail{
+			ID: strategyID, Name: strategyID, Action: api.AuthAction_READ_WRITE.String(), Owner: adminUserID,
+			Revision: "r1",
+			// 重复的成员由 insert ignore 忽略
+			Principals: []model.Principal{principal, principal},
+			Resources: []model.StrategyResource{
+				{StrategyID: strategyID, ResType: int32(api.ResourceType_Namespaces), ResID: nsName},
+			},
+		}), ShouldBeNil)
+
+		detail, err := s.GetStrategyDetail(strategyID, false)
+		So(err, ShouldBeNil)
+		So(detail, ShouldNotBeNil)
+		So(detail.Default, ShouldBeFalse)
+		So(len(detail.Principals), ShouldEqual, 1)
+		So(len(detail.Resources), ShouldEqual, 1)
+
+		// 重复添加资源由 replace into 覆盖
+		resource := model.StrategyResource{StrategyID: strategyID, ResType: int32(api.ResourceType_Services),
+			ResID: svc.ID}
+		So(s.LooseAddStrategyResources([]model.StrategyResource{resource}), ShouldBeNil)
+		So(s.LooseAddStrategyResources([]model.StrategyResource{resource}), ShouldBeNil)
+		detail, err = s.GetStrategyDetail(strategyID, false)
+		So(err, ShouldBeNil)
+		So(len(detail.Resources), ShouldEqual, 2)
+	})
+
+	Convey("客户端", t, func() {
+		clientID := "client-" + suffix
+		client := model.NewClient(&api.Client{
+			Id:      &wrappers.StringValue{Value: clientID},
+			Host:    &wrappers.StringValue{Value: "127.0.0.1"},
+			Version: &wrappers.StringValue{Value: "1.0.0"},
+			Location: &api.Location{
+				Region: &wrappers.StringValue{Value: "region"},
+				Zone:   &wrappers.StringValue{Value: "zone"},
+				Campus: &wrappers.StringValue{Value: "campus"},
+			},
+			Stat: []*api.StatInfo{
+				{
+					Target:   &wrappers.StringValue{Value: "prometheus"},
+					Port:     &wrappers.UInt32Value{Value: 8080},
+					Path:     &wrappers.StringValue{Value: "/metrics"},
+					Protocol: &wrappers.StringValue{Value: "http"},
+				},
+			},
+		})
+		So(s.BatchAddClients([]*model.Client{client}), ShouldBeNil)
+		// 重复上报走 replace into
+		So(s.BatchAddClients([]*model.Client{client}), ShouldBeNil)
+
+		clients, err := s.GetMoreClients(before, false)
+		So(err, ShouldBeNil)
+		So(clients[clientID], ShouldNotBeNil)
+		So(clients[clientID].Proto().GetLocation().GetZone().GetValue(), ShouldEqual, "zone")
+		So(len(clients[clientID].Proto().GetStat()), ShouldEqual, 1)
+	})
+}
+
+// newTestInstance 创建一个带健康检查的实例
+func newTestInstance(serviceID, id, host string, port uint32) *model.Instance {
+	return &model.Instance{
+		ServiceID: serviceID,
+		Proto: &api.Instance{
+			Id:                &wrappers.StringValue{Value: id},
+			Host:              &wrappers.StringValue{Value: host},
+			Port:              &wrappers.UInt32Value{Value: port},
+			Revision:          &wrappers.StringValue{Value: "r1"},
+			Healthy:           &wrappers.BoolValue{Value: true},
+			EnableHealthCheck: &wrappers.BoolValue{Value: true},
+			HealthCheck: &api.HealthCheck{
+				Type:      api.HealthCheck_HEARTBEAT,
+				Heartbeat: &api.HeartbeatHealthCheck{Ttl: &wrappers.UInt32Value{Value: 5}},
+			},
+			Metadata: map[string]string{"k": "v"},
+		},
+		Valid: true,
+	}
+}
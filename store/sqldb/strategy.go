@@ -50,7 +50,7 @@ var (
 
 type strategyStore struct {
 	master *BaseDB
-	slave  *BaseDB
+	slave  *replicaSet
 }
 
 func (s *strategyStore) AddStrategy(strategy *model.StrategyDetail) error {
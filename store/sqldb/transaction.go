@@ -24,13 +24,15 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/polarismesh/polaris-server/common/model"
+	"github.com/polarismesh/polaris-server/store"
 )
 
 // transaction 事务; 不支持多协程并发操作，当前先支持单个协程串行操作
 type transaction struct {
-	tx     *BaseTx
-	failed bool // 判断事务执行是否失败
-	commit bool // 判断事务已经提交，如果已经提交，则Commit会立即返回
+	tx      *BaseTx
+	failed  bool            // 判断事务执行是否失败
+	commit  bool            // 判断事务已经提交，如果已经提交，则Commit会立即返回
+	changes *changeLogStore // 服务的变更日志
 }
 
 // Commit 提交事务，释放tx
@@ -175,6 +177,12 @@ func (t *transaction) DeleteService(name string, namespace string) error {
 		t.failed = true
 		return err
 	}
+	err := t.changes.recordBySelect(t.tx, store.ChangeLogService,
+		"select id from service where name = ? and namespace = ?", name, namespace)
+	if err != nil {
+		t.failed = true
+		return err
+	}
 
 	return nil
 }
@@ -191,6 +199,12 @@ func (t *transaction) DeleteAliasWithSourceID(sourceServiceID string) error {
 		t.failed = false
 		return err
 	}
+	err := t.changes.recordBySelect(t.tx, store.ChangeLogService,
+		"select id from service where reference = ?", sourceServiceID)
+	if err != nil {
+		t.failed = true
+		return err
+	}
 
 	return nil
 }
@@ -52,7 +52,7 @@ var (
 
 type userStore struct {
 	master *BaseDB
-	slave  *BaseDB
+	slave  *replicaSet
 }
 
 // AddUser 添加用户
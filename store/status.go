@@ -61,21 +61,39 @@ func Error(err error) error {
 	}
 
 	s := &StatusError{message: err.Error()}
-	if strings.Contains(s.message, "Data too long") {
-		s.code = OutOfRangeErr
-	} else if strings.Contains(s.message, "Duplicate entry") {
-		s.code = DuplicateEntryErr
-	} else if strings.Contains(s.message, "a foreign key constraint fails") {
-		s.code = ForeignKeyErr
-	} else if strings.Contains(s.message, "Deadlock") {
-		s.code = DeadlockErr
-	} else {
-		s.code = Unknown
+	s.code = Unknown
+	// 按照顺序匹配，避免不同数据库的错误信息存在包含关系时结果不确定
+	for _, item := range errorMessages {
+		for _, msg := range item.messages {
+			if strings.Contains(s.message, msg) {
+				s.code = item.code
+				return s
+			}
+		}
 	}
 
 	return s
 }
 
+// errorMessages 不同数据库的错误信息与状态码的映射关系
+var errorMessages = []struct {
+	code     StatusCode
+	messages []string
+}{
+	// mysql: Data too long, postgresql: value too long
+	{code: OutOfRangeErr, messages: []string{"Data too long", "value too long"}},
+	// mysql: Duplicate entry, postgresql: duplicate key value violates unique constraint,
+	// sqlite: UNIQUE constraint failed
+	{code: DuplicateEntryErr, messages: []string{"Duplicate entry", "duplicate key value",
+		"UNIQUE constraint failed"}},
+	// mysql: a foreign key constraint fails, postgresql: violates foreign key constraint,
+	// sqlite: FOREIGN KEY constraint failed
+	{code: ForeignKeyErr, messages: []string{"a foreign key constraint fails", "violates foreign key constraint",
+		"FOREIGN KEY constraint failed"}},
+	// mysql: Deadlock, postgresql: deadlock detected
+	{code: DeadlockErr, messages: []string{"Deadlock", "deadlock detected"}},
+}
+
 // NewStatusError 根据code和message创建StatusError
 func NewStatusError(code StatusCode, message string) error {
 	return &StatusError{
@@ -33,6 +33,8 @@ type Config struct {
 var (
 	// StoreSlots store slots
 	StoreSlots = make(map[string]Store)
+	// StoreCreators 创建独立Store实例的函数，例如数据迁移时需要同时打开两个同类型的存储
+	StoreCreators = make(map[string]func() Store)
 
 	once   = &sync.Once{}
 	config = &Config{}
@@ -49,6 +51,25 @@ func RegisterStore(s Store) error {
 	return nil
 }
 
+// RegisterStoreCreator 注册Store的创建函数
+func RegisterStoreCreator(name string, creator func() Store) error {
+	if _, ok := StoreCreators[name]; ok {
+		return errors.New("store creator already existed")
+	}
+
+	StoreCreators[name] = creator
+	return nil
+}
+
+// NewStore 创建一个未初始化的Store实例，与StoreSlots中的实例相互独立
+func NewStore(name string) (Store, error) {
+	creator, ok := StoreCreators[name]
+	if !ok {
+		return nil, fmt.Errorf("store `%s` not found", name)
+	}
+	return creator(), nil
+}
+
 // GetStore 获取Store
 func GetStore() (Store, error) {
 	name := config.Name